@@ -11,16 +11,27 @@ import (
 
 // Config struct to hold the configuration data for server
 type Config struct {
-	Port          string   `yaml:"port"`
-	Vault         Vault    `yaml:"vault"`
-	Redis         Redis    `yaml:"redis"`
-	Postgres      Postgres `yaml:"postgres"`
-	ES            ES       `yaml:"es"`
-	NSQ           NSQ      `yaml:"nsq"`
-	FarmHandler   Handler  `yaml:"farm_handler"`
-	StatHandler   Handler  `yaml:"stat_handler"`
-	PondHandler   Handler  `yaml:"pond_handler"`
-	TrackingEvent Consumer `yaml:"tracking_event"`
+	Port              string    `yaml:"port"`
+	Vault             Vault     `yaml:"vault"`
+	Redis             Redis     `yaml:"redis"`
+	Postgres          Postgres  `yaml:"postgres"`
+	ES                ES        `yaml:"es"`
+	NSQ               NSQ       `yaml:"nsq"`
+	MessageBus        Bus       `yaml:"message_bus"`
+	FarmHandler       Handler   `yaml:"farm_handler"`
+	StatHandler       Handler   `yaml:"stat_handler"`
+	PondHandler       Handler   `yaml:"pond_handler"`
+	AdminHandler      Handler   `yaml:"admin_handler"`
+	TrackingEvent     Consumer  `yaml:"tracking_event"`
+	TrackingPublisher Publisher `yaml:"tracking_publisher"`
+	OutboxRelay       Relay     `yaml:"outbox_relay"`
+	WebhookHandler    Handler   `yaml:"webhook_handler"`
+	WebhookDelivery   Delivery  `yaml:"webhook_delivery"`
+	StoreCache        Cache     `yaml:"store_cache"`
+	GRPC              GRPC      `yaml:"grpc"`
+	GraphQL           GraphQL   `yaml:"graphql"`
+	ImportHandler     Import    `yaml:"import_handler"`
+	ExportHandler     Export    `yaml:"export_handler"`
 }
 
 // Vault struct to hold the configuration data for vault
@@ -29,7 +40,8 @@ type Vault struct {
 	VaultHost string `yaml:"vault_host"`
 }
 
-// Postgres struct to hold the configuration data for postgres
+// Postgres struct to hold the configuration data for sql database,
+// config with sqlite:// or file: scheme open sqlite file instead of postgres
 type Postgres struct {
 	Config string `yaml:"postgres_config"`
 }
@@ -38,15 +50,88 @@ type Postgres struct {
 type NSQ struct {
 	ProducerHost string `yaml:"producer_host"`
 	ConsumerHost string `yaml:"consumer_host"`
+	SchemaDir    string `yaml:"schema_dir"`
+}
+
+// Bus struct to hold the configuration data for message bus,
+// driver is nsq or memory to run without nsqd and nsqlookupd
+type Bus struct {
+	Driver string `yaml:"driver"`
 }
 
 // Consumer struct to hold the configuration data for Consumer
 type Consumer struct {
-	Topic        string `yaml:"topic"`
-	Channel      string `yaml:"channel"`
-	MaxInFlight  int    `yaml:"max_in_flight"`
-	NumConsumer  int    `yaml:"num_of_consumer"`
-	TimeoutInSec int    `yaml:"timeout_in_sec"`
+	Topic             string `yaml:"topic"`
+	Channel           string `yaml:"channel"`
+	MaxInFlight       int    `yaml:"max_in_flight"`
+	NumConsumer       int    `yaml:"num_of_consumer"`
+	TimeoutInSec      int    `yaml:"timeout_in_sec"`
+	MaxAttempts       int    `yaml:"max_attempts"`
+	RetryBackoffInSec int    `yaml:"retry_backoff_in_sec"`
+	DeadLetterTopic   string `yaml:"dead_letter_topic"`
+}
+
+// Publisher struct to hold the configuration data for batched Publisher
+type Publisher struct {
+	BufferSize           int    `yaml:"buffer_size"`
+	BatchSize            int    `yaml:"batch_size"`
+	NumWorker            int    `yaml:"num_of_worker"`
+	FlushIntervalInMs    int    `yaml:"flush_interval_in_ms"`
+	OverflowPolicy       string `yaml:"overflow_policy"`
+	BlockTimeoutInMs     int    `yaml:"block_timeout_in_ms"`
+	ShutdownTimeoutInSec int    `yaml:"shutdown_timeout_in_sec"`
+}
+
+// Relay struct to hold the configuration data for outbox Relay
+type Relay struct {
+	PollIntervalInMs     int `yaml:"poll_interval_in_ms"`
+	BatchSize            int `yaml:"batch_size"`
+	MaxAttempts          int `yaml:"max_attempts"`
+	RetentionInHours     int `yaml:"retention_in_hours"`
+	ShutdownTimeoutInSec int `yaml:"shutdown_timeout_in_sec"`
+}
+
+// Delivery struct to hold the configuration data for webhook Delivery
+type Delivery struct {
+	Channel              string `yaml:"channel"`
+	MaxInFlight          int    `yaml:"max_in_flight"`
+	MaxAttempts          int    `yaml:"max_attempts"`
+	RetryBackoffInMs     int    `yaml:"retry_backoff_in_ms"`
+	RequestTimeoutInSec  int    `yaml:"request_timeout_in_sec"`
+	ShutdownTimeoutInSec int    `yaml:"shutdown_timeout_in_sec"`
+}
+
+// Cache struct to hold the configuration data for redis cache of farm and pond store
+type Cache struct {
+	Enabled  bool `yaml:"enabled"`
+	TTLInSec int  `yaml:"ttl_in_sec"`
+}
+
+// GRPC struct to hold the configuration data for grpc server, the server is not started when port is empty
+// and every call must send the auth token as bearer token in authorization metadata
+type GRPC struct {
+	Port      string `yaml:"port"`
+	AuthToken string `yaml:"auth_token"`
+}
+
+// GraphQL struct to hold the configuration data for graphql handler, the query that is deeper
+// or more complex than the limit is rejected before it is executed
+type GraphQL struct {
+	TimeoutInSec  int `yaml:"timeout_in_sec"`
+	MaxDepth      int `yaml:"max_depth"`
+	MaxComplexity int `yaml:"max_complexity"`
+}
+
+// Import struct to hold the configuration data for import handler, the file that has more rows than max rows is rejected
+type Import struct {
+	TimeoutInSec int `yaml:"timeout_in_sec"`
+	MaxRows      int `yaml:"max_rows"`
+}
+
+// Export struct to hold the configuration data for export handler, the file is flushed to the client every flush interval rows
+type Export struct {
+	TimeoutInSec  int `yaml:"timeout_in_sec"`
+	FlushInterval int `yaml:"flush_interval"`
 }
 
 // ES struct to hold the configuration data for ES
@@ -64,8 +149,9 @@ type Redis struct {
 
 // Handler struct to hold the configuration data for handler
 type Handler struct {
-	TimeoutInSec       int `yaml:"timeout_in_sec"`
-	BackupTimeInMinute int `yaml:"backup_time_in_minute"`
+	TimeoutInSec       int    `yaml:"timeout_in_sec"`
+	BackupTimeInMinute int    `yaml:"backup_time_in_minute"`
+	AuthToken          string `yaml:"auth_token"` // bearer token of the handler that is only for operator, empty token reject every request
 }
 
 // GetConfig is func to load config and replace it by secret value
@@ -6,5 +6,8 @@ import (
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		os.Exit(server.RunMigrate(os.Args[2:]))
+	}
 	os.Exit(server.Run())
 }
@@ -0,0 +1,315 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"aqua-farm-manager/cmd/aqua-farm-manager/config"
+	"aqua-farm-manager/internal/app/apidoc"
+	"aqua-farm-manager/internal/infrastructure/stat"
+	"aqua-farm-manager/pkg/bus"
+	"aqua-farm-manager/pkg/memorydb"
+	"aqua-farm-manager/pkg/openapi"
+)
+
+// postmanCollection is the example request that is shared with api client
+var postmanCollection = filepath.Join("..", "..", "..", "Aquafarm Management System.postman_collection.json")
+
+// example is a request that is replayed into the server, status 0 accept every documented status
+// and the token is sent as bearer token when it is set
+type example struct {
+	name   string
+	method string
+	path   string
+	body   string
+	status int
+	token  string
+}
+
+// contractExamples is replayed after the postman collection, it cover the route that is not in the
+// collection and the success response of the resource that is deleted by the collection
+var contractExamples = []example{
+	{name: "create farm", method: "POST", path: "/v1/farms", body: `{"name":"Blue Lagoon","location":"Bali","owner":"John Doe","area":"3 Acres"}`, status: http.StatusOK},
+	{name: "create duplicate farm", method: "POST", path: "/v1/farms", body: `{"name":"Blue Lagoon"}`, status: http.StatusConflict},
+	{name: "create farm with invalid json", method: "POST", path: "/v1/farms", body: `{"name":`, status: http.StatusBadRequest},
+	{name: "create farm with invalid field", method: "POST", path: "/v1/farms", body: `{"name":"","owner":"Jane#Doe"}`, status: http.StatusUnprocessableEntity},
+	{name: "create pond", method: "POST", path: "/v1/ponds", body: `{"name":"Lotus Pond","capacity":80,"depth":3.5,"water_quality":7.2,"species":"Tilapia","farm_id":2}`, status: http.StatusOK},
+	{name: "update pond", method: "PUT", path: "/v1/ponds", body: `{"name":"Lotus Pond","depth":4,"farm_id":2}`, status: http.StatusOK},
+	{name: "get farm", method: "GET", path: "/v1/farms", body: `{"size":10}`, status: http.StatusOK},
+	{name: "get farm by id", method: "GET", path: "/v1/farms/2", status: http.StatusOK},
+	{name: "get farm by invalid id", method: "GET", path: "/v1/farms/abc", status: http.StatusUnprocessableEntity},
+	{name: "get pond", method: "GET", path: "/v1/ponds", body: `{}`, status: http.StatusOK},
+	{name: "get pond by id", method: "GET", path: "/v1/ponds/1", status: http.StatusOK},
+	{name: "delete farm with pond", method: "DELETE", path: "/v1/farms", body: `{"name":"Blue Lagoon"}`, status: http.StatusConflict},
+	{name: "create webhook", method: "POST", path: "/v1/webhooks", token: testWebhookAuthToken, body: `{"url":"https://partner.invalid/hook","event_types":["farm.created"],"secret":"0123456789abcdef"}`, status: http.StatusOK},
+	{name: "create webhook with loopback url", method: "POST", path: "/v1/webhooks", token: testWebhookAuthToken, body: `{"url":"https://127.0.0.1:1/hook","event_types":["farm.created"],"secret":"0123456789abcdef"}`, status: http.StatusUnprocessableEntity},
+	{name: "create webhook without token", method: "POST", path: "/v1/webhooks", body: `{"url":"https://partner.invalid/hook","event_types":["farm.created"],"secret":"0123456789abcdef"}`, status: http.StatusUnauthorized},
+	{name: "create webhook with invalid url", method: "POST", path: "/v1/webhooks", token: testWebhookAuthToken, body: `{"url":"http://partner.com/hook","event_types":["farm.created"],"secret":"0123456789abcdef"}`, status: http.StatusUnprocessableEntity},
+	{name: "get webhook", method: "GET", path: "/v1/webhooks?size=5", token: testWebhookAuthToken, status: http.StatusOK},
+	{name: "get webhook deliveries", method: "GET", path: "/v1/webhooks/1/deliveries?limit=10", token: testWebhookAuthToken, status: http.StatusOK},
+	{name: "get webhook deliveries with invalid limit", method: "GET", path: "/v1/webhooks/1/deliveries?limit=0", token: testWebhookAuthToken, status: http.StatusUnprocessableEntity},
+	{name: "delete webhook", method: "DELETE", path: "/v1/webhooks/1", token: testWebhookAuthToken, status: http.StatusOK},
+	{name: "delete missing webhook", method: "DELETE", path: "/v1/webhooks/1", token: testWebhookAuthToken, status: http.StatusNotFound},
+	{name: "get webhook deliveries without token", method: "GET", path: "/v1/webhooks/1/deliveries?limit=10", status: http.StatusUnauthorized},
+	{name: "graphql nested query", method: "POST", path: "/graphql", body: `{"query":"query Farms($size: Int) { farms(size: $size) { items { name ponds { name farm { name } } } nextCursor } pond(id: 1) { name } stats { key count } }","variables":{"size":5}}`, status: http.StatusOK},
+	{name: "graphql with syntax error", method: "POST", path: "/graphql", body: `{"query":"{ farms "}`, status: http.StatusBadRequest},
+	{name: "graphql with unknown field", method: "POST", path: "/graphql", body: `{"query":"{ farms { readings } }"}`, status: http.StatusUnprocessableEntity},
+	{name: "import farms from csv", method: "POST", path: "/v1/import?entity=farms", body: "name,location,owner\nCoral Bay,Lombok,Jane Doe\nBlue Lagoon,Bali,John Doe\n", status: http.StatusOK},
+	{name: "import ponds from ndjson on dry run", method: "POST", path: "/v1/import?entity=ponds&format=ndjson&mode=atomic&dry_run=true", body: `{"name":"Reed Pond","depth":2,"farm_name":"Coral Bay"}`, status: http.StatusOK},
+	{name: "import with invalid entity", method: "POST", path: "/v1/import?entity=stats", status: http.StatusUnprocessableEntity},
+	{name: "import malformed csv", method: "POST", path: "/v1/import?entity=farms", body: "name\n\"Coral Bay\n", status: http.StatusBadRequest},
+	{name: "export active farms into csv", method: "GET", path: "/v1/export?entity=farms", status: http.StatusOK},
+	{name: "export every pond into ndjson", method: "GET", path: "/v1/export?entity=ponds&format=ndjson&status=all", status: http.StatusOK},
+	{name: "export with invalid status", method: "GET", path: "/v1/export?entity=farms&status=deleted", status: http.StatusUnprocessableEntity},
+	{name: "delete farm with dependencies", method: "DELETE", path: "/v1/farms/2", status: http.StatusOK},
+	{name: "replay dead letter", method: "POST", path: "/v1/admin/dlq/replay?limit=5", token: testAdminAuthToken},
+	{name: "replay dead letter without token", method: "POST", path: "/v1/admin/dlq/replay?limit=5", status: http.StatusUnauthorized},
+	{name: "get stat", method: "GET", path: "/v1/stat", status: http.StatusOK},
+	{name: "get metrics", method: "GET", path: "/metrics", status: http.StatusOK},
+	{name: "get openapi", method: "GET", path: "/openapi.json", status: http.StatusOK},
+}
+
+// TestContract replay the postman collection and the contract examples into the full router with in memory
+// dependencies, every response must have documented status and the body must match the openapi schema
+func TestContract(t *testing.T) {
+	ts := newContractServer(t)
+
+	// the served document is used so the contract is the one that is seen by api client
+	res, err := http.Get(ts.URL + "/openapi.json")
+	if err != nil {
+		t.Fatalf("GET /openapi.json error = %v", err)
+	}
+	data, _ := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	var doc openapi.Document
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("GET /openapi.json body is not openapi document : %v", err)
+	}
+
+	examples := append(loadPostmanExamples(t, postmanCollection), contractExamples...)
+	covered := map[apidoc.Route]bool{}
+	for _, ex := range examples {
+		route, op := findOperation(&doc, ex.method, ex.path)
+		if op == nil {
+			t.Errorf("%s: %s %s is not in the openapi document", ex.name, ex.method, ex.path)
+			continue
+		}
+		covered[route] = true
+
+		req, err := http.NewRequest(ex.method, ts.URL+ex.path, strings.NewReader(ex.body))
+		if err != nil {
+			t.Fatalf("%s: invalid example : %v", ex.name, err)
+		}
+		if ex.token != "" {
+			req.Header.Set("Authorization", "Bearer "+ex.token)
+		}
+		res, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("%s: %s %s error = %v", ex.name, ex.method, ex.path, err)
+		}
+		body, _ := ioutil.ReadAll(res.Body)
+		res.Body.Close()
+
+		if err := checkResponse(&doc, op, res, body); err != nil {
+			t.Errorf("%s: %s %s got %v %s : %v", ex.name, ex.method, ex.path, res.StatusCode, body, err)
+			continue
+		}
+		if ex.status != 0 && res.StatusCode != ex.status {
+			t.Errorf("%s: %s %s status = %v, want %v, body %s", ex.name, ex.method, ex.path, res.StatusCode, ex.status, body)
+		}
+	}
+
+	// every documented operation must have an example so its contract is checked
+	for path, item := range doc.Paths {
+		for method := range item {
+			route := apidoc.Route{Method: strings.ToUpper(method), Path: path}
+			if !covered[route] {
+				t.Errorf("%s has no example request", route)
+			}
+		}
+	}
+}
+
+// newContractServer is func to start the http server of newMemoryServer
+func newContractServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	s := newMemoryServer(t)
+	ts := httptest.NewServer(s.httpServer.Handler)
+	t.Cleanup(ts.Close)
+	return ts
+}
+
+// newMemoryServer is func to create server from NewServer with in memory database, message bus and stat store,
+// the server is closed when the test is done
+func newMemoryServer(t *testing.T) *Server {
+	t.Helper()
+
+	timeout := config.Handler{TimeoutInSec: 5}
+	s, err := NewServer(
+		WithConfigOptions(config.Config{
+			Postgres:     config.Postgres{Config: "sqlite://:memory:"},
+			NSQ:          config.NSQ{SchemaDir: filepath.Join("..", "..", "..", "schema", "nsq")},
+			MessageBus:   config.Bus{Driver: bus.DriverMemory},
+			FarmHandler:  timeout,
+			PondHandler:  timeout,
+			StatHandler:  timeout,
+			AdminHandler: config.Handler{TimeoutInSec: 5, AuthToken: testAdminAuthToken},
+			TrackingEvent: config.Consumer{
+				Topic:           "aqua_farm_tracking_event",
+				Channel:         "tracking_event",
+				MaxInFlight:     1,
+				NumConsumer:     1,
+				TimeoutInSec:    3,
+				MaxAttempts:     1,
+				DeadLetterTopic: "aqua_farm_tracking_event_dlq",
+			},
+			OutboxRelay:    config.Relay{PollIntervalInMs: 50, BatchSize: 100},
+			WebhookHandler: config.Handler{TimeoutInSec: 5, AuthToken: testWebhookAuthToken},
+			WebhookDelivery: config.Delivery{
+				Channel:             "webhook",
+				MaxInFlight:         1,
+				MaxAttempts:         1,
+				RequestTimeoutInSec: 1,
+			},
+			GRPC: config.GRPC{AuthToken: testGRPCAuthToken},
+		}),
+		WithStatStoreOptions(stat.NewMemoryStatStore(memorydb.NewDB())),
+	)
+	if err != nil {
+		t.Fatalf("NewServer() error = %v", err)
+	}
+
+	t.Cleanup(s.Close)
+	return s
+}
+
+// loadPostmanExamples is func to get every request of postman collection in order
+func loadPostmanExamples(t *testing.T, file string) []example {
+	t.Helper()
+
+	type item struct {
+		Name    string `json:"name"`
+		Items   []item `json:"item"`
+		Request *struct {
+			Method string `json:"method"`
+			Body   struct {
+				Raw string `json:"raw"`
+			} `json:"body"`
+			URL struct {
+				Raw string `json:"raw"`
+			} `json:"url"`
+		} `json:"request"`
+	}
+
+	data, err := ioutil.ReadFile(file)
+	if err != nil {
+		t.Fatalf("read postman collection error = %v", err)
+	}
+	var collection item
+	if err := json.Unmarshal(data, &collection); err != nil {
+		t.Fatalf("invalid postman collection : %v", err)
+	}
+
+	var examples []example
+	var walk func(prefix string, items []item)
+	walk = func(prefix string, items []item) {
+		for _, it := range items {
+			if it.Request == nil {
+				walk(prefix+it.Name+"/", it.Items)
+				continue
+			}
+			u, err := url.Parse(it.Request.URL.Raw)
+			if err != nil {
+				t.Fatalf("invalid url of postman request %s : %v", it.Name, err)
+			}
+			examples = append(examples, example{
+				name:   "postman " + prefix + it.Name,
+				method: strings.ToUpper(it.Request.Method),
+				path:   u.RequestURI(),
+				body:   it.Request.Body.Raw,
+			})
+		}
+	}
+	walk("", collection.Items)
+	return examples
+}
+
+// findOperation is func to get documented operation of method and request path
+func findOperation(doc *openapi.Document, method, requestPath string) (apidoc.Route, *openapi.Operation) {
+	u, err := url.Parse(requestPath)
+	if err != nil {
+		return apidoc.Route{}, nil
+	}
+
+	paths := make([]string, 0, len(doc.Paths))
+	for path := range doc.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		if matchPath(path, u.Path) {
+			if op := doc.Operation(method, path); op != nil {
+				return apidoc.Route{Method: method, Path: path}, op
+			}
+		}
+	}
+	return apidoc.Route{}, nil
+}
+
+// matchPath is func to check request path match path template, the variable match any segment
+func matchPath(template, path string) bool {
+	want, got := strings.Split(template, "/"), strings.Split(path, "/")
+	if len(want) != len(got) {
+		return false
+	}
+	for i := range want {
+		if !strings.HasPrefix(want[i], "{") && want[i] != got[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// checkResponse is func to check status, content type and body of response against the operation
+func checkResponse(doc *openapi.Document, op *openapi.Operation, res *http.Response, body []byte) error {
+	documented, ok := op.Responses[strconv.Itoa(res.StatusCode)]
+	if !ok {
+		return fmt.Errorf("status %v is not documented", res.StatusCode)
+	}
+
+	contentType := res.Header.Get("Content-Type")
+	for mediaType, content := range documented.Content {
+		if !strings.HasPrefix(contentType, mediaType) {
+			continue
+		}
+		if mediaType != "application/json" {
+			return nil
+		}
+		if err := doc.ValidateJSON(content.Schema, body); err != nil {
+			return err
+		}
+
+		// standard response always repeat the status in the body
+		var standard struct {
+			Code *int `json:"code"`
+		}
+		if _, isStandard := content.Schema.Properties["code"]; isStandard {
+			json.Unmarshal(body, &standard)
+			if standard.Code == nil || *standard.Code != res.StatusCode {
+				return fmt.Errorf("code in body is not %v", res.StatusCode)
+			}
+		}
+		return nil
+	}
+	return fmt.Errorf("content type %q is not documented", contentType)
+}
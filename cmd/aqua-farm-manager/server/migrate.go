@@ -0,0 +1,111 @@
+package server
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"aqua-farm-manager/pkg/postgres"
+)
+
+const migrateUsage = `usage: aqua-farm-manager migrate [-dsn <database config>] [-steps <n>] up|down|status
+
+  up      apply every pending migration
+  down    rollback the newest applied migration, -steps set how many migration is rolled back
+  status  list every migration and its applied time
+
+the database config is taken from postgres.postgres_config when -dsn is not set`
+
+// RunMigrate is func to run migrate subcommand with its arguments and return the exit code
+func RunMigrate(args []string) int {
+	flags := flag.NewFlagSet("migrate", flag.ContinueOnError)
+	dsn := flags.String("dsn", "", "database config, postgres dsn or sqlite://<path>")
+	steps := flags.Int("steps", 1, "number of migration rolled back by down")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), migrateUsage)
+	}
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	command := flags.Arg(0)
+	if flags.NArg() != 1 || *steps < 1 || (command != "up" && command != "down" && command != "status") {
+		flags.Usage()
+		return 2
+	}
+
+	if len(*dsn) <= 0 {
+		s := &Server{}
+		if err := s.initConfig(); err != nil {
+			return 1
+		}
+		*dsn = s.cfg.Postgres.Config
+	}
+
+	db, err := postgres.Open(*dsn)
+	if err != nil {
+		fmt.Println("[Got Error]-Postgres :", err)
+		return 1
+	}
+	defer db.Close()
+
+	migrator, err := postgres.NewMigrator(db)
+	if err != nil {
+		fmt.Println("[Got Error]-Migrate :", err)
+		return 1
+	}
+
+	switch command {
+	case "up":
+		applied, err := migrator.Up()
+		for _, m := range applied {
+			fmt.Printf("applied %d_%s\n", m.Version, m.Name)
+		}
+		if err != nil {
+			fmt.Println("[Got Error]-Migrate :", err)
+			return 1
+		}
+		if len(applied) == 0 {
+			fmt.Println("no pending migration")
+		}
+	case "down":
+		rolledBack, err := migrator.Down(*steps)
+		for _, m := range rolledBack {
+			fmt.Printf("rolled back %d_%s\n", m.Version, m.Name)
+		}
+		if err != nil {
+			fmt.Println("[Got Error]-Migrate :", err)
+			return 1
+		}
+		if len(rolledBack) == 0 {
+			fmt.Println("no applied migration")
+		}
+	case "status":
+		version, err := migrator.Version()
+		if err != nil {
+			fmt.Println("[Got Error]-Migrate :", err)
+			return 1
+		}
+		list, err := migrator.Status()
+		if err != nil {
+			fmt.Println("[Got Error]-Migrate :", err)
+			return 1
+		}
+
+		fmt.Printf("database version %d, latest known version %d\n", version, migrator.LatestVersion())
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED AT")
+		for _, m := range list {
+			appliedAt := "pending"
+			if m.Applied {
+				appliedAt = m.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Fprintf(w, "%d\t%s\t%s\n", m.Version, m.Name, appliedAt)
+		}
+		w.Flush()
+		if version > migrator.LatestVersion() {
+			fmt.Println("database is migrated by newer binary, the server refuse to start")
+		}
+	}
+	return 0
+}
@@ -2,8 +2,10 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -11,17 +13,32 @@ import (
 
 	"aqua-farm-manager/cmd/aqua-farm-manager/config"
 	"aqua-farm-manager/internal/app"
+	"aqua-farm-manager/internal/app/admin"
+	"aqua-farm-manager/internal/app/apidoc"
+	"aqua-farm-manager/internal/app/exporter"
 	"aqua-farm-manager/internal/app/farm"
+	"aqua-farm-manager/internal/app/graph"
+	"aqua-farm-manager/internal/app/importer"
+	"aqua-farm-manager/internal/app/metrics"
 	"aqua-farm-manager/internal/app/middleware"
+	"aqua-farm-manager/internal/app/outbox"
 	"aqua-farm-manager/internal/app/pond"
+	"aqua-farm-manager/internal/app/rpc"
 	"aqua-farm-manager/internal/app/stat"
 	"aqua-farm-manager/internal/app/trackingevent"
+	"aqua-farm-manager/internal/app/webhook"
 	farmdomain "aqua-farm-manager/internal/domain/farm"
+	importdomain "aqua-farm-manager/internal/domain/importer"
 	ponddomain "aqua-farm-manager/internal/domain/pond"
 	statdomain "aqua-farm-manager/internal/domain/stat"
+	webhookdomain "aqua-farm-manager/internal/domain/webhook"
 	farminfra "aqua-farm-manager/internal/infrastructure/farm"
+	outboxinfra "aqua-farm-manager/internal/infrastructure/outbox"
 	pondinfra "aqua-farm-manager/internal/infrastructure/pond"
 	statinfra "aqua-farm-manager/internal/infrastructure/stat"
+	webhookinfra "aqua-farm-manager/internal/infrastructure/webhook"
+	"aqua-farm-manager/pkg/bus"
+	"aqua-farm-manager/pkg/bus/memory"
 	"aqua-farm-manager/pkg/nsq"
 	"aqua-farm-manager/pkg/postgres"
 	"aqua-farm-manager/pkg/redis"
@@ -29,38 +46,75 @@ import (
 
 	"github.com/gorilla/mux"
 	"github.com/joho/godotenv"
+	"google.golang.org/grpc"
 )
 
 // Servcer is list configuration to run Server
 type Server struct {
-	cfg         config.Config
-	vault       vault.VaultMethod
-	redis       redis.RedisMethod
-	postgres    postgres.PostgresMethod
-	nsqProducer nsq.NsqMethod
-	middleware  middleware.Middleware
-	statDomain  statdomain.StatDomain
-	statInfra   statinfra.StatStore
-	statHandler stat.StatHandler
-	farmDomain  farmdomain.FarmDomain
-	farmInfra   farminfra.FarmStore
-	farmHandler farm.FarmHandler
-	pondDomain  ponddomain.PondDomain
-	pondInfra   pondinfra.PondStore
-	pondHandler pond.PondHandler
-	httpServer  *http.Server
+	cfg               config.Config
+	vault             vault.VaultMethod
+	redis             redis.RedisMethod
+	postgres          postgres.PostgresMethod
+	nsqSchemas        *nsq.Schemas
+	publisher         bus.Publisher
+	subscriber        bus.Subscriber
+	middleware        middleware.Middleware
+	trackingPublisher *middleware.Publisher
+	outboxInfra       outboxinfra.OutboxStore
+	outboxRelay       *outbox.Relay
+	statDomain        statdomain.StatDomain
+	statInfra         statinfra.StatStore
+	statHandler       stat.StatHandler
+	farmDomain        farmdomain.FarmDomain
+	farmInfra         farminfra.FarmStore
+	farmHandler       farm.FarmHandler
+	pondDomain        ponddomain.PondDomain
+	pondInfra         pondinfra.PondStore
+	pondHandler       pond.PondHandler
+	metricsHandler    metrics.MetricsHandler
+	adminHandler      admin.AdminHandler
+	webhookDomain     webhookdomain.WebhookDomain
+	webhookInfra      webhookinfra.WebhookStore
+	webhookHandler    webhook.WebhookHandler
+	webhookConsumer   *webhook.DeliveryConsumer
+	graphQLHandler    graph.GraphQLHandler
+	importDomain      importdomain.ImportDomain
+	importHandler     importer.ImportHandler
+	exportHandler     exporter.ExportHandler
+	apiDocHandler     apidoc.APIDocHandler
+	httpServer        *http.Server
+	grpcServer        *grpc.Server
+	hasConfig         bool
 }
 
-// NewServer is func to create server with all configuration
-func NewServer() (*Server, error) {
-	s := &Server{}
+// Option set options for server dependencies
+type Option func(*Server)
 
-	// ======== Init Dependencies Related ========
+// WithConfigOptions is func to set config into server, the env file and vault is not loaded
+func WithConfigOptions(cfg config.Config) Option {
+	return Option(
+		func(s *Server) {
+			s.cfg = cfg
+			s.hasConfig = true
+		})
+}
+
+// WithStatStoreOptions is func to set stat store into server instead of the redis stat store,
+// e.g in memory stat store to run without redis
+func WithStatStoreOptions(store statinfra.StatStore) Option {
+	return Option(
+		func(s *Server) {
+			s.statInfra = store
+		})
+}
+
+// initConfig is func to load env file and get config from yaml and vault secret
+func (s *Server) initConfig() error {
 	// Load Env File
 	err := godotenv.Load()
 	if err != nil {
 		fmt.Printf("Error loading .env file: %v", err)
-		return s, err
+		return err
 	}
 
 	// Init Vault
@@ -68,13 +122,13 @@ func NewServer() (*Server, error) {
 		token := os.Getenv("VAULT_TOKEN")
 		if len(token) <= 0 {
 			fmt.Print("[Got Error]-Vault Invalid VAULT_TOKEN")
-			return s, fmt.Errorf("[Got Error]-Vault Invalid VAULT_TOKEN")
+			return fmt.Errorf("[Got Error]-Vault Invalid VAULT_TOKEN")
 		}
 
 		host := os.Getenv("VAULT_HOST")
 		if len(host) <= 0 {
 			fmt.Print("[Got Error]-Vault Invalid VAULT_HOST")
-			return s, fmt.Errorf("[Got Error]-Vault Invalid VAULT_HOST")
+			return fmt.Errorf("[Got Error]-Vault Invalid VAULT_HOST")
 		}
 
 		vaultMethod, err := vault.NewVaultClient(token, host)
@@ -101,6 +155,26 @@ func NewServer() (*Server, error) {
 		log.Println("LOAD-Config")
 	}
 
+	return nil
+}
+
+// NewServer is func to create server with all configuration
+func NewServer(options ...Option) (*Server, error) {
+	s := &Server{}
+
+	// Apply options
+	for _, opt := range options {
+		opt(s)
+	}
+
+	// ======== Init Dependencies Related ========
+	if !s.hasConfig {
+		err := s.initConfig()
+		if err != nil {
+			return s, err
+		}
+	}
+
 	// Init RedisClient
 	{
 		redisMethod, err := redis.NewRedisClient(redis.RedisConfig{
@@ -121,6 +195,9 @@ func NewServer() (*Server, error) {
 	// Init Postgres
 	{
 		postgresMethod, err := postgres.NewPostgresClient(s.cfg.Postgres.Config)
+		if errors.Is(err, postgres.ErrSchemaTooNew) {
+			return s, fmt.Errorf("[Got Error]-Postgres : %v", err)
+		}
 		if err != nil {
 			fmt.Print("[Got Error]-Postgres :", err)
 		}
@@ -130,20 +207,46 @@ func NewServer() (*Server, error) {
 		log.Println("Init-Postgres")
 	}
 
-	// Init NSQ Producer
+	// Init NSQ Schema
 	{
+		schemas, err := nsq.LoadSchemas(s.cfg.NSQ.SchemaDir)
+		if err != nil {
+			return s, fmt.Errorf("[Got Error]-NSQ Schema : %v", err)
+		}
+		s.nsqSchemas = schemas
+
+		log.Println("Init-NSQ Schema")
+	}
+
+	// Init Message Bus
+	switch s.cfg.MessageBus.Driver {
+	case bus.DriverMemory:
+		memoryBus := memory.NewBus(memory.WithSchemaOptions(s.nsqSchemas))
+		s.publisher = memoryBus
+		s.subscriber = memoryBus
+		log.Println("Init-Memory Message Bus")
+	case bus.DriverNSQ, "":
 		os.Setenv("NSQD_VERBOSE", "false")
-		nsqProducer, err := nsq.NewNsqClient(s.cfg.NSQ.ProducerHost)
+		nsqProducer, err := nsq.NewNsqClient(s.cfg.NSQ.ProducerHost, nsq.WithSchemaOptions(s.nsqSchemas))
 		if err != nil {
 			fmt.Print("[Got Error]-NSQ Producer :", err)
 		}
-		s.nsqProducer = nsqProducer
+		s.publisher = nsqProducer
 		log.Println("Init-NSQ Producer")
+
+		nsqSubscriber, err := nsq.NewNsqSubscriber(s.cfg.NSQ.ConsumerHost)
+		if err != nil {
+			return s, fmt.Errorf("[Got Error]-NSQ Subscriber : %v", err)
+		}
+		s.subscriber = nsqSubscriber
+		log.Println("Init-NSQ Subscriber")
+	default:
+		return s, fmt.Errorf("[Got Error]-Unknown Message Bus Driver : %s", s.cfg.MessageBus.Driver)
 	}
 
 	// ======== Init Dependencies Infra ========
 	// Init Stat Infra
-	{
+	if s.statInfra == nil {
 		statinf := statinfra.NewStatStore(s.redis, s.postgres)
 		s.statInfra = statinf
 		log.Println("Init-NewStatStore")
@@ -153,12 +256,32 @@ func NewServer() (*Server, error) {
 		farmInf := farminfra.NewFarmStore(s.postgres)
 		s.farmInfra = farmInf
 		log.Println("Init-NewFarmStore")
+		if s.cfg.StoreCache.Enabled {
+			s.farmInfra = farminfra.NewCachedFarmStore(farmInf, s.redis, s.cfg.StoreCache.TTLInSec)
+			log.Println("Init-NewCachedFarmStore")
+		}
 	}
 	// Init Pond Infra
 	{
 		pondInf := pondinfra.NewPondStore(s.postgres)
 		s.pondInfra = pondInf
 		log.Println("Init-NewPondStore")
+		if s.cfg.StoreCache.Enabled {
+			s.pondInfra = pondinfra.NewCachedPondStore(pondInf, s.redis, s.cfg.StoreCache.TTLInSec)
+			log.Println("Init-NewCachedPondStore")
+		}
+	}
+	// Init Outbox Infra
+	{
+		outboxInf := outboxinfra.NewOutboxStore(s.postgres)
+		s.outboxInfra = outboxInf
+		log.Println("Init-NewOutboxStore")
+	}
+	// Init Webhook Infra
+	{
+		webhookInf := webhookinfra.NewWebhookStore(s.postgres)
+		s.webhookInfra = webhookInf
+		log.Println("Init-NewWebhookStore")
 	}
 
 	// ======== Init Dependencies Domain ========
@@ -183,18 +306,50 @@ func NewServer() (*Server, error) {
 		log.Println("Init-NewPondDomain")
 	}
 
+	// Init Webhook Domain
+	{
+		webhookDom := webhookdomain.NewWebhookDomain(s.webhookInfra)
+		s.webhookDomain = webhookDom
+		log.Println("Init-NewWebhookDomain")
+	}
+
+	// Init Import Domain
+	{
+		importDom := importdomain.NewImportDomain(s.farmInfra, s.pondInfra)
+		s.importDomain = importDom
+		log.Println("Init-NewImportDomain")
+	}
+
 	// ======== Init Dependencies Handler/App ========
 	// Init Middleware
 	{
-		mdl := middleware.NewMiddleware(s.cfg.TrackingEvent.Topic, s.nsqProducer)
+		publisher := middleware.NewPublisher(s.cfg.TrackingEvent.Topic, s.publisher,
+			middleware.WithBufferOptions(s.cfg.TrackingPublisher.BufferSize, s.cfg.TrackingPublisher.BatchSize),
+			middleware.WithWorkerOptions(s.cfg.TrackingPublisher.NumWorker, s.cfg.TrackingPublisher.FlushIntervalInMs),
+			middleware.WithOverflowOptions(s.cfg.TrackingPublisher.OverflowPolicy, s.cfg.TrackingPublisher.BlockTimeoutInMs))
+		publisher.Start()
+		s.trackingPublisher = publisher
+
+		mdl := middleware.NewMiddleware(publisher)
 		s.middleware = mdl
 		log.Println("Init-NewMiddleware")
 	}
 
-	// Init Stat Migrator
+	// Init Outbox Relay
 	{
-		s.statDomain.MigrateStat()
-		log.Println("Init-MigrateStatMetrics From Postgres To Redis")
+		relay := outbox.NewRelay(s.outboxInfra, s.publisher,
+			outbox.WithPollOptions(s.cfg.OutboxRelay.PollIntervalInMs, s.cfg.OutboxRelay.BatchSize),
+			outbox.WithMaxAttemptsOptions(s.cfg.OutboxRelay.MaxAttempts),
+			outbox.WithRetentionOptions(s.cfg.OutboxRelay.RetentionInHours))
+		relay.Start()
+		s.outboxRelay = relay
+		log.Println("Init-OutboxRelay")
+	}
+
+	// Init Stat Recovery
+	{
+		s.statDomain.BackUpStat(context.Background())
+		log.Println("Init-Flush Pending Stat Metrics From Redis To Postgres")
 	}
 
 	// Init FarmHandler
@@ -227,21 +382,107 @@ func NewServer() (*Server, error) {
 		s.statHandler = *handler
 	}
 
+	// Init MetricsHandler
+	{
+		var opts []metrics.Option
+		opts = append(opts, metrics.WithTimeoutOptions(s.cfg.StatHandler.TimeoutInSec))
+		handler := metrics.NewMetricsHandler(s.farmDomain, s.redis, s.postgres, opts...)
+
+		log.Println("Init-MetricsHandler")
+		s.metricsHandler = *handler
+	}
+
 	// Init Tracking Event Consumer
 	{
 		consumer := trackingevent.NewTrackingEventConsumer(
 			s.cfg.TrackingEvent.Topic,
 			s.cfg.TrackingEvent.Channel,
-			s.cfg.NSQ.ConsumerHost,
+			s.subscriber,
 			s.cfg.TrackingEvent.MaxInFlight,
 			s.cfg.TrackingEvent.NumConsumer,
 			s.cfg.TrackingEvent.TimeoutInSec,
-			s.statDomain)
+			s.statDomain,
+			trackingevent.WithRetryOptions(s.cfg.TrackingEvent.MaxAttempts, s.cfg.TrackingEvent.RetryBackoffInSec),
+			trackingevent.WithDeadLetterOptions(s.cfg.TrackingEvent.DeadLetterTopic, s.publisher),
+			trackingevent.WithSchemaOptions(s.nsqSchemas))
 		err := consumer.Start()
 		if err != nil {
 			fmt.Print("[Got Error]-NewTrackingEverntConsumer :", err)
 		}
 		log.Println("Init-TrackingEverntConsumer")
+
+		// Init AdminHandler
+		var opts []admin.Option
+		opts = append(opts, admin.WithTimeoutOptions(s.cfg.AdminHandler.TimeoutInSec))
+		handler := admin.NewAdminHandler(consumer, opts...)
+
+		log.Println("Init-AdminHandler")
+		s.adminHandler = *handler
+	}
+
+	// Init WebhookHandler
+	{
+		var opts []webhook.Option
+		opts = append(opts, webhook.WithTimeoutOptions(s.cfg.WebhookHandler.TimeoutInSec))
+		handler := webhook.NewWebhookHandler(s.webhookDomain, opts...)
+
+		log.Println("Init-WebhookHandler")
+		s.webhookHandler = *handler
+	}
+
+	// Init GraphQLHandler
+	{
+		var opts []graph.Option
+		opts = append(opts, graph.WithTimeoutOptions(s.cfg.GraphQL.TimeoutInSec))
+		opts = append(opts, graph.WithLimitOptions(s.cfg.GraphQL.MaxDepth, s.cfg.GraphQL.MaxComplexity))
+		handler, err := graph.NewGraphQLHandler(s.farmInfra, s.pondInfra, s.statDomain, opts...)
+		if err != nil {
+			return s, fmt.Errorf("[Got Error]-GraphQL Schema : %v", err)
+		}
+
+		log.Println("Init-GraphQLHandler")
+		s.graphQLHandler = *handler
+	}
+
+	// Init ImportHandler
+	{
+		var opts []importer.Option
+		opts = append(opts, importer.WithTimeoutOptions(s.cfg.ImportHandler.TimeoutInSec))
+		opts = append(opts, importer.WithMaxRowsOptions(s.cfg.ImportHandler.MaxRows))
+		handler := importer.NewImportHandler(s.importDomain, opts...)
+
+		log.Println("Init-ImportHandler")
+		s.importHandler = *handler
+	}
+
+	// Init ExportHandler
+	{
+		var opts []exporter.Option
+		opts = append(opts, exporter.WithTimeoutOptions(s.cfg.ExportHandler.TimeoutInSec))
+		opts = append(opts, exporter.WithFlushIntervalOptions(s.cfg.ExportHandler.FlushInterval))
+		handler := exporter.NewExportHandler(s.farmInfra, s.pondInfra, opts...)
+
+		log.Println("Init-ExportHandler")
+		s.exportHandler = *handler
+	}
+
+	// Init Webhook Delivery Consumer
+	{
+		deliverer := webhook.NewDeliverer(s.webhookDomain,
+			webhook.WithRequestTimeoutOptions(s.cfg.WebhookDelivery.RequestTimeoutInSec),
+			webhook.WithDeliveryRetryOptions(s.cfg.WebhookDelivery.MaxAttempts, s.cfg.WebhookDelivery.RetryBackoffInMs))
+		consumer := webhook.NewDeliveryConsumer(
+			s.cfg.WebhookDelivery.Channel,
+			s.subscriber,
+			s.cfg.WebhookDelivery.MaxInFlight,
+			s.webhookDomain,
+			deliverer)
+		err := consumer.Start()
+		if err != nil {
+			fmt.Print("[Got Error]-NewDeliveryConsumer :", err)
+		}
+		s.webhookConsumer = consumer
+		log.Println("Init-WebhookDeliveryConsumer")
 	}
 
 	// Init Stat Backup Cron
@@ -252,33 +493,23 @@ func NewServer() (*Server, error) {
 
 	// Init Router
 	{
-		r := mux.NewRouter()
-		// Init Farm Path
-		farmPath := app.Farms
-		r.HandleFunc(farmPath.String(), s.middleware.Middleware(s.farmHandler.CreateFarmHandler)).Methods("POST")
-		r.HandleFunc(farmPath.String(), s.middleware.Middleware(s.farmHandler.GetFarmHandler)).Methods("GET")
-		r.HandleFunc(farmPath.String(), s.middleware.Middleware(s.farmHandler.UpdateFarmHandler)).Methods("PUT")
-		r.HandleFunc(farmPath.String(), s.middleware.Middleware(s.farmHandler.DeleteFarmHandler)).Methods("DELETE")
-
-		// Init Farm Get By ID
-		farmByIDPath := farmPath.String() + "/{id}"
-		r.HandleFunc(farmByIDPath, s.middleware.Middleware(s.farmHandler.GetByIDFarmHandler)).Methods("GET")
-		r.HandleFunc(farmByIDPath, s.middleware.Middleware(s.farmHandler.DeleteByIDFarmHandler)).Methods("DELETE")
-
-		// Init Pond Path
-		pondPath := app.Ponds
-		r.HandleFunc(pondPath.String(), s.middleware.Middleware(s.pondHandler.CreatePondHandler)).Methods("POST")
-		r.HandleFunc(pondPath.String(), s.middleware.Middleware(s.pondHandler.UpdatePondHandler)).Methods("PUT")
-		r.HandleFunc(pondPath.String(), s.middleware.Middleware(s.pondHandler.DeletePondHandler)).Methods("Delete")
-		r.HandleFunc(pondPath.String(), s.middleware.Middleware(s.pondHandler.GetPondHandler)).Methods("Get")
-
-		// Init Pond Get By ID
-		getPondByIDPath := pondPath.String() + "/{id}"
-		r.HandleFunc(getPondByIDPath, s.middleware.Middleware(s.pondHandler.GetByIDPondHandler)).Methods("GET")
-
-		// Init Stat Path
-		statPath := app.Stat
-		r.HandleFunc(statPath.String(), s.statHandler.GetStatHandler).Methods("GET")
+		r := s.initRouter()
+
+		// Init APIDocHandler from the registered route
+		routes, err := apidoc.Routes(r)
+		if err != nil {
+			return s, fmt.Errorf("[Got Error]-Walk Router : %v", err)
+		}
+		doc, err := apidoc.NewDocument(routes)
+		if err != nil {
+			fmt.Print("[Got Error]-OpenAPI :", err)
+		}
+		handler, err := apidoc.NewAPIDocHandler(doc)
+		if err != nil {
+			return s, fmt.Errorf("[Got Error]-OpenAPI : %v", err)
+		}
+		s.apiDocHandler = *handler
+		log.Println("Init-APIDocHandler")
 
 		port := ":" + s.cfg.Port
 		log.Println("running on port ", port)
@@ -290,9 +521,91 @@ func NewServer() (*Server, error) {
 
 		s.httpServer = server
 	}
+
+	// Init GRPC Server with the same domain of http handler
+	{
+		farmServer := rpc.NewFarmServer(s.farmDomain, rpc.WithTimeoutOptions(s.cfg.FarmHandler.TimeoutInSec))
+		pondServer := rpc.NewPondServer(s.pondDomain, rpc.WithTimeoutOptions(s.cfg.PondHandler.TimeoutInSec))
+		statServer := rpc.NewStatServer(s.statDomain, rpc.WithTimeoutOptions(s.cfg.StatHandler.TimeoutInSec))
+		interceptor := rpc.NewInterceptor(s.middleware, s.cfg.GRPC.AuthToken)
+
+		s.grpcServer = rpc.NewServer(interceptor, farmServer, pondServer, statServer)
+		log.Println("Init-GRPCServer")
+	}
 	return s, nil
 }
 
+// initRouter is func to register every route of the server, the route must be documented in apidoc
+// so it is served in the openapi document
+func (s *Server) initRouter() *mux.Router {
+	r := mux.NewRouter()
+	// every matched route is measured, including the route without tracking
+	r.Use(middleware.Metrics)
+
+	// Init Farm Path
+	farmPath := app.Farms
+	r.HandleFunc(farmPath.String(), s.middleware.Middleware(s.farmHandler.CreateFarmHandler)).Methods("POST")
+	r.HandleFunc(farmPath.String(), s.middleware.Middleware(s.farmHandler.GetFarmHandler)).Methods("GET")
+	r.HandleFunc(farmPath.String(), s.middleware.Middleware(s.farmHandler.UpdateFarmHandler)).Methods("PUT")
+	r.HandleFunc(farmPath.String(), s.middleware.Middleware(s.farmHandler.DeleteFarmHandler)).Methods("DELETE")
+
+	// Init Farm Get By ID
+	farmByIDPath := farmPath.String() + "/{id}"
+	r.HandleFunc(farmByIDPath, s.middleware.Middleware(s.farmHandler.GetByIDFarmHandler)).Methods("GET")
+	r.HandleFunc(farmByIDPath, s.middleware.Middleware(s.farmHandler.DeleteByIDFarmHandler)).Methods("DELETE")
+
+	// Init Pond Path
+	pondPath := app.Ponds
+	r.HandleFunc(pondPath.String(), s.middleware.Middleware(s.pondHandler.CreatePondHandler)).Methods("POST")
+	r.HandleFunc(pondPath.String(), s.middleware.Middleware(s.pondHandler.UpdatePondHandler)).Methods("PUT")
+	r.HandleFunc(pondPath.String(), s.middleware.Middleware(s.pondHandler.DeletePondHandler)).Methods("Delete")
+	r.HandleFunc(pondPath.String(), s.middleware.Middleware(s.pondHandler.GetPondHandler)).Methods("Get")
+
+	// Init Pond Get By ID
+	getPondByIDPath := pondPath.String() + "/{id}"
+	r.HandleFunc(getPondByIDPath, s.middleware.Middleware(s.pondHandler.GetByIDPondHandler)).Methods("GET")
+
+	// Init Stat Path
+	statPath := app.Stat
+	r.HandleFunc(statPath.String(), s.statHandler.GetStatHandler).Methods("GET")
+
+	// Init Metrics Path
+	metricsPath := app.Metrics
+	r.HandleFunc(metricsPath.String(), s.metricsHandler.GetMetricsHandler).Methods("GET")
+
+	// Init Admin Path
+	replayPath := app.Replay
+	r.HandleFunc(replayPath.String(), middleware.Auth(s.cfg.AdminHandler.AuthToken, s.adminHandler.ReplayDeadLetterHandler)).Methods("POST")
+
+	// Init Webhook Path, every webhook route require the webhook bearer token
+	webhookPath := app.Webhooks
+	r.HandleFunc(webhookPath.String(), middleware.Auth(s.cfg.WebhookHandler.AuthToken, s.middleware.Middleware(s.webhookHandler.CreateWebhookHandler))).Methods("POST")
+	r.HandleFunc(webhookPath.String(), middleware.Auth(s.cfg.WebhookHandler.AuthToken, s.middleware.Middleware(s.webhookHandler.GetWebhookHandler))).Methods("GET")
+
+	// Init Webhook By ID
+	webhookByIDPath := webhookPath.String() + "/{id}"
+	r.HandleFunc(webhookByIDPath, middleware.Auth(s.cfg.WebhookHandler.AuthToken, s.middleware.Middleware(s.webhookHandler.DeleteWebhookHandler))).Methods("DELETE")
+	r.HandleFunc(webhookByIDPath+"/deliveries", middleware.Auth(s.cfg.WebhookHandler.AuthToken, s.middleware.Middleware(s.webhookHandler.GetDeliveriesHandler))).Methods("GET")
+
+	// Init GraphQL Path
+	graphQLPath := app.GraphQL
+	r.HandleFunc(graphQLPath.String(), s.middleware.Middleware(s.graphQLHandler.GraphQLHandler)).Methods("POST")
+
+	// Init Import Path
+	importPath := app.Import
+	r.HandleFunc(importPath.String(), s.middleware.Middleware(s.importHandler.ImportHandler)).Methods("POST")
+
+	// Init Export Path
+	exportPath := app.Export
+	r.HandleFunc(exportPath.String(), s.middleware.Middleware(s.exportHandler.ExportHandler)).Methods("GET")
+
+	// Init OpenAPI Path
+	openAPIPath := app.OpenAPI
+	r.HandleFunc(openAPIPath.String(), s.apiDocHandler.GetOpenAPIHandler).Methods("GET")
+
+	return r
+}
+
 func (s *Server) Start() int {
 	go func() {
 		if err := s.httpServer.ListenAndServe(); err != nil {
@@ -300,20 +613,87 @@ func (s *Server) Start() int {
 		}
 	}()
 
+	if len(s.cfg.GRPC.Port) > 0 {
+		lis, err := net.Listen("tcp", ":"+s.cfg.GRPC.Port)
+		if err != nil {
+			fmt.Println("[Got Error]-GRPC Listen :", err)
+			return 1
+		}
+		log.Println("running grpc on port ", lis.Addr())
+
+		go func() {
+			if err := s.grpcServer.Serve(lis); err != nil {
+				fmt.Println(err)
+			}
+		}()
+	}
+
 	// Wait for a signal to shut down the application
 	c := make(chan os.Signal, 1)
 	signal.Notify(c, os.Interrupt)
 	<-c
 
 	log.Println("Received interrupt signal, performing backup...")
-	// Backup data from redis to postgres before shytdown
-	s.statDomain.BackUpStat()
+	s.Close()
+	log.Println("complete, shutting down.")
+	return 0
+}
+
+// Close is func to stop receiving request and stop every background worker of the server,
+// the buffered event is flushed and the stat is backed up before it return
+func (s *Server) Close() {
 	// Create a context with a timeout to allow the server to cleanly shut down
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
 	defer cancel()
 	s.httpServer.Shutdown(ctx)
-	log.Println("complete, shutting down.")
-	return 0
+
+	// Wait running grpc call until the timeout then close every connection
+	stopped := make(chan struct{})
+	go func() {
+		s.grpcServer.GracefulStop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		s.grpcServer.Stop()
+	}
+
+	// Flush buffered tracking event after no more request come in
+	flushTimeout := s.cfg.TrackingPublisher.ShutdownTimeoutInSec
+	if flushTimeout <= 0 {
+		flushTimeout = 5
+	}
+	flushCtx, flushCancel := context.WithTimeout(context.Background(), time.Duration(flushTimeout)*time.Second)
+	defer flushCancel()
+	if err := s.trackingPublisher.Close(flushCtx); err != nil {
+		fmt.Println("[Got Error]-Flush Tracking Event :", err, "dropped :", s.trackingPublisher.Dropped())
+	}
+
+	// Stop outbox relay, pending event is published on next start
+	relayTimeout := s.cfg.OutboxRelay.ShutdownTimeoutInSec
+	if relayTimeout <= 0 {
+		relayTimeout = 5
+	}
+	relayCtx, relayCancel := context.WithTimeout(context.Background(), time.Duration(relayTimeout)*time.Second)
+	defer relayCancel()
+	if err := s.outboxRelay.Close(relayCtx); err != nil {
+		fmt.Println("[Got Error]-Stop Outbox Relay :", err)
+	}
+
+	// Stop webhook delivery, unfinished event is requeued by nsq
+	deliveryTimeout := s.cfg.WebhookDelivery.ShutdownTimeoutInSec
+	if deliveryTimeout <= 0 {
+		deliveryTimeout = 5
+	}
+	deliveryCtx, deliveryCancel := context.WithTimeout(context.Background(), time.Duration(deliveryTimeout)*time.Second)
+	defer deliveryCancel()
+	if err := s.webhookConsumer.Close(deliveryCtx); err != nil {
+		fmt.Println("[Got Error]-Stop Webhook Delivery :", err)
+	}
+
+	// Backup data from redis to postgres before shytdown
+	s.statDomain.BackUpStat(context.Background())
 }
 
 // Run is func to create server and invoke Start()
@@ -0,0 +1,193 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"aqua-farm-manager/internal/app"
+	"aqua-farm-manager/internal/app/apidoc"
+	"aqua-farm-manager/pkg/aquafarmpb"
+	"aqua-farm-manager/pkg/openapi"
+	"aqua-farm-manager/pkg/prometheus"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// list bearer token of the test server
+const (
+	testGRPCAuthToken    = "secret-token"
+	testAdminAuthToken   = "admin-token"
+	testWebhookAuthToken = "webhook-token"
+)
+
+func TestServer_initRouter_OpenAPI(t *testing.T) {
+	s := &Server{}
+	r := s.initRouter()
+
+	routes, err := apidoc.Routes(r)
+	if err != nil {
+		t.Fatalf("apidoc.Routes() error = %v", err)
+	}
+	if len(routes) == 0 {
+		t.Fatalf("apidoc.Routes() got no route")
+	}
+
+	doc, err := apidoc.NewDocument(routes)
+	if err != nil {
+		t.Fatalf("apidoc.NewDocument() error = %v, every registered route must be documented in apidoc operations", err)
+	}
+	handler, err := apidoc.NewAPIDocHandler(doc)
+	if err != nil {
+		t.Fatalf("apidoc.NewAPIDocHandler() error = %v", err)
+	}
+	s.apiDocHandler = *handler
+
+	// the served document is checked so the route registered after the document is created is caught too
+	req := httptest.NewRequest(http.MethodGet, "/openapi.json", nil)
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("GET /openapi.json code = %v, want %v", rec.Code, http.StatusOK)
+	}
+
+	var served openapi.Document
+	if err := json.Unmarshal(rec.Body.Bytes(), &served); err != nil {
+		t.Fatalf("GET /openapi.json body is not openapi document : %v", err)
+	}
+	if served.OpenAPI != openapi.Version {
+		t.Errorf("openapi version = %v, want %v", served.OpenAPI, openapi.Version)
+	}
+	for _, route := range routes {
+		op := served.Operation(route.Method, route.Path)
+		if op == nil {
+			t.Errorf("route %s is registered but missing from the openapi document", route)
+			continue
+		}
+		if _, ok := op.Responses["200"]; !ok {
+			t.Errorf("route %s has no success response in the openapi document", route)
+		}
+	}
+}
+
+// httpRequestsTotal is func to get the http request counter of the method, route and status code
+func httpRequestsTotal(method, route, code string) float64 {
+	for _, family := range prometheus.Default.Gather() {
+		if family.Name != "aqua_farm_http_requests_total" {
+			continue
+		}
+		for _, metric := range family.Metrics {
+			labels := map[string]string{}
+			for _, label := range metric.Labels {
+				labels[label.Name] = label.Value
+			}
+			if labels["method"] == method && labels["route"] == route && labels["code"] == code {
+				return metric.Value
+			}
+		}
+	}
+	return 0
+}
+
+func TestServer_initRouter_Metrics(t *testing.T) {
+	s := &Server{}
+	r := s.initRouter()
+
+	// the route that is not wrapped by the tracking middleware is counted by the router
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		code   string
+	}{
+		{
+			name:   "openapi document",
+			method: http.MethodGet,
+			path:   app.OpenAPI.String(),
+			code:   "200",
+		},
+		{
+			name:   "admin request rejected by auth",
+			method: http.MethodPost,
+			path:   app.Replay.String(),
+			code:   "401",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := httpRequestsTotal(tt.method, tt.path, tt.code) + 1
+
+			r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(tt.method, tt.path, nil))
+
+			if got := httpRequestsTotal(tt.method, tt.path, tt.code); got != want {
+				t.Errorf("%s %s requests total = %v, want %v", tt.method, tt.path, got, want)
+			}
+		})
+	}
+}
+
+func TestServer_grpcServer(t *testing.T) {
+	s := newMemoryServer(t)
+	ts := httptest.NewServer(s.httpServer.Handler)
+	defer ts.Close()
+
+	lis := bufconn.Listen(1024 * 1024)
+	go s.grpcServer.Serve(lis)
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithInsecure())
+	if err != nil {
+		t.Fatalf("grpc.Dial() error = %v", err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	client := aquafarmpb.NewFarmServiceClient(conn)
+
+	// call without token is rejected before the domain is called
+	_, err = client.CreateFarm(ctx, &aquafarmpb.CreateFarmRequest{Name: "Blue Lagoon"})
+	if code := status.Code(err); code != codes.Unauthenticated {
+		t.Fatalf("CreateFarm() without token code = %v, want %v", code, codes.Unauthenticated)
+	}
+
+	authCtx := metadata.AppendToOutgoingContext(ctx, "authorization", "Bearer "+testGRPCAuthToken)
+	created, err := client.CreateFarm(authCtx, &aquafarmpb.CreateFarmRequest{Name: "Blue Lagoon", Owner: "John Doe"})
+	if err != nil {
+		t.Fatalf("CreateFarm() error = %v", err)
+	}
+	_, err = client.CreateFarm(authCtx, &aquafarmpb.CreateFarmRequest{Name: "Blue Lagoon"})
+	if code := status.Code(err); code != codes.AlreadyExists {
+		t.Errorf("CreateFarm() duplicate code = %v, want %v", code, codes.AlreadyExists)
+	}
+
+	// the farm created by grpc is served by http api as both use the same domain
+	res, err := http.Get(fmt.Sprintf("%s/v1/farms/%d", ts.URL, created.GetId()))
+	if err != nil {
+		t.Fatalf("GET /v1/farms/{id} error = %v", err)
+	}
+	defer res.Body.Close()
+	var body struct {
+		Data struct {
+			Name  string `json:"name"`
+			Owner string `json:"owner"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&body); err != nil {
+		t.Fatalf("GET /v1/farms/{id} invalid body : %v", err)
+	}
+	if res.StatusCode != http.StatusOK || body.Data.Name != "Blue Lagoon" || body.Data.Owner != "John Doe" {
+		t.Errorf("GET /v1/farms/{id} = %v %+v, want farm created by grpc", res.StatusCode, body.Data)
+	}
+}
@@ -0,0 +1,42 @@
+package admin
+
+import "aqua-farm-manager/internal/app/trackingevent"
+
+// AdminHandler list dependencies for admin handler
+type AdminHandler struct {
+	replayer     trackingevent.DeadLetterReplayer
+	timeoutInSec int
+}
+
+// Option set options for http handler config
+type Option func(*AdminHandler)
+
+const (
+	defaultTimeout = 30
+)
+
+// NewAdminHandler is func to create http admin handler
+func NewAdminHandler(replayer trackingevent.DeadLetterReplayer, options ...Option) *AdminHandler {
+	handler := &AdminHandler{
+		replayer:     replayer,
+		timeoutInSec: defaultTimeout,
+	}
+
+	// Apply options
+	for _, opt := range options {
+		opt(handler)
+	}
+
+	return handler
+}
+
+// WithTimeoutOptions is func to set timeout config into handler
+func WithTimeoutOptions(timeoutinsec int) Option {
+	return Option(
+		func(ah *AdminHandler) {
+			if timeoutinsec <= 0 {
+				timeoutinsec = defaultTimeout
+			}
+			ah.timeoutInSec = timeoutinsec
+		})
+}
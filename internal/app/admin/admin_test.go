@@ -0,0 +1,60 @@
+package admin
+
+import (
+	"aqua-farm-manager/internal/app/trackingevent"
+	"reflect"
+	"testing"
+)
+
+func TestNewAdminHandler(t *testing.T) {
+	type args struct {
+		replayer trackingevent.DeadLetterReplayer
+		options  []Option
+	}
+	tests := []struct {
+		name string
+		args args
+		want *AdminHandler
+	}{
+		{
+			name: "success with setting flow",
+			args: args{
+				replayer: &trackingevent.TrackingEventConsumer{},
+				options:  []Option{WithTimeoutOptions(10)},
+			},
+			want: &AdminHandler{
+				timeoutInSec: 10,
+				replayer:     &trackingevent.TrackingEventConsumer{},
+			},
+		},
+		{
+			name: "success without option flow",
+			args: args{
+				replayer: &trackingevent.TrackingEventConsumer{},
+				options:  []Option{},
+			},
+			want: &AdminHandler{
+				timeoutInSec: 30,
+				replayer:     &trackingevent.TrackingEventConsumer{},
+			},
+		},
+		{
+			name: "success with invalid setting flow",
+			args: args{
+				replayer: &trackingevent.TrackingEventConsumer{},
+				options:  []Option{WithTimeoutOptions(-1)},
+			},
+			want: &AdminHandler{
+				timeoutInSec: 30,
+				replayer:     &trackingevent.TrackingEventConsumer{},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewAdminHandler(tt.args.replayer, tt.args.options...); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewAdminHandler() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
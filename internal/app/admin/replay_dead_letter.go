@@ -0,0 +1,89 @@
+package admin
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"aqua-farm-manager/internal/app/trackingevent"
+	"aqua-farm-manager/pkg/apperror"
+	utilhttp "aqua-farm-manager/pkg/utilhttp"
+)
+
+const (
+	defaultReplayLimit = 100
+	maxReplayLimit     = 1000
+)
+
+// ReplayDeadLetterResponse is list response parameter for replay dead letter
+type ReplayDeadLetterResponse struct {
+	Replayed  int `json:"replayed"`
+	Discarded int `json:"discarded"`
+	Failed    int `json:"failed"`
+}
+
+// ReplayDeadLetterHandler is func handler to replay tracking event from dead letter topic,
+// the number of replayed message can be set by limit query param
+func (h *AdminHandler) ReplayDeadLetterHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.timeoutInSec)*time.Second)
+	defer cancel()
+
+	var err error
+	var response utilhttp.StandardResponse
+	var code int = http.StatusOK
+
+	defer func() {
+		if err != nil {
+			code = apperror.HTTPStatus(err)
+			if code == http.StatusInternalServerError {
+				log.Println("[ReplayDeadLetterHandler]-Internal Error :", err)
+			}
+			response.SetError(err)
+		} else {
+			response.Message = "success"
+		}
+		response.Code = code
+
+		data, errMarshal := json.Marshal(response)
+		if errMarshal != nil {
+			log.Println("[ReplayDeadLetterHandler]-Error Marshal Response :", err)
+			code = http.StatusInternalServerError
+			data = []byte(`{"code":500,"message":"Internal Server Error"}`)
+		}
+		utilhttp.WriteResponse(w, data, code)
+	}()
+
+	// checking valid param
+	limit := defaultReplayLimit
+	if param := r.URL.Query().Get("limit"); param != "" {
+		limit, err = strconv.Atoi(param)
+		if err != nil || limit <= 0 || limit > maxReplayLimit {
+			err = apperror.Validation(apperror.Field("limit", fmt.Sprintf("limit must be between 1 and %d", maxReplayLimit)))
+			return
+		}
+	}
+
+	// replay is stopped by the context so the partial result still can be returned on timeout
+	res, err := h.replayer.ReplayDeadLetter(ctx, limit)
+	if err != nil {
+		return
+	}
+
+	response = mapResponseReplayDeadLetter(res)
+}
+
+func mapResponseReplayDeadLetter(r trackingevent.ReplayResult) utilhttp.StandardResponse {
+	var res utilhttp.StandardResponse
+	data := ReplayDeadLetterResponse{
+		Replayed:  r.Replayed,
+		Discarded: r.Discarded,
+		Failed:    r.Failed,
+	}
+
+	res.Data = data
+	return res
+}
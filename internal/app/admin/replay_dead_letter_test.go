@@ -0,0 +1,133 @@
+package admin
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"aqua-farm-manager/internal/app/trackingevent"
+	"aqua-farm-manager/internal/app/trackingevent/mock_trackingevent"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestAdminHandler_ReplayDeadLetterHandler(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	type want struct {
+		body string
+		code int
+	}
+	tests := []struct {
+		name     string
+		url      string
+		mockFunc func(*mock_trackingevent.MockDeadLetterReplayer)
+		want     want
+	}{
+		{
+			name: "success with default limit flow",
+			url:  "/v1/admin/dlq/replay",
+			mockFunc: func(m *mock_trackingevent.MockDeadLetterReplayer) {
+				m.EXPECT().ReplayDeadLetter(gomock.Any(), 100).Return(trackingevent.ReplayResult{Replayed: 3, Discarded: 1}, nil)
+			},
+			want: want{
+				code: 200,
+				body: `{"data":{"replayed":3,"discarded":1,"failed":0},"code":200,"message":"success"}`,
+			},
+		},
+		{
+			name: "success with limit flow",
+			url:  "/v1/admin/dlq/replay?limit=10",
+			mockFunc: func(m *mock_trackingevent.MockDeadLetterReplayer) {
+				m.EXPECT().ReplayDeadLetter(gomock.Any(), 10).Return(trackingevent.ReplayResult{Replayed: 9, Failed: 1}, nil)
+			},
+			want: want{
+				code: 200,
+				body: `{"data":{"replayed":9,"discarded":0,"failed":1},"code":200,"message":"success"}`,
+			},
+		},
+		{
+			name:     "invalid limit flow",
+			url:      "/v1/admin/dlq/replay?limit=abc",
+			mockFunc: func(m *mock_trackingevent.MockDeadLetterReplayer) {},
+			want: want{
+				code: 422,
+				body: `{"code":422,"message":"Invalid Parameter Request","errors":[{"code":"VALIDATION_FAILED","field":"limit","message":"limit must be between 1 and 1000"}]}`,
+			},
+		},
+		{
+			name:     "limit over max flow",
+			url:      "/v1/admin/dlq/replay?limit=1001",
+			mockFunc: func(m *mock_trackingevent.MockDeadLetterReplayer) {},
+			want: want{
+				code: 422,
+				body: `{"code":422,"message":"Invalid Parameter Request","errors":[{"code":"VALIDATION_FAILED","field":"limit","message":"limit must be between 1 and 1000"}]}`,
+			},
+		},
+		{
+			name: "replay in progress flow",
+			url:  "/v1/admin/dlq/replay",
+			mockFunc: func(m *mock_trackingevent.MockDeadLetterReplayer) {
+				m.EXPECT().ReplayDeadLetter(gomock.Any(), 100).Return(trackingevent.ReplayResult{}, trackingevent.ErrReplayInProgress)
+			},
+			want: want{
+				code: 409,
+				body: `{"code":409,"message":"dead letter replay is in progress","errors":[{"code":"CONFLICT","message":"dead letter replay is in progress"}]}`,
+			},
+		},
+		{
+			name: "dead letter disabled flow",
+			url:  "/v1/admin/dlq/replay",
+			mockFunc: func(m *mock_trackingevent.MockDeadLetterReplayer) {
+				m.EXPECT().ReplayDeadLetter(gomock.Any(), 100).Return(trackingevent.ReplayResult{}, trackingevent.ErrDeadLetterDisabled)
+			},
+			want: want{
+				code: 503,
+				body: `{"code":503,"message":"dead letter topic is not configured","errors":[{"code":"SERVICE_UNAVAILABLE","message":"dead letter topic is not configured"}]}`,
+			},
+		},
+		{
+			name: "got error replay flow",
+			url:  "/v1/admin/dlq/replay",
+			mockFunc: func(m *mock_trackingevent.MockDeadLetterReplayer) {
+				m.EXPECT().ReplayDeadLetter(gomock.Any(), 100).Return(trackingevent.ReplayResult{}, fmt.Errorf("some error"))
+			},
+			want: want{
+				code: 500,
+				body: `{"code":500,"message":"Internal Server Error","errors":[{"code":"INTERNAL_ERROR","message":"Internal Server Error"}]}`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			replayer := mock_trackingevent.NewMockDeadLetterReplayer(mockCtrl)
+			tt.mockFunc(replayer)
+
+			handler := AdminHandler{
+				replayer:     replayer,
+				timeoutInSec: 5,
+			}
+
+			r := httptest.NewRequest(http.MethodPost, tt.url, strings.NewReader(""))
+			w := httptest.NewRecorder()
+			handler.ReplayDeadLetterHandler(w, r)
+			result := w.Result()
+			resBody, err := ioutil.ReadAll(result.Body)
+
+			if err != nil {
+				t.Fatalf("Error read body err = %v\n", err)
+			}
+
+			if result.StatusCode != tt.want.code {
+				t.Fatalf("ReplayDeadLetterHandler status code got =%d, want %d \n", result.StatusCode, tt.want.code)
+			}
+
+			if string(resBody) != tt.want.body {
+				t.Fatalf("ReplayDeadLetterHandler body got =%s, want %s \n", string(resBody), tt.want.body)
+			}
+		})
+	}
+}
@@ -0,0 +1,242 @@
+package apidoc
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"aqua-farm-manager/pkg/apperror"
+	"aqua-farm-manager/pkg/openapi"
+	utilhttp "aqua-farm-manager/pkg/utilhttp"
+
+	"github.com/gorilla/mux"
+)
+
+// APIDocHandler struct is list dependecies to serve the openapi document
+type APIDocHandler struct {
+	spec []byte
+}
+
+// NewAPIDocHandler is func to create APIDocHandler that serve doc,
+// the document is encoded once as it never change while the server is running
+func NewAPIDocHandler(doc *openapi.Document) (*APIDocHandler, error) {
+	spec, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+
+	return &APIDocHandler{
+		spec: spec,
+	}, nil
+}
+
+// GetOpenAPIHandler is func handler to get openapi document of the api
+func (h *APIDocHandler) GetOpenAPIHandler(w http.ResponseWriter, r *http.Request) {
+	utilhttp.WriteResponse(w, h.spec, http.StatusOK)
+}
+
+// Route is path template and method that is registered in the router
+type Route struct {
+	Method string
+	Path   string
+}
+
+// String is func to get string representation of route
+func (r Route) String() string { return r.Method + " " + r.Path }
+
+// pathVarPattern is pattern of mux path variable with its regexp, e.g {id:[0-9]+}
+var pathVarPattern = regexp.MustCompile(`\{([^{}:]+):[^{}]*\}`)
+
+// Routes is func to get every route of router r, the path variable pattern is
+// removed so the path is the same as openapi path template
+func Routes(r *mux.Router) ([]Route, error) {
+	var routes []Route
+	err := r.Walk(func(route *mux.Route, _ *mux.Router, _ []*mux.Route) error {
+		path, err := route.GetPathTemplate()
+		if err != nil {
+			return nil
+		}
+		methods, err := route.GetMethods()
+		if err != nil {
+			return nil
+		}
+
+		path = pathVarPattern.ReplaceAllString(path, "{$1}")
+		for _, method := range methods {
+			routes = append(routes, Route{Method: strings.ToUpper(method), Path: path})
+		}
+		return nil
+	})
+	return routes, err
+}
+
+// NewDocument is func to generate openapi document of routes from the operation and the handler request, response type.
+// Route without operation is not in the document and it is returned in the error
+func NewDocument(routes []Route) (*openapi.Document, error) {
+	doc := openapi.NewDocument(openapi.Info{
+		Title:       "Aqua Farm Manager",
+		Description: "Api to manage farm, pond and its usage statistic.",
+		Version:     "v1",
+	})
+
+	var missing []string
+	for _, route := range routes {
+		op, ok := operations[route]
+		if !ok {
+			missing = append(missing, route.String())
+			continue
+		}
+		doc.AddOperation(route.Method, route.Path, op.build(doc, route))
+	}
+
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return doc, fmt.Errorf("route is not documented : %s", strings.Join(missing, ", "))
+	}
+	return doc, nil
+}
+
+// operation is doc of a route, the request and response is zero value of the handler type
+type operation struct {
+	id           string
+	summary      string
+	tag          string
+	params       []openapi.Parameter
+	request      interface{}
+	requestType  []string // content type of raw request body that is not json, e.g csv file
+	response     interface{}
+	responseType []string // content type of raw success response that is not json, the error is still standard response
+	errors       []int    // status of domain error, bad request, validation, internal and timeout status is added by build for standard response
+	contentType  string   // content type of success response, default is json in standard response
+	auth         bool     // the route require bearer token in authorization header
+}
+
+// build is func to create openapi operation of op
+func (op operation) build(doc *openapi.Document, route Route) *openapi.Operation {
+	res := &openapi.Operation{
+		OperationID: op.id,
+		Summary:     op.summary,
+		Tags:        []string{op.tag},
+		Responses:   map[string]openapi.Response{},
+	}
+
+	for _, name := range pathVars(route.Path) {
+		res.Parameters = append(res.Parameters, openapi.Parameter{
+			Name:     name,
+			In:       "path",
+			Required: true,
+			Schema:   &openapi.Schema{Type: "integer", Format: "int64"},
+		})
+	}
+	res.Parameters = append(res.Parameters, op.params...)
+
+	if op.request != nil {
+		res.RequestBody = &openapi.RequestBody{
+			Content: jsonContent(doc.RequestSchema(op.request)),
+		}
+	}
+	if len(op.requestType) > 0 {
+		res.RequestBody = &openapi.RequestBody{Required: true, Content: rawContent(op.requestType)}
+	}
+
+	switch op.contentType {
+	case "":
+		success := jsonContent(envelope(doc, doc.ResponseSchema(op.response), "data"))
+		if len(op.responseType) > 0 {
+			success = rawContent(op.responseType)
+		}
+		res.Responses["200"] = openapi.Response{
+			Description: "success",
+			Content:     success,
+		}
+		for _, status := range op.statuses(route) {
+			res.Responses[strconv.Itoa(status)] = openapi.Response{
+				Description: http.StatusText(status),
+				Content:     jsonContent(envelope(doc, nil, "errors")),
+			}
+		}
+	case "application/json":
+		res.Responses["200"] = openapi.Response{
+			Description: "success",
+			Content:     jsonContent(doc.ResponseSchema(op.response)),
+		}
+		// the error is written in the same body of the success response
+		for _, status := range op.errors {
+			res.Responses[strconv.Itoa(status)] = openapi.Response{
+				Description: http.StatusText(status),
+				Content:     jsonContent(doc.ResponseSchema(op.response)),
+			}
+		}
+	default:
+		res.Responses["200"] = openapi.Response{
+			Description: "success",
+			Content: map[string]openapi.MediaType{
+				op.contentType: {Schema: &openapi.Schema{Type: "string"}},
+			},
+		}
+	}
+	return res
+}
+
+// statuses is func to get every error status of op, the request body can be bad request or invalid
+// and the path variable can be invalid, every standard response handler can be internal error or timeout
+func (op operation) statuses(route Route) []int {
+	statuses := append([]int{}, op.errors...)
+	if op.auth {
+		statuses = append(statuses, http.StatusUnauthorized)
+	}
+	if op.request != nil || len(op.requestType) > 0 {
+		statuses = append(statuses, http.StatusBadRequest, http.StatusUnprocessableEntity)
+	}
+	if len(pathVars(route.Path)) > 0 {
+		statuses = append(statuses, http.StatusUnprocessableEntity)
+	}
+	return append(statuses, http.StatusInternalServerError, http.StatusGatewayTimeout)
+}
+
+// envelope is func to get schema of utilhttp.StandardResponse with data schema, required is the extra field that is always set
+func envelope(doc *openapi.Document, data *openapi.Schema, required ...string) *openapi.Schema {
+	s := &openapi.Schema{
+		Type: "object",
+		Properties: map[string]*openapi.Schema{
+			"code":    {Type: "integer", Format: "int32"},
+			"message": {Type: "string"},
+			"errors":  {Type: "array", Items: doc.ResponseSchema(apperror.Detail{})},
+		},
+		Required: append([]string{"code", "message"}, required...),
+	}
+	if data != nil {
+		s.Properties["data"] = data
+	}
+	return s
+}
+
+// rawContent is func to get content of every content type of raw body that is a string
+func rawContent(contentTypes []string) map[string]openapi.MediaType {
+	content := make(map[string]openapi.MediaType, len(contentTypes))
+	for _, contentType := range contentTypes {
+		content[contentType] = openapi.MediaType{Schema: &openapi.Schema{Type: "string"}}
+	}
+	return content
+}
+
+func jsonContent(schema *openapi.Schema) map[string]openapi.MediaType {
+	return map[string]openapi.MediaType{
+		"application/json": {Schema: schema},
+	}
+}
+
+// pathVars is func to get name of every variable in path template
+func pathVars(path string) []string {
+	var vars []string
+	for _, part := range strings.Split(path, "/") {
+		if strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}") {
+			vars = append(vars, strings.Trim(part, "{}"))
+		}
+	}
+	return vars
+}
@@ -0,0 +1,167 @@
+package apidoc
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"testing"
+
+	"aqua-farm-manager/internal/app/farm"
+	"aqua-farm-manager/pkg/openapi"
+
+	"github.com/gorilla/mux"
+)
+
+func TestRoutes(t *testing.T) {
+	handler := func(w http.ResponseWriter, r *http.Request) {}
+	r := mux.NewRouter()
+	r.HandleFunc("/v1/farms", handler).Methods("POST", "Get")
+	r.HandleFunc("/v1/farms/{id:[0-9]+}", handler).Methods("DELETE")
+	r.HandleFunc("/any", handler)
+
+	got, err := Routes(r)
+	if err != nil {
+		t.Fatalf("Routes() error = %v", err)
+	}
+	want := []Route{
+		{Method: "POST", Path: "/v1/farms"},
+		{Method: "GET", Path: "/v1/farms"},
+		{Method: "DELETE", Path: "/v1/farms/{id}"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Routes() = %v, want %v", got, want)
+	}
+}
+
+func TestNewDocument(t *testing.T) {
+	tests := []struct {
+		name    string
+		routes  []Route
+		want    []Route
+		wantErr bool
+	}{
+		{
+			name: "success flow",
+			routes: []Route{
+				{Method: "POST", Path: "/v1/farms"},
+				{Method: "GET", Path: "/v1/farms/{id}"},
+			},
+			want: []Route{
+				{Method: "POST", Path: "/v1/farms"},
+				{Method: "GET", Path: "/v1/farms/{id}"},
+			},
+		},
+		{
+			name: "undocumented route flow",
+			routes: []Route{
+				{Method: "POST", Path: "/v1/farms"},
+				{Method: "PATCH", Path: "/v1/farms"},
+			},
+			want: []Route{
+				{Method: "POST", Path: "/v1/farms"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := NewDocument(tt.routes)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewDocument() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			count := 0
+			for _, item := range doc.Paths {
+				count += len(item)
+			}
+			if count != len(tt.want) {
+				t.Errorf("NewDocument() got %v operation, want %v", count, len(tt.want))
+			}
+			for _, route := range tt.want {
+				if doc.Operation(route.Method, route.Path) == nil {
+					t.Errorf("NewDocument() missing operation %s", route)
+				}
+			}
+		})
+	}
+}
+
+func TestNewDocument_Schema(t *testing.T) {
+	doc, err := NewDocument([]Route{
+		{Method: "POST", Path: "/v1/farms"},
+		{Method: "GET", Path: "/v1/farms"},
+		{Method: "GET", Path: "/v1/farms/{id}"},
+	})
+	if err != nil {
+		t.Fatalf("NewDocument() error = %v", err)
+	}
+
+	// request is required and limited by the validate tag
+	create := doc.Operation("POST", "/v1/farms")
+	request := doc.Component(create.RequestBody.Content["application/json"].Schema.Ref)
+	if request == nil {
+		t.Fatalf("request schema of CreateFarmRequest is not a component")
+	}
+	if !reflect.DeepEqual(request.Required, []string{"name"}) {
+		t.Errorf("CreateFarmRequest required = %v, want [name]", request.Required)
+	}
+	if max := request.Properties["name"].MaxLength; max == nil || *max != 100 {
+		t.Errorf("CreateFarmRequest name maxLength = %v, want 100", max)
+	}
+
+	// response is wrapped in standard response and every field without omitempty is required
+	success := create.Responses["200"].Content["application/json"].Schema
+	if !reflect.DeepEqual(success.Required, []string{"code", "message", "data"}) {
+		t.Errorf("standard response required = %v, want [code message data]", success.Required)
+	}
+	var statuses []string
+	for status := range create.Responses {
+		statuses = append(statuses, status)
+	}
+	sort.Strings(statuses)
+	if want := []string{"200", "400", "409", "422", "500", "504"}; !reflect.DeepEqual(statuses, want) {
+		t.Errorf("POST /v1/farms responses = %v, want %v", statuses, want)
+	}
+	failed := create.Responses["409"].Content["application/json"].Schema
+	if !reflect.DeepEqual(failed.Required, []string{"code", "message", "errors"}) {
+		t.Errorf("error response required = %v, want [code message errors]", failed.Required)
+	}
+
+	list := doc.Component(doc.ResponseSchema(farm.FarmInfo{}).Ref)
+	if pondIDs := list.Properties["list_pondID"]; pondIDs == nil || !pondIDs.Nullable || pondIDs.Type != "array" {
+		t.Errorf("FarmInfo list_pondID = %+v, want nullable array", pondIDs)
+	}
+	byID := doc.Component(doc.ResponseSchema(farm.GetByIDFarmResponse{}).Ref)
+	for _, field := range byID.Required {
+		if field == "pond_info" {
+			t.Errorf("GetByIDFarmResponse pond_info with omitempty is required")
+		}
+	}
+
+	// path variable is documented as parameter
+	params := doc.Operation("GET", "/v1/farms/{id}").Parameters
+	if len(params) != 1 || params[0].Name != "id" || params[0].In != "path" || !params[0].Required {
+		t.Errorf("GET /v1/farms/{id} parameters = %+v, want required id in path", params)
+	}
+}
+
+func TestAPIDocHandler_GetOpenAPIHandler(t *testing.T) {
+	doc := openapi.NewDocument(openapi.Info{Title: "test", Version: "v1"})
+	handler, err := NewAPIDocHandler(doc)
+	if err != nil {
+		t.Fatalf("NewAPIDocHandler() error = %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/openapi.json", nil)
+	rec := httptest.NewRecorder()
+	handler.GetOpenAPIHandler(rec, req)
+
+	want := `{"openapi":"3.0.3","info":{"title":"test","version":"v1"},"paths":{},"components":{"schemas":{}}}`
+	if rec.Code != http.StatusOK || rec.Body.String() != want {
+		t.Errorf("GetOpenAPIHandler() = %v %v, want %v %v", rec.Code, rec.Body.String(), http.StatusOK, want)
+	}
+	if got := rec.Header().Get("Content-Type"); got != "application/json" {
+		t.Errorf("GetOpenAPIHandler() content type = %v, want application/json", got)
+	}
+}
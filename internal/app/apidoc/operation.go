@@ -0,0 +1,186 @@
+package apidoc
+
+import (
+	"net/http"
+
+	"aqua-farm-manager/internal/app"
+	"aqua-farm-manager/internal/app/admin"
+	"aqua-farm-manager/internal/app/farm"
+	"aqua-farm-manager/internal/app/importer"
+	"aqua-farm-manager/internal/app/pond"
+	"aqua-farm-manager/internal/app/stat"
+	"aqua-farm-manager/internal/app/webhook"
+	"aqua-farm-manager/pkg/graphql"
+	"aqua-farm-manager/pkg/openapi"
+	"aqua-farm-manager/pkg/prometheus"
+)
+
+// operations is doc of every route that is registered in the server, new route must be added here
+// so it is in the openapi document
+var operations = map[Route]operation{
+	// Farm
+	{"POST", app.Farms.String()}: {
+		id: "createFarm", summary: "Create farm", tag: "farm",
+		request: farm.CreateFarmRequest{}, response: farm.CreateFarmResponse{},
+		errors: []int{http.StatusConflict},
+	},
+	{"GET", app.Farms.String()}: {
+		id: "getFarms", summary: "Get page of farm, the page is set in the body", tag: "farm",
+		request: farm.GetFarmRequest{}, response: farm.GetFarmResponse{},
+		errors: []int{http.StatusNotFound},
+	},
+	{"PUT", app.Farms.String()}: {
+		id: "updateFarm", summary: "Update farm by name, the farm is created when it does not exist", tag: "farm",
+		request: farm.UpdateFarmRequest{}, response: farm.UpdateFarmResponse{},
+		errors: []int{http.StatusConflict},
+	},
+	{"DELETE", app.Farms.String()}: {
+		id: "deleteFarm", summary: "Delete farm by id or name", tag: "farm",
+		request: farm.DeleteFarmRequest{}, response: farm.DeleteFarmResponse{},
+		errors: []int{http.StatusNotFound, http.StatusConflict},
+	},
+	{"GET", app.Farms.String() + "/{id}"}: {
+		id: "getFarmByID", summary: "Get farm with its ponds", tag: "farm",
+		response: farm.GetByIDFarmResponse{},
+		errors:   []int{http.StatusNotFound},
+	},
+	{"DELETE", app.Farms.String() + "/{id}"}: {
+		id: "deleteFarmByID", summary: "Delete farm with its ponds", tag: "farm",
+		response: farm.DeleteByIDFarmResponse{},
+		errors:   []int{http.StatusNotFound},
+	},
+
+	// Pond
+	{"POST", app.Ponds.String()}: {
+		id: "createPond", summary: "Create pond in a farm", tag: "pond",
+		request: pond.CreatePondRequest{}, response: pond.CreatePondResponse{},
+		errors: []int{http.StatusNotFound, http.StatusConflict},
+	},
+	{"GET", app.Ponds.String()}: {
+		id: "getPonds", summary: "Get page of pond, the page is set in the body", tag: "pond",
+		request: pond.GetPondRequest{}, response: pond.GetPondResponse{},
+		errors: []int{http.StatusNotFound},
+	},
+	{"PUT", app.Ponds.String()}: {
+		id: "updatePond", summary: "Update pond by name, the pond is created when it does not exist", tag: "pond",
+		request: pond.UpdatePondRequest{}, response: pond.UpdatePondResponse{},
+		errors: []int{http.StatusNotFound, http.StatusConflict},
+	},
+	{"DELETE", app.Ponds.String()}: {
+		id: "deletePond", summary: "Delete pond by id or name", tag: "pond",
+		request: pond.DeletePondRequest{}, response: pond.DeletePondResponse{},
+		errors: []int{http.StatusNotFound},
+	},
+	{"GET", app.Ponds.String() + "/{id}"}: {
+		id: "getPondByID", summary: "Get pond with its farm", tag: "pond",
+		response: pond.GetByIDPondResponse{},
+		errors:   []int{http.StatusNotFound},
+	},
+
+	// Stat and Metrics
+	{"GET", app.Stat.String()}: {
+		id: "getStat", summary: "Get request statistic by method and path", tag: "stat",
+		response: map[string]stat.Metrics{},
+	},
+	{"GET", app.Metrics.String()}: {
+		id: "getMetrics", summary: "Get prometheus metrics", tag: "stat",
+		contentType: prometheus.ContentType,
+	},
+
+	// Admin
+	{"POST", app.Replay.String()}: {
+		id: "replayDeadLetter", summary: "Replay tracking event from dead letter topic, the bearer token is admin_handler.auth_token", tag: "admin",
+		params:   []openapi.Parameter{queryInt("limit", "number of replayed message, 1 to 1000, default is 100")},
+		response: admin.ReplayDeadLetterResponse{},
+		errors:   []int{http.StatusUnprocessableEntity, http.StatusConflict, http.StatusServiceUnavailable},
+		auth:     true,
+	},
+
+	// Webhook
+	{"POST", app.Webhooks.String()}: {
+		id: "createWebhook", summary: "Register outbound webhook, the bearer token is webhook_handler.auth_token", tag: "webhook",
+		request: webhook.CreateWebhookRequest{}, response: webhook.CreateWebhookResponse{},
+		auth: true,
+	},
+	{"GET", app.Webhooks.String()}: {
+		id: "getWebhooks", summary: "Get page of registered webhook", tag: "webhook",
+		params: []openapi.Parameter{
+			queryInt("size", "page size, 1 to 20, default is 20"),
+			queryInt("cursor", "cursor of the page from the previous response"),
+		},
+		response: webhook.GetWebhookResponse{},
+		auth:     true,
+	},
+	{"DELETE", app.Webhooks.String() + "/{id}"}: {
+		id: "deleteWebhook", summary: "Delete registered webhook", tag: "webhook",
+		response: webhook.DeleteWebhookResponse{},
+		errors:   []int{http.StatusNotFound},
+		auth:     true,
+	},
+	{"GET", app.Webhooks.String() + "/{id}/deliveries"}: {
+		id: "getWebhookDeliveries", summary: "Get latest delivery attempt of webhook", tag: "webhook",
+		params:   []openapi.Parameter{queryInt("limit", "number of delivery, 1 to 500, default is 50")},
+		response: webhook.GetDeliveriesResponse{},
+		errors:   []int{http.StatusNotFound, http.StatusUnprocessableEntity},
+		auth:     true,
+	},
+
+	// GraphQL
+	{"POST", app.GraphQL.String()}: {
+		id: "graphql", summary: "Execute graphql query of farm, pond and stat, field error is returned with status 200", tag: "graphql",
+		request: graphql.Request{}, response: graphql.Response{},
+		errors:      []int{http.StatusBadRequest, http.StatusUnprocessableEntity},
+		contentType: "application/json",
+	},
+
+	// Import
+	{"POST", app.Import.String()}: {
+		id: "importFile", summary: "Import farms or ponds from csv or ndjson file, invalid row is returned with status 200", tag: "import",
+		params: []openapi.Parameter{
+			queryString("entity", "entity of every row, farms or ponds", true),
+			queryString("format", "format of the file, csv with header or ndjson, default is csv", false),
+			queryString("mode", "partial create every valid row, atomic create nothing when any row is invalid, default is partial", false),
+			queryString("dry_run", "validate every row without creating it, default is false", false),
+		},
+		requestType: []string{"text/csv", "application/x-ndjson"},
+		response:    importer.ImportResponse{},
+		errors:      []int{http.StatusNotFound, http.StatusConflict},
+	},
+
+	// Export
+	{"GET", app.Export.String()}: {
+		id: "exportFile", summary: "Export farms or ponds into csv or ndjson file, the rows is streamed from the storage", tag: "export",
+		params: []openapi.Parameter{
+			queryString("entity", "entity of every row, farms or ponds", true),
+			queryString("format", "format of the file, csv with header or ndjson, default is csv", false),
+			queryString("status", "status of the rows, active, inactive for the deleted rows or all, default is active", false),
+		},
+		responseType: []string{"text/csv", "application/x-ndjson"},
+		errors:       []int{http.StatusUnprocessableEntity},
+	},
+
+	// OpenAPI
+	{"GET", app.OpenAPI.String()}: {
+		id: "getOpenAPI", summary: "Get openapi document of the api", tag: "doc",
+		contentType: "application/json",
+	},
+}
+
+func queryInt(name, description string) openapi.Parameter {
+	return openapi.Parameter{
+		Name:        name,
+		In:          "query",
+		Description: description,
+		Schema:      &openapi.Schema{Type: "integer", Format: "int32"},
+	}
+}
+
+func queryString(name, description string, required bool) openapi.Parameter {
+	return openapi.Parameter{
+		Name:        name,
+		In:          "query",
+		Description: description,
+		Required:    required,
+		Schema:      &openapi.Schema{Type: "string"},
+	}
+}
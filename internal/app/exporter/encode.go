@@ -0,0 +1,179 @@
+package exporter
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"aqua-farm-manager/pkg/apperror"
+)
+
+// list format of export file
+const (
+	formatCSV    = "csv"
+	formatNDJSON = "ndjson"
+)
+
+// contentType is content type of every format of export file
+var contentType = map[string]string{
+	formatCSV:    "text/csv; charset=utf-8",
+	formatNDJSON: "application/x-ndjson",
+}
+
+// list trailer that is sent after the last row, the file is complete only when X-Export-Complete is true
+const (
+	TrailerComplete = "X-Export-Complete"
+	TrailerError    = "X-Export-Error"
+)
+
+// exportError is the last line of ndjson file that is cut by an error
+type exportError struct {
+	Error exportErrorDetail `json:"error"`
+}
+
+type exportErrorDetail struct {
+	Code    apperror.Code `json:"code"`
+	Message string        `json:"message"`
+}
+
+// exportRow is a row of export file, the record is the value of every csv column
+type exportRow interface {
+	record() []string
+}
+
+// stream is writer of export file, the status and header is written with the first row
+// so the error before any row is written can still be returned in standard response
+type stream struct {
+	w             http.ResponseWriter
+	params        exportParams
+	columns       []string
+	csv           *csv.Writer
+	buf           *bufio.Writer
+	json          *json.Encoder
+	flushInterval int
+	rows          int
+	started       bool
+}
+
+func newStream(w http.ResponseWriter, params exportParams, flushInterval int) *stream {
+	s := &stream{
+		w:             w,
+		params:        params,
+		columns:       farmColumns,
+		flushInterval: flushInterval,
+	}
+	if params.entity == entityPonds {
+		s.columns = pondColumns
+	}
+	if params.format == formatNDJSON {
+		s.buf = bufio.NewWriter(w)
+		s.json = json.NewEncoder(s.buf)
+	} else {
+		s.csv = csv.NewWriter(w)
+	}
+	return s
+}
+
+// start is func to write status, header and the csv header row
+func (s *stream) start() error {
+	s.started = true
+	s.w.Header().Set("Content-Type", contentType[s.params.format])
+	s.w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.%s"`, s.params.entity, s.params.format))
+	s.w.Header().Set("Trailer", TrailerComplete+", "+TrailerError)
+	s.w.WriteHeader(http.StatusOK)
+
+	if s.csv != nil {
+		return s.csv.Write(s.columns)
+	}
+	return nil
+}
+
+// write is func to write a row, the file is flushed to the client every flush interval rows
+func (s *stream) write(row exportRow) error {
+	if !s.started {
+		if err := s.start(); err != nil {
+			return err
+		}
+	}
+
+	var err error
+	if s.csv != nil {
+		err = s.csv.Write(row.record())
+	} else {
+		err = s.json.Encode(row)
+	}
+	if err != nil {
+		return err
+	}
+
+	s.rows++
+	if s.rows%s.flushInterval == 0 {
+		return s.flush()
+	}
+	return nil
+}
+
+// close is func to flush the rest of the file, the file without row only has the csv header row
+func (s *stream) close() error {
+	if !s.started {
+		if err := s.start(); err != nil {
+			return err
+		}
+	}
+	return s.flush()
+}
+
+// finish is func to mark the started file complete or cut by err in the trailer,
+// the ndjson file that is cut also has the error in the last line
+func (s *stream) finish(err error) {
+	if err == nil {
+		s.w.Header().Set(TrailerComplete, "true")
+		return
+	}
+
+	e := apperror.From(err)
+	s.w.Header().Set(TrailerComplete, "false")
+	s.w.Header().Set(TrailerError, e.Message)
+	if s.json != nil {
+		if errEncode := s.json.Encode(exportError{Error: exportErrorDetail{Code: e.Code, Message: e.Message}}); errEncode == nil {
+			s.flush()
+		}
+	}
+}
+
+func (s *stream) flush() error {
+	var err error
+	if s.csv != nil {
+		s.csv.Flush()
+		err = s.csv.Error()
+	} else {
+		err = s.buf.Flush()
+	}
+	if err != nil {
+		return err
+	}
+
+	if flusher, ok := s.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+	return nil
+}
+
+func formatID(id uint) string {
+	if id == 0 {
+		return ""
+	}
+	return strconv.FormatUint(uint64(id), 10)
+}
+
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+func formatTime(value time.Time) string {
+	return value.Format(time.RFC3339)
+}
@@ -0,0 +1,232 @@
+package exporter
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"aqua-farm-manager/internal/infrastructure/farm"
+	"aqua-farm-manager/internal/infrastructure/pond"
+	"aqua-farm-manager/internal/model"
+	"aqua-farm-manager/pkg/apperror"
+	utilhttp "aqua-farm-manager/pkg/utilhttp"
+)
+
+// list entity of export file
+const (
+	entityFarms = "farms"
+	entityPonds = "ponds"
+)
+
+// list status filter of export, all include the deleted rows as history
+const (
+	statusActive   = "active"
+	statusInactive = "inactive"
+	statusAll      = "all"
+)
+
+// statusFilter is status of the store for every status filter
+var statusFilter = map[string]model.Status{
+	statusActive:   model.Active,
+	statusInactive: model.Inactive,
+	statusAll:      model.Unknown,
+}
+
+// farmColumns is list column of farm csv file
+var farmColumns = []string{"id", "name", "location", "owner", "area", "status", "created_at", "updated_at"}
+
+// pondColumns is list column of pond csv file, the farm is the farm of the pond in farm ponds mapping
+var pondColumns = []string{"id", "name", "capacity", "depth", "water_quality", "species", "status", "farm_id", "farm_name", "created_at", "updated_at"}
+
+// ExportFarmRow is a farm row of export file
+type ExportFarmRow struct {
+	ID        uint      `json:"id"`
+	Name      string    `json:"name"`
+	Location  string    `json:"location"`
+	Owner     string    `json:"owner"`
+	Area      string    `json:"area"`
+	Status    string    `json:"status"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func (r ExportFarmRow) record() []string {
+	return []string{
+		formatID(r.ID), r.Name, r.Location, r.Owner, r.Area, r.Status,
+		formatTime(r.CreatedAt), formatTime(r.UpdatedAt),
+	}
+}
+
+// ExportPondRow is a pond row of export file, the farm is empty when the pond has no farm
+type ExportPondRow struct {
+	ID           uint      `json:"id"`
+	Name         string    `json:"name"`
+	Capacity     float64   `json:"capacity"`
+	Depth        float64   `json:"depth"`
+	WaterQuality float64   `json:"water_quality"`
+	Species      string    `json:"species"`
+	Status       string    `json:"status"`
+	FarmID       uint      `json:"farm_id,omitempty"`
+	FarmName     string    `json:"farm_name,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+func (r ExportPondRow) record() []string {
+	return []string{
+		formatID(r.ID), r.Name, formatFloat(r.Capacity), formatFloat(r.Depth), formatFloat(r.WaterQuality),
+		r.Species, r.Status, formatID(r.FarmID), r.FarmName, formatTime(r.CreatedAt), formatTime(r.UpdatedAt),
+	}
+}
+
+// exportParams is list query param of Export Api
+type exportParams struct {
+	entity string
+	format string
+	status string
+}
+
+// ExportHandler is func handler for export farms or ponds into csv or ndjson file, the rows is written
+// while it is read from the store so the error after the first row cut the file and it is marked in the trailer.
+// The timeout only apply until the first row is read, the cursor then run as long as the client read the file
+func (h *ExportHandler) ExportHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+	firstRow := time.AfterFunc(time.Duration(h.timeoutInSec)*time.Second, cancel)
+	defer firstRow.Stop()
+
+	// checking valid param
+	params, err := getExportParams(r)
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+
+	s := newStream(w, params, h.flushInterval)
+	write := func(row exportRow) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		firstRow.Stop()
+		return s.write(row)
+	}
+	if params.entity == entityFarms {
+		err = h.farmstore.Export(ctx, statusFilter[params.status], func(info farm.ExportFarmInfo) error {
+			return write(mapFarmRow(info))
+		})
+	} else {
+		err = h.pondstore.Export(ctx, statusFilter[params.status], func(info pond.ExportPondInfo) error {
+			return write(mapPondRow(info))
+		})
+	}
+	if err == nil {
+		err = s.close()
+	}
+	if s.started {
+		if err != nil {
+			log.Println("[ExportHandler]-Error Stream Rows :", err)
+		}
+		s.finish(err)
+		return
+	}
+	if err != nil && ctx.Err() != nil {
+		err = apperror.Wrap(apperror.CodeTimeout, apperror.MessageTimeout, err)
+	}
+	if err != nil {
+		writeError(w, err)
+	}
+}
+
+// getExportParams is func to get query param with the default format csv and the default status active
+func getExportParams(r *http.Request) (exportParams, error) {
+	query := r.URL.Query()
+	params := exportParams{
+		entity: query.Get("entity"),
+		format: query.Get("format"),
+		status: query.Get("status"),
+	}
+	if params.format == "" {
+		params.format = formatCSV
+	}
+	if params.status == "" {
+		params.status = statusActive
+	}
+
+	var details []apperror.Detail
+	if params.entity != entityFarms && params.entity != entityPonds {
+		details = append(details, apperror.Field("entity", "entity must be farms or ponds"))
+	}
+	if params.format != formatCSV && params.format != formatNDJSON {
+		details = append(details, apperror.Field("format", "format must be csv or ndjson"))
+	}
+	if _, ok := statusFilter[params.status]; !ok {
+		details = append(details, apperror.Field("status", "status must be active, inactive or all"))
+	}
+
+	if len(details) > 0 {
+		return params, apperror.Validation(details...)
+	}
+	return params, nil
+}
+
+// writeError is func to write error in standard response, it is only used before the first row is written
+func writeError(w http.ResponseWriter, err error) {
+	var response utilhttp.StandardResponse
+	code := apperror.HTTPStatus(err)
+	if code == http.StatusInternalServerError {
+		log.Println("[ExportHandler]-Internal Error :", err)
+	}
+	response.SetError(err)
+	response.Code = code
+
+	data, errMarshal := json.Marshal(response)
+	if errMarshal != nil {
+		log.Println("[ExportHandler]-Error Marshal Response :", err)
+		code = http.StatusInternalServerError
+		data = []byte(`{"code":500,"message":"Internal Server Error"}`)
+	}
+	utilhttp.WriteResponse(w, data, code)
+}
+
+func mapFarmRow(info farm.ExportFarmInfo) ExportFarmRow {
+	return ExportFarmRow{
+		ID:        info.ID,
+		Name:      info.Name,
+		Location:  info.Location,
+		Owner:     info.Owner,
+		Area:      info.Area,
+		Status:    statusName(info.Status),
+		CreatedAt: info.CreatedAt,
+		UpdatedAt: info.UpdatedAt,
+	}
+}
+
+func mapPondRow(info pond.ExportPondInfo) ExportPondRow {
+	return ExportPondRow{
+		ID:           info.ID,
+		Name:         info.Name,
+		Capacity:     info.Capacity,
+		Depth:        info.Depth,
+		WaterQuality: info.WaterQuality,
+		Species:      info.Species,
+		Status:       statusName(info.Status),
+		FarmID:       info.FarmID,
+		FarmName:     info.FarmName,
+		CreatedAt:    info.CreatedAt,
+		UpdatedAt:    info.UpdatedAt,
+	}
+}
+
+// statusName is func to get name of stored status, the deleted row is inactive
+func statusName(status int) string {
+	switch model.Status(status) {
+	case model.Active:
+		return statusActive
+	case model.Inactive:
+		return statusInactive
+	}
+	return fmt.Sprint(status)
+}
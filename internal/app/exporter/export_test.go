@@ -0,0 +1,253 @@
+package exporter
+
+import (
+	"aqua-farm-manager/internal/infrastructure/farm"
+	"aqua-farm-manager/internal/infrastructure/farm/mock_farm"
+	"aqua-farm-manager/internal/infrastructure/pond"
+	"aqua-farm-manager/internal/infrastructure/pond/mock_pond"
+	"aqua-farm-manager/internal/model"
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestExportHandler_ExportHandler(t *testing.T) {
+	created := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	farms := []farm.ExportFarmInfo{
+		{ID: 1, Name: "farm a", Location: "bandung", Owner: "budi", Area: "10 ha", Status: 1, CreatedAt: created, UpdatedAt: created},
+		{ID: 2, Name: "farm, b", Status: 2, CreatedAt: created, UpdatedAt: created},
+	}
+	ponds := []pond.ExportPondInfo{
+		{ID: 3, Name: "pond a", Capacity: 1.5, Depth: 2, WaterQuality: 7, Species: "nila", Status: 1, FarmID: 1, FarmName: "farm a", CreatedAt: created, UpdatedAt: created},
+		{ID: 4, Name: "pond b", Status: 2, CreatedAt: created, UpdatedAt: created},
+	}
+	exportFarms := func(list []farm.ExportFarmInfo, err error) func(ctx context.Context, status model.Status, fn func(farm.ExportFarmInfo) error) error {
+		return func(ctx context.Context, status model.Status, fn func(farm.ExportFarmInfo) error) error {
+			for _, info := range list {
+				if err := fn(info); err != nil {
+					return err
+				}
+			}
+			return err
+		}
+	}
+	exportPonds := func(list []pond.ExportPondInfo) func(ctx context.Context, status model.Status, fn func(pond.ExportPondInfo) error) error {
+		return func(ctx context.Context, status model.Status, fn func(pond.ExportPondInfo) error) error {
+			for _, info := range list {
+				if err := fn(info); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+
+	type want struct {
+		body        string
+		code        int
+		contentType string
+		complete    string
+		exportErr   string
+	}
+	tests := []struct {
+		name     string
+		query    string
+		options  []Option
+		mockFunc func(farmStore *mock_farm.MockFarmStore, pondStore *mock_pond.MockPondStore)
+		want     want
+	}{
+		{
+			name:  "csv export of active farms by default flow",
+			query: "?entity=farms",
+			mockFunc: func(farmStore *mock_farm.MockFarmStore, pondStore *mock_pond.MockPondStore) {
+				farmStore.EXPECT().Export(gomock.Any(), model.Active, gomock.Any()).DoAndReturn(exportFarms(farms[:1], nil))
+			},
+			want: want{
+				body: "id,name,location,owner,area,status,created_at,updated_at\n" +
+					"1,farm a,bandung,budi,10 ha,active,2023-01-02T03:04:05Z,2023-01-02T03:04:05Z\n",
+				code:        200,
+				contentType: "text/csv; charset=utf-8",
+				complete:    "true",
+			},
+		},
+		{
+			name:  "csv export of every farm with quoted field flow",
+			query: "?entity=farms&status=all",
+			mockFunc: func(farmStore *mock_farm.MockFarmStore, pondStore *mock_pond.MockPondStore) {
+				farmStore.EXPECT().Export(gomock.Any(), model.Unknown, gomock.Any()).DoAndReturn(exportFarms(farms, nil))
+			},
+			want: want{
+				body: "id,name,location,owner,area,status,created_at,updated_at\n" +
+					"1,farm a,bandung,budi,10 ha,active,2023-01-02T03:04:05Z,2023-01-02T03:04:05Z\n" +
+					"2,\"farm, b\",,,,inactive,2023-01-02T03:04:05Z,2023-01-02T03:04:05Z\n",
+				code:        200,
+				contentType: "text/csv; charset=utf-8",
+				complete:    "true",
+			},
+		},
+		{
+			name:  "csv export without row only has header flow",
+			query: "?entity=ponds&status=inactive",
+			mockFunc: func(farmStore *mock_farm.MockFarmStore, pondStore *mock_pond.MockPondStore) {
+				pondStore.EXPECT().Export(gomock.Any(), model.Inactive, gomock.Any()).Return(nil)
+			},
+			want: want{
+				body:        "id,name,capacity,depth,water_quality,species,status,farm_id,farm_name,created_at,updated_at\n",
+				code:        200,
+				contentType: "text/csv; charset=utf-8",
+				complete:    "true",
+			},
+		},
+		{
+			name:  "ndjson export of ponds with farm name flow",
+			query: "?entity=ponds&format=ndjson&status=all",
+			mockFunc: func(farmStore *mock_farm.MockFarmStore, pondStore *mock_pond.MockPondStore) {
+				pondStore.EXPECT().Export(gomock.Any(), model.Unknown, gomock.Any()).DoAndReturn(exportPonds(ponds))
+			},
+			want: want{
+				body: `{"id":3,"name":"pond a","capacity":1.5,"depth":2,"water_quality":7,"species":"nila","status":"active","farm_id":1,"farm_name":"farm a","created_at":"2023-01-02T03:04:05Z","updated_at":"2023-01-02T03:04:05Z"}` + "\n" +
+					`{"id":4,"name":"pond b","capacity":0,"depth":0,"water_quality":0,"species":"","status":"inactive","created_at":"2023-01-02T03:04:05Z","updated_at":"2023-01-02T03:04:05Z"}` + "\n",
+				code:        200,
+				contentType: "application/x-ndjson",
+				complete:    "true",
+			},
+		},
+		{
+			name:     "invalid param flow",
+			query:    "?entity=stats&format=xml&status=deleted",
+			mockFunc: func(farmStore *mock_farm.MockFarmStore, pondStore *mock_pond.MockPondStore) {},
+			want: want{
+				body: `{"code":422,"message":"Invalid Parameter Request","errors":[` +
+					`{"code":"VALIDATION_FAILED","field":"entity","message":"entity must be farms or ponds"},` +
+					`{"code":"VALIDATION_FAILED","field":"format","message":"format must be csv or ndjson"},` +
+					`{"code":"VALIDATION_FAILED","field":"status","message":"status must be active, inactive or all"}]}`,
+				code:        422,
+				contentType: "application/json",
+			},
+		},
+		{
+			name:  "error before the first row flow",
+			query: "?entity=farms",
+			mockFunc: func(farmStore *mock_farm.MockFarmStore, pondStore *mock_pond.MockPondStore) {
+				farmStore.EXPECT().Export(gomock.Any(), model.Active, gomock.Any()).Return(errors.New("some error"))
+			},
+			want: want{
+				body:        `{"code":500,"message":"Internal Server Error","errors":[{"code":"INTERNAL_ERROR","message":"Internal Server Error"}]}`,
+				code:        500,
+				contentType: "application/json",
+			},
+		},
+		{
+			name:  "error after the first row cut the file flow",
+			query: "?entity=farms&status=all",
+			mockFunc: func(farmStore *mock_farm.MockFarmStore, pondStore *mock_pond.MockPondStore) {
+				farmStore.EXPECT().Export(gomock.Any(), model.Unknown, gomock.Any()).DoAndReturn(exportFarms(farms[:1], errors.New("some error")))
+			},
+			want: want{
+				body: "id,name,location,owner,area,status,created_at,updated_at\n" +
+					"1,farm a,bandung,budi,10 ha,active,2023-01-02T03:04:05Z,2023-01-02T03:04:05Z\n",
+				code:        200,
+				contentType: "text/csv; charset=utf-8",
+				complete:    "false",
+				exportErr:   "Internal Server Error",
+			},
+		},
+		{
+			name:  "error after the first row cut the ndjson file with error line flow",
+			query: "?entity=farms&format=ndjson",
+			mockFunc: func(farmStore *mock_farm.MockFarmStore, pondStore *mock_pond.MockPondStore) {
+				farmStore.EXPECT().Export(gomock.Any(), model.Active, gomock.Any()).DoAndReturn(exportFarms(farms[:1], errors.New("some error")))
+			},
+			want: want{
+				body: `{"id":1,"name":"farm a","location":"bandung","owner":"budi","area":"10 ha","status":"active","created_at":"2023-01-02T03:04:05Z","updated_at":"2023-01-02T03:04:05Z"}` + "\n" +
+					`{"error":{"code":"INTERNAL_ERROR","message":"Internal Server Error"}}` + "\n",
+				code:        200,
+				contentType: "application/x-ndjson",
+				complete:    "false",
+				exportErr:   "Internal Server Error",
+			},
+		},
+		{
+			name:    "timeout before the first row flow",
+			query:   "?entity=farms",
+			options: []Option{WithTimeoutOptions(1)},
+			mockFunc: func(farmStore *mock_farm.MockFarmStore, pondStore *mock_pond.MockPondStore) {
+				farmStore.EXPECT().Export(gomock.Any(), model.Active, gomock.Any()).DoAndReturn(
+					func(ctx context.Context, status model.Status, fn func(farm.ExportFarmInfo) error) error {
+						<-ctx.Done()
+						return ctx.Err()
+					})
+			},
+			want: want{
+				body:        `{"code":504,"message":"Timeout","errors":[{"code":"TIMEOUT","message":"Timeout"}]}`,
+				code:        504,
+				contentType: "application/json",
+			},
+		},
+		{
+			name:    "slow cursor after the first row is not cut by timeout flow",
+			query:   "?entity=farms&status=all",
+			options: []Option{WithTimeoutOptions(1)},
+			mockFunc: func(farmStore *mock_farm.MockFarmStore, pondStore *mock_pond.MockPondStore) {
+				farmStore.EXPECT().Export(gomock.Any(), model.Unknown, gomock.Any()).DoAndReturn(
+					func(ctx context.Context, status model.Status, fn func(farm.ExportFarmInfo) error) error {
+						if err := fn(farms[0]); err != nil {
+							return err
+						}
+						time.Sleep(1200 * time.Millisecond)
+						return fn(farms[1])
+					})
+			},
+			want: want{
+				body: "id,name,location,owner,area,status,created_at,updated_at\n" +
+					"1,farm a,bandung,budi,10 ha,active,2023-01-02T03:04:05Z,2023-01-02T03:04:05Z\n" +
+					"2,\"farm, b\",,,,inactive,2023-01-02T03:04:05Z,2023-01-02T03:04:05Z\n",
+				code:        200,
+				contentType: "text/csv; charset=utf-8",
+				complete:    "true",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			farmStore := mock_farm.NewMockFarmStore(mockCtrl)
+			pondStore := mock_pond.NewMockPondStore(mockCtrl)
+			tt.mockFunc(farmStore, pondStore)
+
+			h := NewExportHandler(farmStore, pondStore, append(tt.options, WithFlushIntervalOptions(1))...)
+			r := httptest.NewRequest("GET", "/v1/export"+tt.query, nil)
+			w := httptest.NewRecorder()
+			h.ExportHandler(w, r)
+
+			res := w.Result()
+			defer res.Body.Close()
+			data, _ := ioutil.ReadAll(res.Body)
+			if string(data) != tt.want.body {
+				t.Errorf("ExportHandler.ExportHandler() body = %v, want %v", string(data), tt.want.body)
+			}
+			if res.StatusCode != tt.want.code {
+				t.Errorf("ExportHandler.ExportHandler() code = %v, want %v", res.StatusCode, tt.want.code)
+			}
+			if got := res.Header.Get("Content-Type"); got != tt.want.contentType {
+				t.Errorf("ExportHandler.ExportHandler() content type = %v, want %v", got, tt.want.contentType)
+			}
+			if got := res.Trailer.Get(TrailerComplete); got != tt.want.complete {
+				t.Errorf("ExportHandler.ExportHandler() complete trailer = %v, want %v", got, tt.want.complete)
+			}
+			if got := res.Trailer.Get(TrailerError); got != tt.want.exportErr {
+				t.Errorf("ExportHandler.ExportHandler() error trailer = %v, want %v", got, tt.want.exportErr)
+			}
+			if tt.want.code == http.StatusOK && !w.Flushed {
+				t.Errorf("ExportHandler.ExportHandler() is not flushed")
+			}
+		})
+	}
+}
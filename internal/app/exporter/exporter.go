@@ -0,0 +1,63 @@
+package exporter
+
+import (
+	"aqua-farm-manager/internal/infrastructure/farm"
+	"aqua-farm-manager/internal/infrastructure/pond"
+)
+
+// ExportHandler list dependencies for export handler, the rows is streamed from the store without domain
+// because export only read the stored data
+type ExportHandler struct {
+	farmstore     farm.FarmStore
+	pondstore     pond.PondStore
+	timeoutInSec  int
+	flushInterval int
+}
+
+// Option set options for http handler config
+type Option func(*ExportHandler)
+
+const (
+	// defaultTimeout is the time to read the first row, the rest of the file is not limited
+	defaultTimeout       = 60
+	defaultFlushInterval = 100
+)
+
+// NewExportHandler is func to create http export handler
+func NewExportHandler(farmstore farm.FarmStore, pondstore pond.PondStore, options ...Option) *ExportHandler {
+	handler := &ExportHandler{
+		farmstore:     farmstore,
+		pondstore:     pondstore,
+		timeoutInSec:  defaultTimeout,
+		flushInterval: defaultFlushInterval,
+	}
+
+	// Apply options
+	for _, opt := range options {
+		opt(handler)
+	}
+
+	return handler
+}
+
+// WithTimeoutOptions is func to set timeout config into handler
+func WithTimeoutOptions(timeoutinsec int) Option {
+	return Option(
+		func(h *ExportHandler) {
+			if timeoutinsec <= 0 {
+				timeoutinsec = defaultTimeout
+			}
+			h.timeoutInSec = timeoutinsec
+		})
+}
+
+// WithFlushIntervalOptions is func to set number of row that is written before it is flushed to the client
+func WithFlushIntervalOptions(rows int) Option {
+	return Option(
+		func(h *ExportHandler) {
+			if rows <= 0 {
+				rows = defaultFlushInterval
+			}
+			h.flushInterval = rows
+		})
+}
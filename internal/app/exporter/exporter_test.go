@@ -0,0 +1,70 @@
+package exporter
+
+import (
+	"aqua-farm-manager/internal/infrastructure/farm"
+	"aqua-farm-manager/internal/infrastructure/pond"
+	"reflect"
+	"testing"
+)
+
+func TestNewExportHandler(t *testing.T) {
+	type args struct {
+		farmstore farm.FarmStore
+		pondstore pond.PondStore
+		options   []Option
+	}
+	tests := []struct {
+		name string
+		args args
+		want *ExportHandler
+	}{
+		{
+			name: "success with setting flow",
+			args: args{
+				farmstore: &farm.Farm{},
+				pondstore: &pond.Pond{},
+				options:   []Option{WithTimeoutOptions(10), WithFlushIntervalOptions(50)},
+			},
+			want: &ExportHandler{
+				farmstore:     &farm.Farm{},
+				pondstore:     &pond.Pond{},
+				timeoutInSec:  10,
+				flushInterval: 50,
+			},
+		},
+		{
+			name: "success without option flow",
+			args: args{
+				farmstore: &farm.Farm{},
+				pondstore: &pond.Pond{},
+			},
+			want: &ExportHandler{
+				farmstore:     &farm.Farm{},
+				pondstore:     &pond.Pond{},
+				timeoutInSec:  60,
+				flushInterval: 100,
+			},
+		},
+		{
+			name: "success with invalid setting flow",
+			args: args{
+				farmstore: &farm.Farm{},
+				pondstore: &pond.Pond{},
+				options:   []Option{WithTimeoutOptions(-1), WithFlushIntervalOptions(0)},
+			},
+			want: &ExportHandler{
+				farmstore:     &farm.Farm{},
+				pondstore:     &pond.Pond{},
+				timeoutInSec:  60,
+				flushInterval: 100,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewExportHandler(tt.args.farmstore, tt.args.pondstore, tt.args.options...); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewExportHandler() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,22 +3,23 @@ package farm
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 	"io/ioutil"
 	"log"
 	"net/http"
 	"time"
 
 	"aqua-farm-manager/internal/domain/farm"
+	"aqua-farm-manager/pkg/apperror"
 	utilhttp "aqua-farm-manager/pkg/utilhttp"
+	"aqua-farm-manager/pkg/validator"
 )
 
 // CreateFarmRequest is list request parameter for Create Api
 type CreateFarmRequest struct {
-	Name     string `json:"name"`
-	Location string `json:"location"`
-	Owner    string `json:"owner"`
-	Area     string `json:"area"`
+	Name     string `json:"name" validate:"required,max=100,charset=name"`
+	Location string `json:"location" validate:"max=200,charset=text"`
+	Owner    string `json:"owner" validate:"max=100,charset=name"`
+	Area     string `json:"area" validate:"max=50,charset=text"`
 }
 
 // CreateFarmResponse is list response parameter for Create Api
@@ -36,12 +37,16 @@ func (h *FarmHandler) CreateFarmHandler(w http.ResponseWriter, r *http.Request)
 	var code int = http.StatusOK
 
 	defer func() {
-		response.Code = code
-		if err == nil {
-			response.Message = "success"
+		if err != nil {
+			code = apperror.HTTPStatus(err)
+			if code == http.StatusInternalServerError {
+				log.Println("[CreateFarmHandler]-Internal Error :", err)
+			}
+			response.SetError(err)
 		} else {
-			response.Message = err.Error()
+			response.Message = "success"
 		}
+		response.Code = code
 
 		data, errMarshal := json.Marshal(response)
 		if errMarshal != nil {
@@ -55,51 +60,35 @@ func (h *FarmHandler) CreateFarmHandler(w http.ResponseWriter, r *http.Request)
 	var body CreateFarmRequest
 	data, err := ioutil.ReadAll(r.Body)
 	if err != nil {
-		code = http.StatusBadRequest
-		err = fmt.Errorf("Bad Request")
+		err = apperror.New(apperror.CodeBadRequest, apperror.MessageBadRequest)
 		return
 	}
 
 	err = json.Unmarshal(data, &body)
 	if err != nil {
-		code = http.StatusBadRequest
-		err = fmt.Errorf("Bad Request")
+		err = apperror.New(apperror.CodeBadRequest, apperror.MessageBadRequest)
 		return
 	}
 
 	// checking valid body
-	if len(body.Name) < 1 {
-		code = http.StatusBadRequest
-		err = fmt.Errorf("Invalid Parameter Request")
+	err = validator.Validate(body)
+	if err != nil {
 		return
 	}
 
-	errChan := make(chan error, 1)
 	var res farm.CreateDomainResponse
-	go func(ctx context.Context) {
-		res, err = h.domain.CreateFarmInfo(farm.CreateDomainRequest{
-			Name:     body.Name,
-			Location: body.Location,
-			Owner:    body.Owner,
-			Area:     body.Area,
-		})
-		errChan <- err
-	}(ctx)
-
-	select {
-	case <-ctx.Done():
-		code = http.StatusGatewayTimeout
-		err = fmt.Errorf("Timeout")
+	res, err = h.domain.CreateFarmInfo(ctx, farm.CreateDomainRequest{
+		Name:     body.Name,
+		Location: body.Location,
+		Owner:    body.Owner,
+		Area:     body.Area,
+	})
+	if err != nil && ctx.Err() != nil {
+		err = apperror.Wrap(apperror.CodeTimeout, apperror.MessageTimeout, err)
+		return
+	}
+	if err != nil {
 		return
-	case err = <-errChan:
-		if err != nil {
-			if err == farm.ErrDuplicateFarm {
-				code = http.StatusConflict
-			} else {
-				code = http.StatusInternalServerError
-			}
-			return
-		}
 	}
 
 	response = mapResonseCreate(res)
@@ -40,7 +40,7 @@ func TestFarmHandler_CreateFarmHandler(t *testing.T) {
 				return context.Background(), func() {}
 			},
 			mockFunc: func(farmDomain mock_farm.MockFarmDomain) {
-				farmDomain.EXPECT().CreateFarmInfo(gomock.Any()).Return(farm.CreateDomainResponse{
+				farmDomain.EXPECT().CreateFarmInfo(gomock.Any(), gomock.Any()).Return(farm.CreateDomainResponse{
 					ID: 1,
 				}, nil)
 			},
@@ -59,10 +59,10 @@ func TestFarmHandler_CreateFarmHandler(t *testing.T) {
 				return context.Background(), func() {}
 			},
 			mockFunc: func(farmDomain mock_farm.MockFarmDomain) {
-				farmDomain.EXPECT().CreateFarmInfo(gomock.Any()).Return(farm.CreateDomainResponse{}, nil).AnyTimes()
+				farmDomain.EXPECT().CreateFarmInfo(gomock.Any(), gomock.Any()).Return(farm.CreateDomainResponse{}, context.DeadlineExceeded).AnyTimes()
 			},
 			want: want{
-				body: `{"code":504,"message":"Timeout"}`,
+				body: `{"code":504,"message":"Timeout","errors":[{"code":"TIMEOUT","message":"Timeout"}]}`,
 				code: 504,
 			},
 		},
@@ -76,10 +76,10 @@ func TestFarmHandler_CreateFarmHandler(t *testing.T) {
 				return context.Background(), func() {}
 			},
 			mockFunc: func(farmDomain mock_farm.MockFarmDomain) {
-				farmDomain.EXPECT().CreateFarmInfo(gomock.Any()).Return(farm.CreateDomainResponse{}, fmt.Errorf("some error"))
+				farmDomain.EXPECT().CreateFarmInfo(gomock.Any(), gomock.Any()).Return(farm.CreateDomainResponse{}, fmt.Errorf("some error"))
 			},
 			want: want{
-				body: `{"code":500,"message":"some error"}`,
+				body: `{"code":500,"message":"Internal Server Error","errors":[{"code":"INTERNAL_ERROR","message":"Internal Server Error"}]}`,
 				code: 500,
 			},
 		},
@@ -95,8 +95,24 @@ func TestFarmHandler_CreateFarmHandler(t *testing.T) {
 			mockFunc: func(farmDomain mock_farm.MockFarmDomain) {
 			},
 			want: want{
-				body: `{"code":400,"message":"Invalid Parameter Request"}`,
-				code: 400,
+				body: `{"code":422,"message":"Invalid Parameter Request","errors":[{"code":"VALIDATION_FAILED","field":"name","message":"name is required"}]}`,
+				code: 422,
+			},
+		},
+		{
+			name: "every invalid field flow",
+			body: `{ "name": "` + strings.Repeat("a", 10240) + `", "location": "California", "owner": "Jane#Doe", "area": "7.5 Acres" }`,
+			args: args{
+				timeout: 10,
+			},
+			mockContext: func() (context.Context, func()) {
+				return context.Background(), func() {}
+			},
+			mockFunc: func(farmDomain mock_farm.MockFarmDomain) {
+			},
+			want: want{
+				body: `{"code":422,"message":"Invalid Parameter Request","errors":[{"code":"VALIDATION_FAILED","field":"name","message":"name must be at most 100 characters"},{"code":"VALIDATION_FAILED","field":"owner","message":"owner contains invalid character '#'"}]}`,
+				code: 422,
 			},
 		},
 		{
@@ -111,7 +127,7 @@ func TestFarmHandler_CreateFarmHandler(t *testing.T) {
 			mockFunc: func(farmDomain mock_farm.MockFarmDomain) {
 			},
 			want: want{
-				body: `{"code":400,"message":"Bad Request"}`,
+				body: `{"code":400,"message":"Bad Request","errors":[{"code":"BAD_REQUEST","message":"Bad Request"}]}`,
 				code: 400,
 			},
 		},
@@ -3,13 +3,13 @@ package farm
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 	"log"
 	"net/http"
 	"strconv"
 	"time"
 
 	"aqua-farm-manager/internal/domain/farm"
+	"aqua-farm-manager/pkg/apperror"
 	utilhttp "aqua-farm-manager/pkg/utilhttp"
 
 	"github.com/gorilla/mux"
@@ -32,12 +32,16 @@ func (h *FarmHandler) DeleteByIDFarmHandler(w http.ResponseWriter, r *http.Reque
 	var code int = http.StatusOK
 
 	defer func() {
-		response.Code = code
-		if err == nil {
-			response.Message = "success"
+		if err != nil {
+			code = apperror.HTTPStatus(err)
+			if code == http.StatusInternalServerError {
+				log.Println("[DeleteByIDFarmHandler]-Internal Error :", err)
+			}
+			response.SetError(err)
 		} else {
-			response.Message = err.Error()
+			response.Message = "success"
 		}
+		response.Code = code
 
 		data, errMarshal := json.Marshal(response)
 		if errMarshal != nil {
@@ -51,33 +55,19 @@ func (h *FarmHandler) DeleteByIDFarmHandler(w http.ResponseWriter, r *http.Reque
 	// checking valid body
 	vars := mux.Vars(r)
 	id, err := strconv.Atoi(vars["id"])
-	if err != nil {
-		code = http.StatusBadRequest
-		err = fmt.Errorf("Invalid Parameter Request")
+	if err != nil || id < 1 {
+		err = apperror.Validation(apperror.Field("id", "id must be a positive number"))
 		return
 	}
 
-	errChan := make(chan error, 1)
 	var res farm.DeleteAllResponse
-	go func(ctx context.Context) {
-		res, err = h.domain.DeleteFarmsWithDependencies(uint(id))
-		errChan <- err
-	}(ctx)
-
-	select {
-	case <-ctx.Done():
-		code = http.StatusGatewayTimeout
-		err = fmt.Errorf("Timeout")
+	res, err = h.domain.DeleteFarmsWithDependencies(ctx, uint(id))
+	if err != nil && ctx.Err() != nil {
+		err = apperror.Wrap(apperror.CodeTimeout, apperror.MessageTimeout, err)
+		return
+	}
+	if err != nil {
 		return
-	case err = <-errChan:
-		if err != nil {
-			if err == farm.ErrInvalidFarm {
-				code = http.StatusNotFound
-			} else {
-				code = http.StatusInternalServerError
-			}
-			return
-		}
 	}
 
 	response = mapResonseDeleteByID(res)
@@ -42,7 +42,7 @@ func TestFarmHandler_DeleteByIDFarmHandler(t *testing.T) {
 				return context.Background(), func() {}
 			},
 			mockFunc: func(farmDomain mock_farm.MockFarmDomain) {
-				farmDomain.EXPECT().DeleteFarmsWithDependencies(gomock.Any()).Return(farm.DeleteAllResponse{
+				farmDomain.EXPECT().DeleteFarmsWithDependencies(gomock.Any(), gomock.Any()).Return(farm.DeleteAllResponse{
 					ID:      1,
 					Name:    "a",
 					PondIds: []uint{1, 2, 3},
@@ -63,10 +63,10 @@ func TestFarmHandler_DeleteByIDFarmHandler(t *testing.T) {
 				return context.Background(), func() {}
 			},
 			mockFunc: func(farmDomain mock_farm.MockFarmDomain) {
-				farmDomain.EXPECT().DeleteFarmsWithDependencies(gomock.Any()).Return(farm.DeleteAllResponse{}, nil).AnyTimes()
+				farmDomain.EXPECT().DeleteFarmsWithDependencies(gomock.Any(), gomock.Any()).Return(farm.DeleteAllResponse{}, context.DeadlineExceeded).AnyTimes()
 			},
 			want: want{
-				body: `{"code":504,"message":"Timeout"}`,
+				body: `{"code":504,"message":"Timeout","errors":[{"code":"TIMEOUT","message":"Timeout"}]}`,
 				code: 504,
 			},
 		},
@@ -80,10 +80,10 @@ func TestFarmHandler_DeleteByIDFarmHandler(t *testing.T) {
 				return context.Background(), func() {}
 			},
 			mockFunc: func(farmDomain mock_farm.MockFarmDomain) {
-				farmDomain.EXPECT().DeleteFarmsWithDependencies(gomock.Any()).Return(farm.DeleteAllResponse{}, farm.ErrInvalidFarm)
+				farmDomain.EXPECT().DeleteFarmsWithDependencies(gomock.Any(), gomock.Any()).Return(farm.DeleteAllResponse{}, farm.ErrInvalidFarm)
 			},
 			want: want{
-				body: `{"code":404,"message":"Farm Is Not Exists"}`,
+				body: `{"code":404,"message":"Farm Is Not Exists","errors":[{"code":"FARM_NOT_FOUND","message":"Farm Is Not Exists"}]}`,
 				code: 404,
 			},
 		},
@@ -97,10 +97,10 @@ func TestFarmHandler_DeleteByIDFarmHandler(t *testing.T) {
 				return context.Background(), func() {}
 			},
 			mockFunc: func(farmDomain mock_farm.MockFarmDomain) {
-				farmDomain.EXPECT().DeleteFarmsWithDependencies(gomock.Any()).Return(farm.DeleteAllResponse{}, fmt.Errorf("some error"))
+				farmDomain.EXPECT().DeleteFarmsWithDependencies(gomock.Any(), gomock.Any()).Return(farm.DeleteAllResponse{}, fmt.Errorf("some error"))
 			},
 			want: want{
-				body: `{"code":500,"message":"some error"}`,
+				body: `{"code":500,"message":"Internal Server Error","errors":[{"code":"INTERNAL_ERROR","message":"Internal Server Error"}]}`,
 				code: 500,
 			},
 		},
@@ -116,8 +116,8 @@ func TestFarmHandler_DeleteByIDFarmHandler(t *testing.T) {
 			mockFunc: func(farmDomain mock_farm.MockFarmDomain) {
 			},
 			want: want{
-				body: `{"code":400,"message":"Invalid Parameter Request"}`,
-				code: 400,
+				body: `{"code":422,"message":"Invalid Parameter Request","errors":[{"code":"VALIDATION_FAILED","field":"id","message":"id must be a positive number"}]}`,
+				code: 422,
 			},
 		},
 	}
@@ -3,20 +3,32 @@ package farm
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 	"io/ioutil"
 	"log"
 	"net/http"
 	"time"
 
 	"aqua-farm-manager/internal/domain/farm"
+	"aqua-farm-manager/pkg/apperror"
 	utilhttp "aqua-farm-manager/pkg/utilhttp"
+	"aqua-farm-manager/pkg/validator"
 )
 
 // DeleteFarmRequest is list request parameter for Delete Api
 type DeleteFarmRequest struct {
 	FarmID   uint   `json:"id"`
-	FarmName string `json:"name"`
+	FarmName string `json:"name" validate:"max=100,charset=name"`
+}
+
+// ValidateCrossField is func to check delete request choose either id or name
+func (r DeleteFarmRequest) ValidateCrossField() []apperror.Detail {
+	if len(r.FarmName) < 1 && r.FarmID < 1 {
+		return []apperror.Detail{apperror.Field("id", "id or name is required")}
+	}
+	if len(r.FarmName) >= 1 && r.FarmID > 0 {
+		return []apperror.Detail{apperror.Field("id", "Please Choose to delete by ID or Name")}
+	}
+	return nil
 }
 
 // DeleteFarmResponse is list response parameter for Delete Api
@@ -35,12 +47,16 @@ func (h *FarmHandler) DeleteFarmHandler(w http.ResponseWriter, r *http.Request)
 	var code int = http.StatusOK
 
 	defer func() {
-		response.Code = code
-		if err == nil {
-			response.Message = "success"
+		if err != nil {
+			code = apperror.HTTPStatus(err)
+			if code == http.StatusInternalServerError {
+				log.Println("[DeleteFarmHandler]-Internal Error :", err)
+			}
+			response.SetError(err)
 		} else {
-			response.Message = err.Error()
+			response.Message = "success"
 		}
+		response.Code = code
 
 		data, errMarshal := json.Marshal(response)
 		if errMarshal != nil {
@@ -54,57 +70,33 @@ func (h *FarmHandler) DeleteFarmHandler(w http.ResponseWriter, r *http.Request)
 	var body DeleteFarmRequest
 	data, err := ioutil.ReadAll(r.Body)
 	if err != nil {
-		code = http.StatusBadRequest
-		err = fmt.Errorf("Bad Request")
+		err = apperror.New(apperror.CodeBadRequest, apperror.MessageBadRequest)
 		return
 	}
 
 	err = json.Unmarshal(data, &body)
 	if err != nil {
-		code = http.StatusBadRequest
-		err = fmt.Errorf("Bad Request")
+		err = apperror.New(apperror.CodeBadRequest, apperror.MessageBadRequest)
 		return
 	}
 
 	// checking valid body
-	if len(body.FarmName) < 1 && body.FarmID < 1 {
-		code = http.StatusBadRequest
-		err = fmt.Errorf("Invalid Parameter Request")
+	err = validator.Validate(body)
+	if err != nil {
 		return
 	}
 
-	if len(body.FarmName) >= 1 && body.FarmID > 0 {
-		code = http.StatusBadRequest
-		err = fmt.Errorf("Please Choose to delete by ID or Name")
+	var res farm.DeleteDomainResponse
+	res, err = h.domain.DeleteFarmInfo(ctx, farm.DeleteDomainRequest{
+		Name: body.FarmName,
+		ID:   body.FarmID,
+	})
+	if err != nil && ctx.Err() != nil {
+		err = apperror.Wrap(apperror.CodeTimeout, apperror.MessageTimeout, err)
 		return
 	}
-
-	errChan := make(chan error, 1)
-	var res farm.DeleteDomainResponse
-	go func(ctx context.Context) {
-		res, err = h.domain.DeleteFarmInfo(farm.DeleteDomainRequest{
-			Name: body.FarmName,
-			ID:   body.FarmID,
-		})
-		errChan <- err
-	}(ctx)
-
-	select {
-	case <-ctx.Done():
-		code = http.StatusGatewayTimeout
-		err = fmt.Errorf("Timeout")
+	if err != nil {
 		return
-	case err = <-errChan:
-		if err != nil {
-			if err == farm.ErrInvalidFarm {
-				code = http.StatusNotFound
-			} else if err == farm.ErrExistsPonds {
-				code = http.StatusConflict
-			} else {
-				code = http.StatusInternalServerError
-			}
-			return
-		}
 	}
 
 	response = mapResonseDelete(res)
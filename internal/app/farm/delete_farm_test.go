@@ -40,7 +40,7 @@ func TestFarmHandler_DeleteFarmHandler(t *testing.T) {
 				return context.Background(), func() {}
 			},
 			mockFunc: func(farmDomain mock_farm.MockFarmDomain) {
-				farmDomain.EXPECT().DeleteFarmInfo(gomock.Any()).Return(farm.DeleteDomainResponse{
+				farmDomain.EXPECT().DeleteFarmInfo(gomock.Any(), gomock.Any()).Return(farm.DeleteDomainResponse{
 					Name: "a",
 					ID:   1,
 				}, nil)
@@ -60,13 +60,13 @@ func TestFarmHandler_DeleteFarmHandler(t *testing.T) {
 				return context.Background(), func() {}
 			},
 			mockFunc: func(farmDomain mock_farm.MockFarmDomain) {
-				farmDomain.EXPECT().DeleteFarmInfo(gomock.Any()).Return(farm.DeleteDomainResponse{
+				farmDomain.EXPECT().DeleteFarmInfo(gomock.Any(), gomock.Any()).Return(farm.DeleteDomainResponse{
 					Name: "a",
 					ID:   1,
-				}, nil).AnyTimes()
+				}, context.DeadlineExceeded).AnyTimes()
 			},
 			want: want{
-				body: `{"code":504,"message":"Timeout"}`,
+				body: `{"code":504,"message":"Timeout","errors":[{"code":"TIMEOUT","message":"Timeout"}]}`,
 				code: 504,
 			},
 		},
@@ -80,10 +80,10 @@ func TestFarmHandler_DeleteFarmHandler(t *testing.T) {
 				return context.Background(), func() {}
 			},
 			mockFunc: func(farmDomain mock_farm.MockFarmDomain) {
-				farmDomain.EXPECT().DeleteFarmInfo(gomock.Any()).Return(farm.DeleteDomainResponse{}, farm.ErrInvalidFarm)
+				farmDomain.EXPECT().DeleteFarmInfo(gomock.Any(), gomock.Any()).Return(farm.DeleteDomainResponse{}, farm.ErrInvalidFarm)
 			},
 			want: want{
-				body: `{"code":404,"message":"Farm Is Not Exists"}`,
+				body: `{"code":404,"message":"Farm Is Not Exists","errors":[{"code":"FARM_NOT_FOUND","message":"Farm Is Not Exists"}]}`,
 				code: 404,
 			},
 		},
@@ -97,10 +97,10 @@ func TestFarmHandler_DeleteFarmHandler(t *testing.T) {
 				return context.Background(), func() {}
 			},
 			mockFunc: func(farmDomain mock_farm.MockFarmDomain) {
-				farmDomain.EXPECT().DeleteFarmInfo(gomock.Any()).Return(farm.DeleteDomainResponse{}, fmt.Errorf("some error"))
+				farmDomain.EXPECT().DeleteFarmInfo(gomock.Any(), gomock.Any()).Return(farm.DeleteDomainResponse{}, fmt.Errorf("some error"))
 			},
 			want: want{
-				body: `{"code":500,"message":"some error"}`,
+				body: `{"code":500,"message":"Internal Server Error","errors":[{"code":"INTERNAL_ERROR","message":"Internal Server Error"}]}`,
 				code: 500,
 			},
 		},
@@ -116,8 +116,8 @@ func TestFarmHandler_DeleteFarmHandler(t *testing.T) {
 			mockFunc: func(farmDomain mock_farm.MockFarmDomain) {
 			},
 			want: want{
-				body: `{"code":400,"message":"Invalid Parameter Request"}`,
-				code: 400,
+				body: `{"code":422,"message":"Invalid Parameter Request","errors":[{"code":"VALIDATION_FAILED","field":"id","message":"id or name is required"}]}`,
+				code: 422,
 			},
 		},
 		{
@@ -135,8 +135,8 @@ func TestFarmHandler_DeleteFarmHandler(t *testing.T) {
 			mockFunc: func(farmDomain mock_farm.MockFarmDomain) {
 			},
 			want: want{
-				body: `{"code":400,"message":"Please Choose to delete by ID or Name"}`,
-				code: 400,
+				body: `{"code":422,"message":"Invalid Parameter Request","errors":[{"code":"VALIDATION_FAILED","field":"id","message":"Please Choose to delete by ID or Name"}]}`,
+				code: 422,
 			},
 		},
 		{
@@ -151,7 +151,7 @@ func TestFarmHandler_DeleteFarmHandler(t *testing.T) {
 			mockFunc: func(farmDomain mock_farm.MockFarmDomain) {
 			},
 			want: want{
-				body: `{"code":400,"message":"Bad Request"}`,
+				body: `{"code":400,"message":"Bad Request","errors":[{"code":"BAD_REQUEST","message":"Bad Request"}]}`,
 				code: 400,
 			},
 		},
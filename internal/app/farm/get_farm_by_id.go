@@ -3,14 +3,13 @@ package farm
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 	"log"
 	"net/http"
 	"strconv"
-	"strings"
 	"time"
 
 	"aqua-farm-manager/internal/domain/farm"
+	"aqua-farm-manager/pkg/apperror"
 	utilhttp "aqua-farm-manager/pkg/utilhttp"
 
 	"github.com/gorilla/mux"
@@ -46,12 +45,16 @@ func (h *FarmHandler) GetByIDFarmHandler(w http.ResponseWriter, r *http.Request)
 	var code int = http.StatusOK
 
 	defer func() {
-		response.Code = code
-		if err == nil {
-			response.Message = "success"
+		if err != nil {
+			code = apperror.HTTPStatus(err)
+			if code == http.StatusInternalServerError {
+				log.Println("[GetByIDFarmHandler]-Internal Error :", err)
+			}
+			response.SetError(err)
 		} else {
-			response.Message = err.Error()
+			response.Message = "success"
 		}
+		response.Code = code
 
 		data, errMarshal := json.Marshal(response)
 		if errMarshal != nil {
@@ -65,34 +68,19 @@ func (h *FarmHandler) GetByIDFarmHandler(w http.ResponseWriter, r *http.Request)
 	// checking valid body
 	vars := mux.Vars(r)
 	id, err := strconv.Atoi(vars["id"])
-	if err != nil {
-		code = http.StatusBadRequest
-		err = fmt.Errorf("Invalid Parameter Request")
+	if err != nil || id < 1 {
+		err = apperror.Validation(apperror.Field("id", "id must be a positive number"))
 		return
 	}
 
-	errChan := make(chan error, 1)
 	var res farm.GetFarmInfoResponse
-	go func(ctx context.Context) {
-		res, err = h.domain.GetFarmInfoByID(uint(id))
-		errChan <- err
-	}(ctx)
-
-	select {
-	case <-ctx.Done():
-		code = http.StatusGatewayTimeout
-		err = fmt.Errorf("Timeout")
+	res, err = h.domain.GetFarmInfoByID(ctx, uint(id))
+	if err != nil && ctx.Err() != nil {
+		err = apperror.Wrap(apperror.CodeTimeout, apperror.MessageTimeout, err)
+		return
+	}
+	if err != nil {
 		return
-	case err = <-errChan:
-		if err != nil {
-			if strings.Contains(err.Error(), "not found") {
-				code = http.StatusNotFound
-				err = fmt.Errorf("Data Not Found")
-			} else {
-				code = http.StatusInternalServerError
-			}
-			return
-		}
 	}
 
 	response = mapResonseGetByID(res)
@@ -41,7 +41,7 @@ func TestFarmHandler_GetByIDFarmHandler(t *testing.T) {
 				return context.Background(), func() {}
 			},
 			mockFunc: func(farmDomain mock_farm.MockFarmDomain) {
-				farmDomain.EXPECT().GetFarmInfoByID(gomock.Any()).Return(farm.GetFarmInfoResponse{
+				farmDomain.EXPECT().GetFarmInfoByID(gomock.Any(), gomock.Any()).Return(farm.GetFarmInfoResponse{
 					ID:       1,
 					Name:     "name",
 					Location: "loc",
@@ -74,10 +74,10 @@ func TestFarmHandler_GetByIDFarmHandler(t *testing.T) {
 				return context.Background(), func() {}
 			},
 			mockFunc: func(farmDomain mock_farm.MockFarmDomain) {
-				farmDomain.EXPECT().GetFarmInfoByID(gomock.Any()).Return(farm.GetFarmInfoResponse{}, nil).AnyTimes()
+				farmDomain.EXPECT().GetFarmInfoByID(gomock.Any(), gomock.Any()).Return(farm.GetFarmInfoResponse{}, context.DeadlineExceeded).AnyTimes()
 			},
 			want: want{
-				body: `{"code":504,"message":"Timeout"}`,
+				body: `{"code":504,"message":"Timeout","errors":[{"code":"TIMEOUT","message":"Timeout"}]}`,
 				code: 504,
 			},
 		},
@@ -93,8 +93,8 @@ func TestFarmHandler_GetByIDFarmHandler(t *testing.T) {
 			mockFunc: func(farmDomain mock_farm.MockFarmDomain) {
 			},
 			want: want{
-				body: `{"code":400,"message":"Invalid Parameter Request"}`,
-				code: 400,
+				body: `{"code":422,"message":"Invalid Parameter Request","errors":[{"code":"VALIDATION_FAILED","field":"id","message":"id must be a positive number"}]}`,
+				code: 422,
 			},
 		},
 		{
@@ -107,10 +107,10 @@ func TestFarmHandler_GetByIDFarmHandler(t *testing.T) {
 				return context.Background(), func() {}
 			},
 			mockFunc: func(farmDomain mock_farm.MockFarmDomain) {
-				farmDomain.EXPECT().GetFarmInfoByID(gomock.Any()).Return(farm.GetFarmInfoResponse{}, fmt.Errorf("record not found"))
+				farmDomain.EXPECT().GetFarmInfoByID(gomock.Any(), gomock.Any()).Return(farm.GetFarmInfoResponse{}, farm.ErrInvalidFarm)
 			},
 			want: want{
-				body: `{"code":404,"message":"Data Not Found"}`,
+				body: `{"code":404,"message":"Farm Is Not Exists","errors":[{"code":"FARM_NOT_FOUND","message":"Farm Is Not Exists"}]}`,
 				code: 404,
 			},
 		},
@@ -124,10 +124,10 @@ func TestFarmHandler_GetByIDFarmHandler(t *testing.T) {
 				return context.Background(), func() {}
 			},
 			mockFunc: func(farmDomain mock_farm.MockFarmDomain) {
-				farmDomain.EXPECT().GetFarmInfoByID(gomock.Any()).Return(farm.GetFarmInfoResponse{}, fmt.Errorf("some error"))
+				farmDomain.EXPECT().GetFarmInfoByID(gomock.Any(), gomock.Any()).Return(farm.GetFarmInfoResponse{}, fmt.Errorf("some error"))
 			},
 			want: want{
-				body: `{"code":500,"message":"some error"}`,
+				body: `{"code":500,"message":"Internal Server Error","errors":[{"code":"INTERNAL_ERROR","message":"Internal Server Error"}]}`,
 				code: 500,
 			},
 		},
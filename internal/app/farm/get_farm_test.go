@@ -40,7 +40,7 @@ func TestFarmHandler_GetFarmHandler(t *testing.T) {
 				return context.Background(), func() {}
 			},
 			mockFunc: func(farmDomain mock_farm.MockFarmDomain) {
-				farmDomain.EXPECT().GetFarm(gomock.Any(), gomock.Any()).Return(
+				farmDomain.EXPECT().GetFarm(gomock.Any(), gomock.Any(), gomock.Any()).Return(
 					[]farm.GetFarmInfoResponse{
 						{
 							ID:       1,
@@ -76,12 +76,12 @@ func TestFarmHandler_GetFarmHandler(t *testing.T) {
 				return context.Background(), func() {}
 			},
 			mockFunc: func(farmDomain mock_farm.MockFarmDomain) {
-				farmDomain.EXPECT().GetFarm(gomock.Any(), gomock.Any()).Return(
+				farmDomain.EXPECT().GetFarm(gomock.Any(), gomock.Any(), gomock.Any()).Return(
 					[]farm.GetFarmInfoResponse{}, 0, nil,
 				)
 			},
 			want: want{
-				body: `{"code":404,"message":"Data Not Found"}`,
+				body: `{"code":404,"message":"Data Not Found","errors":[{"code":"NOT_FOUND","message":"Data Not Found"}]}`,
 				code: 404,
 			},
 		},
@@ -95,17 +95,17 @@ func TestFarmHandler_GetFarmHandler(t *testing.T) {
 				return context.Background(), func() {}
 			},
 			mockFunc: func(farmDomain mock_farm.MockFarmDomain) {
-				farmDomain.EXPECT().GetFarm(gomock.Any(), gomock.Any()).Return(
-					[]farm.GetFarmInfoResponse{}, 0, nil,
+				farmDomain.EXPECT().GetFarm(gomock.Any(), gomock.Any(), gomock.Any()).Return(
+					[]farm.GetFarmInfoResponse{}, 0, context.DeadlineExceeded,
 				).AnyTimes()
 			},
 			want: want{
-				body: `{"code":504,"message":"Timeout"}`,
+				body: `{"code":504,"message":"Timeout","errors":[{"code":"TIMEOUT","message":"Timeout"}]}`,
 				code: 504,
 			},
 		},
 		{
-			name: "data not found flow",
+			name: "untyped not found error flow",
 			body: `{"size":2,"cursor":1}`,
 			args: args{
 				timeout: 10,
@@ -114,13 +114,13 @@ func TestFarmHandler_GetFarmHandler(t *testing.T) {
 				return context.Background(), func() {}
 			},
 			mockFunc: func(farmDomain mock_farm.MockFarmDomain) {
-				farmDomain.EXPECT().GetFarm(gomock.Any(), gomock.Any()).Return(
+				farmDomain.EXPECT().GetFarm(gomock.Any(), gomock.Any(), gomock.Any()).Return(
 					[]farm.GetFarmInfoResponse{}, 0, fmt.Errorf("record not found"),
 				)
 			},
 			want: want{
-				body: `{"code":404,"message":"Data Not Found"}`,
-				code: 404,
+				body: `{"code":500,"message":"Internal Server Error","errors":[{"code":"INTERNAL_ERROR","message":"Internal Server Error"}]}`,
+				code: 500,
 			},
 		},
 		{
@@ -133,15 +133,34 @@ func TestFarmHandler_GetFarmHandler(t *testing.T) {
 				return context.Background(), func() {}
 			},
 			mockFunc: func(farmDomain mock_farm.MockFarmDomain) {
-				farmDomain.EXPECT().GetFarm(gomock.Any(), gomock.Any()).Return(
+				farmDomain.EXPECT().GetFarm(gomock.Any(), gomock.Any(), gomock.Any()).Return(
 					[]farm.GetFarmInfoResponse{}, 0, fmt.Errorf("some error"),
 				)
 			},
 			want: want{
-				body: `{"code":500,"message":"some error"}`,
+				body: `{"code":500,"message":"Internal Server Error","errors":[{"code":"INTERNAL_ERROR","message":"Internal Server Error"}]}`,
 				code: 500,
 			},
 		},
+		{
+			name: "size over max is clamped flow",
+			body: `{"size":21,"cursor":1}`,
+			args: args{
+				timeout: 10,
+			},
+			mockContext: func() (context.Context, func()) {
+				return context.Background(), func() {}
+			},
+			mockFunc: func(farmDomain mock_farm.MockFarmDomain) {
+				farmDomain.EXPECT().GetFarm(gomock.Any(), 20, 1).Return(
+					[]farm.GetFarmInfoResponse{{ID: 1, Name: "1"}}, 0, nil,
+				)
+			},
+			want: want{
+				body: `{"data":{"farms":[{"id":1,"name":"1","location":"","owner":"","area":"","list_pondID":null}]},"code":200,"message":"success"}`,
+				code: 200,
+			},
+		},
 		{
 			name: "broken request",
 			body: `{`,
@@ -154,7 +173,7 @@ func TestFarmHandler_GetFarmHandler(t *testing.T) {
 			mockFunc: func(farmDomain mock_farm.MockFarmDomain) {
 			},
 			want: want{
-				body: `{"code":400,"message":"Bad Request"}`,
+				body: `{"code":400,"message":"Bad Request","errors":[{"code":"BAD_REQUEST","message":"Bad Request"}]}`,
 				code: 400,
 			},
 		},
@@ -3,22 +3,31 @@ package farm
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 	"io/ioutil"
 	"log"
 	"net/http"
 	"time"
 
 	"aqua-farm-manager/internal/domain/farm"
+	"aqua-farm-manager/pkg/apperror"
 	utilhttp "aqua-farm-manager/pkg/utilhttp"
+	"aqua-farm-manager/pkg/validator"
 )
 
 // UpdateFarmRequest is list request parameter for Update Api
 type UpdateFarmRequest struct {
-	Name     string `json:"name"`
-	Location string `json:"location"`
-	Owner    string `json:"owner"`
-	Area     string `json:"area"`
+	Name     string `json:"name" validate:"required,max=100,charset=name"`
+	Location string `json:"location" validate:"max=200,charset=text"`
+	Owner    string `json:"owner" validate:"max=100,charset=name"`
+	Area     string `json:"area" validate:"max=50,charset=text"`
+}
+
+// ValidateCrossField is func to check update request change at least one field other than the name
+func (r UpdateFarmRequest) ValidateCrossField() []apperror.Detail {
+	if len(r.Location) < 1 && len(r.Owner) < 1 && len(r.Area) < 1 {
+		return []apperror.Detail{apperror.Field("location", "location, owner or area is required")}
+	}
+	return nil
 }
 
 // UpdateFarmResponse is list response parameter for Update Api
@@ -40,12 +49,16 @@ func (h *FarmHandler) UpdateFarmHandler(w http.ResponseWriter, r *http.Request)
 	var code int = http.StatusOK
 
 	defer func() {
-		response.Code = code
-		if err == nil {
-			response.Message = "success"
+		if err != nil {
+			code = apperror.HTTPStatus(err)
+			if code == http.StatusInternalServerError {
+				log.Println("[UpdateFarmHandler]-Internal Error :", err)
+			}
+			response.SetError(err)
 		} else {
-			response.Message = err.Error()
+			response.Message = "success"
 		}
+		response.Code = code
 
 		data, errMarshal := json.Marshal(response)
 		if errMarshal != nil {
@@ -59,47 +72,35 @@ func (h *FarmHandler) UpdateFarmHandler(w http.ResponseWriter, r *http.Request)
 	var body UpdateFarmRequest
 	data, err := ioutil.ReadAll(r.Body)
 	if err != nil {
-		code = http.StatusBadRequest
-		err = fmt.Errorf("Bad Request")
+		err = apperror.New(apperror.CodeBadRequest, apperror.MessageBadRequest)
 		return
 	}
 
 	err = json.Unmarshal(data, &body)
 	if err != nil {
-		code = http.StatusBadRequest
-		err = fmt.Errorf("Bad Request")
+		err = apperror.New(apperror.CodeBadRequest, apperror.MessageBadRequest)
 		return
 	}
 
 	// checking valid body
-	if len(body.Name) < 1 || (len(body.Location) < 1 && len(body.Area) < 1 && len(body.Owner) < 1) {
-		code = http.StatusBadRequest
-		err = fmt.Errorf("Invalid Parameter Request")
+	err = validator.Validate(body)
+	if err != nil {
 		return
 	}
 
-	errChan := make(chan error, 1)
 	var res farm.UpdateDomainResponse
-	go func(ctx context.Context) {
-		res, err = h.domain.UpdateFarmInfo(farm.UpdateDomainRequest{
-			Name:     body.Name,
-			Location: body.Location,
-			Owner:    body.Owner,
-			Area:     body.Area,
-		})
-		errChan <- err
-	}(ctx)
-
-	select {
-	case <-ctx.Done():
-		code = http.StatusGatewayTimeout
-		err = fmt.Errorf("Timeout")
+	res, err = h.domain.UpdateFarmInfo(ctx, farm.UpdateDomainRequest{
+		Name:     body.Name,
+		Location: body.Location,
+		Owner:    body.Owner,
+		Area:     body.Area,
+	})
+	if err != nil && ctx.Err() != nil {
+		err = apperror.Wrap(apperror.CodeTimeout, apperror.MessageTimeout, err)
+		return
+	}
+	if err != nil {
 		return
-	case err = <-errChan:
-		if err != nil {
-			code = http.StatusInternalServerError
-			return
-		}
 	}
 
 	response = mapResonseUpdate(res)
@@ -40,7 +40,7 @@ func TestFarmHandler_UpdateFarmHandler(t *testing.T) {
 				return context.Background(), func() {}
 			},
 			mockFunc: func(farmDomain mock_farm.MockFarmDomain) {
-				farmDomain.EXPECT().UpdateFarmInfo(gomock.Any()).Return(farm.UpdateDomainResponse{
+				farmDomain.EXPECT().UpdateFarmInfo(gomock.Any(), gomock.Any()).Return(farm.UpdateDomainResponse{
 					ID:       1,
 					Name:     "name",
 					Location: "location",
@@ -63,13 +63,30 @@ func TestFarmHandler_UpdateFarmHandler(t *testing.T) {
 				return context.Background(), func() {}
 			},
 			mockFunc: func(farmDomain mock_farm.MockFarmDomain) {
-				farmDomain.EXPECT().UpdateFarmInfo(gomock.Any()).Return(farm.UpdateDomainResponse{}, nil).AnyTimes()
+				farmDomain.EXPECT().UpdateFarmInfo(gomock.Any(), gomock.Any()).Return(farm.UpdateDomainResponse{}, context.DeadlineExceeded).AnyTimes()
 			},
 			want: want{
-				body: `{"code":504,"message":"Timeout"}`,
+				body: `{"code":504,"message":"Timeout","errors":[{"code":"TIMEOUT","message":"Timeout"}]}`,
 				code: 504,
 			},
 		},
+		{
+			name: "duplicate farm flow",
+			body: `{ "name": "name", "location": "location", "owner": "owner", "area": "area" }`,
+			args: args{
+				timeout: 10,
+			},
+			mockContext: func() (context.Context, func()) {
+				return context.Background(), func() {}
+			},
+			mockFunc: func(farmDomain mock_farm.MockFarmDomain) {
+				farmDomain.EXPECT().UpdateFarmInfo(gomock.Any(), gomock.Any()).Return(farm.UpdateDomainResponse{}, farm.ErrDuplicateFarm)
+			},
+			want: want{
+				body: `{"code":409,"message":"Farm Already Exists","errors":[{"code":"FARM_ALREADY_EXISTS","message":"Farm Already Exists"}]}`,
+				code: 409,
+			},
+		},
 		{
 			name: "internal server error flow",
 			body: `{ "name": "name", "location": "location", "owner": "owner", "area": "area" }`,
@@ -80,10 +97,10 @@ func TestFarmHandler_UpdateFarmHandler(t *testing.T) {
 				return context.Background(), func() {}
 			},
 			mockFunc: func(farmDomain mock_farm.MockFarmDomain) {
-				farmDomain.EXPECT().UpdateFarmInfo(gomock.Any()).Return(farm.UpdateDomainResponse{}, fmt.Errorf("some error"))
+				farmDomain.EXPECT().UpdateFarmInfo(gomock.Any(), gomock.Any()).Return(farm.UpdateDomainResponse{}, fmt.Errorf("some error"))
 			},
 			want: want{
-				body: `{"code":500,"message":"some error"}`,
+				body: `{"code":500,"message":"Internal Server Error","errors":[{"code":"INTERNAL_ERROR","message":"Internal Server Error"}]}`,
 				code: 500,
 			},
 		},
@@ -99,8 +116,8 @@ func TestFarmHandler_UpdateFarmHandler(t *testing.T) {
 			mockFunc: func(farmDomain mock_farm.MockFarmDomain) {
 			},
 			want: want{
-				body: `{"code":400,"message":"Invalid Parameter Request"}`,
-				code: 400,
+				body: `{"code":422,"message":"Invalid Parameter Request","errors":[{"code":"VALIDATION_FAILED","field":"location","message":"location, owner or area is required"}]}`,
+				code: 422,
 			},
 		},
 		{
@@ -115,7 +132,7 @@ func TestFarmHandler_UpdateFarmHandler(t *testing.T) {
 			mockFunc: func(farmDomain mock_farm.MockFarmDomain) {
 			},
 			want: want{
-				body: `{"code":400,"message":"Bad Request"}`,
+				body: `{"code":400,"message":"Bad Request","errors":[{"code":"BAD_REQUEST","message":"Bad Request"}]}`,
 				code: 400,
 			},
 		},
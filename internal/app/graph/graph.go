@@ -0,0 +1,142 @@
+package graph
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"time"
+
+	"aqua-farm-manager/internal/domain/loader"
+	"aqua-farm-manager/internal/domain/stat"
+	"aqua-farm-manager/internal/infrastructure/farm"
+	"aqua-farm-manager/internal/infrastructure/pond"
+	"aqua-farm-manager/pkg/apperror"
+	"aqua-farm-manager/pkg/graphql"
+	utilhttp "aqua-farm-manager/pkg/utilhttp"
+)
+
+// GraphQLHandler struct is list dependecies to run GraphQL Handler
+type GraphQLHandler struct {
+	farmstore     farm.FarmStore
+	pondstore     pond.PondStore
+	stat          stat.StatDomain
+	schema        *graphql.Schema
+	timeoutInSec  int
+	maxDepth      int
+	maxComplexity int
+}
+
+// Option set options for http handler config
+type Option func(*GraphQLHandler)
+
+const (
+	defaultTimeout = 5
+	// maxBodySize is max size of the request body, the bigger body is rejected before it is parsed
+	maxBodySize = 1 << 20
+)
+
+// WithTimeoutOptions is func to set timeout config into handler
+func WithTimeoutOptions(timeoutinsec int) Option {
+	return Option(
+		func(h *GraphQLHandler) {
+			if timeoutinsec <= 0 {
+				timeoutinsec = defaultTimeout
+			}
+			h.timeoutInSec = timeoutinsec
+		})
+}
+
+// WithLimitOptions is func to set max depth and max complexity of the query,
+// the default limit of the schema is used when the value is not positive
+func WithLimitOptions(maxDepth, maxComplexity int) Option {
+	return Option(
+		func(h *GraphQLHandler) {
+			h.maxDepth = maxDepth
+			h.maxComplexity = maxComplexity
+		})
+}
+
+// NewGraphQLHandler is func to create GraphQLHandler with the schema of farm, pond and stat,
+// the farm and pond is loaded by a loader of each request so nested field is loaded in batch
+func NewGraphQLHandler(farmstore farm.FarmStore, pondstore pond.PondStore, stat stat.StatDomain, options ...Option) (*GraphQLHandler, error) {
+	handler := &GraphQLHandler{
+		farmstore:    farmstore,
+		pondstore:    pondstore,
+		stat:         stat,
+		timeoutInSec: defaultTimeout,
+	}
+
+	// Apply options
+	for _, opt := range options {
+		opt(handler)
+	}
+
+	schema, err := graphql.NewSchema(handler.query(),
+		graphql.WithMaxDepthOptions(handler.maxDepth),
+		graphql.WithMaxComplexityOptions(handler.maxComplexity))
+	if err != nil {
+		return nil, err
+	}
+	handler.schema = schema
+
+	return handler, nil
+}
+
+// GraphQLHandler is func handler to execute graphql query in the request body,
+// the status is 200 when the query is executed even if some field has error
+func (h *GraphQLHandler) GraphQLHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.timeoutInSec)*time.Second)
+	defer cancel()
+
+	var response *graphql.Response
+	var code int = http.StatusOK
+
+	defer func() {
+		// the query is rejected before it is executed
+		if response.Data == nil && len(response.Errors) > 0 {
+			code = apperror.HTTPStatus(response.Errors[0])
+		}
+		for _, e := range response.Errors {
+			if e.Extensions.Code == apperror.CodeInternal {
+				log.Println("[GraphQLHandler]-Internal Error :", e.Unwrap())
+			}
+		}
+
+		data, errMarshal := json.Marshal(response)
+		if errMarshal != nil {
+			log.Println("[GraphQLHandler]-Error Marshal Response :", errMarshal)
+			code = http.StatusInternalServerError
+			data = []byte(`{"errors":[{"message":"Internal Server Error","extensions":{"code":"INTERNAL_ERROR"}}]}`)
+		}
+		utilhttp.WriteResponse(w, data, code)
+	}()
+
+	var body graphql.Request
+	data, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, maxBodySize))
+	if err == nil {
+		err = json.Unmarshal(data, &body)
+	}
+	if err != nil {
+		response = &graphql.Response{Errors: []*graphql.Error{
+			graphql.NewError(apperror.New(apperror.CodeBadRequest, apperror.MessageBadRequest)),
+		}}
+		return
+	}
+
+	ctx = withLoader(ctx, loader.NewLoader(ctx, h.farmstore, h.pondstore))
+	response = h.schema.Do(ctx, body)
+}
+
+// loaderKey is context key of the loader of the request
+type loaderKey struct{}
+
+func withLoader(ctx context.Context, l *loader.Loader) context.Context {
+	return context.WithValue(ctx, loaderKey{}, l)
+}
+
+func loaderFrom(ctx context.Context) *loader.Loader {
+	l, _ := ctx.Value(loaderKey{}).(*loader.Loader)
+	return l
+}
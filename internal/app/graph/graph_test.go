@@ -0,0 +1,244 @@
+package graph
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"aqua-farm-manager/internal/domain/stat"
+	"aqua-farm-manager/internal/domain/stat/mock_stat"
+	"aqua-farm-manager/internal/infrastructure/farm"
+	"aqua-farm-manager/internal/infrastructure/pond"
+	"aqua-farm-manager/pkg/memorydb"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestNewGraphQLHandler(t *testing.T) {
+	tests := []struct {
+		name              string
+		options           []Option
+		wantTimeout       int
+		wantMaxDepth      int
+		wantMaxComplexity int
+	}{
+		{
+			name:        "success without option",
+			wantTimeout: 5,
+		},
+		{
+			name:              "success with option",
+			options:           []Option{WithTimeoutOptions(10), WithLimitOptions(3, 100)},
+			wantTimeout:       10,
+			wantMaxDepth:      3,
+			wantMaxComplexity: 100,
+		},
+		{
+			name:        "success with invalid option value",
+			options:     []Option{WithTimeoutOptions(0)},
+			wantTimeout: 5,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewGraphQLHandler(&farm.Farm{}, &pond.Pond{}, &stat.Stat{}, tt.options...)
+			if err != nil {
+				t.Fatalf("NewGraphQLHandler() error = %v", err)
+			}
+			if got.timeoutInSec != tt.wantTimeout || got.maxDepth != tt.wantMaxDepth || got.maxComplexity != tt.wantMaxComplexity {
+				t.Errorf("NewGraphQLHandler() = %+v, want timeout %d, max depth %d, max complexity %d",
+					got, tt.wantTimeout, tt.wantMaxDepth, tt.wantMaxComplexity)
+			}
+			if got.schema == nil {
+				t.Errorf("NewGraphQLHandler() schema is nil")
+			}
+		})
+	}
+}
+
+func TestGraphQLHandler_GraphQLHandler(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	type want struct {
+		body string
+		code int
+	}
+	tests := []struct {
+		name     string
+		options  []Option
+		mockFunc func(*mock_stat.MockStatDomain)
+		body     string
+		want     want
+	}{
+		{
+			name: "nested farm, pond and farm of pond",
+			body: `{"query":"{ farms(size: 2) { items { id name ponds { name farm { name } } } nextCursor } }"}`,
+			want: want{
+				code: 200,
+				body: `{"data":{"farms":{"items":[` +
+					`{"id":"1","name":"farm a","ponds":[{"name":"pond a1","farm":{"name":"farm a"}},{"name":"pond a2","farm":{"name":"farm a"}}]},` +
+					`{"id":"2","name":"farm b","ponds":[{"name":"pond b1","farm":{"name":"farm b"}}]}],"nextCursor":2}}}`,
+			},
+		},
+		{
+			name: "pond with its farm using variable and fragment",
+			body: `{"query":"query Pond($id: ID!) { pond(id: $id) { ...pond } } fragment pond on Pond { name farmId farm { name } }","variables":{"id":3}}`,
+			want: want{
+				code: 200,
+				body: `{"data":{"pond":{"name":"pond b1","farmId":"2","farm":{"name":"farm b"}}}}`,
+			},
+		},
+		{
+			name: "farm not found is null",
+			body: `{"query":"{ farm(id: \"99\") { name } }"}`,
+			want: want{
+				code: 200,
+				body: `{"data":{"farm":null}}`,
+			},
+		},
+		{
+			name: "invalid size",
+			body: `{"query":"{ farms(size: 21) { nextCursor } }"}`,
+			want: want{
+				code: 200,
+				body: `{"data":null,"errors":[{"message":"Invalid Parameter Request","locations":[{"line":1,"column":3}],"path":["farms"],` +
+					`"extensions":{"code":"VALIDATION_FAILED","fields":[{"code":"VALIDATION_FAILED","field":"size","message":"size must be between 0 and 20"}]}}]}`,
+			},
+		},
+		{
+			name: "stats ordered by key",
+			mockFunc: func(msd *mock_stat.MockStatDomain) {
+				msd.EXPECT().GenerateStatAPI(gomock.Any()).Return(map[string]stat.StatMetrics{
+					"POST /v1/farms": {NumRequested: 3, NumUniqAgent: 1, NumSuccess: 2, NumError: 1},
+					"GET /v1/farms":  {NumRequested: 1, NumUniqAgent: 1, NumSuccess: 1},
+				})
+			},
+			body: `{"query":"{ stats { key method path count } }"}`,
+			want: want{
+				code: 200,
+				body: `{"data":{"stats":[{"key":"GET /v1/farms","method":"GET","path":"/v1/farms","count":1},` +
+					`{"key":"POST /v1/farms","method":"POST","path":"/v1/farms","count":3}]}}`,
+			},
+		},
+		{
+			name: "syntax error",
+			body: `{"query":"{ farms "}`,
+			want: want{
+				code: 400,
+				body: `{"errors":[{"message":"Syntax Error: Unexpected \u003cEOF\u003e","locations":[{"line":1,"column":9}],"extensions":{"code":"BAD_REQUEST"}}]}`,
+			},
+		},
+		{
+			name: "unknown field",
+			body: `{"query":"{ farms { readings } }"}`,
+			want: want{
+				code: 422,
+				body: `{"errors":[{"message":"Cannot query field \"readings\" on type \"FarmPage\"","locations":[{"line":1,"column":11}],"extensions":{"code":"VALIDATION_FAILED"}}]}`,
+			},
+		},
+		{
+			name:    "query is too deep",
+			options: []Option{WithLimitOptions(3, 0)},
+			body:    `{"query":"{ farm(id: 1) { ponds { farm { name } } } }"}`,
+			want: want{
+				code: 422,
+				body: `{"errors":[{"message":"Query depth exceeds the max depth 3","locations":[{"line":1,"column":30}],"extensions":{"code":"VALIDATION_FAILED"}}]}`,
+			},
+		},
+		{
+			name: "query is too complex",
+			body: `{"query":"{ farms { items { ponds { farm { ponds { name } } } } } }"}`,
+			want: want{
+				code: 422,
+				body: `{"errors":[{"message":"Query complexity 2441 exceeds the max complexity 1000","locations":[{"line":1,"column":1}],"extensions":{"code":"VALIDATION_FAILED"}}]}`,
+			},
+		},
+		{
+			name: "deep nested value",
+			body: `{"query":"{ farm(id: [[[[[[[[[[[1]]]]]]]]]]]) { name } }"}`,
+			want: want{
+				code: 422,
+				body: `{"errors":[{"message":"Value depth exceeds the max depth 10","locations":[{"line":1,"column":22}],"extensions":{"code":"VALIDATION_FAILED"}}]}`,
+			},
+		},
+		{
+			name: "body is too large",
+			body: `{"query":"{ farms { nextCursor } }","operationName":"` + strings.Repeat("a", maxBodySize) + `"}`,
+			want: want{
+				code: 400,
+				body: `{"errors":[{"message":"Bad Request","extensions":{"code":"BAD_REQUEST"}}]}`,
+			},
+		},
+		{
+			name: "invalid body",
+			body: `{"query":`,
+			want: want{
+				code: 400,
+				body: `{"errors":[{"message":"Bad Request","extensions":{"code":"BAD_REQUEST"}}]}`,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			domain := mock_stat.NewMockStatDomain(mockCtrl)
+			if tt.mockFunc != nil {
+				tt.mockFunc(domain)
+			}
+			farmStore, pondStore := newStores(t)
+
+			handler, err := NewGraphQLHandler(farmStore, pondStore, domain, tt.options...)
+			if err != nil {
+				t.Fatalf("NewGraphQLHandler() error = %v", err)
+			}
+
+			r := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+			handler.GraphQLHandler(w, r)
+			result := w.Result()
+			resBody, err := ioutil.ReadAll(result.Body)
+
+			if err != nil {
+				t.Fatalf("Error read body err = %v\n", err)
+			}
+
+			if result.StatusCode != tt.want.code {
+				t.Fatalf("GraphQLHandler status code got =%d, want %d \n", result.StatusCode, tt.want.code)
+			}
+
+			if string(resBody) != tt.want.body {
+				t.Fatalf("GraphQLHandler body got =%s, want %s \n", string(resBody), tt.want.body)
+			}
+		})
+	}
+}
+
+// newStores is func to create in memory farm a with 2 ponds, farm b with 1 pond and inactive farm c
+func newStores(t *testing.T) (farm.FarmStore, pond.PondStore) {
+	db := memorydb.NewDB()
+	farmStore, pondStore := farm.NewMemoryFarmStore(db), pond.NewMemoryPondStore(db)
+	ctx := context.Background()
+
+	ponds := map[string][]string{
+		"farm a": {"pond a1", "pond a2"},
+		"farm b": {"pond b1"},
+		"farm c": nil,
+	}
+	for _, name := range []string{"farm a", "farm b", "farm c"} {
+		f := &farm.FarmInfraInfo{Name: name}
+		if err := farmStore.Create(ctx, f); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+		for _, pondName := range ponds[name] {
+			if err := pondStore.Create(ctx, &pond.PondInfraInfo{Name: pondName, FarmID: f.ID}); err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+		}
+	}
+	if err := farmStore.Delete(ctx, &farm.FarmInfraInfo{Name: "farm c"}); err != nil {
+		t.Fatalf("Delete() error = %v", err)
+	}
+	return farmStore, pondStore
+}
@@ -0,0 +1,317 @@
+package graph
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+
+	"aqua-farm-manager/internal/domain/loader"
+	ponddomain "aqua-farm-manager/internal/domain/pond"
+	"aqua-farm-manager/pkg/apperror"
+	"aqua-farm-manager/pkg/graphql"
+)
+
+// list paging config, it is the same with the rest api
+const (
+	defaultSize   = 20
+	maxSize       = 20
+	defaultCursor = 1
+	// maxPondsInFarm is the estimate of active pond in a farm for the complexity
+	maxPondsInFarm = ponddomain.MaxPondInFarm
+)
+
+// Stat is metrics of an api in the graphql response
+type Stat struct {
+	Key             string `json:"key"`
+	Method          string `json:"method"`
+	Path            string `json:"path"`
+	Count           int    `json:"count"`
+	UniqueUserAgent int    `json:"uniqueUserAgent"`
+	NumSuccess      int    `json:"numSuccess"`
+	NumError        int    `json:"numError"`
+}
+
+// Page is list item of a page with the cursor of the next page, the next cursor is 0 on the last page
+type Page struct {
+	Items      interface{} `json:"items"`
+	NextCursor int         `json:"nextCursor"`
+}
+
+// query is func to create query type of the schema
+func (h *GraphQLHandler) query() *graphql.Object {
+	farmType := &graphql.Object{Name: "Farm", Description: "Active farm"}
+	pondType := &graphql.Object{Name: "Pond", Description: "Active pond"}
+
+	farmType.Fields = graphql.Fields{
+		"id":       {Type: graphql.NewNonNull(graphql.ID)},
+		"name":     {Type: graphql.NewNonNull(graphql.String)},
+		"location": {Type: graphql.NewNonNull(graphql.String)},
+		"owner":    {Type: graphql.NewNonNull(graphql.String)},
+		"area":     {Type: graphql.NewNonNull(graphql.String)},
+		"ponds": {
+			Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(pondType))),
+			Description: "Active pond of the farm",
+			Resolve:     resolveFarmPonds,
+			Complexity: func(args map[string]interface{}, childComplexity int) int {
+				return 1 + maxPondsInFarm*childComplexity
+			},
+		},
+	}
+	pondType.Fields = graphql.Fields{
+		"id":           {Type: graphql.NewNonNull(graphql.ID)},
+		"name":         {Type: graphql.NewNonNull(graphql.String)},
+		"capacity":     {Type: graphql.NewNonNull(graphql.Float)},
+		"depth":        {Type: graphql.NewNonNull(graphql.Float)},
+		"waterQuality": {Type: graphql.NewNonNull(graphql.Float)},
+		"species":      {Type: graphql.NewNonNull(graphql.String)},
+		"farmId":       {Type: graphql.ID, Resolve: resolvePondFarmID},
+		"farm": {
+			Type:        farmType,
+			Description: "Farm of the pond, it is null when the pond is not mapped into active farm",
+			Resolve:     resolvePondFarm,
+		},
+	}
+
+	statType := &graphql.Object{
+		Name:        "Stat",
+		Description: "Metrics of tracked api",
+		Fields: graphql.Fields{
+			"key":             {Type: graphql.NewNonNull(graphql.String)},
+			"method":          {Type: graphql.NewNonNull(graphql.String)},
+			"path":            {Type: graphql.NewNonNull(graphql.String)},
+			"count":           {Type: graphql.NewNonNull(graphql.Int)},
+			"uniqueUserAgent": {Type: graphql.NewNonNull(graphql.Int)},
+			"numSuccess":      {Type: graphql.NewNonNull(graphql.Int)},
+			"numError":        {Type: graphql.NewNonNull(graphql.Int)},
+		},
+	}
+
+	return &graphql.Object{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"farms": {
+				Type:        graphql.NewNonNull(pageType("FarmPage", farmType)),
+				Description: "Active farm with paging",
+				Args:        pagingArgs(),
+				Resolve:     resolveFarms,
+				Complexity:  pageComplexity,
+			},
+			"farm": {
+				Type:        farmType,
+				Description: "Active farm by id, it is null when the farm is not found",
+				Args:        graphql.Args{"id": {Type: graphql.NewNonNull(graphql.ID)}},
+				Resolve:     resolveFarm,
+			},
+			"ponds": {
+				Type:        graphql.NewNonNull(pageType("PondPage", pondType)),
+				Description: "Active pond with paging",
+				Args:        pagingArgs(),
+				Resolve:     resolvePonds,
+				Complexity:  pageComplexity,
+			},
+			"pond": {
+				Type:        pondType,
+				Description: "Active pond by id, it is null when the pond is not found",
+				Args:        graphql.Args{"id": {Type: graphql.NewNonNull(graphql.ID)}},
+				Resolve:     resolvePond,
+			},
+			"stats": {
+				Type:        graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(statType))),
+				Description: "Metrics of every tracked api ordered by key",
+				Resolve:     h.resolveStats,
+			},
+		},
+	}
+}
+
+func pageType(name string, item *graphql.Object) *graphql.Object {
+	return &graphql.Object{
+		Name: name,
+		Fields: graphql.Fields{
+			"items":      {Type: graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(item)))},
+			"nextCursor": {Type: graphql.NewNonNull(graphql.Int), Description: "Cursor of the next page, it is 0 on the last page"},
+		},
+	}
+}
+
+func pagingArgs() graphql.Args {
+	return graphql.Args{
+		"size":   {Type: graphql.Int, DefaultValue: defaultSize, Description: "Number of item in a page, max 20"},
+		"cursor": {Type: graphql.Int, DefaultValue: defaultCursor, Description: "Page number that start from 1"},
+	}
+}
+
+// pageComplexity is func to multiply the complexity of the item by the size of the page
+func pageComplexity(args map[string]interface{}, childComplexity int) int {
+	size, ok := args["size"].(int)
+	if !ok || size < 0 || size > maxSize {
+		size = maxSize
+	}
+	return 1 + size*childComplexity
+}
+
+// getPaging is func to get valid size and cursor from the argument
+func getPaging(args map[string]interface{}) (int, int, error) {
+	size, ok := args["size"].(int)
+	if !ok {
+		size = defaultSize
+	}
+	cursor, ok := args["cursor"].(int)
+	if !ok {
+		cursor = defaultCursor
+	}
+
+	var fields []apperror.Detail
+	if size < 0 || size > maxSize {
+		fields = append(fields, apperror.Field("size", "size must be between 0 and 20"))
+	}
+	if cursor < 1 {
+		fields = append(fields, apperror.Field("cursor", "cursor must be greater than or equal to 1"))
+	}
+	if len(fields) > 0 {
+		return 0, 0, apperror.Validation(fields...)
+	}
+	return size, cursor, nil
+}
+
+// getID is func to get id argument as uint
+func getID(args map[string]interface{}) (uint, error) {
+	raw, _ := args["id"].(string)
+	id, err := strconv.ParseUint(raw, 10, 32)
+	if err != nil || id == 0 {
+		return 0, apperror.Invalid("id", "id must be positive integer")
+	}
+	return uint(id), nil
+}
+
+func resolveFarms(p graphql.ResolveParams) (interface{}, error) {
+	size, cursor, err := getPaging(p.Args)
+	if err != nil {
+		return nil, err
+	}
+
+	farms, next, err := loaderFrom(p.Context).FarmPage(size, cursor)
+	if err != nil {
+		return nil, err
+	}
+	return Page{Items: farms, NextCursor: next}, nil
+}
+
+func resolvePonds(p graphql.ResolveParams) (interface{}, error) {
+	size, cursor, err := getPaging(p.Args)
+	if err != nil {
+		return nil, err
+	}
+
+	ponds, next, err := loaderFrom(p.Context).PondPage(size, cursor)
+	if err != nil {
+		return nil, err
+	}
+	return Page{Items: ponds, NextCursor: next}, nil
+}
+
+func resolveFarm(p graphql.ResolveParams) (interface{}, error) {
+	id, err := getID(p.Args)
+	if err != nil {
+		return nil, err
+	}
+
+	load := loaderFrom(p.Context).Farm(id)
+	return graphql.Thunk(func() (interface{}, error) {
+		return load()
+	}), nil
+}
+
+func resolvePond(p graphql.ResolveParams) (interface{}, error) {
+	id, err := getID(p.Args)
+	if err != nil {
+		return nil, err
+	}
+
+	load := loaderFrom(p.Context).Pond(id)
+	return graphql.Thunk(func() (interface{}, error) {
+		return load()
+	}), nil
+}
+
+// resolveFarmPonds is func to load pond ids of the farm then load the ponds,
+// the ids of every farm in the level is loaded before any pond is loaded
+func resolveFarmPonds(p graphql.ResolveParams) (interface{}, error) {
+	l := loaderFrom(p.Context)
+	loadIDs := l.PondIDs(farmOf(p.Source).ID)
+	return graphql.Thunk(func() (interface{}, error) {
+		ids, err := loadIDs()
+		if err != nil {
+			return nil, err
+		}
+
+		loadPonds := l.Ponds(ids)
+		return graphql.Thunk(func() (interface{}, error) {
+			return loadPonds()
+		}), nil
+	}), nil
+}
+
+func resolvePondFarmID(p graphql.ResolveParams) (interface{}, error) {
+	if id := pondOf(p.Source).FarmID; id > 0 {
+		return id, nil
+	}
+	return nil, nil
+}
+
+func resolvePondFarm(p graphql.ResolveParams) (interface{}, error) {
+	id := pondOf(p.Source).FarmID
+	if id == 0 {
+		return nil, nil
+	}
+
+	load := loaderFrom(p.Context).Farm(id)
+	return graphql.Thunk(func() (interface{}, error) {
+		return load()
+	}), nil
+}
+
+func (h *GraphQLHandler) resolveStats(p graphql.ResolveParams) (interface{}, error) {
+	metrics := h.stat.GenerateStatAPI(p.Context)
+	if err := p.Context.Err(); err != nil {
+		return nil, apperror.Wrap(apperror.CodeTimeout, apperror.MessageTimeout, err)
+	}
+
+	stats := make([]Stat, 0, len(metrics))
+	for key, value := range metrics {
+		method, path, _ := strings.Cut(key, " ")
+		stats = append(stats, Stat{
+			Key:             key,
+			Method:          method,
+			Path:            path,
+			Count:           value.NumRequested,
+			UniqueUserAgent: value.NumUniqAgent,
+			NumSuccess:      value.NumSuccess,
+			NumError:        value.NumError,
+		})
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Key < stats[j].Key })
+	return stats, nil
+}
+
+// farmOf is func to get farm of the source that is item of page or farm from the loader
+func farmOf(source interface{}) loader.FarmInfo {
+	switch f := source.(type) {
+	case loader.FarmInfo:
+		return f
+	case *loader.FarmInfo:
+		return *f
+	}
+	return loader.FarmInfo{}
+}
+
+// pondOf is func to get pond of the source that is item of page or pond from the loader
+func pondOf(source interface{}) loader.PondInfo {
+	switch p := source.(type) {
+	case loader.PondInfo:
+		return p
+	case *loader.PondInfo:
+		return *p
+	}
+	return loader.PondInfo{}
+}
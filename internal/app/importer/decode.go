@@ -0,0 +1,227 @@
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"aqua-farm-manager/pkg/apperror"
+)
+
+// maxLineSize is max size of a line of ndjson file
+const maxLineSize = 1 << 20
+
+// list format of import file
+const (
+	formatCSV    = "csv"
+	formatNDJSON = "ndjson"
+)
+
+// decodedRow is a row of import file with the violation of decoding and validation
+type decodedRow[T any] struct {
+	line   int
+	body   T
+	errors []apperror.Detail
+}
+
+// decodeRows is func to decode every row of the file, a row that can not be decoded is kept with the violation
+// so the other rows is still validated, error is returned when the file itself is invalid
+func decodeRows[T any](body io.Reader, format string, columns []string,
+	fromRecord func(record map[string]string) (T, []apperror.Detail), maxRows int) ([]decodedRow[T], error) {
+	if format == formatNDJSON {
+		return decodeNDJSON[T](body, maxRows)
+	}
+	return decodeCSV(body, columns, fromRecord, maxRows)
+}
+
+// decodeCSV is func to decode csv file with header, the header has the name of the column in any order
+func decodeCSV[T any](body io.Reader, columns []string,
+	fromRecord func(record map[string]string) (T, []apperror.Detail), maxRows int) ([]decodedRow[T], error) {
+	reader := csv.NewReader(body)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, csvError(err)
+	}
+
+	header, err = mapHeader(header, columns)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []decodedRow[T]
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+
+		var parseErr *csv.ParseError
+		switch {
+		case errors.As(err, &parseErr) && errors.Is(err, csv.ErrFieldCount):
+			rows = append(rows, decodedRow[T]{
+				line: parseErr.StartLine,
+				errors: []apperror.Detail{{
+					Code:    apperror.CodeBadRequest,
+					Message: fmt.Sprintf("row must have %d columns", len(header)),
+				}},
+			})
+		case err != nil:
+			return nil, csvError(err)
+		default:
+			line, _ := reader.FieldPos(0)
+			values := make(map[string]string, len(header))
+			for i, column := range header {
+				values[column] = strings.TrimSpace(record[i])
+			}
+			row, details := fromRecord(values)
+			rows = append(rows, decodedRow[T]{line: line, body: row, errors: details})
+		}
+
+		if len(rows) > maxRows {
+			return nil, errTooManyRows(maxRows)
+		}
+	}
+
+	return rows, nil
+}
+
+// mapHeader is func to get the column name of every field of the header,
+// the column name is case insensitive and name column is required
+func mapHeader(header []string, columns []string) ([]string, error) {
+	known := make(map[string]bool, len(columns))
+	for _, column := range columns {
+		known[column] = true
+	}
+
+	var details []apperror.Detail
+	seen := make(map[string]bool, len(header))
+	result := make([]string, len(header))
+	for i, column := range header {
+		if i == 0 {
+			column = strings.TrimPrefix(column, "\ufeff")
+		}
+		column = strings.ToLower(strings.TrimSpace(column))
+		switch {
+		case !known[column]:
+			details = append(details, apperror.Field("header", fmt.Sprintf("unknown column %q, column must be one of %s", column, strings.Join(columns, ", "))))
+		case seen[column]:
+			details = append(details, apperror.Field("header", fmt.Sprintf("duplicate column %q", column)))
+		}
+		seen[column] = true
+		result[i] = column
+	}
+	if !seen["name"] {
+		details = append(details, apperror.Field("header", `column "name" is required`))
+	}
+
+	if len(details) > 0 {
+		return nil, apperror.Validation(details...)
+	}
+	return result, nil
+}
+
+// csvError is func to map error of malformed csv into bad request
+func csvError(err error) error {
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return apperror.Wrap(apperror.CodeBadRequest,
+			fmt.Sprintf("Invalid CSV at line %d column %d", parseErr.Line, parseErr.Column), err)
+	}
+	return apperror.Wrap(apperror.CodeBadRequest, apperror.MessageBadRequest, err)
+}
+
+// decodeNDJSON is func to decode a json object per line, blank line is skipped
+func decodeNDJSON[T any](body io.Reader, maxRows int) ([]decodedRow[T], error) {
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxLineSize)
+
+	var rows []decodedRow[T]
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+
+		row := decodedRow[T]{line: line}
+		decoder := json.NewDecoder(bytes.NewReader(text))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&row.body); err != nil {
+			row.errors = []apperror.Detail{jsonDetail(err)}
+		} else if decoder.More() {
+			row.errors = []apperror.Detail{{Code: apperror.CodeBadRequest, Message: "line must have a single json object"}}
+		}
+		rows = append(rows, row)
+
+		if len(rows) > maxRows {
+			return nil, errTooManyRows(maxRows)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, apperror.Wrap(apperror.CodeBadRequest,
+			fmt.Sprintf("Invalid NDJSON at line %d, line must be at most %d bytes", line+1, maxLineSize), err)
+	}
+
+	return rows, nil
+}
+
+// jsonDetail is func to map error of json line into violation without go type name
+func jsonDetail(err error) apperror.Detail {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		kind := "string"
+		switch typeErr.Type.Kind() {
+		case reflect.Float32, reflect.Float64, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			kind = "number"
+		}
+		return apperror.Field(typeErr.Field, fmt.Sprintf("%s must be a %s", typeErr.Field, kind))
+	}
+	if strings.HasPrefix(err.Error(), "json: unknown field ") {
+		return apperror.Detail{Code: apperror.CodeBadRequest, Message: strings.TrimPrefix(err.Error(), "json: ")}
+	}
+	return apperror.Detail{Code: apperror.CodeBadRequest, Message: "line must be a json object"}
+}
+
+// parseFloat is func to parse number column, blank column is 0
+func parseFloat(record map[string]string, column string, details *[]apperror.Detail) float64 {
+	value := record[column]
+	if value == "" {
+		return 0
+	}
+	number, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		*details = append(*details, apperror.Field(column, fmt.Sprintf("%s must be a number", column)))
+	}
+	return number
+}
+
+// parseID is func to parse id column, blank column is 0
+func parseID(record map[string]string, column string, details *[]apperror.Detail) uint {
+	value := record[column]
+	if value == "" {
+		return 0
+	}
+	id, err := strconv.ParseUint(value, 10, 32)
+	if err != nil {
+		*details = append(*details, apperror.Field(column, fmt.Sprintf("%s must be a positive integer", column)))
+	}
+	return uint(id)
+}
+
+func errTooManyRows(maxRows int) error {
+	return apperror.Invalid("body", fmt.Sprintf("import file must have at most %d rows", maxRows))
+}
@@ -0,0 +1,351 @@
+package importer
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"aqua-farm-manager/internal/domain/importer"
+	"aqua-farm-manager/pkg/apperror"
+	utilhttp "aqua-farm-manager/pkg/utilhttp"
+	"aqua-farm-manager/pkg/validator"
+)
+
+// list entity of import file
+const (
+	entityFarms = "farms"
+	entityPonds = "ponds"
+)
+
+// list mode of import, partial create every valid row and atomic create nothing when any row is invalid
+const (
+	modePartial = "partial"
+	modeAtomic  = "atomic"
+)
+
+// list status of a row in the response
+const (
+	statusCreated = "created"
+	statusValid   = "valid"
+	statusInvalid = "invalid"
+)
+
+// farmColumns is list column of farm csv file
+var farmColumns = []string{"name", "location", "owner", "area"}
+
+// pondColumns is list column of pond csv file, the farm is found by farm_id or farm_name
+var pondColumns = []string{"name", "capacity", "depth", "water_quality", "species", "farm_id", "farm_name"}
+
+// ImportFarmRequest is list parameter of a farm row, the rule is the same with the request of Create Farm Api
+type ImportFarmRequest struct {
+	Name     string `json:"name" validate:"required,max=100,charset=name"`
+	Location string `json:"location" validate:"max=200,charset=text"`
+	Owner    string `json:"owner" validate:"max=100,charset=name"`
+	Area     string `json:"area" validate:"max=50,charset=text"`
+}
+
+// ImportPondRequest is list parameter of a pond row, the rule is the same with the request of Create Pond Api
+// except the farm can be chosen by name
+type ImportPondRequest struct {
+	Name         string  `json:"name" validate:"required,max=100,charset=name"`
+	Capacity     float64 `json:"capacity" validate:"min=0,max=1000000000"`
+	Depth        float64 `json:"depth" validate:"min=0,max=100"`
+	WaterQuality float64 `json:"water_quality" validate:"min=0,max=14"`
+	Species      string  `json:"species" validate:"max=100,charset=name"`
+	FarmID       uint    `json:"farm_id"`
+	FarmName     string  `json:"farm_name" validate:"max=100,charset=name"`
+}
+
+// ValidateCrossField is func to check pond row choose the farm either by id or name
+func (r ImportPondRequest) ValidateCrossField() []apperror.Detail {
+	if len(r.FarmName) < 1 && r.FarmID < 1 {
+		return []apperror.Detail{apperror.Field("farm_id", "farm_id or farm_name is required")}
+	}
+	if len(r.FarmName) >= 1 && r.FarmID > 0 {
+		return []apperror.Detail{apperror.Field("farm_id", "Please Choose the farm by farm_id or farm_name")}
+	}
+	return nil
+}
+
+// ImportResponse is list response parameter for Import Api
+type ImportResponse struct {
+	Entity  string      `json:"entity"`
+	Format  string      `json:"format"`
+	Mode    string      `json:"mode"`
+	DryRun  bool        `json:"dry_run"`
+	Applied bool        `json:"applied"`
+	Total   int         `json:"total"`
+	Valid   int         `json:"valid"`
+	Invalid int         `json:"invalid"`
+	Rows    []ImportRow `json:"rows"`
+}
+
+// ImportRow is result of a row, the line is line number of the row in the file
+type ImportRow struct {
+	Line   int               `json:"line"`
+	Status string            `json:"status"`
+	ID     uint              `json:"id,omitempty"`
+	Errors []apperror.Detail `json:"errors,omitempty"`
+}
+
+// importParams is list query param of Import Api
+type importParams struct {
+	entity string
+	format string
+	mode   string
+	dryRun bool
+}
+
+// ImportHandler is func handler for import farms or ponds from csv or ndjson body,
+// the status is 200 when the file is processed even if some row is invalid
+func (h *ImportHandler) ImportHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.timeoutInSec)*time.Second)
+	defer cancel()
+
+	var err error
+	var response utilhttp.StandardResponse
+	var code int = http.StatusOK
+
+	defer func() {
+		if err != nil {
+			code = apperror.HTTPStatus(err)
+			if code == http.StatusInternalServerError {
+				log.Println("[ImportHandler]-Internal Error :", err)
+			}
+			response.SetError(err)
+		} else {
+			response.Message = "success"
+		}
+		response.Code = code
+
+		data, errMarshal := json.Marshal(response)
+		if errMarshal != nil {
+			log.Println("[ImportHandler]-Error Marshal Response :", err)
+			code = http.StatusInternalServerError
+			data = []byte(`{"code":500,"message":"Internal Server Error"}`)
+		}
+		utilhttp.WriteResponse(w, data, code)
+	}()
+
+	// checking valid param
+	params, err := getImportParams(r)
+	if err != nil {
+		return
+	}
+
+	var res ImportResponse
+	if params.entity == entityFarms {
+		res, err = h.importFarms(ctx, r, params)
+	} else {
+		res, err = h.importPonds(ctx, r, params)
+	}
+	if err != nil && ctx.Err() != nil {
+		err = apperror.Wrap(apperror.CodeTimeout, apperror.MessageTimeout, err)
+		return
+	}
+	if err != nil {
+		return
+	}
+
+	response.Data = res
+}
+
+// getImportParams is func to get query param with the default format csv and the default mode partial
+func getImportParams(r *http.Request) (importParams, error) {
+	query := r.URL.Query()
+	params := importParams{
+		entity: query.Get("entity"),
+		format: query.Get("format"),
+		mode:   query.Get("mode"),
+	}
+	if params.format == "" {
+		params.format = formatCSV
+	}
+	if params.mode == "" {
+		params.mode = modePartial
+	}
+
+	var details []apperror.Detail
+	if params.entity != entityFarms && params.entity != entityPonds {
+		details = append(details, apperror.Field("entity", "entity must be farms or ponds"))
+	}
+	if params.format != formatCSV && params.format != formatNDJSON {
+		details = append(details, apperror.Field("format", "format must be csv or ndjson"))
+	}
+	if params.mode != modePartial && params.mode != modeAtomic {
+		details = append(details, apperror.Field("mode", "mode must be partial or atomic"))
+	}
+	if value := query.Get("dry_run"); value != "" {
+		dryRun, err := strconv.ParseBool(value)
+		if err != nil {
+			details = append(details, apperror.Field("dry_run", "dry_run must be true or false"))
+		}
+		params.dryRun = dryRun
+	}
+
+	if len(details) > 0 {
+		return params, apperror.Validation(details...)
+	}
+	return params, nil
+}
+
+func (h *ImportHandler) importFarms(ctx context.Context, r *http.Request, params importParams) (ImportResponse, error) {
+	rows, err := decodeRows(r.Body, params.format, farmColumns, farmFromRecord, h.maxRows)
+	if err != nil {
+		return ImportResponse{}, err
+	}
+	if len(rows) == 0 {
+		return ImportResponse{}, errEmptyFile()
+	}
+
+	var list []importer.FarmRow
+	for i := range rows {
+		if !validRow(&rows[i]) {
+			continue
+		}
+		list = append(list, importer.FarmRow{
+			Line:     rows[i].line,
+			Name:     rows[i].body.Name,
+			Location: rows[i].body.Location,
+			Owner:    rows[i].body.Owner,
+			Area:     rows[i].body.Area,
+		})
+	}
+
+	res, err := h.domain.ImportFarms(ctx, importer.ImportFarmsRequest{
+		Rows:   list,
+		DryRun: dryRun(params, len(list), len(rows)),
+		Atomic: params.mode == modeAtomic,
+	})
+	if err != nil {
+		return ImportResponse{}, err
+	}
+
+	return mapResponseImport(params, rows, res), nil
+}
+
+func farmFromRecord(record map[string]string) (ImportFarmRequest, []apperror.Detail) {
+	return ImportFarmRequest{
+		Name:     record["name"],
+		Location: record["location"],
+		Owner:    record["owner"],
+		Area:     record["area"],
+	}, nil
+}
+
+func (h *ImportHandler) importPonds(ctx context.Context, r *http.Request, params importParams) (ImportResponse, error) {
+	rows, err := decodeRows(r.Body, params.format, pondColumns, pondFromRecord, h.maxRows)
+	if err != nil {
+		return ImportResponse{}, err
+	}
+	if len(rows) == 0 {
+		return ImportResponse{}, errEmptyFile()
+	}
+
+	var list []importer.PondRow
+	for i := range rows {
+		if !validRow(&rows[i]) {
+			continue
+		}
+		list = append(list, importer.PondRow{
+			Line:         rows[i].line,
+			Name:         rows[i].body.Name,
+			Capacity:     rows[i].body.Capacity,
+			Depth:        rows[i].body.Depth,
+			WaterQuality: rows[i].body.WaterQuality,
+			Species:      rows[i].body.Species,
+			FarmID:       rows[i].body.FarmID,
+			FarmName:     rows[i].body.FarmName,
+		})
+	}
+
+	res, err := h.domain.ImportPonds(ctx, importer.ImportPondsRequest{
+		Rows:   list,
+		DryRun: dryRun(params, len(list), len(rows)),
+		Atomic: params.mode == modeAtomic,
+	})
+	if err != nil {
+		return ImportResponse{}, err
+	}
+
+	return mapResponseImport(params, rows, res), nil
+}
+
+func pondFromRecord(record map[string]string) (ImportPondRequest, []apperror.Detail) {
+	var details []apperror.Detail
+	body := ImportPondRequest{
+		Name:     record["name"],
+		Species:  record["species"],
+		FarmName: record["farm_name"],
+	}
+	body.Capacity = parseFloat(record, "capacity", &details)
+	body.Depth = parseFloat(record, "depth", &details)
+	body.WaterQuality = parseFloat(record, "water_quality", &details)
+	body.FarmID = parseID(record, "farm_id", &details)
+	return body, details
+}
+
+// validRow is func to validate decoded row with the rule of the request, the violation is kept in the row
+func validRow[T any](row *decodedRow[T]) bool {
+	if len(row.errors) > 0 {
+		return false
+	}
+	row.errors = validator.Struct(row.body)
+	return len(row.errors) == 0
+}
+
+// dryRun is func to check the domain only validate the rows,
+// atomic import create nothing when any row is already invalid before it reach the domain
+func dryRun(params importParams, numValid, numRows int) bool {
+	return params.dryRun || (params.mode == modeAtomic && numValid < numRows)
+}
+
+func errEmptyFile() error {
+	return apperror.Invalid("body", "import file must have at least one row")
+}
+
+func mapResponseImport[T any](params importParams, rows []decodedRow[T], r importer.ImportResponse) ImportResponse {
+	results := make(map[int]importer.RowResult, len(r.Results))
+	for _, result := range r.Results {
+		results[result.Line] = result
+	}
+
+	res := ImportResponse{
+		Entity:  params.entity,
+		Format:  params.format,
+		Mode:    params.mode,
+		DryRun:  params.dryRun,
+		Applied: r.Applied,
+		Total:   len(rows),
+		Rows:    make([]ImportRow, 0, len(rows)),
+	}
+	for _, row := range rows {
+		item := ImportRow{
+			Line:   row.line,
+			Errors: row.errors,
+		}
+		if result, ok := results[row.line]; ok && result.Err != nil {
+			item.Errors = apperror.From(result.Err).Details()
+		} else if ok {
+			item.ID = result.ID
+		}
+
+		switch {
+		case len(item.Errors) > 0:
+			item.Status = statusInvalid
+			res.Invalid++
+		case item.ID > 0:
+			item.Status = statusCreated
+			res.Valid++
+		default:
+			item.Status = statusValid
+			res.Valid++
+		}
+		res.Rows = append(res.Rows, item)
+	}
+
+	return res
+}
@@ -0,0 +1,195 @@
+package importer
+
+import (
+	"aqua-farm-manager/internal/domain/importer"
+	"aqua-farm-manager/internal/domain/importer/mock_importer"
+	ponddomain "aqua-farm-manager/internal/domain/pond"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestImportHandler_ImportHandler(t *testing.T) {
+	type want struct {
+		body string
+		code int
+	}
+	tests := []struct {
+		name     string
+		query    string
+		body     string
+		maxRows  int
+		mockFunc func(domain *mock_importer.MockImportDomain)
+		want     want
+	}{
+		{
+			name:  "partial csv import of farms flow",
+			query: "?entity=farms",
+			body:  "Name,location,owner\nfarm a,bandung,budi\n,bogor,\nfarm c\n",
+			mockFunc: func(domain *mock_importer.MockImportDomain) {
+				domain.EXPECT().ImportFarms(gomock.Any(), importer.ImportFarmsRequest{
+					Rows: []importer.FarmRow{{Line: 2, Name: "farm a", Location: "bandung", Owner: "budi"}},
+				}).Return(importer.ImportResponse{
+					Results: []importer.RowResult{{Line: 2, ID: 7}},
+					Applied: true,
+				}, nil)
+			},
+			want: want{
+				body: `{"data":{"entity":"farms","format":"csv","mode":"partial","dry_run":false,"applied":true,"total":3,"valid":1,"invalid":2,"rows":[` +
+					`{"line":2,"status":"created","id":7},` +
+					`{"line":3,"status":"invalid","errors":[{"code":"VALIDATION_FAILED","field":"name","message":"name is required"}]},` +
+					`{"line":4,"status":"invalid","errors":[{"code":"BAD_REQUEST","message":"row must have 3 columns"}]}]},"code":200,"message":"success"}`,
+				code: 200,
+			},
+		},
+		{
+			name:  "atomic ndjson import of ponds with invalid row is dry run flow",
+			query: "?entity=ponds&format=ndjson&mode=atomic",
+			body: `{"name":"pond a","capacity":10,"farm_name":"farm a"}` + "\n\n" +
+				`{"name":"pond b","farm_id":9}` + "\n" +
+				`{"name":"pond c","capacity":"10","farm_id":1}` + "\n" +
+				`{"name":"pond d","color":"red","farm_id":1}` + "\n" +
+				`{"name":"pond e"}` + "\n",
+			mockFunc: func(domain *mock_importer.MockImportDomain) {
+				domain.EXPECT().ImportPonds(gomock.Any(), importer.ImportPondsRequest{
+					Rows: []importer.PondRow{
+						{Line: 1, Name: "pond a", Capacity: 10, FarmName: "farm a"},
+						{Line: 3, Name: "pond b", FarmID: 9},
+					},
+					DryRun: true,
+					Atomic: true,
+				}).Return(importer.ImportResponse{
+					Results: []importer.RowResult{{Line: 1}, {Line: 3, Err: ponddomain.ErrInvalidFarm}},
+				}, nil)
+			},
+			want: want{
+				body: `{"data":{"entity":"ponds","format":"ndjson","mode":"atomic","dry_run":false,"applied":false,"total":5,"valid":1,"invalid":4,"rows":[` +
+					`{"line":1,"status":"valid"},` +
+					`{"line":3,"status":"invalid","errors":[{"code":"FARM_NOT_FOUND","message":"Farm Is Not Exists"}]},` +
+					`{"line":4,"status":"invalid","errors":[{"code":"VALIDATION_FAILED","field":"capacity","message":"capacity must be a number"}]},` +
+					`{"line":5,"status":"invalid","errors":[{"code":"BAD_REQUEST","message":"unknown field \"color\""}]},` +
+					`{"line":6,"status":"invalid","errors":[{"code":"VALIDATION_FAILED","field":"farm_id","message":"farm_id or farm_name is required"}]}]},"code":200,"message":"success"}`,
+				code: 200,
+			},
+		},
+		{
+			name:  "dry run csv import of ponds flow",
+			query: "?entity=ponds&dry_run=true",
+			body:  "name,depth,farm_id\npond a,2.5,1\npond b,deep,x\n",
+			mockFunc: func(domain *mock_importer.MockImportDomain) {
+				domain.EXPECT().ImportPonds(gomock.Any(), importer.ImportPondsRequest{
+					Rows:   []importer.PondRow{{Line: 2, Name: "pond a", Depth: 2.5, FarmID: 1}},
+					DryRun: true,
+				}).Return(importer.ImportResponse{
+					Results: []importer.RowResult{{Line: 2}},
+				}, nil)
+			},
+			want: want{
+				body: `{"data":{"entity":"ponds","format":"csv","mode":"partial","dry_run":true,"applied":false,"total":2,"valid":1,"invalid":1,"rows":[` +
+					`{"line":2,"status":"valid"},` +
+					`{"line":3,"status":"invalid","errors":[{"code":"VALIDATION_FAILED","field":"depth","message":"depth must be a number"},` +
+					`{"code":"VALIDATION_FAILED","field":"farm_id","message":"farm_id must be a positive integer"}]}]},"code":200,"message":"success"}`,
+				code: 200,
+			},
+		},
+		{
+			name:  "store error flow",
+			query: "?entity=ponds&format=ndjson",
+			body:  `{"name":"pond a","farm_id":1}`,
+			mockFunc: func(domain *mock_importer.MockImportDomain) {
+				domain.EXPECT().ImportPonds(gomock.Any(), gomock.Any()).Return(importer.ImportResponse{}, ponddomain.ErrDuplicatePond)
+			},
+			want: want{
+				body: `{"code":409,"message":"Pond Is Already Exists","errors":[{"code":"POND_ALREADY_EXISTS","message":"Pond Is Already Exists"}]}`,
+				code: 409,
+			},
+		},
+		{
+			name:     "invalid param flow",
+			query:    "?format=xlsx&mode=all&dry_run=yes",
+			mockFunc: func(domain *mock_importer.MockImportDomain) {},
+			want: want{
+				body: `{"code":422,"message":"Invalid Parameter Request","errors":[` +
+					`{"code":"VALIDATION_FAILED","field":"entity","message":"entity must be farms or ponds"},` +
+					`{"code":"VALIDATION_FAILED","field":"format","message":"format must be csv or ndjson"},` +
+					`{"code":"VALIDATION_FAILED","field":"mode","message":"mode must be partial or atomic"},` +
+					`{"code":"VALIDATION_FAILED","field":"dry_run","message":"dry_run must be true or false"}]}`,
+				code: 422,
+			},
+		},
+		{
+			name:     "invalid header flow",
+			query:    "?entity=farms",
+			body:     "location,size\nbandung,1\n",
+			mockFunc: func(domain *mock_importer.MockImportDomain) {},
+			want: want{
+				body: `{"code":422,"message":"Invalid Parameter Request","errors":[` +
+					`{"code":"VALIDATION_FAILED","field":"header","message":"unknown column \"size\", column must be one of name, location, owner, area"},` +
+					`{"code":"VALIDATION_FAILED","field":"header","message":"column \"name\" is required"}]}`,
+				code: 422,
+			},
+		},
+		{
+			name:     "malformed csv flow",
+			query:    "?entity=farms",
+			body:     "name\n\"farm a\n",
+			mockFunc: func(domain *mock_importer.MockImportDomain) {},
+			want: want{
+				body: `{"code":400,"message":"Invalid CSV at line 2 column 9","errors":[{"code":"BAD_REQUEST","message":"Invalid CSV at line 2 column 9"}]}`,
+				code: 400,
+			},
+		},
+		{
+			name:     "empty file flow",
+			query:    "?entity=farms",
+			body:     "name\n",
+			mockFunc: func(domain *mock_importer.MockImportDomain) {},
+			want: want{
+				body: `{"code":422,"message":"import file must have at least one row","errors":[{"code":"VALIDATION_FAILED","field":"body","message":"import file must have at least one row"}]}`,
+				code: 422,
+			},
+		},
+		{
+			name:     "too many rows flow",
+			query:    "?entity=farms&format=ndjson",
+			body:     `{"name":"farm a"}` + "\n" + `{"name":"farm b"}`,
+			maxRows:  1,
+			mockFunc: func(domain *mock_importer.MockImportDomain) {},
+			want: want{
+				body: `{"code":422,"message":"import file must have at most 1 rows","errors":[{"code":"VALIDATION_FAILED","field":"body","message":"import file must have at most 1 rows"}]}`,
+				code: 422,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			domain := mock_importer.NewMockImportDomain(mockCtrl)
+			tt.mockFunc(domain)
+
+			handler := NewImportHandler(domain, WithMaxRowsOptions(tt.maxRows))
+
+			r := httptest.NewRequest(http.MethodPost, "/v1/import"+tt.query, strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+			handler.ImportHandler(w, r)
+			result := w.Result()
+			resBody, err := ioutil.ReadAll(result.Body)
+			if err != nil {
+				t.Fatalf("Error read body err = %v\n", err)
+			}
+
+			if result.StatusCode != tt.want.code {
+				t.Fatalf("ImportHandler status code got =%d, want %d \n", result.StatusCode, tt.want.code)
+			}
+
+			if string(resBody) != tt.want.body {
+				t.Fatalf("ImportHandler body got =%s, want %s \n", string(resBody), tt.want.body)
+			}
+		})
+	}
+}
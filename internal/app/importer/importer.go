@@ -0,0 +1,57 @@
+package importer
+
+import "aqua-farm-manager/internal/domain/importer"
+
+// ImportHandler list dependencies for import handler
+type ImportHandler struct {
+	domain       importer.ImportDomain
+	timeoutInSec int
+	maxRows      int
+}
+
+// Option set options for http handler config
+type Option func(*ImportHandler)
+
+const (
+	// defaultTimeout is longer than other handler because every row is verified before it is created
+	defaultTimeout = 30
+	defaultMaxRows = 1000
+)
+
+// NewImportHandler is func to create http import handler
+func NewImportHandler(domain importer.ImportDomain, options ...Option) *ImportHandler {
+	handler := &ImportHandler{
+		domain:       domain,
+		timeoutInSec: defaultTimeout,
+		maxRows:      defaultMaxRows,
+	}
+
+	// Apply options
+	for _, opt := range options {
+		opt(handler)
+	}
+
+	return handler
+}
+
+// WithTimeoutOptions is func to set timeout config into handler
+func WithTimeoutOptions(timeoutinsec int) Option {
+	return Option(
+		func(h *ImportHandler) {
+			if timeoutinsec <= 0 {
+				timeoutinsec = defaultTimeout
+			}
+			h.timeoutInSec = timeoutinsec
+		})
+}
+
+// WithMaxRowsOptions is func to set max number of row in an import file
+func WithMaxRowsOptions(maxRows int) Option {
+	return Option(
+		func(h *ImportHandler) {
+			if maxRows <= 0 {
+				maxRows = defaultMaxRows
+			}
+			h.maxRows = maxRows
+		})
+}
@@ -0,0 +1,62 @@
+package importer
+
+import (
+	"aqua-farm-manager/internal/domain/importer"
+	"reflect"
+	"testing"
+)
+
+func TestNewImportHandler(t *testing.T) {
+	type args struct {
+		domain  importer.ImportDomain
+		options []Option
+	}
+	tests := []struct {
+		name string
+		args args
+		want *ImportHandler
+	}{
+		{
+			name: "success with setting flow",
+			args: args{
+				domain:  &importer.Importer{},
+				options: []Option{WithTimeoutOptions(10), WithMaxRowsOptions(50)},
+			},
+			want: &ImportHandler{
+				domain:       &importer.Importer{},
+				timeoutInSec: 10,
+				maxRows:      50,
+			},
+		},
+		{
+			name: "success without option flow",
+			args: args{
+				domain: &importer.Importer{},
+			},
+			want: &ImportHandler{
+				domain:       &importer.Importer{},
+				timeoutInSec: 30,
+				maxRows:      1000,
+			},
+		},
+		{
+			name: "success with invalid setting flow",
+			args: args{
+				domain:  &importer.Importer{},
+				options: []Option{WithTimeoutOptions(-1), WithMaxRowsOptions(0)},
+			},
+			want: &ImportHandler{
+				domain:       &importer.Importer{},
+				timeoutInSec: 30,
+				maxRows:      1000,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewImportHandler(tt.args.domain, tt.args.options...); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewImportHandler() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"log"
+	"net/http"
+	"time"
+
+	"aqua-farm-manager/internal/domain/farm"
+	"aqua-farm-manager/pkg/postgres"
+	"aqua-farm-manager/pkg/prometheus"
+	"aqua-farm-manager/pkg/redis"
+)
+
+// pondsPerFarmBuckets is upper bound of ponds per farm histogram, a farm can have up to 10 ponds
+var pondsPerFarmBuckets = []float64{0, 1, 2, 3, 5, 8, 10}
+
+// MetricsHandler struct is list dependecies to run Metrics Handler
+type MetricsHandler struct {
+	farm         farm.FarmDomain
+	redis        redis.RedisMethod
+	postgres     postgres.PostgresMethod
+	registry     *prometheus.Registry
+	timeoutInSec int
+}
+
+// Option set options for http handler config
+type Option func(*MetricsHandler)
+
+const (
+	defaultTimeout = 5
+)
+
+// NewMetricsHandler is func to create MetricsHandler Struct
+func NewMetricsHandler(farm farm.FarmDomain, redis redis.RedisMethod, postgres postgres.PostgresMethod, options ...Option) *MetricsHandler {
+	handler := &MetricsHandler{
+		farm:         farm,
+		redis:        redis,
+		postgres:     postgres,
+		registry:     prometheus.Default,
+		timeoutInSec: defaultTimeout,
+	}
+
+	// Apply options
+	for _, opt := range options {
+		opt(handler)
+	}
+
+	return handler
+}
+
+// WithTimeoutOptions is func to set timeout config into handler
+func WithTimeoutOptions(timeoutinsec int) Option {
+	return Option(
+		func(h *MetricsHandler) {
+			if timeoutinsec <= 0 {
+				timeoutinsec = defaultTimeout
+			}
+			h.timeoutInSec = timeoutinsec
+		})
+}
+
+// GetMetricsHandler is func handler to expose metrics in prometheus text exposition format
+func (h *MetricsHandler) GetMetricsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.timeoutInSec)*time.Second)
+	defer cancel()
+
+	families := h.registry.Gather()
+	families = append(families, h.collectRedisPool()...)
+	families = append(families, h.collectPostgresPool()...)
+	families = append(families, h.collectBusiness(ctx)...)
+
+	var buf bytes.Buffer
+	err := prometheus.Write(&buf, families)
+	if err != nil {
+		log.Println("[GetMetricsHandler]-Error Write Metrics :", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", prometheus.ContentType)
+	w.WriteHeader(http.StatusOK)
+	w.Write(buf.Bytes())
+}
+
+// collectRedisPool is func to collect redis connection pool stats
+func (h *MetricsHandler) collectRedisPool() []prometheus.Family {
+	if h.redis == nil {
+		return nil
+	}
+
+	stats := h.redis.Stats()
+	return []prometheus.Family{
+		gauge("aqua_farm_redis_pool_active_connections", "Number of redis connections in the pool including idle.", float64(stats.ActiveCount)),
+		gauge("aqua_farm_redis_pool_idle_connections", "Number of idle redis connections in the pool.", float64(stats.IdleCount)),
+		counter("aqua_farm_redis_pool_wait_total", "Total number of redis connections waited for.", float64(stats.WaitCount)),
+		counter("aqua_farm_redis_pool_wait_duration_seconds_total", "Total time blocked waiting for a new redis connection.", stats.WaitDuration.Seconds()),
+	}
+}
+
+// collectPostgresPool is func to collect postgres connection pool stats
+func (h *MetricsHandler) collectPostgresPool() []prometheus.Family {
+	if h.postgres == nil {
+		return nil
+	}
+
+	db := postgres.SQLDB(h.postgres.GetDB(context.Background()))
+	if db == nil {
+		return nil
+	}
+
+	stats := db.Stats()
+	return []prometheus.Family{
+		gauge("aqua_farm_postgres_pool_max_open_connections", "Maximum number of open postgres connections.", float64(stats.MaxOpenConnections)),
+		gauge("aqua_farm_postgres_pool_open_connections", "Number of established postgres connections both in use and idle.", float64(stats.OpenConnections)),
+		gauge("aqua_farm_postgres_pool_in_use_connections", "Number of postgres connections currently in use.", float64(stats.InUse)),
+		gauge("aqua_farm_postgres_pool_idle_connections", "Number of idle postgres connections.", float64(stats.Idle)),
+		counter("aqua_farm_postgres_pool_wait_total", "Total number of postgres connections waited for.", float64(stats.WaitCount)),
+		counter("aqua_farm_postgres_pool_wait_duration_seconds_total", "Total time blocked waiting for a new postgres connection.", stats.WaitDuration.Seconds()),
+		counter("aqua_farm_postgres_pool_max_idle_closed_total", "Total number of postgres connections closed due to max idle.", float64(stats.MaxIdleClosed)),
+		counter("aqua_farm_postgres_pool_max_lifetime_closed_total", "Total number of postgres connections closed due to max lifetime.", float64(stats.MaxLifetimeClosed)),
+	}
+}
+
+// collectBusiness is func to collect farm and pond metrics from domain,
+// the business metrics is skipped when the domain got error or timeout
+func (h *MetricsHandler) collectBusiness(ctx context.Context) []prometheus.Family {
+	if h.farm == nil {
+		return nil
+	}
+
+	summary, err := h.farm.GetFarmSummary(ctx)
+	if err != nil && ctx.Err() != nil {
+		log.Println("[GetMetricsHandler]-Timeout Collect Business Metrics")
+		return nil
+	}
+	if err != nil {
+		log.Println("[GetMetricsHandler]-Error Collect Business Metrics :", err)
+		return nil
+	}
+
+	observations := make([]float64, 0, len(summary.PondsPerFarm))
+	for _, count := range summary.PondsPerFarm {
+		observations = append(observations, float64(count))
+	}
+
+	return []prometheus.Family{
+		gauge("aqua_farm_active_farms", "Number of active farms.", float64(summary.ActiveFarms)),
+		gauge("aqua_farm_active_ponds", "Number of active ponds.", float64(summary.ActivePonds)),
+		{
+			Name: "aqua_farm_ponds_per_farm",
+			Help: "Distribution of active ponds per active farm.",
+			Type: prometheus.Histogram,
+			Metrics: []prometheus.Metric{
+				{Histogram: prometheus.NewHistogramValue(pondsPerFarmBuckets, observations)},
+			},
+		},
+	}
+}
+
+func gauge(name, help string, value float64) prometheus.Family {
+	return prometheus.Family{
+		Name:    name,
+		Help:    help,
+		Type:    prometheus.Gauge,
+		Metrics: []prometheus.Metric{{Value: value}},
+	}
+}
+
+func counter(name, help string, value float64) prometheus.Family {
+	return prometheus.Family{
+		Name:    name,
+		Help:    help,
+		Type:    prometheus.Counter,
+		Metrics: []prometheus.Metric{{Value: value}},
+	}
+}
@@ -0,0 +1,275 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"aqua-farm-manager/internal/domain/farm"
+	"aqua-farm-manager/internal/domain/farm/mock_farm"
+	mock_postgres "aqua-farm-manager/pkg/postgres/mock"
+	"aqua-farm-manager/pkg/prometheus"
+	"aqua-farm-manager/pkg/redis"
+	mock_redis "aqua-farm-manager/pkg/redis/mock"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jinzhu/gorm"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestNewMetricsHandler(t *testing.T) {
+	type args struct {
+		farm    farm.FarmDomain
+		options []Option
+	}
+	tests := []struct {
+		name string
+		args args
+		want *MetricsHandler
+	}{
+		{
+			name: "success without option",
+			args: args{
+				farm:    &farm.Farm{},
+				options: []Option{},
+			},
+			want: &MetricsHandler{
+				farm:         &farm.Farm{},
+				registry:     prometheus.Default,
+				timeoutInSec: 5,
+			},
+		},
+		{
+			name: "success wit option",
+			args: args{
+				farm:    &farm.Farm{},
+				options: []Option{WithTimeoutOptions(10)},
+			},
+			want: &MetricsHandler{
+				farm:         &farm.Farm{},
+				registry:     prometheus.Default,
+				timeoutInSec: 10,
+			},
+		},
+		{
+			name: "success wit invalid option value",
+			args: args{
+				farm:    &farm.Farm{},
+				options: []Option{WithTimeoutOptions(0)},
+			},
+			want: &MetricsHandler{
+				farm:         &farm.Farm{},
+				registry:     prometheus.Default,
+				timeoutInSec: 5,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewMetricsHandler(tt.args.farm, nil, nil, tt.args.options...); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewMetricsHandler() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+var (
+	sampleLine  = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*(\{[a-zA-Z_][a-zA-Z0-9_]*="(?:[^"\\]|\\.)*"(?:,[a-zA-Z_][a-zA-Z0-9_]*="(?:[^"\\]|\\.)*")*\})? [-+]?(?:[0-9.eE+-]+|Inf|NaN)$`)
+	commentLine = regexp.MustCompile(`^# (HELP|TYPE) ([a-zA-Z_:][a-zA-Z0-9_:]*) (.*)$`)
+)
+
+// validateExposition is func to check body follow prometheus text exposition format
+func validateExposition(t *testing.T, body string) {
+	typed := make(map[string]string)
+	for i, line := range strings.Split(strings.TrimSuffix(body, "\n"), "\n") {
+		if m := commentLine.FindStringSubmatch(line); m != nil {
+			if m[1] == "TYPE" {
+				typed[m[2]] = m[3]
+			}
+			continue
+		}
+		if !sampleLine.MatchString(line) {
+			t.Fatalf("line %d is not valid sample: %q", i+1, line)
+		}
+
+		name := line[:strings.IndexAny(line, "{ ")]
+		base := name
+		for _, suffix := range []string{"_bucket", "_sum", "_count"} {
+			if strings.HasSuffix(name, suffix) && typed[strings.TrimSuffix(name, suffix)] == string(prometheus.Histogram) {
+				base = strings.TrimSuffix(name, suffix)
+			}
+		}
+		if _, ok := typed[base]; !ok {
+			t.Fatalf("line %d sample %s appear before its TYPE", i+1, name)
+		}
+	}
+}
+
+func TestMetricsHandler_GetMetricsHandler(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	db, _, _ := sqlmock.New()
+	defer db.Close()
+	gormDB, _ := gorm.Open("postgres", db)
+
+	registry := prometheus.NewRegistry()
+	requests := registry.NewCounterVec("test_requests_total", "Total test requests.", "route")
+	requests.Inc(`/v1/"farms"`)
+	duration := registry.NewHistogramVec("test_duration_seconds", "Test duration.", []float64{0.1, 1}, "route")
+	duration.Observe(0.05, "/v1/farms")
+	duration.Observe(0.5, "/v1/farms")
+	duration.Observe(5, "/v1/farms")
+
+	type args struct {
+		timeout int
+	}
+	type want struct {
+		code     int
+		contains []string
+		excludes []string
+	}
+	tests := []struct {
+		name     string
+		args     args
+		mockFunc func(*mock_farm.MockFarmDomain, *mock_redis.MockRedisMethod, *mock_postgres.MockPostgresMethod)
+		want     want
+	}{
+		{
+			name: "success flow",
+			args: args{
+				timeout: 5,
+			},
+			mockFunc: func(mfd *mock_farm.MockFarmDomain, mrm *mock_redis.MockRedisMethod, mpm *mock_postgres.MockPostgresMethod) {
+				mfd.EXPECT().GetFarmSummary(gomock.Any()).Return(farm.FarmSummaryResponse{
+					ActiveFarms:  3,
+					ActivePonds:  14,
+					PondsPerFarm: []int{0, 4, 10},
+				}, nil)
+				mrm.EXPECT().Stats().Return(redis.PoolStats{ActiveCount: 4, IdleCount: 2, WaitCount: 7, WaitDuration: 1500 * time.Millisecond})
+				mpm.EXPECT().GetDB(gomock.Any()).Return(gormDB)
+			},
+			want: want{
+				code: 200,
+				contains: []string{
+					"# HELP test_requests_total Total test requests.\n# TYPE test_requests_total counter\n" + `test_requests_total{route="/v1/\"farms\""} 1` + "\n",
+					`test_duration_seconds_bucket{route="/v1/farms",le="0.1"} 1`,
+					`test_duration_seconds_bucket{route="/v1/farms",le="1"} 2`,
+					`test_duration_seconds_bucket{route="/v1/farms",le="+Inf"} 3`,
+					`test_duration_seconds_sum{route="/v1/farms"} 5.55`,
+					`test_duration_seconds_count{route="/v1/farms"} 3`,
+					"aqua_farm_redis_pool_active_connections 4\n",
+					"aqua_farm_redis_pool_idle_connections 2\n",
+					"aqua_farm_redis_pool_wait_total 7\n",
+					"aqua_farm_redis_pool_wait_duration_seconds_total 1.5\n",
+					"# TYPE aqua_farm_postgres_pool_open_connections gauge\n",
+					"aqua_farm_active_farms 3\n",
+					"aqua_farm_active_ponds 14\n",
+					`aqua_farm_ponds_per_farm_bucket{le="0"} 1`,
+					`aqua_farm_ponds_per_farm_bucket{le="3"} 1`,
+					`aqua_farm_ponds_per_farm_bucket{le="5"} 2`,
+					`aqua_farm_ponds_per_farm_bucket{le="10"} 3`,
+					`aqua_farm_ponds_per_farm_bucket{le="+Inf"} 3`,
+					"aqua_farm_ponds_per_farm_sum 14\n",
+					"aqua_farm_ponds_per_farm_count 3\n",
+				},
+			},
+		},
+		{
+			name: "got error business metrics flow",
+			args: args{
+				timeout: 5,
+			},
+			mockFunc: func(mfd *mock_farm.MockFarmDomain, mrm *mock_redis.MockRedisMethod, mpm *mock_postgres.MockPostgresMethod) {
+				mfd.EXPECT().GetFarmSummary(gomock.Any()).Return(farm.FarmSummaryResponse{}, fmt.Errorf("some error"))
+				mrm.EXPECT().Stats().Return(redis.PoolStats{})
+				mpm.EXPECT().GetDB(gomock.Any()).Return(nil)
+			},
+			want: want{
+				code: 200,
+				contains: []string{
+					"test_requests_total",
+					"aqua_farm_redis_pool_active_connections 0\n",
+				},
+				excludes: []string{
+					"aqua_farm_active_farms",
+					"aqua_farm_postgres_pool_open_connections",
+				},
+			},
+		},
+		{
+			name: "got timeout business metrics flow",
+			args: args{
+				timeout: 0,
+			},
+			mockFunc: func(mfd *mock_farm.MockFarmDomain, mrm *mock_redis.MockRedisMethod, mpm *mock_postgres.MockPostgresMethod) {
+				mfd.EXPECT().GetFarmSummary(gomock.Any()).Return(farm.FarmSummaryResponse{ActiveFarms: 1}, context.DeadlineExceeded).AnyTimes()
+				mrm.EXPECT().Stats().Return(redis.PoolStats{})
+				mpm.EXPECT().GetDB(gomock.Any()).Return(gormDB)
+			},
+			want: want{
+				code: 200,
+				contains: []string{
+					"test_requests_total",
+				},
+				excludes: []string{
+					"aqua_farm_active_farms",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			farmDomain := mock_farm.NewMockFarmDomain(mockCtrl)
+			redisMethod := mock_redis.NewMockRedisMethod(mockCtrl)
+			postgresMethod := mock_postgres.NewMockPostgresMethod(mockCtrl)
+			tt.mockFunc(farmDomain, redisMethod, postgresMethod)
+
+			handler := MetricsHandler{
+				farm:         farmDomain,
+				redis:        redisMethod,
+				postgres:     postgresMethod,
+				registry:     registry,
+				timeoutInSec: tt.args.timeout,
+			}
+
+			r := httptest.NewRequest(http.MethodGet, "/metrics", strings.NewReader(""))
+			w := httptest.NewRecorder()
+			handler.GetMetricsHandler(w, r)
+			result := w.Result()
+			resBody, err := ioutil.ReadAll(result.Body)
+
+			if err != nil {
+				t.Fatalf("Error read body err = %v\n", err)
+			}
+
+			if result.StatusCode != tt.want.code {
+				t.Fatalf("GetMetricsHandler status code got =%d, want %d \n", result.StatusCode, tt.want.code)
+			}
+
+			if got := result.Header.Get("Content-Type"); got != prometheus.ContentType {
+				t.Fatalf("GetMetricsHandler content type got =%s, want %s \n", got, prometheus.ContentType)
+			}
+
+			body := string(resBody)
+			validateExposition(t, body)
+			for _, c := range tt.want.contains {
+				if !strings.Contains(body, c) {
+					t.Fatalf("GetMetricsHandler body missing %q, got \n%s", c, body)
+				}
+			}
+			for _, e := range tt.want.excludes {
+				if strings.Contains(body, e) {
+					t.Fatalf("GetMetricsHandler body should not contain %q, got \n%s", e, body)
+				}
+			}
+		})
+	}
+}
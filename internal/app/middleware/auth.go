@@ -0,0 +1,30 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"aqua-farm-manager/pkg/apperror"
+	utilhttp "aqua-farm-manager/pkg/utilhttp"
+)
+
+// Auth is func to check the bearer token in authorization header before execute the handler,
+// every request is rejected when the auth token is empty so the route is never open by missing config
+func Auth(authToken string, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if len(authToken) < 1 || subtle.ConstantTimeCompare([]byte(token), []byte(authToken)) != 1 {
+			var response utilhttp.StandardResponse
+			response.SetError(apperror.New(apperror.CodeUnauthenticated, apperror.MessageUnauthenticated))
+			response.Code = http.StatusUnauthorized
+
+			data, _ := json.Marshal(response)
+			utilhttp.WriteResponse(w, data, http.StatusUnauthorized)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	}
+}
@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAuth(t *testing.T) {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("OK"))
+	})
+	unauthenticated := `{"code":401,"message":"Unauthenticated","errors":[{"code":"UNAUTHENTICATED","message":"Unauthenticated"}]}`
+
+	tests := []struct {
+		name      string
+		authToken string
+		header    string
+		want      int
+		wantBody  string
+	}{
+		{
+			name:      "valid token",
+			authToken: "secret",
+			header:    "Bearer secret",
+			want:      http.StatusOK,
+			wantBody:  "OK",
+		},
+		{
+			name:      "invalid token",
+			authToken: "secret",
+			header:    "Bearer other",
+			want:      http.StatusUnauthorized,
+			wantBody:  unauthenticated,
+		},
+		{
+			name:      "without token",
+			authToken: "secret",
+			want:      http.StatusUnauthorized,
+			wantBody:  unauthenticated,
+		},
+		{
+			name:     "empty auth token reject every request",
+			header:   "Bearer ",
+			want:     http.StatusUnauthorized,
+			wantBody: unauthenticated,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest("POST", "/v1/admin/dlq/replay", nil)
+			if tt.header != "" {
+				request.Header.Set("Authorization", tt.header)
+			}
+
+			Auth(tt.authToken, next)(recorder, request)
+
+			res := recorder.Result()
+			body, _ := ioutil.ReadAll(res.Body)
+			if res.StatusCode != tt.want {
+				t.Errorf("Auth() status = %v, want %v", res.StatusCode, tt.want)
+			}
+			if string(body) != tt.wantBody {
+				t.Errorf("Auth() body = %v, want %v", string(body), tt.wantBody)
+			}
+		})
+	}
+}
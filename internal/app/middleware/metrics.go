@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"aqua-farm-manager/pkg/prometheus"
+
+	"github.com/gorilla/mux"
+)
+
+// list http metrics labelled by route template to keep the cardinality low
+var (
+	requestsTotal = prometheus.Default.NewCounterVec(
+		"aqua_farm_http_requests_total",
+		"Total number of http request by method, route and status code.",
+		"method", "route", "code",
+	)
+	requestDuration = prometheus.Default.NewHistogramVec(
+		"aqua_farm_http_request_duration_seconds",
+		"Duration of http request in seconds by method and route.",
+		prometheus.DefBuckets,
+		"method", "route",
+	)
+)
+
+// Metrics is func to count every request of the router and observe its duration by route template,
+// it is used by the router so the route without tracking middleware is measured too
+func Metrics(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sw := &statusResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
+		start := time.Now()
+
+		next.ServeHTTP(sw, r)
+
+		route := routeTemplate(r)
+		requestsTotal.Inc(r.Method, route, strconv.Itoa(sw.statusCode))
+		requestDuration.Observe(time.Since(start).Seconds(), r.Method, route)
+	})
+}
+
+// routeTemplate is func to get registered path template of the request
+func routeTemplate(r *http.Request) string {
+	route := mux.CurrentRoute(r)
+	if route == nil {
+		return "unmatched"
+	}
+
+	template, err := route.GetPathTemplate()
+	if err != nil {
+		return "unmatched"
+	}
+	return template
+}
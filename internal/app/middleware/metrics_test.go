@@ -0,0 +1,87 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"aqua-farm-manager/pkg/prometheus"
+
+	"github.com/gorilla/mux"
+)
+
+// sampleValue is func to get value of metric family with the label values, histogram return its count
+func sampleValue(name string, labels map[string]string) float64 {
+	for _, family := range prometheus.Default.Gather() {
+		if family.Name != name {
+			continue
+		}
+		for _, metric := range family.Metrics {
+			matched := 0
+			for _, label := range metric.Labels {
+				if labels[label.Name] == label.Value {
+					matched++
+				}
+			}
+			if matched != len(labels) {
+				continue
+			}
+			if metric.Histogram != nil {
+				return float64(metric.Histogram.Count)
+			}
+			return metric.Value
+		}
+	}
+	return 0
+}
+
+func TestMetrics(t *testing.T) {
+	r := mux.NewRouter()
+	r.Use(Metrics)
+	r.HandleFunc("/v1/metrics-test/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusCreated)
+	}).Methods("POST")
+	r.HandleFunc("/metrics-test", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("OK"))
+	}).Methods("GET")
+
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		route  string
+		code   string
+	}{
+		{
+			name:   "route with path variable is counted by its template",
+			method: http.MethodPost,
+			path:   "/v1/metrics-test/7",
+			route:  "/v1/metrics-test/{id}",
+			code:   "201",
+		},
+		{
+			name:   "route without status code is counted as ok",
+			method: http.MethodGet,
+			path:   "/metrics-test",
+			route:  "/metrics-test",
+			code:   "200",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			counter := map[string]string{"method": tt.method, "route": tt.route, "code": tt.code}
+			histogram := map[string]string{"method": tt.method, "route": tt.route}
+			wantTotal := sampleValue("aqua_farm_http_requests_total", counter) + 1
+			wantCount := sampleValue("aqua_farm_http_request_duration_seconds", histogram) + 1
+
+			r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(tt.method, tt.path, nil))
+
+			if got := sampleValue("aqua_farm_http_requests_total", counter); got != wantTotal {
+				t.Errorf("Metrics() requests total = %v, want %v", got, wantTotal)
+			}
+			if got := sampleValue("aqua_farm_http_request_duration_seconds", histogram); got != wantCount {
+				t.Errorf("Metrics() request duration count = %v, want %v", got, wantCount)
+			}
+		})
+	}
+}
@@ -5,20 +5,19 @@ import (
 	"net/http"
 
 	"aqua-farm-manager/internal/app/trackingevent"
-	"aqua-farm-manager/pkg/nsq"
+
+	"github.com/hashicorp/go-uuid"
 )
 
 // Middleware struct is list dependecies to run Middleware func
 type Middleware struct {
-	nsq   nsq.NsqMethod
-	topic string
+	publisher *Publisher
 }
 
 // NewMiddleware is func to create Middleware Struct
-func NewMiddleware(topic string, nsq nsq.NsqMethod) Middleware {
+func NewMiddleware(publisher *Publisher) Middleware {
 	return Middleware{
-		nsq:   nsq,
-		topic: topic,
+		publisher: publisher,
 	}
 }
 
@@ -36,31 +35,46 @@ func (w *statusResponseWriter) WriteHeader(statusCode int) {
 	w.ResponseWriter.WriteHeader(statusCode)
 }
 
+// Flush is func to send the buffered body to the client when the wrapped writer support it,
+// so the streamed response is not held by the middleware
+func (w *statusResponseWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
 // Middleware is func to validate before execute the handler
 func (m *Middleware) Middleware(next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		path := r.URL.Path
 		method := r.Method
 		ua := r.UserAgent()
-		sw := &statusResponseWriter{ResponseWriter: w}
+		sw := &statusResponseWriter{ResponseWriter: w, statusCode: http.StatusOK}
 
 		next.ServeHTTP(sw, r)
-		go func() {
-			m.publishToTrackingEvent(path, method, ua, sw.statusCode)
-		}()
+
+		m.Track(path, method, ua, sw.statusCode)
 	}
 }
 
-func (m *Middleware) publishToTrackingEvent(path, method, ua string, code int) {
-	msg := trackingevent.TrackingEventMessage{
-		Path:   path,
-		Code:   code,
-		Method: method,
-		UA:     ua,
+// Track is func to publish tracking event of a request, it is used by other transport
+// so the request is counted in stat the same way as http request
+func (m *Middleware) Track(path, method, ua string, code int) {
+	eventID, err := uuid.GenerateUUID()
+	if err != nil {
+		fmt.Println("Middleware-Got Error while Generate Event ID :", err)
+		return
 	}
 
-	err := m.nsq.Publish(m.topic, msg)
-	if err != nil {
-		fmt.Println("Middleware-Got Error while Publish :", err)
+	msg := trackingevent.TrackingEventMessage{
+		EventID: eventID,
+		Version: trackingevent.TrackingEventVersion,
+		Path:    path,
+		Code:    code,
+		Method:  method,
+		UA:      ua,
 	}
+
+	// the event is published by publisher worker so the request is not blocked by nsq
+	m.publisher.Enqueue(msg)
 }
@@ -3,6 +3,7 @@ package middleware
 import (
 	"aqua-farm-manager/pkg/nsq"
 	"aqua-farm-manager/pkg/nsq/mock_nsq"
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
@@ -12,9 +13,9 @@ import (
 )
 
 func TestNewMiddleware(t *testing.T) {
+	publisher := NewPublisher("topic", &nsq.Client{})
 	type args struct {
-		topic string
-		nsq   nsq.NsqMethod
+		publisher *Publisher
 	}
 	tests := []struct {
 		name string
@@ -24,18 +25,16 @@ func TestNewMiddleware(t *testing.T) {
 		{
 			name: "success",
 			args: args{
-				topic: "topic",
-				nsq:   &nsq.Client{},
+				publisher: publisher,
 			},
 			want: Middleware{
-				nsq:   &nsq.Client{},
-				topic: "topic",
+				publisher: publisher,
 			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := NewMiddleware(tt.args.topic, tt.args.nsq); !reflect.DeepEqual(got, tt.want) {
+			if got := NewMiddleware(tt.args.publisher); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("NewMiddleware() = %v, want %v", got, tt.want)
 			}
 		})
@@ -52,7 +51,7 @@ func TestMiddleware_Middleware(t *testing.T) {
 		_, _ = w.Write([]byte("OK"))
 	})
 
-	nsqMock.EXPECT().Publish(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	nsqMock.EXPECT().MultiPublish(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 	tests := []struct {
 		name       string
 		status     int
@@ -78,8 +77,11 @@ func TestMiddleware_Middleware(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			publisher := NewPublisher("topic", nsqMock)
+			publisher.Start()
+			defer publisher.Close(context.Background())
 			m := &Middleware{
-				nsq: nsqMock,
+				publisher: publisher,
 			}
 
 			var gotPath string
@@ -114,3 +116,17 @@ func TestMiddleware_Middleware(t *testing.T) {
 		})
 	}
 }
+
+func TestStatusResponseWriter_Flush(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	var w http.ResponseWriter = &statusResponseWriter{ResponseWriter: recorder, statusCode: http.StatusOK}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		t.Fatalf("statusResponseWriter is not http.Flusher")
+	}
+	flusher.Flush()
+	if !recorder.Flushed {
+		t.Errorf("statusResponseWriter.Flush() is not flushed into the wrapped writer")
+	}
+}
@@ -0,0 +1,266 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"aqua-farm-manager/internal/app/trackingevent"
+	"aqua-farm-manager/pkg/bus"
+	"aqua-farm-manager/pkg/nsq"
+	"aqua-farm-manager/pkg/prometheus"
+)
+
+// droppedTotal count tracking event that never published by reason
+var droppedTotal = prometheus.Default.NewCounterVec(
+	"aqua_farm_tracking_event_dropped_total",
+	"Total number of tracking event dropped before published to nsq by reason.",
+	"reason",
+)
+
+// OverflowPolicy denotes what to do with new event when the buffer is full
+type OverflowPolicy string
+
+// list supported overflow policy
+const (
+	// DropNewest drop the new event and keep the buffered one
+	DropNewest OverflowPolicy = "drop_newest"
+	// DropOldest drop the oldest buffered event to make room for the new one
+	DropOldest OverflowPolicy = "drop_oldest"
+	// Block wait until the buffer has room or the block timeout is reached then drop the new event
+	Block OverflowPolicy = "block"
+)
+
+// list reason of dropped event
+const (
+	reasonBufferFull   = "buffer_full"
+	reasonClosed       = "closed"
+	reasonPublishError = "publish_error"
+	reasonInvalid      = "invalid"
+)
+
+const (
+	defaultBufferSize     = 1000
+	defaultBatchSize      = 50
+	defaultNumWorker      = 2
+	defaultFlushInterval  = 100 * time.Millisecond
+	defaultBlockTimeout   = 50 * time.Millisecond
+	defaultOverflowPolicy = DropNewest
+)
+
+// Publisher is bounded buffer of tracking event that is published in batch by fixed number of worker
+type Publisher struct {
+	publisher     bus.Publisher
+	topic         string
+	bufferSize    int
+	batchSize     int
+	numWorker     int
+	flushInterval time.Duration
+	blockTimeout  time.Duration
+	policy        OverflowPolicy
+
+	events  chan trackingevent.TrackingEventMessage
+	mu      sync.RWMutex
+	closed  bool
+	wg      sync.WaitGroup
+	dropped uint64
+}
+
+// PublisherOption set options for publisher config
+type PublisherOption func(*Publisher)
+
+// NewPublisher is func to create Publisher, Start must be called before the event is published
+func NewPublisher(topic string, publisher bus.Publisher, options ...PublisherOption) *Publisher {
+	p := &Publisher{
+		publisher:     publisher,
+		topic:         topic,
+		bufferSize:    defaultBufferSize,
+		batchSize:     defaultBatchSize,
+		numWorker:     defaultNumWorker,
+		flushInterval: defaultFlushInterval,
+		blockTimeout:  defaultBlockTimeout,
+		policy:        defaultOverflowPolicy,
+	}
+
+	// Apply options
+	for _, opt := range options {
+		opt(p)
+	}
+
+	p.events = make(chan trackingevent.TrackingEventMessage, p.bufferSize)
+	return p
+}
+
+// WithBufferOptions is func to set buffer size and max number of event in a batch
+func WithBufferOptions(bufferSize, batchSize int) PublisherOption {
+	return PublisherOption(
+		func(p *Publisher) {
+			if bufferSize > 0 {
+				p.bufferSize = bufferSize
+			}
+			if batchSize > 0 {
+				p.batchSize = batchSize
+			}
+		})
+}
+
+// WithWorkerOptions is func to set number of worker and max time an event wait in a batch
+func WithWorkerOptions(numWorker int, flushIntervalInMs int) PublisherOption {
+	return PublisherOption(
+		func(p *Publisher) {
+			if numWorker > 0 {
+				p.numWorker = numWorker
+			}
+			if flushIntervalInMs > 0 {
+				p.flushInterval = time.Duration(flushIntervalInMs) * time.Millisecond
+			}
+		})
+}
+
+// WithOverflowOptions is func to set overflow policy when the buffer is full,
+// block timeout is only used by Block policy
+func WithOverflowOptions(policy string, blockTimeoutInMs int) PublisherOption {
+	return PublisherOption(
+		func(p *Publisher) {
+			switch OverflowPolicy(policy) {
+			case DropNewest, DropOldest, Block:
+				p.policy = OverflowPolicy(policy)
+			}
+			if blockTimeoutInMs > 0 {
+				p.blockTimeout = time.Duration(blockTimeoutInMs) * time.Millisecond
+			}
+		})
+}
+
+// Start is func to start the publisher workers
+func (p *Publisher) Start() {
+	for i := 0; i < p.numWorker; i++ {
+		p.wg.Add(1)
+		go p.work()
+	}
+}
+
+// Enqueue is func to put event into the buffer without waiting the event is published,
+// it return false when the event is dropped
+func (p *Publisher) Enqueue(msg trackingevent.TrackingEventMessage) bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.closed {
+		p.drop(reasonClosed, 1)
+		return false
+	}
+
+	select {
+	case p.events <- msg:
+		return true
+	default:
+	}
+
+	switch p.policy {
+	case DropOldest:
+		select {
+		case <-p.events:
+			p.drop(reasonBufferFull, 1)
+		default:
+		}
+		select {
+		case p.events <- msg:
+			return true
+		default:
+		}
+	case Block:
+		timer := time.NewTimer(p.blockTimeout)
+		defer timer.Stop()
+		select {
+		case p.events <- msg:
+			return true
+		case <-timer.C:
+		}
+	}
+
+	p.drop(reasonBufferFull, 1)
+	return false
+}
+
+// Close is func to stop accepting event and wait the buffered event is published,
+// it return error when the context is done before the buffer is flushed
+func (p *Publisher) Close(ctx context.Context) error {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.events)
+	}
+	p.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("flush tracking event: %w", ctx.Err())
+	case <-done:
+		return nil
+	}
+}
+
+// Dropped is func to get number of dropped event
+func (p *Publisher) Dropped() uint64 {
+	return atomic.LoadUint64(&p.dropped)
+}
+
+func (p *Publisher) drop(reason string, n int) {
+	atomic.AddUint64(&p.dropped, uint64(n))
+	droppedTotal.Add(float64(n), reason)
+}
+
+// work is func to collect event into batch and publish it when the batch is full or the flush interval is reached
+func (p *Publisher) work() {
+	defer p.wg.Done()
+
+	batch := make([]interface{}, 0, p.batchSize)
+	ticker := time.NewTicker(p.flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case msg, ok := <-p.events:
+			if !ok {
+				p.publish(batch)
+				return
+			}
+			batch = append(batch, msg)
+			if len(batch) >= p.batchSize {
+				p.publish(batch)
+				batch = make([]interface{}, 0, p.batchSize)
+			}
+		case <-ticker.C:
+			if len(batch) > 0 {
+				p.publish(batch)
+				batch = make([]interface{}, 0, p.batchSize)
+			}
+		}
+	}
+}
+
+func (p *Publisher) publish(batch []interface{}) {
+	if len(batch) == 0 {
+		return
+	}
+
+	err := p.publisher.MultiPublish(p.topic, batch)
+	if err != nil {
+		fmt.Println("Middleware-Got Error while Publish :", err)
+		var invalidErr *nsq.InvalidMessagesError
+		if errors.As(err, &invalidErr) {
+			p.drop(reasonInvalid, invalidErr.Count)
+			return
+		}
+		p.drop(reasonPublishError, len(batch))
+	}
+}
@@ -0,0 +1,317 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"aqua-farm-manager/internal/app/trackingevent"
+	"aqua-farm-manager/pkg/nsq"
+	"aqua-farm-manager/pkg/nsq/mock_nsq"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestNewPublisher(t *testing.T) {
+	type args struct {
+		options []PublisherOption
+	}
+	tests := []struct {
+		name string
+		args args
+		want *Publisher
+	}{
+		{
+			name: "success without option",
+			args: args{
+				options: []PublisherOption{},
+			},
+			want: &Publisher{
+				publisher:     &nsq.Client{},
+				topic:         "topic",
+				bufferSize:    1000,
+				batchSize:     50,
+				numWorker:     2,
+				flushInterval: 100 * time.Millisecond,
+				blockTimeout:  50 * time.Millisecond,
+				policy:        DropNewest,
+			},
+		},
+		{
+			name: "success with option",
+			args: args{
+				options: []PublisherOption{
+					WithBufferOptions(10, 5),
+					WithWorkerOptions(3, 200),
+					WithOverflowOptions("block", 20),
+				},
+			},
+			want: &Publisher{
+				publisher:     &nsq.Client{},
+				topic:         "topic",
+				bufferSize:    10,
+				batchSize:     5,
+				numWorker:     3,
+				flushInterval: 200 * time.Millisecond,
+				blockTimeout:  20 * time.Millisecond,
+				policy:        Block,
+			},
+		},
+		{
+			name: "success with invalid option",
+			args: args{
+				options: []PublisherOption{
+					WithBufferOptions(0, -1),
+					WithWorkerOptions(0, 0),
+					WithOverflowOptions("unknown", 0),
+				},
+			},
+			want: &Publisher{
+				publisher:     &nsq.Client{},
+				topic:         "topic",
+				bufferSize:    1000,
+				batchSize:     50,
+				numWorker:     2,
+				flushInterval: 100 * time.Millisecond,
+				blockTimeout:  50 * time.Millisecond,
+				policy:        DropNewest,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewPublisher("topic", &nsq.Client{}, tt.args.options...)
+			if cap(got.events) != tt.want.bufferSize {
+				t.Errorf("NewPublisher() buffer = %v, want %v", cap(got.events), tt.want.bufferSize)
+			}
+			got.events = nil
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewPublisher() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func event(path string) trackingevent.TrackingEventMessage {
+	return trackingevent.TrackingEventMessage{Path: path}
+}
+
+// recordPublish is func to record every published batch into the returned func
+func recordPublish(m *mock_nsq.MockNsqMethod, err error) func() [][]interface{} {
+	var mu sync.Mutex
+	var batches [][]interface{}
+	m.EXPECT().MultiPublish("topic", gomock.Any()).DoAndReturn(func(topic string, data []interface{}) error {
+		mu.Lock()
+		defer mu.Unlock()
+		batches = append(batches, data)
+		return err
+	}).AnyTimes()
+	return func() [][]interface{} {
+		mu.Lock()
+		defer mu.Unlock()
+		return batches
+	}
+}
+
+func TestPublisher_Batch(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	nsqMock := mock_nsq.NewMockNsqMethod(mockCtrl)
+	batches := recordPublish(nsqMock, nil)
+
+	p := NewPublisher("topic", nsqMock, WithBufferOptions(10, 2), WithWorkerOptions(1, 60000))
+	p.Start()
+	for _, path := range []string{"/1", "/2", "/3", "/4", "/5"} {
+		if !p.Enqueue(event(path)) {
+			t.Fatalf("Publisher.Enqueue() dropped %s", path)
+		}
+	}
+	if err := p.Close(context.Background()); err != nil {
+		t.Fatalf("Publisher.Close() error = %v", err)
+	}
+
+	want := [][]interface{}{
+		{event("/1"), event("/2")},
+		{event("/3"), event("/4")},
+		{event("/5")},
+	}
+	if got := batches(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Publisher batches = %v, want %v", got, want)
+	}
+	if got := p.Dropped(); got != 0 {
+		t.Errorf("Publisher.Dropped() = %v, want 0", got)
+	}
+}
+
+func TestPublisher_FlushInterval(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	nsqMock := mock_nsq.NewMockNsqMethod(mockCtrl)
+	published := make(chan []interface{}, 1)
+	nsqMock.EXPECT().MultiPublish("topic", gomock.Any()).DoAndReturn(func(topic string, data []interface{}) error {
+		published <- data
+		return nil
+	})
+
+	p := NewPublisher("topic", nsqMock, WithBufferOptions(10, 5), WithWorkerOptions(1, 10))
+	p.Start()
+	defer p.Close(context.Background())
+	p.Enqueue(event("/1"))
+
+	select {
+	case got := <-published:
+		if want := []interface{}{event("/1")}; !reflect.DeepEqual(got, want) {
+			t.Errorf("Publisher batch = %v, want %v", got, want)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("Publisher did not flush partial batch on interval")
+	}
+}
+
+func TestPublisher_Overflow(t *testing.T) {
+	tests := []struct {
+		name        string
+		policy      string
+		wantQueued  []bool
+		wantPublish []interface{}
+		wantDropped uint64
+	}{
+		{
+			name:        "drop newest",
+			policy:      "drop_newest",
+			wantQueued:  []bool{true, true, false},
+			wantPublish: []interface{}{event("/1"), event("/2")},
+			wantDropped: 1,
+		},
+		{
+			name:        "drop oldest",
+			policy:      "drop_oldest",
+			wantQueued:  []bool{true, true, true},
+			wantPublish: []interface{}{event("/2"), event("/3")},
+			wantDropped: 1,
+		},
+		{
+			name:        "block until timeout",
+			policy:      "block",
+			wantQueued:  []bool{true, true, false},
+			wantPublish: []interface{}{event("/1"), event("/2")},
+			wantDropped: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			nsqMock := mock_nsq.NewMockNsqMethod(mockCtrl)
+			batches := recordPublish(nsqMock, nil)
+
+			// worker is not started so the buffer is full after 2 event
+			p := NewPublisher("topic", nsqMock, WithBufferOptions(2, 10), WithWorkerOptions(1, 60000), WithOverflowOptions(tt.policy, 10))
+			var queued []bool
+			for _, path := range []string{"/1", "/2", "/3"} {
+				queued = append(queued, p.Enqueue(event(path)))
+			}
+			if !reflect.DeepEqual(queued, tt.wantQueued) {
+				t.Errorf("Publisher.Enqueue() = %v, want %v", queued, tt.wantQueued)
+			}
+
+			p.Start()
+			if err := p.Close(context.Background()); err != nil {
+				t.Fatalf("Publisher.Close() error = %v", err)
+			}
+			if got := batches(); !reflect.DeepEqual(got, [][]interface{}{tt.wantPublish}) {
+				t.Errorf("Publisher batches = %v, want %v", got, [][]interface{}{tt.wantPublish})
+			}
+			if got := p.Dropped(); got != tt.wantDropped {
+				t.Errorf("Publisher.Dropped() = %v, want %v", got, tt.wantDropped)
+			}
+		})
+	}
+}
+
+func TestPublisher_PublishError(t *testing.T) {
+	tests := []struct {
+		name        string
+		err         error
+		wantDropped uint64
+	}{
+		{
+			name:        "nsq error drop whole batch",
+			err:         fmt.Errorf("some error"),
+			wantDropped: 3,
+		},
+		{
+			name:        "invalid message drop only skipped message",
+			err:         &nsq.InvalidMessagesError{Count: 1, Err: nsq.ErrInvalidMessage},
+			wantDropped: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			nsqMock := mock_nsq.NewMockNsqMethod(mockCtrl)
+			recordPublish(nsqMock, tt.err)
+
+			p := NewPublisher("topic", nsqMock, WithBufferOptions(10, 10), WithWorkerOptions(1, 60000))
+			p.Start()
+			for _, path := range []string{"/1", "/2", "/3"} {
+				p.Enqueue(event(path))
+			}
+			if err := p.Close(context.Background()); err != nil {
+				t.Fatalf("Publisher.Close() error = %v", err)
+			}
+			if got := p.Dropped(); got != tt.wantDropped {
+				t.Errorf("Publisher.Dropped() = %v, want %v", got, tt.wantDropped)
+			}
+		})
+	}
+}
+
+func TestPublisher_Close(t *testing.T) {
+	t.Run("enqueue after close is dropped", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		nsqMock := mock_nsq.NewMockNsqMethod(mockCtrl)
+
+		p := NewPublisher("topic", nsqMock)
+		p.Start()
+		if err := p.Close(context.Background()); err != nil {
+			t.Fatalf("Publisher.Close() error = %v", err)
+		}
+		if p.Enqueue(event("/1")) {
+			t.Errorf("Publisher.Enqueue() after close = true, want false")
+		}
+		if got := p.Dropped(); got != 1 {
+			t.Errorf("Publisher.Dropped() = %v, want 1", got)
+		}
+		if err := p.Close(context.Background()); err != nil {
+			t.Errorf("Publisher.Close() twice error = %v", err)
+		}
+	})
+
+	t.Run("close timeout when nsq is slow", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		nsqMock := mock_nsq.NewMockNsqMethod(mockCtrl)
+		release := make(chan struct{})
+		nsqMock.EXPECT().MultiPublish("topic", gomock.Any()).DoAndReturn(func(topic string, data []interface{}) error {
+			<-release
+			return nil
+		})
+
+		p := NewPublisher("topic", nsqMock, WithWorkerOptions(1, 60000))
+		p.Start()
+		p.Enqueue(event("/1"))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		if err := p.Close(ctx); err == nil {
+			t.Errorf("Publisher.Close() error = nil, want timeout")
+		}
+		close(release)
+	})
+}
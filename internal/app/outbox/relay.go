@@ -0,0 +1,249 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	outboxinfra "aqua-farm-manager/internal/infrastructure/outbox"
+	"aqua-farm-manager/pkg/bus"
+	"aqua-farm-manager/pkg/nsq"
+	"aqua-farm-manager/pkg/prometheus"
+)
+
+// relayedTotal count outbox event handled by relay by topic and result
+var relayedTotal = prometheus.Default.NewCounterVec(
+	"aqua_farm_outbox_events_total",
+	"Total number of outbox event handled by relay by topic and result.",
+	"topic", "result",
+)
+
+// list result of relayed event
+const (
+	resultPublished = "published"
+	resultFailed    = "failed"
+	resultDiscarded = "discarded"
+	resultExhausted = "exhausted"
+)
+
+const (
+	defaultPollInterval    = time.Second
+	defaultBatchSize       = 100
+	defaultMaxAttempts     = 50
+	defaultMaxBackoff      = time.Minute
+	defaultRetention       = 7 * 24 * time.Hour
+	defaultCleanupInterval = time.Hour
+)
+
+// Relay is worker that publish pending outbox event to message bus,
+// event is marked published only after the bus accept it so it is delivered at least once.
+// Event that fail to be published is kept pending and retried with capped backoff until the bus recover,
+// only event that never match the topic schema is discarded. Published event is deleted after the retention
+type Relay struct {
+	store           outboxinfra.OutboxStore
+	publisher       bus.Publisher
+	pollInterval    time.Duration
+	batchSize       int
+	maxAttempts     int
+	maxBackoff      time.Duration
+	retention       time.Duration
+	cleanupInterval time.Duration
+
+	// retryAt is time the next poll is allowed after failed publish, it is only used by the relay goroutine
+	retryAt time.Time
+
+	stop chan struct{}
+	done chan struct{}
+	once sync.Once
+}
+
+// Option set options for relay config
+type Option func(*Relay)
+
+// NewRelay is func to create Relay, Start must be called before the event is published
+func NewRelay(store outboxinfra.OutboxStore, publisher bus.Publisher, options ...Option) *Relay {
+	r := &Relay{
+		store:           store,
+		publisher:       publisher,
+		pollInterval:    defaultPollInterval,
+		batchSize:       defaultBatchSize,
+		maxAttempts:     defaultMaxAttempts,
+		maxBackoff:      defaultMaxBackoff,
+		retention:       defaultRetention,
+		cleanupInterval: defaultCleanupInterval,
+	}
+
+	// Apply options
+	for _, opt := range options {
+		opt(r)
+	}
+
+	r.stop = make(chan struct{})
+	r.done = make(chan struct{})
+	return r
+}
+
+// WithPollOptions is func to set interval to poll pending event and max number of event in a poll
+func WithPollOptions(pollIntervalInMs, batchSize int) Option {
+	return Option(
+		func(r *Relay) {
+			if pollIntervalInMs > 0 {
+				r.pollInterval = time.Duration(pollIntervalInMs) * time.Millisecond
+			}
+			if batchSize > 0 {
+				r.batchSize = batchSize
+			}
+		})
+}
+
+// WithMaxAttemptsOptions is func to set failed publish attempt after which the event is reported as exhausted,
+// the event is still kept pending and retried so event of committed write is never lost
+func WithMaxAttemptsOptions(maxAttempts int) Option {
+	return Option(
+		func(r *Relay) {
+			if maxAttempts > 0 {
+				r.maxAttempts = maxAttempts
+			}
+		})
+}
+
+// WithRetentionOptions is func to set how long published event is kept before it is deleted
+func WithRetentionOptions(retentionInHours int) Option {
+	return Option(
+		func(r *Relay) {
+			if retentionInHours > 0 {
+				r.retention = time.Duration(retentionInHours) * time.Hour
+			}
+		})
+}
+
+// Start is func to start polling pending event and cleanup published event in background
+func (r *Relay) Start() {
+	go func() {
+		defer close(r.done)
+
+		ticker := time.NewTicker(r.pollInterval)
+		defer ticker.Stop()
+		cleanup := time.NewTicker(r.cleanupInterval)
+		defer cleanup.Stop()
+		for {
+			select {
+			case <-r.stop:
+				return
+			case <-ticker.C:
+				r.drain()
+			case <-cleanup.C:
+				if _, err := r.Cleanup(context.Background()); err != nil {
+					fmt.Println("OutboxRelay-Got Error while Cleanup :", err)
+				}
+			}
+		}
+	}()
+}
+
+// drain is func to keep relaying while the batch is full so backlog is published without waiting next tick,
+// the poll is skipped until the backoff of the last failed publish is over
+func (r *Relay) drain() {
+	if time.Now().Before(r.retryAt) {
+		return
+	}
+
+	for {
+		// the batch is not cancelled on Close, a published event must be marked before the relay stop
+		n, err := r.Relay(context.Background())
+		if err != nil {
+			fmt.Println("OutboxRelay-Got Error while Relay :", err)
+			return
+		}
+		if n < r.batchSize {
+			return
+		}
+
+		select {
+		case <-r.stop:
+			return
+		default:
+		}
+	}
+}
+
+// Close is func to stop the relay and wait the running poll is done
+func (r *Relay) Close(ctx context.Context) error {
+	r.once.Do(func() { close(r.stop) })
+
+	select {
+	case <-ctx.Done():
+		return fmt.Errorf("stop outbox relay: %w", ctx.Err())
+	case <-r.done:
+		return nil
+	}
+}
+
+// Relay is func to publish one batch of pending event in insertion order,
+// it stop at the first failed event so the event of an aggregate is never published out of order
+func (r *Relay) Relay(ctx context.Context) (int, error) {
+	events, err := r.store.GetPendingEvents(ctx, r.batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	var published []uint
+	var publishErr error
+	for _, e := range events {
+		err = r.publisher.Publish(e.Topic, json.RawMessage(e.Payload))
+		if errors.Is(err, nsq.ErrInvalidMessage) {
+			// the event will never match the topic schema, keep it in the table for investigation
+			fmt.Println("OutboxRelay-Discard Event :", e.EventID, err)
+			relayedTotal.Inc(e.Topic, resultDiscarded)
+			if err := r.store.MarkDiscarded(ctx, e.ID); err != nil {
+				publishErr = err
+				break
+			}
+			continue
+		}
+		if err != nil {
+			// the bus is not available, the event is kept pending so it is published when the bus recover
+			relayedTotal.Inc(e.Topic, resultFailed)
+			if e.Attempts+1 == r.maxAttempts {
+				fmt.Println("OutboxRelay-Event Exhausted Max Attempts :", e.EventID, err)
+				relayedTotal.Inc(e.Topic, resultExhausted)
+			}
+			if err := r.store.IncrAttempts(ctx, e.ID); err != nil {
+				fmt.Println("OutboxRelay-Got Error while Incr Attempts :", err)
+			}
+			r.retryAt = time.Now().Add(r.backoff(e.Attempts + 1))
+			publishErr = err
+			break
+		}
+		relayedTotal.Inc(e.Topic, resultPublished)
+		published = append(published, e.ID)
+	}
+
+	// event that is published but not marked is published again on next poll
+	err = r.store.MarkPublished(ctx, published)
+	if err != nil {
+		return len(published), err
+	}
+	return len(published), publishErr
+}
+
+// backoff is func to get delay before the next poll after the event failed attempts times,
+// it start from the poll interval and it is doubled on every attempt up to the max backoff
+func (r *Relay) backoff(attempts int) time.Duration {
+	delay := r.pollInterval
+	for i := 1; i < attempts && delay < r.maxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > r.maxBackoff {
+		delay = r.maxBackoff
+	}
+	return delay
+}
+
+// Cleanup is func to delete published event that is older than the retention
+func (r *Relay) Cleanup(ctx context.Context) (int64, error) {
+	return r.store.DeletePublished(ctx, time.Now().Add(-r.retention))
+}
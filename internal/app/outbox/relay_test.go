@@ -0,0 +1,354 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	outboxinfra "aqua-farm-manager/internal/infrastructure/outbox"
+	"aqua-farm-manager/internal/infrastructure/outbox/mock_outbox"
+	"aqua-farm-manager/pkg/nsq"
+	"aqua-farm-manager/pkg/nsq/mock_nsq"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestNewRelay(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []Option
+		want    *Relay
+	}{
+		{
+			name:    "success without option",
+			options: []Option{},
+			want: &Relay{
+				publisher:       &nsq.Client{},
+				pollInterval:    time.Second,
+				batchSize:       100,
+				maxAttempts:     50,
+				maxBackoff:      time.Minute,
+				retention:       7 * 24 * time.Hour,
+				cleanupInterval: time.Hour,
+			},
+		},
+		{
+			name:    "success with option",
+			options: []Option{WithPollOptions(200, 10), WithMaxAttemptsOptions(3), WithRetentionOptions(24)},
+			want: &Relay{
+				publisher:       &nsq.Client{},
+				pollInterval:    200 * time.Millisecond,
+				batchSize:       10,
+				maxAttempts:     3,
+				maxBackoff:      time.Minute,
+				retention:       24 * time.Hour,
+				cleanupInterval: time.Hour,
+			},
+		},
+		{
+			name:    "success with invalid option",
+			options: []Option{WithPollOptions(0, -1), WithMaxAttemptsOptions(0), WithRetentionOptions(-1)},
+			want: &Relay{
+				publisher:       &nsq.Client{},
+				pollInterval:    time.Second,
+				batchSize:       100,
+				maxAttempts:     50,
+				maxBackoff:      time.Minute,
+				retention:       7 * 24 * time.Hour,
+				cleanupInterval: time.Hour,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := NewRelay(nil, &nsq.Client{}, tt.options...)
+			got.stop = nil
+			got.done = nil
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewRelay() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func pendingEvents(ids ...uint) []outboxinfra.OutboxInfraInfo {
+	var list []outboxinfra.OutboxInfraInfo
+	for _, id := range ids {
+		list = append(list, outboxinfra.OutboxInfraInfo{
+			ID:      id,
+			EventID: fmt.Sprint(id),
+			Topic:   "topic",
+			Payload: fmt.Sprintf(`{"id":%d}`, id),
+		})
+	}
+	return list
+}
+
+func TestRelay_Relay(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	store := mock_outbox.NewMockOutboxStore(mockCtrl)
+	nsqMock := mock_nsq.NewMockNsqMethod(mockCtrl)
+
+	tests := []struct {
+		name     string
+		mockFunc func()
+		want     int
+		wantErr  bool
+	}{
+		{
+			name: "success publish every event in order",
+			mockFunc: func() {
+				store.EXPECT().GetPendingEvents(gomock.Any(), 10).Return(pendingEvents(1, 2), nil)
+				gomock.InOrder(
+					nsqMock.EXPECT().Publish("topic", json.RawMessage(`{"id":1}`)).Return(nil),
+					nsqMock.EXPECT().Publish("topic", json.RawMessage(`{"id":2}`)).Return(nil),
+				)
+				store.EXPECT().MarkPublished(gomock.Any(), []uint{1, 2}).Return(nil)
+			},
+			want:    2,
+			wantErr: false,
+		},
+		{
+			name: "stop at first failed event",
+			mockFunc: func() {
+				store.EXPECT().GetPendingEvents(gomock.Any(), 10).Return(pendingEvents(1, 2, 3), nil)
+				nsqMock.EXPECT().Publish("topic", json.RawMessage(`{"id":1}`)).Return(nil)
+				nsqMock.EXPECT().Publish("topic", json.RawMessage(`{"id":2}`)).Return(fmt.Errorf("some error"))
+				store.EXPECT().IncrAttempts(gomock.Any(), uint(2)).Return(nil)
+				store.EXPECT().MarkPublished(gomock.Any(), []uint{1}).Return(nil)
+			},
+			want:    1,
+			wantErr: true,
+		},
+		{
+			name: "discard event that does not match schema",
+			mockFunc: func() {
+				store.EXPECT().GetPendingEvents(gomock.Any(), 10).Return(pendingEvents(1, 2), nil)
+				nsqMock.EXPECT().Publish("topic", json.RawMessage(`{"id":1}`)).Return(fmt.Errorf("%w: some violation", nsq.ErrInvalidMessage))
+				store.EXPECT().MarkDiscarded(gomock.Any(), uint(1)).Return(nil)
+				nsqMock.EXPECT().Publish("topic", json.RawMessage(`{"id":2}`)).Return(nil)
+				store.EXPECT().MarkPublished(gomock.Any(), []uint{2}).Return(nil)
+			},
+			want:    1,
+			wantErr: false,
+		},
+		{
+			name: "keep event pending after max attempts",
+			mockFunc: func() {
+				events := pendingEvents(1, 2)
+				events[0].Attempts = 2
+				store.EXPECT().GetPendingEvents(gomock.Any(), 10).Return(events, nil)
+				nsqMock.EXPECT().Publish("topic", json.RawMessage(`{"id":1}`)).Return(fmt.Errorf("some error"))
+				store.EXPECT().IncrAttempts(gomock.Any(), uint(1)).Return(nil)
+				store.EXPECT().MarkPublished(gomock.Any(), nil).Return(nil)
+			},
+			want:    0,
+			wantErr: true,
+		},
+		{
+			name: "keep event pending after got error incr attempts",
+			mockFunc: func() {
+				events := pendingEvents(1, 2)
+				events[0].Attempts = 5
+				store.EXPECT().GetPendingEvents(gomock.Any(), 10).Return(events, nil)
+				nsqMock.EXPECT().Publish("topic", json.RawMessage(`{"id":1}`)).Return(fmt.Errorf("some error"))
+				store.EXPECT().IncrAttempts(gomock.Any(), uint(1)).Return(fmt.Errorf("some error"))
+				store.EXPECT().MarkPublished(gomock.Any(), nil).Return(nil)
+			},
+			want:    0,
+			wantErr: true,
+		},
+		{
+			name: "got error mark published",
+			mockFunc: func() {
+				store.EXPECT().GetPendingEvents(gomock.Any(), 10).Return(pendingEvents(1), nil)
+				nsqMock.EXPECT().Publish("topic", json.RawMessage(`{"id":1}`)).Return(nil)
+				store.EXPECT().MarkPublished(gomock.Any(), []uint{1}).Return(fmt.Errorf("some error"))
+			},
+			want:    1,
+			wantErr: true,
+		},
+		{
+			name: "got error get pending events",
+			mockFunc: func() {
+				store.EXPECT().GetPendingEvents(gomock.Any(), 10).Return(nil, fmt.Errorf("some error"))
+			},
+			want:    0,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			r := NewRelay(store, nsqMock, WithPollOptions(10, 10), WithMaxAttemptsOptions(3))
+			got, err := r.Relay(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Relay.Relay() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Relay.Relay() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRelay_Relay_BusRecover(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	store := mock_outbox.NewMockOutboxStore(mockCtrl)
+	nsqMock := mock_nsq.NewMockNsqMethod(mockCtrl)
+
+	// the bus is down for more than max attempts, the event must stay pending until it is published
+	const failures = 5
+	var attempts int
+	published := make(chan struct{})
+	store.EXPECT().GetPendingEvents(gomock.Any(), 10).DoAndReturn(func(context.Context, int) ([]outboxinfra.OutboxInfraInfo, error) {
+		select {
+		case <-published:
+			return nil, nil
+		default:
+		}
+		events := pendingEvents(1)
+		events[0].Attempts = attempts
+		return events, nil
+	}).AnyTimes()
+	gomock.InOrder(
+		nsqMock.EXPECT().Publish("topic", json.RawMessage(`{"id":1}`)).Return(fmt.Errorf("some error")).Times(failures),
+		nsqMock.EXPECT().Publish("topic", json.RawMessage(`{"id":1}`)).Return(nil),
+	)
+	store.EXPECT().IncrAttempts(gomock.Any(), uint(1)).DoAndReturn(func(context.Context, uint) error {
+		attempts++
+		return nil
+	}).Times(failures)
+	store.EXPECT().MarkPublished(gomock.Any(), nil).Return(nil).AnyTimes()
+	store.EXPECT().MarkPublished(gomock.Any(), []uint{1}).DoAndReturn(func(context.Context, []uint) error {
+		close(published)
+		return nil
+	})
+	store.EXPECT().MarkDiscarded(gomock.Any(), gomock.Any()).Times(0)
+
+	r := NewRelay(store, nsqMock, WithPollOptions(1, 10), WithMaxAttemptsOptions(3))
+	r.maxBackoff = 4 * time.Millisecond
+	deadline := time.After(time.Second)
+	for {
+		r.drain()
+		select {
+		case <-published:
+			if attempts != failures {
+				t.Errorf("Relay attempts = %v, want %v", attempts, failures)
+			}
+			return
+		case <-deadline:
+			t.Fatal("Relay did not publish the event after the bus recover")
+		case <-time.After(time.Millisecond):
+		}
+	}
+}
+
+func TestRelay_backoff(t *testing.T) {
+	tests := []struct {
+		name     string
+		attempts int
+		want     time.Duration
+	}{
+		{
+			name:     "first attempt wait poll interval",
+			attempts: 1,
+			want:     time.Second,
+		},
+		{
+			name:     "next attempt double the delay",
+			attempts: 3,
+			want:     4 * time.Second,
+		},
+		{
+			name:     "delay is capped by max backoff",
+			attempts: 50,
+			want:     time.Minute,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewRelay(nil, nil)
+			if got := r.backoff(tt.attempts); got != tt.want {
+				t.Errorf("Relay.backoff() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRelay_Cleanup(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	store := mock_outbox.NewMockOutboxStore(mockCtrl)
+
+	start := time.Now()
+	store.EXPECT().DeletePublished(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, before time.Time) (int64, error) {
+		if want := start.Add(-24 * time.Hour); before.Before(want) || before.After(time.Now().Add(-24*time.Hour)) {
+			t.Errorf("Relay.Cleanup() before = %v, want %v", before, want)
+		}
+		return 3, nil
+	})
+
+	r := NewRelay(store, nil, WithRetentionOptions(24))
+	got, err := r.Cleanup(context.Background())
+	if err != nil || got != 3 {
+		t.Errorf("Relay.Cleanup() = %v, %v, want 3", got, err)
+	}
+}
+
+func TestRelay_Start(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	store := mock_outbox.NewMockOutboxStore(mockCtrl)
+	nsqMock := mock_nsq.NewMockNsqMethod(mockCtrl)
+
+	// first poll has full batch so it is drained without waiting next tick
+	published := make(chan struct{})
+	gomock.InOrder(
+		store.EXPECT().GetPendingEvents(gomock.Any(), 2).Return(pendingEvents(1, 2), nil),
+		store.EXPECT().GetPendingEvents(gomock.Any(), 2).Return(pendingEvents(3), nil),
+	)
+	nsqMock.EXPECT().Publish("topic", gomock.Any()).Return(nil).Times(3)
+	store.EXPECT().MarkPublished(gomock.Any(), []uint{1, 2}).Return(nil)
+	store.EXPECT().MarkPublished(gomock.Any(), []uint{3}).DoAndReturn(func(_ context.Context, ids []uint) error {
+		close(published)
+		return nil
+	})
+	store.EXPECT().GetPendingEvents(gomock.Any(), 2).Return(nil, nil).AnyTimes()
+	store.EXPECT().MarkPublished(gomock.Any(), nil).Return(nil).AnyTimes()
+
+	// published event is cleaned up on every cleanup interval
+	cleaned := make(chan struct{})
+	var cleanOnce sync.Once
+	store.EXPECT().DeletePublished(gomock.Any(), gomock.Any()).DoAndReturn(func(context.Context, time.Time) (int64, error) {
+		cleanOnce.Do(func() { close(cleaned) })
+		return 0, nil
+	}).MinTimes(1)
+
+	r := NewRelay(store, nsqMock, WithPollOptions(10, 2))
+	r.cleanupInterval = 10 * time.Millisecond
+	r.Start()
+	select {
+	case <-cleaned:
+	case <-time.After(time.Second):
+		t.Fatal("Relay did not cleanup published event")
+	}
+	select {
+	case <-published:
+	case <-time.After(time.Second):
+		t.Fatal("Relay did not publish pending event")
+	}
+
+	if err := r.Close(context.Background()); err != nil {
+		t.Errorf("Relay.Close() error = %v", err)
+	}
+	if err := r.Close(context.Background()); err != nil {
+		t.Errorf("Relay.Close() twice error = %v", err)
+	}
+}
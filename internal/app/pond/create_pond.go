@@ -3,24 +3,25 @@ package pond
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 	"io/ioutil"
 	"log"
 	"net/http"
 	"time"
 
 	"aqua-farm-manager/internal/domain/pond"
+	"aqua-farm-manager/pkg/apperror"
 	utilhttp "aqua-farm-manager/pkg/utilhttp"
+	"aqua-farm-manager/pkg/validator"
 )
 
 // CreatePondRequest is list request parameter for Create Api
 type CreatePondRequest struct {
-	Name         string  `json:"name"`
-	Capacity     float64 `json:"capacity"`
-	Depth        float64 `json:"depth"`
-	WaterQuality float64 `json:"water_quality"`
-	Species      string  `json:"species"`
-	FarmID       uint    `json:"farm_id"`
+	Name         string  `json:"name" validate:"required,max=100,charset=name"`
+	Capacity     float64 `json:"capacity" validate:"min=0,max=1000000000"`
+	Depth        float64 `json:"depth" validate:"min=0,max=100"`
+	WaterQuality float64 `json:"water_quality" validate:"min=0,max=14"`
+	Species      string  `json:"species" validate:"max=100,charset=name"`
+	FarmID       uint    `json:"farm_id" validate:"required"`
 }
 
 // CreatePondResponse is list response parameter for Create Api
@@ -38,12 +39,16 @@ func (h *PondHandler) CreatePondHandler(w http.ResponseWriter, r *http.Request)
 	var code int = http.StatusOK
 
 	defer func() {
-		response.Code = code
-		if err == nil {
-			response.Message = "success"
+		if err != nil {
+			code = apperror.HTTPStatus(err)
+			if code == http.StatusInternalServerError {
+				log.Println("[CreatePondHandler]-Internal Error :", err)
+			}
+			response.SetError(err)
 		} else {
-			response.Message = err.Error()
+			response.Message = "success"
 		}
+		response.Code = code
 
 		data, errMarshal := json.Marshal(response)
 		if errMarshal != nil {
@@ -57,55 +62,37 @@ func (h *PondHandler) CreatePondHandler(w http.ResponseWriter, r *http.Request)
 	var body CreatePondRequest
 	data, err := ioutil.ReadAll(r.Body)
 	if err != nil {
-		code = http.StatusBadRequest
-		err = fmt.Errorf("Bad Request")
+		err = apperror.New(apperror.CodeBadRequest, apperror.MessageBadRequest)
 		return
 	}
 
 	err = json.Unmarshal(data, &body)
 	if err != nil {
-		code = http.StatusBadRequest
-		err = fmt.Errorf("Bad Request")
+		err = apperror.New(apperror.CodeBadRequest, apperror.MessageBadRequest)
 		return
 	}
 
 	// checking valid body
-	if len(body.Name) < 1 || body.FarmID < 1 {
-		code = http.StatusBadRequest
-		err = fmt.Errorf("Invalid Parameter Request")
+	err = validator.Validate(body)
+	if err != nil {
 		return
 	}
 
-	errChan := make(chan error, 1)
 	var res pond.CreateDomainResponse
-	go func(ctx context.Context) {
-		res, err = h.domain.CreatePondInfo(pond.CreateDomainRequest{
-			Name:         body.Name,
-			Capacity:     body.Capacity,
-			Depth:        body.Depth,
-			WaterQuality: body.WaterQuality,
-			Species:      body.Species,
-			FarmID:       body.FarmID,
-		})
-		errChan <- err
-	}(ctx)
-
-	select {
-	case <-ctx.Done():
-		code = http.StatusGatewayTimeout
-		err = fmt.Errorf("Timeout")
+	res, err = h.domain.CreatePondInfo(ctx, pond.CreateDomainRequest{
+		Name:         body.Name,
+		Capacity:     body.Capacity,
+		Depth:        body.Depth,
+		WaterQuality: body.WaterQuality,
+		Species:      body.Species,
+		FarmID:       body.FarmID,
+	})
+	if err != nil && ctx.Err() != nil {
+		err = apperror.Wrap(apperror.CodeTimeout, apperror.MessageTimeout, err)
+		return
+	}
+	if err != nil {
 		return
-	case err = <-errChan:
-		if err != nil {
-			if err == pond.ErrDuplicatePond || err == pond.ErrMaxPond {
-				code = http.StatusConflict
-			} else if err == pond.ErrInvalidFarm {
-				code = http.StatusNotFound
-			} else {
-				code = http.StatusInternalServerError
-			}
-			return
-		}
 	}
 
 	response = mapResonse(res)
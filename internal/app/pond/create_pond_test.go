@@ -40,7 +40,7 @@ func TestPondHandler_CreatePondHandler(t *testing.T) {
 				return context.Background(), func() {}
 			},
 			mockFunc: func(pondDomain mock_pond.MockPondDomain) {
-				pondDomain.EXPECT().CreatePondInfo(gomock.Any()).Return(pond.CreateDomainResponse{
+				pondDomain.EXPECT().CreatePondInfo(gomock.Any(), gomock.Any()).Return(pond.CreateDomainResponse{
 					PondID: 1,
 				}, nil)
 			},
@@ -59,12 +59,12 @@ func TestPondHandler_CreatePondHandler(t *testing.T) {
 				return context.Background(), func() {}
 			},
 			mockFunc: func(pondDomain mock_pond.MockPondDomain) {
-				pondDomain.EXPECT().CreatePondInfo(gomock.Any()).Return(pond.CreateDomainResponse{
+				pondDomain.EXPECT().CreatePondInfo(gomock.Any(), gomock.Any()).Return(pond.CreateDomainResponse{
 					PondID: 1,
-				}, nil).AnyTimes()
+				}, context.DeadlineExceeded).AnyTimes()
 			},
 			want: want{
-				body: `{"code":504,"message":"Timeout"}`,
+				body: `{"code":504,"message":"Timeout","errors":[{"code":"TIMEOUT","message":"Timeout"}]}`,
 				code: 504,
 			},
 		},
@@ -78,10 +78,10 @@ func TestPondHandler_CreatePondHandler(t *testing.T) {
 				return context.Background(), func() {}
 			},
 			mockFunc: func(pondDomain mock_pond.MockPondDomain) {
-				pondDomain.EXPECT().CreatePondInfo(gomock.Any()).Return(pond.CreateDomainResponse{}, pond.ErrDuplicatePond)
+				pondDomain.EXPECT().CreatePondInfo(gomock.Any(), gomock.Any()).Return(pond.CreateDomainResponse{}, pond.ErrDuplicatePond)
 			},
 			want: want{
-				body: `{"code":409,"message":"Pond Is Already Exists"}`,
+				body: `{"code":409,"message":"Pond Is Already Exists","errors":[{"code":"POND_ALREADY_EXISTS","message":"Pond Is Already Exists"}]}`,
 				code: 409,
 			},
 		},
@@ -95,10 +95,10 @@ func TestPondHandler_CreatePondHandler(t *testing.T) {
 				return context.Background(), func() {}
 			},
 			mockFunc: func(pondDomain mock_pond.MockPondDomain) {
-				pondDomain.EXPECT().CreatePondInfo(gomock.Any()).Return(pond.CreateDomainResponse{}, pond.ErrInvalidFarm)
+				pondDomain.EXPECT().CreatePondInfo(gomock.Any(), gomock.Any()).Return(pond.CreateDomainResponse{}, pond.ErrInvalidFarm)
 			},
 			want: want{
-				body: `{"code":404,"message":"Farm Is Not Exists"}`,
+				body: `{"code":404,"message":"Farm Is Not Exists","errors":[{"code":"FARM_NOT_FOUND","message":"Farm Is Not Exists"}]}`,
 				code: 404,
 			},
 		},
@@ -112,10 +112,10 @@ func TestPondHandler_CreatePondHandler(t *testing.T) {
 				return context.Background(), func() {}
 			},
 			mockFunc: func(pondDomain mock_pond.MockPondDomain) {
-				pondDomain.EXPECT().CreatePondInfo(gomock.Any()).Return(pond.CreateDomainResponse{}, fmt.Errorf("Internal Server Error"))
+				pondDomain.EXPECT().CreatePondInfo(gomock.Any(), gomock.Any()).Return(pond.CreateDomainResponse{}, fmt.Errorf("Internal Server Error"))
 			},
 			want: want{
-				body: `{"code":500,"message":"Internal Server Error"}`,
+				body: `{"code":500,"message":"Internal Server Error","errors":[{"code":"INTERNAL_ERROR","message":"Internal Server Error"}]}`,
 				code: 500,
 			},
 		},
@@ -131,8 +131,24 @@ func TestPondHandler_CreatePondHandler(t *testing.T) {
 			mockFunc: func(pondDomain mock_pond.MockPondDomain) {
 			},
 			want: want{
-				body: `{"code":400,"message":"Invalid Parameter Request"}`,
-				code: 400,
+				body: `{"code":422,"message":"Invalid Parameter Request","errors":[{"code":"VALIDATION_FAILED","field":"name","message":"name is required"}]}`,
+				code: 422,
+			},
+		},
+		{
+			name: "error out of range request flow",
+			body: `{"name":"Pond 1","capacity":-1,"depth":200,"water_quality":15,"species":"Tilapia"}`,
+			args: args{
+				timeout: 10,
+			},
+			mockContext: func() (context.Context, func()) {
+				return context.Background(), func() {}
+			},
+			mockFunc: func(pondDomain mock_pond.MockPondDomain) {
+			},
+			want: want{
+				body: `{"code":422,"message":"Invalid Parameter Request","errors":[{"code":"VALIDATION_FAILED","field":"capacity","message":"capacity must be at least 0"},{"code":"VALIDATION_FAILED","field":"depth","message":"depth must be at most 100"},{"code":"VALIDATION_FAILED","field":"water_quality","message":"water_quality must be at most 14"},{"code":"VALIDATION_FAILED","field":"farm_id","message":"farm_id is required"}]}`,
+				code: 422,
 			},
 		},
 		{
@@ -147,7 +163,7 @@ func TestPondHandler_CreatePondHandler(t *testing.T) {
 			mockFunc: func(pondDomain mock_pond.MockPondDomain) {
 			},
 			want: want{
-				body: `{"code":400,"message":"Bad Request"}`,
+				body: `{"code":400,"message":"Bad Request","errors":[{"code":"BAD_REQUEST","message":"Bad Request"}]}`,
 				code: 400,
 			},
 		},
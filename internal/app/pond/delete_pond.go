@@ -3,20 +3,32 @@ package pond
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 	"io/ioutil"
 	"log"
 	"net/http"
 	"time"
 
 	"aqua-farm-manager/internal/domain/pond"
+	"aqua-farm-manager/pkg/apperror"
 	utilhttp "aqua-farm-manager/pkg/utilhttp"
+	"aqua-farm-manager/pkg/validator"
 )
 
 // DeletePondRequest is list request parameter for Delete Api
 type DeletePondRequest struct {
 	PondID   uint   `json:"id"`
-	PondName string `json:"name"`
+	PondName string `json:"name" validate:"max=100,charset=name"`
+}
+
+// ValidateCrossField is func to check delete request choose either id or name
+func (r DeletePondRequest) ValidateCrossField() []apperror.Detail {
+	if len(r.PondName) < 1 && r.PondID < 1 {
+		return []apperror.Detail{apperror.Field("id", "id or name is required")}
+	}
+	if len(r.PondName) >= 1 && r.PondID > 0 {
+		return []apperror.Detail{apperror.Field("id", "Please Choose to delete by ID or Name")}
+	}
+	return nil
 }
 
 // DeletePondResponse is list response parameter for Delete Api
@@ -35,12 +47,16 @@ func (h *PondHandler) DeletePondHandler(w http.ResponseWriter, r *http.Request)
 	var code int = http.StatusOK
 
 	defer func() {
-		response.Code = code
-		if err == nil {
-			response.Message = "success"
+		if err != nil {
+			code = apperror.HTTPStatus(err)
+			if code == http.StatusInternalServerError {
+				log.Println("[DeletePondHandler]-Internal Error :", err)
+			}
+			response.SetError(err)
 		} else {
-			response.Message = err.Error()
+			response.Message = "success"
 		}
+		response.Code = code
 
 		data, errMarshal := json.Marshal(response)
 		if errMarshal != nil {
@@ -54,55 +70,33 @@ func (h *PondHandler) DeletePondHandler(w http.ResponseWriter, r *http.Request)
 	var body DeletePondRequest
 	data, err := ioutil.ReadAll(r.Body)
 	if err != nil {
-		code = http.StatusBadRequest
-		err = fmt.Errorf("Bad Request")
+		err = apperror.New(apperror.CodeBadRequest, apperror.MessageBadRequest)
 		return
 	}
 
 	err = json.Unmarshal(data, &body)
 	if err != nil {
-		code = http.StatusBadRequest
-		err = fmt.Errorf("Bad Request")
+		err = apperror.New(apperror.CodeBadRequest, apperror.MessageBadRequest)
 		return
 	}
 
 	// checking valid body
-	if len(body.PondName) < 1 && body.PondID < 1 {
-		code = http.StatusBadRequest
-		err = fmt.Errorf("Invalid Parameter Request")
+	err = validator.Validate(body)
+	if err != nil {
 		return
 	}
 
-	if len(body.PondName) >= 1 && body.PondID > 0 {
-		code = http.StatusBadRequest
-		err = fmt.Errorf("Please Choose to delete by ID or Name")
+	var res pond.DeleteDomainResponse
+	res, err = h.domain.DeletePondInfo(ctx, pond.DeleteDomainRequest{
+		Name: body.PondName,
+		ID:   body.PondID,
+	})
+	if err != nil && ctx.Err() != nil {
+		err = apperror.Wrap(apperror.CodeTimeout, apperror.MessageTimeout, err)
 		return
 	}
-
-	errChan := make(chan error, 1)
-	var res pond.DeleteDomainResponse
-	go func(ctx context.Context) {
-		res, err = h.domain.DeletePondInfo(pond.DeleteDomainRequest{
-			Name: body.PondName,
-			ID:   body.PondID,
-		})
-		errChan <- err
-	}(ctx)
-
-	select {
-	case <-ctx.Done():
-		code = http.StatusGatewayTimeout
-		err = fmt.Errorf("Timeout")
+	if err != nil {
 		return
-	case err = <-errChan:
-		if err != nil {
-			if err == pond.ErrInvalidPond {
-				code = http.StatusNotFound
-			} else {
-				code = http.StatusInternalServerError
-			}
-			return
-		}
 	}
 
 	response = mapResonseDelete(res)
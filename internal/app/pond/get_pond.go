@@ -3,21 +3,21 @@ package pond
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 	"io/ioutil"
 	"log"
 	"net/http"
-	"strings"
 	"time"
 
 	"aqua-farm-manager/internal/domain/pond"
+	"aqua-farm-manager/pkg/apperror"
 	utilhttp "aqua-farm-manager/pkg/utilhttp"
+	"aqua-farm-manager/pkg/validator"
 )
 
 // GetPondRequest is list response parameter for Get Api
 type GetPondRequest struct {
 	Size   int `json:"size"`
-	Cursor int `json:"cursor"`
+	Cursor int `json:"cursor" validate:"min=0"`
 }
 
 // GetPondResponse is list response parameter for Get Api
@@ -46,12 +46,16 @@ func (h *PondHandler) GetPondHandler(w http.ResponseWriter, r *http.Request) {
 	var code int = http.StatusOK
 
 	defer func() {
-		response.Code = code
-		if err == nil {
-			response.Message = "success"
+		if err != nil {
+			code = apperror.HTTPStatus(err)
+			if code == http.StatusInternalServerError {
+				log.Println("[GetPondHandler]-Internal Error :", err)
+			}
+			response.SetError(err)
 		} else {
-			response.Message = err.Error()
+			response.Message = "success"
 		}
+		response.Code = code
 
 		data, errMarshal := json.Marshal(response)
 		if errMarshal != nil {
@@ -66,15 +70,18 @@ func (h *PondHandler) GetPondHandler(w http.ResponseWriter, r *http.Request) {
 	var body GetPondRequest
 	data, err := ioutil.ReadAll(r.Body)
 	if err != nil {
-		code = http.StatusBadRequest
-		err = fmt.Errorf("Bad Request")
+		err = apperror.New(apperror.CodeBadRequest, apperror.MessageBadRequest)
 		return
 	}
 
 	err = json.Unmarshal(data, &body)
 	if err != nil {
-		code = http.StatusBadRequest
-		err = fmt.Errorf("Bad Request")
+		err = apperror.New(apperror.CodeBadRequest, apperror.MessageBadRequest)
+		return
+	}
+
+	err = validator.Validate(body)
+	if err != nil {
 		return
 	}
 
@@ -86,34 +93,19 @@ func (h *PondHandler) GetPondHandler(w http.ResponseWriter, r *http.Request) {
 		body.Cursor = 1
 	}
 
-	errChan := make(chan error, 1)
 	var res []pond.GetPondInfoResponse
 	var next int
-	go func(ctx context.Context) {
-		res, next, err = h.domain.GetAllPond(body.Size, body.Cursor)
-		errChan <- err
-	}(ctx)
-
-	select {
-	case <-ctx.Done():
-		code = http.StatusGatewayTimeout
-		err = fmt.Errorf("Timeout")
+	res, next, err = h.domain.GetAllPond(ctx, body.Size, body.Cursor)
+	if err != nil && ctx.Err() != nil {
+		err = apperror.Wrap(apperror.CodeTimeout, apperror.MessageTimeout, err)
+		return
+	}
+	if err != nil {
 		return
-	case err = <-errChan:
-		if err != nil {
-			if strings.Contains(err.Error(), "not found") {
-				code = http.StatusNotFound
-				err = fmt.Errorf("Data Not Found")
-			} else {
-				code = http.StatusInternalServerError
-			}
-			return
-		}
 	}
 
 	if len(res) == 0 {
-		code = http.StatusNotFound
-		err = fmt.Errorf("Data Not Found")
+		err = apperror.New(apperror.CodeNotFound, apperror.MessageNotFound)
 		return
 	}
 
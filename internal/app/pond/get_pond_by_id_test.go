@@ -41,7 +41,7 @@ func TestPondHandler_GetByIDPondHandler(t *testing.T) {
 				return context.Background(), func() {}
 			},
 			mockFunc: func(pondDomain mock_pond.MockPondDomain) {
-				pondDomain.EXPECT().GetPondInfoByID(gomock.Any()).Return(pond.GetPondInfoResponse{
+				pondDomain.EXPECT().GetPondInfoByID(gomock.Any(), gomock.Any()).Return(pond.GetPondInfoResponse{
 					ID:           1,
 					Name:         "name",
 					Capacity:     1,
@@ -73,10 +73,10 @@ func TestPondHandler_GetByIDPondHandler(t *testing.T) {
 				return context.Background(), func() {}
 			},
 			mockFunc: func(pondDomain mock_pond.MockPondDomain) {
-				pondDomain.EXPECT().GetPondInfoByID(gomock.Any()).Return(pond.GetPondInfoResponse{}, nil).AnyTimes()
+				pondDomain.EXPECT().GetPondInfoByID(gomock.Any(), gomock.Any()).Return(pond.GetPondInfoResponse{}, context.DeadlineExceeded).AnyTimes()
 			},
 			want: want{
-				body: `{"code":504,"message":"Timeout"}`,
+				body: `{"code":504,"message":"Timeout","errors":[{"code":"TIMEOUT","message":"Timeout"}]}`,
 				code: 504,
 			},
 		},
@@ -92,8 +92,8 @@ func TestPondHandler_GetByIDPondHandler(t *testing.T) {
 			mockFunc: func(pondDomain mock_pond.MockPondDomain) {
 			},
 			want: want{
-				body: `{"code":400,"message":"Invalid Parameter Request"}`,
-				code: 400,
+				body: `{"code":422,"message":"Invalid Parameter Request","errors":[{"code":"VALIDATION_FAILED","field":"id","message":"id must be a positive number"}]}`,
+				code: 422,
 			},
 		},
 		{
@@ -106,10 +106,10 @@ func TestPondHandler_GetByIDPondHandler(t *testing.T) {
 				return context.Background(), func() {}
 			},
 			mockFunc: func(pondDomain mock_pond.MockPondDomain) {
-				pondDomain.EXPECT().GetPondInfoByID(gomock.Any()).Return(pond.GetPondInfoResponse{}, fmt.Errorf("record not found"))
+				pondDomain.EXPECT().GetPondInfoByID(gomock.Any(), gomock.Any()).Return(pond.GetPondInfoResponse{}, pond.ErrInvalidPond)
 			},
 			want: want{
-				body: `{"code":404,"message":"Data Not Found"}`,
+				body: `{"code":404,"message":"Pond Is Not Exists","errors":[{"code":"POND_NOT_FOUND","message":"Pond Is Not Exists"}]}`,
 				code: 404,
 			},
 		},
@@ -123,10 +123,10 @@ func TestPondHandler_GetByIDPondHandler(t *testing.T) {
 				return context.Background(), func() {}
 			},
 			mockFunc: func(pondDomain mock_pond.MockPondDomain) {
-				pondDomain.EXPECT().GetPondInfoByID(gomock.Any()).Return(pond.GetPondInfoResponse{}, fmt.Errorf("some error"))
+				pondDomain.EXPECT().GetPondInfoByID(gomock.Any(), gomock.Any()).Return(pond.GetPondInfoResponse{}, fmt.Errorf("some error"))
 			},
 			want: want{
-				body: `{"code":500,"message":"some error"}`,
+				body: `{"code":500,"message":"Internal Server Error","errors":[{"code":"INTERNAL_ERROR","message":"Internal Server Error"}]}`,
 				code: 500,
 			},
 		},
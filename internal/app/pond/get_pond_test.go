@@ -40,7 +40,7 @@ func TestPondHandler_GetPondHandler(t *testing.T) {
 				return context.Background(), func() {}
 			},
 			mockFunc: func(pondDomain mock_pond.MockPondDomain) {
-				pondDomain.EXPECT().GetAllPond(gomock.Any(), gomock.Any()).Return(
+				pondDomain.EXPECT().GetAllPond(gomock.Any(), gomock.Any(), gomock.Any()).Return(
 					[]pond.GetPondInfoResponse{
 						{
 							ID:           1,
@@ -78,12 +78,12 @@ func TestPondHandler_GetPondHandler(t *testing.T) {
 				return context.Background(), func() {}
 			},
 			mockFunc: func(pondDomain mock_pond.MockPondDomain) {
-				pondDomain.EXPECT().GetAllPond(gomock.Any(), gomock.Any()).Return(
+				pondDomain.EXPECT().GetAllPond(gomock.Any(), gomock.Any(), gomock.Any()).Return(
 					[]pond.GetPondInfoResponse{}, 0, nil,
 				)
 			},
 			want: want{
-				body: `{"code":404,"message":"Data Not Found"}`,
+				body: `{"code":404,"message":"Data Not Found","errors":[{"code":"NOT_FOUND","message":"Data Not Found"}]}`,
 				code: 404,
 			},
 		},
@@ -97,17 +97,17 @@ func TestPondHandler_GetPondHandler(t *testing.T) {
 				return context.Background(), func() {}
 			},
 			mockFunc: func(pondDomain mock_pond.MockPondDomain) {
-				pondDomain.EXPECT().GetAllPond(gomock.Any(), gomock.Any()).Return(
-					[]pond.GetPondInfoResponse{}, 0, nil,
+				pondDomain.EXPECT().GetAllPond(gomock.Any(), gomock.Any(), gomock.Any()).Return(
+					[]pond.GetPondInfoResponse{}, 0, context.DeadlineExceeded,
 				).AnyTimes()
 			},
 			want: want{
-				body: `{"code":504,"message":"Timeout"}`,
+				body: `{"code":504,"message":"Timeout","errors":[{"code":"TIMEOUT","message":"Timeout"}]}`,
 				code: 504,
 			},
 		},
 		{
-			name: "data not found flow",
+			name: "untyped not found error flow",
 			body: `{"size":2,"cursor":1}`,
 			args: args{
 				timeout: 10,
@@ -116,13 +116,13 @@ func TestPondHandler_GetPondHandler(t *testing.T) {
 				return context.Background(), func() {}
 			},
 			mockFunc: func(pondDomain mock_pond.MockPondDomain) {
-				pondDomain.EXPECT().GetAllPond(gomock.Any(), gomock.Any()).Return(
+				pondDomain.EXPECT().GetAllPond(gomock.Any(), gomock.Any(), gomock.Any()).Return(
 					[]pond.GetPondInfoResponse{}, 0, fmt.Errorf("record not found"),
 				)
 			},
 			want: want{
-				body: `{"code":404,"message":"Data Not Found"}`,
-				code: 404,
+				body: `{"code":500,"message":"Internal Server Error","errors":[{"code":"INTERNAL_ERROR","message":"Internal Server Error"}]}`,
+				code: 500,
 			},
 		},
 		{
@@ -135,12 +135,12 @@ func TestPondHandler_GetPondHandler(t *testing.T) {
 				return context.Background(), func() {}
 			},
 			mockFunc: func(pondDomain mock_pond.MockPondDomain) {
-				pondDomain.EXPECT().GetAllPond(gomock.Any(), gomock.Any()).Return(
+				pondDomain.EXPECT().GetAllPond(gomock.Any(), gomock.Any(), gomock.Any()).Return(
 					[]pond.GetPondInfoResponse{}, 0, fmt.Errorf("some error"),
 				)
 			},
 			want: want{
-				body: `{"code":500,"message":"some error"}`,
+				body: `{"code":500,"message":"Internal Server Error","errors":[{"code":"INTERNAL_ERROR","message":"Internal Server Error"}]}`,
 				code: 500,
 			},
 		},
@@ -156,7 +156,7 @@ func TestPondHandler_GetPondHandler(t *testing.T) {
 			mockFunc: func(pondDomain mock_pond.MockPondDomain) {
 			},
 			want: want{
-				body: `{"code":400,"message":"Bad Request"}`,
+				body: `{"code":400,"message":"Bad Request","errors":[{"code":"BAD_REQUEST","message":"Bad Request"}]}`,
 				code: 400,
 			},
 		},
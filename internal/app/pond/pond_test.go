@@ -2,6 +2,9 @@ package pond
 
 import (
 	"aqua-farm-manager/internal/domain/pond"
+	"aqua-farm-manager/pkg/apperror"
+	"aqua-farm-manager/pkg/validator"
+	"math"
 	"reflect"
 	"testing"
 )
@@ -58,3 +61,53 @@ func TestNewPondHandler(t *testing.T) {
 		})
 	}
 }
+
+func TestPondRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		request interface{}
+		want    []apperror.Detail
+	}{
+		{
+			name:    "valid create request",
+			request: CreatePondRequest{Name: "Pond 1", Capacity: 1000, Depth: 2.5, WaterQuality: 7.8, Species: "Tilapia", FarmID: 1},
+		},
+		{
+			name:    "nan and infinite number",
+			request: CreatePondRequest{Name: "Pond 1", Capacity: math.Inf(1), Depth: math.NaN(), FarmID: 1},
+			want: []apperror.Detail{
+				apperror.Field("capacity", "capacity must be a finite number"),
+				apperror.Field("depth", "depth must be a finite number"),
+			},
+		},
+		{
+			name:    "blank name and control character of species",
+			request: CreatePondRequest{Name: "   ", Species: "Tila\x00pia", FarmID: 1},
+			want: []apperror.Detail{
+				apperror.Field("name", "name is required"),
+				apperror.Field("species", `species contains invalid character '\x00'`),
+			},
+		},
+		{
+			name:    "update without changed field",
+			request: UpdatePondRequest{Name: "Pond 1"},
+			want: []apperror.Detail{
+				apperror.Field("farm_id", "farm_id, species, capacity, depth or water_quality is required"),
+			},
+		},
+		{
+			name:    "delete by id and name",
+			request: DeletePondRequest{PondID: 1, PondName: "Pond 1"},
+			want: []apperror.Detail{
+				apperror.Field("id", "Please Choose to delete by ID or Name"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := validator.Struct(tt.request); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("validator.Struct() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,26 +3,35 @@ package pond
 import (
 	"context"
 	"encoding/json"
-	"fmt"
 	"io/ioutil"
 	"log"
 	"net/http"
 	"time"
 
 	"aqua-farm-manager/internal/domain/pond"
+	"aqua-farm-manager/pkg/apperror"
 	utilhttp "aqua-farm-manager/pkg/utilhttp"
+	"aqua-farm-manager/pkg/validator"
 )
 
 // UpdatePondRequest is list request parameter for Update Api
 type UpdatePondRequest struct {
-	Name         string  `json:"name"`
-	Capacity     float64 `json:"capacity"`
-	Depth        float64 `json:"depth"`
-	WaterQuality float64 `json:"water_quality"`
-	Species      string  `json:"species"`
+	Name         string  `json:"name" validate:"required,max=100,charset=name"`
+	Capacity     float64 `json:"capacity" validate:"min=0,max=1000000000"`
+	Depth        float64 `json:"depth" validate:"min=0,max=100"`
+	WaterQuality float64 `json:"water_quality" validate:"min=0,max=14"`
+	Species      string  `json:"species" validate:"max=100,charset=name"`
 	FarmID       uint    `json:"farm_id"`
 }
 
+// ValidateCrossField is func to check update request change at least one field other than the name
+func (r UpdatePondRequest) ValidateCrossField() []apperror.Detail {
+	if len(r.Species) < 1 && r.Capacity <= 0 && r.Depth <= 0 && r.WaterQuality <= 0 && r.FarmID < 1 {
+		return []apperror.Detail{apperror.Field("farm_id", "farm_id, species, capacity, depth or water_quality is required")}
+	}
+	return nil
+}
+
 // UpdatePondResponse is list response parameter for Update Api
 type UpdatePondResponse struct {
 	ID           uint    `json:"id"`
@@ -44,12 +53,16 @@ func (h *PondHandler) UpdatePondHandler(w http.ResponseWriter, r *http.Request)
 	var code int = http.StatusOK
 
 	defer func() {
-		response.Code = code
-		if err == nil {
-			response.Message = "success"
+		if err != nil {
+			code = apperror.HTTPStatus(err)
+			if code == http.StatusInternalServerError {
+				log.Println("[UpdatePondHandler]-Internal Error :", err)
+			}
+			response.SetError(err)
 		} else {
-			response.Message = err.Error()
+			response.Message = "success"
 		}
+		response.Code = code
 
 		data, errMarshal := json.Marshal(response)
 		if errMarshal != nil {
@@ -63,53 +76,37 @@ func (h *PondHandler) UpdatePondHandler(w http.ResponseWriter, r *http.Request)
 	var body UpdatePondRequest
 	data, err := ioutil.ReadAll(r.Body)
 	if err != nil {
-		code = http.StatusBadRequest
-		err = fmt.Errorf("Bad Request")
+		err = apperror.New(apperror.CodeBadRequest, apperror.MessageBadRequest)
 		return
 	}
 
 	err = json.Unmarshal(data, &body)
 	if err != nil {
-		code = http.StatusBadRequest
-		err = fmt.Errorf("Bad Request")
+		err = apperror.New(apperror.CodeBadRequest, apperror.MessageBadRequest)
 		return
 	}
 
 	// checking valid body
-	if len(body.Name) < 1 || (len(body.Species) < 1 && body.Capacity < 1 && body.Depth < 1 && body.WaterQuality < 1 && body.FarmID < 1) {
-		code = http.StatusBadRequest
-		err = fmt.Errorf("Invalid Parameter Request")
+	err = validator.Validate(body)
+	if err != nil {
 		return
 	}
 
-	errChan := make(chan error, 1)
 	var res pond.UpdateDomainResponse
-	go func(ctx context.Context) {
-		res, err = h.domain.UpdatePondInfo(pond.UpdateDomainRequest{
-			Name:         body.Name,
-			Capacity:     body.Capacity,
-			Depth:        body.Depth,
-			WaterQuality: body.WaterQuality,
-			Species:      body.Species,
-			FarmID:       body.FarmID,
-		})
-		errChan <- err
-	}(ctx)
-
-	select {
-	case <-ctx.Done():
-		code = http.StatusGatewayTimeout
-		err = fmt.Errorf("Timeout")
+	res, err = h.domain.UpdatePondInfo(ctx, pond.UpdateDomainRequest{
+		Name:         body.Name,
+		Capacity:     body.Capacity,
+		Depth:        body.Depth,
+		WaterQuality: body.WaterQuality,
+		Species:      body.Species,
+		FarmID:       body.FarmID,
+	})
+	if err != nil && ctx.Err() != nil {
+		err = apperror.Wrap(apperror.CodeTimeout, apperror.MessageTimeout, err)
+		return
+	}
+	if err != nil {
 		return
-	case err = <-errChan:
-		if err != nil {
-			if err == pond.ErrInvalidFarm || err == pond.ErrMaxPond {
-				code = http.StatusConflict
-			} else {
-				code = http.StatusInternalServerError
-			}
-			return
-		}
 	}
 
 	response = mapResonseUpdate(res)
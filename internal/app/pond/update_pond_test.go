@@ -40,7 +40,7 @@ func TestPondHandler_UpdatePondHandler(t *testing.T) {
 				return context.Background(), func() {}
 			},
 			mockFunc: func(pondDomain mock_pond.MockPondDomain) {
-				pondDomain.EXPECT().UpdatePondInfo(gomock.Any()).Return(pond.UpdateDomainResponse{
+				pondDomain.EXPECT().UpdatePondInfo(gomock.Any(), gomock.Any()).Return(pond.UpdateDomainResponse{
 					ID:           1,
 					Name:         "Pond 1",
 					Capacity:     1000,
@@ -65,10 +65,10 @@ func TestPondHandler_UpdatePondHandler(t *testing.T) {
 				return context.Background(), func() {}
 			},
 			mockFunc: func(pondDomain mock_pond.MockPondDomain) {
-				pondDomain.EXPECT().UpdatePondInfo(gomock.Any()).Return(pond.UpdateDomainResponse{}, nil).AnyTimes()
+				pondDomain.EXPECT().UpdatePondInfo(gomock.Any(), gomock.Any()).Return(pond.UpdateDomainResponse{}, context.DeadlineExceeded).AnyTimes()
 			},
 			want: want{
-				body: `{"code":504,"message":"Timeout"}`,
+				body: `{"code":504,"message":"Timeout","errors":[{"code":"TIMEOUT","message":"Timeout"}]}`,
 				code: 504,
 			},
 		},
@@ -82,10 +82,27 @@ func TestPondHandler_UpdatePondHandler(t *testing.T) {
 				return context.Background(), func() {}
 			},
 			mockFunc: func(pondDomain mock_pond.MockPondDomain) {
-				pondDomain.EXPECT().UpdatePondInfo(gomock.Any()).Return(pond.UpdateDomainResponse{}, pond.ErrInvalidFarm)
+				pondDomain.EXPECT().UpdatePondInfo(gomock.Any(), gomock.Any()).Return(pond.UpdateDomainResponse{}, pond.ErrInvalidFarm)
 			},
 			want: want{
-				body: `{"code":409,"message":"Farm Is Not Exists"}`,
+				body: `{"code":404,"message":"Farm Is Not Exists","errors":[{"code":"FARM_NOT_FOUND","message":"Farm Is Not Exists"}]}`,
+				code: 404,
+			},
+		},
+		{
+			name: "duplicate pond flow",
+			body: `{"name":"Pond 1","capacity":1000,"depth":2.5,"water_quality":7.8,"species":"Tilapia","farm_id":1}`,
+			args: args{
+				timeout: 10,
+			},
+			mockContext: func() (context.Context, func()) {
+				return context.Background(), func() {}
+			},
+			mockFunc: func(pondDomain mock_pond.MockPondDomain) {
+				pondDomain.EXPECT().UpdatePondInfo(gomock.Any(), gomock.Any()).Return(pond.UpdateDomainResponse{}, pond.ErrDuplicatePond)
+			},
+			want: want{
+				body: `{"code":409,"message":"Pond Is Already Exists","errors":[{"code":"POND_ALREADY_EXISTS","message":"Pond Is Already Exists"}]}`,
 				code: 409,
 			},
 		},
@@ -99,10 +116,10 @@ func TestPondHandler_UpdatePondHandler(t *testing.T) {
 				return context.Background(), func() {}
 			},
 			mockFunc: func(pondDomain mock_pond.MockPondDomain) {
-				pondDomain.EXPECT().UpdatePondInfo(gomock.Any()).Return(pond.UpdateDomainResponse{}, fmt.Errorf("some error"))
+				pondDomain.EXPECT().UpdatePondInfo(gomock.Any(), gomock.Any()).Return(pond.UpdateDomainResponse{}, fmt.Errorf("some error"))
 			},
 			want: want{
-				body: `{"code":500,"message":"some error"}`,
+				body: `{"code":500,"message":"Internal Server Error","errors":[{"code":"INTERNAL_ERROR","message":"Internal Server Error"}]}`,
 				code: 500,
 			},
 		},
@@ -118,8 +135,8 @@ func TestPondHandler_UpdatePondHandler(t *testing.T) {
 			mockFunc: func(pondDomain mock_pond.MockPondDomain) {
 			},
 			want: want{
-				body: `{"code":400,"message":"Invalid Parameter Request"}`,
-				code: 400,
+				body: `{"code":422,"message":"Invalid Parameter Request","errors":[{"code":"VALIDATION_FAILED","field":"farm_id","message":"farm_id, species, capacity, depth or water_quality is required"}]}`,
+				code: 422,
 			},
 		},
 		{
@@ -134,7 +151,7 @@ func TestPondHandler_UpdatePondHandler(t *testing.T) {
 			mockFunc: func(pondDomain mock_pond.MockPondDomain) {
 			},
 			want: want{
-				body: `{"code":400,"message":"Bad Request"}`,
+				body: `{"code":400,"message":"Bad Request","errors":[{"code":"BAD_REQUEST","message":"Bad Request"}]}`,
 				code: 400,
 			},
 		},
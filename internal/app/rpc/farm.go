@@ -0,0 +1,245 @@
+package rpc
+
+import (
+	"context"
+
+	"aqua-farm-manager/internal/app/farm"
+	farmdomain "aqua-farm-manager/internal/domain/farm"
+	"aqua-farm-manager/pkg/apperror"
+	"aqua-farm-manager/pkg/aquafarmpb"
+	"aqua-farm-manager/pkg/validator"
+)
+
+// FarmServer list dependencies for farm grpc service
+type FarmServer struct {
+	aquafarmpb.UnimplementedFarmServiceServer
+	server
+	domain farmdomain.FarmDomain
+}
+
+// NewFarmServer is func to create grpc farm service
+func NewFarmServer(domain farmdomain.FarmDomain, options ...Option) *FarmServer {
+	return &FarmServer{
+		server: newServer(options...),
+		domain: domain,
+	}
+}
+
+// CreateFarm is func to create farm, the request is validated with the rule of http api
+func (s *FarmServer) CreateFarm(ctx context.Context, r *aquafarmpb.CreateFarmRequest) (*aquafarmpb.CreateFarmResponse, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	err := validator.Validate(farm.CreateFarmRequest{
+		Name:     r.GetName(),
+		Location: r.GetLocation(),
+		Owner:    r.GetOwner(),
+		Area:     r.GetArea(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.domain.CreateFarmInfo(ctx, farmdomain.CreateDomainRequest{
+		Name:     r.GetName(),
+		Location: r.GetLocation(),
+		Owner:    r.GetOwner(),
+		Area:     r.GetArea(),
+	})
+	if err != nil {
+		return nil, domainError(ctx, err)
+	}
+
+	return &aquafarmpb.CreateFarmResponse{Id: uint64(res.ID)}, nil
+}
+
+// UpdateFarm is func to update farm by name, the farm is created when it does not exist
+func (s *FarmServer) UpdateFarm(ctx context.Context, r *aquafarmpb.UpdateFarmRequest) (*aquafarmpb.Farm, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	err := validator.Validate(farm.UpdateFarmRequest{
+		Name:     r.GetName(),
+		Location: r.GetLocation(),
+		Owner:    r.GetOwner(),
+		Area:     r.GetArea(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.domain.UpdateFarmInfo(ctx, farmdomain.UpdateDomainRequest{
+		Name:     r.GetName(),
+		Location: r.GetLocation(),
+		Owner:    r.GetOwner(),
+		Area:     r.GetArea(),
+	})
+	if err != nil {
+		return nil, domainError(ctx, err)
+	}
+
+	return &aquafarmpb.Farm{
+		Id:       uint64(res.ID),
+		Name:     res.Name,
+		Location: res.Location,
+		Owner:    res.Owner,
+		Area:     res.Area,
+	}, nil
+}
+
+// DeleteFarm is func to delete farm without pond by id or name
+func (s *FarmServer) DeleteFarm(ctx context.Context, r *aquafarmpb.DeleteFarmRequest) (*aquafarmpb.DeleteFarmResponse, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	err := validator.Validate(farm.DeleteFarmRequest{
+		FarmID:   uint(r.GetId()),
+		FarmName: r.GetName(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.domain.DeleteFarmInfo(ctx, farmdomain.DeleteDomainRequest{
+		ID:   uint(r.GetId()),
+		Name: r.GetName(),
+	})
+	if err != nil {
+		return nil, domainError(ctx, err)
+	}
+
+	return &aquafarmpb.DeleteFarmResponse{Id: uint64(res.ID), Name: res.Name}, nil
+}
+
+// GetFarm is func to get farm with its ponds
+func (s *FarmServer) GetFarm(ctx context.Context, r *aquafarmpb.GetFarmRequest) (*aquafarmpb.GetFarmResponse, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	if r.GetId() < 1 {
+		return nil, apperror.Validation(apperror.Field("id", "id must be a positive number"))
+	}
+
+	res, err := s.domain.GetFarmInfoByID(ctx, uint(r.GetId()))
+	if err != nil {
+		return nil, domainError(ctx, err)
+	}
+
+	ponds := make([]*aquafarmpb.Pond, 0, len(res.PondInfos))
+	for _, pond := range res.PondInfos {
+		ponds = append(ponds, &aquafarmpb.Pond{
+			Id:           uint64(pond.ID),
+			Name:         pond.Name,
+			Capacity:     pond.Capacity,
+			Depth:        pond.Depth,
+			WaterQuality: pond.WaterQuality,
+			Species:      pond.Species,
+			FarmId:       uint64(res.ID),
+		})
+	}
+
+	return &aquafarmpb.GetFarmResponse{
+		Farm:  mapFarm(res),
+		Ponds: ponds,
+	}, nil
+}
+
+// ListFarms is func to get page of farm, the last page has no next cursor
+func (s *FarmServer) ListFarms(ctx context.Context, r *aquafarmpb.ListFarmsRequest) (*aquafarmpb.ListFarmsResponse, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	page := farm.GetFarmRequest{
+		Size:   int(r.GetSize()),
+		Cursor: int(r.GetCursor()),
+	}
+	err := validator.Validate(page)
+	if err != nil {
+		return nil, err
+	}
+
+	if page.Size < 1 || page.Size > defaultPageSize {
+		page.Size = defaultPageSize
+	}
+
+	if page.Cursor < 1 {
+		page.Cursor = firstCursor
+	}
+
+	res, next, err := s.domain.GetFarm(ctx, page.Size, page.Cursor)
+	if err != nil {
+		return nil, domainError(ctx, err)
+	}
+
+	farms := make([]*aquafarmpb.Farm, 0, len(res))
+	for _, info := range res {
+		farms = append(farms, mapFarm(info))
+	}
+
+	return &aquafarmpb.ListFarmsResponse{
+		Farms:      farms,
+		NextCursor: int32(next),
+	}, nil
+}
+
+// DeleteFarmWithPonds is func to delete farm with every its pond
+func (s *FarmServer) DeleteFarmWithPonds(ctx context.Context, r *aquafarmpb.DeleteFarmWithPondsRequest) (*aquafarmpb.DeleteFarmWithPondsResponse, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	if r.GetId() < 1 {
+		return nil, apperror.Validation(apperror.Field("id", "id must be a positive number"))
+	}
+
+	res, err := s.domain.DeleteFarmsWithDependencies(ctx, uint(r.GetId()))
+	if err != nil {
+		return nil, domainError(ctx, err)
+	}
+
+	return &aquafarmpb.DeleteFarmWithPondsResponse{
+		Id:      uint64(res.ID),
+		Name:    res.Name,
+		PondIds: mapIDs(res.PondIds),
+	}, nil
+}
+
+// GetFarmSummary is func to get number of active farm and pond
+func (s *FarmServer) GetFarmSummary(ctx context.Context, r *aquafarmpb.GetFarmSummaryRequest) (*aquafarmpb.GetFarmSummaryResponse, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	res, err := s.domain.GetFarmSummary(ctx)
+	if err != nil {
+		return nil, domainError(ctx, err)
+	}
+
+	pondsPerFarm := make([]int64, 0, len(res.PondsPerFarm))
+	for _, num := range res.PondsPerFarm {
+		pondsPerFarm = append(pondsPerFarm, int64(num))
+	}
+
+	return &aquafarmpb.GetFarmSummaryResponse{
+		ActiveFarms:  int64(res.ActiveFarms),
+		ActivePonds:  int64(res.ActivePonds),
+		PondsPerFarm: pondsPerFarm,
+	}, nil
+}
+
+func mapFarm(r farmdomain.GetFarmInfoResponse) *aquafarmpb.Farm {
+	return &aquafarmpb.Farm{
+		Id:       uint64(r.ID),
+		Name:     r.Name,
+		Location: r.Location,
+		Owner:    r.Owner,
+		Area:     r.Area,
+		PondIds:  mapIDs(r.PondIDs),
+	}
+}
+
+func mapIDs(ids []uint) []uint64 {
+	list := make([]uint64, 0, len(ids))
+	for _, id := range ids {
+		list = append(list, uint64(id))
+	}
+	return list
+}
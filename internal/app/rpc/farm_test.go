@@ -0,0 +1,350 @@
+package rpc
+
+import (
+	"context"
+	"testing"
+
+	"aqua-farm-manager/internal/domain/farm"
+	"aqua-farm-manager/pkg/aquafarmpb"
+
+	"github.com/golang/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestFarmServer_CreateFarm(t *testing.T) {
+	tests := []struct {
+		name     string
+		request  *aquafarmpb.CreateFarmRequest
+		mockFunc func(ts *testServer)
+		want     *aquafarmpb.CreateFarmResponse
+		wantCode codes.Code
+	}{
+		{
+			name:    "success flow",
+			request: &aquafarmpb.CreateFarmRequest{Name: "farm", Location: "loc", Owner: "own", Area: "area"},
+			mockFunc: func(ts *testServer) {
+				ts.farm.EXPECT().CreateFarmInfo(gomock.Any(), farm.CreateDomainRequest{
+					Name:     "farm",
+					Location: "loc",
+					Owner:    "own",
+					Area:     "area",
+				}).Return(farm.CreateDomainResponse{ID: 1}, nil)
+			},
+			want:     &aquafarmpb.CreateFarmResponse{Id: 1},
+			wantCode: codes.OK,
+		},
+		{
+			name:     "invalid request flow",
+			request:  &aquafarmpb.CreateFarmRequest{Owner: "own"},
+			mockFunc: func(ts *testServer) {},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:    "duplicate farm flow",
+			request: &aquafarmpb.CreateFarmRequest{Name: "farm"},
+			mockFunc: func(ts *testServer) {
+				ts.farm.EXPECT().CreateFarmInfo(gomock.Any(), gomock.Any()).Return(farm.CreateDomainResponse{}, farm.ErrDuplicateFarm)
+			},
+			wantCode: codes.AlreadyExists,
+		},
+		{
+			name:    "timeout flow",
+			request: &aquafarmpb.CreateFarmRequest{Name: "farm"},
+			mockFunc: func(ts *testServer) {
+				ts.farm.EXPECT().CreateFarmInfo(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, _ farm.CreateDomainRequest) (farm.CreateDomainResponse, error) {
+						<-ctx.Done()
+						return farm.CreateDomainResponse{}, ctx.Err()
+					})
+			},
+			wantCode: codes.DeadlineExceeded,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := newTestServer(t, WithTimeoutOptions(1))
+			tt.mockFunc(ts)
+
+			got, err := aquafarmpb.NewFarmServiceClient(ts.conn).CreateFarm(authContext(testAuthToken), tt.request)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("FarmServer.CreateFarm() code = %v, want %v, error %v", code, tt.wantCode, err)
+			}
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("FarmServer.CreateFarm() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFarmServer_GetFarm(t *testing.T) {
+	tests := []struct {
+		name     string
+		request  *aquafarmpb.GetFarmRequest
+		mockFunc func(ts *testServer)
+		want     *aquafarmpb.GetFarmResponse
+		wantCode codes.Code
+	}{
+		{
+			name:    "success flow",
+			request: &aquafarmpb.GetFarmRequest{Id: 1},
+			mockFunc: func(ts *testServer) {
+				ts.farm.EXPECT().GetFarmInfoByID(gomock.Any(), uint(1)).Return(farm.GetFarmInfoResponse{
+					ID:       1,
+					Name:     "farm",
+					Location: "loc",
+					PondInfos: []farm.PondInfo{
+						{ID: 2, Name: "pond", Capacity: 1, Depth: 2, WaterQuality: 3, Species: "fish"},
+					},
+				}, nil)
+			},
+			want: &aquafarmpb.GetFarmResponse{
+				Farm: &aquafarmpb.Farm{Id: 1, Name: "farm", Location: "loc"},
+				Ponds: []*aquafarmpb.Pond{
+					{Id: 2, Name: "pond", Capacity: 1, Depth: 2, WaterQuality: 3, Species: "fish", FarmId: 1},
+				},
+			},
+			wantCode: codes.OK,
+		},
+		{
+			name:     "invalid id flow",
+			request:  &aquafarmpb.GetFarmRequest{},
+			mockFunc: func(ts *testServer) {},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:    "not found flow",
+			request: &aquafarmpb.GetFarmRequest{Id: 1},
+			mockFunc: func(ts *testServer) {
+				ts.farm.EXPECT().GetFarmInfoByID(gomock.Any(), uint(1)).Return(farm.GetFarmInfoResponse{}, farm.ErrInvalidFarm)
+			},
+			wantCode: codes.NotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := newTestServer(t)
+			tt.mockFunc(ts)
+
+			got, err := aquafarmpb.NewFarmServiceClient(ts.conn).GetFarm(authContext(testAuthToken), tt.request)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("FarmServer.GetFarm() code = %v, want %v, error %v", code, tt.wantCode, err)
+			}
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("FarmServer.GetFarm() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFarmServer_ListFarms(t *testing.T) {
+	tests := []struct {
+		name     string
+		request  *aquafarmpb.ListFarmsRequest
+		mockFunc func(ts *testServer)
+		want     *aquafarmpb.ListFarmsResponse
+		wantCode codes.Code
+	}{
+		{
+			name:    "default page flow",
+			request: &aquafarmpb.ListFarmsRequest{},
+			mockFunc: func(ts *testServer) {
+				ts.farm.EXPECT().GetFarm(gomock.Any(), defaultPageSize, firstCursor).Return([]farm.GetFarmInfoResponse{
+					{ID: 1, Name: "farm", PondIDs: []uint{2, 3}},
+				}, 2, nil)
+			},
+			want: &aquafarmpb.ListFarmsResponse{
+				Farms:      []*aquafarmpb.Farm{{Id: 1, Name: "farm", PondIds: []uint64{2, 3}}},
+				NextCursor: 2,
+			},
+			wantCode: codes.OK,
+		},
+		{
+			name:    "empty page flow",
+			request: &aquafarmpb.ListFarmsRequest{Size: 5, Cursor: 3},
+			mockFunc: func(ts *testServer) {
+				ts.farm.EXPECT().GetFarm(gomock.Any(), 5, 3).Return(nil, 0, nil)
+			},
+			want:     &aquafarmpb.ListFarmsResponse{},
+			wantCode: codes.OK,
+		},
+		{
+			name:    "size over max is clamped flow",
+			request: &aquafarmpb.ListFarmsRequest{Size: 21},
+			mockFunc: func(ts *testServer) {
+				ts.farm.EXPECT().GetFarm(gomock.Any(), defaultPageSize, firstCursor).Return(nil, 0, nil)
+			},
+			want:     &aquafarmpb.ListFarmsResponse{},
+			wantCode: codes.OK,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := newTestServer(t)
+			tt.mockFunc(ts)
+
+			got, err := aquafarmpb.NewFarmServiceClient(ts.conn).ListFarms(authContext(testAuthToken), tt.request)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("FarmServer.ListFarms() code = %v, want %v, error %v", code, tt.wantCode, err)
+			}
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("FarmServer.ListFarms() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFarmServer_DeleteFarmWithPonds(t *testing.T) {
+	tests := []struct {
+		name     string
+		request  *aquafarmpb.DeleteFarmWithPondsRequest
+		mockFunc func(ts *testServer)
+		want     *aquafarmpb.DeleteFarmWithPondsResponse
+		wantCode codes.Code
+	}{
+		{
+			name:    "success flow",
+			request: &aquafarmpb.DeleteFarmWithPondsRequest{Id: 1},
+			mockFunc: func(ts *testServer) {
+				ts.farm.EXPECT().DeleteFarmsWithDependencies(gomock.Any(), uint(1)).Return(farm.DeleteAllResponse{
+					ID:      1,
+					Name:    "farm",
+					PondIds: []uint{2},
+				}, nil)
+			},
+			want:     &aquafarmpb.DeleteFarmWithPondsResponse{Id: 1, Name: "farm", PondIds: []uint64{2}},
+			wantCode: codes.OK,
+		},
+		{
+			name:     "invalid id flow",
+			request:  &aquafarmpb.DeleteFarmWithPondsRequest{},
+			mockFunc: func(ts *testServer) {},
+			wantCode: codes.InvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := newTestServer(t)
+			tt.mockFunc(ts)
+
+			got, err := aquafarmpb.NewFarmServiceClient(ts.conn).DeleteFarmWithPonds(authContext(testAuthToken), tt.request)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("FarmServer.DeleteFarmWithPonds() code = %v, want %v, error %v", code, tt.wantCode, err)
+			}
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("FarmServer.DeleteFarmWithPonds() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFarmServer_DeleteFarm(t *testing.T) {
+	tests := []struct {
+		name     string
+		request  *aquafarmpb.DeleteFarmRequest
+		mockFunc func(ts *testServer)
+		want     *aquafarmpb.DeleteFarmResponse
+		wantCode codes.Code
+	}{
+		{
+			name:    "success flow",
+			request: &aquafarmpb.DeleteFarmRequest{Name: "farm"},
+			mockFunc: func(ts *testServer) {
+				ts.farm.EXPECT().DeleteFarmInfo(gomock.Any(), farm.DeleteDomainRequest{Name: "farm"}).Return(farm.DeleteDomainResponse{ID: 1, Name: "farm"}, nil)
+			},
+			want:     &aquafarmpb.DeleteFarmResponse{Id: 1, Name: "farm"},
+			wantCode: codes.OK,
+		},
+		{
+			name:     "both id and name flow",
+			request:  &aquafarmpb.DeleteFarmRequest{Id: 1, Name: "farm"},
+			mockFunc: func(ts *testServer) {},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:    "farm has ponds flow",
+			request: &aquafarmpb.DeleteFarmRequest{Id: 1},
+			mockFunc: func(ts *testServer) {
+				ts.farm.EXPECT().DeleteFarmInfo(gomock.Any(), gomock.Any()).Return(farm.DeleteDomainResponse{}, farm.ErrExistsPonds)
+			},
+			wantCode: codes.FailedPrecondition,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := newTestServer(t)
+			tt.mockFunc(ts)
+
+			got, err := aquafarmpb.NewFarmServiceClient(ts.conn).DeleteFarm(authContext(testAuthToken), tt.request)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("FarmServer.DeleteFarm() code = %v, want %v, error %v", code, tt.wantCode, err)
+			}
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("FarmServer.DeleteFarm() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFarmServer_GetFarmSummary(t *testing.T) {
+	ts := newTestServer(t)
+	ts.farm.EXPECT().GetFarmSummary(gomock.Any()).Return(farm.FarmSummaryResponse{
+		ActiveFarms:  2,
+		ActivePonds:  3,
+		PondsPerFarm: []int{1, 2},
+	}, nil)
+
+	got, err := aquafarmpb.NewFarmServiceClient(ts.conn).GetFarmSummary(authContext(testAuthToken), &aquafarmpb.GetFarmSummaryRequest{})
+	if err != nil {
+		t.Fatalf("FarmServer.GetFarmSummary() error = %v", err)
+	}
+	want := &aquafarmpb.GetFarmSummaryResponse{ActiveFarms: 2, ActivePonds: 3, PondsPerFarm: []int64{1, 2}}
+	if !proto.Equal(got, want) {
+		t.Errorf("FarmServer.GetFarmSummary() = %v, want %v", got, want)
+	}
+}
+
+func TestFarmServer_UpdateFarm(t *testing.T) {
+	tests := []struct {
+		name     string
+		request  *aquafarmpb.UpdateFarmRequest
+		mockFunc func(ts *testServer)
+		want     *aquafarmpb.Farm
+		wantCode codes.Code
+	}{
+		{
+			name:    "success flow",
+			request: &aquafarmpb.UpdateFarmRequest{Name: "farm", Owner: "own"},
+			mockFunc: func(ts *testServer) {
+				ts.farm.EXPECT().UpdateFarmInfo(gomock.Any(), farm.UpdateDomainRequest{Name: "farm", Owner: "own"}).Return(farm.UpdateDomainResponse{
+					ID:    1,
+					Name:  "farm",
+					Owner: "own",
+				}, nil)
+			},
+			want:     &aquafarmpb.Farm{Id: 1, Name: "farm", Owner: "own"},
+			wantCode: codes.OK,
+		},
+		{
+			name:     "nothing to update flow",
+			request:  &aquafarmpb.UpdateFarmRequest{Name: "farm"},
+			mockFunc: func(ts *testServer) {},
+			wantCode: codes.InvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := newTestServer(t)
+			tt.mockFunc(ts)
+
+			got, err := aquafarmpb.NewFarmServiceClient(ts.conn).UpdateFarm(authContext(testAuthToken), tt.request)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("FarmServer.UpdateFarm() code = %v, want %v, error %v", code, tt.wantCode, err)
+			}
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("FarmServer.UpdateFarm() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package rpc
+
+import (
+	"context"
+	"crypto/subtle"
+	"log"
+	"net/http"
+	"strings"
+
+	"aqua-farm-manager/internal/app"
+	"aqua-farm-manager/internal/app/middleware"
+	"aqua-farm-manager/pkg/apperror"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// ErrorDomain is domain of ErrorInfo detail, the reason of the detail is the error code
+const ErrorDomain = "aqua-farm-manager"
+
+// route is http path and method of grpc method, the call is tracked as the http request
+// so it is counted in stat together with the http api. The path is the known path of app.UrlIDName,
+// the call by id is counted in the path of its collection because stat only count the known path
+type route struct {
+	path   string
+	method string
+}
+
+// routes is http route of every grpc method, method that is not in the list is tracked with its full method
+var routes = map[string]route{
+	"/aquafarm.v1.FarmService/CreateFarm":          {app.Farms.String(), http.MethodPost},
+	"/aquafarm.v1.FarmService/UpdateFarm":          {app.Farms.String(), http.MethodPut},
+	"/aquafarm.v1.FarmService/DeleteFarm":          {app.Farms.String(), http.MethodDelete},
+	"/aquafarm.v1.FarmService/ListFarms":           {app.Farms.String(), http.MethodGet},
+	"/aquafarm.v1.FarmService/GetFarm":             {app.Farms.String(), http.MethodGet},
+	"/aquafarm.v1.FarmService/DeleteFarmWithPonds": {app.Farms.String(), http.MethodDelete},
+	"/aquafarm.v1.PondService/CreatePond":          {app.Ponds.String(), http.MethodPost},
+	"/aquafarm.v1.PondService/UpdatePond":          {app.Ponds.String(), http.MethodPut},
+	"/aquafarm.v1.PondService/DeletePond":          {app.Ponds.String(), http.MethodDelete},
+	"/aquafarm.v1.PondService/ListPonds":           {app.Ponds.String(), http.MethodGet},
+	"/aquafarm.v1.PondService/GetPond":             {app.Ponds.String(), http.MethodGet},
+	"/aquafarm.v1.StatService/GetStat":             {app.Stat.String(), http.MethodGet},
+}
+
+// codeByAppCode is grpc code of every error code, unknown code is internal error
+var codeByAppCode = map[apperror.Code]codes.Code{
+	apperror.CodeBadRequest:        codes.InvalidArgument,
+	apperror.CodeValidationFailed:  codes.InvalidArgument,
+	apperror.CodeUnauthenticated:   codes.Unauthenticated,
+	apperror.CodeNotFound:          codes.NotFound,
+	apperror.CodeFarmNotFound:      codes.NotFound,
+	apperror.CodeFarmAlreadyExists: codes.AlreadyExists,
+	apperror.CodeFarmHasPonds:      codes.FailedPrecondition,
+	apperror.CodePondNotFound:      codes.NotFound,
+	apperror.CodePondAlreadyExists: codes.AlreadyExists,
+	apperror.CodePondLimitReached:  codes.FailedPrecondition,
+	apperror.CodeWebhookNotFound:   codes.NotFound,
+	apperror.CodeConflict:          codes.Aborted,
+	apperror.CodeUnavailable:       codes.Unavailable,
+	apperror.CodeTimeout:           codes.DeadlineExceeded,
+	apperror.CodeInternal:          codes.Internal,
+}
+
+// Interceptor struct is list dependecies to run grpc interceptor
+type Interceptor struct {
+	middleware middleware.Middleware
+	authToken  string
+}
+
+// NewInterceptor is func to create Interceptor, every call is rejected when the auth token is empty
+func NewInterceptor(mdl middleware.Middleware, authToken string) *Interceptor {
+	return &Interceptor{
+		middleware: mdl,
+		authToken:  authToken,
+	}
+}
+
+// ErrorCode is func to map error of the call into grpc status with the error code in ErrorInfo detail
+// and the violation of every field in BadRequest detail
+func (i *Interceptor) ErrorCode(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	res, err := handler(ctx, req)
+	if err != nil {
+		st := Status(err)
+		if st.Code() == codes.Internal {
+			log.Printf("[%s]-Internal Error : %v", info.FullMethod, err)
+		}
+		return nil, st.Err()
+	}
+	return res, nil
+}
+
+// Tracking is func to publish tracking event of the call with http status of the error
+func (i *Interceptor) Tracking(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	res, err := handler(ctx, req)
+
+	r, ok := routes[info.FullMethod]
+	if !ok {
+		r = route{path: info.FullMethod, method: http.MethodPost}
+	}
+	i.middleware.Track(r.path, r.method, userAgent(ctx), apperror.HTTPStatus(err))
+
+	return res, err
+}
+
+// Auth is func to check the bearer token in authorization metadata of the call
+func (i *Interceptor) Auth(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	var token string
+	if values := md.Get("authorization"); len(values) > 0 {
+		token = strings.TrimPrefix(values[0], "Bearer ")
+	}
+	if len(i.authToken) < 1 || subtle.ConstantTimeCompare([]byte(token), []byte(i.authToken)) != 1 {
+		return nil, apperror.New(apperror.CodeUnauthenticated, apperror.MessageUnauthenticated)
+	}
+
+	return handler(ctx, req)
+}
+
+// Status is func to get grpc status of err, status error is returned as it is
+func Status(err error) *status.Status {
+	if st, ok := status.FromError(err); ok {
+		return st
+	}
+
+	e := apperror.From(err)
+	code, ok := codeByAppCode[e.Code]
+	if !ok {
+		code = codes.Internal
+	}
+	st := status.New(code, e.Message)
+
+	badRequest := &errdetails.BadRequest{}
+	for _, field := range e.Fields {
+		badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+			Field:       field.Field,
+			Description: field.Message,
+		})
+	}
+
+	withDetails, errDetails := st.WithDetails(&errdetails.ErrorInfo{Reason: string(e.Code), Domain: ErrorDomain})
+	if len(badRequest.FieldViolations) > 0 && errDetails == nil {
+		withDetails, errDetails = withDetails.WithDetails(badRequest)
+	}
+	if errDetails != nil {
+		return st
+	}
+	return withDetails
+}
+
+// userAgent is func to get user agent of the call, tracking event require non empty user agent
+func userAgent(ctx context.Context) string {
+	md, _ := metadata.FromIncomingContext(ctx)
+	if values := md.Get("user-agent"); len(values) > 0 && len(values[0]) > 0 {
+		return values[0]
+	}
+	return "grpc"
+}
@@ -0,0 +1,248 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"aqua-farm-manager/internal/app"
+	"aqua-farm-manager/internal/app/middleware"
+	"aqua-farm-manager/internal/app/trackingevent"
+	"aqua-farm-manager/pkg/apperror"
+	"aqua-farm-manager/pkg/aquafarmpb"
+	"aqua-farm-manager/pkg/nsq/mock_nsq"
+
+	"github.com/golang/mock/gomock"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestInterceptor_Auth(t *testing.T) {
+	tests := []struct {
+		name      string
+		authToken string
+		ctx       context.Context
+		wantCode  codes.Code
+	}{
+		{
+			name:      "success flow",
+			authToken: testAuthToken,
+			ctx:       authContext(testAuthToken),
+			wantCode:  codes.OK,
+		},
+		{
+			name:      "missing token flow",
+			authToken: testAuthToken,
+			ctx:       context.Background(),
+			wantCode:  codes.Unauthenticated,
+		},
+		{
+			name:      "wrong token flow",
+			authToken: testAuthToken,
+			ctx:       authContext("wrong-token"),
+			wantCode:  codes.Unauthenticated,
+		},
+		{
+			name:      "token is not configured flow",
+			authToken: "",
+			ctx:       authContext(""),
+			wantCode:  codes.Unauthenticated,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			interceptor := NewInterceptor(middleware.Middleware{}, tt.authToken)
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				return &aquafarmpb.GetStatResponse{}, nil
+			}
+			ctx := tt.ctx
+			if md, ok := metadata.FromOutgoingContext(ctx); ok {
+				ctx = metadata.NewIncomingContext(ctx, md)
+			}
+
+			_, err := interceptor.Auth(ctx, &aquafarmpb.GetStatRequest{}, &grpc.UnaryServerInfo{FullMethod: "/aquafarm.v1.StatService/GetStat"}, handler)
+			if code := Status(err).Code(); code != tt.wantCode {
+				t.Errorf("Interceptor.Auth() code = %v, want %v, error %v", code, tt.wantCode, err)
+			}
+		})
+	}
+}
+
+func TestServer_Unauthenticated(t *testing.T) {
+	ts := newTestServer(t)
+
+	_, err := aquafarmpb.NewFarmServiceClient(ts.conn).GetFarm(authContext("wrong-token"), &aquafarmpb.GetFarmRequest{Id: 1})
+	st := status.Convert(err)
+	if st.Code() != codes.Unauthenticated {
+		t.Fatalf("GetFarm() code = %v, want %v", st.Code(), codes.Unauthenticated)
+	}
+	if got := errorReason(st); got != string(apperror.CodeUnauthenticated) {
+		t.Errorf("GetFarm() reason = %v, want %v", got, apperror.CodeUnauthenticated)
+	}
+}
+
+func TestStatus(t *testing.T) {
+	tests := []struct {
+		name           string
+		err            error
+		wantCode       codes.Code
+		wantMessage    string
+		wantReason     string
+		wantViolations map[string]string
+	}{
+		{
+			name:        "app error flow",
+			err:         apperror.New(apperror.CodeFarmNotFound, "Farm Is Not Exists"),
+			wantCode:    codes.NotFound,
+			wantMessage: "Farm Is Not Exists",
+			wantReason:  string(apperror.CodeFarmNotFound),
+		},
+		{
+			name:        "validation error flow",
+			err:         apperror.Validation(apperror.Field("name", "name is required"), apperror.Field("farm_id", "farm_id is required")),
+			wantCode:    codes.InvalidArgument,
+			wantMessage: apperror.MessageInvalidParameter,
+			wantReason:  string(apperror.CodeValidationFailed),
+			wantViolations: map[string]string{
+				"name":    "name is required",
+				"farm_id": "farm_id is required",
+			},
+		},
+		{
+			name:        "timeout error flow",
+			err:         context.DeadlineExceeded,
+			wantCode:    codes.DeadlineExceeded,
+			wantMessage: apperror.MessageTimeout,
+			wantReason:  string(apperror.CodeTimeout),
+		},
+		{
+			name:        "unknown error flow",
+			err:         errors.New("connection refused"),
+			wantCode:    codes.Internal,
+			wantMessage: apperror.MessageInternal,
+			wantReason:  string(apperror.CodeInternal),
+		},
+		{
+			name:        "status error flow",
+			err:         status.Error(codes.Unimplemented, "not implemented"),
+			wantCode:    codes.Unimplemented,
+			wantMessage: "not implemented",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			st := Status(tt.err)
+			if st.Code() != tt.wantCode || st.Message() != tt.wantMessage {
+				t.Errorf("Status() = %v %v, want %v %v", st.Code(), st.Message(), tt.wantCode, tt.wantMessage)
+			}
+			if got := errorReason(st); got != tt.wantReason {
+				t.Errorf("Status() reason = %v, want %v", got, tt.wantReason)
+			}
+
+			var violations map[string]string
+			for _, detail := range st.Details() {
+				if badRequest, ok := detail.(*errdetails.BadRequest); ok {
+					violations = map[string]string{}
+					for _, v := range badRequest.GetFieldViolations() {
+						violations[v.GetField()] = v.GetDescription()
+					}
+				}
+			}
+			if !reflect.DeepEqual(violations, tt.wantViolations) {
+				t.Errorf("Status() violations = %v, want %v", violations, tt.wantViolations)
+			}
+		})
+	}
+}
+
+func TestInterceptor_Tracking(t *testing.T) {
+	tests := []struct {
+		name       string
+		fullMethod string
+		err        error
+		ua         string
+		want       trackingevent.TrackingEventMessage
+	}{
+		{
+			name:       "success flow",
+			fullMethod: "/aquafarm.v1.FarmService/CreateFarm",
+			ua:         "grpc-go/1.41.0",
+			want:       trackingevent.TrackingEventMessage{Path: "/v1/farms", Method: http.MethodPost, Code: http.StatusOK, UA: "grpc-go/1.41.0"},
+		},
+		{
+			name:       "error flow",
+			fullMethod: "/aquafarm.v1.PondService/GetPond",
+			err:        apperror.New(apperror.CodePondNotFound, "Pond Is Not Exists"),
+			ua:         "client",
+			want:       trackingevent.TrackingEventMessage{Path: "/v1/ponds", Method: http.MethodGet, Code: http.StatusNotFound, UA: "client"},
+		},
+		{
+			name:       "unknown method flow",
+			fullMethod: "/aquafarm.v1.FarmService/Unknown",
+			err:        apperror.New(apperror.CodeUnauthenticated, apperror.MessageUnauthenticated),
+			want:       trackingevent.TrackingEventMessage{Path: "/aquafarm.v1.FarmService/Unknown", Method: http.MethodPost, Code: http.StatusUnauthorized, UA: "grpc"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			nsqMock := mock_nsq.NewMockNsqMethod(mockCtrl)
+
+			var published []interface{}
+			nsqMock.EXPECT().MultiPublish(gomock.Any(), gomock.Any()).DoAndReturn(func(topic string, data []interface{}) error {
+				published = append(published, data...)
+				return nil
+			}).AnyTimes()
+			publisher := middleware.NewPublisher("topic", nsqMock)
+			publisher.Start()
+
+			interceptor := NewInterceptor(middleware.NewMiddleware(publisher), testAuthToken)
+			ctx := context.Background()
+			if len(tt.ua) > 0 {
+				ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("user-agent", tt.ua))
+			}
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				return nil, tt.err
+			}
+
+			_, err := interceptor.Tracking(ctx, nil, &grpc.UnaryServerInfo{FullMethod: tt.fullMethod}, handler)
+			if err != tt.err {
+				t.Errorf("Interceptor.Tracking() error = %v, want %v", err, tt.err)
+			}
+
+			// every buffered event is published when the publisher is closed
+			publisher.Close(context.Background())
+			if len(published) != 1 {
+				t.Fatalf("Interceptor.Tracking() published %v event, want 1", len(published))
+			}
+			got := published[0].(trackingevent.TrackingEventMessage)
+			got.EventID, got.Version = "", 0
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Interceptor.Tracking() event = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoutes(t *testing.T) {
+	for fullMethod, r := range routes {
+		if _, ok := app.UrlIDValue[r.path]; !ok {
+			t.Errorf("route of %s has path %s, want known path of app.UrlIDName", fullMethod, r.path)
+		}
+	}
+}
+
+// errorReason is func to get reason of ErrorInfo detail of the status
+func errorReason(st *status.Status) string {
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			return info.GetReason()
+		}
+	}
+	return ""
+}
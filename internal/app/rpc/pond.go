@@ -0,0 +1,201 @@
+package rpc
+
+import (
+	"context"
+
+	"aqua-farm-manager/internal/app/pond"
+	ponddomain "aqua-farm-manager/internal/domain/pond"
+	"aqua-farm-manager/pkg/apperror"
+	"aqua-farm-manager/pkg/aquafarmpb"
+	"aqua-farm-manager/pkg/validator"
+)
+
+// PondServer list dependencies for pond grpc service
+type PondServer struct {
+	aquafarmpb.UnimplementedPondServiceServer
+	server
+	domain ponddomain.PondDomain
+}
+
+// NewPondServer is func to create grpc pond service
+func NewPondServer(domain ponddomain.PondDomain, options ...Option) *PondServer {
+	return &PondServer{
+		server: newServer(options...),
+		domain: domain,
+	}
+}
+
+// CreatePond is func to create pond in a farm, the request is validated with the rule of http api
+func (s *PondServer) CreatePond(ctx context.Context, r *aquafarmpb.CreatePondRequest) (*aquafarmpb.CreatePondResponse, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	err := validator.Validate(pond.CreatePondRequest{
+		Name:         r.GetName(),
+		Capacity:     r.GetCapacity(),
+		Depth:        r.GetDepth(),
+		WaterQuality: r.GetWaterQuality(),
+		Species:      r.GetSpecies(),
+		FarmID:       uint(r.GetFarmId()),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.domain.CreatePondInfo(ctx, ponddomain.CreateDomainRequest{
+		Name:         r.GetName(),
+		Capacity:     r.GetCapacity(),
+		Depth:        r.GetDepth(),
+		WaterQuality: r.GetWaterQuality(),
+		Species:      r.GetSpecies(),
+		FarmID:       uint(r.GetFarmId()),
+	})
+	if err != nil {
+		return nil, domainError(ctx, err)
+	}
+
+	return &aquafarmpb.CreatePondResponse{Id: uint64(res.PondID)}, nil
+}
+
+// UpdatePond is func to update pond by name, the pond is created when it does not exist
+func (s *PondServer) UpdatePond(ctx context.Context, r *aquafarmpb.UpdatePondRequest) (*aquafarmpb.Pond, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	err := validator.Validate(pond.UpdatePondRequest{
+		Name:         r.GetName(),
+		Capacity:     r.GetCapacity(),
+		Depth:        r.GetDepth(),
+		WaterQuality: r.GetWaterQuality(),
+		Species:      r.GetSpecies(),
+		FarmID:       uint(r.GetFarmId()),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.domain.UpdatePondInfo(ctx, ponddomain.UpdateDomainRequest{
+		Name:         r.GetName(),
+		Capacity:     r.GetCapacity(),
+		Depth:        r.GetDepth(),
+		WaterQuality: r.GetWaterQuality(),
+		Species:      r.GetSpecies(),
+		FarmID:       uint(r.GetFarmId()),
+	})
+	if err != nil {
+		return nil, domainError(ctx, err)
+	}
+
+	return &aquafarmpb.Pond{
+		Id:           uint64(res.ID),
+		Name:         res.Name,
+		Capacity:     res.Capacity,
+		Depth:        res.Depth,
+		WaterQuality: res.WaterQuality,
+		Species:      res.Species,
+		FarmId:       uint64(res.FarmID),
+	}, nil
+}
+
+// DeletePond is func to delete pond by id or name
+func (s *PondServer) DeletePond(ctx context.Context, r *aquafarmpb.DeletePondRequest) (*aquafarmpb.DeletePondResponse, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	err := validator.Validate(pond.DeletePondRequest{
+		PondID:   uint(r.GetId()),
+		PondName: r.GetName(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.domain.DeletePondInfo(ctx, ponddomain.DeleteDomainRequest{
+		ID:   uint(r.GetId()),
+		Name: r.GetName(),
+	})
+	if err != nil {
+		return nil, domainError(ctx, err)
+	}
+
+	return &aquafarmpb.DeletePondResponse{Id: uint64(res.ID), Name: res.Name}, nil
+}
+
+// GetPond is func to get pond with its farm
+func (s *PondServer) GetPond(ctx context.Context, r *aquafarmpb.GetPondRequest) (*aquafarmpb.GetPondResponse, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	if r.GetId() < 1 {
+		return nil, apperror.Validation(apperror.Field("id", "id must be a positive number"))
+	}
+
+	res, err := s.domain.GetPondInfoByID(ctx, uint(r.GetId()))
+	if err != nil {
+		return nil, domainError(ctx, err)
+	}
+
+	response := &aquafarmpb.GetPondResponse{
+		Pond: mapPond(res),
+	}
+	if res.FarmInfo.ID != 0 {
+		response.Farm = &aquafarmpb.Farm{
+			Id:       uint64(res.FarmInfo.ID),
+			Name:     res.FarmInfo.Name,
+			Location: res.FarmInfo.Location,
+			Owner:    res.FarmInfo.Owner,
+			Area:     res.FarmInfo.Area,
+		}
+	}
+	return response, nil
+}
+
+// ListPonds is func to get page of pond, the last page has no next cursor
+func (s *PondServer) ListPonds(ctx context.Context, r *aquafarmpb.ListPondsRequest) (*aquafarmpb.ListPondsResponse, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	page := pond.GetPondRequest{
+		Size:   int(r.GetSize()),
+		Cursor: int(r.GetCursor()),
+	}
+	err := validator.Validate(page)
+	if err != nil {
+		return nil, err
+	}
+
+	if page.Size < 1 || page.Size > defaultPageSize {
+		page.Size = defaultPageSize
+	}
+
+	if page.Cursor < 1 {
+		page.Cursor = firstCursor
+	}
+
+	res, next, err := s.domain.GetAllPond(ctx, page.Size, page.Cursor)
+	if err != nil {
+		return nil, domainError(ctx, err)
+	}
+
+	ponds := make([]*aquafarmpb.Pond, 0, len(res))
+	for _, info := range res {
+		ponds = append(ponds, mapPond(info))
+	}
+
+	return &aquafarmpb.ListPondsResponse{
+		Ponds:      ponds,
+		NextCursor: int32(next),
+	}, nil
+}
+
+func mapPond(r ponddomain.GetPondInfoResponse) *aquafarmpb.Pond {
+	return &aquafarmpb.Pond{
+		Id:           uint64(r.ID),
+		Name:         r.Name,
+		Capacity:     r.Capacity,
+		Depth:        r.Depth,
+		WaterQuality: r.WaterQuality,
+		Species:      r.Species,
+		FarmId:       uint64(r.FarmID),
+	}
+}
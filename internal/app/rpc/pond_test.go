@@ -0,0 +1,208 @@
+package rpc
+
+import (
+	"testing"
+
+	"aqua-farm-manager/internal/domain/pond"
+	"aqua-farm-manager/pkg/aquafarmpb"
+
+	"github.com/golang/mock/gomock"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestPondServer_CreatePond(t *testing.T) {
+	tests := []struct {
+		name     string
+		request  *aquafarmpb.CreatePondRequest
+		mockFunc func(ts *testServer)
+		want     *aquafarmpb.CreatePondResponse
+		wantCode codes.Code
+	}{
+		{
+			name:    "success flow",
+			request: &aquafarmpb.CreatePondRequest{Name: "pond", Capacity: 10, Depth: 2, WaterQuality: 7, Species: "fish", FarmId: 1},
+			mockFunc: func(ts *testServer) {
+				ts.pond.EXPECT().CreatePondInfo(gomock.Any(), pond.CreateDomainRequest{
+					Name:         "pond",
+					Capacity:     10,
+					Depth:        2,
+					WaterQuality: 7,
+					Species:      "fish",
+					FarmID:       1,
+				}).Return(pond.CreateDomainResponse{PondID: 3}, nil)
+			},
+			want:     &aquafarmpb.CreatePondResponse{Id: 3},
+			wantCode: codes.OK,
+		},
+		{
+			name:     "invalid water quality flow",
+			request:  &aquafarmpb.CreatePondRequest{Name: "pond", WaterQuality: 15, FarmId: 1},
+			mockFunc: func(ts *testServer) {},
+			wantCode: codes.InvalidArgument,
+		},
+		{
+			name:    "farm not found flow",
+			request: &aquafarmpb.CreatePondRequest{Name: "pond", FarmId: 1},
+			mockFunc: func(ts *testServer) {
+				ts.pond.EXPECT().CreatePondInfo(gomock.Any(), gomock.Any()).Return(pond.CreateDomainResponse{}, pond.ErrInvalidFarm)
+			},
+			wantCode: codes.NotFound,
+		},
+		{
+			name:    "max pond flow",
+			request: &aquafarmpb.CreatePondRequest{Name: "pond", FarmId: 1},
+			mockFunc: func(ts *testServer) {
+				ts.pond.EXPECT().CreatePondInfo(gomock.Any(), gomock.Any()).Return(pond.CreateDomainResponse{}, pond.ErrMaxPond)
+			},
+			wantCode: codes.FailedPrecondition,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := newTestServer(t)
+			tt.mockFunc(ts)
+
+			got, err := aquafarmpb.NewPondServiceClient(ts.conn).CreatePond(authContext(testAuthToken), tt.request)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("PondServer.CreatePond() code = %v, want %v, error %v", code, tt.wantCode, err)
+			}
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("PondServer.CreatePond() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPondServer_UpdatePond(t *testing.T) {
+	ts := newTestServer(t)
+	ts.pond.EXPECT().UpdatePondInfo(gomock.Any(), pond.UpdateDomainRequest{Name: "pond", Depth: 4, FarmID: 2}).Return(pond.UpdateDomainResponse{
+		ID:     3,
+		Name:   "pond",
+		Depth:  4,
+		FarmID: 2,
+	}, nil)
+
+	got, err := aquafarmpb.NewPondServiceClient(ts.conn).UpdatePond(authContext(testAuthToken), &aquafarmpb.UpdatePondRequest{Name: "pond", Depth: 4, FarmId: 2})
+	if err != nil {
+		t.Fatalf("PondServer.UpdatePond() error = %v", err)
+	}
+	want := &aquafarmpb.Pond{Id: 3, Name: "pond", Depth: 4, FarmId: 2}
+	if !proto.Equal(got, want) {
+		t.Errorf("PondServer.UpdatePond() = %v, want %v", got, want)
+	}
+}
+
+func TestPondServer_DeletePond(t *testing.T) {
+	tests := []struct {
+		name     string
+		request  *aquafarmpb.DeletePondRequest
+		mockFunc func(ts *testServer)
+		want     *aquafarmpb.DeletePondResponse
+		wantCode codes.Code
+	}{
+		{
+			name:    "success flow",
+			request: &aquafarmpb.DeletePondRequest{Id: 3},
+			mockFunc: func(ts *testServer) {
+				ts.pond.EXPECT().DeletePondInfo(gomock.Any(), pond.DeleteDomainRequest{ID: 3}).Return(pond.DeleteDomainResponse{ID: 3, Name: "pond"}, nil)
+			},
+			want:     &aquafarmpb.DeletePondResponse{Id: 3, Name: "pond"},
+			wantCode: codes.OK,
+		},
+		{
+			name:     "empty request flow",
+			request:  &aquafarmpb.DeletePondRequest{},
+			mockFunc: func(ts *testServer) {},
+			wantCode: codes.InvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := newTestServer(t)
+			tt.mockFunc(ts)
+
+			got, err := aquafarmpb.NewPondServiceClient(ts.conn).DeletePond(authContext(testAuthToken), tt.request)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("PondServer.DeletePond() code = %v, want %v, error %v", code, tt.wantCode, err)
+			}
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("PondServer.DeletePond() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPondServer_GetPond(t *testing.T) {
+	tests := []struct {
+		name     string
+		request  *aquafarmpb.GetPondRequest
+		mockFunc func(ts *testServer)
+		want     *aquafarmpb.GetPondResponse
+		wantCode codes.Code
+	}{
+		{
+			name:    "success flow",
+			request: &aquafarmpb.GetPondRequest{Id: 3},
+			mockFunc: func(ts *testServer) {
+				ts.pond.EXPECT().GetPondInfoByID(gomock.Any(), uint(3)).Return(pond.GetPondInfoResponse{
+					ID:       3,
+					Name:     "pond",
+					FarmID:   1,
+					FarmInfo: pond.FarmInfo{ID: 1, Name: "farm"},
+				}, nil)
+			},
+			want: &aquafarmpb.GetPondResponse{
+				Pond: &aquafarmpb.Pond{Id: 3, Name: "pond", FarmId: 1},
+				Farm: &aquafarmpb.Farm{Id: 1, Name: "farm"},
+			},
+			wantCode: codes.OK,
+		},
+		{
+			name:    "not found flow",
+			request: &aquafarmpb.GetPondRequest{Id: 3},
+			mockFunc: func(ts *testServer) {
+				ts.pond.EXPECT().GetPondInfoByID(gomock.Any(), uint(3)).Return(pond.GetPondInfoResponse{}, pond.ErrInvalidPond)
+			},
+			wantCode: codes.NotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := newTestServer(t)
+			tt.mockFunc(ts)
+
+			got, err := aquafarmpb.NewPondServiceClient(ts.conn).GetPond(authContext(testAuthToken), tt.request)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("PondServer.GetPond() code = %v, want %v, error %v", code, tt.wantCode, err)
+			}
+			if !proto.Equal(got, tt.want) {
+				t.Errorf("PondServer.GetPond() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPondServer_ListPonds(t *testing.T) {
+	ts := newTestServer(t)
+	ts.pond.EXPECT().GetAllPond(gomock.Any(), 2, 1).Return([]pond.GetPondInfoResponse{
+		{ID: 1, Name: "p1", FarmID: 1},
+		{ID: 2, Name: "p2", FarmID: 1},
+	}, 3, nil)
+
+	got, err := aquafarmpb.NewPondServiceClient(ts.conn).ListPonds(authContext(testAuthToken), &aquafarmpb.ListPondsRequest{Size: 2})
+	if err != nil {
+		t.Fatalf("PondServer.ListPonds() error = %v", err)
+	}
+	want := &aquafarmpb.ListPondsResponse{
+		Ponds: []*aquafarmpb.Pond{
+			{Id: 1, Name: "p1", FarmId: 1},
+			{Id: 2, Name: "p2", FarmId: 1},
+		},
+		NextCursor: 3,
+	}
+	if !proto.Equal(got, want) {
+		t.Errorf("PondServer.ListPonds() = %v, want %v", got, want)
+	}
+}
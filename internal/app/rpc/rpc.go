@@ -0,0 +1,78 @@
+package rpc
+
+import (
+	"context"
+	"time"
+
+	"aqua-farm-manager/pkg/apperror"
+	"aqua-farm-manager/pkg/aquafarmpb"
+
+	"google.golang.org/grpc"
+)
+
+// list default value of page request, it is the same with http api
+const (
+	defaultTimeout  = 5
+	defaultPageSize = 20
+	firstCursor     = 1
+)
+
+// server list config that is shared by every grpc service
+type server struct {
+	timeoutInSec int
+}
+
+// Option set options for grpc service config
+type Option func(*server)
+
+// WithTimeoutOptions is func to set timeout config into grpc service
+func WithTimeoutOptions(timeoutinsec int) Option {
+	return Option(
+		func(s *server) {
+			if timeoutinsec <= 0 {
+				timeoutinsec = defaultTimeout
+			}
+			s.timeoutInSec = timeoutinsec
+		})
+}
+
+func newServer(options ...Option) server {
+	s := server{
+		timeoutInSec: defaultTimeout,
+	}
+
+	// Apply options
+	for _, opt := range options {
+		opt(&s)
+	}
+
+	return s
+}
+
+// withTimeout is func to limit the call with timeout of the service
+func (s *server) withTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, time.Duration(s.timeoutInSec)*time.Second)
+}
+
+// domainError is func to get error of domain call, the error is timeout when ctx is done
+func domainError(ctx context.Context, err error) error {
+	if ctx.Err() != nil {
+		return apperror.Wrap(apperror.CodeTimeout, apperror.MessageTimeout, err)
+	}
+	return err
+}
+
+// NewServer is func to create grpc server with every service, the error is mapped into grpc status
+// after the call is authenticated and tracked, the same as http api the call that is not authenticated is not tracked
+func NewServer(interceptor *Interceptor, farm *FarmServer, pond *PondServer, stat *StatServer) *grpc.Server {
+	s := grpc.NewServer(grpc.ChainUnaryInterceptor(
+		interceptor.ErrorCode,
+		interceptor.Auth,
+		interceptor.Tracking,
+	))
+
+	aquafarmpb.RegisterFarmServiceServer(s, farm)
+	aquafarmpb.RegisterPondServiceServer(s, pond)
+	aquafarmpb.RegisterStatServiceServer(s, stat)
+	return s
+}
@@ -0,0 +1,104 @@
+package rpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"aqua-farm-manager/internal/app/middleware"
+	"aqua-farm-manager/internal/domain/farm/mock_farm"
+	"aqua-farm-manager/internal/domain/pond/mock_pond"
+	"aqua-farm-manager/internal/domain/stat/mock_stat"
+	"aqua-farm-manager/pkg/nsq/mock_nsq"
+
+	"github.com/golang/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const (
+	testAuthToken   = "secret-token"
+	testCallTimeout = 5 * time.Second
+)
+
+// testServer is grpc server on bufconn with mock domain of every service
+type testServer struct {
+	conn      *grpc.ClientConn
+	farm      *mock_farm.MockFarmDomain
+	pond      *mock_pond.MockPondDomain
+	stat      *mock_stat.MockStatDomain
+	publisher *middleware.Publisher
+}
+
+// newTestServer is func to start grpc server from NewServer on bufconn, the server is stopped when the test is done
+func newTestServer(t *testing.T, options ...Option) *testServer {
+	t.Helper()
+
+	mockCtrl := gomock.NewController(t)
+	nsqMock := mock_nsq.NewMockNsqMethod(mockCtrl)
+	nsqMock.EXPECT().MultiPublish(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+	ts := &testServer{
+		farm:      mock_farm.NewMockFarmDomain(mockCtrl),
+		pond:      mock_pond.NewMockPondDomain(mockCtrl),
+		stat:      mock_stat.NewMockStatDomain(mockCtrl),
+		publisher: middleware.NewPublisher("topic", nsqMock),
+	}
+	ts.publisher.Start()
+
+	s := NewServer(
+		NewInterceptor(middleware.NewMiddleware(ts.publisher), testAuthToken),
+		NewFarmServer(ts.farm, options...),
+		NewPondServer(ts.pond, options...),
+		NewStatServer(ts.stat, options...),
+	)
+	lis := bufconn.Listen(1024 * 1024)
+	go s.Serve(lis)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return lis.DialContext(ctx)
+		}),
+		grpc.WithInsecure(),
+		// unexpected call of mock domain stop the server handler, the deadline make the call fail instead of hang
+		grpc.WithUnaryInterceptor(func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+			ctx, cancel := context.WithTimeout(ctx, testCallTimeout)
+			defer cancel()
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}))
+	if err != nil {
+		t.Fatalf("grpc.Dial() error = %v", err)
+	}
+	ts.conn = conn
+
+	t.Cleanup(func() {
+		conn.Close()
+		s.Stop()
+		ts.publisher.Close(context.Background())
+	})
+	return ts
+}
+
+// authContext is func to get context with the bearer token in authorization metadata
+func authContext(token string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+}
+
+func TestWithTimeoutOptions(t *testing.T) {
+	tests := []struct {
+		name    string
+		timeout int
+		want    int
+	}{
+		{name: "set timeout", timeout: 10, want: 10},
+		{name: "default timeout", timeout: 0, want: defaultTimeout},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := newServer(WithTimeoutOptions(tt.timeout)); got.timeoutInSec != tt.want {
+				t.Errorf("WithTimeoutOptions() timeout = %v, want %v", got.timeoutInSec, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package rpc
+
+import (
+	"context"
+
+	statdomain "aqua-farm-manager/internal/domain/stat"
+	"aqua-farm-manager/pkg/apperror"
+	"aqua-farm-manager/pkg/aquafarmpb"
+)
+
+// StatServer list dependencies for stat grpc service
+type StatServer struct {
+	aquafarmpb.UnimplementedStatServiceServer
+	server
+	domain statdomain.StatDomain
+}
+
+// NewStatServer is func to create grpc stat service
+func NewStatServer(domain statdomain.StatDomain, options ...Option) *StatServer {
+	return &StatServer{
+		server: newServer(options...),
+		domain: domain,
+	}
+}
+
+// GetStat is func to get request statistic by method and path
+func (s *StatServer) GetStat(ctx context.Context, r *aquafarmpb.GetStatRequest) (*aquafarmpb.GetStatResponse, error) {
+	ctx, cancel := s.withTimeout(ctx)
+	defer cancel()
+
+	metrics := s.domain.GenerateStatAPI(ctx)
+	if ctx.Err() != nil {
+		return nil, apperror.Wrap(apperror.CodeTimeout, apperror.MessageTimeout, ctx.Err())
+	}
+
+	response := &aquafarmpb.GetStatResponse{
+		Metrics: make(map[string]*aquafarmpb.StatMetrics, len(metrics)),
+	}
+	for key, value := range metrics {
+		response.Metrics[key] = &aquafarmpb.StatMetrics{
+			Count:           int64(value.NumRequested),
+			UniqueUserAgent: int64(value.NumUniqAgent),
+			NumSuccess:      int64(value.NumSuccess),
+			NumError:        int64(value.NumError),
+		}
+	}
+	return response, nil
+}
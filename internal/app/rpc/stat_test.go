@@ -0,0 +1,31 @@
+package rpc
+
+import (
+	"testing"
+
+	"aqua-farm-manager/internal/domain/stat"
+	"aqua-farm-manager/pkg/aquafarmpb"
+
+	"github.com/golang/mock/gomock"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestStatServer_GetStat(t *testing.T) {
+	ts := newTestServer(t)
+	ts.stat.EXPECT().GenerateStatAPI(gomock.Any()).Return(map[string]stat.StatMetrics{
+		"POST /v1/farms": {NumRequested: 3, NumUniqAgent: 1, NumSuccess: 2, NumError: 1},
+	})
+
+	got, err := aquafarmpb.NewStatServiceClient(ts.conn).GetStat(authContext(testAuthToken), &aquafarmpb.GetStatRequest{})
+	if err != nil {
+		t.Fatalf("StatServer.GetStat() error = %v", err)
+	}
+	want := &aquafarmpb.GetStatResponse{
+		Metrics: map[string]*aquafarmpb.StatMetrics{
+			"POST /v1/farms": {Count: 3, UniqueUserAgent: 1, NumSuccess: 2, NumError: 1},
+		},
+	}
+	if !proto.Equal(got, want) {
+		t.Errorf("StatServer.GetStat() = %v, want %v", got, want)
+	}
+}
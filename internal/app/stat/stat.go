@@ -2,10 +2,10 @@ package stat
 
 import (
 	"aqua-farm-manager/internal/domain/stat"
+	"aqua-farm-manager/pkg/apperror"
 	"aqua-farm-manager/pkg/utilhttp"
 	"context"
 	"encoding/json"
-	"fmt"
 	"log"
 	"net/http"
 	"time"
@@ -60,12 +60,16 @@ func (h *StatHandler) GetStatHandler(w http.ResponseWriter, r *http.Request) {
 	var code int = http.StatusOK
 
 	defer func() {
-		response.Code = code
-		if err == nil {
-			response.Message = "success"
+		if err != nil {
+			code = apperror.HTTPStatus(err)
+			if code == http.StatusInternalServerError {
+				log.Println("[GetStatHandler]-Internal Error :", err)
+			}
+			response.SetError(err)
 		} else {
-			response.Message = err.Error()
+			response.Message = "success"
 		}
+		response.Code = code
 
 		data, errMarshal := json.Marshal(response)
 		if errMarshal != nil {
@@ -76,19 +80,11 @@ func (h *StatHandler) GetStatHandler(w http.ResponseWriter, r *http.Request) {
 		utilhttp.WriteResponse(w, data, code)
 	}()
 
-	errChan := make(chan error, 1)
 	var metrics map[string]stat.StatMetrics
-	go func(ctx context.Context) {
-		metrics = h.stat.GenerateStatAPI()
-		errChan <- nil
-	}(ctx)
-
-	select {
-	case <-ctx.Done():
-		code = http.StatusGatewayTimeout
-		err = fmt.Errorf("Timeout")
+	metrics = h.stat.GenerateStatAPI(ctx)
+	if ctx.Err() != nil {
+		err = apperror.Wrap(apperror.CodeTimeout, apperror.MessageTimeout, err)
 		return
-	case err = <-errChan:
 	}
 
 	response = mapResponse(metrics)
@@ -115,7 +111,7 @@ func (h *StatHandler) InitMigrate(tickInMinute int) {
 		tick := time.Tick(time.Duration(tickInMinute) * time.Minute)
 		for range tick {
 			log.Println("Running Backup Stat")
-			h.stat.BackUpStat()
+			h.stat.BackUpStat(context.Background())
 		}
 	}()
 }
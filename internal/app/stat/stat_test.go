@@ -91,7 +91,7 @@ func TestStatHandler_GetStatHandler(t *testing.T) {
 				timeout: 5,
 			},
 			mockFunc: func(msd *mock_stat.MockStatDomain) {
-				msd.EXPECT().GenerateStatAPI().Return(map[string]stat.StatMetrics{"POST /farms": {NumRequested: 3, NumUniqAgent: 1, NumSuccess: 2, NumError: 1}})
+				msd.EXPECT().GenerateStatAPI(gomock.Any()).Return(map[string]stat.StatMetrics{"POST /farms": {NumRequested: 3, NumUniqAgent: 1, NumSuccess: 2, NumError: 1}})
 			},
 			mockContext: func() (context.Context, func()) {
 				return context.Background(), func() {}
@@ -107,14 +107,14 @@ func TestStatHandler_GetStatHandler(t *testing.T) {
 				timeout: 0,
 			},
 			mockFunc: func(msd *mock_stat.MockStatDomain) {
-				msd.EXPECT().GenerateStatAPI().Return(map[string]stat.StatMetrics{}).AnyTimes()
+				msd.EXPECT().GenerateStatAPI(gomock.Any()).Return(map[string]stat.StatMetrics{}).AnyTimes()
 			},
 			mockContext: func() (context.Context, func()) {
 				return context.Background(), func() {}
 			},
 			want: want{
 				code: 504,
-				body: `{"code":504,"message":"Timeout"}`,
+				body: `{"code":504,"message":"Timeout","errors":[{"code":"TIMEOUT","message":"Timeout"}]}`,
 			},
 		},
 	}
@@ -0,0 +1,107 @@
+package trackingevent
+
+import (
+	"aqua-farm-manager/internal/domain/stat"
+	"aqua-farm-manager/internal/domain/stat/mock_stat"
+	"aqua-farm-manager/pkg/bus/memory"
+	nsqclient "aqua-farm-manager/pkg/nsq"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestTrackingEventConsumer_MemoryBus(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	domain := mock_stat.NewMockStatDomain(mockCtrl)
+
+	schemas, err := nsqclient.LoadSchemas("../../../schema/nsq")
+	if err != nil {
+		t.Fatalf("LoadSchemas() error = %v", err)
+	}
+
+	topic := "aqua_farm_tracking_event"
+	b := memory.NewBus(memory.WithSchemaOptions(schemas))
+	c := NewTrackingEventConsumer(topic, "tracking_event", b, 1, 1, 1, domain,
+		WithDeadLetterOptions("aqua_farm_tracking_event_dlq", b),
+		WithSchemaOptions(schemas))
+	c.retryBackoff = time.Millisecond
+	if err := c.Start(); err != nil {
+		t.Fatalf("TrackingEventConsumer.Start() error = %v", err)
+	}
+
+	ingested := make(chan stat.IngestStatRequest, 10)
+	record := func(_ context.Context, r stat.IngestStatRequest) error {
+		ingested <- r
+		return nil
+	}
+	wait := func(want stat.IngestStatRequest) {
+		t.Helper()
+		select {
+		case got := <-ingested:
+			if got != want {
+				t.Fatalf("IngestStatAPI() got = %+v, want %+v", got, want)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("IngestStatAPI() is not called")
+		}
+	}
+
+	t.Run("transient error is retried", func(t *testing.T) {
+		gomock.InOrder(
+			domain.EXPECT().IngestStatAPI(gomock.Any(), gomock.Any()).Return(fmt.Errorf("%w: redis down", stat.ErrTransientIngest)),
+			domain.EXPECT().IngestStatAPI(gomock.Any(), gomock.Any()).DoAndReturn(record),
+		)
+		err := b.Publish(topic, TrackingEventMessage{
+			EventID: "0b8f5d3c-6a2e-4c1e-9a57-0c3e5a1f9d21",
+			Version: TrackingEventVersion,
+			Path:    "/v1/farms/1",
+			Code:    200,
+			Method:  "GET",
+			UA:      "curl",
+		})
+		if err != nil {
+			t.Fatalf("Publish() error = %v", err)
+		}
+		wait(stat.IngestStatRequest{Path: "/v1/farms", Method: "GET", Ua: "curl", Code: 200})
+	})
+
+	t.Run("permanent error is replayed from dead letter", func(t *testing.T) {
+		gomock.InOrder(
+			domain.EXPECT().IngestStatAPI(gomock.Any(), gomock.Any()).Return(fmt.Errorf("%w: bad path", stat.ErrPermanentIngest)),
+			domain.EXPECT().IngestStatAPI(gomock.Any(), gomock.Any()).DoAndReturn(record),
+		)
+		err := b.Publish(topic, TrackingEventMessage{
+			EventID: "5f2e7a90-1c3b-4d8e-a6f4-2b9c0d1e3f45",
+			Version: TrackingEventVersion,
+			Path:    "/v1/ponds",
+			Code:    201,
+			Method:  "POST",
+			UA:      "curl",
+		})
+		if err != nil {
+			t.Fatalf("Publish() error = %v", err)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		result, err := c.ReplayDeadLetter(ctx, 1)
+		if err != nil {
+			t.Fatalf("ReplayDeadLetter() error = %v", err)
+		}
+		if result.Replayed != 1 {
+			t.Fatalf("ReplayDeadLetter() got = %+v, want 1 replayed", result)
+		}
+		wait(stat.IngestStatRequest{Path: "/v1/ponds", Method: "POST", Ua: "curl", Code: 201})
+	})
+
+	t.Run("message not match schema is not published", func(t *testing.T) {
+		err := b.Publish(topic, TrackingEventMessage{Version: TrackingEventVersion, Path: "/v1/ponds"})
+		if err == nil {
+			t.Fatalf("Publish() expect schema error")
+		}
+	})
+}
@@ -0,0 +1,153 @@
+package trackingevent
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"aqua-farm-manager/pkg/apperror"
+	"aqua-farm-manager/pkg/bus"
+	nsqclient "aqua-farm-manager/pkg/nsq"
+)
+
+// list error of replay dead letter
+var (
+	ErrDeadLetterDisabled = apperror.New(apperror.CodeUnavailable, "dead letter topic is not configured")
+	ErrReplayInProgress   = apperror.New(apperror.CodeConflict, "dead letter replay is in progress")
+)
+
+const (
+	// replayChannel is channel of dead letter topic used to replay message,
+	// the channel keep the message published to dead letter topic until it is replayed
+	replayChannel = "replay"
+	// replayIdleTimeout is time to wait new message before replay is considered done
+	replayIdleTimeout = 3 * time.Second
+)
+
+// DeadLetterReplayer is list method to replay message from dead letter topic
+type DeadLetterReplayer interface {
+	ReplayDeadLetter(ctx context.Context, limit int) (ReplayResult, error)
+}
+
+// ReplayResult is summary of replay dead letter
+type ReplayResult struct {
+	Replayed  int
+	Discarded int
+	Failed    int
+}
+
+// ReplayDeadLetter is func to publish back up to limit message from dead letter topic into the origin topic,
+// replay is stopped when limit is reached, no message come in replayIdleTimeout or the context is done
+func (c *TrackingEventConsumer) ReplayDeadLetter(ctx context.Context, limit int) (ReplayResult, error) {
+	var result ReplayResult
+	if c.producer == nil || c.dlqTopic == "" {
+		return result, ErrDeadLetterDisabled
+	}
+
+	if !c.replayMu.TryLock() {
+		return result, ErrReplayInProgress
+	}
+	defer c.replayMu.Unlock()
+
+	var mu sync.Mutex
+	var processed int
+	var once sync.Once
+	done := make(chan struct{})
+	activity := make(chan struct{}, 1)
+	handler := bus.HandlerFunc(func(msg bus.Message) error {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if processed >= limit {
+			msg.RequeueWithoutBackoff(0)
+			return nil
+		}
+		processed++
+
+		replayed, err := c.replayMessage(msg.Body())
+		switch {
+		case err != nil:
+			fmt.Println("TrackingEventConsumer-Got Error Replay Dead Letter :", err)
+			result.Failed++
+			msg.RequeueWithoutBackoff(c.retryBackoff)
+		case replayed:
+			result.Replayed++
+			msg.Finish()
+		default:
+			fmt.Println("TrackingEventConsumer-Discard Dead Letter :", string(msg.Body()))
+			result.Discarded++
+			msg.Finish()
+		}
+
+		select {
+		case activity <- struct{}{}:
+		default:
+		}
+		if processed >= limit {
+			once.Do(func() { close(done) })
+		}
+		return nil
+	})
+
+	subscription, err := c.subscriber.Subscribe(c.dlqTopic, replayChannel, handler, bus.SubscribeConfig{
+		MaxInFlight: 1,
+		Concurrency: 1,
+	})
+	if err != nil {
+		return result, err
+	}
+
+	idle := time.NewTimer(replayIdleTimeout)
+	defer idle.Stop()
+wait:
+	for {
+		select {
+		case <-ctx.Done():
+			break wait
+		case <-done:
+			break wait
+		case <-idle.C:
+			break wait
+		case <-activity:
+			if !idle.Stop() {
+				<-idle.C
+			}
+			idle.Reset(replayIdleTimeout)
+		}
+	}
+
+	subscription.Stop()
+	<-subscription.Done()
+
+	mu.Lock()
+	defer mu.Unlock()
+	return result, nil
+}
+
+// replayMessage is func to publish original body of dead letter into the topic of the consumer,
+// it return false without error when the dead letter can not be replayed anymore
+// because it is not valid json, it is dead letter of other topic or it does not match the topic schema
+func (c *TrackingEventConsumer) replayMessage(data []byte) (bool, error) {
+	var dl DeadLetterMessage
+	err := json.Unmarshal(data, &dl)
+	if err != nil || !json.Valid([]byte(dl.Body)) {
+		return false, nil
+	}
+
+	// the dead letter is only published into the topic of this consumer, so the body can not choose the topic
+	if dl.Topic != "" && dl.Topic != c.topic {
+		return false, nil
+	}
+
+	err = c.producer.Publish(c.topic, json.RawMessage(dl.Body))
+	if errors.Is(err, nsqclient.ErrInvalidMessage) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
@@ -2,74 +2,172 @@ package trackingevent
 
 import (
 	"aqua-farm-manager/internal/domain/stat"
+	"aqua-farm-manager/pkg/bus"
+	nsqclient "aqua-farm-manager/pkg/nsq"
+	"aqua-farm-manager/pkg/prometheus"
+	"context"
+	"errors"
 	"regexp"
 	"time"
 
 	"encoding/json"
 	"fmt"
-	"strings"
+	"sync"
+)
+
+// consumedTotal count consumed message by topic and status
+var consumedTotal = prometheus.Default.NewCounterVec(
+	"aqua_farm_nsq_consumed_messages_total",
+	"Total number of message consumed from nsq by topic and status.",
+	"topic", "status",
+)
+
+// deadLetterTotal count message sent to dead letter topic by origin topic
+var deadLetterTotal = prometheus.Default.NewCounterVec(
+	"aqua_farm_nsq_dead_letter_messages_total",
+	"Total number of message sent to dead letter topic by origin topic.",
+	"topic",
+)
+
+// ErrInvalidMessage is error for message that can not be processed no matter how many it retried
+var ErrInvalidMessage = errors.New("invalid tracking event message")
 
-	"github.com/nsqio/go-nsq"
+const (
+	defaultMaxAttempts       = 5
+	defaultRetryBackoffInSec = 1
+	maxRetryBackoff          = 10 * time.Minute
 )
 
 // TrackingEventConsumer is list dependencies of consumer
 type TrackingEventConsumer struct {
 	topic        string
 	channel      string
-	host         string
+	subscriber   bus.Subscriber
 	maxInFlight  int
 	numconsumer  int
 	timeoutInSec int
 	stat         stat.StatDomain
+	maxAttempts  int
+	retryBackoff time.Duration
+	dlqTopic     string
+	producer     bus.Publisher
+	schemas      *nsqclient.Schemas
+	replayMu     sync.Mutex
 }
 
+// Option set options for tracking event consumer
+type Option func(*TrackingEventConsumer)
+
 // NewTrackingEventConsumer is func to create TrackingEventConsumer
-func NewTrackingEventConsumer(topic, channel, host string, maxInFlight, numconsumer, timeoutInSec int, stat stat.StatDomain) *TrackingEventConsumer {
-	return &TrackingEventConsumer{
+func NewTrackingEventConsumer(topic, channel string, subscriber bus.Subscriber, maxInFlight, numconsumer, timeoutInSec int, stat stat.StatDomain, options ...Option) *TrackingEventConsumer {
+	consumer := &TrackingEventConsumer{
 		topic:        topic,
 		channel:      channel,
-		host:         host,
+		subscriber:   subscriber,
 		stat:         stat,
 		maxInFlight:  maxInFlight,
 		numconsumer:  numconsumer,
 		timeoutInSec: timeoutInSec,
+		maxAttempts:  defaultMaxAttempts,
+		retryBackoff: defaultRetryBackoffInSec * time.Second,
+	}
+
+	// Apply options
+	for _, opt := range options {
+		opt(consumer)
 	}
+
+	return consumer
 }
 
-// Start is func to start the consumer
+// WithRetryOptions is func to set max attempts and base backoff of failed message,
+// the backoff is doubled on every attempt
+func WithRetryOptions(maxAttempts, backoffInSec int) Option {
+	return Option(
+		func(c *TrackingEventConsumer) {
+			if maxAttempts <= 0 {
+				maxAttempts = defaultMaxAttempts
+			}
+			if backoffInSec <= 0 {
+				backoffInSec = defaultRetryBackoffInSec
+			}
+			c.maxAttempts = maxAttempts
+			c.retryBackoff = time.Duration(backoffInSec) * time.Second
+		})
+}
+
+// WithDeadLetterOptions is func to set dead letter topic and producer to publish message that run out of attempts
+func WithDeadLetterOptions(topic string, producer bus.Publisher) Option {
+	return Option(
+		func(c *TrackingEventConsumer) {
+			c.dlqTopic = topic
+			c.producer = producer
+		})
+}
+
+// WithSchemaOptions is func to validate consumed message against the topic schema
+func WithSchemaOptions(schemas *nsqclient.Schemas) Option {
+	return Option(
+		func(c *TrackingEventConsumer) {
+			c.schemas = schemas
+		})
+}
+
+// Start is func to start the consumer, attempts is handled by HandleMessage
+// so the message is not dropped before sent to dead letter topic
 func (c *TrackingEventConsumer) Start() error {
-	config := nsq.NewConfig()
-	config.MaxInFlight = c.maxInFlight
-	config.MsgTimeout = time.Duration(c.timeoutInSec) * time.Second
-	consumer, err := nsq.NewConsumer(c.topic, c.channel, config)
+	_, err := c.subscriber.Subscribe(c.topic, c.channel, c, bus.SubscribeConfig{
+		MaxInFlight: c.maxInFlight,
+		Concurrency: c.numconsumer,
+		MsgTimeout:  time.Duration(c.timeoutInSec) * time.Second,
+	})
+	return err
+}
+
+// HandleMessage is func to handler the message from aqua_farm_tracking_event,
+// failed message is requeued with backoff until max attempts then sent to dead letter topic.
+// The ingestion is cancelled after the message timeout because the message is delivered again after it
+func (c *TrackingEventConsumer) HandleMessage(msg bus.Message) error {
+	ctx := context.Background()
+	if c.timeoutInSec > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(c.timeoutInSec)*time.Second)
+		defer cancel()
+	}
+
+	err := c.processMessage(ctx, msg.Body())
 	if err != nil {
+		consumedTotal.Inc(c.topic, "error")
+		fmt.Println("TrackingEventConsumer-Got Error :", err)
+		c.handleFailedMessage(msg, err)
 		return err
 	}
 
-	consumer.AddConcurrentHandlers(c, c.numconsumer)
+	consumedTotal.Inc(c.topic, "success")
+	msg.Finish()
+	return nil
+}
 
-	lookupd := strings.Split(c.host, ",")
-	if len(lookupd) < 1 {
-		return fmt.Errorf("invalid lookupd config")
+func (c *TrackingEventConsumer) processMessage(ctx context.Context, data []byte) error {
+	if c.schemas != nil {
+		err := c.schemas.Validate(c.topic, data)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrInvalidMessage, err)
+		}
 	}
-	return consumer.ConnectToNSQLookupds(lookupd)
-}
 
-// HandleMessage is func to handler the message from aqua_farm_tracking_event
-func (c *TrackingEventConsumer) HandleMessage(msg *nsq.Message) error {
-	var err error
-	msg.DisableAutoResponse()
 	var body TrackingEventMessage
-	defer msg.Finish()
-	err = json.Unmarshal(msg.Body, &body)
+	err := json.Unmarshal(data, &body)
 	if err != nil {
-		fmt.Println("TrackingEventConsumer-Got Error Unmarshal :", err)
-		return err
+		return fmt.Errorf("%w: %v", ErrInvalidMessage, err)
 	}
 
 	// checking valid body
+	if body.Version != TrackingEventVersion {
+		return fmt.Errorf("%w: unsupported version %d", ErrInvalidMessage, body.Version)
+	}
 	if len(body.Path) < 1 || len(body.Method) < 1 || len(body.UA) < 1 {
-		return nil
+		return fmt.Errorf("%w: path, method and ua is mandatory", ErrInvalidMessage)
 	}
 
 	// Validate path contain ID
@@ -81,11 +179,62 @@ func (c *TrackingEventConsumer) HandleMessage(msg *nsq.Message) error {
 		path = "/" + split[1]
 	}
 
-	c.stat.IngestStatAPI(stat.IngestStatRequest{
+	return c.stat.IngestStatAPI(ctx, stat.IngestStatRequest{
 		Path:   path,
 		Method: body.Method,
 		Ua:     body.UA,
 		Code:   body.Code,
 	})
-	return nil
+}
+
+// handleFailedMessage is func to decide failed message is requeued or sent to dead letter topic,
+// permanent error is sent directly because retrying will not fix it
+func (c *TrackingEventConsumer) handleFailedMessage(msg bus.Message, cause error) {
+	if !isPermanentError(cause) && int(msg.Attempts()) < c.maxAttempts {
+		msg.Requeue(c.backoff(msg.Attempts()))
+		return
+	}
+
+	if c.producer == nil || c.dlqTopic == "" {
+		fmt.Println("TrackingEventConsumer-Drop Message Without Dead Letter Topic :", string(msg.Body()))
+		msg.Finish()
+		return
+	}
+
+	err := c.producer.Publish(c.dlqTopic, DeadLetterMessage{
+		Version:  DeadLetterVersion,
+		Topic:    c.topic,
+		Channel:  c.channel,
+		Body:     string(msg.Body()),
+		Error:    cause.Error(),
+		Attempts: msg.Attempts(),
+		FailedAt: time.Now().Unix(),
+	})
+	if err != nil {
+		// keep the message in the main topic so it is not lost when dead letter topic is unavailable
+		fmt.Println("TrackingEventConsumer-Got Error Publish Dead Letter :", err)
+		msg.Requeue(c.backoff(msg.Attempts()))
+		return
+	}
+
+	deadLetterTotal.Inc(c.topic)
+	msg.Finish()
+}
+
+// isPermanentError is func to check error always fail for the same message,
+// unclassified error is treated as transient so the message is not dead lettered too early
+func isPermanentError(err error) bool {
+	return errors.Is(err, ErrInvalidMessage) || errors.Is(err, stat.ErrPermanentIngest)
+}
+
+// backoff is func to get requeue delay of the attempts, the delay is doubled every attempt
+func (c *TrackingEventConsumer) backoff(attempts uint16) time.Duration {
+	delay := c.retryBackoff
+	for i := uint16(1); i < attempts; i++ {
+		delay *= 2
+		if delay >= maxRetryBackoff {
+			return maxRetryBackoff
+		}
+	}
+	return delay
 }
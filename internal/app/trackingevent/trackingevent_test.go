@@ -3,6 +3,12 @@ package trackingevent
 import (
 	"aqua-farm-manager/internal/domain/stat"
 	"aqua-farm-manager/internal/domain/stat/mock_stat"
+	"aqua-farm-manager/pkg/bus"
+	nsqclient "aqua-farm-manager/pkg/nsq"
+	"aqua-farm-manager/pkg/nsq/mock_nsq"
+	"context"
+	"encoding/json"
+	"fmt"
 	"reflect"
 	"testing"
 	"time"
@@ -15,11 +21,12 @@ func TestNewTrackingEverntConsumer(t *testing.T) {
 	type args struct {
 		topic        string
 		channel      string
-		host         string
+		subscriber   bus.Subscriber
 		maxInFlight  int
 		numconsumer  int
 		timeoutInSec int
 		stat         stat.StatDomain
+		options      []Option
 	}
 	tests := []struct {
 		name string
@@ -31,7 +38,6 @@ func TestNewTrackingEverntConsumer(t *testing.T) {
 			args: args{
 				topic:        "topic",
 				channel:      "channel",
-				host:         "host",
 				maxInFlight:  1,
 				numconsumer:  1,
 				timeoutInSec: 1,
@@ -40,17 +46,67 @@ func TestNewTrackingEverntConsumer(t *testing.T) {
 			want: &TrackingEventConsumer{
 				topic:        "topic",
 				channel:      "channel",
-				host:         "host",
 				maxInFlight:  1,
 				numconsumer:  1,
 				timeoutInSec: 1,
 				stat:         &stat.Stat{},
+				maxAttempts:  5,
+				retryBackoff: time.Second,
+			},
+		},
+		{
+			name: "success with option",
+			args: args{
+				topic:        "topic",
+				channel:      "channel",
+				maxInFlight:  1,
+				numconsumer:  1,
+				timeoutInSec: 1,
+				stat:         &stat.Stat{},
+				options: []Option{
+					WithRetryOptions(3, 2),
+					WithDeadLetterOptions("topic_dlq", &nsqclient.Client{}),
+				},
+			},
+			want: &TrackingEventConsumer{
+				topic:        "topic",
+				channel:      "channel",
+				maxInFlight:  1,
+				numconsumer:  1,
+				timeoutInSec: 1,
+				stat:         &stat.Stat{},
+				maxAttempts:  3,
+				retryBackoff: 2 * time.Second,
+				dlqTopic:     "topic_dlq",
+				producer:     &nsqclient.Client{},
+			},
+		},
+		{
+			name: "success with invalid retry option",
+			args: args{
+				topic:        "topic",
+				channel:      "channel",
+				maxInFlight:  1,
+				numconsumer:  1,
+				timeoutInSec: 1,
+				stat:         &stat.Stat{},
+				options:      []Option{WithRetryOptions(0, 0)},
+			},
+			want: &TrackingEventConsumer{
+				topic:        "topic",
+				channel:      "channel",
+				maxInFlight:  1,
+				numconsumer:  1,
+				timeoutInSec: 1,
+				stat:         &stat.Stat{},
+				maxAttempts:  5,
+				retryBackoff: time.Second,
 			},
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := NewTrackingEventConsumer(tt.args.topic, tt.args.channel, tt.args.host, tt.args.maxInFlight, tt.args.numconsumer, tt.args.timeoutInSec, tt.args.stat); !reflect.DeepEqual(got, tt.want) {
+			if got := NewTrackingEventConsumer(tt.args.topic, tt.args.channel, tt.args.subscriber, tt.args.maxInFlight, tt.args.numconsumer, tt.args.timeoutInSec, tt.args.stat, tt.args.options...); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("NewTrackingEventConsumer() = %v, want %v", got, tt.want)
 			}
 		})
@@ -59,11 +115,17 @@ func TestNewTrackingEverntConsumer(t *testing.T) {
 
 type mockNSQ struct {
 	nsq.Message
+	finished bool
+	requeued bool
+	delay    time.Duration
 }
 
-func (m *mockNSQ) OnFinish(msg *nsq.Message) {}
+func (m *mockNSQ) OnFinish(msg *nsq.Message) { m.finished = true }
 
-func (m *mockNSQ) OnRequeue(msg *nsq.Message, delay time.Duration, backoff bool) {}
+func (m *mockNSQ) OnRequeue(msg *nsq.Message, delay time.Duration, backoff bool) {
+	m.requeued = true
+	m.delay = delay
+}
 
 func (m *mockNSQ) OnTouch(*nsq.Message) {}
 
@@ -71,52 +133,192 @@ func TestTrackingEventConsumer_HandleMessage(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
 	domain := mock_stat.NewMockStatDomain(mockCtrl)
-	type args struct {
-		body string
+	producer := mock_nsq.NewMockNsqMethod(mockCtrl)
+	validBody := `{
+				"event_id": "0b8f5d3c-6a2e-4c1e-9a57-0c3e5a1f9d21",
+				"version": 1,
+				"path": "/v1/farms",
+				"code": 200,
+				"method": "GET",
+				"ua": "Mozilla/5.0"
+			  }`
+	type want struct {
+		finished bool
+		requeued bool
+		delay    time.Duration
 	}
 	tests := []struct {
 		name     string
 		body     string
+		attempts uint16
+		producer nsqclient.NsqMethod
 		mockFunc func()
+		want     want
 		wantErr  bool
 	}{
 		{
-			name: "success flow",
+			name:     "success flow",
+			body:     validBody,
+			attempts: 1,
+			producer: producer,
+			mockFunc: func() {
+				domain.EXPECT().IngestStatAPI(gomock.Any(), stat.IngestStatRequest{
+					Path:   "/v1/farms",
+					Method: "GET",
+					Ua:     "Mozilla/5.0",
+					Code:   200,
+				}).Return(nil)
+			},
+			want:    want{finished: true},
+			wantErr: false,
+		},
+		{
+			name: "success path with id flow",
 			body: `{
-				"path": "/v1/farms",
-				"code": 200,
+				"event_id": "0b8f5d3c-6a2e-4c1e-9a57-0c3e5a1f9d21",
+				"version": 1,
+				"path": "/v1/farms/12",
+				"code": 404,
 				"method": "GET",
 				"ua": "Mozilla/5.0"
 			  }`,
+			attempts: 1,
+			producer: producer,
 			mockFunc: func() {
-				domain.EXPECT().IngestStatAPI(gomock.Any())
+				domain.EXPECT().IngestStatAPI(gomock.Any(), stat.IngestStatRequest{
+					Path:   "/v1/farms",
+					Method: "GET",
+					Ua:     "Mozilla/5.0",
+					Code:   404,
+				}).Return(nil)
 			},
+			want:    want{finished: true},
 			wantErr: false,
 		},
 		{
-			name: "invalid value",
+			name:     "got error ingest and requeue with backoff flow",
+			body:     validBody,
+			attempts: 3,
+			producer: producer,
+			mockFunc: func() {
+				domain.EXPECT().IngestStatAPI(gomock.Any(), gomock.Any()).Return(fmt.Errorf("some error"))
+			},
+			want:    want{requeued: true, delay: 4 * time.Second},
+			wantErr: true,
+		},
+		{
+			name:     "got transient error ingest and requeue flow",
+			body:     validBody,
+			attempts: 1,
+			producer: producer,
+			mockFunc: func() {
+				domain.EXPECT().IngestStatAPI(gomock.Any(), gomock.Any()).Return(fmt.Errorf("%w: some error", stat.ErrTransientIngest))
+			},
+			want:    want{requeued: true, delay: time.Second},
+			wantErr: true,
+		},
+		{
+			name:     "got permanent error ingest and sent to dead letter flow",
+			body:     validBody,
+			attempts: 1,
+			producer: producer,
+			mockFunc: func() {
+				domain.EXPECT().IngestStatAPI(gomock.Any(), gomock.Any()).Return(fmt.Errorf("%w: some error", stat.ErrPermanentIngest))
+				producer.EXPECT().Publish("topic_dlq", gomock.Any()).Return(nil)
+			},
+			want:    want{finished: true},
+			wantErr: true,
+		},
+		{
+			name:     "got error ingest on max attempts and sent to dead letter flow",
+			body:     validBody,
+			attempts: 5,
+			producer: producer,
+			mockFunc: func() {
+				domain.EXPECT().IngestStatAPI(gomock.Any(), gomock.Any()).Return(fmt.Errorf("some error"))
+				producer.EXPECT().Publish("topic_dlq", gomock.Any()).DoAndReturn(func(topic string, data interface{}) error {
+					dl := data.(DeadLetterMessage)
+					if dl.Version != DeadLetterVersion || dl.Topic != "topic" || dl.Channel != "channel" || dl.Body != validBody || dl.Error != "some error" || dl.Attempts != 5 {
+						t.Errorf("unexpected dead letter message = %+v", dl)
+					}
+					return nil
+				})
+			},
+			want:    want{finished: true},
+			wantErr: true,
+		},
+		{
+			name:     "got error publish dead letter and requeue flow",
+			body:     validBody,
+			attempts: 5,
+			producer: producer,
+			mockFunc: func() {
+				domain.EXPECT().IngestStatAPI(gomock.Any(), gomock.Any()).Return(fmt.Errorf("some error"))
+				producer.EXPECT().Publish("topic_dlq", gomock.Any()).Return(fmt.Errorf("some error"))
+			},
+			want:    want{requeued: true, delay: 16 * time.Second},
+			wantErr: true,
+		},
+		{
+			name: "invalid value sent to dead letter flow",
 			body: `{
+				"event_id": "0b8f5d3c-6a2e-4c1e-9a57-0c3e5a1f9d21",
+				"version": 1,
 				"path": "",
 				"code": 200,
 				"method": "GET",
 				"ua": "Mozilla/5.0"
 			  }`,
+			attempts: 1,
+			producer: producer,
 			mockFunc: func() {
+				producer.EXPECT().Publish("topic_dlq", gomock.Any()).Return(nil)
 			},
-			wantErr: false,
+			want:    want{finished: true},
+			wantErr: true,
+		},
+		{
+			name: "unsupported version sent to dead letter flow",
+			body: `{
+				"event_id": "0b8f5d3c-6a2e-4c1e-9a57-0c3e5a1f9d21",
+				"version": 2,
+				"path": "/v1/farms",
+				"code": 200,
+				"method": "GET",
+				"ua": "Mozilla/5.0"
+			  }`,
+			attempts: 1,
+			producer: producer,
+			mockFunc: func() {
+				producer.EXPECT().Publish("topic_dlq", gomock.Any()).Return(nil)
+			},
+			want:    want{finished: true},
+			wantErr: true,
 		},
 		{
-			name: "error unmarshall",
+			name: "error unmarshall sent to dead letter flow",
 			body: `{
 				"path": "",
 				"code": 200,
 				"method": "GET",
 				"ua": "Mozilla/5.0",
 			  }`,
+			attempts: 1,
+			producer: producer,
 			mockFunc: func() {
+				producer.EXPECT().Publish("topic_dlq", gomock.Any()).Return(nil)
 			},
+			want:    want{finished: true},
 			wantErr: true,
 		},
+		{
+			name:     "error unmarshall without dead letter flow",
+			body:     `{`,
+			attempts: 1,
+			mockFunc: func() {},
+			want:     want{finished: true},
+			wantErr:  true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -124,19 +326,277 @@ func TestTrackingEventConsumer_HandleMessage(t *testing.T) {
 			c := TrackingEventConsumer{
 				topic:        "topic",
 				channel:      "channel",
-				host:         "host",
 				maxInFlight:  1,
 				numconsumer:  1,
 				timeoutInSec: 1,
 				stat:         domain,
+				maxAttempts:  5,
+				retryBackoff: time.Second,
+			}
+			if tt.producer != nil {
+				c.dlqTopic = "topic_dlq"
+				c.producer = tt.producer
+			}
+			delegate := &mockNSQ{}
+			msg := &nsq.Message{
+				Body:     []byte(tt.body),
+				Attempts: tt.attempts,
+				Delegate: delegate,
+			}
+			if err := c.HandleMessage(nsqclient.NewMessage(msg)); (err != nil) != tt.wantErr {
+				t.Errorf("TrackingEventConsumer.HandleMessage() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			got := want{finished: delegate.finished, requeued: delegate.requeued, delay: delegate.delay}
+			if got != tt.want {
+				t.Errorf("TrackingEventConsumer.HandleMessage() response = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTrackingEventConsumer_backoff(t *testing.T) {
+	tests := []struct {
+		name     string
+		attempts uint16
+		want     time.Duration
+	}{
+		{
+			name:     "first attempt",
+			attempts: 1,
+			want:     2 * time.Second,
+		},
+		{
+			name:     "third attempt",
+			attempts: 3,
+			want:     8 * time.Second,
+		},
+		{
+			name:     "capped attempt",
+			attempts: 100,
+			want:     maxRetryBackoff,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &TrackingEventConsumer{retryBackoff: 2 * time.Second}
+			if got := c.backoff(tt.attempts); got != tt.want {
+				t.Errorf("TrackingEventConsumer.backoff() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTrackingEventConsumer_replayMessage(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	producer := mock_nsq.NewMockNsqMethod(mockCtrl)
+	tests := []struct {
+		name     string
+		body     string
+		mockFunc func()
+		want     bool
+		wantErr  bool
+	}{
+		{
+			name: "success flow",
+			body: `{"topic":"topic","body":"{\"path\":\"/v1/farms\"}","error":"some error","attempts":5}`,
+			mockFunc: func() {
+				producer.EXPECT().Publish("topic", json.RawMessage(`{"path":"/v1/farms"}`)).Return(nil)
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name: "success without origin topic flow",
+			body: `{"body":"{}"}`,
+			mockFunc: func() {
+				producer.EXPECT().Publish("topic", json.RawMessage(`{}`)).Return(nil)
+			},
+			want:    true,
+			wantErr: false,
+		},
+		{
+			name:     "dead letter of other topic is discarded flow",
+			body:     `{"topic":"other","body":"{}"}`,
+			mockFunc: func() {},
+			want:     false,
+			wantErr:  false,
+		},
+		{
+			name:     "invalid dead letter flow",
+			body:     `{`,
+			mockFunc: func() {},
+			want:     false,
+			wantErr:  false,
+		},
+		{
+			name:     "invalid original body flow",
+			body:     `{"topic":"topic","body":"{"}`,
+			mockFunc: func() {},
+			want:     false,
+			wantErr:  false,
+		},
+		{
+			name: "original body not match schema flow",
+			body: `{"topic":"topic","body":"{}"}`,
+			mockFunc: func() {
+				producer.EXPECT().Publish("topic", gomock.Any()).Return(fmt.Errorf("%w: event_id is required", nsqclient.ErrInvalidMessage))
+			},
+			want:    false,
+			wantErr: false,
+		},
+		{
+			name: "got error publish flow",
+			body: `{"topic":"topic","body":"{}"}`,
+			mockFunc: func() {
+				producer.EXPECT().Publish("topic", gomock.Any()).Return(fmt.Errorf("some error"))
+			},
+			want:    false,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			c := &TrackingEventConsumer{
+				topic:    "topic",
+				dlqTopic: "topic_dlq",
+				producer: producer,
+			}
+			got, err := c.replayMessage([]byte(tt.body))
+			if (err != nil) != tt.wantErr {
+				t.Errorf("TrackingEventConsumer.replayMessage() error = %v, wantErr %v", err, tt.wantErr)
+				return
 			}
+			if got != tt.want {
+				t.Errorf("TrackingEventConsumer.replayMessage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTrackingEventConsumer_ReplayDeadLetter(t *testing.T) {
+	t.Run("dead letter disabled", func(t *testing.T) {
+		c := &TrackingEventConsumer{}
+		if _, err := c.ReplayDeadLetter(context.Background(), 1); err != ErrDeadLetterDisabled {
+			t.Errorf("TrackingEventConsumer.ReplayDeadLetter() error = %v, want %v", err, ErrDeadLetterDisabled)
+		}
+	})
+	t.Run("replay in progress", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+		c := &TrackingEventConsumer{dlqTopic: "topic_dlq", producer: mock_nsq.NewMockNsqMethod(mockCtrl)}
+		c.replayMu.Lock()
+		defer c.replayMu.Unlock()
+		if _, err := c.ReplayDeadLetter(context.Background(), 1); err != ErrReplayInProgress {
+			t.Errorf("TrackingEventConsumer.ReplayDeadLetter() error = %v, want %v", err, ErrReplayInProgress)
+		}
+	})
+}
+
+func TestTrackingEventConsumer_HandleMessage_Schema(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	domain := mock_stat.NewMockStatDomain(mockCtrl)
+	producer := mock_nsq.NewMockNsqMethod(mockCtrl)
+
+	schemas, err := nsqclient.LoadSchemas("../../../schema/nsq")
+	if err != nil {
+		t.Fatalf("LoadSchemas() error = %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		body     string
+		mockFunc func()
+		wantErr  bool
+	}{
+		{
+			name: "success flow",
+			body: `{"event_id":"0b8f5d3c-6a2e-4c1e-9a57-0c3e5a1f9d21","version":1,"path":"/v1/ponds","code":201,"method":"POST","ua":"curl"}`,
+			mockFunc: func() {
+				domain.EXPECT().IngestStatAPI(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "missing event id flow",
+			body: `{"version":1,"path":"/v1/ponds","code":201,"method":"POST","ua":"curl"}`,
+			mockFunc: func() {
+				producer.EXPECT().Publish("aqua_farm_tracking_event_dlq", gomock.Any()).Return(nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid code flow",
+			body: `{"event_id":"0b8f5d3c-6a2e-4c1e-9a57-0c3e5a1f9d21","version":1,"path":"/v1/ponds","code":1000,"method":"POST","ua":"curl"}`,
+			mockFunc: func() {
+				producer.EXPECT().Publish("aqua_farm_tracking_event_dlq", gomock.Any()).Return(nil)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			c := NewTrackingEventConsumer("aqua_farm_tracking_event", "channel", nil, 1, 1, 1, domain,
+				WithDeadLetterOptions("aqua_farm_tracking_event_dlq", producer),
+				WithSchemaOptions(schemas))
 			msg := &nsq.Message{
 				Body:     []byte(tt.body),
+				Attempts: 1,
 				Delegate: &mockNSQ{},
 			}
-			if err := c.HandleMessage(msg); (err != nil) != tt.wantErr {
+			if err := c.HandleMessage(nsqclient.NewMessage(msg)); (err != nil) != tt.wantErr {
 				t.Errorf("TrackingEventConsumer.HandleMessage() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
 	}
 }
+
+func TestMessage_Schema(t *testing.T) {
+	schemas, err := nsqclient.LoadSchemas("../../../schema/nsq")
+	if err != nil {
+		t.Fatalf("LoadSchemas() error = %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		topic string
+		msg   interface{}
+	}{
+		{
+			name:  "tracking event",
+			topic: "aqua_farm_tracking_event",
+			msg: TrackingEventMessage{
+				EventID: "0b8f5d3c-6a2e-4c1e-9a57-0c3e5a1f9d21",
+				Version: TrackingEventVersion,
+				Path:    "/v1/farms/1",
+				Code:    200,
+				Method:  "GET",
+				UA:      "Mozilla/5.0",
+			},
+		},
+		{
+			name:  "dead letter",
+			topic: "aqua_farm_tracking_event_dlq",
+			msg: DeadLetterMessage{
+				Version:  DeadLetterVersion,
+				Topic:    "aqua_farm_tracking_event",
+				Channel:  "tracking_event",
+				Body:     "{",
+				Error:    "some error",
+				Attempts: 5,
+				FailedAt: 1700000000,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, _ := json.Marshal(tt.msg)
+			if err := schemas.Validate(tt.topic, body); err != nil {
+				t.Errorf("Schemas.Validate() error = %v", err)
+			}
+		})
+	}
+}
@@ -1,9 +1,29 @@
 package trackingevent
 
+// list supported message version, it must be the same with version in schema/nsq
+const (
+	TrackingEventVersion = 1
+	DeadLetterVersion    = 1
+)
+
 //TrackingEventMessage represents data object of nsq message for aqua_farm_tracking_event
 type TrackingEventMessage struct {
-	Path   string `json:"path"`
-	Code   int    `json:"code"`
-	Method string `json:"method"`
-	UA     string `json:"ua"`
+	EventID string `json:"event_id"`
+	Version int    `json:"version"`
+	Path    string `json:"path"`
+	Code    int    `json:"code"`
+	Method  string `json:"method"`
+	UA      string `json:"ua"`
+}
+
+// DeadLetterMessage represents data object of nsq message for aqua_farm_tracking_event_dlq,
+// Body is kept as string so the original message is recorded even when it is not valid json
+type DeadLetterMessage struct {
+	Version  int    `json:"version"`
+	Topic    string `json:"topic"`
+	Channel  string `json:"channel"`
+	Body     string `json:"body"`
+	Error    string `json:"error"`
+	Attempts uint16 `json:"attempts"`
+	FailedAt int64  `json:"failed_at"`
 }
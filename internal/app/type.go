@@ -5,30 +5,61 @@ type UrlID int
 
 // list defined UrlID
 const (
-	Farms UrlID = 1
-	Ponds UrlID = 2
-	Limit UrlID = 3 // this will be flag to stop
-	Stat  UrlID = 4 // include stat api for getting metrics
+	Farms    UrlID = 1
+	Ponds    UrlID = 2
+	Limit    UrlID = 3  // not used anymore, it is kept so the stored url id of the next path is not changed
+	Stat     UrlID = 4  // include stat api for getting metrics
+	Metrics  UrlID = 5  // include prometheus metrics api
+	Replay   UrlID = 6  // include admin api to replay dead letter message
+	Webhooks UrlID = 7  // include api to register outbound webhook
+	OpenAPI  UrlID = 8  // include openapi document of the api
+	GraphQL  UrlID = 9  // include graphql api of farm, pond and stat
+	Import   UrlID = 10 // include api to import farms and ponds from file
+	Export   UrlID = 11 // include api to export farms and ponds into file
 )
 
 // this list define all known of path setting
 var (
 	UrlIDName = map[UrlID]string{
-		Farms: "/v1/farms",
-		Ponds: "/v1/ponds",
-		Stat:  "/v1/stat",
+		Farms:    "/v1/farms",
+		Ponds:    "/v1/ponds",
+		Stat:     "/v1/stat",
+		Metrics:  "/metrics",
+		Replay:   "/v1/admin/dlq/replay",
+		Webhooks: "/v1/webhooks",
+		OpenAPI:  "/openapi.json",
+		GraphQL:  "/graphql",
+		Import:   "/v1/import",
+		Export:   "/v1/export",
 	}
 
 	UrlIDValue = map[string]UrlID{
-		UrlIDName[Farms]: Farms,
-		UrlIDName[Ponds]: Ponds,
-		UrlIDName[Stat]:  Stat,
+		UrlIDName[Farms]:    Farms,
+		UrlIDName[Ponds]:    Ponds,
+		UrlIDName[Stat]:     Stat,
+		UrlIDName[Metrics]:  Metrics,
+		UrlIDName[Replay]:   Replay,
+		UrlIDName[Webhooks]: Webhooks,
+		UrlIDName[OpenAPI]:  OpenAPI,
+		UrlIDName[GraphQL]:  GraphQL,
+		UrlIDName[Import]:   Import,
+		UrlIDName[Export]:   Export,
 	}
 
+	// TrackedUrlID is list url that is counted in stat, the stat is ingested, flushed and read only for these url
+	TrackedUrlID = []UrlID{Farms, Ponds, Webhooks, GraphQL, Import, Export}
+
 	UrlIDMethod = map[UrlID][]string{
-		Farms: {"POST", "GET", "PUT", "DELETE"},
-		Ponds: {"POST", "GET", "PUT", "DELETE"},
-		Stat:  {"GET"},
+		Farms:    {"POST", "GET", "PUT", "DELETE"},
+		Ponds:    {"POST", "GET", "PUT", "DELETE"},
+		Stat:     {"GET"},
+		Metrics:  {"GET"},
+		Replay:   {"POST"},
+		Webhooks: {"POST", "GET", "DELETE"},
+		OpenAPI:  {"GET"},
+		GraphQL:  {"POST"},
+		Import:   {"POST"},
+		Export:   {"GET"},
 	}
 )
 
@@ -38,5 +69,15 @@ func (urlID UrlID) Int() int { return int(urlID) }
 // string return string representation of urlID
 func (urlID UrlID) String() string { return UrlIDName[urlID] }
 
+// IsTracked return true when the stat of urlID is counted
+func (urlID UrlID) IsTracked() bool {
+	for _, id := range TrackedUrlID {
+		if id == urlID {
+			return true
+		}
+	}
+	return false
+}
+
 // GetListMethod return list method representation of urlID
 func (urlID UrlID) GetListMethod() []string { return UrlIDMethod[urlID] }
@@ -26,6 +26,41 @@ func TestUrlID_Int(t *testing.T) {
 			urlID: Stat,
 			want:  4,
 		},
+		{
+			name:  "get /metrics",
+			urlID: Metrics,
+			want:  5,
+		},
+		{
+			name:  "get /admin/dlq/replay",
+			urlID: Replay,
+			want:  6,
+		},
+		{
+			name:  "get /webhooks",
+			urlID: Webhooks,
+			want:  7,
+		},
+		{
+			name:  "get /openapi.json",
+			urlID: OpenAPI,
+			want:  8,
+		},
+		{
+			name:  "post /graphql",
+			urlID: GraphQL,
+			want:  9,
+		},
+		{
+			name:  "post /v1/import",
+			urlID: Import,
+			want:  10,
+		},
+		{
+			name:  "get /v1/export",
+			urlID: Export,
+			want:  11,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -57,6 +92,41 @@ func TestUrlID_String(t *testing.T) {
 			urlID: Stat,
 			want:  UrlIDName[Stat],
 		},
+		{
+			name:  "get /metrics",
+			urlID: Metrics,
+			want:  UrlIDName[Metrics],
+		},
+		{
+			name:  "get /admin/dlq/replay",
+			urlID: Replay,
+			want:  UrlIDName[Replay],
+		},
+		{
+			name:  "get /webhooks",
+			urlID: Webhooks,
+			want:  UrlIDName[Webhooks],
+		},
+		{
+			name:  "get /openapi.json",
+			urlID: OpenAPI,
+			want:  UrlIDName[OpenAPI],
+		},
+		{
+			name:  "post /graphql",
+			urlID: GraphQL,
+			want:  UrlIDName[GraphQL],
+		},
+		{
+			name:  "post /v1/import",
+			urlID: Import,
+			want:  UrlIDName[Import],
+		},
+		{
+			name:  "get /v1/export",
+			urlID: Export,
+			want:  UrlIDName[Export],
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -88,6 +158,41 @@ func TestUrlID_GetListMethod(t *testing.T) {
 			urlID: Stat,
 			want:  UrlIDMethod[Stat],
 		},
+		{
+			name:  "get /metrics",
+			urlID: Metrics,
+			want:  UrlIDMethod[Metrics],
+		},
+		{
+			name:  "get /admin/dlq/replay",
+			urlID: Replay,
+			want:  UrlIDMethod[Replay],
+		},
+		{
+			name:  "get /webhooks",
+			urlID: Webhooks,
+			want:  UrlIDMethod[Webhooks],
+		},
+		{
+			name:  "get /openapi.json",
+			urlID: OpenAPI,
+			want:  UrlIDMethod[OpenAPI],
+		},
+		{
+			name:  "post /graphql",
+			urlID: GraphQL,
+			want:  UrlIDMethod[GraphQL],
+		},
+		{
+			name:  "post /v1/import",
+			urlID: Import,
+			want:  UrlIDMethod[Import],
+		},
+		{
+			name:  "get /v1/export",
+			urlID: Export,
+			want:  UrlIDMethod[Export],
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -97,3 +202,44 @@ func TestUrlID_GetListMethod(t *testing.T) {
 		})
 	}
 }
+
+func TestUrlID_IsTracked(t *testing.T) {
+	tests := []struct {
+		name  string
+		urlID UrlID
+		want  bool
+	}{
+		{
+			name:  "farms is tracked",
+			urlID: Farms,
+			want:  true,
+		},
+		{
+			name:  "webhooks is tracked",
+			urlID: Webhooks,
+			want:  true,
+		},
+		{
+			name:  "export is tracked",
+			urlID: Export,
+			want:  true,
+		},
+		{
+			name:  "stat is not tracked",
+			urlID: Stat,
+			want:  false,
+		},
+		{
+			name:  "unknown is not tracked",
+			urlID: UrlID(0),
+			want:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.urlID.IsTracked(); got != tt.want {
+				t.Errorf("UrlID.IsTracked() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
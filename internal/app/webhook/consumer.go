@@ -0,0 +1,149 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"aqua-farm-manager/internal/domain/event"
+	"aqua-farm-manager/internal/domain/webhook"
+	"aqua-farm-manager/pkg/bus"
+)
+
+const (
+	defaultDeliveryChannel = "webhook"
+	// touchInterval is interval to reset message timeout while delivery is retried
+	touchInterval = 5 * time.Second
+)
+
+// eventMessage is field of domain event needed to deliver the event
+type eventMessage struct {
+	EventID string `json:"event_id"`
+	Type    string `json:"type"`
+}
+
+// DeliveryConsumer is list dependencies of consumer that deliver domain event to webhook subscriber
+type DeliveryConsumer struct {
+	topics        []string
+	channel       string
+	subscriber    bus.Subscriber
+	maxInFlight   int
+	domain        webhook.WebhookDomain
+	deliverer     *Deliverer
+	subscriptions []bus.Subscription
+}
+
+// NewDeliveryConsumer is func to create DeliveryConsumer for farm and pond event
+func NewDeliveryConsumer(channel string, subscriber bus.Subscriber, maxInFlight int, domain webhook.WebhookDomain, deliverer *Deliverer) *DeliveryConsumer {
+	if channel == "" {
+		channel = defaultDeliveryChannel
+	}
+	if maxInFlight <= 0 {
+		maxInFlight = 1
+	}
+	return &DeliveryConsumer{
+		topics:      []string{event.FarmTopic, event.PondTopic},
+		channel:     channel,
+		subscriber:  subscriber,
+		maxInFlight: maxInFlight,
+		domain:      domain,
+		deliverer:   deliverer,
+	}
+}
+
+// Start is func to start consumer for every event topic
+func (c *DeliveryConsumer) Start() error {
+	for _, topic := range c.topics {
+		subscription, err := c.subscriber.Subscribe(topic, c.channel, c, bus.SubscribeConfig{
+			MaxInFlight: c.maxInFlight,
+			Concurrency: c.maxInFlight,
+		})
+		if err != nil {
+			return err
+		}
+		c.subscriptions = append(c.subscriptions, subscription)
+	}
+	return nil
+}
+
+// Close is func to stop consuming and wait in flight delivery until ctx is done
+func (c *DeliveryConsumer) Close(ctx context.Context) error {
+	for _, subscription := range c.subscriptions {
+		subscription.Stop()
+	}
+	for _, subscription := range c.subscriptions {
+		select {
+		case <-subscription.Done():
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// HandleMessage is func to deliver farm and pond event to every subscriber,
+// the message is only requeued when subscriber can not be loaded because each
+// subscriber already retry its own delivery
+func (c *DeliveryConsumer) HandleMessage(msg bus.Message) error {
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		ticker := time.NewTicker(touchInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				msg.Touch()
+			}
+		}
+	}()
+
+	err := c.processMessage(context.Background(), msg.Body())
+	if err != nil {
+		fmt.Println("DeliveryConsumer-Got Error :", err)
+		msg.Requeue(-1)
+		return err
+	}
+
+	msg.Finish()
+	return nil
+}
+
+// processMessage is func to deliver the event concurrently to every subscriber of event type
+func (c *DeliveryConsumer) processMessage(ctx context.Context, data []byte) error {
+	var body eventMessage
+	err := json.Unmarshal(data, &body)
+	if err != nil || body.EventID == "" || body.Type == "" {
+		// the message can never be delivered, drop it
+		fmt.Println("DeliveryConsumer-Invalid Message :", string(data))
+		return nil
+	}
+
+	subscribers, err := c.domain.GetSubscribers(ctx, body.Type)
+	if err != nil {
+		return err
+	}
+
+	var wg sync.WaitGroup
+	for _, subscriber := range subscribers {
+		wg.Add(1)
+		go func(subscriber webhook.Subscriber) {
+			defer wg.Done()
+			err := c.deliverer.Deliver(ctx, Delivery{
+				Subscriber: subscriber,
+				EventID:    body.EventID,
+				EventType:  body.Type,
+				Payload:    data,
+			})
+			if err != nil {
+				fmt.Println("DeliveryConsumer-Failed Deliver Event", body.EventID, "To Webhook", subscriber.ID, ":", err)
+			}
+		}(subscriber)
+	}
+	wg.Wait()
+	return nil
+}
@@ -0,0 +1,92 @@
+package webhook
+
+import (
+	"aqua-farm-manager/internal/domain/webhook"
+	"aqua-farm-manager/internal/domain/webhook/mock_webhook"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestDeliveryConsumer_processMessage(t *testing.T) {
+	payload := []byte(`{"event_id":"e1","version":1,"type":"pond.moved","aggregate_id":1,"data":{}}`)
+
+	var received int32
+	receiver := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		if string(body) != string(payload) {
+			t.Errorf("receiver body got = %s, want %s", body, payload)
+		}
+		if r.Header.Get(HeaderSignature) != Sign("0123456789abcdef", r.Header.Get(HeaderTimestamp), body) {
+			t.Errorf("receiver got invalid signature")
+		}
+		atomic.AddInt32(&received, 1)
+	}))
+	defer receiver.Close()
+
+	tests := []struct {
+		name         string
+		data         []byte
+		mockFunc     func(domain *mock_webhook.MockWebhookDomain)
+		wantErr      bool
+		wantReceived int32
+	}{
+		{
+			name: "deliver to every subscriber",
+			data: payload,
+			mockFunc: func(domain *mock_webhook.MockWebhookDomain) {
+				domain.EXPECT().GetSubscribers(gomock.Any(), "pond.moved").Return([]webhook.Subscriber{
+					{ID: 1, URL: receiver.URL, Secret: "0123456789abcdef"},
+					{ID: 2, URL: receiver.URL, Secret: "0123456789abcdef"},
+				}, nil)
+				domain.EXPECT().RecordDelivery(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+			},
+			wantReceived: 2,
+		},
+		{
+			name: "no subscriber",
+			data: payload,
+			mockFunc: func(domain *mock_webhook.MockWebhookDomain) {
+				domain.EXPECT().GetSubscribers(gomock.Any(), "pond.moved").Return(nil, nil)
+			},
+		},
+		{
+			name: "got error get subscriber",
+			data: payload,
+			mockFunc: func(domain *mock_webhook.MockWebhookDomain) {
+				domain.EXPECT().GetSubscribers(gomock.Any(), "pond.moved").Return(nil, fmt.Errorf("some error"))
+			},
+			wantErr: true,
+		},
+		{
+			name:     "invalid message is dropped",
+			data:     []byte(`{"event_id":`),
+			mockFunc: func(domain *mock_webhook.MockWebhookDomain) {},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			atomic.StoreInt32(&received, 0)
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			domain := mock_webhook.NewMockWebhookDomain(mockCtrl)
+			tt.mockFunc(domain)
+
+			deliverer := NewDeliverer(domain, WithHTTPClientOptions(receiver.Client()), WithDeliveryRetryOptions(1, 1))
+			c := NewDeliveryConsumer("", nil, 0, domain, deliverer)
+			err := c.processMessage(context.Background(), tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("DeliveryConsumer.processMessage() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got := atomic.LoadInt32(&received); got != tt.wantReceived {
+				t.Errorf("DeliveryConsumer.processMessage() delivered %d, want %d", got, tt.wantReceived)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"log"
+	"net/http"
+	"time"
+
+	"aqua-farm-manager/internal/domain/webhook"
+	"aqua-farm-manager/pkg/apperror"
+	utilhttp "aqua-farm-manager/pkg/utilhttp"
+)
+
+// CreateWebhookRequest is list request parameter for Create Api
+type CreateWebhookRequest struct {
+	URL        string   `json:"url"`
+	EventTypes []string `json:"event_types"`
+	Secret     string   `json:"secret"`
+}
+
+// CreateWebhookResponse is list response parameter for Create Api
+type CreateWebhookResponse struct {
+	ID uint `json:"id"`
+}
+
+// CreateWebhookHandler is func handler for register webhook
+func (h *WebhookHandler) CreateWebhookHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.timeoutInSec)*time.Second)
+	defer cancel()
+
+	var err error
+	var response utilhttp.StandardResponse
+	var code int = http.StatusOK
+
+	defer func() {
+		if err != nil {
+			code = apperror.HTTPStatus(err)
+			if code == http.StatusInternalServerError {
+				log.Println("[CreateWebhookHandler]-Internal Error :", err)
+			}
+			response.SetError(err)
+		} else {
+			response.Message = "success"
+		}
+		response.Code = code
+
+		data, errMarshal := json.Marshal(response)
+		if errMarshal != nil {
+			log.Println("[CreateWebhookHandler]-Error Marshal Response :", err)
+			code = http.StatusInternalServerError
+			data = []byte(`{"code":500,"message":"Internal Server Error"}`)
+		}
+		utilhttp.WriteResponse(w, data, code)
+	}()
+
+	var body CreateWebhookRequest
+	data, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		err = apperror.New(apperror.CodeBadRequest, apperror.MessageBadRequest)
+		return
+	}
+
+	err = json.Unmarshal(data, &body)
+	if err != nil {
+		err = apperror.New(apperror.CodeBadRequest, apperror.MessageBadRequest)
+		return
+	}
+
+	var res webhook.CreateDomainResponse
+	res, err = h.domain.RegisterWebhook(ctx, webhook.CreateDomainRequest{
+		URL:        body.URL,
+		EventTypes: body.EventTypes,
+		Secret:     body.Secret,
+	})
+	if err != nil && ctx.Err() != nil {
+		err = apperror.Wrap(apperror.CodeTimeout, apperror.MessageTimeout, err)
+		return
+	}
+	if err != nil {
+		return
+	}
+
+	response = mapResponseCreate(res)
+}
+
+func mapResponseCreate(r webhook.CreateDomainResponse) utilhttp.StandardResponse {
+	var res utilhttp.StandardResponse
+	data := CreateWebhookResponse{
+		ID: r.ID,
+	}
+	res.Data = data
+	return res
+}
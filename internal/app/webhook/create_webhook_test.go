@@ -0,0 +1,135 @@
+package webhook
+
+import (
+	"aqua-farm-manager/internal/domain/webhook"
+	"aqua-farm-manager/internal/domain/webhook/mock_webhook"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestWebhookHandler_CreateWebhookHandler(t *testing.T) {
+	type args struct {
+		timeout int
+	}
+	type want struct {
+		body string
+		code int
+	}
+	tests := []struct {
+		name     string
+		body     string
+		args     args
+		mockFunc func(domain *mock_webhook.MockWebhookDomain)
+		want     want
+	}{
+		{
+			name: "success flow",
+			body: `{"url":"https://partner.com/hook","event_types":["farm.created"],"secret":"0123456789abcdef"}`,
+			args: args{
+				timeout: 10,
+			},
+			mockFunc: func(domain *mock_webhook.MockWebhookDomain) {
+				domain.EXPECT().RegisterWebhook(gomock.Any(), webhook.CreateDomainRequest{
+					URL:        "https://partner.com/hook",
+					EventTypes: []string{"farm.created"},
+					Secret:     "0123456789abcdef",
+				}).Return(webhook.CreateDomainResponse{ID: 1}, nil)
+			},
+			want: want{
+				body: `{"data":{"id":1},"code":200,"message":"success"}`,
+				code: 200,
+			},
+		},
+		{
+			name: "timeout flow",
+			body: `{"url":"https://partner.com/hook","event_types":["farm.created"],"secret":"0123456789abcdef"}`,
+			args: args{
+				timeout: 0,
+			},
+			mockFunc: func(domain *mock_webhook.MockWebhookDomain) {
+				domain.EXPECT().RegisterWebhook(gomock.Any(), gomock.Any()).Return(webhook.CreateDomainResponse{}, context.DeadlineExceeded).AnyTimes()
+			},
+			want: want{
+				body: `{"code":504,"message":"Timeout","errors":[{"code":"TIMEOUT","message":"Timeout"}]}`,
+				code: 504,
+			},
+		},
+		{
+			name: "invalid url flow",
+			body: `{"url":"http://partner.com/hook","event_types":["farm.created"],"secret":"0123456789abcdef"}`,
+			args: args{
+				timeout: 10,
+			},
+			mockFunc: func(domain *mock_webhook.MockWebhookDomain) {
+				domain.EXPECT().RegisterWebhook(gomock.Any(), gomock.Any()).Return(webhook.CreateDomainResponse{}, webhook.ErrInvalidURL)
+			},
+			want: want{
+				body: `{"code":422,"message":"Webhook URL Must Be Valid HTTPS URL","errors":[{"code":"VALIDATION_FAILED","field":"url","message":"Webhook URL Must Be Valid HTTPS URL"}]}`,
+				code: 422,
+			},
+		},
+		{
+			name: "internal server error flow",
+			body: `{"url":"https://partner.com/hook","event_types":["farm.created"],"secret":"0123456789abcdef"}`,
+			args: args{
+				timeout: 10,
+			},
+			mockFunc: func(domain *mock_webhook.MockWebhookDomain) {
+				domain.EXPECT().RegisterWebhook(gomock.Any(), gomock.Any()).Return(webhook.CreateDomainResponse{}, fmt.Errorf("some error"))
+			},
+			want: want{
+				body: `{"code":500,"message":"Internal Server Error","errors":[{"code":"INTERNAL_ERROR","message":"Internal Server Error"}]}`,
+				code: 500,
+			},
+		},
+		{
+			name: "invalid body flow",
+			body: `{"url":`,
+			args: args{
+				timeout: 10,
+			},
+			mockFunc: func(domain *mock_webhook.MockWebhookDomain) {},
+			want: want{
+				body: `{"code":400,"message":"Bad Request","errors":[{"code":"BAD_REQUEST","message":"Bad Request"}]}`,
+				code: 400,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			domain := mock_webhook.NewMockWebhookDomain(mockCtrl)
+			tt.mockFunc(domain)
+
+			handler := WebhookHandler{
+				domain:       domain,
+				timeoutInSec: tt.args.timeout,
+			}
+
+			r := httptest.NewRequest(http.MethodPost, "/v1/webhooks", strings.NewReader(tt.body))
+			w := httptest.NewRecorder()
+			handler.CreateWebhookHandler(w, r)
+			result := w.Result()
+			resBody, err := ioutil.ReadAll(result.Body)
+			if err != nil {
+				t.Fatalf("Error read body err = %v\n", err)
+			}
+
+			if result.StatusCode != tt.want.code {
+				t.Fatalf("CreateWebhookHandler status code got =%d, want %d \n", result.StatusCode, tt.want.code)
+			}
+
+			if string(resBody) != tt.want.body {
+				t.Fatalf("CreateWebhookHandler body got =%s, want %s \n", string(resBody), tt.want.body)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"aqua-farm-manager/pkg/apperror"
+	utilhttp "aqua-farm-manager/pkg/utilhttp"
+
+	"github.com/gorilla/mux"
+)
+
+// DeleteWebhookResponse is list response parameter for Delete Api
+type DeleteWebhookResponse struct {
+	ID uint `json:"id"`
+}
+
+// DeleteWebhookHandler is func handler for delete webhook by id
+func (h *WebhookHandler) DeleteWebhookHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.timeoutInSec)*time.Second)
+	defer cancel()
+
+	var err error
+	var response utilhttp.StandardResponse
+	var code int = http.StatusOK
+
+	defer func() {
+		if err != nil {
+			code = apperror.HTTPStatus(err)
+			if code == http.StatusInternalServerError {
+				log.Println("[DeleteWebhookHandler]-Internal Error :", err)
+			}
+			response.SetError(err)
+		} else {
+			response.Message = "success"
+		}
+		response.Code = code
+
+		data, errMarshal := json.Marshal(response)
+		if errMarshal != nil {
+			log.Println("[DeleteWebhookHandler]-Error Marshal Response :", err)
+			code = http.StatusInternalServerError
+			data = []byte(`{"code":500,"message":"Internal Server Error"}`)
+		}
+		utilhttp.WriteResponse(w, data, code)
+	}()
+
+	// checking valid param
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil || id <= 0 {
+		err = apperror.Validation(apperror.Field("id", "id must be a positive number"))
+		return
+	}
+
+	err = h.domain.DeleteWebhook(ctx, uint(id))
+	if err != nil && ctx.Err() != nil {
+		err = apperror.Wrap(apperror.CodeTimeout, apperror.MessageTimeout, err)
+		return
+	}
+	if err != nil {
+		return
+	}
+
+	response.Data = DeleteWebhookResponse{
+		ID: uint(id),
+	}
+}
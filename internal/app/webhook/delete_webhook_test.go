@@ -0,0 +1,103 @@
+package webhook
+
+import (
+	"aqua-farm-manager/internal/domain/webhook"
+	"aqua-farm-manager/internal/domain/webhook/mock_webhook"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+)
+
+func TestWebhookHandler_DeleteWebhookHandler(t *testing.T) {
+	type want struct {
+		body string
+		code int
+	}
+	tests := []struct {
+		name     string
+		id       string
+		mockFunc func(domain *mock_webhook.MockWebhookDomain)
+		want     want
+	}{
+		{
+			name: "success flow",
+			id:   "1",
+			mockFunc: func(domain *mock_webhook.MockWebhookDomain) {
+				domain.EXPECT().DeleteWebhook(gomock.Any(), uint(1)).Return(nil)
+			},
+			want: want{
+				body: `{"data":{"id":1},"code":200,"message":"success"}`,
+				code: 200,
+			},
+		},
+		{
+			name: "webhook not found flow",
+			id:   "1",
+			mockFunc: func(domain *mock_webhook.MockWebhookDomain) {
+				domain.EXPECT().DeleteWebhook(gomock.Any(), uint(1)).Return(webhook.ErrInvalidWebhook)
+			},
+			want: want{
+				body: `{"code":404,"message":"Webhook Is Not Exists","errors":[{"code":"WEBHOOK_NOT_FOUND","message":"Webhook Is Not Exists"}]}`,
+				code: 404,
+			},
+		},
+		{
+			name: "internal server error flow",
+			id:   "1",
+			mockFunc: func(domain *mock_webhook.MockWebhookDomain) {
+				domain.EXPECT().DeleteWebhook(gomock.Any(), uint(1)).Return(fmt.Errorf("some error"))
+			},
+			want: want{
+				body: `{"code":500,"message":"Internal Server Error","errors":[{"code":"INTERNAL_ERROR","message":"Internal Server Error"}]}`,
+				code: 500,
+			},
+		},
+		{
+			name:     "invalid id flow",
+			id:       "a",
+			mockFunc: func(domain *mock_webhook.MockWebhookDomain) {},
+			want: want{
+				body: `{"code":422,"message":"Invalid Parameter Request","errors":[{"code":"VALIDATION_FAILED","field":"id","message":"id must be a positive number"}]}`,
+				code: 422,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			domain := mock_webhook.NewMockWebhookDomain(mockCtrl)
+			tt.mockFunc(domain)
+
+			handler := WebhookHandler{
+				domain:       domain,
+				timeoutInSec: 10,
+			}
+
+			r := httptest.NewRequest(http.MethodDelete, "/v1/webhooks/{id}", nil)
+			r = mux.SetURLVars(r, map[string]string{
+				"id": tt.id,
+			})
+			w := httptest.NewRecorder()
+			handler.DeleteWebhookHandler(w, r)
+			result := w.Result()
+			resBody, err := ioutil.ReadAll(result.Body)
+			if err != nil {
+				t.Fatalf("Error read body err = %v\n", err)
+			}
+
+			if result.StatusCode != tt.want.code {
+				t.Fatalf("DeleteWebhookHandler status code got =%d, want %d \n", result.StatusCode, tt.want.code)
+			}
+
+			if string(resBody) != tt.want.body {
+				t.Fatalf("DeleteWebhookHandler body got =%s, want %s \n", string(resBody), tt.want.body)
+			}
+		})
+	}
+}
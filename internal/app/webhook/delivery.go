@@ -0,0 +1,264 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strconv"
+	"syscall"
+	"time"
+
+	"aqua-farm-manager/internal/domain/webhook"
+	"aqua-farm-manager/pkg/prometheus"
+)
+
+// deliveredTotal count delivery attempt of webhook by result
+var deliveredTotal = prometheus.Default.NewCounterVec(
+	"aqua_farm_webhook_deliveries_total",
+	"Total number of webhook delivery attempt by result.",
+	"result",
+)
+
+// list header sent on every webhook delivery
+const (
+	HeaderWebhookID = "X-Webhook-ID"
+	HeaderEventID   = "X-Event-ID"
+	HeaderEventType = "X-Event-Type"
+	HeaderAttempt   = "X-Delivery-Attempt"
+	HeaderTimestamp = "X-Webhook-Timestamp"
+	HeaderSignature = "X-Signature-256"
+)
+
+// signaturePrefix is prefix of signature header value
+const signaturePrefix = "sha256="
+
+const (
+	defaultDeliveryAttempts  = 5
+	defaultDeliveryBackoffMs = 1000
+	defaultRequestTimeoutSec = 10
+	maxDeliveryBackoff       = time.Minute
+	dialTimeout              = 30 * time.Second
+)
+
+// errPrivateAddress is error when the webhook host resolve into non public ip
+var errPrivateAddress = errors.New("webhook host resolve into loopback, private or link local address")
+
+// Delivery is event that is sent to a webhook subscriber
+type Delivery struct {
+	Subscriber webhook.Subscriber
+	EventID    string
+	EventType  string
+	Payload    []byte
+}
+
+// Deliverer is list dependencies to deliver event to webhook subscriber
+type Deliverer struct {
+	domain      webhook.WebhookDomain
+	client      *http.Client
+	maxAttempts int
+	backoff     time.Duration
+}
+
+// DelivererOption set options for deliverer
+type DelivererOption func(*Deliverer)
+
+// NewDeliverer is func to create Deliverer
+func NewDeliverer(domain webhook.WebhookDomain, options ...DelivererOption) *Deliverer {
+	deliverer := &Deliverer{
+		domain:      domain,
+		client:      newPublicClient(),
+		maxAttempts: defaultDeliveryAttempts,
+		backoff:     defaultDeliveryBackoffMs * time.Millisecond,
+	}
+
+	// Apply options
+	for _, opt := range options {
+		opt(deliverer)
+	}
+
+	return deliverer
+}
+
+// newPublicClient is func to create http client that refuse to dial non public ip, the check is done
+// on the resolved address so the host name that resolve into internal network is also rejected
+func newPublicClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: dialTimeout,
+		Control: publicAddressControl,
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Timeout:   defaultRequestTimeoutSec * time.Second,
+		Transport: transport,
+	}
+}
+
+// publicAddressControl is func to reject the connection before it is dialed when the address is not public ip
+func publicAddressControl(network, address string, c syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || !webhook.PublicIP(ip) {
+		return errPrivateAddress
+	}
+	return nil
+}
+
+// WithHTTPClientOptions is func to set http client used to send the webhook request
+func WithHTTPClientOptions(client *http.Client) DelivererOption {
+	return DelivererOption(
+		func(d *Deliverer) {
+			if client != nil {
+				d.client = client
+			}
+		})
+}
+
+// WithRequestTimeoutOptions is func to set timeout of a single webhook request
+func WithRequestTimeoutOptions(timeoutInSec int) DelivererOption {
+	return DelivererOption(
+		func(d *Deliverer) {
+			if timeoutInSec <= 0 {
+				timeoutInSec = defaultRequestTimeoutSec
+			}
+			d.client.Timeout = time.Duration(timeoutInSec) * time.Second
+		})
+}
+
+// WithDeliveryRetryOptions is func to set max attempts and base backoff of failed delivery,
+// the backoff is doubled on every attempt
+func WithDeliveryRetryOptions(maxAttempts, backoffInMs int) DelivererOption {
+	return DelivererOption(
+		func(d *Deliverer) {
+			if maxAttempts <= 0 {
+				maxAttempts = defaultDeliveryAttempts
+			}
+			if backoffInMs <= 0 {
+				backoffInMs = defaultDeliveryBackoffMs
+			}
+			d.maxAttempts = maxAttempts
+			d.backoff = time.Duration(backoffInMs) * time.Millisecond
+		})
+}
+
+// Sign is func to generate signature of the payload, the receiver should compute
+// hex(HMAC-SHA256(secret, timestamp + "." + body)) and compare it with X-Signature-256 header
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Deliver is func to POST the event to subscriber, failed attempt is retried with exponential backoff
+// until max attempts, every attempt is recorded. Client error other than 408 and 429 is not retried
+func (d *Deliverer) Deliver(ctx context.Context, r Delivery) error {
+	var err error
+	for attempt := 1; attempt <= d.maxAttempts; attempt++ {
+		var retryable bool
+		retryable, err = d.attempt(ctx, r, attempt)
+		if err == nil {
+			return nil
+		}
+		if !retryable || attempt == d.maxAttempts {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(d.backoffDuration(attempt)):
+		}
+	}
+	return err
+}
+
+// attempt is func to send one webhook request and record the result
+func (d *Deliverer) attempt(ctx context.Context, r Delivery, attempt int) (bool, error) {
+	start := time.Now()
+	statusCode, retryable, err := d.send(ctx, r, attempt)
+	record := webhook.RecordDeliveryRequest{
+		WebhookID:  r.Subscriber.ID,
+		EventID:    r.EventID,
+		EventType:  r.EventType,
+		Attempt:    attempt,
+		StatusCode: statusCode,
+		Success:    err == nil,
+		Duration:   time.Since(start),
+	}
+	if err != nil {
+		record.Error = err.Error()
+		deliveredTotal.Inc("failed")
+	} else {
+		deliveredTotal.Inc("success")
+	}
+
+	errRecord := d.domain.RecordDelivery(ctx, record)
+	if errRecord != nil {
+		fmt.Println("[Deliverer]-Got Error Record Delivery :", errRecord)
+	}
+	return retryable, err
+}
+
+// send is func to POST signed payload to subscriber url
+func (d *Deliverer) send(ctx context.Context, r Delivery, attempt int) (int, bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, r.Subscriber.URL, bytes.NewReader(r.Payload))
+	if err != nil {
+		return 0, false, err
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(HeaderWebhookID, strconv.FormatUint(uint64(r.Subscriber.ID), 10))
+	req.Header.Set(HeaderEventID, r.EventID)
+	req.Header.Set(HeaderEventType, r.EventType)
+	req.Header.Set(HeaderAttempt, strconv.Itoa(attempt))
+	req.Header.Set(HeaderTimestamp, timestamp)
+	req.Header.Set(HeaderSignature, Sign(r.Subscriber.Secret, timestamp, r.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, true, err
+	}
+	defer resp.Body.Close()
+	// drain the body so the connection can be reused
+	io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return resp.StatusCode, false, nil
+	}
+
+	err = fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	return resp.StatusCode, isRetryableStatus(resp.StatusCode), err
+}
+
+// isRetryableStatus is func to check the receiver may accept the same request later
+func isRetryableStatus(code int) bool {
+	return code >= 500 || code == http.StatusRequestTimeout || code == http.StatusTooManyRequests
+}
+
+// backoffDuration is func to get wait time before next attempt, it is doubled on every attempt
+func (d *Deliverer) backoffDuration(attempt int) time.Duration {
+	backoff := d.backoff
+	for i := 1; i < attempt; i++ {
+		backoff *= 2
+		if backoff >= maxDeliveryBackoff {
+			return maxDeliveryBackoff
+		}
+	}
+	return backoff
+}
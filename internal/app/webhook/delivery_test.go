@@ -0,0 +1,246 @@
+package webhook
+
+import (
+	"aqua-farm-manager/internal/domain/webhook"
+	"aqua-farm-manager/internal/domain/webhook/mock_webhook"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+// recorder keep every delivery attempt recorded by deliverer
+type recorder struct {
+	mu      sync.Mutex
+	records []webhook.RecordDeliveryRequest
+}
+
+func (r *recorder) record(_ context.Context, req webhook.RecordDeliveryRequest) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.records = append(r.records, req)
+	return nil
+}
+
+func (r *recorder) get() []webhook.RecordDeliveryRequest {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]webhook.RecordDeliveryRequest(nil), r.records...)
+}
+
+func TestSign(t *testing.T) {
+	got := Sign("0123456789abcdef", "1700000000", []byte(`{"event_id":"a"}`))
+	want := "sha256=6b80dd4d66ec7b820dd6e4aadf1b92fbcc2cfd3a1c407f0e2ff6e1f43da1e1c4"
+	if got != want {
+		t.Fatalf("Sign() = %s, want %s", got, want)
+	}
+	if got == Sign("0123456789abcdeX", "1700000000", []byte(`{"event_id":"a"}`)) {
+		t.Fatalf("Sign() must depend on secret")
+	}
+	if got == Sign("0123456789abcdef", "1700000001", []byte(`{"event_id":"a"}`)) {
+		t.Fatalf("Sign() must depend on timestamp")
+	}
+}
+
+func TestDeliverer_Deliver(t *testing.T) {
+	secret := "0123456789abcdef"
+	payload := []byte(`{"event_id":"e1","type":"farm.created"}`)
+	tests := []struct {
+		name        string
+		statusCodes []int
+		wantErr     bool
+		wantStatus  []int
+		wantSuccess []bool
+	}{
+		{
+			name:        "success on first attempt",
+			statusCodes: []int{http.StatusOK},
+			wantStatus:  []int{200},
+			wantSuccess: []bool{true},
+		},
+		{
+			name:        "retry server error then success",
+			statusCodes: []int{http.StatusServiceUnavailable, http.StatusTooManyRequests, http.StatusNoContent},
+			wantStatus:  []int{503, 429, 204},
+			wantSuccess: []bool{false, false, true},
+		},
+		{
+			name:        "client error is not retried",
+			statusCodes: []int{http.StatusBadRequest},
+			wantErr:     true,
+			wantStatus:  []int{400},
+			wantSuccess: []bool{false},
+		},
+		{
+			name:        "give up after max attempts",
+			statusCodes: []int{http.StatusInternalServerError, http.StatusBadGateway, http.StatusGatewayTimeout, http.StatusOK},
+			wantErr:     true,
+			wantStatus:  []int{500, 502, 504},
+			wantSuccess: []bool{false, false, false},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int32
+			var mu sync.Mutex
+			var headers []http.Header
+			server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				call := atomic.AddInt32(&calls, 1)
+				body, _ := ioutil.ReadAll(r.Body)
+				if string(body) != string(payload) {
+					t.Errorf("receiver body got = %s, want %s", body, payload)
+				}
+
+				mu.Lock()
+				headers = append(headers, r.Header.Clone())
+				mu.Unlock()
+
+				signature := Sign(secret, r.Header.Get(HeaderTimestamp), body)
+				if r.Header.Get(HeaderSignature) != signature {
+					t.Errorf("receiver signature got = %s, want %s", r.Header.Get(HeaderSignature), signature)
+				}
+				w.WriteHeader(tt.statusCodes[call-1])
+			}))
+			defer server.Close()
+
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			domain := mock_webhook.NewMockWebhookDomain(mockCtrl)
+			rec := &recorder{}
+			domain.EXPECT().RecordDelivery(gomock.Any(), gomock.Any()).DoAndReturn(rec.record).AnyTimes()
+
+			deliverer := NewDeliverer(domain,
+				WithHTTPClientOptions(server.Client()),
+				WithDeliveryRetryOptions(3, 1))
+
+			err := deliverer.Deliver(context.Background(), Delivery{
+				Subscriber: webhook.Subscriber{ID: 7, URL: server.URL, Secret: secret},
+				EventID:    "e1",
+				EventType:  "farm.created",
+				Payload:    payload,
+			})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Deliverer.Deliver() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			records := rec.get()
+			if len(records) != len(tt.wantSuccess) {
+				t.Fatalf("Deliverer.Deliver() recorded %d attempt, want %d", len(records), len(tt.wantSuccess))
+			}
+			for i, r := range records {
+				if r.WebhookID != 7 || r.EventID != "e1" || r.EventType != "farm.created" || r.Attempt != i+1 {
+					t.Errorf("record %d got = %+v", i, r)
+				}
+				if r.Success != tt.wantSuccess[i] {
+					t.Errorf("record %d success got = %v, want %v", i, r.Success, tt.wantSuccess[i])
+				}
+				if !r.Success && r.Error == "" {
+					t.Errorf("record %d failed attempt must have error", i)
+				}
+				if r.StatusCode != tt.wantStatus[i] {
+					t.Errorf("record %d status code got = %d, want %d", i, r.StatusCode, tt.wantStatus[i])
+				}
+			}
+
+			for i, h := range headers {
+				if h.Get(HeaderWebhookID) != "7" || h.Get(HeaderEventID) != "e1" || h.Get(HeaderEventType) != "farm.created" {
+					t.Errorf("request %d header got = %v", i, h)
+				}
+				if h.Get(HeaderAttempt) != strconv.Itoa(i+1) {
+					t.Errorf("request %d attempt header got = %s, want %d", i, h.Get(HeaderAttempt), i+1)
+				}
+				if h.Get("Content-Type") != "application/json" {
+					t.Errorf("request %d content type got = %s", i, h.Get("Content-Type"))
+				}
+			}
+		})
+	}
+}
+
+func TestDeliverer_DeliverNetworkError(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	client := server.Client()
+	url := server.URL
+	server.Close()
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	domain := mock_webhook.NewMockWebhookDomain(mockCtrl)
+	rec := &recorder{}
+	domain.EXPECT().RecordDelivery(gomock.Any(), gomock.Any()).DoAndReturn(rec.record).Times(2)
+
+	deliverer := NewDeliverer(domain, WithHTTPClientOptions(client), WithDeliveryRetryOptions(2, 1))
+	err := deliverer.Deliver(context.Background(), Delivery{
+		Subscriber: webhook.Subscriber{ID: 1, URL: url, Secret: "0123456789abcdef"},
+		EventID:    "e1",
+		EventType:  "farm.created",
+		Payload:    []byte(`{}`),
+	})
+	if err == nil {
+		t.Fatalf("Deliverer.Deliver() expect error on closed receiver")
+	}
+	for _, r := range rec.get() {
+		if r.Success || r.StatusCode != 0 {
+			t.Errorf("record got = %+v, want failed without status code", r)
+		}
+	}
+}
+
+func TestDeliverer_DeliverPrivateAddress(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+	}))
+	defer server.Close()
+
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	domain := mock_webhook.NewMockWebhookDomain(mockCtrl)
+	rec := &recorder{}
+	domain.EXPECT().RecordDelivery(gomock.Any(), gomock.Any()).DoAndReturn(rec.record).Times(1)
+
+	deliverer := NewDeliverer(domain, WithDeliveryRetryOptions(1, 1))
+	err := deliverer.Deliver(context.Background(), Delivery{
+		Subscriber: webhook.Subscriber{ID: 1, URL: server.URL, Secret: "0123456789abcdef"},
+		EventID:    "e1",
+		EventType:  "farm.created",
+		Payload:    []byte(`{}`),
+	})
+	if err == nil {
+		t.Fatalf("Deliverer.Deliver() expect error on loopback receiver")
+	}
+	if atomic.LoadInt32(&calls) != 0 {
+		t.Errorf("Deliverer.Deliver() loopback receiver got %d request, want 0", calls)
+	}
+	for _, r := range rec.get() {
+		if r.Success || !strings.Contains(r.Error, errPrivateAddress.Error()) {
+			t.Errorf("record got = %+v, want failed by private address", r)
+		}
+	}
+}
+
+func TestDeliverer_backoffDuration(t *testing.T) {
+	d := NewDeliverer(nil, WithDeliveryRetryOptions(10, 1000))
+	tests := []struct {
+		attempt int
+		want    time.Duration
+	}{
+		{attempt: 1, want: time.Second},
+		{attempt: 2, want: 2 * time.Second},
+		{attempt: 3, want: 4 * time.Second},
+		{attempt: 10, want: maxDeliveryBackoff},
+	}
+	for _, tt := range tests {
+		if got := d.backoffDuration(tt.attempt); got != tt.want {
+			t.Errorf("Deliverer.backoffDuration(%d) = %v, want %v", tt.attempt, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,124 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"aqua-farm-manager/internal/domain/webhook"
+	"aqua-farm-manager/pkg/apperror"
+	utilhttp "aqua-farm-manager/pkg/utilhttp"
+
+	"github.com/gorilla/mux"
+)
+
+const (
+	defaultDeliveryLimit = 50
+	maxDeliveryLimit     = 500
+)
+
+// GetDeliveriesResponse is list response parameter for Get Deliveries Api
+type GetDeliveriesResponse struct {
+	Deliveries []DeliveryInfo `json:"deliveries"`
+}
+
+// DeliveryInfo is a delivery attempt of webhook
+type DeliveryInfo struct {
+	ID         uint      `json:"id"`
+	EventID    string    `json:"event_id"`
+	EventType  string    `json:"event_type"`
+	Attempt    int       `json:"attempt"`
+	StatusCode int       `json:"status_code,omitempty"`
+	Success    bool      `json:"success"`
+	Error      string    `json:"error,omitempty"`
+	DurationMs int64     `json:"duration_ms"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// GetDeliveriesHandler is func handler for get latest delivery attempt of webhook,
+// the number of attempt can be set by limit query param
+func (h *WebhookHandler) GetDeliveriesHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.timeoutInSec)*time.Second)
+	defer cancel()
+
+	var err error
+	var response utilhttp.StandardResponse
+	var code int = http.StatusOK
+
+	defer func() {
+		if err != nil {
+			code = apperror.HTTPStatus(err)
+			if code == http.StatusInternalServerError {
+				log.Println("[GetDeliveriesHandler]-Internal Error :", err)
+			}
+			response.SetError(err)
+		} else {
+			response.Message = "success"
+		}
+		response.Code = code
+
+		data, errMarshal := json.Marshal(response)
+		if errMarshal != nil {
+			log.Println("[GetDeliveriesHandler]-Error Marshal Response :", err)
+			code = http.StatusInternalServerError
+			data = []byte(`{"code":500,"message":"Internal Server Error"}`)
+		}
+		utilhttp.WriteResponse(w, data, code)
+	}()
+
+	// checking valid param
+	vars := mux.Vars(r)
+	id, err := strconv.Atoi(vars["id"])
+	if err != nil || id <= 0 {
+		err = apperror.Validation(apperror.Field("id", "id must be a positive number"))
+		return
+	}
+
+	limit := defaultDeliveryLimit
+	if param := r.URL.Query().Get("limit"); param != "" {
+		limit, err = strconv.Atoi(param)
+		if err != nil || limit <= 0 || limit > maxDeliveryLimit {
+			err = apperror.Validation(apperror.Field("limit", fmt.Sprintf("limit must be between 1 and %d", maxDeliveryLimit)))
+			return
+		}
+	}
+
+	var res []webhook.DeliveryInfo
+	res, err = h.domain.GetDeliveries(ctx, uint(id), limit)
+	if err != nil && ctx.Err() != nil {
+		err = apperror.Wrap(apperror.CodeTimeout, apperror.MessageTimeout, err)
+		return
+	}
+	if err != nil {
+		return
+	}
+
+	response = mapResponseGetDeliveries(res)
+}
+
+func mapResponseGetDeliveries(deliveries []webhook.DeliveryInfo) utilhttp.StandardResponse {
+	list := make([]DeliveryInfo, 0, len(deliveries))
+	for _, d := range deliveries {
+		list = append(list, DeliveryInfo{
+			ID:         d.ID,
+			EventID:    d.EventID,
+			EventType:  d.EventType,
+			Attempt:    d.Attempt,
+			StatusCode: d.StatusCode,
+			Success:    d.Success,
+			Error:      d.Error,
+			DurationMs: d.Duration.Milliseconds(),
+			CreatedAt:  d.CreatedAt,
+		})
+	}
+
+	var res utilhttp.StandardResponse
+	res.Data = GetDeliveriesResponse{
+		Deliveries: list,
+	}
+	return res
+}
@@ -0,0 +1,113 @@
+package webhook
+
+import (
+	"aqua-farm-manager/internal/domain/webhook"
+	"aqua-farm-manager/internal/domain/webhook/mock_webhook"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+)
+
+func TestWebhookHandler_GetDeliveriesHandler(t *testing.T) {
+	createdAt := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	type want struct {
+		body string
+		code int
+	}
+	tests := []struct {
+		name     string
+		id       string
+		query    string
+		mockFunc func(domain *mock_webhook.MockWebhookDomain)
+		want     want
+	}{
+		{
+			name:  "success flow",
+			id:    "1",
+			query: "?limit=2",
+			mockFunc: func(domain *mock_webhook.MockWebhookDomain) {
+				domain.EXPECT().GetDeliveries(gomock.Any(), uint(1), 2).Return([]webhook.DeliveryInfo{
+					{ID: 2, EventID: "e1", EventType: "farm.created", Attempt: 2, StatusCode: 200, Success: true, Duration: 15 * time.Millisecond, CreatedAt: createdAt},
+					{ID: 1, EventID: "e1", EventType: "farm.created", Attempt: 1, StatusCode: 503, Error: "unexpected status code 503", Duration: 20 * time.Millisecond, CreatedAt: createdAt},
+				}, nil)
+			},
+			want: want{
+				body: `{"data":{"deliveries":[` +
+					`{"id":2,"event_id":"e1","event_type":"farm.created","attempt":2,"status_code":200,"success":true,"duration_ms":15,"created_at":"2023-01-02T03:04:05Z"},` +
+					`{"id":1,"event_id":"e1","event_type":"farm.created","attempt":1,"status_code":503,"success":false,"error":"unexpected status code 503","duration_ms":20,"created_at":"2023-01-02T03:04:05Z"}` +
+					`]},"code":200,"message":"success"}`,
+				code: 200,
+			},
+		},
+		{
+			name: "default limit flow",
+			id:   "1",
+			mockFunc: func(domain *mock_webhook.MockWebhookDomain) {
+				domain.EXPECT().GetDeliveries(gomock.Any(), uint(1), 50).Return(nil, nil)
+			},
+			want: want{
+				body: `{"data":{"deliveries":[]},"code":200,"message":"success"}`,
+				code: 200,
+			},
+		},
+		{
+			name: "webhook not found flow",
+			id:   "1",
+			mockFunc: func(domain *mock_webhook.MockWebhookDomain) {
+				domain.EXPECT().GetDeliveries(gomock.Any(), uint(1), 50).Return(nil, webhook.ErrInvalidWebhook)
+			},
+			want: want{
+				body: `{"code":404,"message":"Webhook Is Not Exists","errors":[{"code":"WEBHOOK_NOT_FOUND","message":"Webhook Is Not Exists"}]}`,
+				code: 404,
+			},
+		},
+		{
+			name:     "invalid limit flow",
+			id:       "1",
+			query:    "?limit=1000",
+			mockFunc: func(domain *mock_webhook.MockWebhookDomain) {},
+			want: want{
+				body: `{"code":422,"message":"Invalid Parameter Request","errors":[{"code":"VALIDATION_FAILED","field":"limit","message":"limit must be between 1 and 500"}]}`,
+				code: 422,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			domain := mock_webhook.NewMockWebhookDomain(mockCtrl)
+			tt.mockFunc(domain)
+
+			handler := WebhookHandler{
+				domain:       domain,
+				timeoutInSec: 10,
+			}
+
+			r := httptest.NewRequest(http.MethodGet, "/v1/webhooks/{id}/deliveries"+tt.query, nil)
+			r = mux.SetURLVars(r, map[string]string{
+				"id": tt.id,
+			})
+			w := httptest.NewRecorder()
+			handler.GetDeliveriesHandler(w, r)
+			result := w.Result()
+			resBody, err := ioutil.ReadAll(result.Body)
+			if err != nil {
+				t.Fatalf("Error read body err = %v\n", err)
+			}
+
+			if result.StatusCode != tt.want.code {
+				t.Fatalf("GetDeliveriesHandler status code got =%d, want %d \n", result.StatusCode, tt.want.code)
+			}
+
+			if string(resBody) != tt.want.body {
+				t.Fatalf("GetDeliveriesHandler body got =%s, want %s \n", string(resBody), tt.want.body)
+			}
+		})
+	}
+}
@@ -0,0 +1,116 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"aqua-farm-manager/internal/domain/webhook"
+	"aqua-farm-manager/pkg/apperror"
+	utilhttp "aqua-farm-manager/pkg/utilhttp"
+)
+
+const (
+	defaultPageSize = 20
+)
+
+// GetWebhookResponse is list response parameter for Get Api
+type GetWebhookResponse struct {
+	Webhooks []WebhookInfo `json:"webhooks"`
+	Cursor   *int          `json:"cursor,omitempty"`
+}
+
+// WebhookInfo is webhook registration, the secret is never returned
+type WebhookInfo struct {
+	ID         uint      `json:"id"`
+	URL        string    `json:"url"`
+	EventTypes []string  `json:"event_types"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// GetWebhookHandler is func handler for get registered webhook,
+// the page can be set by size and cursor query param
+func (h *WebhookHandler) GetWebhookHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), time.Duration(h.timeoutInSec)*time.Second)
+	defer cancel()
+
+	var err error
+	var response utilhttp.StandardResponse
+	var code int = http.StatusOK
+
+	defer func() {
+		if err != nil {
+			code = apperror.HTTPStatus(err)
+			if code == http.StatusInternalServerError {
+				log.Println("[GetWebhookHandler]-Internal Error :", err)
+			}
+			response.SetError(err)
+		} else {
+			response.Message = "success"
+		}
+		response.Code = code
+
+		data, errMarshal := json.Marshal(response)
+		if errMarshal != nil {
+			log.Println("[GetWebhookHandler]-Error Marshal Response :", err)
+			code = http.StatusInternalServerError
+			data = []byte(`{"code":500,"message":"Internal Server Error"}`)
+		}
+		utilhttp.WriteResponse(w, data, code)
+	}()
+
+	// checking valid param
+	size, _ := strconv.Atoi(r.URL.Query().Get("size"))
+	if size < 1 || size > defaultPageSize {
+		size = defaultPageSize
+	}
+
+	cursor, _ := strconv.Atoi(r.URL.Query().Get("cursor"))
+	if cursor < 1 {
+		cursor = 1
+	}
+
+	var res []webhook.WebhookInfo
+	var next int
+	res, next, err = h.domain.GetWebhooks(ctx, size, cursor)
+	if err != nil && ctx.Err() != nil {
+		err = apperror.Wrap(apperror.CodeTimeout, apperror.MessageTimeout, err)
+		return
+	}
+	if err != nil {
+		return
+	}
+
+	if len(res) == 0 {
+		err = apperror.New(apperror.CodeNotFound, apperror.MessageNotFound)
+		return
+	}
+
+	response = mapResponseGet(res, next)
+}
+
+func mapResponseGet(webhooks []webhook.WebhookInfo, next int) utilhttp.StandardResponse {
+	var list []WebhookInfo
+	for _, wh := range webhooks {
+		list = append(list, WebhookInfo{
+			ID:         wh.ID,
+			URL:        wh.URL,
+			EventTypes: wh.EventTypes,
+			CreatedAt:  wh.CreatedAt,
+		})
+	}
+
+	response := GetWebhookResponse{
+		Webhooks: list,
+	}
+	if next > 0 {
+		response.Cursor = &next
+	}
+
+	var res utilhttp.StandardResponse
+	res.Data = response
+	return res
+}
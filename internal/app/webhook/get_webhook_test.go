@@ -0,0 +1,93 @@
+package webhook
+
+import (
+	"aqua-farm-manager/internal/domain/webhook"
+	"aqua-farm-manager/internal/domain/webhook/mock_webhook"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestWebhookHandler_GetWebhookHandler(t *testing.T) {
+	createdAt := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	type want struct {
+		body string
+		code int
+	}
+	tests := []struct {
+		name     string
+		query    string
+		mockFunc func(domain *mock_webhook.MockWebhookDomain)
+		want     want
+	}{
+		{
+			name:  "success flow",
+			query: "?size=1&cursor=1",
+			mockFunc: func(domain *mock_webhook.MockWebhookDomain) {
+				domain.EXPECT().GetWebhooks(gomock.Any(), 1, 1).Return([]webhook.WebhookInfo{
+					{ID: 1, URL: "https://partner.com/hook", EventTypes: []string{"*"}, CreatedAt: createdAt},
+				}, 2, nil)
+			},
+			want: want{
+				body: `{"data":{"webhooks":[{"id":1,"url":"https://partner.com/hook","event_types":["*"],"created_at":"2023-01-02T03:04:05Z"}],"cursor":2},"code":200,"message":"success"}`,
+				code: 200,
+			},
+		},
+		{
+			name:  "default paging flow",
+			query: "?size=100",
+			mockFunc: func(domain *mock_webhook.MockWebhookDomain) {
+				domain.EXPECT().GetWebhooks(gomock.Any(), 20, 1).Return(nil, 0, nil)
+			},
+			want: want{
+				body: `{"code":404,"message":"Data Not Found","errors":[{"code":"NOT_FOUND","message":"Data Not Found"}]}`,
+				code: 404,
+			},
+		},
+		{
+			name: "internal server error flow",
+			mockFunc: func(domain *mock_webhook.MockWebhookDomain) {
+				domain.EXPECT().GetWebhooks(gomock.Any(), 20, 1).Return(nil, 0, fmt.Errorf("some error"))
+			},
+			want: want{
+				body: `{"code":500,"message":"Internal Server Error","errors":[{"code":"INTERNAL_ERROR","message":"Internal Server Error"}]}`,
+				code: 500,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			domain := mock_webhook.NewMockWebhookDomain(mockCtrl)
+			tt.mockFunc(domain)
+
+			handler := WebhookHandler{
+				domain:       domain,
+				timeoutInSec: 10,
+			}
+
+			r := httptest.NewRequest(http.MethodGet, "/v1/webhooks"+tt.query, nil)
+			w := httptest.NewRecorder()
+			handler.GetWebhookHandler(w, r)
+			result := w.Result()
+			resBody, err := ioutil.ReadAll(result.Body)
+			if err != nil {
+				t.Fatalf("Error read body err = %v\n", err)
+			}
+
+			if result.StatusCode != tt.want.code {
+				t.Fatalf("GetWebhookHandler status code got =%d, want %d \n", result.StatusCode, tt.want.code)
+			}
+
+			if string(resBody) != tt.want.body {
+				t.Fatalf("GetWebhookHandler body got =%s, want %s \n", string(resBody), tt.want.body)
+			}
+		})
+	}
+}
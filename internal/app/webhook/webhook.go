@@ -0,0 +1,42 @@
+package webhook
+
+import "aqua-farm-manager/internal/domain/webhook"
+
+// WebhookHandler list dependencies for webhook handler
+type WebhookHandler struct {
+	domain       webhook.WebhookDomain
+	timeoutInSec int
+}
+
+// Option set options for http handler config
+type Option func(*WebhookHandler)
+
+const (
+	defaultTimeout = 5
+)
+
+// NewWebhookHandler is func to create http webhook handler
+func NewWebhookHandler(domain webhook.WebhookDomain, options ...Option) *WebhookHandler {
+	handler := &WebhookHandler{
+		domain:       domain,
+		timeoutInSec: defaultTimeout,
+	}
+
+	// Apply options
+	for _, opt := range options {
+		opt(handler)
+	}
+
+	return handler
+}
+
+// WithTimeoutOptions is func to set timeout config into handler
+func WithTimeoutOptions(timeoutinsec int) Option {
+	return Option(
+		func(wh *WebhookHandler) {
+			if timeoutinsec <= 0 {
+				timeoutinsec = defaultTimeout
+			}
+			wh.timeoutInSec = timeoutinsec
+		})
+}
@@ -0,0 +1,49 @@
+package webhook
+
+import (
+	"aqua-farm-manager/internal/domain/webhook"
+	"reflect"
+	"testing"
+)
+
+func TestNewWebhookHandler(t *testing.T) {
+	type args struct {
+		domain  webhook.WebhookDomain
+		options []Option
+	}
+	tests := []struct {
+		name string
+		args args
+		want *WebhookHandler
+	}{
+		{
+			name: "success with setting flow",
+			args: args{
+				domain:  &webhook.Webhook{},
+				options: []Option{WithTimeoutOptions(10)},
+			},
+			want: &WebhookHandler{
+				timeoutInSec: 10,
+				domain:       &webhook.Webhook{},
+			},
+		},
+		{
+			name: "success with invalid setting flow",
+			args: args{
+				domain:  &webhook.Webhook{},
+				options: []Option{WithTimeoutOptions(-1)},
+			},
+			want: &WebhookHandler{
+				timeoutInSec: 5,
+				domain:       &webhook.Webhook{},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewWebhookHandler(tt.args.domain, tt.args.options...); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewWebhookHandler() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
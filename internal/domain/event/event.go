@@ -0,0 +1,77 @@
+package event
+
+import "aqua-farm-manager/internal/infrastructure/outbox"
+
+// list topic of domain event
+const (
+	FarmTopic = "aqua_farm_farm_event"
+	PondTopic = "aqua_farm_pond_event"
+)
+
+// Type denotes the type of domain event
+type Type string
+
+// list type of domain event
+const (
+	FarmCreated Type = "farm.created"
+	FarmUpdated Type = "farm.updated"
+	FarmDeleted Type = "farm.deleted"
+	PondCreated Type = "pond.created"
+	PondUpdated Type = "pond.updated"
+	PondDeleted Type = "pond.deleted"
+	PondMoved   Type = "pond.moved"
+)
+
+// Types is list every known type of domain event
+var Types = []Type{
+	FarmCreated,
+	FarmUpdated,
+	FarmDeleted,
+	PondCreated,
+	PondUpdated,
+	PondDeleted,
+	PondMoved,
+}
+
+// String is func to convert Type into string
+func (t Type) String() string {
+	return string(t)
+}
+
+// FarmData struct is state of farm carried by farm event
+type FarmData struct {
+	Name     string `json:"name"`
+	Location string `json:"location,omitempty"`
+	Owner    string `json:"owner,omitempty"`
+	Area     string `json:"area,omitempty"`
+}
+
+// PondData struct is state of pond carried by pond event,
+// PreviousFarmID is only set on pond.moved
+type PondData struct {
+	Name           string  `json:"name"`
+	Capacity       float64 `json:"capacity,omitempty"`
+	Depth          float64 `json:"depth,omitempty"`
+	WaterQuality   float64 `json:"water_quality,omitempty"`
+	Species        string  `json:"species,omitempty"`
+	FarmID         uint    `json:"farm_id,omitempty"`
+	PreviousFarmID uint    `json:"previous_farm_id,omitempty"`
+}
+
+// NewFarmEvent is func to create farm event stored into outbox
+func NewFarmEvent(t Type, data FarmData) outbox.Event {
+	return outbox.Event{
+		Topic: FarmTopic,
+		Type:  t.String(),
+		Data:  data,
+	}
+}
+
+// NewPondEvent is func to create pond event stored into outbox
+func NewPondEvent(t Type, data PondData) outbox.Event {
+	return outbox.Event{
+		Topic: PondTopic,
+		Type:  t.String(),
+		Data:  data,
+	}
+}
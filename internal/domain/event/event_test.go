@@ -0,0 +1,68 @@
+package event
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"aqua-farm-manager/internal/infrastructure/outbox"
+	"aqua-farm-manager/pkg/nsq"
+)
+
+func TestEvent_Schema(t *testing.T) {
+	schemas, err := nsq.LoadSchemas("../../../schema/nsq")
+	if err != nil {
+		t.Fatalf("LoadSchemas() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		event   outbox.Event
+		wantErr bool
+	}{
+		{
+			name:  "farm created",
+			event: NewFarmEvent(FarmCreated, FarmData{Name: "Farm 1", Location: "Bandung", Owner: "Budi", Area: "100"}),
+		},
+		{
+			name:  "farm deleted",
+			event: NewFarmEvent(FarmDeleted, FarmData{Name: "Farm 1"}),
+		},
+		{
+			name:  "pond updated",
+			event: NewPondEvent(PondUpdated, PondData{Name: "Pond 1", Depth: 2, FarmID: 1}),
+		},
+		{
+			name:  "pond moved",
+			event: NewPondEvent(PondMoved, PondData{Name: "Pond 1", FarmID: 2, PreviousFarmID: 1}),
+		},
+		{
+			name:    "pond moved without previous farm",
+			event:   NewPondEvent(PondMoved, PondData{Name: "Pond 1", FarmID: 2}),
+			wantErr: true,
+		},
+		{
+			name:    "farm event with pond type",
+			event:   outbox.Event{Topic: FarmTopic, Type: PondCreated.String(), Data: FarmData{Name: "Farm 1"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body, err := json.Marshal(outbox.Message{
+				EventID:     "event-id",
+				Version:     outbox.MessageVersion,
+				Type:        tt.event.Type,
+				AggregateID: 1,
+				OccurredAt:  time.Now().UTC(),
+				Data:        tt.event.Data,
+			})
+			if err != nil {
+				t.Fatalf("json.Marshal() error = %v", err)
+			}
+			if err := schemas.Validate(tt.event.Topic, body); (err != nil) != tt.wantErr {
+				t.Errorf("Schemas.Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -1,18 +1,24 @@
 package farm
 
 import (
+	"aqua-farm-manager/internal/domain/event"
 	"aqua-farm-manager/internal/infrastructure/farm"
+	"aqua-farm-manager/internal/infrastructure/outbox"
 	"aqua-farm-manager/internal/infrastructure/pond"
+	"context"
+	"errors"
+	"sort"
 )
 
 // FarmDomain is list method for Farm domain
 type FarmDomain interface {
-	CreateFarmInfo(r CreateDomainRequest) (CreateDomainResponse, error)
-	DeleteFarmInfo(r DeleteDomainRequest) (DeleteDomainResponse, error)
-	UpdateFarmInfo(r UpdateDomainRequest) (UpdateDomainResponse, error)
-	GetFarmInfoByID(ID uint) (GetFarmInfoResponse, error)
-	GetFarm(size, cursor int) ([]GetFarmInfoResponse, int, error)
-	DeleteFarmsWithDependencies(ID uint) (DeleteAllResponse, error)
+	CreateFarmInfo(ctx context.Context, r CreateDomainRequest) (CreateDomainResponse, error)
+	DeleteFarmInfo(ctx context.Context, r DeleteDomainRequest) (DeleteDomainResponse, error)
+	UpdateFarmInfo(ctx context.Context, r UpdateDomainRequest) (UpdateDomainResponse, error)
+	GetFarmInfoByID(ctx context.Context, ID uint) (GetFarmInfoResponse, error)
+	GetFarm(ctx context.Context, size, cursor int) ([]GetFarmInfoResponse, int, error)
+	DeleteFarmsWithDependencies(ctx context.Context, ID uint) (DeleteAllResponse, error)
+	GetFarmSummary(ctx context.Context) (FarmSummaryResponse, error)
 }
 
 // Stat is list dependencies stat domain
@@ -30,11 +36,11 @@ func NewFarmDomain(store farm.FarmStore, pondstore pond.PondStore) FarmDomain {
 }
 
 // CreateFarmInfo is func to store and validate request to create farm info
-func (f *Farm) CreateFarmInfo(r CreateDomainRequest) (CreateDomainResponse, error) {
+func (f *Farm) CreateFarmInfo(ctx context.Context, r CreateDomainRequest) (CreateDomainResponse, error) {
 	var err error
 	var res CreateDomainResponse
 
-	exists, err := f.farmstore.Verify(&farm.FarmInfraInfo{
+	exists, err := f.farmstore.Verify(ctx, &farm.FarmInfraInfo{
 		Name: r.Name,
 	})
 
@@ -47,10 +53,13 @@ func (f *Farm) CreateFarmInfo(r CreateDomainRequest) (CreateDomainResponse, erro
 	}
 
 	farmsInfra := mapCreateFarmInfoRequest(r)
+	farmsInfra.Events = []outbox.Event{
+		event.NewFarmEvent(event.FarmCreated, mapFarmEventData(farmsInfra)),
+	}
 
-	err = f.farmstore.Create(&farmsInfra)
+	err = f.farmstore.Create(ctx, &farmsInfra)
 	if err != nil {
-		return res, err
+		return res, mapStoreError(err)
 	}
 
 	res.ID = farmsInfra.ID
@@ -67,8 +76,17 @@ func mapCreateFarmInfoRequest(r CreateDomainRequest) farm.FarmInfraInfo {
 	}
 }
 
+func mapFarmEventData(r farm.FarmInfraInfo) event.FarmData {
+	return event.FarmData{
+		Name:     r.Name,
+		Location: r.Location,
+		Owner:    r.Owner,
+		Area:     r.Area,
+	}
+}
+
 // DeleteFarmInfo is func to soft delete farm info in database
-func (f *Farm) DeleteFarmInfo(r DeleteDomainRequest) (DeleteDomainResponse, error) {
+func (f *Farm) DeleteFarmInfo(ctx context.Context, r DeleteDomainRequest) (DeleteDomainResponse, error) {
 	var err error
 	var res DeleteDomainResponse
 	var exists bool
@@ -82,7 +100,7 @@ func (f *Farm) DeleteFarmInfo(r DeleteDomainRequest) (DeleteDomainResponse, erro
 		return res, ErrInvalidFarm
 	}
 
-	exists, err = f.farmstore.Verify(&verify)
+	exists, err = f.farmstore.Verify(ctx, &verify)
 
 	if err != nil {
 		return res, err
@@ -92,15 +110,18 @@ func (f *Farm) DeleteFarmInfo(r DeleteDomainRequest) (DeleteDomainResponse, erro
 		return res, ErrInvalidFarm
 	}
 
-	ponds := f.farmstore.GetActivePondsInFarm(verify.ID)
+	ponds := f.farmstore.GetActivePondsInFarm(ctx, verify.ID)
 
 	if len(ponds) > 0 {
 		return res, ErrExistsPonds
 	}
 
-	err = f.farmstore.Delete(&farm.FarmInfraInfo{
+	err = f.farmstore.Delete(ctx, &farm.FarmInfraInfo{
 		ID:   verify.ID,
 		Name: verify.Name,
+		Events: []outbox.Event{
+			event.NewFarmEvent(event.FarmDeleted, event.FarmData{Name: verify.Name}),
+		},
 	})
 	if err != nil {
 		return res, err
@@ -113,12 +134,12 @@ func (f *Farm) DeleteFarmInfo(r DeleteDomainRequest) (DeleteDomainResponse, erro
 }
 
 // UpdateFarmInfo is func to update farm info in database
-func (f *Farm) UpdateFarmInfo(r UpdateDomainRequest) (UpdateDomainResponse, error) {
+func (f *Farm) UpdateFarmInfo(ctx context.Context, r UpdateDomainRequest) (UpdateDomainResponse, error) {
 	var err error
 	var res UpdateDomainResponse
 	var exists bool
 
-	exists, err = f.farmstore.Verify(&farm.FarmInfraInfo{
+	exists, err = f.farmstore.Verify(ctx, &farm.FarmInfraInfo{
 		Name: r.Name,
 	})
 	if err != nil {
@@ -132,9 +153,12 @@ func (f *Farm) UpdateFarmInfo(r UpdateDomainRequest) (UpdateDomainResponse, erro
 		Area:     r.Area,
 	}
 	if !exists {
-		err = f.farmstore.Create(farmsInfra)
+		farmsInfra.Events = []outbox.Event{
+			event.NewFarmEvent(event.FarmCreated, mapFarmEventData(*farmsInfra)),
+		}
+		err = f.farmstore.Create(ctx, farmsInfra)
 	} else {
-		err = f.farmstore.GetFarmByName(farmsInfra)
+		err = f.farmstore.GetFarmByName(ctx, farmsInfra)
 		if err != nil {
 			return res, err
 		}
@@ -150,11 +174,14 @@ func (f *Farm) UpdateFarmInfo(r UpdateDomainRequest) (UpdateDomainResponse, erro
 			farmsInfra.Area = r.Area
 		}
 
-		err = f.farmstore.Update(farmsInfra)
+		farmsInfra.Events = []outbox.Event{
+			event.NewFarmEvent(event.FarmUpdated, mapFarmEventData(*farmsInfra)),
+		}
+		err = f.farmstore.Update(ctx, farmsInfra)
 	}
 
 	if err != nil {
-		return res, err
+		return res, mapStoreError(err)
 	}
 
 	return UpdateDomainResponse{
@@ -167,29 +194,40 @@ func (f *Farm) UpdateFarmInfo(r UpdateDomainRequest) (UpdateDomainResponse, erro
 }
 
 // GetFarmInfoByID is func to get farm info by id
-func (f *Farm) GetFarmInfoByID(ID uint) (GetFarmInfoResponse, error) {
+func (f *Farm) GetFarmInfoByID(ctx context.Context, ID uint) (GetFarmInfoResponse, error) {
 	var err error
 
-	farm := &farm.FarmInfraInfo{
+	farmInfra := &farm.FarmInfraInfo{
 		ID: ID,
 	}
 
-	err = f.farmstore.GetFarmByID(farm)
+	err = f.farmstore.GetFarmByID(ctx, farmInfra)
+	if errors.Is(err, farm.ErrNotFound) {
+		return GetFarmInfoResponse{}, ErrInvalidFarm
+	}
+	if err != nil {
+		return GetFarmInfoResponse{}, err
+	}
+
+	ids, err := f.pondstore.GetPondIDbyFarmID(ctx, farmInfra.ID)
 	if err != nil {
 		return GetFarmInfoResponse{}, err
 	}
 
-	ids, err := f.pondstore.GetPondIDbyFarmID(farm.ID)
+	ponds, err := f.pondstore.GetPondsByIDs(ctx, ids)
 	if err != nil {
 		return GetFarmInfoResponse{}, err
 	}
+	pondByID := make(map[uint]pond.PondInfraInfo, len(ponds))
+	for _, p := range ponds {
+		pondByID[p.ID] = p
+	}
+
+	// keep the order of pond ids, inactive pond is not returned by the store
 	var listPond []PondInfo
 	for _, id := range ids {
-		pond := &pond.PondInfraInfo{
-			ID: id,
-		}
-		err := f.pondstore.GetPondByID(pond)
-		if err != nil {
+		pond, ok := pondByID[id]
+		if !ok {
 			continue
 		}
 		listPond = append(listPond, PondInfo{
@@ -202,21 +240,21 @@ func (f *Farm) GetFarmInfoByID(ID uint) (GetFarmInfoResponse, error) {
 		})
 	}
 	return GetFarmInfoResponse{
-		ID:        farm.ID,
-		Name:      farm.Name,
-		Location:  farm.Location,
-		Owner:     farm.Owner,
-		Area:      farm.Area,
+		ID:        farmInfra.ID,
+		Name:      farmInfra.Name,
+		Location:  farmInfra.Location,
+		Owner:     farmInfra.Owner,
+		Area:      farmInfra.Area,
 		PondIDs:   ids,
 		PondInfos: listPond,
 	}, err
 }
 
 // GetFarm is func to get farm info by id
-func (f *Farm) GetFarm(size, cursor int) ([]GetFarmInfoResponse, int, error) {
+func (f *Farm) GetFarm(ctx context.Context, size, cursor int) ([]GetFarmInfoResponse, int, error) {
 	var err error
 	var list []GetFarmInfoResponse
-	farmsInfra, err := f.farmstore.GetFarmWithPaging(
+	farmsInfra, err := f.farmstore.GetFarmWithPaging(ctx,
 		farm.GetFarmWithPagingRequest{
 			Size:   size,
 			Cursor: cursor,
@@ -226,18 +264,23 @@ func (f *Farm) GetFarm(size, cursor int) ([]GetFarmInfoResponse, int, error) {
 		return list, 0, err
 	}
 
+	farmIDs := make([]uint, 0, len(farmsInfra))
+	for _, farm := range farmsInfra {
+		farmIDs = append(farmIDs, farm.ID)
+	}
+	pondIDs, err := f.pondstore.GetPondsByFarmIDs(ctx, farmIDs)
+	if err != nil {
+		return list, 0, err
+	}
+
 	for _, farm := range farmsInfra {
-		ids, err := f.pondstore.GetPondIDbyFarmID(farm.ID)
-		if err != nil {
-			return list, 0, err
-		}
 		info := GetFarmInfoResponse{
 			ID:       farm.ID,
 			Name:     farm.Name,
 			Location: farm.Location,
 			Owner:    farm.Owner,
 			Area:     farm.Area,
-			PondIDs:  ids,
+			PondIDs:  pondIDs[farm.ID],
 		}
 
 		list = append(list, info)
@@ -252,14 +295,14 @@ func (f *Farm) GetFarm(size, cursor int) ([]GetFarmInfoResponse, int, error) {
 }
 
 // DeleteFarmsWithDependencies is func to delete farms and all ponds dependencies
-func (f *Farm) DeleteFarmsWithDependencies(ID uint) (DeleteAllResponse, error) {
+func (f *Farm) DeleteFarmsWithDependencies(ctx context.Context, ID uint) (DeleteAllResponse, error) {
 	var err error
 	var res DeleteAllResponse
 	var exists bool
 
 	verify := farm.FarmInfraInfo{ID: ID}
 
-	exists, err = f.farmstore.Verify(&verify)
+	exists, err = f.farmstore.Verify(ctx, &verify)
 
 	if err != nil {
 		return res, err
@@ -269,28 +312,34 @@ func (f *Farm) DeleteFarmsWithDependencies(ID uint) (DeleteAllResponse, error) {
 		return res, ErrInvalidFarm
 	}
 
-	ponds := f.farmstore.GetActivePondsInFarm(verify.ID)
+	ponds := f.farmstore.GetActivePondsInFarm(ctx, verify.ID)
 
 	for _, p := range ponds {
 		verifyPond := pond.PondInfraInfo{
 			ID: p,
 		}
-		_, err = f.pondstore.Verify(&verifyPond)
+		_, err = f.pondstore.Verify(ctx, &verifyPond)
 		if err != nil {
 			return res, err
 		}
-		err = f.pondstore.Delete(&pond.PondInfraInfo{
+		err = f.pondstore.Delete(ctx, &pond.PondInfraInfo{
 			ID:   verifyPond.ID,
 			Name: verifyPond.Name,
+			Events: []outbox.Event{
+				event.NewPondEvent(event.PondDeleted, event.PondData{Name: verifyPond.Name, FarmID: verify.ID}),
+			},
 		})
 		if err != nil {
 			return res, err
 		}
 	}
 
-	err = f.farmstore.Delete(&farm.FarmInfraInfo{
+	err = f.farmstore.Delete(ctx, &farm.FarmInfraInfo{
 		ID:   verify.ID,
 		Name: verify.Name,
+		Events: []outbox.Event{
+			event.NewFarmEvent(event.FarmDeleted, event.FarmData{Name: verify.Name}),
+		},
 	})
 	if err != nil {
 		return res, err
@@ -302,3 +351,35 @@ func (f *Farm) DeleteFarmsWithDependencies(ID uint) (DeleteAllResponse, error) {
 
 	return res, err
 }
+
+// GetFarmSummary is func to get number of active farms, ponds and ponds of every farm
+func (f *Farm) GetFarmSummary(ctx context.Context) (FarmSummaryResponse, error) {
+	var res FarmSummaryResponse
+
+	counts, err := f.farmstore.GetPondCountPerFarm(ctx)
+	if err != nil {
+		return res, err
+	}
+
+	activePonds, err := f.pondstore.CountActivePonds(ctx)
+	if err != nil {
+		return res, err
+	}
+
+	res.ActiveFarms = len(counts)
+	res.ActivePonds = activePonds
+	for _, count := range counts {
+		res.PondsPerFarm = append(res.PondsPerFarm, count)
+	}
+	sort.Ints(res.PondsPerFarm)
+
+	return res, nil
+}
+
+// mapStoreError is func to map constraint violation of farm store into domain error
+func mapStoreError(err error) error {
+	if errors.Is(err, farm.ErrDuplicateName) {
+		return ErrDuplicateFarm
+	}
+	return err
+}
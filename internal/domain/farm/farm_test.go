@@ -5,6 +5,8 @@ import (
 	"aqua-farm-manager/internal/infrastructure/farm/mock_farm"
 	"aqua-farm-manager/internal/infrastructure/pond"
 	"aqua-farm-manager/internal/infrastructure/pond/mock_pond"
+	"context"
+	"errors"
 	"fmt"
 	"reflect"
 	"testing"
@@ -61,9 +63,9 @@ func TestFarm_CreateFarmInfo(t *testing.T) {
 		{
 			name: "success",
 			mockFunc: func() {
-				farmStore.EXPECT().Verify(gomock.Any()).Return(false, nil)
-				farmStore.EXPECT().Create(gomock.Any()).DoAndReturn(
-					func(r *farm.FarmInfraInfo) error {
+				farmStore.EXPECT().Verify(gomock.Any(), gomock.Any()).Return(false, nil)
+				farmStore.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, r *farm.FarmInfraInfo) error {
 						r.ID = 1
 						return nil
 					})
@@ -84,8 +86,8 @@ func TestFarm_CreateFarmInfo(t *testing.T) {
 		{
 			name: "error when create",
 			mockFunc: func() {
-				farmStore.EXPECT().Verify(gomock.Any()).Return(false, nil)
-				farmStore.EXPECT().Create(gomock.Any()).Return(fmt.Errorf("some error"))
+				farmStore.EXPECT().Verify(gomock.Any(), gomock.Any()).Return(false, nil)
+				farmStore.EXPECT().Create(gomock.Any(), gomock.Any()).Return(fmt.Errorf("some error"))
 			},
 			args: args{
 				r: CreateDomainRequest{
@@ -101,7 +103,7 @@ func TestFarm_CreateFarmInfo(t *testing.T) {
 		{
 			name: "farm exists",
 			mockFunc: func() {
-				farmStore.EXPECT().Verify(gomock.Any()).Return(true, nil)
+				farmStore.EXPECT().Verify(gomock.Any(), gomock.Any()).Return(true, nil)
 			},
 			args: args{
 				r: CreateDomainRequest{
@@ -117,7 +119,7 @@ func TestFarm_CreateFarmInfo(t *testing.T) {
 		{
 			name: "error verify",
 			mockFunc: func() {
-				farmStore.EXPECT().Verify(gomock.Any()).Return(false, fmt.Errorf("some error"))
+				farmStore.EXPECT().Verify(gomock.Any(), gomock.Any()).Return(false, fmt.Errorf("some error"))
 			},
 			args: args{
 				r: CreateDomainRequest{
@@ -135,7 +137,7 @@ func TestFarm_CreateFarmInfo(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockFunc()
 			s := NewFarmDomain(farmStore, pondStore)
-			got, err := s.CreateFarmInfo(tt.args.r)
+			got, err := s.CreateFarmInfo(context.Background(), tt.args.r)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Farm.CreateFarmInfo() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -170,14 +172,14 @@ func TestFarm_DeleteFarmInfo(t *testing.T) {
 				},
 			},
 			mockFunc: func() {
-				farmStore.EXPECT().Verify(gomock.Any()).DoAndReturn(
-					func(r *farm.FarmInfraInfo) (bool, error) {
+				farmStore.EXPECT().Verify(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, r *farm.FarmInfraInfo) (bool, error) {
 						r.ID = 1
 						r.Name = "farm"
 						return true, nil
 					})
-				farmStore.EXPECT().GetActivePondsInFarm(uint(1)).Return([]uint{})
-				farmStore.EXPECT().Delete(gomock.Any()).Return(nil)
+				farmStore.EXPECT().GetActivePondsInFarm(gomock.Any(), uint(1)).Return([]uint{})
+				farmStore.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil)
 			},
 			want: DeleteDomainResponse{
 				Name: "farm",
@@ -193,14 +195,14 @@ func TestFarm_DeleteFarmInfo(t *testing.T) {
 				},
 			},
 			mockFunc: func() {
-				farmStore.EXPECT().Verify(gomock.Any()).DoAndReturn(
-					func(r *farm.FarmInfraInfo) (bool, error) {
+				farmStore.EXPECT().Verify(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, r *farm.FarmInfraInfo) (bool, error) {
 						r.ID = 1
 						r.Name = "farm"
 						return true, nil
 					})
-				farmStore.EXPECT().GetActivePondsInFarm(uint(1)).Return([]uint{})
-				farmStore.EXPECT().Delete(gomock.Any()).Return(nil)
+				farmStore.EXPECT().GetActivePondsInFarm(gomock.Any(), uint(1)).Return([]uint{})
+				farmStore.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil)
 			},
 			want: DeleteDomainResponse{
 				Name: "farm",
@@ -216,14 +218,14 @@ func TestFarm_DeleteFarmInfo(t *testing.T) {
 				},
 			},
 			mockFunc: func() {
-				farmStore.EXPECT().Verify(gomock.Any()).DoAndReturn(
-					func(r *farm.FarmInfraInfo) (bool, error) {
+				farmStore.EXPECT().Verify(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, r *farm.FarmInfraInfo) (bool, error) {
 						r.ID = 1
 						r.Name = "farm"
 						return true, nil
 					})
-				farmStore.EXPECT().GetActivePondsInFarm(uint(1)).Return([]uint{})
-				farmStore.EXPECT().Delete(gomock.Any()).Return(fmt.Errorf("some error"))
+				farmStore.EXPECT().GetActivePondsInFarm(gomock.Any(), uint(1)).Return([]uint{})
+				farmStore.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(fmt.Errorf("some error"))
 			},
 			want:    DeleteDomainResponse{},
 			wantErr: true,
@@ -236,13 +238,13 @@ func TestFarm_DeleteFarmInfo(t *testing.T) {
 				},
 			},
 			mockFunc: func() {
-				farmStore.EXPECT().Verify(gomock.Any()).DoAndReturn(
-					func(r *farm.FarmInfraInfo) (bool, error) {
+				farmStore.EXPECT().Verify(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, r *farm.FarmInfraInfo) (bool, error) {
 						r.ID = 1
 						r.Name = "farm"
 						return true, nil
 					})
-				farmStore.EXPECT().GetActivePondsInFarm(uint(1)).Return([]uint{1, 2, 3})
+				farmStore.EXPECT().GetActivePondsInFarm(gomock.Any(), uint(1)).Return([]uint{1, 2, 3})
 			},
 			want:    DeleteDomainResponse{},
 			wantErr: true,
@@ -255,7 +257,7 @@ func TestFarm_DeleteFarmInfo(t *testing.T) {
 				},
 			},
 			mockFunc: func() {
-				farmStore.EXPECT().Verify(gomock.Any()).Return(false, nil)
+				farmStore.EXPECT().Verify(gomock.Any(), gomock.Any()).Return(false, nil)
 			},
 			want:    DeleteDomainResponse{},
 			wantErr: true,
@@ -268,7 +270,7 @@ func TestFarm_DeleteFarmInfo(t *testing.T) {
 				},
 			},
 			mockFunc: func() {
-				farmStore.EXPECT().Verify(gomock.Any()).Return(false, fmt.Errorf("some error"))
+				farmStore.EXPECT().Verify(gomock.Any(), gomock.Any()).Return(false, fmt.Errorf("some error"))
 			},
 			want:    DeleteDomainResponse{},
 			wantErr: true,
@@ -278,7 +280,7 @@ func TestFarm_DeleteFarmInfo(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockFunc()
 			s := NewFarmDomain(farmStore, pondStore)
-			got, err := s.DeleteFarmInfo(tt.args.r)
+			got, err := s.DeleteFarmInfo(context.Background(), tt.args.r)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Farm.DeleteFarmInfo() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -316,8 +318,8 @@ func TestFarm_UpdateFarmInfo(t *testing.T) {
 				},
 			},
 			mockFunc: func() {
-				farmStore.EXPECT().Verify(gomock.Any()).DoAndReturn(
-					func(r *farm.FarmInfraInfo) (bool, error) {
+				farmStore.EXPECT().Verify(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, r *farm.FarmInfraInfo) (bool, error) {
 						r.ID = 1
 						r.Name = "Name"
 						r.Area = "Area"
@@ -325,8 +327,8 @@ func TestFarm_UpdateFarmInfo(t *testing.T) {
 						r.Owner = "Owner"
 						return false, nil
 					})
-				farmStore.EXPECT().Create(gomock.Any()).DoAndReturn(
-					func(r *farm.FarmInfraInfo) error {
+				farmStore.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, r *farm.FarmInfraInfo) error {
 						r.ID = 1
 						return nil
 					})
@@ -351,13 +353,13 @@ func TestFarm_UpdateFarmInfo(t *testing.T) {
 				},
 			},
 			mockFunc: func() {
-				farmStore.EXPECT().Verify(gomock.Any()).DoAndReturn(
-					func(r *farm.FarmInfraInfo) (bool, error) {
+				farmStore.EXPECT().Verify(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, r *farm.FarmInfraInfo) (bool, error) {
 						r.ID = 1
 						return true, nil
 					})
-				farmStore.EXPECT().GetFarmByName(gomock.Any()).DoAndReturn(
-					func(r *farm.FarmInfraInfo) error {
+				farmStore.EXPECT().GetFarmByName(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, r *farm.FarmInfraInfo) error {
 						r.ID = 1
 						r.Name = "Name"
 						r.Area = "Area"
@@ -365,8 +367,8 @@ func TestFarm_UpdateFarmInfo(t *testing.T) {
 						r.Owner = "Owner"
 						return nil
 					})
-				farmStore.EXPECT().Update(gomock.Any()).DoAndReturn(
-					func(r *farm.FarmInfraInfo) error {
+				farmStore.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, r *farm.FarmInfraInfo) error {
 						r.ID = 1
 						r.Name = "Name"
 						r.Area = "Area"
@@ -395,13 +397,13 @@ func TestFarm_UpdateFarmInfo(t *testing.T) {
 				},
 			},
 			mockFunc: func() {
-				farmStore.EXPECT().Verify(gomock.Any()).DoAndReturn(
-					func(r *farm.FarmInfraInfo) (bool, error) {
+				farmStore.EXPECT().Verify(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, r *farm.FarmInfraInfo) (bool, error) {
 						r.ID = 1
 						return true, nil
 					})
-				farmStore.EXPECT().GetFarmByName(gomock.Any()).DoAndReturn(
-					func(r *farm.FarmInfraInfo) error {
+				farmStore.EXPECT().GetFarmByName(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, r *farm.FarmInfraInfo) error {
 						r.ID = 1
 						r.Name = "Name"
 						r.Area = "Area"
@@ -409,7 +411,7 @@ func TestFarm_UpdateFarmInfo(t *testing.T) {
 						r.Owner = "Owner"
 						return nil
 					})
-				farmStore.EXPECT().Update(gomock.Any()).Return(fmt.Errorf("some error"))
+				farmStore.EXPECT().Update(gomock.Any(), gomock.Any()).Return(fmt.Errorf("some error"))
 			},
 			want:    UpdateDomainResponse{},
 			wantErr: true,
@@ -425,12 +427,12 @@ func TestFarm_UpdateFarmInfo(t *testing.T) {
 				},
 			},
 			mockFunc: func() {
-				farmStore.EXPECT().Verify(gomock.Any()).DoAndReturn(
-					func(r *farm.FarmInfraInfo) (bool, error) {
+				farmStore.EXPECT().Verify(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, r *farm.FarmInfraInfo) (bool, error) {
 						r.ID = 1
 						return true, nil
 					})
-				farmStore.EXPECT().GetFarmByName(gomock.Any()).Return(fmt.Errorf("some error"))
+				farmStore.EXPECT().GetFarmByName(gomock.Any(), gomock.Any()).Return(fmt.Errorf("some error"))
 			},
 			want:    UpdateDomainResponse{},
 			wantErr: true,
@@ -446,7 +448,7 @@ func TestFarm_UpdateFarmInfo(t *testing.T) {
 				},
 			},
 			mockFunc: func() {
-				farmStore.EXPECT().Verify(gomock.Any()).Return(true, fmt.Errorf("some error"))
+				farmStore.EXPECT().Verify(gomock.Any(), gomock.Any()).Return(true, fmt.Errorf("some error"))
 			},
 			want:    UpdateDomainResponse{},
 			wantErr: true,
@@ -456,7 +458,7 @@ func TestFarm_UpdateFarmInfo(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockFunc()
 			s := NewFarmDomain(farmStore, pondStore)
-			got, err := s.UpdateFarmInfo(tt.args.r)
+			got, err := s.UpdateFarmInfo(context.Background(), tt.args.r)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Farm.UpdateFarmInfo() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -482,12 +484,13 @@ func TestFarm_GetFarmInfoByID(t *testing.T) {
 		args     args
 		want     GetFarmInfoResponse
 		wantErr  bool
+		errIs    error
 	}{
 		{
 			name: "success flow",
 			mockFunc: func() {
-				farmStore.EXPECT().GetFarmByID(gomock.Any()).DoAndReturn(
-					func(r *farm.FarmInfraInfo) error {
+				farmStore.EXPECT().GetFarmByID(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, r *farm.FarmInfraInfo) error {
 						r.Name = "name"
 						r.Area = "area"
 						r.ID = 1
@@ -495,16 +498,12 @@ func TestFarm_GetFarmInfoByID(t *testing.T) {
 						r.Owner = "owner"
 						return nil
 					})
-				pondStore.EXPECT().GetPondIDbyFarmID(gomock.Any()).Return([]uint{1}, nil)
-				pondStore.EXPECT().GetPondByID(gomock.Any()).DoAndReturn(func(r *pond.PondInfraInfo) error {
-					r.ID = 1
-					r.Name = "name"
-					r.Capacity = 1
-					r.Depth = 1
-					r.WaterQuality = 1
-					r.Species = "ikan"
-					return nil
-				})
+				pondStore.EXPECT().GetPondIDbyFarmID(gomock.Any(), gomock.Any()).Return([]uint{2, 1, 3}, nil)
+				// pond 3 is inactive
+				pondStore.EXPECT().GetPondsByIDs(gomock.Any(), []uint{2, 1, 3}).Return([]pond.PondInfraInfo{
+					{ID: 1, Name: "name", Capacity: 1, Depth: 1, WaterQuality: 1, Species: "ikan", FarmID: 1},
+					{ID: 2, Name: "name 2", Capacity: 2, Depth: 2, WaterQuality: 2, Species: "udang", FarmID: 1},
+				}, nil)
 			},
 			args: args{
 				ID: 1,
@@ -515,8 +514,16 @@ func TestFarm_GetFarmInfoByID(t *testing.T) {
 				Location: "location",
 				Owner:    "owner",
 				Area:     "area",
-				PondIDs:  []uint{1},
+				PondIDs:  []uint{2, 1, 3},
 				PondInfos: []PondInfo{
+					{
+						ID:           2,
+						Name:         "name 2",
+						Capacity:     2,
+						Depth:        2,
+						WaterQuality: 2,
+						Species:      "udang",
+					},
 					{
 						ID:           1,
 						Name:         "name",
@@ -531,8 +538,8 @@ func TestFarm_GetFarmInfoByID(t *testing.T) {
 		{
 			name: "error get pond id flow",
 			mockFunc: func() {
-				farmStore.EXPECT().GetFarmByID(gomock.Any()).DoAndReturn(
-					func(r *farm.FarmInfraInfo) error {
+				farmStore.EXPECT().GetFarmByID(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, r *farm.FarmInfraInfo) error {
 						r.Name = "name"
 						r.Area = "area"
 						r.ID = 1
@@ -540,7 +547,20 @@ func TestFarm_GetFarmInfoByID(t *testing.T) {
 						r.Owner = "owner"
 						return nil
 					})
-				pondStore.EXPECT().GetPondIDbyFarmID(gomock.Any()).Return([]uint{1}, fmt.Errorf("some error"))
+				pondStore.EXPECT().GetPondIDbyFarmID(gomock.Any(), gomock.Any()).Return([]uint{1}, fmt.Errorf("some error"))
+			},
+			args: args{
+				ID: 1,
+			},
+			want:    GetFarmInfoResponse{},
+			wantErr: true,
+		},
+		{
+			name: "error get ponds flow",
+			mockFunc: func() {
+				farmStore.EXPECT().GetFarmByID(gomock.Any(), gomock.Any()).Return(nil)
+				pondStore.EXPECT().GetPondIDbyFarmID(gomock.Any(), gomock.Any()).Return([]uint{1}, nil)
+				pondStore.EXPECT().GetPondsByIDs(gomock.Any(), []uint{1}).Return(nil, fmt.Errorf("some error"))
 			},
 			args: args{
 				ID: 1,
@@ -551,24 +571,39 @@ func TestFarm_GetFarmInfoByID(t *testing.T) {
 		{
 			name: "error get farm flow",
 			mockFunc: func() {
-				farmStore.EXPECT().GetFarmByID(gomock.Any()).Return(fmt.Errorf("some error"))
+				farmStore.EXPECT().GetFarmByID(gomock.Any(), gomock.Any()).Return(fmt.Errorf("some error"))
+			},
+			args: args{
+				ID: 1,
+			},
+			want:    GetFarmInfoResponse{},
+			wantErr: true,
+		},
+		{
+			name: "farm not found flow",
+			mockFunc: func() {
+				farmStore.EXPECT().GetFarmByID(gomock.Any(), gomock.Any()).Return(farm.ErrNotFound)
 			},
 			args: args{
 				ID: 1,
 			},
 			want:    GetFarmInfoResponse{},
 			wantErr: true,
+			errIs:   ErrInvalidFarm,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockFunc()
 			s := NewFarmDomain(farmStore, pondStore)
-			got, err := s.GetFarmInfoByID(tt.args.ID)
+			got, err := s.GetFarmInfoByID(context.Background(), tt.args.ID)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Farm.GetFarmInfoByID() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
+			if tt.errIs != nil && !errors.Is(err, tt.errIs) {
+				t.Errorf("Farm.GetFarmInfoByID() error = %v, want %v", err, tt.errIs)
+			}
 			if !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("Farm.GetFarmInfoByID() = %v, want %v", got, tt.want)
 			}
@@ -596,7 +631,7 @@ func TestFarm_GetFarm(t *testing.T) {
 		{
 			name: "success flow",
 			mockFunc: func() {
-				farmStore.EXPECT().GetFarmWithPaging(gomock.Any()).Return([]farm.FarmInfraInfo{
+				farmStore.EXPECT().GetFarmWithPaging(gomock.Any(), gomock.Any()).Return([]farm.FarmInfraInfo{
 					{
 						ID:       1,
 						Name:     "1",
@@ -612,8 +647,10 @@ func TestFarm_GetFarm(t *testing.T) {
 						Area:     "2",
 					},
 				}, nil)
-				pondStore.EXPECT().GetPondIDbyFarmID(uint(1)).Return([]uint{1, 2}, nil)
-				pondStore.EXPECT().GetPondIDbyFarmID(uint(2)).Return([]uint{3, 4}, nil)
+				pondStore.EXPECT().GetPondsByFarmIDs(gomock.Any(), []uint{1, 2}).Return(map[uint][]uint{
+					1: {1, 2},
+					2: {3, 4},
+				}, nil)
 			},
 			args: args{
 				size:   10,
@@ -643,7 +680,7 @@ func TestFarm_GetFarm(t *testing.T) {
 		{
 			name: "success flow with next cursor",
 			mockFunc: func() {
-				farmStore.EXPECT().GetFarmWithPaging(gomock.Any()).Return([]farm.FarmInfraInfo{
+				farmStore.EXPECT().GetFarmWithPaging(gomock.Any(), gomock.Any()).Return([]farm.FarmInfraInfo{
 					{
 						ID:       1,
 						Name:     "1",
@@ -659,8 +696,10 @@ func TestFarm_GetFarm(t *testing.T) {
 						Area:     "2",
 					},
 				}, nil)
-				pondStore.EXPECT().GetPondIDbyFarmID(uint(1)).Return([]uint{1, 2}, nil)
-				pondStore.EXPECT().GetPondIDbyFarmID(uint(2)).Return([]uint{3, 4}, nil)
+				pondStore.EXPECT().GetPondsByFarmIDs(gomock.Any(), []uint{1, 2}).Return(map[uint][]uint{
+					1: {1, 2},
+					2: {3, 4},
+				}, nil)
 			},
 			args: args{
 				size:   2,
@@ -688,9 +727,9 @@ func TestFarm_GetFarm(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name: "error GetPondIDbyFarmID",
+			name: "error GetPondsByFarmIDs",
 			mockFunc: func() {
-				farmStore.EXPECT().GetFarmWithPaging(gomock.Any()).Return([]farm.FarmInfraInfo{
+				farmStore.EXPECT().GetFarmWithPaging(gomock.Any(), gomock.Any()).Return([]farm.FarmInfraInfo{
 					{
 						ID:       1,
 						Name:     "1",
@@ -706,7 +745,7 @@ func TestFarm_GetFarm(t *testing.T) {
 						Area:     "2",
 					},
 				}, nil)
-				pondStore.EXPECT().GetPondIDbyFarmID(gomock.Any()).Return([]uint{}, fmt.Errorf("some error"))
+				pondStore.EXPECT().GetPondsByFarmIDs(gomock.Any(), gomock.Any()).Return(map[uint][]uint{}, fmt.Errorf("some error"))
 			},
 			args: args{
 				size:   10,
@@ -718,7 +757,7 @@ func TestFarm_GetFarm(t *testing.T) {
 		{
 			name: "Error GetFarmWithPaging",
 			mockFunc: func() {
-				farmStore.EXPECT().GetFarmWithPaging(gomock.Any()).Return([]farm.FarmInfraInfo{
+				farmStore.EXPECT().GetFarmWithPaging(gomock.Any(), gomock.Any()).Return([]farm.FarmInfraInfo{
 					{
 						ID:       1,
 						Name:     "1",
@@ -747,7 +786,7 @@ func TestFarm_GetFarm(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockFunc()
 			s := NewFarmDomain(farmStore, pondStore)
-			got, got1, err := s.GetFarm(tt.args.size, tt.args.cursor)
+			got, got1, err := s.GetFarm(context.Background(), tt.args.size, tt.args.cursor)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Farm.GetFarm() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -780,21 +819,21 @@ func TestFarm_DeleteFarmsWithDependencies(t *testing.T) {
 		{
 			name: "success flow",
 			mockFunc: func() {
-				farmStore.EXPECT().Verify(gomock.Any()).DoAndReturn(
-					func(r *farm.FarmInfraInfo) (bool, error) {
+				farmStore.EXPECT().Verify(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, r *farm.FarmInfraInfo) (bool, error) {
 						r.ID = 1
 						r.Name = "farm"
 						return true, nil
 					})
-				farmStore.EXPECT().GetActivePondsInFarm(uint(1)).Return([]uint{1})
-				pondStore.EXPECT().Verify(gomock.Any()).DoAndReturn(
-					func(r *pond.PondInfraInfo) (bool, error) {
+				farmStore.EXPECT().GetActivePondsInFarm(gomock.Any(), uint(1)).Return([]uint{1})
+				pondStore.EXPECT().Verify(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, r *pond.PondInfraInfo) (bool, error) {
 						r.ID = 1
 						r.Name = "pond"
 						return true, nil
 					})
-				pondStore.EXPECT().Delete(gomock.Any()).Return(nil)
-				farmStore.EXPECT().Delete(gomock.Any()).Return(nil)
+				pondStore.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil)
+				farmStore.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil)
 			},
 			args: args{
 				ID: 1,
@@ -809,21 +848,21 @@ func TestFarm_DeleteFarmsWithDependencies(t *testing.T) {
 		{
 			name: "error delete farm flow",
 			mockFunc: func() {
-				farmStore.EXPECT().Verify(gomock.Any()).DoAndReturn(
-					func(r *farm.FarmInfraInfo) (bool, error) {
+				farmStore.EXPECT().Verify(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, r *farm.FarmInfraInfo) (bool, error) {
 						r.ID = 1
 						r.Name = "farm"
 						return true, nil
 					})
-				farmStore.EXPECT().GetActivePondsInFarm(uint(1)).Return([]uint{1})
-				pondStore.EXPECT().Verify(gomock.Any()).DoAndReturn(
-					func(r *pond.PondInfraInfo) (bool, error) {
+				farmStore.EXPECT().GetActivePondsInFarm(gomock.Any(), uint(1)).Return([]uint{1})
+				pondStore.EXPECT().Verify(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, r *pond.PondInfraInfo) (bool, error) {
 						r.ID = 1
 						r.Name = "pond"
 						return true, nil
 					})
-				pondStore.EXPECT().Delete(gomock.Any()).Return(nil)
-				farmStore.EXPECT().Delete(gomock.Any()).Return(fmt.Errorf("some error"))
+				pondStore.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil)
+				farmStore.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(fmt.Errorf("some error"))
 			},
 			args: args{
 				ID: 1,
@@ -834,20 +873,20 @@ func TestFarm_DeleteFarmsWithDependencies(t *testing.T) {
 		{
 			name: "error pond flow",
 			mockFunc: func() {
-				farmStore.EXPECT().Verify(gomock.Any()).DoAndReturn(
-					func(r *farm.FarmInfraInfo) (bool, error) {
+				farmStore.EXPECT().Verify(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, r *farm.FarmInfraInfo) (bool, error) {
 						r.ID = 1
 						r.Name = "farm"
 						return true, nil
 					})
-				farmStore.EXPECT().GetActivePondsInFarm(uint(1)).Return([]uint{1})
-				pondStore.EXPECT().Verify(gomock.Any()).DoAndReturn(
-					func(r *pond.PondInfraInfo) (bool, error) {
+				farmStore.EXPECT().GetActivePondsInFarm(gomock.Any(), uint(1)).Return([]uint{1})
+				pondStore.EXPECT().Verify(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, r *pond.PondInfraInfo) (bool, error) {
 						r.ID = 1
 						r.Name = "pond"
 						return true, nil
 					})
-				pondStore.EXPECT().Delete(gomock.Any()).Return(fmt.Errorf("some error"))
+				pondStore.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(fmt.Errorf("some error"))
 			},
 			args: args{
 				ID: 1,
@@ -858,14 +897,14 @@ func TestFarm_DeleteFarmsWithDependencies(t *testing.T) {
 		{
 			name: "error verify pond flow",
 			mockFunc: func() {
-				farmStore.EXPECT().Verify(gomock.Any()).DoAndReturn(
-					func(r *farm.FarmInfraInfo) (bool, error) {
+				farmStore.EXPECT().Verify(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, r *farm.FarmInfraInfo) (bool, error) {
 						r.ID = 1
 						r.Name = "farm"
 						return true, nil
 					})
-				farmStore.EXPECT().GetActivePondsInFarm(uint(1)).Return([]uint{1})
-				pondStore.EXPECT().Verify(gomock.Any()).Return(false, fmt.Errorf("some error"))
+				farmStore.EXPECT().GetActivePondsInFarm(gomock.Any(), uint(1)).Return([]uint{1})
+				pondStore.EXPECT().Verify(gomock.Any(), gomock.Any()).Return(false, fmt.Errorf("some error"))
 			},
 			args: args{
 				ID: 1,
@@ -878,7 +917,7 @@ func TestFarm_DeleteFarmsWithDependencies(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockFunc()
 			s := NewFarmDomain(farmStore, pondStore)
-			got, err := s.DeleteFarmsWithDependencies(tt.args.ID)
+			got, err := s.DeleteFarmsWithDependencies(context.Background(), tt.args.ID)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Farm.DeleteFarmsWithDependencies() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -889,3 +928,91 @@ func TestFarm_DeleteFarmsWithDependencies(t *testing.T) {
 		})
 	}
 }
+
+func TestFarm_GetFarmSummary(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	farmStore := mock_farm.NewMockFarmStore(mockCtrl)
+	pondStore := mock_pond.NewMockPondStore(mockCtrl)
+	tests := []struct {
+		name     string
+		mockFunc func()
+		want     FarmSummaryResponse
+		wantErr  bool
+	}{
+		{
+			name: "success flow",
+			mockFunc: func() {
+				farmStore.EXPECT().GetPondCountPerFarm(gomock.Any()).Return(map[uint]int{1: 3, 2: 0, 3: 10}, nil)
+				pondStore.EXPECT().CountActivePonds(gomock.Any()).Return(14, nil)
+			},
+			want: FarmSummaryResponse{
+				ActiveFarms:  3,
+				ActivePonds:  14,
+				PondsPerFarm: []int{0, 3, 10},
+			},
+			wantErr: false,
+		},
+		{
+			name: "error count pond per farm flow",
+			mockFunc: func() {
+				farmStore.EXPECT().GetPondCountPerFarm(gomock.Any()).Return(nil, fmt.Errorf("some error"))
+			},
+			want:    FarmSummaryResponse{},
+			wantErr: true,
+		},
+		{
+			name: "error count active ponds flow",
+			mockFunc: func() {
+				farmStore.EXPECT().GetPondCountPerFarm(gomock.Any()).Return(map[uint]int{1: 3}, nil)
+				pondStore.EXPECT().CountActivePonds(gomock.Any()).Return(0, fmt.Errorf("some error"))
+			},
+			want:    FarmSummaryResponse{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			f := NewFarmDomain(farmStore, pondStore)
+			got, err := f.GetFarmSummary(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Farm.GetFarmSummary() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Farm.GetFarmSummary() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_mapStoreError(t *testing.T) {
+	someErr := fmt.Errorf("some error")
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{
+			name: "duplicate name",
+			err:  farm.ErrDuplicateName,
+			want: ErrDuplicateFarm,
+		},
+		{
+			name: "other error",
+			err:  someErr,
+			want: someErr,
+		},
+		{
+			name: "nil error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mapStoreError(tt.err); got != tt.want {
+				t.Errorf("mapStoreError() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
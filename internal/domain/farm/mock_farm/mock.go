@@ -6,6 +6,7 @@ package mock_farm
 
 import (
 	farm "aqua-farm-manager/internal/domain/farm"
+	context "context"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -35,54 +36,54 @@ func (m *MockFarmDomain) EXPECT() *MockFarmDomainMockRecorder {
 }
 
 // CreateFarmInfo mocks base method.
-func (m *MockFarmDomain) CreateFarmInfo(r farm.CreateDomainRequest) (farm.CreateDomainResponse, error) {
+func (m *MockFarmDomain) CreateFarmInfo(ctx context.Context, r farm.CreateDomainRequest) (farm.CreateDomainResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateFarmInfo", r)
+	ret := m.ctrl.Call(m, "CreateFarmInfo", ctx, r)
 	ret0, _ := ret[0].(farm.CreateDomainResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreateFarmInfo indicates an expected call of CreateFarmInfo.
-func (mr *MockFarmDomainMockRecorder) CreateFarmInfo(r interface{}) *gomock.Call {
+func (mr *MockFarmDomainMockRecorder) CreateFarmInfo(ctx, r interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFarmInfo", reflect.TypeOf((*MockFarmDomain)(nil).CreateFarmInfo), r)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFarmInfo", reflect.TypeOf((*MockFarmDomain)(nil).CreateFarmInfo), ctx, r)
 }
 
 // DeleteFarmInfo mocks base method.
-func (m *MockFarmDomain) DeleteFarmInfo(r farm.DeleteDomainRequest) (farm.DeleteDomainResponse, error) {
+func (m *MockFarmDomain) DeleteFarmInfo(ctx context.Context, r farm.DeleteDomainRequest) (farm.DeleteDomainResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteFarmInfo", r)
+	ret := m.ctrl.Call(m, "DeleteFarmInfo", ctx, r)
 	ret0, _ := ret[0].(farm.DeleteDomainResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // DeleteFarmInfo indicates an expected call of DeleteFarmInfo.
-func (mr *MockFarmDomainMockRecorder) DeleteFarmInfo(r interface{}) *gomock.Call {
+func (mr *MockFarmDomainMockRecorder) DeleteFarmInfo(ctx, r interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFarmInfo", reflect.TypeOf((*MockFarmDomain)(nil).DeleteFarmInfo), r)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFarmInfo", reflect.TypeOf((*MockFarmDomain)(nil).DeleteFarmInfo), ctx, r)
 }
 
 // DeleteFarmsWithDependencies mocks base method.
-func (m *MockFarmDomain) DeleteFarmsWithDependencies(ID uint) (farm.DeleteAllResponse, error) {
+func (m *MockFarmDomain) DeleteFarmsWithDependencies(ctx context.Context, ID uint) (farm.DeleteAllResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteFarmsWithDependencies", ID)
+	ret := m.ctrl.Call(m, "DeleteFarmsWithDependencies", ctx, ID)
 	ret0, _ := ret[0].(farm.DeleteAllResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // DeleteFarmsWithDependencies indicates an expected call of DeleteFarmsWithDependencies.
-func (mr *MockFarmDomainMockRecorder) DeleteFarmsWithDependencies(ID interface{}) *gomock.Call {
+func (mr *MockFarmDomainMockRecorder) DeleteFarmsWithDependencies(ctx, ID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFarmsWithDependencies", reflect.TypeOf((*MockFarmDomain)(nil).DeleteFarmsWithDependencies), ID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFarmsWithDependencies", reflect.TypeOf((*MockFarmDomain)(nil).DeleteFarmsWithDependencies), ctx, ID)
 }
 
 // GetFarm mocks base method.
-func (m *MockFarmDomain) GetFarm(size, cursor int) ([]farm.GetFarmInfoResponse, int, error) {
+func (m *MockFarmDomain) GetFarm(ctx context.Context, size, cursor int) ([]farm.GetFarmInfoResponse, int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetFarm", size, cursor)
+	ret := m.ctrl.Call(m, "GetFarm", ctx, size, cursor)
 	ret0, _ := ret[0].([]farm.GetFarmInfoResponse)
 	ret1, _ := ret[1].(int)
 	ret2, _ := ret[2].(error)
@@ -90,37 +91,52 @@ func (m *MockFarmDomain) GetFarm(size, cursor int) ([]farm.GetFarmInfoResponse,
 }
 
 // GetFarm indicates an expected call of GetFarm.
-func (mr *MockFarmDomainMockRecorder) GetFarm(size, cursor interface{}) *gomock.Call {
+func (mr *MockFarmDomainMockRecorder) GetFarm(ctx, size, cursor interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFarm", reflect.TypeOf((*MockFarmDomain)(nil).GetFarm), size, cursor)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFarm", reflect.TypeOf((*MockFarmDomain)(nil).GetFarm), ctx, size, cursor)
 }
 
 // GetFarmInfoByID mocks base method.
-func (m *MockFarmDomain) GetFarmInfoByID(ID uint) (farm.GetFarmInfoResponse, error) {
+func (m *MockFarmDomain) GetFarmInfoByID(ctx context.Context, ID uint) (farm.GetFarmInfoResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetFarmInfoByID", ID)
+	ret := m.ctrl.Call(m, "GetFarmInfoByID", ctx, ID)
 	ret0, _ := ret[0].(farm.GetFarmInfoResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetFarmInfoByID indicates an expected call of GetFarmInfoByID.
-func (mr *MockFarmDomainMockRecorder) GetFarmInfoByID(ID interface{}) *gomock.Call {
+func (mr *MockFarmDomainMockRecorder) GetFarmInfoByID(ctx, ID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFarmInfoByID", reflect.TypeOf((*MockFarmDomain)(nil).GetFarmInfoByID), ID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFarmInfoByID", reflect.TypeOf((*MockFarmDomain)(nil).GetFarmInfoByID), ctx, ID)
+}
+
+// GetFarmSummary mocks base method.
+func (m *MockFarmDomain) GetFarmSummary(ctx context.Context) (farm.FarmSummaryResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFarmSummary", ctx)
+	ret0, _ := ret[0].(farm.FarmSummaryResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFarmSummary indicates an expected call of GetFarmSummary.
+func (mr *MockFarmDomainMockRecorder) GetFarmSummary(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFarmSummary", reflect.TypeOf((*MockFarmDomain)(nil).GetFarmSummary), ctx)
 }
 
 // UpdateFarmInfo mocks base method.
-func (m *MockFarmDomain) UpdateFarmInfo(r farm.UpdateDomainRequest) (farm.UpdateDomainResponse, error) {
+func (m *MockFarmDomain) UpdateFarmInfo(ctx context.Context, r farm.UpdateDomainRequest) (farm.UpdateDomainResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateFarmInfo", r)
+	ret := m.ctrl.Call(m, "UpdateFarmInfo", ctx, r)
 	ret0, _ := ret[0].(farm.UpdateDomainResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateFarmInfo indicates an expected call of UpdateFarmInfo.
-func (mr *MockFarmDomainMockRecorder) UpdateFarmInfo(r interface{}) *gomock.Call {
+func (mr *MockFarmDomainMockRecorder) UpdateFarmInfo(ctx, r interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFarmInfo", reflect.TypeOf((*MockFarmDomain)(nil).UpdateFarmInfo), r)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateFarmInfo", reflect.TypeOf((*MockFarmDomain)(nil).UpdateFarmInfo), ctx, r)
 }
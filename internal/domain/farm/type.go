@@ -1,14 +1,14 @@
 package farm
 
 import (
-	"errors"
+	"aqua-farm-manager/pkg/apperror"
 )
 
 // list Domain error
 var (
-	ErrDuplicateFarm = errors.New("Farm Already Exists")
-	ErrInvalidFarm   = errors.New("Farm Is Not Exists")
-	ErrExistsPonds   = errors.New("Cannot Delete Farm While Ponds Is Exists")
+	ErrDuplicateFarm = apperror.New(apperror.CodeFarmAlreadyExists, "Farm Already Exists")
+	ErrInvalidFarm   = apperror.New(apperror.CodeFarmNotFound, "Farm Is Not Exists")
+	ErrExistsPonds   = apperror.New(apperror.CodeFarmHasPonds, "Cannot Delete Farm While Ponds Is Exists")
 )
 
 // CreateDomainRequest struct is list parameter for Create Farm domain
@@ -80,3 +80,10 @@ type PondInfo struct {
 	WaterQuality float64
 	Species      string
 }
+
+// FarmSummaryResponse struct is list parameter response for GetFarmSummary domain
+type FarmSummaryResponse struct {
+	ActiveFarms  int
+	ActivePonds  int
+	PondsPerFarm []int
+}
@@ -0,0 +1,266 @@
+package importer
+
+import (
+	"aqua-farm-manager/internal/domain/event"
+	farmdomain "aqua-farm-manager/internal/domain/farm"
+	ponddomain "aqua-farm-manager/internal/domain/pond"
+	"aqua-farm-manager/internal/infrastructure/farm"
+	"aqua-farm-manager/internal/infrastructure/outbox"
+	"aqua-farm-manager/internal/infrastructure/pond"
+	"context"
+	"errors"
+)
+
+// ImportDomain is list method for Import domain
+type ImportDomain interface {
+	ImportFarms(ctx context.Context, r ImportFarmsRequest) (ImportResponse, error)
+	ImportPonds(ctx context.Context, r ImportPondsRequest) (ImportResponse, error)
+}
+
+// Importer is list dependencies import domain
+type Importer struct {
+	farmstore farm.FarmStore
+	pondstore pond.PondStore
+}
+
+// NewImportDomain is func to generate ImportDomain interface
+func NewImportDomain(farmstore farm.FarmStore, pondstore pond.PondStore) ImportDomain {
+	return &Importer{
+		farmstore: farmstore,
+		pondstore: pondstore,
+	}
+}
+
+// ImportFarms is func to validate every farm row with the rule of farm domain then create the valid rows
+// in a single transaction, nothing is created on dry run or when any row is invalid on atomic import
+func (i *Importer) ImportFarms(ctx context.Context, r ImportFarmsRequest) (ImportResponse, error) {
+	res := ImportResponse{
+		Results: make([]RowResult, len(r.Rows)),
+	}
+
+	var valid []*farm.FarmInfraInfo
+	var validIdx []int
+	names := make(map[string]bool, len(r.Rows))
+	for idx, row := range r.Rows {
+		res.Results[idx].Line = row.Line
+
+		if names[row.Name] {
+			res.Results[idx].Err = farmdomain.ErrDuplicateFarm
+			continue
+		}
+		names[row.Name] = true
+
+		exists, err := i.farmstore.Verify(ctx, &farm.FarmInfraInfo{
+			Name: row.Name,
+		})
+		if err != nil {
+			return ImportResponse{}, err
+		}
+
+		if exists {
+			res.Results[idx].Err = farmdomain.ErrDuplicateFarm
+			continue
+		}
+
+		farmInfra := mapFarmRow(row)
+		farmInfra.Events = []outbox.Event{
+			event.NewFarmEvent(event.FarmCreated, event.FarmData{
+				Name:     farmInfra.Name,
+				Location: farmInfra.Location,
+				Owner:    farmInfra.Owner,
+				Area:     farmInfra.Area,
+			}),
+		}
+		valid = append(valid, farmInfra)
+		validIdx = append(validIdx, idx)
+	}
+
+	if !shouldApply(r.DryRun, r.Atomic, len(valid), len(r.Rows)) {
+		return res, nil
+	}
+
+	err := i.farmstore.BulkCreate(ctx, valid)
+	if err != nil {
+		if errors.Is(err, farm.ErrDuplicateName) {
+			return ImportResponse{}, farmdomain.ErrDuplicateFarm
+		}
+		return ImportResponse{}, err
+	}
+
+	for n, idx := range validIdx {
+		res.Results[idx].ID = valid[n].ID
+	}
+	res.Applied = true
+
+	return res, nil
+}
+
+func mapFarmRow(r FarmRow) *farm.FarmInfraInfo {
+	return &farm.FarmInfraInfo{
+		Name:     r.Name,
+		Location: r.Location,
+		Owner:    r.Owner,
+		Area:     r.Area,
+	}
+}
+
+// ImportPonds is func to validate every pond row with the rule of pond domain then create the valid rows
+// in a single transaction, the pond limit of a farm count the active ponds and the valid rows before the row
+func (i *Importer) ImportPonds(ctx context.Context, r ImportPondsRequest) (ImportResponse, error) {
+	res := ImportResponse{
+		Results: make([]RowResult, len(r.Rows)),
+	}
+
+	var valid []*pond.PondInfraInfo
+	var validIdx []int
+	names := make(map[string]bool, len(r.Rows))
+	counts := make(map[uint]int)
+	farms := newFarmResolver(i.farmstore)
+	for idx, row := range r.Rows {
+		res.Results[idx].Line = row.Line
+
+		farmID, err := farms.resolve(ctx, row)
+		if err != nil {
+			return ImportResponse{}, err
+		}
+
+		if farmID == 0 {
+			res.Results[idx].Err = ponddomain.ErrInvalidFarm
+			continue
+		}
+
+		if names[row.Name] {
+			res.Results[idx].Err = ponddomain.ErrDuplicatePond
+			continue
+		}
+		names[row.Name] = true
+
+		exists, err := i.pondstore.Verify(ctx, &pond.PondInfraInfo{
+			Name: row.Name,
+		})
+		if err != nil {
+			return ImportResponse{}, err
+		}
+
+		if exists {
+			res.Results[idx].Err = ponddomain.ErrDuplicatePond
+			continue
+		}
+
+		count, ok := counts[farmID]
+		if !ok {
+			count = len(i.farmstore.GetActivePondsInFarm(ctx, farmID))
+		}
+		if count >= ponddomain.MaxPondInFarm {
+			res.Results[idx].Err = ponddomain.ErrMaxPond
+			continue
+		}
+		counts[farmID] = count + 1
+
+		pondInfra := mapPondRow(row, farmID)
+		pondInfra.Events = []outbox.Event{
+			event.NewPondEvent(event.PondCreated, event.PondData{
+				Name:         pondInfra.Name,
+				Capacity:     pondInfra.Capacity,
+				Depth:        pondInfra.Depth,
+				WaterQuality: pondInfra.WaterQuality,
+				Species:      pondInfra.Species,
+				FarmID:       pondInfra.FarmID,
+			}),
+		}
+		valid = append(valid, pondInfra)
+		validIdx = append(validIdx, idx)
+	}
+
+	if !shouldApply(r.DryRun, r.Atomic, len(valid), len(r.Rows)) {
+		return res, nil
+	}
+
+	err := i.pondstore.BulkCreate(ctx, valid)
+	if err != nil {
+		switch {
+		case errors.Is(err, pond.ErrDuplicateName):
+			return ImportResponse{}, ponddomain.ErrDuplicatePond
+		case errors.Is(err, pond.ErrInvalidFarm):
+			return ImportResponse{}, ponddomain.ErrInvalidFarm
+		}
+		return ImportResponse{}, err
+	}
+
+	for n, idx := range validIdx {
+		res.Results[idx].ID = valid[n].ID
+	}
+	res.Applied = true
+
+	return res, nil
+}
+
+func mapPondRow(r PondRow, farmID uint) *pond.PondInfraInfo {
+	return &pond.PondInfraInfo{
+		Name:         r.Name,
+		Capacity:     r.Capacity,
+		Depth:        r.Depth,
+		WaterQuality: r.WaterQuality,
+		Species:      r.Species,
+		FarmID:       farmID,
+	}
+}
+
+// shouldApply is func to check the valid rows is created, atomic import create nothing when any row is invalid
+func shouldApply(dryRun, atomic bool, numValid, numRows int) bool {
+	if dryRun || numValid == 0 {
+		return false
+	}
+	return !atomic || numValid == numRows
+}
+
+// farmResolver struct is list active farm id that is already verified by id or by name
+type farmResolver struct {
+	farmstore farm.FarmStore
+	byID      map[uint]uint
+	byName    map[string]uint
+}
+
+func newFarmResolver(farmstore farm.FarmStore) *farmResolver {
+	return &farmResolver{
+		farmstore: farmstore,
+		byID:      make(map[uint]uint),
+		byName:    make(map[string]uint),
+	}
+}
+
+// resolve is func to get id of active farm of the row, the id is 0 when the farm is not exists
+func (f *farmResolver) resolve(ctx context.Context, row PondRow) (uint, error) {
+	if row.FarmID > 0 {
+		if id, ok := f.byID[row.FarmID]; ok {
+			return id, nil
+		}
+	} else if len(row.FarmName) > 0 {
+		if id, ok := f.byName[row.FarmName]; ok {
+			return id, nil
+		}
+	} else {
+		return 0, nil
+	}
+
+	verify := farm.FarmInfraInfo{
+		ID:   row.FarmID,
+		Name: row.FarmName,
+	}
+	exists, err := f.farmstore.Verify(ctx, &verify)
+	if err != nil {
+		return 0, err
+	}
+
+	var id uint
+	if exists {
+		id = verify.ID
+	}
+	if row.FarmID > 0 {
+		f.byID[row.FarmID] = id
+	} else {
+		f.byName[row.FarmName] = id
+	}
+
+	return id, nil
+}
@@ -0,0 +1,272 @@
+package importer
+
+import (
+	farmdomain "aqua-farm-manager/internal/domain/farm"
+	ponddomain "aqua-farm-manager/internal/domain/pond"
+	"aqua-farm-manager/internal/infrastructure/farm"
+	"aqua-farm-manager/internal/infrastructure/farm/mock_farm"
+	"aqua-farm-manager/internal/infrastructure/pond"
+	"aqua-farm-manager/internal/infrastructure/pond/mock_pond"
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestNewImportDomain(t *testing.T) {
+	want := &Importer{
+		farmstore: &farm.Farm{},
+		pondstore: &pond.Pond{},
+	}
+	if got := NewImportDomain(&farm.Farm{}, &pond.Pond{}); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewImportDomain() = %v, want %v", got, want)
+	}
+}
+
+func TestImporter_ImportFarms(t *testing.T) {
+	// every case has its own stores because verify of farm is expected any times
+	var farmStore *mock_farm.MockFarmStore
+	var pondStore *mock_pond.MockPondStore
+
+	rows := []FarmRow{
+		{Line: 2, Name: "farm a"},
+		{Line: 3, Name: "farm b"},
+		{Line: 4, Name: "farm a"},
+	}
+	verify := func() {
+		farmStore.EXPECT().Verify(gomock.Any(), &farm.FarmInfraInfo{Name: "farm a"}).Return(false, nil)
+		farmStore.EXPECT().Verify(gomock.Any(), &farm.FarmInfraInfo{Name: "farm b"}).Return(true, nil)
+	}
+	invalid := []RowResult{
+		{Line: 2},
+		{Line: 3, Err: farmdomain.ErrDuplicateFarm},
+		{Line: 4, Err: farmdomain.ErrDuplicateFarm},
+	}
+	tests := []struct {
+		name     string
+		mockFunc func()
+		r        ImportFarmsRequest
+		want     ImportResponse
+		wantErr  error
+	}{
+		{
+			name: "partial import create the valid rows",
+			mockFunc: func() {
+				verify()
+				farmStore.EXPECT().BulkCreate(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, list []*farm.FarmInfraInfo) error {
+						if len(list) != 1 || list[0].Name != "farm a" || len(list[0].Events) != 1 {
+							t.Errorf("BulkCreate() list = %v, want farm a with created event", list)
+						}
+						list[0].ID = 7
+						return nil
+					})
+			},
+			r: ImportFarmsRequest{Rows: rows},
+			want: ImportResponse{
+				Results: []RowResult{
+					{Line: 2, ID: 7},
+					{Line: 3, Err: farmdomain.ErrDuplicateFarm},
+					{Line: 4, Err: farmdomain.ErrDuplicateFarm},
+				},
+				Applied: true,
+			},
+		},
+		{
+			name:     "atomic import with invalid row create nothing",
+			mockFunc: verify,
+			r:        ImportFarmsRequest{Rows: rows, Atomic: true},
+			want:     ImportResponse{Results: invalid},
+		},
+		{
+			name:     "dry run create nothing",
+			mockFunc: verify,
+			r:        ImportFarmsRequest{Rows: rows, DryRun: true},
+			want:     ImportResponse{Results: invalid},
+		},
+		{
+			name: "error when verify farm",
+			mockFunc: func() {
+				farmStore.EXPECT().Verify(gomock.Any(), gomock.Any()).Return(false, errors.New("some error"))
+			},
+			r:       ImportFarmsRequest{Rows: rows},
+			wantErr: errors.New("some error"),
+		},
+		{
+			name: "duplicate name of the store is mapped into domain error",
+			mockFunc: func() {
+				verify()
+				farmStore.EXPECT().BulkCreate(gomock.Any(), gomock.Any()).Return(farm.ErrDuplicateName)
+			},
+			r:       ImportFarmsRequest{Rows: rows},
+			wantErr: farmdomain.ErrDuplicateFarm,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			farmStore = mock_farm.NewMockFarmStore(mockCtrl)
+			pondStore = mock_pond.NewMockPondStore(mockCtrl)
+			tt.mockFunc()
+			i := NewImportDomain(farmStore, pondStore)
+			got, err := i.ImportFarms(context.Background(), tt.r)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Fatalf("Importer.ImportFarms() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Importer.ImportFarms() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestImporter_ImportPonds(t *testing.T) {
+	// every case has its own stores because verify of farm is expected any times
+	var farmStore *mock_farm.MockFarmStore
+	var pondStore *mock_pond.MockPondStore
+
+	// farm 1 is found by id and by name, farm 9 does not exist
+	verifyFarm := func() {
+		farmStore.EXPECT().Verify(gomock.Any(), gomock.Any()).DoAndReturn(
+			func(ctx context.Context, r *farm.FarmInfraInfo) (bool, error) {
+				if r.ID == 1 || r.Name == "farm a" {
+					r.ID, r.Name = 1, "farm a"
+					return true, nil
+				}
+				return false, nil
+			}).AnyTimes()
+	}
+	tests := []struct {
+		name     string
+		mockFunc func()
+		r        ImportPondsRequest
+		want     ImportResponse
+		wantErr  error
+	}{
+		{
+			name: "every rule is checked per row",
+			mockFunc: func() {
+				verifyFarm()
+				pondStore.EXPECT().Verify(gomock.Any(), &pond.PondInfraInfo{Name: "pond a"}).Return(false, nil)
+				pondStore.EXPECT().Verify(gomock.Any(), &pond.PondInfraInfo{Name: "pond b"}).Return(true, nil)
+				pondStore.EXPECT().Verify(gomock.Any(), &pond.PondInfraInfo{Name: "pond c"}).Return(false, nil)
+				// farm 1 has room for one more pond, pond a fill it and pond c is over the limit
+				farmStore.EXPECT().GetActivePondsInFarm(gomock.Any(), uint(1)).Return(make([]uint, ponddomain.MaxPondInFarm-1)).Times(1)
+				pondStore.EXPECT().BulkCreate(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, list []*pond.PondInfraInfo) error {
+						if len(list) != 1 || list[0].Name != "pond a" || list[0].FarmID != 1 || len(list[0].Events) != 1 {
+							t.Errorf("BulkCreate() list = %v, want pond a of farm 1 with created event", list)
+						}
+						list[0].ID = 5
+						return nil
+					})
+			},
+			r: ImportPondsRequest{Rows: []PondRow{
+				{Line: 2, Name: "pond a", FarmName: "farm a"},
+				{Line: 3, Name: "pond a", FarmID: 1},
+				{Line: 4, Name: "pond b", FarmID: 1},
+				{Line: 5, Name: "pond c", FarmID: 1},
+				{Line: 6, Name: "pond d", FarmID: 9},
+				{Line: 7, Name: "pond e"},
+			}},
+			want: ImportResponse{
+				Results: []RowResult{
+					{Line: 2, ID: 5},
+					{Line: 3, Err: ponddomain.ErrDuplicatePond},
+					{Line: 4, Err: ponddomain.ErrDuplicatePond},
+					{Line: 5, Err: ponddomain.ErrMaxPond},
+					{Line: 6, Err: ponddomain.ErrInvalidFarm},
+					{Line: 7, Err: ponddomain.ErrInvalidFarm},
+				},
+				Applied: true,
+			},
+		},
+		{
+			name: "farm with max pond reject the first row",
+			mockFunc: func() {
+				verifyFarm()
+				pondStore.EXPECT().Verify(gomock.Any(), gomock.Any()).Return(false, nil)
+				farmStore.EXPECT().GetActivePondsInFarm(gomock.Any(), uint(1)).Return(make([]uint, ponddomain.MaxPondInFarm))
+			},
+			r: ImportPondsRequest{Rows: []PondRow{{Line: 1, Name: "pond a", FarmID: 1}}},
+			want: ImportResponse{
+				Results: []RowResult{{Line: 1, Err: ponddomain.ErrMaxPond}},
+			},
+		},
+		{
+			name: "atomic import with every row valid",
+			mockFunc: func() {
+				verifyFarm()
+				pondStore.EXPECT().Verify(gomock.Any(), gomock.Any()).Return(false, nil).Times(2)
+				farmStore.EXPECT().GetActivePondsInFarm(gomock.Any(), uint(1)).Return(nil).Times(1)
+				pondStore.EXPECT().BulkCreate(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(ctx context.Context, list []*pond.PondInfraInfo) error {
+						list[0].ID, list[1].ID = 5, 6
+						return nil
+					})
+			},
+			r: ImportPondsRequest{Rows: []PondRow{
+				{Line: 1, Name: "pond a", FarmID: 1},
+				{Line: 2, Name: "pond b", FarmID: 1},
+			}, Atomic: true},
+			want: ImportResponse{
+				Results: []RowResult{{Line: 1, ID: 5}, {Line: 2, ID: 6}},
+				Applied: true,
+			},
+		},
+		{
+			name: "atomic import with invalid row create nothing",
+			mockFunc: func() {
+				verifyFarm()
+				pondStore.EXPECT().Verify(gomock.Any(), gomock.Any()).Return(false, nil)
+				farmStore.EXPECT().GetActivePondsInFarm(gomock.Any(), uint(1)).Return(nil)
+			},
+			r: ImportPondsRequest{Rows: []PondRow{
+				{Line: 1, Name: "pond a", FarmID: 1},
+				{Line: 2, Name: "pond b", FarmID: 9},
+			}, Atomic: true},
+			want: ImportResponse{
+				Results: []RowResult{{Line: 1}, {Line: 2, Err: ponddomain.ErrInvalidFarm}},
+			},
+		},
+		{
+			name: "error when verify farm",
+			mockFunc: func() {
+				farmStore.EXPECT().Verify(gomock.Any(), gomock.Any()).Return(false, errors.New("some error"))
+			},
+			r:       ImportPondsRequest{Rows: []PondRow{{Line: 1, Name: "pond a", FarmID: 1}}},
+			wantErr: errors.New("some error"),
+		},
+		{
+			name: "deleted farm of the store is mapped into domain error",
+			mockFunc: func() {
+				verifyFarm()
+				pondStore.EXPECT().Verify(gomock.Any(), gomock.Any()).Return(false, nil)
+				farmStore.EXPECT().GetActivePondsInFarm(gomock.Any(), uint(1)).Return(nil)
+				pondStore.EXPECT().BulkCreate(gomock.Any(), gomock.Any()).Return(pond.ErrInvalidFarm)
+			},
+			r:       ImportPondsRequest{Rows: []PondRow{{Line: 1, Name: "pond a", FarmID: 1}}},
+			wantErr: ponddomain.ErrInvalidFarm,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			farmStore = mock_farm.NewMockFarmStore(mockCtrl)
+			pondStore = mock_pond.NewMockPondStore(mockCtrl)
+			tt.mockFunc()
+			i := NewImportDomain(farmStore, pondStore)
+			got, err := i.ImportPonds(context.Background(), tt.r)
+			if !reflect.DeepEqual(err, tt.wantErr) {
+				t.Fatalf("Importer.ImportPonds() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Importer.ImportPonds() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package importer
+
+// FarmRow struct is list parameter of a farm row in the import file
+type FarmRow struct {
+	Line     int
+	Name     string
+	Location string
+	Owner    string
+	Area     string
+}
+
+// PondRow struct is list parameter of a pond row in the import file,
+// the farm of the pond is found by FarmID or by FarmName when FarmID is 0
+type PondRow struct {
+	Line         int
+	Name         string
+	Capacity     float64
+	Depth        float64
+	WaterQuality float64
+	Species      string
+	FarmID       uint
+	FarmName     string
+}
+
+// ImportFarmsRequest struct is list parameter request for Import Farms domain
+type ImportFarmsRequest struct {
+	Rows   []FarmRow
+	DryRun bool
+	Atomic bool
+}
+
+// ImportPondsRequest struct is list parameter request for Import Ponds domain
+type ImportPondsRequest struct {
+	Rows   []PondRow
+	DryRun bool
+	Atomic bool
+}
+
+// RowResult struct is list result of a row, ID is 0 when the row is not created
+type RowResult struct {
+	Line int
+	ID   uint
+	Err  error
+}
+
+// ImportResponse struct is list parameter response for import domain,
+// the results is in the same order with the rows of the request
+type ImportResponse struct {
+	Results []RowResult
+	Applied bool
+}
@@ -0,0 +1,238 @@
+package loader
+
+import (
+	"aqua-farm-manager/internal/infrastructure/farm"
+	"aqua-farm-manager/internal/infrastructure/pond"
+	"context"
+)
+
+// Loader struct is list dependecies to load farm and pond of a single request, every id that is requested
+// before the first value is read is loaded in a single store call and the loaded value is cached
+// until the end of the request. Loader is not safe for concurrent use
+type Loader struct {
+	ctx       context.Context
+	farmstore farm.FarmStore
+	pondstore pond.PondStore
+	farms     *batch[*FarmInfo]
+	ponds     *batch[*PondInfo]
+	pondIDs   *batch[[]uint]
+}
+
+// NewLoader is func to create Loader for the request with context ctx
+func NewLoader(ctx context.Context, farmstore farm.FarmStore, pondstore pond.PondStore) *Loader {
+	l := &Loader{
+		ctx:       ctx,
+		farmstore: farmstore,
+		pondstore: pondstore,
+	}
+	l.farms = newBatch(l.fetchFarms)
+	l.ponds = newBatch(l.fetchPonds)
+	l.pondIDs = newBatch(l.fetchPondIDs)
+	return l
+}
+
+// Farm is func to queue active farm with id, the farm is nil when it does not exist or inactive
+func (l *Loader) Farm(id uint) func() (*FarmInfo, error) {
+	return l.farms.load(id)
+}
+
+// Pond is func to queue active pond with id, the pond is nil when it does not exist or inactive
+func (l *Loader) Pond(id uint) func() (*PondInfo, error) {
+	return l.ponds.load(id)
+}
+
+// PondIDs is func to queue id of every pond mapped into farm with farmID, the deleted pond is not filtered
+// so the id is loaded by Ponds to get the active one
+func (l *Loader) PondIDs(farmID uint) func() ([]uint, error) {
+	return l.pondIDs.load(farmID)
+}
+
+// Ponds is func to queue every pond of ids, the result keep the order of ids and skip the inactive pond
+func (l *Loader) Ponds(ids []uint) func() ([]PondInfo, error) {
+	thunks := make([]func() (*PondInfo, error), 0, len(ids))
+	for _, id := range ids {
+		thunks = append(thunks, l.ponds.load(id))
+	}
+	return func() ([]PondInfo, error) {
+		list := make([]PondInfo, 0, len(thunks))
+		for _, thunk := range thunks {
+			p, err := thunk()
+			if err != nil {
+				return nil, err
+			}
+			if p != nil {
+				list = append(list, *p)
+			}
+		}
+		return list, nil
+	}
+}
+
+// FarmPage is func to get active farm in page cursor with size item, the next cursor is 0 on the last page.
+// The farm of the page is cached so it is not loaded again by Farm
+func (l *Loader) FarmPage(size, cursor int) ([]FarmInfo, int, error) {
+	farms, err := l.farmstore.GetFarmWithPaging(l.ctx, farm.GetFarmWithPagingRequest{
+		Size:   size,
+		Cursor: cursor,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	list := make([]FarmInfo, 0, len(farms))
+	for _, f := range farms {
+		info := mapFarmInfo(f)
+		l.farms.prime(info.ID, &info)
+		list = append(list, info)
+	}
+	return list, nextCursor(len(farms), size, cursor), nil
+}
+
+// PondPage is func to get active pond in page cursor with size item, the next cursor is 0 on the last page.
+// The pond of the page is cached so it is not loaded again by Pond
+func (l *Loader) PondPage(size, cursor int) ([]PondInfo, int, error) {
+	ponds, err := l.pondstore.GetPondWithPaging(l.ctx, pond.GetPondWithPagingRequest{
+		Size:   size,
+		Cursor: cursor,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	list := make([]PondInfo, 0, len(ponds))
+	for _, p := range ponds {
+		info := mapPondInfo(p)
+		l.ponds.prime(info.ID, &info)
+		list = append(list, info)
+	}
+	return list, nextCursor(len(ponds), size, cursor), nil
+}
+
+func nextCursor(n, size, cursor int) int {
+	if n < size {
+		return 0
+	}
+	return cursor + 1
+}
+
+func (l *Loader) fetchFarms(ids []uint) (map[uint]*FarmInfo, error) {
+	farms, err := l.farmstore.GetFarmsByIDs(l.ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[uint]*FarmInfo, len(farms))
+	for _, f := range farms {
+		info := mapFarmInfo(f)
+		res[info.ID] = &info
+	}
+	return res, nil
+}
+
+func (l *Loader) fetchPonds(ids []uint) (map[uint]*PondInfo, error) {
+	ponds, err := l.pondstore.GetPondsByIDs(l.ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	res := make(map[uint]*PondInfo, len(ponds))
+	for _, p := range ponds {
+		info := mapPondInfo(p)
+		res[info.ID] = &info
+	}
+	return res, nil
+}
+
+func (l *Loader) fetchPondIDs(farmIDs []uint) (map[uint][]uint, error) {
+	return l.pondstore.GetPondsByFarmIDs(l.ctx, farmIDs)
+}
+
+func mapFarmInfo(f farm.FarmInfraInfo) FarmInfo {
+	return FarmInfo{
+		ID:       f.ID,
+		Name:     f.Name,
+		Location: f.Location,
+		Owner:    f.Owner,
+		Area:     f.Area,
+	}
+}
+
+func mapPondInfo(p pond.PondInfraInfo) PondInfo {
+	return PondInfo{
+		ID:           p.ID,
+		Name:         p.Name,
+		Capacity:     p.Capacity,
+		Depth:        p.Depth,
+		WaterQuality: p.WaterQuality,
+		Species:      p.Species,
+		FarmID:       p.FarmID,
+	}
+}
+
+// batch is cache of value by id, the queued id is fetched together when the value of any of them is read
+type batch[V any] struct {
+	fetch  func(ids []uint) (map[uint]V, error)
+	queued []uint
+	queue  map[uint]bool
+	values map[uint]V
+	errs   map[uint]error
+}
+
+func newBatch[V any](fetch func(ids []uint) (map[uint]V, error)) *batch[V] {
+	return &batch[V]{
+		fetch:  fetch,
+		queue:  make(map[uint]bool),
+		values: make(map[uint]V),
+		errs:   make(map[uint]error),
+	}
+}
+
+// load is func to queue id when it is not loaded yet and get func to read its value
+func (b *batch[V]) load(id uint) func() (V, error) {
+	if !b.loaded(id) && !b.queue[id] {
+		b.queue[id] = true
+		b.queued = append(b.queued, id)
+	}
+	return func() (V, error) {
+		b.dispatch()
+		return b.values[id], b.errs[id]
+	}
+}
+
+// prime is func to cache value v of id that is loaded by another query
+func (b *batch[V]) prime(id uint, v V) {
+	if !b.loaded(id) {
+		b.values[id] = v
+	}
+}
+
+// dispatch is func to fetch every queued id, the id that is not found get zero value
+// and the error of the fetch is returned for every id of the batch
+func (b *batch[V]) dispatch() {
+	if len(b.queued) == 0 {
+		return
+	}
+	ids := b.queued
+	b.queued = nil
+	b.queue = make(map[uint]bool)
+
+	values, err := b.fetch(ids)
+	for _, id := range ids {
+		if b.loaded(id) {
+			continue
+		}
+		if err != nil {
+			b.errs[id] = err
+			continue
+		}
+		b.values[id] = values[id]
+	}
+}
+
+func (b *batch[V]) loaded(id uint) bool {
+	if _, ok := b.values[id]; ok {
+		return true
+	}
+	_, ok := b.errs[id]
+	return ok
+}
@@ -0,0 +1,265 @@
+package loader
+
+import (
+	"aqua-farm-manager/internal/infrastructure/farm"
+	"aqua-farm-manager/internal/infrastructure/farm/mock_farm"
+	"aqua-farm-manager/internal/infrastructure/pond"
+	"aqua-farm-manager/internal/infrastructure/pond/mock_pond"
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestLoader_Farm(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	farmStore := mock_farm.NewMockFarmStore(mockCtrl)
+	pondStore := mock_pond.NewMockPondStore(mockCtrl)
+	tests := []struct {
+		name     string
+		mockFunc func()
+		ids      []uint
+		want     []*FarmInfo
+		wantErr  bool
+	}{
+		{
+			name: "every id is loaded in a single call",
+			mockFunc: func() {
+				farmStore.EXPECT().GetFarmsByIDs(gomock.Any(), []uint{1, 2}).Return([]farm.FarmInfraInfo{
+					{ID: 1, Name: "farm 1"},
+					{ID: 2, Name: "farm 2"},
+				}, nil).Times(1)
+			},
+			ids: []uint{1, 2, 1},
+			want: []*FarmInfo{
+				{ID: 1, Name: "farm 1"},
+				{ID: 2, Name: "farm 2"},
+				{ID: 1, Name: "farm 1"},
+			},
+		},
+		{
+			name: "missing farm is nil",
+			mockFunc: func() {
+				farmStore.EXPECT().GetFarmsByIDs(gomock.Any(), []uint{1, 3}).Return([]farm.FarmInfraInfo{
+					{ID: 1, Name: "farm 1"},
+				}, nil).Times(1)
+			},
+			ids: []uint{1, 3},
+			want: []*FarmInfo{
+				{ID: 1, Name: "farm 1"},
+				nil,
+			},
+		},
+		{
+			name: "error of the store is returned for every id",
+			mockFunc: func() {
+				farmStore.EXPECT().GetFarmsByIDs(gomock.Any(), []uint{1, 2}).Return(nil, errors.New("some error")).Times(1)
+			},
+			ids:     []uint{1, 2},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			l := NewLoader(context.Background(), farmStore, pondStore)
+
+			var thunks []func() (*FarmInfo, error)
+			for _, id := range tt.ids {
+				thunks = append(thunks, l.Farm(id))
+			}
+
+			var got []*FarmInfo
+			for _, thunk := range thunks {
+				f, err := thunk()
+				if (err != nil) != tt.wantErr {
+					t.Fatalf("Loader.Farm() error = %v, wantErr %v", err, tt.wantErr)
+				}
+				if err != nil {
+					continue
+				}
+				got = append(got, f)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Loader.Farm() = %v, want %v", got, tt.want)
+			}
+
+			// the loaded farm is cached
+			for _, id := range tt.ids {
+				l.Farm(id)()
+			}
+		})
+	}
+}
+
+func TestLoader_Ponds(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	farmStore := mock_farm.NewMockFarmStore(mockCtrl)
+	pondStore := mock_pond.NewMockPondStore(mockCtrl)
+	tests := []struct {
+		name     string
+		mockFunc func()
+		farmIDs  []uint
+		want     [][]PondInfo
+		wantErr  bool
+	}{
+		{
+			name: "pond of every farm is loaded in two call",
+			mockFunc: func() {
+				pondStore.EXPECT().GetPondsByFarmIDs(gomock.Any(), []uint{1, 2, 3}).Return(map[uint][]uint{
+					1: {11, 12},
+					2: {21},
+				}, nil).Times(1)
+				pondStore.EXPECT().GetPondsByIDs(gomock.Any(), []uint{11, 12, 21}).Return([]pond.PondInfraInfo{
+					{ID: 11, Name: "pond 11", FarmID: 1},
+					{ID: 21, Name: "pond 21", FarmID: 2},
+				}, nil).Times(1)
+			},
+			farmIDs: []uint{1, 2, 3},
+			want: [][]PondInfo{
+				{{ID: 11, Name: "pond 11", FarmID: 1}},
+				{{ID: 21, Name: "pond 21", FarmID: 2}},
+				{},
+			},
+		},
+		{
+			name: "error when get pond ids",
+			mockFunc: func() {
+				pondStore.EXPECT().GetPondsByFarmIDs(gomock.Any(), []uint{1}).Return(nil, errors.New("some error")).Times(1)
+			},
+			farmIDs: []uint{1},
+			wantErr: true,
+		},
+		{
+			name: "error when get ponds",
+			mockFunc: func() {
+				pondStore.EXPECT().GetPondsByFarmIDs(gomock.Any(), []uint{1}).Return(map[uint][]uint{
+					1: {11},
+				}, nil).Times(1)
+				pondStore.EXPECT().GetPondsByIDs(gomock.Any(), []uint{11}).Return(nil, errors.New("some error")).Times(1)
+			},
+			farmIDs: []uint{1},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			l := NewLoader(context.Background(), farmStore, pondStore)
+
+			var idThunks []func() ([]uint, error)
+			for _, id := range tt.farmIDs {
+				idThunks = append(idThunks, l.PondIDs(id))
+			}
+
+			var err error
+			var pondThunks []func() ([]PondInfo, error)
+			for _, thunk := range idThunks {
+				var ids []uint
+				ids, err = thunk()
+				if err != nil {
+					break
+				}
+				pondThunks = append(pondThunks, l.Ponds(ids))
+			}
+
+			var got [][]PondInfo
+			for _, thunk := range pondThunks {
+				var ponds []PondInfo
+				ponds, err = thunk()
+				if err != nil {
+					break
+				}
+				got = append(got, ponds)
+			}
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Loader.Ponds() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Loader.Ponds() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoader_FarmPage(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	farmStore := mock_farm.NewMockFarmStore(mockCtrl)
+	pondStore := mock_pond.NewMockPondStore(mockCtrl)
+	type args struct {
+		size   int
+		cursor int
+	}
+	tests := []struct {
+		name     string
+		mockFunc func()
+		args     args
+		want     []FarmInfo
+		wantNext int
+		wantErr  bool
+	}{
+		{
+			name: "full page",
+			mockFunc: func() {
+				farmStore.EXPECT().GetFarmWithPaging(gomock.Any(), farm.GetFarmWithPagingRequest{Size: 2, Cursor: 1}).Return([]farm.FarmInfraInfo{
+					{ID: 1, Name: "farm 1"},
+					{ID: 2, Name: "farm 2"},
+				}, nil)
+			},
+			args:     args{size: 2, cursor: 1},
+			want:     []FarmInfo{{ID: 1, Name: "farm 1"}, {ID: 2, Name: "farm 2"}},
+			wantNext: 2,
+		},
+		{
+			name: "last page",
+			mockFunc: func() {
+				farmStore.EXPECT().GetFarmWithPaging(gomock.Any(), farm.GetFarmWithPagingRequest{Size: 2, Cursor: 2}).Return([]farm.FarmInfraInfo{
+					{ID: 3, Name: "farm 3"},
+				}, nil)
+			},
+			args:     args{size: 2, cursor: 2},
+			want:     []FarmInfo{{ID: 3, Name: "farm 3"}},
+			wantNext: 0,
+		},
+		{
+			name: "error when get page",
+			mockFunc: func() {
+				farmStore.EXPECT().GetFarmWithPaging(gomock.Any(), gomock.Any()).Return(nil, errors.New("some error"))
+			},
+			args:    args{size: 2, cursor: 1},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			l := NewLoader(context.Background(), farmStore, pondStore)
+
+			got, next, err := l.FarmPage(tt.args.size, tt.args.cursor)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Loader.FarmPage() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Loader.FarmPage() = %v, want %v", got, tt.want)
+			}
+			if next != tt.wantNext {
+				t.Errorf("Loader.FarmPage() next = %v, want %v", next, tt.wantNext)
+			}
+
+			// farm of the page is not loaded again
+			for _, f := range tt.want {
+				if loaded, err := l.Farm(f.ID)(); err != nil || loaded.Name != f.Name {
+					t.Errorf("Loader.Farm() = %v, %v, want %v", loaded, err, f)
+				}
+			}
+		})
+	}
+}
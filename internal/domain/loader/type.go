@@ -0,0 +1,21 @@
+package loader
+
+// FarmInfo struct is list parameter of loaded farm
+type FarmInfo struct {
+	ID       uint
+	Name     string
+	Location string
+	Owner    string
+	Area     string
+}
+
+// PondInfo struct is list parameter of loaded pond with the id of its farm
+type PondInfo struct {
+	ID           uint
+	Name         string
+	Capacity     float64
+	Depth        float64
+	WaterQuality float64
+	Species      string
+	FarmID       uint
+}
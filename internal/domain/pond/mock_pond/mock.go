@@ -6,6 +6,7 @@ package mock_pond
 
 import (
 	pond "aqua-farm-manager/internal/domain/pond"
+	context "context"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -35,39 +36,39 @@ func (m *MockPondDomain) EXPECT() *MockPondDomainMockRecorder {
 }
 
 // CreatePondInfo mocks base method.
-func (m *MockPondDomain) CreatePondInfo(r pond.CreateDomainRequest) (pond.CreateDomainResponse, error) {
+func (m *MockPondDomain) CreatePondInfo(ctx context.Context, r pond.CreateDomainRequest) (pond.CreateDomainResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreatePondInfo", r)
+	ret := m.ctrl.Call(m, "CreatePondInfo", ctx, r)
 	ret0, _ := ret[0].(pond.CreateDomainResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // CreatePondInfo indicates an expected call of CreatePondInfo.
-func (mr *MockPondDomainMockRecorder) CreatePondInfo(r interface{}) *gomock.Call {
+func (mr *MockPondDomainMockRecorder) CreatePondInfo(ctx, r interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePondInfo", reflect.TypeOf((*MockPondDomain)(nil).CreatePondInfo), r)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePondInfo", reflect.TypeOf((*MockPondDomain)(nil).CreatePondInfo), ctx, r)
 }
 
 // DeletePondInfo mocks base method.
-func (m *MockPondDomain) DeletePondInfo(r pond.DeleteDomainRequest) (pond.DeleteDomainResponse, error) {
+func (m *MockPondDomain) DeletePondInfo(ctx context.Context, r pond.DeleteDomainRequest) (pond.DeleteDomainResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeletePondInfo", r)
+	ret := m.ctrl.Call(m, "DeletePondInfo", ctx, r)
 	ret0, _ := ret[0].(pond.DeleteDomainResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // DeletePondInfo indicates an expected call of DeletePondInfo.
-func (mr *MockPondDomainMockRecorder) DeletePondInfo(r interface{}) *gomock.Call {
+func (mr *MockPondDomainMockRecorder) DeletePondInfo(ctx, r interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePondInfo", reflect.TypeOf((*MockPondDomain)(nil).DeletePondInfo), r)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePondInfo", reflect.TypeOf((*MockPondDomain)(nil).DeletePondInfo), ctx, r)
 }
 
 // GetAllPond mocks base method.
-func (m *MockPondDomain) GetAllPond(size, cursor int) ([]pond.GetPondInfoResponse, int, error) {
+func (m *MockPondDomain) GetAllPond(ctx context.Context, size, cursor int) ([]pond.GetPondInfoResponse, int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllPond", size, cursor)
+	ret := m.ctrl.Call(m, "GetAllPond", ctx, size, cursor)
 	ret0, _ := ret[0].([]pond.GetPondInfoResponse)
 	ret1, _ := ret[1].(int)
 	ret2, _ := ret[2].(error)
@@ -75,37 +76,37 @@ func (m *MockPondDomain) GetAllPond(size, cursor int) ([]pond.GetPondInfoRespons
 }
 
 // GetAllPond indicates an expected call of GetAllPond.
-func (mr *MockPondDomainMockRecorder) GetAllPond(size, cursor interface{}) *gomock.Call {
+func (mr *MockPondDomainMockRecorder) GetAllPond(ctx, size, cursor interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllPond", reflect.TypeOf((*MockPondDomain)(nil).GetAllPond), size, cursor)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllPond", reflect.TypeOf((*MockPondDomain)(nil).GetAllPond), ctx, size, cursor)
 }
 
 // GetPondInfoByID mocks base method.
-func (m *MockPondDomain) GetPondInfoByID(ID uint) (pond.GetPondInfoResponse, error) {
+func (m *MockPondDomain) GetPondInfoByID(ctx context.Context, ID uint) (pond.GetPondInfoResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPondInfoByID", ID)
+	ret := m.ctrl.Call(m, "GetPondInfoByID", ctx, ID)
 	ret0, _ := ret[0].(pond.GetPondInfoResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetPondInfoByID indicates an expected call of GetPondInfoByID.
-func (mr *MockPondDomainMockRecorder) GetPondInfoByID(ID interface{}) *gomock.Call {
+func (mr *MockPondDomainMockRecorder) GetPondInfoByID(ctx, ID interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPondInfoByID", reflect.TypeOf((*MockPondDomain)(nil).GetPondInfoByID), ID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPondInfoByID", reflect.TypeOf((*MockPondDomain)(nil).GetPondInfoByID), ctx, ID)
 }
 
 // UpdatePondInfo mocks base method.
-func (m *MockPondDomain) UpdatePondInfo(r pond.UpdateDomainRequest) (pond.UpdateDomainResponse, error) {
+func (m *MockPondDomain) UpdatePondInfo(ctx context.Context, r pond.UpdateDomainRequest) (pond.UpdateDomainResponse, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdatePondInfo", r)
+	ret := m.ctrl.Call(m, "UpdatePondInfo", ctx, r)
 	ret0, _ := ret[0].(pond.UpdateDomainResponse)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdatePondInfo indicates an expected call of UpdatePondInfo.
-func (mr *MockPondDomainMockRecorder) UpdatePondInfo(r interface{}) *gomock.Call {
+func (mr *MockPondDomainMockRecorder) UpdatePondInfo(ctx, r interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePondInfo", reflect.TypeOf((*MockPondDomain)(nil).UpdatePondInfo), r)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePondInfo", reflect.TypeOf((*MockPondDomain)(nil).UpdatePondInfo), ctx, r)
 }
@@ -1,17 +1,21 @@
 package pond
 
 import (
+	"aqua-farm-manager/internal/domain/event"
 	"aqua-farm-manager/internal/infrastructure/farm"
+	"aqua-farm-manager/internal/infrastructure/outbox"
 	"aqua-farm-manager/internal/infrastructure/pond"
+	"context"
+	"errors"
 )
 
 // PondDomain is list method for pond domain
 type PondDomain interface {
-	CreatePondInfo(r CreateDomainRequest) (CreateDomainResponse, error)
-	UpdatePondInfo(r UpdateDomainRequest) (UpdateDomainResponse, error)
-	DeletePondInfo(r DeleteDomainRequest) (DeleteDomainResponse, error)
-	GetPondInfoByID(ID uint) (GetPondInfoResponse, error)
-	GetAllPond(size, cursor int) ([]GetPondInfoResponse, int, error)
+	CreatePondInfo(ctx context.Context, r CreateDomainRequest) (CreateDomainResponse, error)
+	UpdatePondInfo(ctx context.Context, r UpdateDomainRequest) (UpdateDomainResponse, error)
+	DeletePondInfo(ctx context.Context, r DeleteDomainRequest) (DeleteDomainResponse, error)
+	GetPondInfoByID(ctx context.Context, ID uint) (GetPondInfoResponse, error)
+	GetAllPond(ctx context.Context, size, cursor int) ([]GetPondInfoResponse, int, error)
 }
 
 // Stat is list dependencies stat domain
@@ -29,11 +33,11 @@ func NewPondDomain(pondstore pond.PondStore, farmstore farm.FarmStore) PondDomai
 }
 
 // CreatePondInfo is func to update farm info in database
-func (p *Pond) CreatePondInfo(r CreateDomainRequest) (CreateDomainResponse, error) {
+func (p *Pond) CreatePondInfo(ctx context.Context, r CreateDomainRequest) (CreateDomainResponse, error) {
 	var err error
 	var res CreateDomainResponse
 	var exists bool
-	exists, err = p.farmstore.Verify(
+	exists, err = p.farmstore.Verify(ctx,
 		&farm.FarmInfraInfo{
 			ID: r.FarmID,
 		})
@@ -45,7 +49,7 @@ func (p *Pond) CreatePondInfo(r CreateDomainRequest) (CreateDomainResponse, erro
 		return res, ErrInvalidFarm
 	}
 
-	exists, err = p.pondstore.Verify(
+	exists, err = p.pondstore.Verify(ctx,
 		&pond.PondInfraInfo{
 			Name: r.Name,
 		})
@@ -59,15 +63,18 @@ func (p *Pond) CreatePondInfo(r CreateDomainRequest) (CreateDomainResponse, erro
 	}
 
 	pondinfra := mapPondRequest(r)
+	pondinfra.Events = []outbox.Event{
+		event.NewPondEvent(event.PondCreated, mapPondEventData(pondinfra)),
+	}
 
-	ponds := p.farmstore.GetActivePondsInFarm(pondinfra.FarmID)
-	if len(ponds) > 10 {
+	ponds := p.farmstore.GetActivePondsInFarm(ctx, pondinfra.FarmID)
+	if len(ponds) >= MaxPondInFarm {
 		return res, ErrMaxPond
 	}
 
-	err = p.pondstore.Create(pondinfra)
+	err = p.pondstore.Create(ctx, pondinfra)
 	if err != nil {
-		return res, err
+		return res, mapStoreError(err)
 	}
 
 	res.PondID = pondinfra.ID
@@ -86,8 +93,19 @@ func mapPondRequest(r CreateDomainRequest) *pond.PondInfraInfo {
 	}
 }
 
+func mapPondEventData(r *pond.PondInfraInfo) event.PondData {
+	return event.PondData{
+		Name:         r.Name,
+		Capacity:     r.Capacity,
+		Depth:        r.Depth,
+		WaterQuality: r.WaterQuality,
+		Species:      r.Species,
+		FarmID:       r.FarmID,
+	}
+}
+
 // UpdatePondInfo is func to update pond info in database
-func (p *Pond) UpdatePondInfo(r UpdateDomainRequest) (UpdateDomainResponse, error) {
+func (p *Pond) UpdatePondInfo(ctx context.Context, r UpdateDomainRequest) (UpdateDomainResponse, error) {
 	var err error
 	var res UpdateDomainResponse
 	var existsPond bool
@@ -95,7 +113,7 @@ func (p *Pond) UpdatePondInfo(r UpdateDomainRequest) (UpdateDomainResponse, erro
 	verify := &pond.PondInfraInfo{
 		Name: r.Name,
 	}
-	existsPond, err = p.pondstore.Verify(verify)
+	existsPond, err = p.pondstore.Verify(ctx, verify)
 
 	if err != nil {
 		return res, err
@@ -103,7 +121,7 @@ func (p *Pond) UpdatePondInfo(r UpdateDomainRequest) (UpdateDomainResponse, erro
 
 	if r.FarmID > 0 {
 		// verify farm id
-		existsFarm, err := p.farmstore.Verify(
+		existsFarm, err := p.farmstore.Verify(ctx,
 			&farm.FarmInfraInfo{
 				ID: r.FarmID,
 			})
@@ -129,18 +147,23 @@ func (p *Pond) UpdatePondInfo(r UpdateDomainRequest) (UpdateDomainResponse, erro
 		if pondInfra.FarmID < 1 {
 			return res, ErrInvalidFarm
 		}
-		ponds := p.farmstore.GetActivePondsInFarm(pondInfra.FarmID)
-		if len(ponds) >= 10 {
+		ponds := p.farmstore.GetActivePondsInFarm(ctx, pondInfra.FarmID)
+		if len(ponds) >= MaxPondInFarm {
 			return res, ErrMaxPond
 		}
-		err = p.pondstore.Create(pondInfra)
+		pondInfra.Events = []outbox.Event{
+			event.NewPondEvent(event.PondCreated, mapPondEventData(pondInfra)),
+		}
+		err = p.pondstore.Create(ctx, pondInfra)
 	} else {
 		pondInfra.ID = verify.ID
-		err = p.pondstore.GetPondByID(pondInfra)
+		err = p.pondstore.GetPondByID(ctx, pondInfra)
 		if err != nil {
 			return res, err
 		}
 
+		previousFarmID := pondInfra.FarmID
+
 		// validate nil request
 		if r.Species != "" {
 			pondInfra.Species = r.Species
@@ -156,19 +179,28 @@ func (p *Pond) UpdatePondInfo(r UpdateDomainRequest) (UpdateDomainResponse, erro
 		}
 		// check before modify farm
 		if r.FarmID != pondInfra.FarmID && r.FarmID != 0 {
-			ponds := p.farmstore.GetActivePondsInFarm(r.FarmID)
-			if len(ponds) >= 10 {
+			ponds := p.farmstore.GetActivePondsInFarm(ctx, r.FarmID)
+			if len(ponds) >= MaxPondInFarm {
 				return res, ErrMaxPond
 			}
 
 			pondInfra.FarmID = r.FarmID
 		}
 
-		err = p.pondstore.Update(pondInfra)
+		pondInfra.Events = []outbox.Event{
+			event.NewPondEvent(event.PondUpdated, mapPondEventData(pondInfra)),
+		}
+		// moved pond is published on its own so consumer of farm does not need to diff every update
+		if pondInfra.FarmID != previousFarmID {
+			moved := mapPondEventData(pondInfra)
+			moved.PreviousFarmID = previousFarmID
+			pondInfra.Events = append(pondInfra.Events, event.NewPondEvent(event.PondMoved, moved))
+		}
+		err = p.pondstore.Update(ctx, pondInfra)
 	}
 
 	if err != nil {
-		return res, err
+		return res, mapStoreError(err)
 	}
 
 	return UpdateDomainResponse{
@@ -183,7 +215,7 @@ func (p *Pond) UpdatePondInfo(r UpdateDomainRequest) (UpdateDomainResponse, erro
 }
 
 // DeletePondInfo is func to soft delete pond info in database
-func (p *Pond) DeletePondInfo(r DeleteDomainRequest) (DeleteDomainResponse, error) {
+func (p *Pond) DeletePondInfo(ctx context.Context, r DeleteDomainRequest) (DeleteDomainResponse, error) {
 	var err error
 	var res DeleteDomainResponse
 	var exists bool
@@ -198,7 +230,7 @@ func (p *Pond) DeletePondInfo(r DeleteDomainRequest) (DeleteDomainResponse, erro
 		return res, ErrInvalidPond
 	}
 
-	exists, err = p.pondstore.Verify(&verify)
+	exists, err = p.pondstore.Verify(ctx, &verify)
 
 	if err != nil {
 		return res, err
@@ -208,9 +240,12 @@ func (p *Pond) DeletePondInfo(r DeleteDomainRequest) (DeleteDomainResponse, erro
 		return res, ErrInvalidPond
 	}
 
-	err = p.pondstore.Delete(&pond.PondInfraInfo{
+	err = p.pondstore.Delete(ctx, &pond.PondInfraInfo{
 		ID:   verify.ID,
 		Name: verify.Name,
+		Events: []outbox.Event{
+			event.NewPondEvent(event.PondDeleted, event.PondData{Name: verify.Name}),
+		},
 	})
 	if err != nil {
 		return res, err
@@ -223,15 +258,17 @@ func (p *Pond) DeletePondInfo(r DeleteDomainRequest) (DeleteDomainResponse, erro
 }
 
 // GetPondInfoByID is func to get farm info by id
-func (p *Pond) GetPondInfoByID(ID uint) (GetPondInfoResponse, error) {
+func (p *Pond) GetPondInfoByID(ctx context.Context, ID uint) (GetPondInfoResponse, error) {
 	var err error
 
 	pondInfra := &pond.PondInfraInfo{
 		ID: ID,
 	}
 
-	err = p.pondstore.GetPondByID(pondInfra)
-
+	err = p.pondstore.GetPondByID(ctx, pondInfra)
+	if errors.Is(err, pond.ErrNotFound) {
+		return GetPondInfoResponse{}, ErrInvalidPond
+	}
 	if err != nil {
 		return GetPondInfoResponse{}, err
 	}
@@ -240,8 +277,10 @@ func (p *Pond) GetPondInfoByID(ID uint) (GetPondInfoResponse, error) {
 		ID: pondInfra.FarmID,
 	}
 
-	err = p.farmstore.GetFarmByID(farmInfra)
-
+	err = p.farmstore.GetFarmByID(ctx, farmInfra)
+	if errors.Is(err, farm.ErrNotFound) {
+		return GetPondInfoResponse{}, ErrInvalidFarm
+	}
 	if err != nil {
 		return GetPondInfoResponse{}, err
 	}
@@ -264,10 +303,10 @@ func (p *Pond) GetPondInfoByID(ID uint) (GetPondInfoResponse, error) {
 }
 
 // GetAllPond is func to get farm info by id
-func (p *Pond) GetAllPond(size, cursor int) ([]GetPondInfoResponse, int, error) {
+func (p *Pond) GetAllPond(ctx context.Context, size, cursor int) ([]GetPondInfoResponse, int, error) {
 	var err error
 	var list []GetPondInfoResponse
-	pondInfra, err := p.pondstore.GetPondWithPaging(
+	pondInfra, err := p.pondstore.GetPondWithPaging(ctx,
 		pond.GetPondWithPagingRequest{
 			Size:   size,
 			Cursor: cursor,
@@ -298,3 +337,14 @@ func (p *Pond) GetAllPond(size, cursor int) ([]GetPondInfoResponse, int, error)
 
 	return list, nextPage, err
 }
+
+// mapStoreError is func to map constraint violation of pond store into domain error
+func mapStoreError(err error) error {
+	switch {
+	case errors.Is(err, pond.ErrDuplicateName):
+		return ErrDuplicatePond
+	case errors.Is(err, pond.ErrInvalidFarm):
+		return ErrInvalidFarm
+	}
+	return err
+}
@@ -1,10 +1,14 @@
 package pond
 
 import (
+	"aqua-farm-manager/internal/domain/event"
 	"aqua-farm-manager/internal/infrastructure/farm"
 	"aqua-farm-manager/internal/infrastructure/farm/mock_farm"
+	"aqua-farm-manager/internal/infrastructure/outbox"
 	"aqua-farm-manager/internal/infrastructure/pond"
 	"aqua-farm-manager/internal/infrastructure/pond/mock_pond"
+	"context"
+	"errors"
 	"fmt"
 	"reflect"
 	"testing"
@@ -72,10 +76,10 @@ func TestPond_CreatePondInfo(t *testing.T) {
 				},
 			},
 			mockFunc: func() {
-				farmStore.EXPECT().Verify(gomock.Any()).Return(true, nil)
-				pondStore.EXPECT().Verify(gomock.Any()).Return(false, nil)
-				farmStore.EXPECT().GetActivePondsInFarm(gomock.Any()).Return([]uint{})
-				pondStore.EXPECT().Create(gomock.Any()).DoAndReturn(func(r *pond.PondInfraInfo) error {
+				farmStore.EXPECT().Verify(gomock.Any(), gomock.Any()).Return(true, nil)
+				pondStore.EXPECT().Verify(gomock.Any(), gomock.Any()).Return(false, nil)
+				farmStore.EXPECT().GetActivePondsInFarm(gomock.Any(), gomock.Any()).Return([]uint{})
+				pondStore.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, r *pond.PondInfraInfo) error {
 					r.ID = 1
 					return nil
 				})
@@ -98,10 +102,10 @@ func TestPond_CreatePondInfo(t *testing.T) {
 				},
 			},
 			mockFunc: func() {
-				farmStore.EXPECT().Verify(gomock.Any()).Return(true, nil)
-				pondStore.EXPECT().Verify(gomock.Any()).Return(false, nil)
-				farmStore.EXPECT().GetActivePondsInFarm(gomock.Any()).Return([]uint{})
-				pondStore.EXPECT().Create(gomock.Any()).Return(fmt.Errorf("some error"))
+				farmStore.EXPECT().Verify(gomock.Any(), gomock.Any()).Return(true, nil)
+				pondStore.EXPECT().Verify(gomock.Any(), gomock.Any()).Return(false, nil)
+				farmStore.EXPECT().GetActivePondsInFarm(gomock.Any(), gomock.Any()).Return([]uint{})
+				pondStore.EXPECT().Create(gomock.Any(), gomock.Any()).Return(fmt.Errorf("some error"))
 			},
 			want: CreateDomainResponse{
 				PondID: 0,
@@ -121,15 +125,63 @@ func TestPond_CreatePondInfo(t *testing.T) {
 				},
 			},
 			mockFunc: func() {
-				farmStore.EXPECT().Verify(gomock.Any()).Return(true, nil)
-				pondStore.EXPECT().Verify(gomock.Any()).Return(false, nil)
-				farmStore.EXPECT().GetActivePondsInFarm(gomock.Any()).Return([]uint{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11})
+				farmStore.EXPECT().Verify(gomock.Any(), gomock.Any()).Return(true, nil)
+				pondStore.EXPECT().Verify(gomock.Any(), gomock.Any()).Return(false, nil)
+				farmStore.EXPECT().GetActivePondsInFarm(gomock.Any(), gomock.Any()).Return([]uint{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11})
 			},
 			want: CreateDomainResponse{
 				PondID: 0,
 			},
 			wantErr: true,
 		},
+		{
+			name: "error farm already has max pond",
+			args: args{
+				r: CreateDomainRequest{
+					Name:         "Pond 1",
+					Capacity:     1,
+					Depth:        1,
+					WaterQuality: 1,
+					Species:      "Ikan",
+					FarmID:       1,
+				},
+			},
+			mockFunc: func() {
+				farmStore.EXPECT().Verify(gomock.Any(), gomock.Any()).Return(true, nil)
+				pondStore.EXPECT().Verify(gomock.Any(), gomock.Any()).Return(false, nil)
+				farmStore.EXPECT().GetActivePondsInFarm(gomock.Any(), gomock.Any()).Return(make([]uint, MaxPondInFarm))
+			},
+			want: CreateDomainResponse{
+				PondID: 0,
+			},
+			wantErr: true,
+		},
+		{
+			name: "success farm has one pond less than max pond",
+			args: args{
+				r: CreateDomainRequest{
+					Name:         "Pond 1",
+					Capacity:     1,
+					Depth:        1,
+					WaterQuality: 1,
+					Species:      "Ikan",
+					FarmID:       1,
+				},
+			},
+			mockFunc: func() {
+				farmStore.EXPECT().Verify(gomock.Any(), gomock.Any()).Return(true, nil)
+				pondStore.EXPECT().Verify(gomock.Any(), gomock.Any()).Return(false, nil)
+				farmStore.EXPECT().GetActivePondsInFarm(gomock.Any(), gomock.Any()).Return(make([]uint, MaxPondInFarm-1))
+				pondStore.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, r *pond.PondInfraInfo) error {
+					r.ID = 1
+					return nil
+				})
+			},
+			want: CreateDomainResponse{
+				PondID: 1,
+			},
+			wantErr: false,
+		},
 		{
 			name: "error duplicate pond",
 			args: args{
@@ -143,8 +195,8 @@ func TestPond_CreatePondInfo(t *testing.T) {
 				},
 			},
 			mockFunc: func() {
-				farmStore.EXPECT().Verify(gomock.Any()).Return(true, nil)
-				pondStore.EXPECT().Verify(gomock.Any()).Return(true, nil)
+				farmStore.EXPECT().Verify(gomock.Any(), gomock.Any()).Return(true, nil)
+				pondStore.EXPECT().Verify(gomock.Any(), gomock.Any()).Return(true, nil)
 			},
 			want: CreateDomainResponse{
 				PondID: 0,
@@ -164,8 +216,8 @@ func TestPond_CreatePondInfo(t *testing.T) {
 				},
 			},
 			mockFunc: func() {
-				farmStore.EXPECT().Verify(gomock.Any()).Return(true, nil)
-				pondStore.EXPECT().Verify(gomock.Any()).Return(false, fmt.Errorf("some error"))
+				farmStore.EXPECT().Verify(gomock.Any(), gomock.Any()).Return(true, nil)
+				pondStore.EXPECT().Verify(gomock.Any(), gomock.Any()).Return(false, fmt.Errorf("some error"))
 			},
 			want: CreateDomainResponse{
 				PondID: 0,
@@ -185,7 +237,7 @@ func TestPond_CreatePondInfo(t *testing.T) {
 				},
 			},
 			mockFunc: func() {
-				farmStore.EXPECT().Verify(gomock.Any()).Return(false, nil)
+				farmStore.EXPECT().Verify(gomock.Any(), gomock.Any()).Return(false, nil)
 			},
 			want: CreateDomainResponse{
 				PondID: 0,
@@ -205,7 +257,7 @@ func TestPond_CreatePondInfo(t *testing.T) {
 				},
 			},
 			mockFunc: func() {
-				farmStore.EXPECT().Verify(gomock.Any()).Return(false, fmt.Errorf("some error"))
+				farmStore.EXPECT().Verify(gomock.Any(), gomock.Any()).Return(false, fmt.Errorf("some error"))
 			},
 			want: CreateDomainResponse{
 				PondID: 0,
@@ -217,7 +269,7 @@ func TestPond_CreatePondInfo(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockFunc()
 			s := NewPondDomain(pondStore, farmStore)
-			got, err := s.CreatePondInfo(tt.args.r)
+			got, err := s.CreatePondInfo(context.Background(), tt.args.r)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Pond.CreatePondInfo() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -248,10 +300,10 @@ func TestPond_UpdatePondInfo(t *testing.T) {
 		{
 			name: "success create flow",
 			mockFunc: func() {
-				pondStore.EXPECT().Verify(gomock.Any()).Return(false, nil)
-				farmStore.EXPECT().Verify(gomock.Any()).Return(true, nil)
-				farmStore.EXPECT().GetActivePondsInFarm(gomock.Any()).Return([]uint{})
-				pondStore.EXPECT().Create(gomock.Any()).DoAndReturn(func(r *pond.PondInfraInfo) error {
+				pondStore.EXPECT().Verify(gomock.Any(), gomock.Any()).Return(false, nil)
+				farmStore.EXPECT().Verify(gomock.Any(), gomock.Any()).Return(true, nil)
+				farmStore.EXPECT().GetActivePondsInFarm(gomock.Any(), gomock.Any()).Return([]uint{})
+				pondStore.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, r *pond.PondInfraInfo) error {
 					r.ID = 1
 					r.Capacity = 1
 					r.Depth = 1
@@ -285,9 +337,9 @@ func TestPond_UpdatePondInfo(t *testing.T) {
 		{
 			name: "success update flow",
 			mockFunc: func() {
-				pondStore.EXPECT().Verify(gomock.Any()).Return(true, nil)
-				farmStore.EXPECT().Verify(gomock.Any()).Return(true, nil)
-				pondStore.EXPECT().GetPondByID(gomock.Any()).DoAndReturn(func(r *pond.PondInfraInfo) error {
+				pondStore.EXPECT().Verify(gomock.Any(), gomock.Any()).Return(true, nil)
+				farmStore.EXPECT().Verify(gomock.Any(), gomock.Any()).Return(true, nil)
+				pondStore.EXPECT().GetPondByID(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, r *pond.PondInfraInfo) error {
 					r.ID = 1
 					r.Capacity = 1
 					r.Depth = 1
@@ -296,8 +348,15 @@ func TestPond_UpdatePondInfo(t *testing.T) {
 					r.FarmID = 2
 					return nil
 				})
-				farmStore.EXPECT().GetActivePondsInFarm(gomock.Any()).Return([]uint{})
-				pondStore.EXPECT().Update(gomock.Any()).DoAndReturn(func(r *pond.PondInfraInfo) error {
+				farmStore.EXPECT().GetActivePondsInFarm(gomock.Any(), gomock.Any()).Return([]uint{})
+				pondStore.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, r *pond.PondInfraInfo) error {
+					wantEvents := []outbox.Event{
+						event.NewPondEvent(event.PondUpdated, event.PondData{Name: "Pond 1", Capacity: 1, Depth: 1, WaterQuality: 1, Species: "ikan", FarmID: 1}),
+						event.NewPondEvent(event.PondMoved, event.PondData{Name: "Pond 1", Capacity: 1, Depth: 1, WaterQuality: 1, Species: "ikan", FarmID: 1, PreviousFarmID: 2}),
+					}
+					if !reflect.DeepEqual(r.Events, wantEvents) {
+						t.Errorf("PondStore.Update() events = %v, want %v", r.Events, wantEvents)
+					}
 					r.ID = 1
 					r.Capacity = 1
 					r.Depth = 1
@@ -328,12 +387,49 @@ func TestPond_UpdatePondInfo(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "success update flow in same farm",
+			mockFunc: func() {
+				pondStore.EXPECT().Verify(gomock.Any(), gomock.Any()).Return(true, nil)
+				farmStore.EXPECT().Verify(gomock.Any(), gomock.Any()).Return(true, nil)
+				pondStore.EXPECT().GetPondByID(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, r *pond.PondInfraInfo) error {
+					r.ID = 1
+					r.Species = "ikan"
+					r.FarmID = 1
+					return nil
+				})
+				pondStore.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, r *pond.PondInfraInfo) error {
+					wantEvents := []outbox.Event{
+						event.NewPondEvent(event.PondUpdated, event.PondData{Name: "Pond 1", Depth: 2, Species: "ikan", FarmID: 1}),
+					}
+					if !reflect.DeepEqual(r.Events, wantEvents) {
+						t.Errorf("PondStore.Update() events = %v, want %v", r.Events, wantEvents)
+					}
+					return nil
+				})
+			},
+			args: args{
+				r: UpdateDomainRequest{
+					Name:   "Pond 1",
+					Depth:  2,
+					FarmID: 1,
+				},
+			},
+			want: UpdateDomainResponse{
+				ID:      1,
+				Name:    "Pond 1",
+				Depth:   2,
+				Species: "ikan",
+				FarmID:  1,
+			},
+			wantErr: false,
+		},
 		{
 			name: "error max pondsuccess create flow",
 			mockFunc: func() {
-				pondStore.EXPECT().Verify(gomock.Any()).Return(false, nil)
-				farmStore.EXPECT().Verify(gomock.Any()).Return(true, nil)
-				farmStore.EXPECT().GetActivePondsInFarm(gomock.Any()).Return([]uint{1, 2, 3, 4, 5, 6, 7, 8, 9, 10})
+				pondStore.EXPECT().Verify(gomock.Any(), gomock.Any()).Return(false, nil)
+				farmStore.EXPECT().Verify(gomock.Any(), gomock.Any()).Return(true, nil)
+				farmStore.EXPECT().GetActivePondsInFarm(gomock.Any(), gomock.Any()).Return([]uint{1, 2, 3, 4, 5, 6, 7, 8, 9, 10})
 			},
 			args: args{
 				r: UpdateDomainRequest{
@@ -351,9 +447,9 @@ func TestPond_UpdatePondInfo(t *testing.T) {
 		{
 			name: "error max pond update flow",
 			mockFunc: func() {
-				pondStore.EXPECT().Verify(gomock.Any()).Return(true, nil)
-				farmStore.EXPECT().Verify(gomock.Any()).Return(true, nil)
-				pondStore.EXPECT().GetPondByID(gomock.Any()).DoAndReturn(func(r *pond.PondInfraInfo) error {
+				pondStore.EXPECT().Verify(gomock.Any(), gomock.Any()).Return(true, nil)
+				farmStore.EXPECT().Verify(gomock.Any(), gomock.Any()).Return(true, nil)
+				pondStore.EXPECT().GetPondByID(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, r *pond.PondInfraInfo) error {
 					r.ID = 1
 					r.Capacity = 1
 					r.Depth = 1
@@ -362,7 +458,7 @@ func TestPond_UpdatePondInfo(t *testing.T) {
 					r.FarmID = 2
 					return nil
 				})
-				farmStore.EXPECT().GetActivePondsInFarm(gomock.Any()).Return([]uint{1, 2, 3, 4, 5, 6, 7, 8, 9, 10})
+				farmStore.EXPECT().GetActivePondsInFarm(gomock.Any(), gomock.Any()).Return([]uint{1, 2, 3, 4, 5, 6, 7, 8, 9, 10})
 			},
 			args: args{
 				r: UpdateDomainRequest{
@@ -380,10 +476,10 @@ func TestPond_UpdatePondInfo(t *testing.T) {
 		{
 			name: "error when create flow",
 			mockFunc: func() {
-				pondStore.EXPECT().Verify(gomock.Any()).Return(false, nil)
-				farmStore.EXPECT().Verify(gomock.Any()).Return(true, nil)
-				farmStore.EXPECT().GetActivePondsInFarm(gomock.Any()).Return([]uint{})
-				pondStore.EXPECT().Create(gomock.Any()).DoAndReturn(func(r *pond.PondInfraInfo) error {
+				pondStore.EXPECT().Verify(gomock.Any(), gomock.Any()).Return(false, nil)
+				farmStore.EXPECT().Verify(gomock.Any(), gomock.Any()).Return(true, nil)
+				farmStore.EXPECT().GetActivePondsInFarm(gomock.Any(), gomock.Any()).Return([]uint{})
+				pondStore.EXPECT().Create(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, r *pond.PondInfraInfo) error {
 					return fmt.Errorf("some error")
 				})
 			},
@@ -403,9 +499,9 @@ func TestPond_UpdatePondInfo(t *testing.T) {
 		{
 			name: "error when update flow",
 			mockFunc: func() {
-				pondStore.EXPECT().Verify(gomock.Any()).Return(true, nil)
-				farmStore.EXPECT().Verify(gomock.Any()).Return(true, nil)
-				pondStore.EXPECT().GetPondByID(gomock.Any()).DoAndReturn(func(r *pond.PondInfraInfo) error {
+				pondStore.EXPECT().Verify(gomock.Any(), gomock.Any()).Return(true, nil)
+				farmStore.EXPECT().Verify(gomock.Any(), gomock.Any()).Return(true, nil)
+				pondStore.EXPECT().GetPondByID(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, r *pond.PondInfraInfo) error {
 					r.ID = 1
 					r.Capacity = 1
 					r.Depth = 1
@@ -414,8 +510,8 @@ func TestPond_UpdatePondInfo(t *testing.T) {
 					r.FarmID = 2
 					return nil
 				})
-				farmStore.EXPECT().GetActivePondsInFarm(gomock.Any()).Return([]uint{})
-				pondStore.EXPECT().Update(gomock.Any()).DoAndReturn(func(r *pond.PondInfraInfo) error {
+				farmStore.EXPECT().GetActivePondsInFarm(gomock.Any(), gomock.Any()).Return([]uint{})
+				pondStore.EXPECT().Update(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, r *pond.PondInfraInfo) error {
 					return fmt.Errorf("some error")
 				})
 			},
@@ -437,7 +533,7 @@ func TestPond_UpdatePondInfo(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockFunc()
 			s := NewPondDomain(pondStore, farmStore)
-			got, err := s.UpdatePondInfo(tt.args.r)
+			got, err := s.UpdatePondInfo(context.Background(), tt.args.r)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Pond.UpdatePondInfo() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -467,12 +563,12 @@ func TestPond_DeletePondInfo(t *testing.T) {
 		{
 			name: "Success Flow By Name",
 			mockFunc: func() {
-				pondStore.EXPECT().Verify(gomock.Any()).DoAndReturn(func(r *pond.PondInfraInfo) (bool, error) {
+				pondStore.EXPECT().Verify(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, r *pond.PondInfraInfo) (bool, error) {
 					r.ID = 1
 					r.Name = "P 1"
 					return true, nil
 				})
-				pondStore.EXPECT().Delete(gomock.Any()).Return(nil)
+				pondStore.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil)
 			},
 			args: args{
 				r: DeleteDomainRequest{
@@ -488,12 +584,12 @@ func TestPond_DeletePondInfo(t *testing.T) {
 		{
 			name: "Success Flow By ID",
 			mockFunc: func() {
-				pondStore.EXPECT().Verify(gomock.Any()).DoAndReturn(func(r *pond.PondInfraInfo) (bool, error) {
+				pondStore.EXPECT().Verify(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, r *pond.PondInfraInfo) (bool, error) {
 					r.ID = 1
 					r.Name = "P 1"
 					return true, nil
 				})
-				pondStore.EXPECT().Delete(gomock.Any()).Return(nil)
+				pondStore.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil)
 			},
 			args: args{
 				r: DeleteDomainRequest{
@@ -509,7 +605,7 @@ func TestPond_DeletePondInfo(t *testing.T) {
 		{
 			name: "Pond Not Exists",
 			mockFunc: func() {
-				pondStore.EXPECT().Verify(gomock.Any()).Return(false, nil)
+				pondStore.EXPECT().Verify(gomock.Any(), gomock.Any()).Return(false, nil)
 			},
 			args: args{
 				r: DeleteDomainRequest{
@@ -522,7 +618,7 @@ func TestPond_DeletePondInfo(t *testing.T) {
 		{
 			name: "error get pond info Exists",
 			mockFunc: func() {
-				pondStore.EXPECT().Verify(gomock.Any()).Return(false, fmt.Errorf("some error"))
+				pondStore.EXPECT().Verify(gomock.Any(), gomock.Any()).Return(false, fmt.Errorf("some error"))
 			},
 			args: args{
 				r: DeleteDomainRequest{
@@ -535,12 +631,12 @@ func TestPond_DeletePondInfo(t *testing.T) {
 		{
 			name: "Error Flow When Delete",
 			mockFunc: func() {
-				pondStore.EXPECT().Verify(gomock.Any()).DoAndReturn(func(r *pond.PondInfraInfo) (bool, error) {
+				pondStore.EXPECT().Verify(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, r *pond.PondInfraInfo) (bool, error) {
 					r.ID = 1
 					r.Name = "P 1"
 					return true, nil
 				})
-				pondStore.EXPECT().Delete(gomock.Any()).Return(fmt.Errorf("some error"))
+				pondStore.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(fmt.Errorf("some error"))
 			},
 			args: args{
 				r: DeleteDomainRequest{
@@ -566,7 +662,7 @@ func TestPond_DeletePondInfo(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockFunc()
 			s := NewPondDomain(pondStore, farmStore)
-			got, err := s.DeletePondInfo(tt.args.r)
+			got, err := s.DeletePondInfo(context.Background(), tt.args.r)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Pond.DeletePondInfo() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -580,6 +676,7 @@ func TestPond_DeletePondInfo(t *testing.T) {
 
 func TestPond_GetPondInfoByID(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
+	errSome := fmt.Errorf("some error")
 	defer mockCtrl.Finish()
 	farmStore := mock_farm.NewMockFarmStore(mockCtrl)
 	pondStore := mock_pond.NewMockPondStore(mockCtrl)
@@ -591,13 +688,13 @@ func TestPond_GetPondInfoByID(t *testing.T) {
 		mockFunc func()
 		args     args
 		want     GetPondInfoResponse
-		wantErr  bool
+		wantErr  error
 	}{
 		{
 			name: "success flow",
 			mockFunc: func() {
-				pondStore.EXPECT().GetPondByID(gomock.Any()).DoAndReturn(
-					func(r *pond.PondInfraInfo) error {
+				pondStore.EXPECT().GetPondByID(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, r *pond.PondInfraInfo) error {
 						r.ID = 1
 						r.Name = "P 1"
 						r.Capacity = 1
@@ -607,8 +704,8 @@ func TestPond_GetPondInfoByID(t *testing.T) {
 						r.FarmID = 1
 						return nil
 					})
-				farmStore.EXPECT().GetFarmByID(gomock.Any()).DoAndReturn(
-					func(r *farm.FarmInfraInfo) error {
+				farmStore.EXPECT().GetFarmByID(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, r *farm.FarmInfraInfo) error {
 						r.ID = 1
 						r.Area = "Area"
 						r.Location = "Location"
@@ -635,13 +732,13 @@ func TestPond_GetPondInfoByID(t *testing.T) {
 					Area:     "Area",
 				},
 			},
-			wantErr: false,
+			wantErr: nil,
 		},
 		{
 			name: "error when get farm info flow",
 			mockFunc: func() {
-				pondStore.EXPECT().GetPondByID(gomock.Any()).DoAndReturn(
-					func(r *pond.PondInfraInfo) error {
+				pondStore.EXPECT().GetPondByID(gomock.Any(), gomock.Any()).DoAndReturn(
+					func(_ context.Context, r *pond.PondInfraInfo) error {
 						r.ID = 1
 						r.Name = "P 1"
 						r.Capacity = 1
@@ -651,32 +748,55 @@ func TestPond_GetPondInfoByID(t *testing.T) {
 						r.FarmID = 1
 						return nil
 					})
-				farmStore.EXPECT().GetFarmByID(gomock.Any()).Return(fmt.Errorf("some error"))
+				farmStore.EXPECT().GetFarmByID(gomock.Any(), gomock.Any()).Return(errSome)
 			},
 			args: args{
 				ID: 1,
 			},
 			want:    GetPondInfoResponse{},
-			wantErr: true,
+			wantErr: errSome,
 		},
 		{
 			name: "error when get farm info flow",
 			mockFunc: func() {
-				pondStore.EXPECT().GetPondByID(gomock.Any()).Return(fmt.Errorf("some error"))
+				pondStore.EXPECT().GetPondByID(gomock.Any(), gomock.Any()).Return(errSome)
 			},
 			args: args{
 				ID: 1,
 			},
 			want:    GetPondInfoResponse{},
-			wantErr: true,
+			wantErr: errSome,
+		},
+		{
+			name: "pond not found flow",
+			mockFunc: func() {
+				pondStore.EXPECT().GetPondByID(gomock.Any(), gomock.Any()).Return(pond.ErrNotFound)
+			},
+			args: args{
+				ID: 1,
+			},
+			want:    GetPondInfoResponse{},
+			wantErr: ErrInvalidPond,
+		},
+		{
+			name: "farm not found flow",
+			mockFunc: func() {
+				pondStore.EXPECT().GetPondByID(gomock.Any(), gomock.Any()).Return(nil)
+				farmStore.EXPECT().GetFarmByID(gomock.Any(), gomock.Any()).Return(farm.ErrNotFound)
+			},
+			args: args{
+				ID: 1,
+			},
+			want:    GetPondInfoResponse{},
+			wantErr: ErrInvalidFarm,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockFunc()
 			s := NewPondDomain(pondStore, farmStore)
-			got, err := s.GetPondInfoByID(tt.args.ID)
-			if (err != nil) != tt.wantErr {
+			got, err := s.GetPondInfoByID(context.Background(), tt.args.ID)
+			if !errors.Is(err, tt.wantErr) {
 				t.Errorf("Pond.GetPondInfoByID() error = %v, wantErr %v", err, tt.wantErr)
 				return
 			}
@@ -707,7 +827,7 @@ func TestPond_GetAllPond(t *testing.T) {
 		{
 			name: "success flow",
 			mockFunc: func() {
-				pondStore.EXPECT().GetPondWithPaging(gomock.Any()).Return(
+				pondStore.EXPECT().GetPondWithPaging(gomock.Any(), gomock.Any()).Return(
 					[]pond.PondInfraInfo{
 						{
 							ID:           1,
@@ -760,7 +880,7 @@ func TestPond_GetAllPond(t *testing.T) {
 		{
 			name: "success flow",
 			mockFunc: func() {
-				pondStore.EXPECT().GetPondWithPaging(gomock.Any()).Return(
+				pondStore.EXPECT().GetPondWithPaging(gomock.Any(), gomock.Any()).Return(
 					[]pond.PondInfraInfo{
 						{
 							ID:           1,
@@ -813,7 +933,7 @@ func TestPond_GetAllPond(t *testing.T) {
 		{
 			name: "success flow",
 			mockFunc: func() {
-				pondStore.EXPECT().GetPondWithPaging(gomock.Any()).Return(
+				pondStore.EXPECT().GetPondWithPaging(gomock.Any(), gomock.Any()).Return(
 					[]pond.PondInfraInfo{}, fmt.Errorf("some error"),
 				)
 			},
@@ -829,7 +949,7 @@ func TestPond_GetAllPond(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockFunc()
 			s := NewPondDomain(pondStore, farmStore)
-			got, got1, err := s.GetAllPond(tt.args.size, tt.args.cursor)
+			got, got1, err := s.GetAllPond(context.Background(), tt.args.size, tt.args.cursor)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Pond.GetAllPond() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -843,3 +963,38 @@ func TestPond_GetAllPond(t *testing.T) {
 		})
 	}
 }
+
+func Test_mapStoreError(t *testing.T) {
+	someErr := fmt.Errorf("some error")
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{
+			name: "duplicate name",
+			err:  pond.ErrDuplicateName,
+			want: ErrDuplicatePond,
+		},
+		{
+			name: "farm does not exist",
+			err:  pond.ErrInvalidFarm,
+			want: ErrInvalidFarm,
+		},
+		{
+			name: "other error",
+			err:  someErr,
+			want: someErr,
+		},
+		{
+			name: "nil error",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mapStoreError(tt.err); got != tt.want {
+				t.Errorf("mapStoreError() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,15 +1,18 @@
 package pond
 
-import "errors"
+import "aqua-farm-manager/pkg/apperror"
 
 // list Domain error
 var (
-	ErrDuplicatePond = errors.New("Pond Is Already Exists")
-	ErrInvalidFarm   = errors.New("Farm Is Not Exists")
-	ErrInvalidPond   = errors.New("Pond Is Not Exists")
-	ErrMaxPond       = errors.New("Farm Already Have Max Ponds")
+	ErrDuplicatePond = apperror.New(apperror.CodePondAlreadyExists, "Pond Is Already Exists")
+	ErrInvalidFarm   = apperror.New(apperror.CodeFarmNotFound, "Farm Is Not Exists")
+	ErrInvalidPond   = apperror.New(apperror.CodePondNotFound, "Pond Is Not Exists")
+	ErrMaxPond       = apperror.New(apperror.CodePondLimitReached, "Farm Already Have Max Ponds")
 )
 
+// MaxPondInFarm is max number of active pond in a farm before new pond of the farm is rejected
+const MaxPondInFarm = 10
+
 // CreateDomainRequest struct is list parameter request for pond domain
 type CreateDomainRequest struct {
 	Name         string
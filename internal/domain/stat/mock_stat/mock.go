@@ -6,6 +6,7 @@ package mock_stat
 
 import (
 	stat "aqua-farm-manager/internal/domain/stat"
+	context "context"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -35,51 +36,41 @@ func (m *MockStatDomain) EXPECT() *MockStatDomainMockRecorder {
 }
 
 // BackUpStat mocks base method.
-func (m *MockStatDomain) BackUpStat() {
+func (m *MockStatDomain) BackUpStat(ctx context.Context) {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "BackUpStat")
+	m.ctrl.Call(m, "BackUpStat", ctx)
 }
 
 // BackUpStat indicates an expected call of BackUpStat.
-func (mr *MockStatDomainMockRecorder) BackUpStat() *gomock.Call {
+func (mr *MockStatDomainMockRecorder) BackUpStat(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BackUpStat", reflect.TypeOf((*MockStatDomain)(nil).BackUpStat))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BackUpStat", reflect.TypeOf((*MockStatDomain)(nil).BackUpStat), ctx)
 }
 
 // GenerateStatAPI mocks base method.
-func (m *MockStatDomain) GenerateStatAPI() map[string]stat.StatMetrics {
+func (m *MockStatDomain) GenerateStatAPI(ctx context.Context) map[string]stat.StatMetrics {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GenerateStatAPI")
+	ret := m.ctrl.Call(m, "GenerateStatAPI", ctx)
 	ret0, _ := ret[0].(map[string]stat.StatMetrics)
 	return ret0
 }
 
 // GenerateStatAPI indicates an expected call of GenerateStatAPI.
-func (mr *MockStatDomainMockRecorder) GenerateStatAPI() *gomock.Call {
+func (mr *MockStatDomainMockRecorder) GenerateStatAPI(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateStatAPI", reflect.TypeOf((*MockStatDomain)(nil).GenerateStatAPI))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateStatAPI", reflect.TypeOf((*MockStatDomain)(nil).GenerateStatAPI), ctx)
 }
 
 // IngestStatAPI mocks base method.
-func (m *MockStatDomain) IngestStatAPI(arg0 stat.IngestStatRequest) {
+func (m *MockStatDomain) IngestStatAPI(ctx context.Context, r stat.IngestStatRequest) error {
 	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "IngestStatAPI", arg0)
+	ret := m.ctrl.Call(m, "IngestStatAPI", ctx, r)
+	ret0, _ := ret[0].(error)
+	return ret0
 }
 
 // IngestStatAPI indicates an expected call of IngestStatAPI.
-func (mr *MockStatDomainMockRecorder) IngestStatAPI(arg0 interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IngestStatAPI", reflect.TypeOf((*MockStatDomain)(nil).IngestStatAPI), arg0)
-}
-
-// MigrateStat mocks base method.
-func (m *MockStatDomain) MigrateStat() {
-	m.ctrl.T.Helper()
-	m.ctrl.Call(m, "MigrateStat")
-}
-
-// MigrateStat indicates an expected call of MigrateStat.
-func (mr *MockStatDomainMockRecorder) MigrateStat() *gomock.Call {
+func (mr *MockStatDomainMockRecorder) IngestStatAPI(ctx, r interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MigrateStat", reflect.TypeOf((*MockStatDomain)(nil).MigrateStat))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IngestStatAPI", reflect.TypeOf((*MockStatDomain)(nil).IngestStatAPI), ctx, r)
 }
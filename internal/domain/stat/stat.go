@@ -3,20 +3,32 @@ package stat
 import (
 	"aqua-farm-manager/internal/app"
 	"aqua-farm-manager/internal/infrastructure/stat"
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
-	"sync"
 
 	"golang.org/x/crypto/sha3"
 )
 
 // StatDomain is list method for stat domain
 type StatDomain interface {
-	GenerateStatAPI() map[string]StatMetrics
-	IngestStatAPI(IngestStatRequest)
-	BackUpStat()
-	MigrateStat()
+	GenerateStatAPI(ctx context.Context) map[string]StatMetrics
+	IngestStatAPI(ctx context.Context, r IngestStatRequest) error
+	BackUpStat(ctx context.Context)
+}
+
+// list classification of ingest stat error, transient error may succeed when it retried
+// while permanent error always fail for the same request
+var (
+	ErrTransientIngest = errors.New("transient ingest stat error")
+	ErrPermanentIngest = errors.New("permanent ingest stat error")
+)
+
+// IsTransientError is func to check ingest stat error is worth to retry
+func IsTransientError(err error) bool {
+	return errors.Is(err, ErrTransientIngest)
 }
 
 type IngestStatRequest struct {
@@ -46,92 +58,101 @@ func NewStatDomain(store stat.StatStore) StatDomain {
 	}
 }
 
-// GenerateStatAPI is func to generate stat info for all api
-func (s *Stat) GenerateStatAPI() map[string]StatMetrics {
-	var metrics = make(map[string]StatMetrics, app.Limit-1)
-	for id := app.UrlID(1); id < app.Limit; id++ {
+// GenerateStatAPI is func to generate stat info for all api,
+// the total is the backup in database added with delta in redis that not yet flushed,
+// it return the stat generated so far when ctx is done
+func (s *Stat) GenerateStatAPI(ctx context.Context) map[string]StatMetrics {
+	var metrics = make(map[string]StatMetrics, len(app.TrackedUrlID))
+	for _, id := range app.TrackedUrlID {
+		if ctx.Err() != nil {
+			break
+		}
 		url := strconv.Itoa(id.Int())
 		listmethod := app.UrlIDMethod[id]
 		for _, method := range listmethod {
-			metric, err := s.store.GetMetrics(stat.GetMetricsRequest{
+			var total StatMetrics
+
+			data, err := s.store.GetStatData(ctx, stat.GetStatDataRequest{
 				UrlID:  url,
 				Method: method,
 			})
-			if err != nil {
-				// get data using database
-				metric, err = s.store.GetStatData(stat.GetStatDataRequest{
-					UrlID:  url,
-					Method: method,
-				})
-				if err != nil {
-					continue
-				}
+			if err == nil {
+				total = addMetrics(total, data)
 			}
 
-			count_req, _ := strconv.Atoi(metric.NumRequest)
-			count_ua, _ := strconv.Atoi(metric.NumUniqAgent)
-			count_suc, _ := strconv.Atoi(metric.NumSuccess)
-			count_err, _ := strconv.Atoi(metric.NumError)
-			if count_req != 0 || count_ua != 0 {
+			delta, err := s.store.GetMetrics(ctx, stat.GetMetricsRequest{
+				UrlID:  url,
+				Method: method,
+			})
+			if err == nil {
+				total = addMetrics(total, delta)
+			}
+
+			if total.NumRequested != 0 || total.NumUniqAgent != 0 {
 				key := method + " " + id.String()
-				metrics[key] = StatMetrics{
-					NumUniqAgent: count_ua,
-					NumRequested: count_req,
-					NumSuccess:   count_suc,
-					NumError:     count_err,
-				}
+				metrics[key] = total
 			}
 		}
 	}
 	return metrics
 }
 
-// IngestStatAPI is func to ingest stat metrics based on path and method
-func (s *Stat) IngestStatAPI(r IngestStatRequest) {
+// addMetrics is func to add metrics info into stat metrics
+func addMetrics(total StatMetrics, metric stat.MetricsInfo) StatMetrics {
+	count_req, _ := strconv.Atoi(metric.NumRequest)
+	count_ua, _ := strconv.Atoi(metric.NumUniqAgent)
+	count_suc, _ := strconv.Atoi(metric.NumSuccess)
+	count_err, _ := strconv.Atoi(metric.NumError)
+
+	total.NumRequested += count_req
+	total.NumUniqAgent += count_ua
+	total.NumSuccess += count_suc
+	total.NumError += count_err
+	return total
+}
+
+// IngestStatAPI is func to ingest stat metrics based on path and method,
+// unknown or untracked path is ignored and store error is wrapped with ErrTransientIngest or ErrPermanentIngest
+func (s *Stat) IngestStatAPI(ctx context.Context, r IngestStatRequest) error {
 	urlID := app.UrlIDValue[r.Path]
+	if !urlID.IsTracked() {
+		return nil
+	}
 
-	if urlID.Int() != 0 {
-		hash := fmt.Sprintf("%x", sha3.Sum256([]byte(r.Ua)))
-		url := strconv.Itoa(urlID.Int())
-		err := s.store.IngestMetrics(
-			stat.IngestMetricsRequest{
-				UrlID:     url,
-				Method:    r.Method,
-				UA:        hash,
-				IsSuccess: r.Code == http.StatusOK,
-			},
-		)
-		if err != nil {
-			fmt.Println("[IngestStatAPI]-Got Error:", err)
+	hash := fmt.Sprintf("%x", sha3.Sum256([]byte(r.Ua)))
+	url := strconv.Itoa(urlID.Int())
+	err := s.store.IngestMetrics(ctx,
+		stat.IngestMetricsRequest{
+			UrlID:     url,
+			Method:    r.Method,
+			UA:        hash,
+			IsSuccess: r.Code == http.StatusOK,
+		},
+	)
+	if err != nil {
+		fmt.Println("[IngestStatAPI]-Got Error:", err)
+		if stat.IsTransientError(err) {
+			return fmt.Errorf("%w: %v", ErrTransientIngest, err)
 		}
+		return fmt.Errorf("%w: %v", ErrPermanentIngest, err)
 	}
+	return nil
 }
 
-// BackUpStat is func to backup data from redis to postgres
-func (s *Stat) BackUpStat() {
-	for id := app.UrlID(1); id < app.Limit; id++ {
+// BackUpStat is func to flush stat delta from redis to postgres and compact old stat data.
+// It also finish any flush left by a previous crash, so it is safe to call on startup.
+// The flush stop when ctx is done and the rest is flushed by the next call
+func (s *Stat) BackUpStat(ctx context.Context) {
+	for _, id := range app.TrackedUrlID {
+		if ctx.Err() != nil {
+			break
+		}
 		url := strconv.Itoa(id.Int())
 		listmethod := app.UrlIDMethod[id]
 		for _, method := range listmethod {
-			metric, err := s.store.GetMetrics(stat.GetMetricsRequest{UrlID: url, Method: method})
-			if err != nil {
-				continue
-			}
-
-			count_req, _ := strconv.Atoi(metric.NumRequest)
-			count_ua, _ := strconv.Atoi(metric.NumUniqAgent)
-			count_suc, _ := strconv.Atoi(metric.NumSuccess)
-			count_err, _ := strconv.Atoi(metric.NumError)
-
-			err = s.store.BackupMetrics(stat.BackupMetricsRequest{
+			err := s.store.BackupMetrics(ctx, stat.BackupMetricsRequest{
 				UrlID:  url,
 				Method: method,
-				Metrics: stat.MetricsRequest{
-					NumRequest:   count_req,
-					NumUniqAgent: count_ua,
-					NumSuccess:   count_suc,
-					NumError:     count_err,
-				},
 			})
 			if err != nil {
 				fmt.Println("[BackUpStat]-Got Error:", err)
@@ -139,39 +160,9 @@ func (s *Stat) BackUpStat() {
 			}
 		}
 	}
-}
 
-// MigrateStat is func to migrate data from postgres to redis
-func (s *Stat) MigrateStat() {
-	var wg sync.WaitGroup
-	for id := app.UrlID(1); id < app.Limit; id++ {
-		url := strconv.Itoa(id.Int())
-		listmethod := app.UrlIDMethod[id]
-		for _, method := range listmethod {
-			wg.Add(1)
-			go func(url, method string) {
-				defer wg.Done()
-				metric, err := s.store.GetStatData(stat.GetStatDataRequest{
-					UrlID:  url,
-					Method: method,
-				})
-				if err != nil {
-					return
-				}
-
-				err = s.store.MigrateMetrics(
-					stat.MigrateMetricsRequest{
-						UrlID:   url,
-						Method:  method,
-						Metrics: metric,
-					},
-				)
-				if err != nil {
-					fmt.Println("[MigrateStat]-Got Error:", err)
-					return
-				}
-			}(url, method)
-		}
+	err := s.store.CompactMetrics(ctx)
+	if err != nil {
+		fmt.Println("[BackUpStat]-Got Error Compact:", err)
 	}
-	wg.Wait()
 }
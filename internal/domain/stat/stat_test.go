@@ -1,10 +1,14 @@
 package stat
 
 import (
+	"aqua-farm-manager/internal/app"
 	"aqua-farm-manager/internal/infrastructure/stat"
 	"aqua-farm-manager/internal/infrastructure/stat/mock_stat"
+	"context"
+	"errors"
 	"fmt"
 	"reflect"
+	"strconv"
 	"testing"
 
 	"github.com/golang/mock/gomock"
@@ -36,6 +40,26 @@ func TestNewStatDomain(t *testing.T) {
 	}
 }
 
+// expectEmptyStat is func to mock empty stat of every tracked url except farms and ponds
+func expectEmptyStat(r *mock_stat.MockStatStore) {
+	for _, id := range app.TrackedUrlID[2:] {
+		for _, method := range id.GetListMethod() {
+			url := strconv.Itoa(id.Int())
+			r.EXPECT().GetStatData(gomock.Any(), stat.GetStatDataRequest{UrlID: url, Method: method}).Return(stat.MetricsInfo{}, fmt.Errorf("record not found"))
+			r.EXPECT().GetMetrics(gomock.Any(), stat.GetMetricsRequest{UrlID: url, Method: method}).Return(stat.MetricsInfo{}, nil)
+		}
+	}
+}
+
+// countTrackedMethod is func to count every method of tracked url
+func countTrackedMethod() int {
+	var count int
+	for _, id := range app.TrackedUrlID {
+		count += len(id.GetListMethod())
+	}
+	return count
+}
+
 func TestStat_GenerateStatAPI(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -45,97 +69,78 @@ func TestStat_GenerateStatAPI(t *testing.T) {
 		{
 			name: "success flow",
 			mockFunc: func(r *mock_stat.MockStatStore) {
-				r.EXPECT().GetMetrics(stat.GetMetricsRequest{
-					UrlID:  "1",
-					Method: "GET",
-				}).Return(stat.MetricsInfo{NumRequest: "1", NumUniqAgent: "1", NumSuccess: "1", NumError: "1"}, nil)
-				r.EXPECT().GetMetrics(stat.GetMetricsRequest{
-					UrlID:  "1",
-					Method: "PUT",
-				}).Return(stat.MetricsInfo{NumRequest: "1", NumUniqAgent: "1", NumSuccess: "1", NumError: "1"}, nil)
-				r.EXPECT().GetMetrics(stat.GetMetricsRequest{
-					UrlID:  "1",
-					Method: "POST",
-				}).Return(stat.MetricsInfo{NumRequest: "1", NumUniqAgent: "1", NumSuccess: "1", NumError: "1"}, nil)
-				r.EXPECT().GetMetrics(stat.GetMetricsRequest{
-					UrlID:  "1",
-					Method: "DELETE",
-				}).Return(stat.MetricsInfo{NumRequest: "1", NumUniqAgent: "1", NumSuccess: "1", NumError: "1"}, nil)
-				r.EXPECT().GetMetrics(stat.GetMetricsRequest{
-					UrlID:  "2",
-					Method: "GET",
-				}).Return(stat.MetricsInfo{NumRequest: "1", NumUniqAgent: "2", NumSuccess: "1", NumError: "1"}, nil)
-				r.EXPECT().GetMetrics(stat.GetMetricsRequest{
-					UrlID:  "2",
-					Method: "PUT",
-				}).Return(stat.MetricsInfo{NumRequest: "1", NumUniqAgent: "2", NumSuccess: "1", NumError: "1"}, nil)
-				r.EXPECT().GetMetrics(stat.GetMetricsRequest{
-					UrlID:  "2",
-					Method: "POST",
-				}).Return(stat.MetricsInfo{NumRequest: "1", NumUniqAgent: "2", NumSuccess: "1", NumError: "1"}, nil)
-				r.EXPECT().GetMetrics(stat.GetMetricsRequest{
-					UrlID:  "2",
-					Method: "DELETE",
-				}).Return(stat.MetricsInfo{NumRequest: "1", NumUniqAgent: "2", NumSuccess: "1", NumError: "1"}, nil)
+				for _, method := range []string{"GET", "PUT", "POST", "DELETE"} {
+					r.EXPECT().GetStatData(gomock.Any(), stat.GetStatDataRequest{
+						UrlID:  "1",
+						Method: method,
+					}).Return(stat.MetricsInfo{NumRequest: "10", NumUniqAgent: "2", NumSuccess: "8", NumError: "2"}, nil)
+					r.EXPECT().GetMetrics(gomock.Any(), stat.GetMetricsRequest{
+						UrlID:  "1",
+						Method: method,
+					}).Return(stat.MetricsInfo{NumRequest: "1", NumUniqAgent: "1", NumSuccess: "1", NumError: "0"}, nil)
+					r.EXPECT().GetStatData(gomock.Any(), stat.GetStatDataRequest{
+						UrlID:  "2",
+						Method: method,
+					}).Return(stat.MetricsInfo{NumRequest: "0", NumUniqAgent: "0", NumSuccess: "0", NumError: "0"}, nil)
+					r.EXPECT().GetMetrics(gomock.Any(), stat.GetMetricsRequest{
+						UrlID:  "2",
+						Method: method,
+					}).Return(stat.MetricsInfo{NumRequest: "1", NumUniqAgent: "2", NumSuccess: "1", NumError: "1"}, nil)
+				}
+				expectEmptyStat(r)
 			},
 			want: map[string]StatMetrics{
-				"DELETE /v1/farms": {1, 1, 1, 1},
+				"DELETE /v1/farms": {3, 11, 9, 2},
 				"DELETE /v1/ponds": {2, 1, 1, 1},
-				"GET /v1/farms":    {1, 1, 1, 1},
+				"GET /v1/farms":    {3, 11, 9, 2},
 				"GET /v1/ponds":    {2, 1, 1, 1},
-				"POST /v1/farms":   {1, 1, 1, 1},
+				"POST /v1/farms":   {3, 11, 9, 2},
 				"POST /v1/ponds":   {2, 1, 1, 1},
-				"PUT /v1/farms":    {1, 1, 1, 1},
+				"PUT /v1/farms":    {3, 11, 9, 2},
 				"PUT /v1/ponds":    {2, 1, 1, 1},
 			},
 		},
 		{
 			name: "partial error flow",
 			mockFunc: func(r *mock_stat.MockStatStore) {
-				r.EXPECT().GetMetrics(stat.GetMetricsRequest{
-					UrlID:  "1",
-					Method: "GET",
-				}).Return(stat.MetricsInfo{NumRequest: "1", NumUniqAgent: "1", NumSuccess: "1", NumError: "1"}, nil)
-				r.EXPECT().GetMetrics(stat.GetMetricsRequest{
-					UrlID:  "1",
-					Method: "PUT",
-				}).Return(stat.MetricsInfo{NumRequest: "1", NumUniqAgent: "1", NumSuccess: "1", NumError: "1"}, nil)
-				r.EXPECT().GetMetrics(stat.GetMetricsRequest{
-					UrlID:  "1",
-					Method: "POST",
-				}).Return(stat.MetricsInfo{NumRequest: "1", NumUniqAgent: "1", NumSuccess: "1", NumError: "1"}, nil)
-				r.EXPECT().GetMetrics(stat.GetMetricsRequest{
-					UrlID:  "1",
-					Method: "DELETE",
-				}).Return(stat.MetricsInfo{NumRequest: "1", NumUniqAgent: "1", NumSuccess: "1", NumError: "1"}, nil)
-				r.EXPECT().GetMetrics(stat.GetMetricsRequest{
-					UrlID:  "2",
-					Method: "GET",
-				}).Return(stat.MetricsInfo{}, fmt.Errorf("some error"))
-				r.EXPECT().GetMetrics(stat.GetMetricsRequest{
-					UrlID:  "2",
-					Method: "PUT",
-				}).Return(stat.MetricsInfo{}, fmt.Errorf("some error"))
-				r.EXPECT().GetMetrics(stat.GetMetricsRequest{
-					UrlID:  "2",
-					Method: "POST",
-				}).Return(stat.MetricsInfo{}, fmt.Errorf("some error"))
-				r.EXPECT().GetMetrics(stat.GetMetricsRequest{
-					UrlID:  "2",
-					Method: "DELETE",
-				}).Return(stat.MetricsInfo{}, fmt.Errorf("some error"))
-				r.EXPECT().GetStatData(gomock.Any()).Return(stat.MetricsInfo{NumRequest: "1", NumUniqAgent: "1", NumSuccess: "1", NumError: "1"}, nil).Times(4)
+				for _, method := range []string{"GET", "PUT", "POST", "DELETE"} {
+					r.EXPECT().GetStatData(gomock.Any(), stat.GetStatDataRequest{
+						UrlID:  "1",
+						Method: method,
+					}).Return(stat.MetricsInfo{}, fmt.Errorf("record not found"))
+					r.EXPECT().GetMetrics(gomock.Any(), stat.GetMetricsRequest{
+						UrlID:  "1",
+						Method: method,
+					}).Return(stat.MetricsInfo{NumRequest: "1", NumUniqAgent: "1", NumSuccess: "1", NumError: "0"}, nil)
+					r.EXPECT().GetStatData(gomock.Any(), stat.GetStatDataRequest{
+						UrlID:  "2",
+						Method: method,
+					}).Return(stat.MetricsInfo{NumRequest: "5", NumUniqAgent: "1", NumSuccess: "5", NumError: "0"}, nil)
+					r.EXPECT().GetMetrics(gomock.Any(), stat.GetMetricsRequest{
+						UrlID:  "2",
+						Method: method,
+					}).Return(stat.MetricsInfo{}, fmt.Errorf("some error"))
+				}
+				expectEmptyStat(r)
 			},
 			want: map[string]StatMetrics{
-				"DELETE /v1/farms": {1, 1, 1, 1},
-				"GET /v1/farms":    {1, 1, 1, 1},
-				"POST /v1/farms":   {1, 1, 1, 1},
-				"PUT /v1/farms":    {1, 1, 1, 1},
-				"DELETE /v1/ponds": {1, 1, 1, 1},
-				"GET /v1/ponds":    {1, 1, 1, 1},
-				"POST /v1/ponds":   {1, 1, 1, 1},
-				"PUT /v1/ponds":    {1, 1, 1, 1},
+				"DELETE /v1/farms": {1, 1, 1, 0},
+				"GET /v1/farms":    {1, 1, 1, 0},
+				"POST /v1/farms":   {1, 1, 1, 0},
+				"PUT /v1/farms":    {1, 1, 1, 0},
+				"DELETE /v1/ponds": {1, 5, 5, 0},
+				"GET /v1/ponds":    {1, 5, 5, 0},
+				"POST /v1/ponds":   {1, 5, 5, 0},
+				"PUT /v1/ponds":    {1, 5, 5, 0},
+			},
+		},
+		{
+			name: "empty data flow",
+			mockFunc: func(r *mock_stat.MockStatStore) {
+				r.EXPECT().GetStatData(gomock.Any(), gomock.Any()).Return(stat.MetricsInfo{}, fmt.Errorf("record not found")).Times(countTrackedMethod())
+				r.EXPECT().GetMetrics(gomock.Any(), gomock.Any()).Return(stat.MetricsInfo{NumRequest: "0", NumUniqAgent: "0", NumSuccess: "0", NumError: "0"}, nil).Times(countTrackedMethod())
 			},
+			want: map[string]StatMetrics{},
 		},
 	}
 	for _, tt := range tests {
@@ -147,7 +152,7 @@ func TestStat_GenerateStatAPI(t *testing.T) {
 			tt.mockFunc(infra)
 			s := NewStatDomain(infra)
 
-			if got := s.GenerateStatAPI(); !reflect.DeepEqual(got, tt.want) {
+			if got := s.GenerateStatAPI(context.Background()); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("Stat.GenerateStatAPI() = %v, want %v", got, tt.want)
 			}
 		})
@@ -165,6 +170,7 @@ func TestStat_IngestStatAPI(t *testing.T) {
 		name     string
 		mockFunc func(r *mock_stat.MockStatStore)
 		args     args
+		wantErr  error
 	}{
 		{
 			name: "success flow",
@@ -175,7 +181,7 @@ func TestStat_IngestStatAPI(t *testing.T) {
 				code:   200,
 			},
 			mockFunc: func(r *mock_stat.MockStatStore) {
-				r.EXPECT().IngestMetrics(stat.IngestMetricsRequest{
+				r.EXPECT().IngestMetrics(gomock.Any(), stat.IngestMetricsRequest{
 					UrlID:     "1",
 					Method:    "GET",
 					UA:        "3a985da74fe225b2045c172d6bd390bd855f086e3e9d525b46bfe24511431532",
@@ -191,8 +197,56 @@ func TestStat_IngestStatAPI(t *testing.T) {
 				ua:     "abc",
 			},
 			mockFunc: func(r *mock_stat.MockStatStore) {
-				r.EXPECT().IngestMetrics(gomock.Any()).Return(fmt.Errorf("some error"))
+				r.EXPECT().IngestMetrics(gomock.Any(), gomock.Any()).Return(fmt.Errorf("some error"))
+			},
+			wantErr: ErrTransientIngest,
+		},
+		{
+			name: "got permanent error flow",
+			args: args{
+				path:   "/v1/ponds",
+				method: "GET",
+				ua:     "abc",
+			},
+			mockFunc: func(r *mock_stat.MockStatStore) {
+				r.EXPECT().IngestMetrics(gomock.Any(), gomock.Any()).Return(stat.ErrInvalidMetrics)
+			},
+			wantErr: ErrPermanentIngest,
+		},
+		{
+			name: "tracked webhook path flow",
+			args: args{
+				path:   "/v1/webhooks",
+				method: "POST",
+				ua:     "abc",
+				code:   200,
+			},
+			mockFunc: func(r *mock_stat.MockStatStore) {
+				r.EXPECT().IngestMetrics(gomock.Any(), stat.IngestMetricsRequest{
+					UrlID:     "7",
+					Method:    "POST",
+					UA:        "3a985da74fe225b2045c172d6bd390bd855f086e3e9d525b46bfe24511431532",
+					IsSuccess: true,
+				}).Return(nil)
+			},
+		},
+		{
+			name: "untracked path flow",
+			args: args{
+				path:   "/v1/stat",
+				method: "GET",
+				ua:     "abc",
+			},
+			mockFunc: func(r *mock_stat.MockStatStore) {},
+		},
+		{
+			name: "unknown path flow",
+			args: args{
+				path:   "/v1/unknown",
+				method: "GET",
+				ua:     "abc",
 			},
+			mockFunc: func(r *mock_stat.MockStatStore) {},
 		},
 	}
 	for _, tt := range tests {
@@ -203,12 +257,15 @@ func TestStat_IngestStatAPI(t *testing.T) {
 
 			tt.mockFunc(infra)
 			s := NewStatDomain(infra)
-			s.IngestStatAPI(IngestStatRequest{
+			err := s.IngestStatAPI(context.Background(), IngestStatRequest{
 				Path:   tt.args.path,
 				Method: tt.args.method,
 				Ua:     tt.args.ua,
 				Code:   tt.args.code,
 			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Stat.IngestStatAPI() error = %v, wantErr %v", err, tt.wantErr)
+			}
 		})
 	}
 }
@@ -221,10 +278,23 @@ func TestStat_BackUpStat(t *testing.T) {
 		{
 			name: "success flow",
 			mockFunc: func(r *mock_stat.MockStatStore) {
-				r.EXPECT().GetMetrics(gomock.Any()).Times(4)
-				r.EXPECT().BackupMetrics(gomock.Any()).Return(nil).Times(4)
-				r.EXPECT().GetMetrics(gomock.Any()).Return(stat.MetricsInfo{NumRequest: "1", NumUniqAgent: "1", NumSuccess: "1", NumError: "1"}, nil).Times(4)
-				r.EXPECT().BackupMetrics(gomock.Any()).Return(nil).Times(4)
+				for _, id := range app.TrackedUrlID {
+					for _, method := range id.GetListMethod() {
+						r.EXPECT().BackupMetrics(gomock.Any(), stat.BackupMetricsRequest{
+							UrlID:  strconv.Itoa(id.Int()),
+							Method: method,
+						}).Return(nil)
+					}
+				}
+				r.EXPECT().CompactMetrics(gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name: "partial error flow",
+			mockFunc: func(r *mock_stat.MockStatStore) {
+				r.EXPECT().BackupMetrics(gomock.Any(), gomock.Any()).Return(fmt.Errorf("some error")).Times(4)
+				r.EXPECT().BackupMetrics(gomock.Any(), gomock.Any()).Return(nil).Times(countTrackedMethod() - 4)
+				r.EXPECT().CompactMetrics(gomock.Any()).Return(fmt.Errorf("some error"))
 			},
 		},
 	}
@@ -236,129 +306,38 @@ func TestStat_BackUpStat(t *testing.T) {
 
 			tt.mockFunc(infra)
 			s := NewStatDomain(infra)
-			s.BackUpStat()
+			s.BackUpStat(context.Background())
 		})
 	}
 }
 
-func TestStat_MigrateStat(t *testing.T) {
-	metric1 := stat.MetricsInfo{
-		NumRequest:   "1",
-		NumUniqAgent: "1",
-		NumSuccess:   "1",
-		NumError:     "1",
-	}
-	metric2 := stat.MetricsInfo{
-		NumRequest:   "2",
-		NumUniqAgent: "2",
-		NumSuccess:   "2",
-		NumError:     "2",
-	}
-	mockCtrl := gomock.NewController(t)
-	defer mockCtrl.Finish()
-	infra := mock_stat.NewMockStatStore(mockCtrl)
+func TestIsTransientError(t *testing.T) {
 	tests := []struct {
-		name     string
-		mockFunc func(r *mock_stat.MockStatStore)
+		name string
+		err  error
+		want bool
 	}{
 		{
-			name: "success flow",
-			mockFunc: func(r *mock_stat.MockStatStore) {
-				r.EXPECT().GetStatData(stat.GetStatDataRequest{
-					UrlID:  "1",
-					Method: "GET",
-				}).Return(metric1, nil)
-				r.EXPECT().GetStatData(stat.GetStatDataRequest{
-					UrlID:  "1",
-					Method: "POST",
-				}).Return(metric1, nil)
-				r.EXPECT().GetStatData(stat.GetStatDataRequest{
-					UrlID:  "1",
-					Method: "PUT",
-				}).Return(metric1, nil)
-				r.EXPECT().GetStatData(stat.GetStatDataRequest{
-					UrlID:  "1",
-					Method: "DELETE",
-				}).Return(metric1, nil)
-				r.EXPECT().GetStatData(stat.GetStatDataRequest{
-					UrlID:  "2",
-					Method: "GET",
-				}).Return(metric2, nil)
-				r.EXPECT().GetStatData(stat.GetStatDataRequest{
-					UrlID:  "2",
-					Method: "POST",
-				}).Return(metric2, nil)
-				r.EXPECT().GetStatData(stat.GetStatDataRequest{
-					UrlID:  "2",
-					Method: "PUT",
-				}).Return(metric2, nil)
-				r.EXPECT().GetStatData(stat.GetStatDataRequest{
-					UrlID:  "2",
-					Method: "DELETE",
-				}).Return(metric2, nil)
-
-				r.EXPECT().MigrateMetrics(stat.MigrateMetricsRequest{
-					UrlID:   "1",
-					Method:  "GET",
-					Metrics: metric1,
-				}).Return(nil)
-				r.EXPECT().MigrateMetrics(stat.MigrateMetricsRequest{
-					UrlID:   "1",
-					Method:  "POST",
-					Metrics: metric1,
-				}).Return(nil)
-				r.EXPECT().MigrateMetrics(stat.MigrateMetricsRequest{
-					UrlID:   "1",
-					Method:  "PUT",
-					Metrics: metric1,
-				}).Return(nil)
-				r.EXPECT().MigrateMetrics(stat.MigrateMetricsRequest{
-					UrlID:   "1",
-					Method:  "DELETE",
-					Metrics: metric1,
-				}).Return(nil)
-				r.EXPECT().MigrateMetrics(stat.MigrateMetricsRequest{
-					UrlID:   "2",
-					Method:  "GET",
-					Metrics: metric2,
-				}).Return(nil)
-				r.EXPECT().MigrateMetrics(stat.MigrateMetricsRequest{
-					UrlID:   "2",
-					Method:  "POST",
-					Metrics: metric2,
-				}).Return(nil)
-				r.EXPECT().MigrateMetrics(stat.MigrateMetricsRequest{
-					UrlID:   "2",
-					Method:  "PUT",
-					Metrics: metric2,
-				}).Return(nil)
-				r.EXPECT().MigrateMetrics(stat.MigrateMetricsRequest{
-					UrlID:   "2",
-					Method:  "DELETE",
-					Metrics: metric2,
-				}).Return(nil)
-			},
+			name: "transient error",
+			err:  fmt.Errorf("%w: some error", ErrTransientIngest),
+			want: true,
 		},
 		{
-			name: "error migrate flow",
-			mockFunc: func(r *mock_stat.MockStatStore) {
-				r.EXPECT().GetStatData(gomock.Any()).Return(metric1, nil).AnyTimes()
-
-				r.EXPECT().MigrateMetrics(gomock.Any()).Return(fmt.Errorf("some error")).AnyTimes()
-			},
+			name: "permanent error",
+			err:  fmt.Errorf("%w: some error", ErrPermanentIngest),
+			want: false,
 		},
 		{
-			name: "error get stat flow",
-			mockFunc: func(r *mock_stat.MockStatStore) {
-				r.EXPECT().GetStatData(gomock.Any()).Return(metric1, fmt.Errorf("some error")).AnyTimes()
-			},
+			name: "nil error",
+			err:  nil,
+			want: false,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			tt.mockFunc(infra)
-			s := NewStatDomain(infra)
-			s.MigrateStat()
+			if got := IsTransientError(tt.err); got != tt.want {
+				t.Errorf("IsTransientError() = %v, want %v", got, tt.want)
+			}
 		})
 	}
 }
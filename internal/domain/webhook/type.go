@@ -0,0 +1,74 @@
+package webhook
+
+import (
+	"time"
+
+	"aqua-farm-manager/pkg/apperror"
+)
+
+// list Domain error
+var (
+	ErrInvalidURL       = apperror.Invalid("url", "Webhook URL Must Be Valid HTTPS URL")
+	ErrPrivateURL       = apperror.Invalid("url", "Webhook URL Must Not Target Loopback, Private Or Link Local Address")
+	ErrInvalidEventType = apperror.Invalid("event_types", "Invalid Event Type")
+	ErrInvalidSecret    = apperror.Invalid("secret", "Webhook Secret Must Have At Least 16 Characters")
+	ErrInvalidWebhook   = apperror.New(apperror.CodeWebhookNotFound, "Webhook Is Not Exists")
+)
+
+// AllEventTypes is event type filter to subscribe every event
+const AllEventTypes = "*"
+
+// minSecretLength is min length of shared secret to sign the payload
+const minSecretLength = 16
+
+// CreateDomainRequest struct is list parameter for Register Webhook domain
+type CreateDomainRequest struct {
+	URL        string
+	EventTypes []string
+	Secret     string
+}
+
+// CreateDomainResponse struct is list parameter response for Register Webhook domain
+type CreateDomainResponse struct {
+	ID uint
+}
+
+// WebhookInfo struct is list parameter response for webhook, the secret is never returned
+type WebhookInfo struct {
+	ID         uint
+	URL        string
+	EventTypes []string
+	CreatedAt  time.Time
+}
+
+// DeliveryInfo struct is list parameter response for a delivery attempt of webhook
+type DeliveryInfo struct {
+	ID         uint
+	EventID    string
+	EventType  string
+	Attempt    int
+	StatusCode int
+	Success    bool
+	Error      string
+	Duration   time.Duration
+	CreatedAt  time.Time
+}
+
+// Subscriber struct is webhook that subscribe an event type
+type Subscriber struct {
+	ID     uint
+	URL    string
+	Secret string
+}
+
+// RecordDeliveryRequest struct is list parameter for RecordDelivery domain
+type RecordDeliveryRequest struct {
+	WebhookID  uint
+	EventID    string
+	EventType  string
+	Attempt    int
+	StatusCode int
+	Success    bool
+	Error      string
+	Duration   time.Duration
+}
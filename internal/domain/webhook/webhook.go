@@ -0,0 +1,225 @@
+package webhook
+
+import (
+	"aqua-farm-manager/internal/domain/event"
+	"aqua-farm-manager/internal/infrastructure/webhook"
+	"context"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// WebhookDomain is list method for webhook domain
+type WebhookDomain interface {
+	RegisterWebhook(ctx context.Context, r CreateDomainRequest) (CreateDomainResponse, error)
+	DeleteWebhook(ctx context.Context, ID uint) error
+	GetWebhooks(ctx context.Context, size, cursor int) ([]WebhookInfo, int, error)
+	GetDeliveries(ctx context.Context, ID uint, limit int) ([]DeliveryInfo, error)
+	GetSubscribers(ctx context.Context, eventType string) ([]Subscriber, error)
+	RecordDelivery(ctx context.Context, r RecordDeliveryRequest) error
+}
+
+// Webhook is list dependencies webhook domain
+type Webhook struct {
+	store webhook.WebhookStore
+}
+
+// NewWebhookDomain is func to generate WebhookDomain interface
+func NewWebhookDomain(store webhook.WebhookStore) WebhookDomain {
+	return &Webhook{
+		store: store,
+	}
+}
+
+// RegisterWebhook is func to validate and store webhook subscription
+func (w *Webhook) RegisterWebhook(ctx context.Context, r CreateDomainRequest) (CreateDomainResponse, error) {
+	var res CreateDomainResponse
+
+	u, err := url.Parse(r.URL)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		return res, ErrInvalidURL
+	}
+
+	if !publicHost(u.Hostname()) {
+		return res, ErrPrivateURL
+	}
+
+	if !validEventTypes(r.EventTypes) {
+		return res, ErrInvalidEventType
+	}
+
+	if len(r.Secret) < minSecretLength {
+		return res, ErrInvalidSecret
+	}
+
+	infra := &webhook.WebhookInfraInfo{
+		URL:        r.URL,
+		EventTypes: r.EventTypes,
+		Secret:     r.Secret,
+	}
+	err = w.store.Create(ctx, infra)
+	if err != nil {
+		return res, err
+	}
+
+	res.ID = infra.ID
+	return res, nil
+}
+
+// publicHost is func to check the host is not localhost or a non public ip, the host name that
+// resolve into non public ip is rejected by the deliverer at dial time
+func publicHost(host string) bool {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return false
+	}
+
+	ip := net.ParseIP(host)
+	return ip == nil || PublicIP(ip)
+}
+
+// PublicIP is func to check the ip is not loopback, private, link local, unspecified or multicast address
+func PublicIP(ip net.IP) bool {
+	return !ip.IsLoopback() && !ip.IsPrivate() && !ip.IsLinkLocalUnicast() && !ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() && !ip.IsMulticast() && !ip.IsUnspecified()
+}
+
+// validEventTypes is func to check every event type is known or subscribe all event
+func validEventTypes(eventTypes []string) bool {
+	if len(eventTypes) == 0 {
+		return false
+	}
+
+	for _, t := range eventTypes {
+		if t == AllEventTypes {
+			continue
+		}
+		known := false
+		for _, k := range event.Types {
+			if t == k.String() {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return false
+		}
+	}
+	return true
+}
+
+// DeleteWebhook is func to soft delete webhook so it does not receive event anymore
+func (w *Webhook) DeleteWebhook(ctx context.Context, ID uint) error {
+	infra := &webhook.WebhookInfraInfo{
+		ID: ID,
+	}
+	err := w.store.GetWebhookByID(ctx, infra)
+	if err == webhook.ErrWebhookNotFound {
+		return ErrInvalidWebhook
+	}
+	if err != nil {
+		return err
+	}
+
+	return w.store.Delete(ctx, infra)
+}
+
+// GetWebhooks is func to get webhook with paging
+func (w *Webhook) GetWebhooks(ctx context.Context, size, cursor int) ([]WebhookInfo, int, error) {
+	var list []WebhookInfo
+	webhooks, err := w.store.GetWebhookWithPaging(ctx, webhook.GetWebhookWithPagingRequest{
+		Size:   size,
+		Cursor: cursor,
+	})
+	if err != nil {
+		return list, 0, err
+	}
+
+	for _, wh := range webhooks {
+		list = append(list, WebhookInfo{
+			ID:         wh.ID,
+			URL:        wh.URL,
+			EventTypes: wh.EventTypes,
+			CreatedAt:  wh.CreatedAt,
+		})
+	}
+
+	nextPage := cursor + 1
+	if len(webhooks) < size {
+		nextPage = 0
+	}
+
+	return list, nextPage, nil
+}
+
+// GetDeliveries is func to get latest delivery attempt of webhook
+func (w *Webhook) GetDeliveries(ctx context.Context, ID uint, limit int) ([]DeliveryInfo, error) {
+	var list []DeliveryInfo
+	err := w.store.GetWebhookByID(ctx, &webhook.WebhookInfraInfo{
+		ID: ID,
+	})
+	if err == webhook.ErrWebhookNotFound {
+		return list, ErrInvalidWebhook
+	}
+	if err != nil {
+		return list, err
+	}
+
+	deliveries, err := w.store.GetDeliveries(ctx, ID, limit)
+	if err != nil {
+		return list, err
+	}
+
+	for _, d := range deliveries {
+		list = append(list, DeliveryInfo{
+			ID:         d.ID,
+			EventID:    d.EventID,
+			EventType:  d.EventType,
+			Attempt:    d.Attempt,
+			StatusCode: d.StatusCode,
+			Success:    d.Success,
+			Error:      d.Error,
+			Duration:   time.Duration(d.DurationMs) * time.Millisecond,
+			CreatedAt:  d.CreatedAt,
+		})
+	}
+	return list, nil
+}
+
+// GetSubscribers is func to get every active webhook that subscribe the event type
+func (w *Webhook) GetSubscribers(ctx context.Context, eventType string) ([]Subscriber, error) {
+	var list []Subscriber
+	webhooks, err := w.store.GetActiveWebhooks(ctx)
+	if err != nil {
+		return list, err
+	}
+
+	for _, wh := range webhooks {
+		for _, t := range wh.EventTypes {
+			if t == AllEventTypes || t == eventType {
+				list = append(list, Subscriber{
+					ID:     wh.ID,
+					URL:    wh.URL,
+					Secret: wh.Secret,
+				})
+				break
+			}
+		}
+	}
+	return list, nil
+}
+
+// RecordDelivery is func to store a delivery attempt of webhook
+func (w *Webhook) RecordDelivery(ctx context.Context, r RecordDeliveryRequest) error {
+	return w.store.RecordDelivery(ctx, &webhook.DeliveryInfraInfo{
+		WebhookID:  r.WebhookID,
+		EventID:    r.EventID,
+		EventType:  r.EventType,
+		Attempt:    r.Attempt,
+		StatusCode: r.StatusCode,
+		Success:    r.Success,
+		Error:      r.Error,
+		DurationMs: r.Duration.Milliseconds(),
+	})
+}
@@ -0,0 +1,324 @@
+package webhook
+
+import (
+	"aqua-farm-manager/internal/infrastructure/webhook"
+	"aqua-farm-manager/internal/infrastructure/webhook/mock_webhook"
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestNewWebhookDomain(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	store := mock_webhook.NewMockWebhookStore(mockCtrl)
+	want := &Webhook{
+		store: store,
+	}
+	if got := NewWebhookDomain(store); !reflect.DeepEqual(got, want) {
+		t.Errorf("NewWebhookDomain() = %v, want %v", got, want)
+	}
+}
+
+func TestWebhook_RegisterWebhook(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	store := mock_webhook.NewMockWebhookStore(mockCtrl)
+
+	secret := "0123456789abcdef"
+	tests := []struct {
+		name     string
+		mockFunc func()
+		r        CreateDomainRequest
+		want     CreateDomainResponse
+		wantErr  error
+	}{
+		{
+			name: "success",
+			mockFunc: func() {
+				store.EXPECT().Create(gomock.Any(), &webhook.WebhookInfraInfo{
+					URL:        "https://partner.com/hook",
+					EventTypes: []string{"farm.created", "pond.moved"},
+					Secret:     secret,
+				}).DoAndReturn(func(_ context.Context, r *webhook.WebhookInfraInfo) error {
+					r.ID = 1
+					return nil
+				})
+			},
+			r: CreateDomainRequest{
+				URL:        "https://partner.com/hook",
+				EventTypes: []string{"farm.created", "pond.moved"},
+				Secret:     secret,
+			},
+			want: CreateDomainResponse{ID: 1},
+		},
+		{
+			name: "success subscribe all event",
+			mockFunc: func() {
+				store.EXPECT().Create(gomock.Any(), gomock.Any()).Return(nil)
+			},
+			r: CreateDomainRequest{
+				URL:        "https://partner.com/hook",
+				EventTypes: []string{"*"},
+				Secret:     secret,
+			},
+		},
+		{
+			name:     "reject http url",
+			mockFunc: func() {},
+			r: CreateDomainRequest{
+				URL:        "http://partner.com/hook",
+				EventTypes: []string{"farm.created"},
+				Secret:     secret,
+			},
+			wantErr: ErrInvalidURL,
+		},
+		{
+			name:     "reject url without host",
+			mockFunc: func() {},
+			r: CreateDomainRequest{
+				URL:        "https:///hook",
+				EventTypes: []string{"farm.created"},
+				Secret:     secret,
+			},
+			wantErr: ErrInvalidURL,
+		},
+		{
+			name:     "reject loopback url",
+			mockFunc: func() {},
+			r: CreateDomainRequest{
+				URL:        "https://127.0.0.1:8443/hook",
+				EventTypes: []string{"farm.created"},
+				Secret:     secret,
+			},
+			wantErr: ErrPrivateURL,
+		},
+		{
+			name:     "reject localhost url",
+			mockFunc: func() {},
+			r: CreateDomainRequest{
+				URL:        "https://localhost/hook",
+				EventTypes: []string{"farm.created"},
+				Secret:     secret,
+			},
+			wantErr: ErrPrivateURL,
+		},
+		{
+			name:     "reject private url",
+			mockFunc: func() {},
+			r: CreateDomainRequest{
+				URL:        "https://10.1.2.3/hook",
+				EventTypes: []string{"farm.created"},
+				Secret:     secret,
+			},
+			wantErr: ErrPrivateURL,
+		},
+		{
+			name:     "reject link local url",
+			mockFunc: func() {},
+			r: CreateDomainRequest{
+				URL:        "https://169.254.169.254/latest/meta-data",
+				EventTypes: []string{"farm.created"},
+				Secret:     secret,
+			},
+			wantErr: ErrPrivateURL,
+		},
+		{
+			name:     "reject ipv6 loopback url",
+			mockFunc: func() {},
+			r: CreateDomainRequest{
+				URL:        "https://[::1]/hook",
+				EventTypes: []string{"farm.created"},
+				Secret:     secret,
+			},
+			wantErr: ErrPrivateURL,
+		},
+		{
+			name:     "reject unknown event type",
+			mockFunc: func() {},
+			r: CreateDomainRequest{
+				URL:        "https://partner.com/hook",
+				EventTypes: []string{"farm.created", "farm.exploded"},
+				Secret:     secret,
+			},
+			wantErr: ErrInvalidEventType,
+		},
+		{
+			name:     "reject empty event type",
+			mockFunc: func() {},
+			r: CreateDomainRequest{
+				URL:    "https://partner.com/hook",
+				Secret: secret,
+			},
+			wantErr: ErrInvalidEventType,
+		},
+		{
+			name:     "reject short secret",
+			mockFunc: func() {},
+			r: CreateDomainRequest{
+				URL:        "https://partner.com/hook",
+				EventTypes: []string{"farm.created"},
+				Secret:     "short",
+			},
+			wantErr: ErrInvalidSecret,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			w := NewWebhookDomain(store)
+			got, err := w.RegisterWebhook(context.Background(), tt.r)
+			if err != tt.wantErr {
+				t.Errorf("Webhook.RegisterWebhook() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Webhook.RegisterWebhook() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWebhook_DeleteWebhook(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	store := mock_webhook.NewMockWebhookStore(mockCtrl)
+
+	tests := []struct {
+		name     string
+		mockFunc func()
+		wantErr  error
+	}{
+		{
+			name: "success",
+			mockFunc: func() {
+				store.EXPECT().GetWebhookByID(gomock.Any(), &webhook.WebhookInfraInfo{ID: 1}).Return(nil)
+				store.EXPECT().Delete(gomock.Any(), gomock.Any()).Return(nil)
+			},
+		},
+		{
+			name: "webhook not found",
+			mockFunc: func() {
+				store.EXPECT().GetWebhookByID(gomock.Any(), gomock.Any()).Return(webhook.ErrWebhookNotFound)
+			},
+			wantErr: ErrInvalidWebhook,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			w := NewWebhookDomain(store)
+			if err := w.DeleteWebhook(context.Background(), 1); err != tt.wantErr {
+				t.Errorf("Webhook.DeleteWebhook() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWebhook_GetDeliveries(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	store := mock_webhook.NewMockWebhookStore(mockCtrl)
+
+	tests := []struct {
+		name     string
+		mockFunc func()
+		want     []DeliveryInfo
+		wantErr  bool
+	}{
+		{
+			name: "success",
+			mockFunc: func() {
+				store.EXPECT().GetWebhookByID(gomock.Any(), gomock.Any()).Return(nil)
+				store.EXPECT().GetDeliveries(gomock.Any(), uint(1), 10).Return([]webhook.DeliveryInfraInfo{
+					{ID: 1, WebhookID: 1, EventID: "event", EventType: "farm.created", Attempt: 1, StatusCode: 200, Success: true, DurationMs: 15},
+				}, nil)
+			},
+			want: []DeliveryInfo{
+				{ID: 1, EventID: "event", EventType: "farm.created", Attempt: 1, StatusCode: 200, Success: true, Duration: 15 * time.Millisecond},
+			},
+		},
+		{
+			name: "webhook not found",
+			mockFunc: func() {
+				store.EXPECT().GetWebhookByID(gomock.Any(), gomock.Any()).Return(webhook.ErrWebhookNotFound)
+			},
+			wantErr: true,
+		},
+		{
+			name: "got error get deliveries",
+			mockFunc: func() {
+				store.EXPECT().GetWebhookByID(gomock.Any(), gomock.Any()).Return(nil)
+				store.EXPECT().GetDeliveries(gomock.Any(), uint(1), 10).Return(nil, fmt.Errorf("some error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			w := NewWebhookDomain(store)
+			got, err := w.GetDeliveries(context.Background(), 1, 10)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Webhook.GetDeliveries() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Webhook.GetDeliveries() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWebhook_GetSubscribers(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	store := mock_webhook.NewMockWebhookStore(mockCtrl)
+
+	tests := []struct {
+		name     string
+		mockFunc func()
+		want     []Subscriber
+		wantErr  bool
+	}{
+		{
+			name: "success filter by event type",
+			mockFunc: func() {
+				store.EXPECT().GetActiveWebhooks(gomock.Any()).Return([]webhook.WebhookInfraInfo{
+					{ID: 1, URL: "https://a.com", EventTypes: []string{"farm.created", "pond.moved"}, Secret: "s1"},
+					{ID: 2, URL: "https://b.com", EventTypes: []string{"farm.created"}, Secret: "s2"},
+					{ID: 3, URL: "https://c.com", EventTypes: []string{"*"}, Secret: "s3"},
+				}, nil)
+			},
+			want: []Subscriber{
+				{ID: 1, URL: "https://a.com", Secret: "s1"},
+				{ID: 3, URL: "https://c.com", Secret: "s3"},
+			},
+		},
+		{
+			name: "got error",
+			mockFunc: func() {
+				store.EXPECT().GetActiveWebhooks(gomock.Any()).Return(nil, fmt.Errorf("some error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			w := NewWebhookDomain(store)
+			got, err := w.GetSubscribers(context.Background(), "pond.moved")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Webhook.GetSubscribers() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Webhook.GetSubscribers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
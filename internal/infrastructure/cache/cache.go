@@ -0,0 +1,203 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+
+	"aqua-farm-manager/pkg/prometheus"
+	"aqua-farm-manager/pkg/redis"
+)
+
+// requestsTotal count cached store lookup by store and result
+var requestsTotal = prometheus.Default.NewCounterVec(
+	"aqua_farm_store_cache_requests_total",
+	"Total number of cached store lookup by store and result, result is hit, miss or error.",
+	"store", "result",
+)
+
+const (
+	// ResultHit is result when the value is found in redis
+	ResultHit = "hit"
+	// ResultMiss is result when the value is loaded from the store
+	ResultMiss = "miss"
+	// ResultError is result when redis fail and the value is loaded from the store
+	ResultError = "error"
+)
+
+// defaultTTLInSec is ttl of cached value when it is not set
+const defaultTTLInSec = 60
+
+// defaultLoadTimeout is timeout of the load that is shared by concurrent miss of the same key
+const defaultLoadTimeout = 5 * time.Second
+
+// Cache is read-through redis cache of a store, concurrent miss of the same key load the store once
+type Cache struct {
+	redis       redis.RedisMethod
+	store       string
+	ttlInSec    int
+	loadTimeout time.Duration
+
+	mu     sync.Mutex
+	flight map[string]*call
+}
+
+// call is in flight load of a key that is waited by every concurrent miss, done is closed after the load
+type call struct {
+	done  chan struct{}
+	value []byte
+	err   error
+}
+
+// NewCache is func to create cache of store, store is used as metrics label and key prefix
+func NewCache(redis redis.RedisMethod, store string, ttlInSec int) *Cache {
+	if ttlInSec <= 0 {
+		ttlInSec = defaultTTLInSec
+	}
+	return &Cache{
+		redis:       redis,
+		store:       store,
+		ttlInSec:    ttlInSec,
+		loadTimeout: defaultLoadTimeout,
+		flight:      make(map[string]*call),
+	}
+}
+
+// Key is func to generate redis key of the cached value
+func (c *Cache) Key(name string) string {
+	return "C:" + c.store + ":" + name
+}
+
+// Fetch is func to get cached value of key into dest, on miss the value is loaded by load and stored with ttl.
+// Error of load is returned and not cached, redis error is counted and the value is loaded from the store.
+// The load get the context of the shared load instead of ctx, see do
+func (c *Cache) Fetch(ctx context.Context, key string, dest interface{}, load func(ctx context.Context) (interface{}, error)) error {
+	value, err := c.redis.Get(ctx, key)
+	if err == nil {
+		if err = json.Unmarshal([]byte(value), dest); err == nil {
+			requestsTotal.Inc(c.store, ResultHit)
+			return nil
+		}
+	}
+	if errors.Is(err, redis.ErrNil) {
+		requestsTotal.Inc(c.store, ResultMiss)
+	} else {
+		requestsTotal.Inc(c.store, ResultError)
+	}
+
+	data, err := c.do(ctx, key, func(ctx context.Context) ([]byte, error) {
+		v, err := load(ctx)
+		if err != nil {
+			return nil, err
+		}
+		data, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		// failing to fill the cache does not fail the lookup
+		c.redis.SetEX(ctx, key, string(data), c.ttlInSec)
+		return data, nil
+	})
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, dest)
+}
+
+// FetchMany is func to get cached value of every key in a single redis call, the value of hit key is
+// decoded into dest of its index and the index of missed key is returned so the caller can load every
+// missed value in a single store call and fill the cache with Store
+func (c *Cache) FetchMany(ctx context.Context, keys []string, dest func(i int) interface{}) []int {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	values, err := c.redis.MGet(ctx, keys...)
+	if err != nil || len(values) != len(keys) {
+		for range keys {
+			requestsTotal.Inc(c.store, ResultError)
+		}
+		missed := make([]int, len(keys))
+		for i := range keys {
+			missed[i] = i
+		}
+		return missed
+	}
+
+	var missed []int
+	for i, value := range values {
+		if value != "" && json.Unmarshal([]byte(value), dest(i)) == nil {
+			requestsTotal.Inc(c.store, ResultHit)
+			continue
+		}
+		requestsTotal.Inc(c.store, ResultMiss)
+		missed = append(missed, i)
+	}
+	return missed
+}
+
+// Store is func to fill cached value of key with ttl, failing to fill the cache is ignored
+func (c *Cache) Store(ctx context.Context, key string, value interface{}) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return
+	}
+	c.redis.SetEX(ctx, key, string(data), c.ttlInSec)
+}
+
+// Invalidate is func to remove cached value of keys
+func (c *Cache) Invalidate(ctx context.Context, keys ...string) error {
+	var err error
+	for _, key := range keys {
+		if e := c.redis.Delete(ctx, key); e != nil {
+			err = e
+		}
+	}
+	return err
+}
+
+// do is func to run fn once for concurrent caller of the same key, every caller get the same result.
+// fn run on the value of ctx of the first caller without its cancellation and with its own timeout,
+// so the caller that stop waiting when its ctx is done does not fail the load of the other caller
+func (c *Cache) do(ctx context.Context, key string, fn func(ctx context.Context) ([]byte, error)) ([]byte, error) {
+	c.mu.Lock()
+	inflight, ok := c.flight[key]
+	if !ok {
+		inflight = &call{done: make(chan struct{})}
+		c.flight[key] = inflight
+		go c.load(detachedContext{ctx}, key, inflight, fn)
+	}
+	c.mu.Unlock()
+
+	select {
+	case <-inflight.done:
+		return inflight.value, inflight.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// load is func to run fn of the in flight call with the load timeout
+func (c *Cache) load(ctx context.Context, key string, inflight *call, fn func(ctx context.Context) ([]byte, error)) {
+	ctx, cancel := context.WithTimeout(ctx, c.loadTimeout)
+	defer cancel()
+
+	inflight.value, inflight.err = fn(ctx)
+
+	c.mu.Lock()
+	delete(c.flight, key)
+	c.mu.Unlock()
+	close(inflight.done)
+}
+
+// detachedContext is context with the value of parent without its deadline and cancellation
+type detachedContext struct {
+	parent context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool)         { return time.Time{}, false }
+func (detachedContext) Done() <-chan struct{}               { return nil }
+func (detachedContext) Err() error                          { return nil }
+func (d detachedContext) Value(key interface{}) interface{} { return d.parent.Value(key) }
@@ -0,0 +1,285 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"aqua-farm-manager/pkg/redis"
+	mock_redis "aqua-farm-manager/pkg/redis/mock"
+
+	"github.com/golang/mock/gomock"
+)
+
+type item struct {
+	ID   uint
+	Name string
+}
+
+func TestCache_Fetch(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	errLoad := errors.New("load failed")
+	key := "C:test:id:1"
+	tests := []struct {
+		name      string
+		mockFunc  func(r *mock_redis.MockRedisMethod)
+		load      func() (interface{}, error)
+		want      item
+		wantLoads int32
+		wantErr   error
+	}{
+		{
+			name: "hit",
+			mockFunc: func(r *mock_redis.MockRedisMethod) {
+				r.EXPECT().Get(gomock.Any(), key).Return(`{"ID":1,"Name":"cached"}`, nil)
+			},
+			load: func() (interface{}, error) { return item{ID: 1, Name: "store"}, nil },
+			want: item{ID: 1, Name: "cached"},
+		},
+		{
+			name: "miss load and fill the cache",
+			mockFunc: func(r *mock_redis.MockRedisMethod) {
+				r.EXPECT().Get(gomock.Any(), key).Return("", redis.ErrNil)
+				r.EXPECT().SetEX(gomock.Any(), key, `{"ID":1,"Name":"store"}`, 30).Return(nil)
+			},
+			load:      func() (interface{}, error) { return item{ID: 1, Name: "store"}, nil },
+			want:      item{ID: 1, Name: "store"},
+			wantLoads: 1,
+		},
+		{
+			name: "redis error load from the store",
+			mockFunc: func(r *mock_redis.MockRedisMethod) {
+				r.EXPECT().Get(gomock.Any(), key).Return("", errors.New("connection refused"))
+				r.EXPECT().SetEX(gomock.Any(), key, gomock.Any(), 30).Return(errors.New("connection refused"))
+			},
+			load:      func() (interface{}, error) { return item{ID: 1, Name: "store"}, nil },
+			want:      item{ID: 1, Name: "store"},
+			wantLoads: 1,
+		},
+		{
+			name: "corrupt cached value load from the store",
+			mockFunc: func(r *mock_redis.MockRedisMethod) {
+				r.EXPECT().Get(gomock.Any(), key).Return(`{`, nil)
+				r.EXPECT().SetEX(gomock.Any(), key, gomock.Any(), 30).Return(nil)
+			},
+			load:      func() (interface{}, error) { return item{ID: 1, Name: "store"}, nil },
+			want:      item{ID: 1, Name: "store"},
+			wantLoads: 1,
+		},
+		{
+			name: "load error is not cached",
+			mockFunc: func(r *mock_redis.MockRedisMethod) {
+				r.EXPECT().Get(gomock.Any(), key).Return("", redis.ErrNil)
+			},
+			load:      func() (interface{}, error) { return nil, errLoad },
+			wantLoads: 1,
+			wantErr:   errLoad,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := mock_redis.NewMockRedisMethod(mockCtrl)
+			tt.mockFunc(r)
+			c := NewCache(r, "test", 30)
+
+			var loads int32
+			var got item
+			err := c.Fetch(context.Background(), c.Key("id:1"), &got, func(ctx context.Context) (interface{}, error) {
+				atomic.AddInt32(&loads, 1)
+				return tt.load()
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("Cache.Fetch() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Cache.Fetch() got = %v, want %v", got, tt.want)
+			}
+			if loads != tt.wantLoads {
+				t.Errorf("Cache.Fetch() load called %d times, want %d", loads, tt.wantLoads)
+			}
+		})
+	}
+}
+
+func TestCache_Fetch_SingleFlight(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	const callers = 10
+	r := mock_redis.NewMockRedisMethod(mockCtrl)
+	r.EXPECT().Get(gomock.Any(), gomock.Any()).Return("", redis.ErrNil).Times(callers)
+	r.EXPECT().SetEX(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).MinTimes(1)
+	c := NewCache(r, "test", 0)
+
+	var loads int32
+	missed := make(chan struct{}, callers)
+	release := make(chan struct{})
+	load := func(ctx context.Context) (interface{}, error) {
+		atomic.AddInt32(&loads, 1)
+		<-release
+		return item{ID: 1, Name: "store"}, nil
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < callers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var got item
+			missed <- struct{}{}
+			if err := c.Fetch(context.Background(), c.Key("id:1"), &got, load); err != nil || got.Name != "store" {
+				t.Errorf("Cache.Fetch() got = %v %v", got, err)
+			}
+		}()
+	}
+	for i := 0; i < callers; i++ {
+		<-missed
+	}
+	// give every caller time to join the in flight load
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	if loads != 1 {
+		t.Errorf("Cache.Fetch() load called %d times by concurrent miss, want 1", loads)
+	}
+}
+
+func TestCache_Fetch_DetachedLoad(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	type ctxKey struct{}
+	r := mock_redis.NewMockRedisMethod(mockCtrl)
+	r.EXPECT().Get(gomock.Any(), gomock.Any()).Return("", redis.ErrNil).Times(2)
+	r.EXPECT().SetEX(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	c := NewCache(r, "test", 0)
+	c.loadTimeout = time.Second
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	loadErr := make(chan error, 1)
+	load := func(ctx context.Context) (interface{}, error) {
+		if ctx.Value(ctxKey{}) != "tx" {
+			t.Errorf("load context value = %v, want the value of the first caller", ctx.Value(ctxKey{}))
+		}
+		if _, ok := ctx.Deadline(); !ok {
+			t.Errorf("load context has no deadline, want the load timeout")
+		}
+		close(started)
+		<-release
+		loadErr <- ctx.Err()
+		return item{ID: 1, Name: "store"}, nil
+	}
+
+	// the first caller give up, the load keep running for the second caller
+	first, cancel := context.WithCancel(context.WithValue(context.Background(), ctxKey{}, "tx"))
+	done := make(chan error)
+	go func() {
+		var got item
+		done <- c.Fetch(first, c.Key("id:1"), &got, load)
+	}()
+	<-started
+	cancel()
+	if err := <-done; !errors.Is(err, context.Canceled) {
+		t.Errorf("Cache.Fetch() of cancelled caller error = %v, want %v", err, context.Canceled)
+	}
+
+	second := make(chan item)
+	go func() {
+		var got item
+		if err := c.Fetch(context.Background(), c.Key("id:1"), &got, load); err != nil {
+			t.Errorf("Cache.Fetch() error = %v", err)
+		}
+		second <- got
+	}()
+	// give the second caller time to join the in flight load
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	if got := <-second; got.Name != "store" {
+		t.Errorf("Cache.Fetch() got = %v, want the shared load", got)
+	}
+	if err := <-loadErr; err != nil {
+		t.Errorf("load context error = %v, want the load not cancelled by the first caller", err)
+	}
+}
+
+func TestCache_FetchMany(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	keys := []string{"C:test:id:1", "C:test:id:2", "C:test:id:3"}
+	tests := []struct {
+		name       string
+		mockFunc   func(r *mock_redis.MockRedisMethod)
+		want       []item
+		wantMissed []int
+	}{
+		{
+			name: "hit, miss and corrupt value",
+			mockFunc: func(r *mock_redis.MockRedisMethod) {
+				r.EXPECT().MGet(gomock.Any(), keys[0], keys[1], keys[2]).Return([]string{`{"ID":1,"Name":"cached"}`, "", `{`}, nil)
+			},
+			want:       []item{{ID: 1, Name: "cached"}, {}, {}},
+			wantMissed: []int{1, 2},
+		},
+		{
+			name: "redis error miss every key",
+			mockFunc: func(r *mock_redis.MockRedisMethod) {
+				r.EXPECT().MGet(gomock.Any(), keys[0], keys[1], keys[2]).Return(nil, errors.New("connection refused"))
+			},
+			want:       []item{{}, {}, {}},
+			wantMissed: []int{0, 1, 2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := mock_redis.NewMockRedisMethod(mockCtrl)
+			tt.mockFunc(r)
+			c := NewCache(r, "test", 30)
+
+			got := make([]item, len(keys))
+			missed := c.FetchMany(context.Background(), keys, func(i int) interface{} { return &got[i] })
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Cache.FetchMany() got = %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(missed, tt.wantMissed) {
+				t.Errorf("Cache.FetchMany() missed = %v, want %v", missed, tt.wantMissed)
+			}
+		})
+	}
+}
+
+func TestCache_Store(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	r := mock_redis.NewMockRedisMethod(mockCtrl)
+	r.EXPECT().SetEX(gomock.Any(), "C:test:id:1", `{"ID":1,"Name":"store"}`, 30).Return(errors.New("connection refused"))
+	r.EXPECT().SetEX(gomock.Any(), "C:test:id:2", "null", 30).Return(nil)
+	c := NewCache(r, "test", 30)
+
+	c.Store(context.Background(), c.Key("id:1"), item{ID: 1, Name: "store"})
+	c.Store(context.Background(), c.Key("id:2"), nil)
+}
+
+func TestCache_Invalidate(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	errRedis := errors.New("connection refused")
+	r := mock_redis.NewMockRedisMethod(mockCtrl)
+	r.EXPECT().Delete(gomock.Any(), "C:test:a").Return(errRedis)
+	r.EXPECT().Delete(gomock.Any(), "C:test:b").Return(nil)
+	c := NewCache(r, "test", 0)
+
+	if err := c.Invalidate(context.Background(), c.Key("a"), c.Key("b")); !errors.Is(err, errRedis) {
+		t.Errorf("Cache.Invalidate() error = %v, want %v", err, errRedis)
+	}
+}
@@ -0,0 +1,51 @@
+package contract
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"aqua-farm-manager/internal/infrastructure/farm"
+	"aqua-farm-manager/internal/infrastructure/pond"
+	"aqua-farm-manager/pkg/postgres"
+)
+
+func TestStoreCancel(t *testing.T) {
+	t.Run("sqlite", func(t *testing.T) {
+		testStoreCancel(t, openSQLite)
+	})
+	t.Run("postgres", func(t *testing.T) {
+		testStoreCancel(t, openPostgres)
+	})
+}
+
+// testStoreCancel check the sql store stop the query when the context is done and the write is not stored
+func testStoreCancel(t *testing.T, open func(t *testing.T) postgres.PostgresMethod) {
+	pg := open(t)
+	farmStore, pondStore := farm.NewFarmStore(pg), pond.NewPondStore(pg)
+	a := &farm.FarmInfraInfo{Name: "farm a"}
+	mustCreateFarm(t, farmStore, a)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := farmStore.GetFarmByID(ctx, &farm.FarmInfraInfo{ID: a.ID}); !errors.Is(err, context.Canceled) {
+		t.Errorf("GetFarmByID() error = %v, want %v", err, context.Canceled)
+	}
+	if _, err := pondStore.GetPondIDbyFarmID(ctx, a.ID); !errors.Is(err, context.Canceled) {
+		t.Errorf("GetPondIDbyFarmID() error = %v, want %v", err, context.Canceled)
+	}
+
+	b := &farm.FarmInfraInfo{Name: "farm b"}
+	if err := farmStore.Create(ctx, b); !errors.Is(err, context.Canceled) {
+		t.Errorf("Create() error = %v, want %v", err, context.Canceled)
+	}
+	if err := farmStore.GetFarmByName(context.Background(), &farm.FarmInfraInfo{Name: "farm b"}); err == nil {
+		t.Errorf("GetFarmByName() of cancelled create error = nil, want not found")
+	}
+
+	// the connection pool is still usable after the cancelled query
+	if err := farmStore.GetFarmByID(context.Background(), &farm.FarmInfraInfo{ID: a.ID}); err != nil {
+		t.Errorf("GetFarmByID() after cancel error = %v", err)
+	}
+}
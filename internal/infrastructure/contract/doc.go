@@ -0,0 +1,7 @@
+// Package contract is shared behavior test of every store implementation,
+// every suite is run against the in memory store, the sqlite store and the postgres store so behavioral drift is caught.
+//
+// The sqlite suite always run on a new file in the test temp dir. The postgres suite is skipped unless
+// AQUA_FARM_TEST_POSTGRES is set with postgres connection string, the postgres tables is truncated before every suite.
+// The sql stat store also need AQUA_FARM_TEST_REDIS with redis host.
+package contract
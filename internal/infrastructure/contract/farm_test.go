@@ -0,0 +1,359 @@
+package contract
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"aqua-farm-manager/internal/infrastructure/farm"
+	"aqua-farm-manager/internal/infrastructure/pond"
+	"aqua-farm-manager/internal/model"
+	"aqua-farm-manager/pkg/memorydb"
+)
+
+// farmStores is func to create empty farm store and pond store that share the same storage
+type farmStores func(t *testing.T) (farm.FarmStore, pond.PondStore)
+
+func TestFarmStore(t *testing.T) {
+	t.Run("memory", func(t *testing.T) {
+		testFarmStore(t, func(t *testing.T) (farm.FarmStore, pond.PondStore) {
+			db := memorydb.NewDB()
+			return farm.NewMemoryFarmStore(db), pond.NewMemoryPondStore(db)
+		})
+	})
+	t.Run("sqlite", func(t *testing.T) {
+		testFarmStore(t, func(t *testing.T) (farm.FarmStore, pond.PondStore) {
+			pg := openSQLite(t)
+			return farm.NewFarmStore(pg), pond.NewPondStore(pg)
+		})
+	})
+	t.Run("postgres", func(t *testing.T) {
+		testFarmStore(t, func(t *testing.T) (farm.FarmStore, pond.PondStore) {
+			pg := openPostgres(t)
+			return farm.NewFarmStore(pg), pond.NewPondStore(pg)
+		})
+	})
+}
+
+func testFarmStore(t *testing.T, newStores farmStores) {
+	t.Run("create and get", func(t *testing.T) {
+		store, _ := newStores(t)
+		a := &farm.FarmInfraInfo{Name: "farm a", Location: "bandung", Owner: "andi", Area: "10"}
+		b := &farm.FarmInfraInfo{Name: "farm b", Location: "bogor", Owner: "budi", Area: "20"}
+		mustCreateFarm(t, store, a)
+		mustCreateFarm(t, store, b)
+		if a.ID == 0 || a.ID == b.ID {
+			t.Fatalf("Create() id got = %d and %d, want unique non zero id", a.ID, b.ID)
+		}
+
+		got := &farm.FarmInfraInfo{ID: a.ID}
+		if err := store.GetFarmByID(context.Background(), got); err != nil {
+			t.Fatalf("GetFarmByID() error = %v", err)
+		}
+		want := farm.FarmInfraInfo{ID: a.ID, Name: "farm a", Location: "bandung", Owner: "andi", Area: "10"}
+		if !reflect.DeepEqual(*got, want) {
+			t.Errorf("GetFarmByID() = %+v, want %+v", *got, want)
+		}
+
+		got = &farm.FarmInfraInfo{Name: "farm a"}
+		if err := store.GetFarmByName(context.Background(), got); err != nil {
+			t.Fatalf("GetFarmByName() error = %v", err)
+		}
+		if !reflect.DeepEqual(*got, want) {
+			t.Errorf("GetFarmByName() = %+v, want %+v", *got, want)
+		}
+
+		if err := store.GetFarmByID(context.Background(), &farm.FarmInfraInfo{ID: b.ID + 100}); err == nil {
+			t.Errorf("GetFarmByID() of unknown id expect error")
+		}
+		if err := store.GetFarmByName(context.Background(), &farm.FarmInfraInfo{Name: "unknown"}); err == nil {
+			t.Errorf("GetFarmByName() of unknown name expect error")
+		}
+		if err := store.GetFarmByID(context.Background(), &farm.FarmInfraInfo{}); err == nil {
+			t.Errorf("GetFarmByID() without id expect error")
+		}
+		if err := store.Create(context.Background(), nil); err == nil {
+			t.Errorf("Create() of nil request expect error")
+		}
+	})
+
+	t.Run("verify by id or name", func(t *testing.T) {
+		store, _ := newStores(t)
+		a := &farm.FarmInfraInfo{Name: "farm a"}
+		mustCreateFarm(t, store, a)
+
+		byID := &farm.FarmInfraInfo{ID: a.ID}
+		if exists, err := store.Verify(context.Background(), byID); err != nil || !exists || byID.Name != "farm a" {
+			t.Errorf("Verify() by id got = %v %+v %v, want exists with name", exists, byID, err)
+		}
+		byName := &farm.FarmInfraInfo{Name: "farm a"}
+		if exists, err := store.Verify(context.Background(), byName); err != nil || !exists || byName.ID != a.ID {
+			t.Errorf("Verify() by name got = %v %+v %v, want exists with id", exists, byName, err)
+		}
+		if exists, err := store.Verify(context.Background(), &farm.FarmInfraInfo{Name: "unknown"}); err != nil || exists {
+			t.Errorf("Verify() unknown name got = %v %v, want not exists", exists, err)
+		}
+		if exists, err := store.Verify(context.Background(), &farm.FarmInfraInfo{ID: a.ID + 100}); err != nil || exists {
+			t.Errorf("Verify() unknown id got = %v %v, want not exists", exists, err)
+		}
+		if _, err := store.Verify(context.Background(), &farm.FarmInfraInfo{}); err == nil {
+			t.Errorf("Verify() without id and name expect error")
+		}
+	})
+
+	t.Run("update by id and reject duplicate name", func(t *testing.T) {
+		store, _ := newStores(t)
+		a := &farm.FarmInfraInfo{Name: "farm a", Location: "bandung", Owner: "andi", Area: "10"}
+		b := &farm.FarmInfraInfo{Name: "farm b"}
+		mustCreateFarm(t, store, a)
+		mustCreateFarm(t, store, b)
+
+		err := store.Update(context.Background(), &farm.FarmInfraInfo{ID: a.ID, Name: "farm a", Location: "jakarta"})
+		if err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+		err = store.Update(context.Background(), &farm.FarmInfraInfo{ID: a.ID, Name: "farm c", Owner: "cici"})
+		if err != nil {
+			t.Fatalf("Update() with new name error = %v", err)
+		}
+		err = store.Update(context.Background(), &farm.FarmInfraInfo{ID: a.ID, Name: "farm b", Area: "20"})
+		if !errors.Is(err, farm.ErrDuplicateName) {
+			t.Errorf("Update() into name of other farm error = %v, want %v", err, farm.ErrDuplicateName)
+		}
+
+		got := &farm.FarmInfraInfo{ID: a.ID}
+		if err := store.GetFarmByID(context.Background(), got); err != nil {
+			t.Fatalf("GetFarmByID() error = %v", err)
+		}
+		want := farm.FarmInfraInfo{ID: a.ID, Name: "farm c", Location: "jakarta", Owner: "cici", Area: "10"}
+		if !reflect.DeepEqual(*got, want) {
+			t.Errorf("GetFarmByID() after update = %+v, want %+v", *got, want)
+		}
+	})
+
+	t.Run("soft delete", func(t *testing.T) {
+		store, _ := newStores(t)
+		a := &farm.FarmInfraInfo{Name: "farm a"}
+		mustCreateFarm(t, store, a)
+
+		if err := store.Delete(context.Background(), &farm.FarmInfraInfo{ID: a.ID, Name: "farm a"}); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+		if err := store.GetFarmByID(context.Background(), &farm.FarmInfraInfo{ID: a.ID}); err == nil {
+			t.Errorf("GetFarmByID() of deleted farm expect error")
+		}
+		if exists, _ := store.Verify(context.Background(), &farm.FarmInfraInfo{Name: "farm a"}); exists {
+			t.Errorf("Verify() of deleted farm got exists")
+		}
+
+		// the name of deleted farm can be used again
+		again := &farm.FarmInfraInfo{Name: "farm a"}
+		mustCreateFarm(t, store, again)
+		got := &farm.FarmInfraInfo{Name: "farm a"}
+		if err := store.GetFarmByName(context.Background(), got); err != nil || got.ID != again.ID {
+			t.Errorf("GetFarmByName() got = %+v %v, want id %d", got, err, again.ID)
+		}
+	})
+
+	t.Run("reject duplicate active name", func(t *testing.T) {
+		store, _ := newStores(t)
+		a := &farm.FarmInfraInfo{Name: "farm a"}
+		mustCreateFarm(t, store, a)
+
+		err := store.Create(context.Background(), &farm.FarmInfraInfo{Name: "farm a"})
+		if !errors.Is(err, farm.ErrDuplicateName) {
+			t.Errorf("Create() duplicate name error = %v, want %v", err, farm.ErrDuplicateName)
+		}
+		page, err := store.GetFarmWithPaging(context.Background(), farm.GetFarmWithPagingRequest{Size: 10, Cursor: 1})
+		if err != nil || len(page) != 1 {
+			t.Errorf("GetFarmWithPaging() got %d farm %v, want 1", len(page), err)
+		}
+	})
+
+	t.Run("paging only active farm", func(t *testing.T) {
+		store, _ := newStores(t)
+		var ids []uint
+		for _, name := range []string{"a", "b", "c", "d"} {
+			f := &farm.FarmInfraInfo{Name: name}
+			mustCreateFarm(t, store, f)
+			ids = append(ids, f.ID)
+		}
+		if err := store.Delete(context.Background(), &farm.FarmInfraInfo{ID: ids[1], Name: "b"}); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+
+		var got []uint
+		for cursor := 1; cursor <= 3; cursor++ {
+			page, err := store.GetFarmWithPaging(context.Background(), farm.GetFarmWithPagingRequest{Size: 2, Cursor: cursor})
+			if err != nil {
+				t.Fatalf("GetFarmWithPaging() error = %v", err)
+			}
+			if len(page) > 2 {
+				t.Fatalf("GetFarmWithPaging() page size got = %d, want at most 2", len(page))
+			}
+			for _, f := range page {
+				got = append(got, f.ID)
+			}
+		}
+		want := []uint{ids[0], ids[2], ids[3]}
+		if !reflect.DeepEqual(sortedIDs(got), want) {
+			t.Errorf("GetFarmWithPaging() got = %v, want %v", sortedIDs(got), want)
+		}
+	})
+
+	t.Run("get only active farm by ids", func(t *testing.T) {
+		store, _ := newStores(t)
+		a := &farm.FarmInfraInfo{Name: "farm a", Location: "bandung"}
+		b := &farm.FarmInfraInfo{Name: "farm b"}
+		c := &farm.FarmInfraInfo{Name: "farm c", Owner: "cici"}
+		for _, f := range []*farm.FarmInfraInfo{a, b, c} {
+			mustCreateFarm(t, store, f)
+		}
+		if err := store.Delete(context.Background(), &farm.FarmInfraInfo{ID: b.ID, Name: "farm b"}); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+
+		got, err := store.GetFarmsByIDs(context.Background(), []uint{c.ID, b.ID, a.ID, c.ID + 100, a.ID})
+		want := []farm.FarmInfraInfo{
+			{ID: a.ID, Name: "farm a", Location: "bandung"},
+			{ID: c.ID, Name: "farm c", Owner: "cici"},
+		}
+		if err != nil || !reflect.DeepEqual(got, want) {
+			t.Errorf("GetFarmsByIDs() = %+v %v, want %+v", got, err, want)
+		}
+		if got, err := store.GetFarmsByIDs(context.Background(), nil); err != nil || len(got) != 0 {
+			t.Errorf("GetFarmsByIDs() without id = %+v %v, want empty", got, err)
+		}
+	})
+
+	t.Run("bulk create all or nothing", func(t *testing.T) {
+		store, _ := newStores(t)
+		a := &farm.FarmInfraInfo{Name: "farm a", Location: "bandung"}
+		b := &farm.FarmInfraInfo{Name: "farm b", Owner: "budi"}
+		if err := store.BulkCreate(context.Background(), []*farm.FarmInfraInfo{a, b}); err != nil {
+			t.Fatalf("BulkCreate() error = %v", err)
+		}
+		if a.ID == 0 || b.ID <= a.ID {
+			t.Fatalf("BulkCreate() id got = %d and %d, want increasing non zero id", a.ID, b.ID)
+		}
+		got, err := store.GetFarmsByIDs(context.Background(), []uint{a.ID, b.ID})
+		want := []farm.FarmInfraInfo{
+			{ID: a.ID, Name: "farm a", Location: "bandung"},
+			{ID: b.ID, Name: "farm b", Owner: "budi"},
+		}
+		if err != nil || !reflect.DeepEqual(got, want) {
+			t.Errorf("GetFarmsByIDs() after BulkCreate() = %+v %v, want %+v", got, err, want)
+		}
+
+		// the farm before the duplicate is not stored
+		err = store.BulkCreate(context.Background(), []*farm.FarmInfraInfo{{Name: "farm c"}, {Name: "farm a"}})
+		if !errors.Is(err, farm.ErrDuplicateName) {
+			t.Errorf("BulkCreate() duplicate active name error = %v, want %v", err, farm.ErrDuplicateName)
+		}
+		err = store.BulkCreate(context.Background(), []*farm.FarmInfraInfo{{Name: "farm c"}, {Name: "farm c"}})
+		if !errors.Is(err, farm.ErrDuplicateName) {
+			t.Errorf("BulkCreate() duplicate name in list error = %v, want %v", err, farm.ErrDuplicateName)
+		}
+		if exists, _ := store.Verify(context.Background(), &farm.FarmInfraInfo{Name: "farm c"}); exists {
+			t.Errorf("BulkCreate() with duplicate store the other farm")
+		}
+	})
+
+	t.Run("export by status", func(t *testing.T) {
+		store, _ := newStores(t)
+		a := &farm.FarmInfraInfo{Name: "farm a", Location: "bandung"}
+		b := &farm.FarmInfraInfo{Name: "farm b"}
+		c := &farm.FarmInfraInfo{Name: "farm c", Owner: "cici"}
+		mustCreateFarm(t, store, a)
+		mustCreateFarm(t, store, b)
+		mustCreateFarm(t, store, c)
+		if err := store.Delete(context.Background(), &farm.FarmInfraInfo{ID: b.ID, Name: "farm b"}); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+
+		rowA := farm.ExportFarmInfo{ID: a.ID, Name: "farm a", Location: "bandung", Status: model.Active.Value()}
+		rowB := farm.ExportFarmInfo{ID: b.ID, Name: "farm b", Status: model.Inactive.Value()}
+		rowC := farm.ExportFarmInfo{ID: c.ID, Name: "farm c", Owner: "cici", Status: model.Active.Value()}
+		for status, want := range map[model.Status][]farm.ExportFarmInfo{
+			model.Active:   {rowA, rowC},
+			model.Inactive: {rowB},
+			model.Unknown:  {rowA, rowB, rowC},
+		} {
+			var got []farm.ExportFarmInfo
+			err := store.Export(context.Background(), status, func(info farm.ExportFarmInfo) error {
+				if info.CreatedAt.IsZero() || info.UpdatedAt.IsZero() {
+					t.Errorf("Export() of farm %d has no time", info.ID)
+				}
+				info.CreatedAt, info.UpdatedAt = time.Time{}, time.Time{}
+				got = append(got, info)
+				return nil
+			})
+			if err != nil || !reflect.DeepEqual(got, want) {
+				t.Errorf("Export() of status %d = %+v %v, want %+v", status, got, err, want)
+			}
+		}
+
+		// the stream stop at the first error of fn
+		stop := errors.New("stop")
+		var calls int
+		err := store.Export(context.Background(), model.Unknown, func(info farm.ExportFarmInfo) error {
+			calls++
+			return stop
+		})
+		if !errors.Is(err, stop) || calls != 1 {
+			t.Errorf("Export() with error got = %v after %d call, want %v after 1 call", err, calls, stop)
+		}
+	})
+
+	t.Run("count active pond per farm", func(t *testing.T) {
+		store, pondStore := newStores(t)
+		a := &farm.FarmInfraInfo{Name: "farm a"}
+		b := &farm.FarmInfraInfo{Name: "farm b"}
+		deleted := &farm.FarmInfraInfo{Name: "farm c"}
+		mustCreateFarm(t, store, a)
+		mustCreateFarm(t, store, b)
+		mustCreateFarm(t, store, deleted)
+
+		p1 := &pond.PondInfraInfo{Name: "pond 1", FarmID: a.ID}
+		p2 := &pond.PondInfraInfo{Name: "pond 2", FarmID: a.ID}
+		p3 := &pond.PondInfraInfo{Name: "pond 3", FarmID: a.ID}
+		p4 := &pond.PondInfraInfo{Name: "pond 4", FarmID: deleted.ID}
+		for _, p := range []*pond.PondInfraInfo{p1, p2, p3, p4} {
+			if err := pondStore.Create(context.Background(), p); err != nil {
+				t.Fatalf("Create() pond error = %v", err)
+			}
+		}
+		if err := pondStore.Delete(context.Background(), &pond.PondInfraInfo{ID: p2.ID, Name: "pond 2"}); err != nil {
+			t.Fatalf("Delete() pond error = %v", err)
+		}
+		if err := store.Delete(context.Background(), &farm.FarmInfraInfo{ID: deleted.ID, Name: "farm c"}); err != nil {
+			t.Fatalf("Delete() farm error = %v", err)
+		}
+
+		if got := sortedIDs(store.GetActivePondsInFarm(context.Background(), a.ID)); !reflect.DeepEqual(got, []uint{p1.ID, p3.ID}) {
+			t.Errorf("GetActivePondsInFarm() = %v, want %v", got, []uint{p1.ID, p3.ID})
+		}
+		if got := store.GetActivePondsInFarm(context.Background(), b.ID); len(got) != 0 {
+			t.Errorf("GetActivePondsInFarm() of empty farm = %v, want empty", got)
+		}
+
+		counts, err := store.GetPondCountPerFarm(context.Background())
+		if err != nil {
+			t.Fatalf("GetPondCountPerFarm() error = %v", err)
+		}
+		want := map[uint]int{a.ID: 2, b.ID: 0}
+		if !reflect.DeepEqual(counts, want) {
+			t.Errorf("GetPondCountPerFarm() = %v, want %v", counts, want)
+		}
+	})
+}
+
+// mustCreateFarm is func to create farm or stop the test
+func mustCreateFarm(t *testing.T, store farm.FarmStore, r *farm.FarmInfraInfo) {
+	t.Helper()
+	if err := store.Create(context.Background(), r); err != nil {
+		t.Fatalf("Create() farm error = %v", err)
+	}
+}
@@ -0,0 +1,72 @@
+package contract
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"aqua-farm-manager/pkg/postgres"
+	"aqua-farm-manager/pkg/redis"
+)
+
+// openPostgres is func to connect postgres from AQUA_FARM_TEST_POSTGRES and empty the tables,
+// the test is skipped when it is not set
+func openPostgres(t *testing.T) postgres.PostgresMethod {
+	t.Helper()
+	dsn := os.Getenv("AQUA_FARM_TEST_POSTGRES")
+	if dsn == "" {
+		t.Skip("AQUA_FARM_TEST_POSTGRES is not set")
+	}
+
+	pg, err := postgres.NewPostgresClient(dsn)
+	if err != nil {
+		t.Fatalf("NewPostgresClient() error = %v", err)
+	}
+	t.Cleanup(func() { pg.GetDB(context.Background()).Close() })
+
+	err = pg.GetDB(context.Background()).Exec("TRUNCATE farms, ponds, farm_ponds_mappings, stat_metrics, outbox_events RESTART IDENTITY").Error
+	if err != nil {
+		t.Fatalf("truncate table error = %v", err)
+	}
+	return pg
+}
+
+// openSQLite is func to open new sqlite file in the test temp dir, the file is removed after the test
+func openSQLite(t *testing.T) postgres.PostgresMethod {
+	t.Helper()
+	pg, err := postgres.NewPostgresClient("sqlite://" + filepath.Join(t.TempDir(), "aqua_farm.db"))
+	if err != nil {
+		t.Fatalf("NewPostgresClient() error = %v", err)
+	}
+	t.Cleanup(func() { pg.GetDB(context.Background()).Close() })
+	return pg
+}
+
+// openRedis is func to connect redis from AQUA_FARM_TEST_REDIS, the test is skipped when it is not set
+func openRedis(t *testing.T) redis.RedisMethod {
+	t.Helper()
+	host := os.Getenv("AQUA_FARM_TEST_REDIS")
+	if host == "" {
+		t.Skip("AQUA_FARM_TEST_REDIS is not set")
+	}
+
+	client, err := redis.NewRedisClient(redis.RedisConfig{
+		RedisHost:        host,
+		Password:         os.Getenv("AQUA_FARM_TEST_REDIS_PASSWORD"),
+		MaxIdleInSec:     60,
+		IdleTimeoutInSec: 1,
+	})
+	if err != nil {
+		t.Fatalf("NewRedisClient() error = %v", err)
+	}
+	return client
+}
+
+// sortedIDs is func to sort id because postgres does not guarantee the order without order by
+func sortedIDs(ids []uint) []uint {
+	sorted := append([]uint(nil), ids...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	return sorted
+}
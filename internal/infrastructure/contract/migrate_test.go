@@ -0,0 +1,288 @@
+package contract
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"aqua-farm-manager/pkg/postgres"
+
+	"github.com/jinzhu/gorm"
+)
+
+// integrityVersion is version of the migration that add unique name and foreign key of the mapping
+const integrityVersion = 6
+
+func TestMigrator(t *testing.T) {
+	t.Run("sqlite", func(t *testing.T) {
+		testMigrator(t, func(t *testing.T) string {
+			return "sqlite://" + filepath.Join(t.TempDir(), "aqua_farm.db")
+		})
+	})
+	t.Run("postgres", func(t *testing.T) {
+		testMigrator(t, func(t *testing.T) string {
+			dsn := os.Getenv("AQUA_FARM_TEST_POSTGRES")
+			if dsn == "" {
+				t.Skip("AQUA_FARM_TEST_POSTGRES is not set")
+			}
+			// start from empty database
+			db := openDB(t, dsn)
+			migrator := newMigrator(t, db)
+			if _, err := migrator.Down(len(mustLoadMigrations(t, db))); err != nil {
+				t.Fatalf("Down() error = %v", err)
+			}
+			db.Exec("DROP TABLE IF EXISTS schema_migrations")
+			return dsn
+		})
+	})
+}
+
+func testMigrator(t *testing.T, newDSN func(t *testing.T) string) {
+	t.Run("up down and status", func(t *testing.T) {
+		db := openDB(t, newDSN(t))
+		migrator := newMigrator(t, db)
+		latest := migrator.LatestVersion()
+
+		applied, err := migrator.Up()
+		if err != nil {
+			t.Fatalf("Up() error = %v", err)
+		}
+		if uint(len(applied)) != latest {
+			t.Errorf("Up() applied %d migration, want %d", len(applied), latest)
+		}
+		assertVersion(t, migrator, latest)
+		if !db.HasTable("farms") || !db.HasTable("farm_ponds_mappings") {
+			t.Errorf("Up() does not create the table")
+		}
+
+		// applying again does nothing
+		if applied, err := migrator.Up(); err != nil || len(applied) != 0 {
+			t.Errorf("second Up() got = %v %v, want nothing applied", applied, err)
+		}
+
+		rolledBack, err := migrator.Down(1)
+		if err != nil || len(rolledBack) != 1 || rolledBack[0].Version != latest {
+			t.Fatalf("Down(1) got = %v %v, want version %d rolled back", rolledBack, err, latest)
+		}
+		assertVersion(t, migrator, latest-1)
+
+		status, err := migrator.Status()
+		if err != nil {
+			t.Fatalf("Status() error = %v", err)
+		}
+		for _, s := range status {
+			if want := s.Version < latest; s.Applied != want {
+				t.Errorf("Status() version %d applied = %v, want %v", s.Version, s.Applied, want)
+			}
+		}
+
+		if _, err := migrator.Down(int(latest)); err != nil {
+			t.Fatalf("Down() all error = %v", err)
+		}
+		assertVersion(t, migrator, 0)
+		if db.HasTable("farms") {
+			t.Errorf("Down() all does not drop the table")
+		}
+	})
+
+	t.Run("baseline over auto migrated schema", func(t *testing.T) {
+		db := openDB(t, newDSN(t))
+		// schema created by gorm AutoMigrate before migration exist, stat_metrics does not have last_batch
+		err := db.AutoMigrate(&postgres.Farms{}, &postgres.Ponds{}, &postgres.FarmPondsMapping{}, &baselineStatMetrics{}).Error
+		if err != nil {
+			t.Fatalf("AutoMigrate() error = %v", err)
+		}
+		rows := []interface{}{
+			&postgres.Farms{Name: "farm a", Status: 1},
+			// the baseline stat backup left two active row of the same key, the newest is kept active
+			&baselineStatMetrics{Key: "Stat_GetFarm", Request: 1, Status: 1},
+			&baselineStatMetrics{Key: "Stat_GetFarm", Request: 2, Status: 1},
+		}
+		for _, row := range rows {
+			if err := db.Create(row).Error; err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+		}
+
+		migrator := newMigrator(t, db)
+		if _, err := migrator.Up(); err != nil {
+			t.Fatalf("Up() error = %v", err)
+		}
+		assertVersion(t, migrator, migrator.LatestVersion())
+
+		var count int
+		db.Model(&postgres.Farms{}).Count(&count)
+		if count != 1 {
+			t.Errorf("Up() over existing schema left %d farm, want 1", count)
+		}
+		if !db.Dialect().HasColumn("stat_metrics", "last_batch") {
+			t.Errorf("Up() does not add last_batch into stat_metrics")
+		}
+		for _, table := range []string{"outbox_events", "webhooks", "webhook_deliveries"} {
+			if !db.HasTable(table) {
+				t.Errorf("Up() does not create table %s", table)
+			}
+		}
+
+		var stats []postgres.StatMetrics
+		if err := db.Order("id").Find(&stats).Error; err != nil {
+			t.Fatalf("Find() stat error = %v", err)
+		}
+		if len(stats) != 2 || stats[0].Status != 2 || stats[1].Status != 1 || stats[1].LastBatch != 0 {
+			t.Errorf("stat after Up() = %+v, want the newest row active", stats)
+		}
+	})
+
+	t.Run("clean dirty data before integrity constraint", func(t *testing.T) {
+		db := openDB(t, newDSN(t))
+		migrator := newMigrator(t, db)
+		if _, err := migrator.Up(); err != nil {
+			t.Fatalf("Up() error = %v", err)
+		}
+		// back to the schema before unique name and foreign key of the mapping
+		if _, err := migrator.Down(int(migrator.LatestVersion() - integrityVersion + 1)); err != nil {
+			t.Fatalf("Down() error = %v", err)
+		}
+
+		deleted := time.Now()
+		rows := []interface{}{
+			&postgres.Farms{Name: "farm a", Status: 1},
+			&postgres.Farms{Name: "farm a", Status: 1},
+			&postgres.Farms{Name: "farm b", Status: 1},
+			&postgres.Ponds{Name: "pond a", Status: 1},
+			&postgres.Ponds{Name: "pond a", Status: 1},
+			&postgres.Ponds{Name: "pond b", Status: 1},
+			// pond 1 is mapped twice, the newer mapping is kept
+			&postgres.FarmPondsMapping{FarmID: 1, PondsID: 1},
+			&postgres.FarmPondsMapping{FarmID: 3, PondsID: 1},
+			// farm 99 and pond 99 does not exist
+			&postgres.FarmPondsMapping{FarmID: 99, PondsID: 2},
+			&postgres.FarmPondsMapping{FarmID: 1, PondsID: 99},
+			// live mapping of pond 3 is kept over the newer deleted mapping
+			&postgres.FarmPondsMapping{FarmID: 1, PondsID: 3},
+			&postgres.FarmPondsMapping{Model: gorm.Model{DeletedAt: &deleted}, FarmID: 3, PondsID: 3},
+		}
+		for _, row := range rows {
+			if err := db.Create(row).Error; err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+		}
+
+		if _, err := migrator.Up(); err != nil {
+			t.Fatalf("Up() over dirty data error = %v", err)
+		}
+		assertVersion(t, migrator, migrator.LatestVersion())
+
+		assertStatus := func(table string, want map[uint]int) {
+			t.Helper()
+			var got []struct {
+				ID     uint
+				Status int
+			}
+			db.Table(table).Select("id, status").Order("id").Scan(&got)
+			for _, row := range got {
+				if row.Status != want[row.ID] {
+					t.Errorf("%s %d status = %d, want %d", table, row.ID, row.Status, want[row.ID])
+				}
+			}
+		}
+		assertStatus("farms", map[uint]int{1: 1, 2: 2, 3: 1})
+		assertStatus("ponds", map[uint]int{1: 1, 2: 2, 3: 1})
+
+		var mappings []postgres.FarmPondsMapping
+		db.Unscoped().Order("id").Find(&mappings)
+		var got [][2]uint
+		for _, m := range mappings {
+			got = append(got, [2]uint{m.FarmID, m.PondsID})
+		}
+		if want := [][2]uint{{3, 1}, {1, 3}}; !reflect.DeepEqual(got, want) {
+			t.Errorf("mapping after Up() = %v, want %v", got, want)
+		}
+	})
+
+	t.Run("refuse newer schema", func(t *testing.T) {
+		dsn := newDSN(t)
+		db := openDB(t, dsn)
+		migrator := newMigrator(t, db)
+		if _, err := migrator.Up(); err != nil {
+			t.Fatalf("Up() error = %v", err)
+		}
+		// migration applied by newer binary
+		err := db.Create(&postgres.SchemaMigrations{Version: migrator.LatestVersion() + 1, Name: "future"}).Error
+		if err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+
+		if _, err := migrator.Up(); !errors.Is(err, postgres.ErrSchemaTooNew) {
+			t.Errorf("Up() error = %v, want %v", err, postgres.ErrSchemaTooNew)
+		}
+		if _, err := migrator.Down(1); !errors.Is(err, postgres.ErrSchemaTooNew) {
+			t.Errorf("Down() error = %v, want %v", err, postgres.ErrSchemaTooNew)
+		}
+		if _, err := postgres.NewPostgresClient(dsn); !errors.Is(err, postgres.ErrSchemaTooNew) {
+			t.Errorf("NewPostgresClient() error = %v, want %v", err, postgres.ErrSchemaTooNew)
+		}
+
+		// clean up so the next postgres test can migrate
+		db.Where("version = ?", migrator.LatestVersion()+1).Delete(&postgres.SchemaMigrations{})
+	})
+}
+
+// baselineStatMetrics is stat_metrics of the baseline schema before the stat batch column
+type baselineStatMetrics struct {
+	gorm.Model
+	Key        string
+	Request    int
+	UniqAgent  int
+	NumSuccess int
+	NumError   int
+	Status     int
+}
+
+// TableName is func to set table name of baselineStatMetrics
+func (baselineStatMetrics) TableName() string {
+	return "stat_metrics"
+}
+
+// openDB is func to open database without applying migration
+func openDB(t *testing.T, dsn string) *gorm.DB {
+	t.Helper()
+	db, err := postgres.Open(dsn)
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+// newMigrator is func to create migrator or stop the test
+func newMigrator(t *testing.T, db *gorm.DB) *postgres.Migrator {
+	t.Helper()
+	migrator, err := postgres.NewMigrator(db)
+	if err != nil {
+		t.Fatalf("NewMigrator() error = %v", err)
+	}
+	return migrator
+}
+
+// mustLoadMigrations is func to get every migration of the database dialect or stop the test
+func mustLoadMigrations(t *testing.T, db *gorm.DB) []postgres.Migration {
+	t.Helper()
+	migrations, err := postgres.LoadMigrations(db.Dialect().GetName())
+	if err != nil {
+		t.Fatalf("LoadMigrations() error = %v", err)
+	}
+	return migrations
+}
+
+// assertVersion is func to check the applied schema version
+func assertVersion(t *testing.T, migrator *postgres.Migrator, want uint) {
+	t.Helper()
+	version, err := migrator.Version()
+	if err != nil || version != want {
+		t.Errorf("Version() = %d %v, want %d", version, err, want)
+	}
+}
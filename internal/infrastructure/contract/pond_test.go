@@ -0,0 +1,364 @@
+package contract
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"aqua-farm-manager/internal/infrastructure/farm"
+	"aqua-farm-manager/internal/infrastructure/pond"
+	"aqua-farm-manager/internal/model"
+	"aqua-farm-manager/pkg/memorydb"
+)
+
+func TestPondStore(t *testing.T) {
+	t.Run("memory", func(t *testing.T) {
+		testPondStore(t, func(t *testing.T) (farm.FarmStore, pond.PondStore) {
+			db := memorydb.NewDB()
+			return farm.NewMemoryFarmStore(db), pond.NewMemoryPondStore(db)
+		})
+	})
+	t.Run("sqlite", func(t *testing.T) {
+		testPondStore(t, func(t *testing.T) (farm.FarmStore, pond.PondStore) {
+			pg := openSQLite(t)
+			return farm.NewFarmStore(pg), pond.NewPondStore(pg)
+		})
+	})
+	t.Run("postgres", func(t *testing.T) {
+		testPondStore(t, func(t *testing.T) (farm.FarmStore, pond.PondStore) {
+			pg := openPostgres(t)
+			return farm.NewFarmStore(pg), pond.NewPondStore(pg)
+		})
+	})
+}
+
+func testPondStore(t *testing.T, newStores farmStores) {
+	// newFarms is func to create two farm to put the pond in
+	newFarms := func(t *testing.T, farmStore farm.FarmStore) (uint, uint) {
+		a := &farm.FarmInfraInfo{Name: "farm a"}
+		b := &farm.FarmInfraInfo{Name: "farm b"}
+		mustCreateFarm(t, farmStore, a)
+		mustCreateFarm(t, farmStore, b)
+		return a.ID, b.ID
+	}
+
+	t.Run("create and get", func(t *testing.T) {
+		farmStore, store := newStores(t)
+		farmA, _ := newFarms(t, farmStore)
+		p := &pond.PondInfraInfo{Name: "pond 1", Capacity: 10, Depth: 2, WaterQuality: 7.5, Species: "tilapia", FarmID: farmA}
+		mustCreatePond(t, store, p)
+		if p.ID == 0 {
+			t.Fatalf("Create() id got = 0, want non zero id")
+		}
+
+		want := pond.PondInfraInfo{ID: p.ID, Name: "pond 1", Capacity: 10, Depth: 2, WaterQuality: 7.5, Species: "tilapia", FarmID: farmA}
+		got := &pond.PondInfraInfo{ID: p.ID}
+		if err := store.GetPondByID(context.Background(), got); err != nil || !reflect.DeepEqual(*got, want) {
+			t.Errorf("GetPondByID() = %+v %v, want %+v", *got, err, want)
+		}
+		got = &pond.PondInfraInfo{Name: "pond 1"}
+		if err := store.GetPondByName(context.Background(), got); err != nil || !reflect.DeepEqual(*got, want) {
+			t.Errorf("GetPondByName() = %+v %v, want %+v", *got, err, want)
+		}
+
+		if err := store.GetPondByID(context.Background(), &pond.PondInfraInfo{ID: p.ID + 100}); err == nil {
+			t.Errorf("GetPondByID() of unknown id expect error")
+		}
+		if err := store.GetPondByName(context.Background(), &pond.PondInfraInfo{Name: "unknown"}); err == nil {
+			t.Errorf("GetPondByName() of unknown name expect error")
+		}
+		if err := store.GetPondByName(context.Background(), &pond.PondInfraInfo{}); err == nil {
+			t.Errorf("GetPondByName() without name expect error")
+		}
+
+		ids, err := store.GetPondIDbyFarmID(context.Background(), farmA)
+		if err != nil || !reflect.DeepEqual(ids, []uint{p.ID}) {
+			t.Errorf("GetPondIDbyFarmID() = %v %v, want %v", ids, err, []uint{p.ID})
+		}
+	})
+
+	t.Run("verify by id or name", func(t *testing.T) {
+		farmStore, store := newStores(t)
+		farmA, _ := newFarms(t, farmStore)
+		p := &pond.PondInfraInfo{Name: "pond 1", FarmID: farmA}
+		mustCreatePond(t, store, p)
+
+		byID := &pond.PondInfraInfo{ID: p.ID}
+		if exists, err := store.Verify(context.Background(), byID); err != nil || !exists || byID.Name != "pond 1" {
+			t.Errorf("Verify() by id got = %v %+v %v, want exists with name", exists, byID, err)
+		}
+		byName := &pond.PondInfraInfo{Name: "pond 1"}
+		if exists, err := store.Verify(context.Background(), byName); err != nil || !exists || byName.ID != p.ID {
+			t.Errorf("Verify() by name got = %v %+v %v, want exists with id", exists, byName, err)
+		}
+		if exists, err := store.Verify(context.Background(), &pond.PondInfraInfo{Name: "unknown"}); err != nil || exists {
+			t.Errorf("Verify() unknown name got = %v %v, want not exists", exists, err)
+		}
+		if _, err := store.Verify(context.Background(), &pond.PondInfraInfo{}); err == nil {
+			t.Errorf("Verify() without id and name expect error")
+		}
+	})
+
+	t.Run("update and move farm", func(t *testing.T) {
+		farmStore, store := newStores(t)
+		farmA, farmB := newFarms(t, farmStore)
+		p := &pond.PondInfraInfo{Name: "pond 1", Capacity: 10, Depth: 2, WaterQuality: 7.5, Species: "tilapia", FarmID: farmA}
+		mustCreatePond(t, store, p)
+
+		err := store.Update(context.Background(), &pond.PondInfraInfo{ID: p.ID, Name: "pond 1", Capacity: 20, Species: "catfish", FarmID: farmB})
+		if err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+
+		want := pond.PondInfraInfo{ID: p.ID, Name: "pond 1", Capacity: 20, Depth: 2, WaterQuality: 7.5, Species: "catfish", FarmID: farmB}
+		got := &pond.PondInfraInfo{ID: p.ID}
+		if err := store.GetPondByID(context.Background(), got); err != nil || !reflect.DeepEqual(*got, want) {
+			t.Errorf("GetPondByID() after update = %+v %v, want %+v", *got, err, want)
+		}
+
+		if ids, _ := store.GetPondIDbyFarmID(context.Background(), farmA); len(ids) != 0 {
+			t.Errorf("GetPondIDbyFarmID() of previous farm = %v, want empty", ids)
+		}
+		if ids, _ := store.GetPondIDbyFarmID(context.Background(), farmB); !reflect.DeepEqual(ids, []uint{p.ID}) {
+			t.Errorf("GetPondIDbyFarmID() of new farm = %v, want %v", ids, []uint{p.ID})
+		}
+	})
+
+	t.Run("soft delete", func(t *testing.T) {
+		farmStore, store := newStores(t)
+		farmA, _ := newFarms(t, farmStore)
+		p1 := &pond.PondInfraInfo{Name: "pond 1", FarmID: farmA}
+		p2 := &pond.PondInfraInfo{Name: "pond 2", FarmID: farmA}
+		mustCreatePond(t, store, p1)
+		mustCreatePond(t, store, p2)
+
+		if err := store.Delete(context.Background(), &pond.PondInfraInfo{ID: p1.ID, Name: "pond 1"}); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+		if err := store.GetPondByID(context.Background(), &pond.PondInfraInfo{ID: p1.ID}); err == nil {
+			t.Errorf("GetPondByID() of deleted pond expect error")
+		}
+		if count, err := store.CountActivePonds(context.Background()); err != nil || count != 1 {
+			t.Errorf("CountActivePonds() = %d %v, want 1", count, err)
+		}
+
+		// the mapping of deleted pond is kept
+		ids, _ := store.GetPondIDbyFarmID(context.Background(), farmA)
+		if !reflect.DeepEqual(sortedIDs(ids), []uint{p1.ID, p2.ID}) {
+			t.Errorf("GetPondIDbyFarmID() = %v, want %v", sortedIDs(ids), []uint{p1.ID, p2.ID})
+		}
+	})
+
+	t.Run("reject duplicate active name and unknown farm", func(t *testing.T) {
+		farmStore, store := newStores(t)
+		farmA, farmB := newFarms(t, farmStore)
+		p := &pond.PondInfraInfo{Name: "pond 1", FarmID: farmA}
+		mustCreatePond(t, store, p)
+
+		err := store.Create(context.Background(), &pond.PondInfraInfo{Name: "pond 1", FarmID: farmB})
+		if !errors.Is(err, pond.ErrDuplicateName) {
+			t.Errorf("Create() duplicate name error = %v, want %v", err, pond.ErrDuplicateName)
+		}
+		err = store.Create(context.Background(), &pond.PondInfraInfo{Name: "pond 2", FarmID: farmB + 100})
+		if !errors.Is(err, pond.ErrInvalidFarm) {
+			t.Errorf("Create() unknown farm error = %v, want %v", err, pond.ErrInvalidFarm)
+		}
+		if exists, _ := store.Verify(context.Background(), &pond.PondInfraInfo{Name: "pond 2"}); exists {
+			t.Errorf("Create() unknown farm store the pond")
+		}
+
+		err = store.Update(context.Background(), &pond.PondInfraInfo{ID: p.ID, Name: "pond 1", Capacity: 5, FarmID: farmB + 100})
+		if !errors.Is(err, pond.ErrInvalidFarm) {
+			t.Errorf("Update() unknown farm error = %v, want %v", err, pond.ErrInvalidFarm)
+		}
+		want := pond.PondInfraInfo{ID: p.ID, Name: "pond 1", FarmID: farmA}
+		got := &pond.PondInfraInfo{ID: p.ID}
+		if err := store.GetPondByID(context.Background(), got); err != nil || !reflect.DeepEqual(*got, want) {
+			t.Errorf("GetPondByID() after rejected update = %+v %v, want %+v", *got, err, want)
+		}
+
+		p2 := &pond.PondInfraInfo{Name: "pond 2", FarmID: farmA}
+		mustCreatePond(t, store, p2)
+		err = store.Update(context.Background(), &pond.PondInfraInfo{ID: p2.ID, Name: "pond 1", Capacity: 5})
+		if !errors.Is(err, pond.ErrDuplicateName) {
+			t.Errorf("Update() into name of other pond error = %v, want %v", err, pond.ErrDuplicateName)
+		}
+		err = store.Update(context.Background(), &pond.PondInfraInfo{ID: p2.ID, Name: "pond 3", Capacity: 5})
+		if err != nil {
+			t.Errorf("Update() with new name error = %v", err)
+		}
+		want = pond.PondInfraInfo{ID: p2.ID, Name: "pond 3", Capacity: 5, FarmID: farmA}
+		got = &pond.PondInfraInfo{ID: p2.ID}
+		if err := store.GetPondByID(context.Background(), got); err != nil || !reflect.DeepEqual(*got, want) {
+			t.Errorf("GetPondByID() after rename = %+v %v, want %+v", *got, err, want)
+		}
+
+		// the name of deleted pond can be used again
+		if err := store.Delete(context.Background(), &pond.PondInfraInfo{ID: p.ID, Name: "pond 1"}); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+		mustCreatePond(t, store, &pond.PondInfraInfo{Name: "pond 1", FarmID: farmB})
+	})
+
+	t.Run("bulk create all or nothing", func(t *testing.T) {
+		farmStore, store := newStores(t)
+		farmA, farmB := newFarms(t, farmStore)
+		p1 := &pond.PondInfraInfo{Name: "pond 1", Capacity: 1, FarmID: farmA}
+		p2 := &pond.PondInfraInfo{Name: "pond 2", Capacity: 2, FarmID: farmB}
+		if err := store.BulkCreate(context.Background(), []*pond.PondInfraInfo{p1, p2}); err != nil {
+			t.Fatalf("BulkCreate() error = %v", err)
+		}
+		got, err := store.GetPondsByIDs(context.Background(), []uint{p1.ID, p2.ID})
+		want := []pond.PondInfraInfo{
+			{ID: p1.ID, Name: "pond 1", Capacity: 1, FarmID: farmA},
+			{ID: p2.ID, Name: "pond 2", Capacity: 2, FarmID: farmB},
+		}
+		if err != nil || !reflect.DeepEqual(got, want) {
+			t.Errorf("GetPondsByIDs() after BulkCreate() = %+v %v, want %+v", got, err, want)
+		}
+
+		// the pond before the rejected pond is not stored
+		err = store.BulkCreate(context.Background(), []*pond.PondInfraInfo{{Name: "pond 3", FarmID: farmA}, {Name: "pond 1", FarmID: farmA}})
+		if !errors.Is(err, pond.ErrDuplicateName) {
+			t.Errorf("BulkCreate() duplicate name error = %v, want %v", err, pond.ErrDuplicateName)
+		}
+		err = store.BulkCreate(context.Background(), []*pond.PondInfraInfo{{Name: "pond 3", FarmID: farmA}, {Name: "pond 4", FarmID: farmB + 100}})
+		if !errors.Is(err, pond.ErrInvalidFarm) {
+			t.Errorf("BulkCreate() unknown farm error = %v, want %v", err, pond.ErrInvalidFarm)
+		}
+		if exists, _ := store.Verify(context.Background(), &pond.PondInfraInfo{Name: "pond 3"}); exists {
+			t.Errorf("BulkCreate() with rejected pond store the other pond")
+		}
+	})
+
+	t.Run("batch lookup by id and farm id", func(t *testing.T) {
+		farmStore, store := newStores(t)
+		farmA, farmB := newFarms(t, farmStore)
+		p1 := &pond.PondInfraInfo{Name: "pond 1", Capacity: 1, FarmID: farmA}
+		p2 := &pond.PondInfraInfo{Name: "pond 2", Capacity: 2, FarmID: farmA}
+		p3 := &pond.PondInfraInfo{Name: "pond 3", Capacity: 3, FarmID: farmB}
+		for _, p := range []*pond.PondInfraInfo{p1, p2, p3} {
+			mustCreatePond(t, store, p)
+		}
+		if err := store.Delete(context.Background(), &pond.PondInfraInfo{ID: p2.ID, Name: "pond 2"}); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+
+		// deleted and unknown pond is not returned
+		got, err := store.GetPondsByIDs(context.Background(), []uint{p3.ID, p2.ID, p1.ID, p3.ID + 100})
+		want := []pond.PondInfraInfo{
+			{ID: p1.ID, Name: "pond 1", Capacity: 1, FarmID: farmA},
+			{ID: p3.ID, Name: "pond 3", Capacity: 3, FarmID: farmB},
+		}
+		if err != nil || !reflect.DeepEqual(got, want) {
+			t.Errorf("GetPondsByIDs() = %+v %v, want %+v", got, err, want)
+		}
+		if got, err := store.GetPondsByIDs(context.Background(), nil); err != nil || len(got) != 0 {
+			t.Errorf("GetPondsByIDs() without id = %+v %v, want empty", got, err)
+		}
+
+		// like GetPondIDbyFarmID the mapping of deleted pond is kept
+		byFarm, err := store.GetPondsByFarmIDs(context.Background(), []uint{farmA, farmB, farmB + 100})
+		if err != nil {
+			t.Fatalf("GetPondsByFarmIDs() error = %v", err)
+		}
+		for farmID, want := range map[uint][]uint{farmA: {p1.ID, p2.ID}, farmB: {p3.ID}, farmB + 100: nil} {
+			if got := sortedIDs(byFarm[farmID]); !reflect.DeepEqual(got, sortedIDs(want)) {
+				t.Errorf("GetPondsByFarmIDs() of farm %d = %v, want %v", farmID, got, want)
+			}
+		}
+	})
+
+	t.Run("export by status with farm name", func(t *testing.T) {
+		farmStore, store := newStores(t)
+		farmA, farmB := newFarms(t, farmStore)
+		p1 := &pond.PondInfraInfo{Name: "pond 1", Capacity: 1, Species: "nila", FarmID: farmA}
+		p2 := &pond.PondInfraInfo{Name: "pond 2", Depth: 2, FarmID: farmB}
+		p3 := &pond.PondInfraInfo{Name: "pond 3", WaterQuality: 7, FarmID: farmA}
+		for _, p := range []*pond.PondInfraInfo{p1, p2, p3} {
+			mustCreatePond(t, store, p)
+		}
+		if err := store.Delete(context.Background(), &pond.PondInfraInfo{ID: p2.ID, Name: "pond 2"}); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+
+		row1 := pond.ExportPondInfo{ID: p1.ID, Name: "pond 1", Capacity: 1, Species: "nila", Status: model.Active.Value(), FarmID: farmA, FarmName: "farm a"}
+		row2 := pond.ExportPondInfo{ID: p2.ID, Name: "pond 2", Depth: 2, Status: model.Inactive.Value(), FarmID: farmB, FarmName: "farm b"}
+		row3 := pond.ExportPondInfo{ID: p3.ID, Name: "pond 3", WaterQuality: 7, Status: model.Active.Value(), FarmID: farmA, FarmName: "farm a"}
+		for status, want := range map[model.Status][]pond.ExportPondInfo{
+			model.Active:   {row1, row3},
+			model.Inactive: {row2},
+			model.Unknown:  {row1, row2, row3},
+		} {
+			var got []pond.ExportPondInfo
+			err := store.Export(context.Background(), status, func(info pond.ExportPondInfo) error {
+				if info.CreatedAt.IsZero() || info.UpdatedAt.IsZero() {
+					t.Errorf("Export() of pond %d has no time", info.ID)
+				}
+				info.CreatedAt, info.UpdatedAt = time.Time{}, time.Time{}
+				got = append(got, info)
+				return nil
+			})
+			if err != nil || !reflect.DeepEqual(got, want) {
+				t.Errorf("Export() of status %d = %+v %v, want %+v", status, got, err, want)
+			}
+		}
+
+		// the stream stop at the first error of fn
+		stop := errors.New("stop")
+		var calls int
+		err := store.Export(context.Background(), model.Unknown, func(info pond.ExportPondInfo) error {
+			calls++
+			return stop
+		})
+		if !errors.Is(err, stop) || calls != 1 {
+			t.Errorf("Export() with error got = %v after %d call, want %v after 1 call", err, calls, stop)
+		}
+	})
+
+	t.Run("paging only active pond with farm", func(t *testing.T) {
+		farmStore, store := newStores(t)
+		farmA, farmB := newFarms(t, farmStore)
+		var ponds []*pond.PondInfraInfo
+		for i, name := range []string{"a", "b", "c"} {
+			p := &pond.PondInfraInfo{Name: name, Capacity: float64(i + 1), FarmID: farmA}
+			if i == 2 {
+				p.FarmID = farmB
+			}
+			mustCreatePond(t, store, p)
+			ponds = append(ponds, p)
+		}
+		if err := store.Delete(context.Background(), &pond.PondInfraInfo{ID: ponds[0].ID, Name: "a"}); err != nil {
+			t.Fatalf("Delete() error = %v", err)
+		}
+
+		got := make(map[uint]pond.PondInfraInfo)
+		for cursor := 1; cursor <= 2; cursor++ {
+			page, err := store.GetPondWithPaging(context.Background(), pond.GetPondWithPagingRequest{Size: 1, Cursor: cursor})
+			if err != nil {
+				t.Fatalf("GetPondWithPaging() error = %v", err)
+			}
+			if len(page) != 1 {
+				t.Fatalf("GetPondWithPaging() page size got = %d, want 1", len(page))
+			}
+			got[page[0].ID] = page[0]
+		}
+		want := map[uint]pond.PondInfraInfo{
+			ponds[1].ID: {ID: ponds[1].ID, Name: "b", Capacity: 2, FarmID: farmA},
+			ponds[2].ID: {ID: ponds[2].ID, Name: "c", Capacity: 3, FarmID: farmB},
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("GetPondWithPaging() = %+v, want %+v", got, want)
+		}
+	})
+}
+
+// mustCreatePond is func to create pond or stop the test
+func mustCreatePond(t *testing.T, store pond.PondStore, r *pond.PondInfraInfo) {
+	t.Helper()
+	if err := store.Create(context.Background(), r); err != nil {
+		t.Fatalf("Create() pond error = %v", err)
+	}
+}
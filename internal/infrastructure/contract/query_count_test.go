@@ -0,0 +1,157 @@
+package contract
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"aqua-farm-manager/internal/app/graph"
+	farmdomain "aqua-farm-manager/internal/domain/farm"
+	"aqua-farm-manager/internal/infrastructure/farm"
+	"aqua-farm-manager/internal/infrastructure/pond"
+	"aqua-farm-manager/pkg/postgres"
+
+	"github.com/jinzhu/gorm"
+)
+
+// TestFarmDomainQueryCount check farm detail and list query the database a fixed number of times
+// whatever the number of farm and pond, so a loop of query per pond or per farm is caught
+func TestFarmDomainQueryCount(t *testing.T) {
+	tests := []struct {
+		name        string
+		farms       int
+		pondPerFarm int
+	}{
+		{name: "one farm with one pond", farms: 1, pondPerFarm: 1},
+		{name: "many farm with many pond", farms: 5, pondPerFarm: 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pg := backgroundDB{openSQLite(t)}
+			farmStore, pondStore := farm.NewFarmStore(pg), pond.NewPondStore(pg)
+			var farmIDs []uint
+			for i := 0; i < tt.farms; i++ {
+				f := &farm.FarmInfraInfo{Name: fmt.Sprintf("farm %d", i)}
+				mustCreateFarm(t, farmStore, f)
+				farmIDs = append(farmIDs, f.ID)
+				for j := 0; j < tt.pondPerFarm; j++ {
+					mustCreatePond(t, pondStore, &pond.PondInfraInfo{Name: fmt.Sprintf("pond %d-%d", i, j), FarmID: f.ID})
+				}
+			}
+			domain := farmdomain.NewFarmDomain(farmStore, pondStore)
+			queries := countQueries(t, pg)
+
+			atomic.StoreInt64(queries, 0)
+			detail, err := domain.GetFarmInfoByID(context.Background(), farmIDs[0])
+			if err != nil || len(detail.PondInfos) != tt.pondPerFarm {
+				t.Fatalf("GetFarmInfoByID() got %d pond %v, want %d", len(detail.PondInfos), err, tt.pondPerFarm)
+			}
+			// farm, pond ids of farm and ponds by ids
+			if got := atomic.LoadInt64(queries); got != 3 {
+				t.Errorf("GetFarmInfoByID() run %d query, want 3", got)
+			}
+
+			atomic.StoreInt64(queries, 0)
+			list, _, err := domain.GetFarm(context.Background(), tt.farms, 1)
+			if err != nil || len(list) != tt.farms {
+				t.Fatalf("GetFarm() got %d farm %v, want %d", len(list), err, tt.farms)
+			}
+			for _, info := range list {
+				if len(info.PondIDs) != tt.pondPerFarm {
+					t.Errorf("GetFarm() farm %d got %d pond id, want %d", info.ID, len(info.PondIDs), tt.pondPerFarm)
+				}
+			}
+			// page of farm and pond ids of every farm in the page
+			if got := atomic.LoadInt64(queries); got != 2 {
+				t.Errorf("GetFarm() run %d query, want 2", got)
+			}
+		})
+	}
+}
+
+// TestGraphQLQueryCount check nested graphql query is loaded in batch per level,
+// so the number of query does not grow with the number of farm and pond
+func TestGraphQLQueryCount(t *testing.T) {
+	tests := []struct {
+		name        string
+		farms       int
+		pondPerFarm int
+	}{
+		{name: "one farm with one pond", farms: 1, pondPerFarm: 1},
+		{name: "many farm with many pond", farms: 5, pondPerFarm: 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pg := backgroundDB{openSQLite(t)}
+			farmStore, pondStore := farm.NewFarmStore(pg), pond.NewPondStore(pg)
+			var farmIDs []uint
+			for i := 0; i < tt.farms; i++ {
+				f := &farm.FarmInfraInfo{Name: fmt.Sprintf("farm %d", i)}
+				mustCreateFarm(t, farmStore, f)
+				farmIDs = append(farmIDs, f.ID)
+				for j := 0; j < tt.pondPerFarm; j++ {
+					mustCreatePond(t, pondStore, &pond.PondInfraInfo{Name: fmt.Sprintf("pond %d-%d", i, j), FarmID: f.ID})
+				}
+			}
+			handler, err := graph.NewGraphQLHandler(farmStore, pondStore, nil)
+			if err != nil {
+				t.Fatalf("NewGraphQLHandler() error = %v", err)
+			}
+			queries := countQueries(t, pg)
+
+			queryCounts := []struct {
+				query string
+				want  int64
+			}{
+				// page of farm, pond ids of every farm and ponds by ids, the farm of pond is cached from the page
+				{query: `{ farms { items { name ponds { name farm { name } } } } }`, want: 3},
+				// page of pond and farm by ids of every pond
+				{query: `{ ponds { items { name farm { name } } } }`, want: 2},
+				// farm by id, pond ids of the farm and ponds by ids
+				{query: fmt.Sprintf(`{ farm(id: %d) { ponds { name } } }`, farmIDs[0]), want: 3},
+			}
+			for _, qc := range queryCounts {
+				atomic.StoreInt64(queries, 0)
+				body := fmt.Sprintf(`{"query":%q}`, qc.query)
+				w := httptest.NewRecorder()
+				handler.GraphQLHandler(w, httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(body)))
+				if w.Code != http.StatusOK || strings.Contains(w.Body.String(), `"errors"`) {
+					t.Fatalf("GraphQLHandler() got %d %s, want success", w.Code, w.Body.String())
+				}
+				if got := atomic.LoadInt64(queries); got != qc.want {
+					t.Errorf("GraphQLHandler() %s run %d query, want %d", qc.query, got, qc.want)
+				}
+			}
+		})
+	}
+}
+
+// backgroundDB is database that run every query on the db of background context, the db of a cancellable
+// context is opened with the default callbacks of gorm so the query run through it is not counted
+type backgroundDB struct {
+	postgres.PostgresMethod
+}
+
+// GetDB is func to return the db of background context whatever ctx is
+func (b backgroundDB) GetDB(ctx context.Context) *gorm.DB {
+	return b.PostgresMethod.GetDB(context.Background())
+}
+
+// countQueries is func to count every select run through the gorm db of pg. The callback is registered on
+// the callbacks cloned for the db of pg instead of the default callback of every gorm db and it is removed after the test
+func countQueries(t *testing.T, pg postgres.PostgresMethod) *int64 {
+	var count int64
+	inc := func(scope *gorm.Scope) { atomic.AddInt64(&count, 1) }
+	callback := pg.GetDB(context.Background()).Callback()
+	callback.Query().After("gorm:query").Register("contract:count_query", inc)
+	callback.RowQuery().After("gorm:row_query").Register("contract:count_row_query", inc)
+	t.Cleanup(func() {
+		callback.Query().Remove("contract:count_query")
+		callback.RowQuery().Remove("contract:count_row_query")
+	})
+	return &count
+}
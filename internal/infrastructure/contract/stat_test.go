@@ -0,0 +1,127 @@
+package contract
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"aqua-farm-manager/internal/infrastructure/stat"
+	"aqua-farm-manager/pkg/memorydb"
+)
+
+func TestStatStore(t *testing.T) {
+	t.Run("memory", func(t *testing.T) {
+		testStatStore(t, func(t *testing.T) stat.StatStore {
+			return stat.NewMemoryStatStore(memorydb.NewDB())
+		})
+	})
+	t.Run("sqlite", func(t *testing.T) {
+		testStatStore(t, func(t *testing.T) stat.StatStore {
+			redis := openRedis(t)
+			return stat.NewStatStore(redis, openSQLite(t))
+		})
+	})
+	t.Run("postgres", func(t *testing.T) {
+		testStatStore(t, func(t *testing.T) stat.StatStore {
+			pg := openPostgres(t)
+			return stat.NewStatStore(openRedis(t), pg)
+		})
+	})
+}
+
+func testStatStore(t *testing.T, newStore func(t *testing.T) stat.StatStore) {
+	// every test use new url id so the key left in redis by previous run is not counted
+	newURLID := func() string {
+		return strconv.FormatInt(time.Now().UnixNano(), 10)
+	}
+
+	t.Run("ingest and get delta", func(t *testing.T) {
+		store := newStore(t)
+		urlID := newURLID()
+		ingest := []stat.IngestMetricsRequest{
+			{UrlID: urlID, Method: "GET", UA: "curl", IsSuccess: true},
+			{UrlID: urlID, Method: "GET", UA: "curl", IsSuccess: false},
+			{UrlID: urlID, Method: "GET", UA: "postman", IsSuccess: true},
+			{UrlID: urlID, Method: "POST", UA: "curl", IsSuccess: true},
+		}
+		for _, r := range ingest {
+			if err := store.IngestMetrics(context.Background(), r); err != nil {
+				t.Fatalf("IngestMetrics() error = %v", err)
+			}
+		}
+
+		got, err := store.GetMetrics(context.Background(), stat.GetMetricsRequest{UrlID: urlID, Method: "GET"})
+		want := stat.MetricsInfo{NumRequest: "3", NumUniqAgent: "2", NumSuccess: "2", NumError: "1"}
+		if err != nil || got != want {
+			t.Errorf("GetMetrics() = %+v %v, want %+v", got, err, want)
+		}
+
+		got, err = store.GetMetrics(context.Background(), stat.GetMetricsRequest{UrlID: newURLID(), Method: "GET"})
+		want = stat.MetricsInfo{NumRequest: "0", NumUniqAgent: "0", NumSuccess: "0", NumError: "0"}
+		if err != nil || got != want {
+			t.Errorf("GetMetrics() of unknown key = %+v %v, want %+v", got, err, want)
+		}
+
+		err = store.IngestMetrics(context.Background(), stat.IngestMetricsRequest{UrlID: urlID, Method: "GET"})
+		if !errors.Is(err, stat.ErrInvalidMetrics) {
+			t.Errorf("IngestMetrics() without ua error = %v, want %v", err, stat.ErrInvalidMetrics)
+		}
+	})
+
+	t.Run("backup move delta into stat data", func(t *testing.T) {
+		store := newStore(t)
+		urlID := newURLID()
+		ingest := func(ua string, success bool) {
+			t.Helper()
+			err := store.IngestMetrics(context.Background(), stat.IngestMetricsRequest{UrlID: urlID, Method: "GET", UA: ua, IsSuccess: success})
+			if err != nil {
+				t.Fatalf("IngestMetrics() error = %v", err)
+			}
+		}
+		backup := func() {
+			t.Helper()
+			if err := store.BackupMetrics(context.Background(), stat.BackupMetricsRequest{UrlID: urlID, Method: "GET"}); err != nil {
+				t.Fatalf("BackupMetrics() error = %v", err)
+			}
+		}
+
+		_, err := store.GetStatData(context.Background(), stat.GetStatDataRequest{UrlID: urlID, Method: "GET"})
+		if err == nil {
+			t.Errorf("GetStatData() before backup expect error")
+		}
+
+		// backup without delta does nothing
+		backup()
+
+		ingest("curl", true)
+		ingest("postman", false)
+		backup()
+
+		zero := stat.MetricsInfo{NumRequest: "0", NumUniqAgent: "0", NumSuccess: "0", NumError: "0"}
+		if got, err := store.GetMetrics(context.Background(), stat.GetMetricsRequest{UrlID: urlID, Method: "GET"}); err != nil || got != zero {
+			t.Errorf("GetMetrics() after backup = %+v %v, want %+v", got, err, zero)
+		}
+		want := stat.MetricsInfo{NumRequest: "2", NumUniqAgent: "2", NumSuccess: "1", NumError: "1"}
+		if got, err := store.GetStatData(context.Background(), stat.GetStatDataRequest{UrlID: urlID, Method: "GET"}); err != nil || got != want {
+			t.Errorf("GetStatData() = %+v %v, want %+v", got, err, want)
+		}
+
+		// the known ua is not counted again after backup
+		ingest("curl", true)
+		ingest("wget", true)
+		backup()
+		want = stat.MetricsInfo{NumRequest: "4", NumUniqAgent: "3", NumSuccess: "3", NumError: "1"}
+		if got, err := store.GetStatData(context.Background(), stat.GetStatDataRequest{UrlID: urlID, Method: "GET"}); err != nil || got != want {
+			t.Errorf("GetStatData() after second backup = %+v %v, want %+v", got, err, want)
+		}
+
+		if err := store.CompactMetrics(context.Background()); err != nil {
+			t.Fatalf("CompactMetrics() error = %v", err)
+		}
+		if got, err := store.GetStatData(context.Background(), stat.GetStatDataRequest{UrlID: urlID, Method: "GET"}); err != nil || got != want {
+			t.Errorf("GetStatData() after compact = %+v %v, want %+v", got, err, want)
+		}
+	})
+}
@@ -0,0 +1,136 @@
+package farm
+
+import (
+	"aqua-farm-manager/internal/infrastructure/cache"
+	"aqua-farm-manager/pkg/redis"
+	"context"
+	"sort"
+	"strconv"
+)
+
+// CachedFarm is FarmStore decorator that cache farm lookup by id in redis,
+// the cached farm is invalidated after Create, Update and Delete with background context
+// because the write is already stored when the request is cancelled
+type CachedFarm struct {
+	FarmStore
+	cache *cache.Cache
+}
+
+// NewCachedFarmStore is func to wrap FarmStore with read-through redis cache
+func NewCachedFarmStore(store FarmStore, redis redis.RedisMethod, ttlInSec int) FarmStore {
+	return &CachedFarm{
+		FarmStore: store,
+		cache:     cache.NewCache(redis, "farm", ttlInSec),
+	}
+}
+
+// GetFarmByID is func to get farm info from cache or from the store when it is not cached
+func (c *CachedFarm) GetFarmByID(ctx context.Context, r *FarmInfraInfo) error {
+	if r == nil || r.ID <= 0 {
+		return c.FarmStore.GetFarmByID(ctx, r)
+	}
+
+	return c.cache.Fetch(ctx, c.idKey(r.ID), r, func(ctx context.Context) (interface{}, error) {
+		info := FarmInfraInfo{ID: r.ID}
+		err := c.FarmStore.GetFarmByID(ctx, &info)
+		return info, err
+	})
+}
+
+// GetFarmsByIDs is func to get active farm info from cache and load every missed farm from the store in a single query,
+// it share the cached farm of GetFarmByID because both only return active farm
+func (c *CachedFarm) GetFarmsByIDs(ctx context.Context, ids []uint) ([]FarmInfraInfo, error) {
+	if len(ids) == 0 {
+		return c.FarmStore.GetFarmsByIDs(ctx, ids)
+	}
+
+	keys := make([]string, len(ids))
+	cached := make([]FarmInfraInfo, len(ids))
+	for i, id := range ids {
+		keys[i] = c.idKey(id)
+	}
+	missed := c.cache.FetchMany(ctx, keys, func(i int) interface{} {
+		return &cached[i]
+	})
+
+	isMissed := make(map[int]bool, len(missed))
+	for _, index := range missed {
+		isMissed[index] = true
+	}
+	var list []FarmInfraInfo
+	for i := range ids {
+		if !isMissed[i] {
+			list = append(list, cached[i])
+		}
+	}
+	if len(missed) == 0 {
+		return list, nil
+	}
+
+	missedIDs := make([]uint, len(missed))
+	for i, index := range missed {
+		missedIDs[i] = ids[index]
+	}
+	loaded, err := c.FarmStore.GetFarmsByIDs(ctx, missedIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	// farm that is not found is not cached, GetFarmByID does not cache it either
+	for _, farm := range loaded {
+		c.cache.Store(ctx, c.idKey(farm.ID), farm)
+		list = append(list, farm)
+	}
+
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	return list, nil
+}
+
+// Create is func to store farm and invalidate its cached value
+func (c *CachedFarm) Create(ctx context.Context, r *FarmInfraInfo) error {
+	err := c.FarmStore.Create(ctx, r)
+	if err != nil {
+		return err
+	}
+	c.cache.Invalidate(context.Background(), c.idKey(r.ID))
+	return nil
+}
+
+// BulkCreate is func to store every farm and invalidate their cached value
+func (c *CachedFarm) BulkCreate(ctx context.Context, list []*FarmInfraInfo) error {
+	err := c.FarmStore.BulkCreate(ctx, list)
+	if err != nil {
+		return err
+	}
+	keys := make([]string, 0, len(list))
+	for _, r := range list {
+		keys = append(keys, c.idKey(r.ID))
+	}
+	c.cache.Invalidate(context.Background(), keys...)
+	return nil
+}
+
+// Update is func to update farm and invalidate its cached value
+func (c *CachedFarm) Update(ctx context.Context, r *FarmInfraInfo) error {
+	err := c.FarmStore.Update(ctx, r)
+	if err != nil {
+		return err
+	}
+	c.cache.Invalidate(context.Background(), c.idKey(r.ID))
+	return nil
+}
+
+// Delete is func to soft delete farm and invalidate its cached value
+func (c *CachedFarm) Delete(ctx context.Context, r *FarmInfraInfo) error {
+	err := c.FarmStore.Delete(ctx, r)
+	if err != nil {
+		return err
+	}
+	c.cache.Invalidate(context.Background(), c.idKey(r.ID))
+	return nil
+}
+
+// idKey is func to generate cache key of farm by id
+func (c *CachedFarm) idKey(id uint) string {
+	return c.cache.Key("id:" + strconv.FormatUint(uint64(id), 10))
+}
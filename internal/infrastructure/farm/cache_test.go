@@ -0,0 +1,84 @@
+package farm
+
+import (
+	"aqua-farm-manager/pkg/memorydb"
+	"aqua-farm-manager/pkg/redis"
+	mock_redis "aqua-farm-manager/pkg/redis/mock"
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+)
+
+func TestCachedFarm(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	r := mock_redis.NewMockRedisMethod(mockCtrl)
+	store := NewMemoryFarmStore(memorydb.NewDB())
+	cached := NewCachedFarmStore(store, r, 30)
+	key := "C:farm:id:1"
+
+	// create invalidate the farm id
+	r.EXPECT().Delete(gomock.Any(), key).Return(nil)
+	farm := &FarmInfraInfo{Name: "farm a", Location: "bandung"}
+	if err := cached.Create(context.Background(), farm); err != nil {
+		t.Fatalf("CachedFarm.Create() error = %v", err)
+	}
+
+	// miss load from the store and fill the cache
+	r.EXPECT().Get(gomock.Any(), key).Return("", redis.ErrNil)
+	r.EXPECT().SetEX(gomock.Any(), key, gomock.Any(), 30).Return(nil)
+	got := &FarmInfraInfo{ID: farm.ID}
+	if err := cached.GetFarmByID(context.Background(), got); err != nil || got.Name != "farm a" {
+		t.Errorf("CachedFarm.GetFarmByID() miss got = %v %v, want farm a", got, err)
+	}
+
+	// hit does not read the store
+	r.EXPECT().Get(gomock.Any(), key).Return(`{"ID":1,"Name":"cached farm"}`, nil)
+	got = &FarmInfraInfo{ID: farm.ID}
+	if err := cached.GetFarmByID(context.Background(), got); err != nil || got.Name != "cached farm" {
+		t.Errorf("CachedFarm.GetFarmByID() hit got = %v %v, want cached farm", got, err)
+	}
+
+	// batch lookup share the cached farm and only load the missed farm from the store
+	r.EXPECT().MGet(gomock.Any(), key, "C:farm:id:99").Return([]string{"", ""}, nil)
+	r.EXPECT().SetEX(gomock.Any(), key, gomock.Any(), 30).Return(nil)
+	if list, err := cached.GetFarmsByIDs(context.Background(), []uint{farm.ID, 99}); err != nil || len(list) != 1 || list[0].Name != "farm a" {
+		t.Errorf("CachedFarm.GetFarmsByIDs() miss got = %v %v, want farm a", list, err)
+	}
+	r.EXPECT().MGet(gomock.Any(), key).Return([]string{`{"ID":1,"Name":"cached farm"}`}, nil)
+	if list, err := cached.GetFarmsByIDs(context.Background(), []uint{farm.ID}); err != nil || len(list) != 1 || list[0].Name != "cached farm" {
+		t.Errorf("CachedFarm.GetFarmsByIDs() hit got = %v %v, want cached farm", list, err)
+	}
+
+	// update and delete invalidate the farm id
+	r.EXPECT().Delete(gomock.Any(), key).Return(nil).Times(2)
+	if err := cached.Update(context.Background(), &FarmInfraInfo{ID: farm.ID, Name: "farm b"}); err != nil {
+		t.Errorf("CachedFarm.Update() error = %v", err)
+	}
+	if err := cached.Delete(context.Background(), &FarmInfraInfo{ID: farm.ID}); err != nil {
+		t.Errorf("CachedFarm.Delete() error = %v", err)
+	}
+
+	// bulk create invalidate every created farm id
+	r.EXPECT().Delete(gomock.Any(), "C:farm:id:2").Return(nil)
+	r.EXPECT().Delete(gomock.Any(), "C:farm:id:3").Return(nil)
+	if err := cached.BulkCreate(context.Background(), []*FarmInfraInfo{{Name: "farm c"}, {Name: "farm d"}}); err != nil {
+		t.Errorf("CachedFarm.BulkCreate() error = %v", err)
+	}
+
+	// failed write keep the cache, no Delete is expected
+	if err := cached.Update(context.Background(), nil); err == nil {
+		t.Errorf("CachedFarm.Update() of nil request error = nil")
+	}
+	if err := cached.BulkCreate(context.Background(), []*FarmInfraInfo{{Name: "farm c"}}); err == nil {
+		t.Errorf("CachedFarm.BulkCreate() of duplicate name error = nil")
+	}
+
+	// store error is not cached
+	r.EXPECT().Get(gomock.Any(), "C:farm:id:99").Return("", redis.ErrNil)
+	if err := cached.GetFarmByID(context.Background(), &FarmInfraInfo{ID: 99}); err == nil {
+		t.Errorf("CachedFarm.GetFarmByID() of unknown farm error = nil")
+	}
+}
@@ -1,23 +1,35 @@
 package farm
 
 import (
+	"aqua-farm-manager/internal/infrastructure/outbox"
 	"aqua-farm-manager/internal/model"
 	"aqua-farm-manager/pkg/postgres"
+	"context"
 	"errors"
 
 	"github.com/jinzhu/gorm"
 )
 
+// ErrDuplicateName is error when active farm with the same name already exists
+var ErrDuplicateName = errors.New("farm name already exists")
+
+// ErrNotFound is error when the farm does not exist, it is the same error of every farm store
+var ErrNotFound = gorm.ErrRecordNotFound
+
 // FarmStore is set of methods for interacting with a farm storage system
 type FarmStore interface {
-	Verify(r *FarmInfraInfo) (bool, error)
-	Create(r *FarmInfraInfo) error
-	Delete(r *FarmInfraInfo) error
-	Update(r *FarmInfraInfo) error
-	GetFarmByName(r *FarmInfraInfo) error
-	GetFarmByID(r *FarmInfraInfo) error
-	GetFarmWithPaging(r GetFarmWithPagingRequest) ([]FarmInfraInfo, error)
-	GetActivePondsInFarm(farmid uint) []uint
+	Verify(ctx context.Context, r *FarmInfraInfo) (bool, error)
+	Create(ctx context.Context, r *FarmInfraInfo) error
+	BulkCreate(ctx context.Context, list []*FarmInfraInfo) error
+	Delete(ctx context.Context, r *FarmInfraInfo) error
+	Update(ctx context.Context, r *FarmInfraInfo) error
+	GetFarmByName(ctx context.Context, r *FarmInfraInfo) error
+	GetFarmByID(ctx context.Context, r *FarmInfraInfo) error
+	GetFarmsByIDs(ctx context.Context, ids []uint) ([]FarmInfraInfo, error)
+	GetFarmWithPaging(ctx context.Context, r GetFarmWithPagingRequest) ([]FarmInfraInfo, error)
+	Export(ctx context.Context, status model.Status, fn func(ExportFarmInfo) error) error
+	GetActivePondsInFarm(ctx context.Context, farmid uint) []uint
+	GetPondCountPerFarm(ctx context.Context) (map[uint]int, error)
 }
 
 // Farm is list dependencies farm store
@@ -33,9 +45,9 @@ func NewFarmStore(pg postgres.PostgresMethod) FarmStore {
 }
 
 // Create is func to store farm into database
-func (f *Farm) Create(r *FarmInfraInfo) error {
+func (f *Farm) Create(ctx context.Context, r *FarmInfraInfo) error {
 	var err error
-	db := f.pg.GetDB()
+	db := f.pg.GetDB(ctx)
 	if db == nil {
 		return errors.New("Database Client is not init")
 	}
@@ -52,7 +64,16 @@ func (f *Farm) Create(r *FarmInfraInfo) error {
 		Status:   model.Active.Value(),
 	}
 
-	err = insert(db, farm)
+	err = db.Transaction(func(tx *gorm.DB) error {
+		err := insert(tx, farm)
+		if postgres.IsUniqueViolation(err) {
+			return ErrDuplicateName
+		}
+		if err != nil {
+			return err
+		}
+		return outbox.Insert(tx, farm.Model.ID, r.Events)
+	})
 	if err != nil {
 		return err
 	}
@@ -62,10 +83,58 @@ func (f *Farm) Create(r *FarmInfraInfo) error {
 	return err
 }
 
-// Update is func to store farm into database
-func (f *Farm) Update(r *FarmInfraInfo) error {
+// BulkCreate is func to store every farm to database in a single transaction,
+// nothing is stored when any farm cannot be stored
+func (f *Farm) BulkCreate(ctx context.Context, list []*FarmInfraInfo) error {
+	db := f.pg.GetDB(ctx)
+	if db == nil {
+		return errors.New("Database Client is not init")
+	}
+
+	farms := make([]*postgres.Farms, 0, len(list))
+	err := db.Transaction(func(tx *gorm.DB) error {
+		for _, r := range list {
+			if r == nil {
+				return errors.New("got nil request")
+			}
+
+			farm := &postgres.Farms{
+				Name:     r.Name,
+				Location: r.Location,
+				Owner:    r.Owner,
+				Area:     r.Area,
+				Status:   model.Active.Value(),
+			}
+			err := insert(tx, farm)
+			if postgres.IsUniqueViolation(err) {
+				return ErrDuplicateName
+			}
+			if err != nil {
+				return err
+			}
+			err = outbox.Insert(tx, farm.Model.ID, r.Events)
+			if err != nil {
+				return err
+			}
+			farms = append(farms, farm)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for i, farm := range farms {
+		list[i].ID = farm.Model.ID
+	}
+	return nil
+}
+
+// Update is func to store farm into database, it return ErrDuplicateName when the farm is renamed
+// into the name of other active farm
+func (f *Farm) Update(ctx context.Context, r *FarmInfraInfo) error {
 	var err error
-	db := f.pg.GetDB()
+	db := f.pg.GetDB(ctx)
 	if db == nil {
 		return errors.New("Database Client is not init")
 	}
@@ -85,7 +154,16 @@ func (f *Farm) Update(r *FarmInfraInfo) error {
 		Status:   model.Active.Value(),
 	}
 
-	err = update(db, farm)
+	err = db.Transaction(func(tx *gorm.DB) error {
+		err := update(tx, farm)
+		if postgres.IsUniqueViolation(err) {
+			return ErrDuplicateName
+		}
+		if err != nil {
+			return err
+		}
+		return outbox.Insert(tx, farm.Model.ID, r.Events)
+	})
 	if err != nil {
 		return err
 	}
@@ -94,9 +172,9 @@ func (f *Farm) Update(r *FarmInfraInfo) error {
 }
 
 // Delete is func to soft delete farm into database
-func (f *Farm) Delete(r *FarmInfraInfo) error {
+func (f *Farm) Delete(ctx context.Context, r *FarmInfraInfo) error {
 	var err error
-	db := f.pg.GetDB()
+	db := f.pg.GetDB(ctx)
 	if db == nil {
 		return errors.New("Database Client is not init")
 	}
@@ -112,7 +190,13 @@ func (f *Farm) Delete(r *FarmInfraInfo) error {
 		Name: r.Name,
 	}
 
-	err = delete(db, farm)
+	err = db.Transaction(func(tx *gorm.DB) error {
+		err := delete(tx, farm)
+		if err != nil {
+			return err
+		}
+		return outbox.Insert(tx, farm.Model.ID, r.Events)
+	})
 	if err != nil {
 		return err
 	}
@@ -121,9 +205,9 @@ func (f *Farm) Delete(r *FarmInfraInfo) error {
 }
 
 // GetFarmByName is func get farm info based on name in database
-func (f *Farm) GetFarmByName(r *FarmInfraInfo) error {
+func (f *Farm) GetFarmByName(ctx context.Context, r *FarmInfraInfo) error {
 	var err error
-	db := f.pg.GetDB()
+	db := f.pg.GetDB(ctx)
 	if db == nil {
 		return errors.New("Database Client is not init")
 	}
@@ -148,9 +232,9 @@ func (f *Farm) GetFarmByName(r *FarmInfraInfo) error {
 }
 
 // GetFarmByID is func get farm info based on id in database
-func (f *Farm) GetFarmByID(r *FarmInfraInfo) error {
+func (f *Farm) GetFarmByID(ctx context.Context, r *FarmInfraInfo) error {
 	var err error
-	db := f.pg.GetDB()
+	db := f.pg.GetDB(ctx)
 	if db == nil {
 		return errors.New("Database Client is not init")
 	}
@@ -176,10 +260,46 @@ func (f *Farm) GetFarmByID(r *FarmInfraInfo) error {
 	return err
 }
 
+// GetFarmsByIDs is func to get active farm info ordered by id in database by list of farm id in a single query,
+// farm that does not exist or inactive is not returned
+func (f *Farm) GetFarmsByIDs(ctx context.Context, ids []uint) ([]FarmInfraInfo, error) {
+	var list []FarmInfraInfo
+	db := f.pg.GetDB(ctx)
+	if db == nil {
+		return list, errors.New("Database Client is not init")
+	}
+
+	if len(ids) == 0 {
+		return list, nil
+	}
+
+	farms, err := getFarmsByIDs(db, ids)
+	if err != nil {
+		return list, err
+	}
+
+	for _, farm := range farms {
+		list = append(list, FarmInfraInfo{
+			ID:       farm.ID,
+			Name:     farm.Name,
+			Location: farm.Location,
+			Owner:    farm.Owner,
+			Area:     farm.Area,
+		})
+	}
+	return list, nil
+}
+
+func getFarmsByIDs(db *gorm.DB, ids []uint) ([]postgres.Farms, error) {
+	var farms []postgres.Farms
+	err := db.Where("id in (?) AND status = ?", ids, model.Active.Value()).Order("id").Find(&farms).Error
+	return farms, err
+}
+
 // Verify is func to check if farm already exists based on id and name
-func (f *Farm) Verify(r *FarmInfraInfo) (bool, error) {
+func (f *Farm) Verify(ctx context.Context, r *FarmInfraInfo) (bool, error) {
 	var exists bool
-	db := f.pg.GetDB()
+	db := f.pg.GetDB(ctx)
 	if db == nil {
 		return false, errors.New("Database Client is not init")
 	}
@@ -226,9 +346,9 @@ func insert(db *gorm.DB, data interface{}) error {
 	return db.Create(data).Error
 }
 
-// update is func to update data farm in database
+// update is func to update data active farm by id in database, the name is updated too when it is not empty
 func update(db *gorm.DB, farm *postgres.Farms) error {
-	return db.Model(farm).Where("name = ? AND id = ? and status = ?", farm.Name, farm.Model.ID, model.Active.Value()).Updates(farm).Error
+	return db.Model(farm).Where("id = ? and status = ?", farm.Model.ID, model.Active.Value()).Updates(farm).Error
 }
 
 // delete is func to soft delete data farm into database with update the status to inactive
@@ -237,11 +357,11 @@ func delete(db *gorm.DB, farm *postgres.Farms) error {
 }
 
 // GetFarmWithPaging is func to get all farm with paging
-func (f *Farm) GetFarmWithPaging(r GetFarmWithPagingRequest) ([]FarmInfraInfo, error) {
+func (f *Farm) GetFarmWithPaging(ctx context.Context, r GetFarmWithPagingRequest) ([]FarmInfraInfo, error) {
 	var list []FarmInfraInfo
 	var err error
 
-	db := f.pg.GetDB()
+	db := f.pg.GetDB(ctx)
 	if db == nil {
 		return list, errors.New("Database Client is not init")
 	}
@@ -270,6 +390,42 @@ func getFarmsWithPaging(db *gorm.DB, cursor int, size int) ([]postgres.Farms, er
 	return farms, err
 }
 
+// Export is func to stream farm with the status into fn one row at a time with database cursor ordered by id,
+// every farm is streamed when the status is unknown and the stream stop at the first error of fn
+func (f *Farm) Export(ctx context.Context, status model.Status, fn func(ExportFarmInfo) error) error {
+	db := f.pg.GetDB(ctx)
+	if db == nil {
+		return errors.New("Database Client is not init")
+	}
+
+	query := db.Table("farms").
+		Select("id, name, location, owner, area, status, created_at, updated_at").
+		Where("deleted_at is null")
+	if status != model.Unknown {
+		query = query.Where("status = ?", status.Value())
+	}
+
+	rows, err := query.Order("id").Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var info ExportFarmInfo
+		err = db.ScanRows(rows, &info)
+		if err != nil {
+			return err
+		}
+		err = fn(info)
+		if err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
 func getActivePondsInFarms(db *gorm.DB, farmID uint) []uint {
 	var farmPondsMappings []postgres.FarmPondsMapping
 	var pondsID []uint
@@ -283,11 +439,49 @@ func getActivePondsInFarms(db *gorm.DB, farmID uint) []uint {
 	return pondsID
 }
 
-func (f *Farm) GetActivePondsInFarm(farmid uint) []uint {
-	db := f.pg.GetDB()
+func (f *Farm) GetActivePondsInFarm(ctx context.Context, farmid uint) []uint {
+	db := f.pg.GetDB(ctx)
 	if db == nil {
 		return []uint{}
 	}
 
 	return getActivePondsInFarms(db, farmid)
 }
+
+// GetPondCountPerFarm is func to count active ponds of every active farm
+func (f *Farm) GetPondCountPerFarm(ctx context.Context) (map[uint]int, error) {
+	db := f.pg.GetDB(ctx)
+	if db == nil {
+		return nil, errors.New("Database Client is not init")
+	}
+
+	counts, err := getPondCountPerFarm(db)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[uint]int, len(counts))
+	for _, count := range counts {
+		result[count.FarmID] = count.Total
+	}
+
+	return result, nil
+}
+
+// farmPondCount is list scanned value for pond count per farm
+type farmPondCount struct {
+	FarmID uint
+	Total  int
+}
+
+func getPondCountPerFarm(db *gorm.DB) ([]farmPondCount, error) {
+	var counts []farmPondCount
+	err := db.Table("farms").
+		Select("farms.id AS farm_id, COUNT(ponds.id) AS total").
+		Joins("LEFT JOIN farm_ponds_mappings ON farm_ponds_mappings.farm_id = farms.id").
+		Joins("LEFT JOIN ponds ON ponds.id = farm_ponds_mappings.ponds_id AND ponds.status = ?", model.Active.Value()).
+		Where("farms.status = ?", model.Active.Value()).
+		Group("farms.id").
+		Scan(&counts).Error
+	return counts, err
+}
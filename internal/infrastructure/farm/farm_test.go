@@ -1,9 +1,11 @@
 package farm
 
 import (
+	"aqua-farm-manager/internal/infrastructure/outbox"
 	"aqua-farm-manager/internal/model"
 	"aqua-farm-manager/pkg/postgres"
 	mock_postgres "aqua-farm-manager/pkg/postgres/mock"
+	"context"
 	"database/sql"
 	"fmt"
 	"log"
@@ -70,7 +72,7 @@ func TestFarm_Create(t *testing.T) {
 		{
 			name: "success",
 			mockFunc: func() {
-				pg.EXPECT().GetDB().Return(gormDB)
+				pg.EXPECT().GetDB(gomock.Any()).Return(gormDB)
 				mockDB.ExpectBegin()
 				mockDB.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "farms" ("created_at","updated_at","deleted_at","name","location","owner","area","status") VALUES ($1,$2,$3,$4,$5,$6,$7,$8)`)).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
 				mockDB.ExpectCommit()
@@ -80,10 +82,40 @@ func TestFarm_Create(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "success with event",
+			mockFunc: func() {
+				pg.EXPECT().GetDB(gomock.Any()).Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "farms" ("created_at","updated_at","deleted_at","name","location","owner","area","status") VALUES ($1,$2,$3,$4,$5,$6,$7,$8)`)).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mockDB.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "outbox_events" ("created_at","updated_at","deleted_at","event_id","topic","payload","status","attempts") VALUES ($1,$2,$3,$4,$5,$6,$7,$8)`)).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mockDB.ExpectCommit()
+			},
+			r: &FarmInfraInfo{
+				Name:   "a",
+				Events: []outbox.Event{{Topic: "topic", Type: "farm.created"}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "rollback when got error store event",
+			mockFunc: func() {
+				pg.EXPECT().GetDB(gomock.Any()).Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "farms" ("created_at","updated_at","deleted_at","name","location","owner","area","status") VALUES ($1,$2,$3,$4,$5,$6,$7,$8)`)).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mockDB.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "outbox_events" ("created_at","updated_at","deleted_at","event_id","topic","payload","status","attempts") VALUES ($1,$2,$3,$4,$5,$6,$7,$8)`)).WillReturnError(fmt.Errorf("some error"))
+				mockDB.ExpectRollback()
+			},
+			r: &FarmInfraInfo{
+				Name:   "a",
+				Events: []outbox.Event{{Topic: "topic", Type: "farm.created"}},
+			},
+			wantErr: true,
+		},
 		{
 			name: "got error exec",
 			mockFunc: func() {
-				pg.EXPECT().GetDB().Return(gormDB)
+				pg.EXPECT().GetDB(gomock.Any()).Return(gormDB)
 				mockDB.ExpectBegin()
 				mockDB.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "farms" ("created_at","updated_at","deleted_at","name","location","owner","area","status") VALUES ($1,$2,$3,$4,$5,$6,$7,$8)`)).WillReturnError(fmt.Errorf("some error"))
 			},
@@ -95,7 +127,7 @@ func TestFarm_Create(t *testing.T) {
 		{
 			name: "nil db",
 			mockFunc: func() {
-				pg.EXPECT().GetDB().Return(nil)
+				pg.EXPECT().GetDB(gomock.Any()).Return(nil)
 			},
 			r: &FarmInfraInfo{
 				ID: 1,
@@ -105,7 +137,7 @@ func TestFarm_Create(t *testing.T) {
 		{
 			name: "req nil",
 			mockFunc: func() {
-				pg.EXPECT().GetDB().Return(gormDB)
+				pg.EXPECT().GetDB(gomock.Any()).Return(gormDB)
 			},
 			wantErr: true,
 		},
@@ -114,7 +146,7 @@ func TestFarm_Create(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockFunc()
 			s := NewFarmStore(pg)
-			if err := s.Create(tt.r); (err != nil) != tt.wantErr {
+			if err := s.Create(context.Background(), tt.r); (err != nil) != tt.wantErr {
 				t.Errorf("Farm.Create() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -137,9 +169,9 @@ func TestFarm_Update(t *testing.T) {
 		{
 			name: "success",
 			mockFunc: func() {
-				pg.EXPECT().GetDB().Return(gormDB)
+				pg.EXPECT().GetDB(gomock.Any()).Return(gormDB)
 				mockDB.ExpectBegin()
-				mockDB.ExpectExec(regexp.QuoteMeta(`UPDATE "farms" SET "id" = $1, "status" = $2, "updated_at" = $3 WHERE "farms"."deleted_at" IS NULL AND "farms"."id" = $4 AND ((name = $5 AND id = $6 and status = $7))`)).WillReturnResult(sqlmock.NewResult(1, 1))
+				mockDB.ExpectExec(regexp.QuoteMeta(`UPDATE "farms" SET "id" = $1, "status" = $2, "updated_at" = $3 WHERE "farms"."deleted_at" IS NULL AND "farms"."id" = $4 AND ((id = $5 and status = $6))`)).WillReturnResult(sqlmock.NewResult(1, 1))
 				mockDB.ExpectCommit()
 			},
 			r: &FarmInfraInfo{
@@ -150,9 +182,9 @@ func TestFarm_Update(t *testing.T) {
 		{
 			name: "got error exec",
 			mockFunc: func() {
-				pg.EXPECT().GetDB().Return(gormDB)
+				pg.EXPECT().GetDB(gomock.Any()).Return(gormDB)
 				mockDB.ExpectBegin()
-				mockDB.ExpectExec(regexp.QuoteMeta(`UPDATE "farms" SET "id" = $1, "status" = $2, "updated_at" = $3 WHERE "farms"."deleted_at" IS NULL AND "farms"."id" = $4 AND ((name = $5 AND id = $6 and status = $7))`)).WillReturnError(fmt.Errorf("some error"))
+				mockDB.ExpectExec(regexp.QuoteMeta(`UPDATE "farms" SET "id" = $1, "status" = $2, "updated_at" = $3 WHERE "farms"."deleted_at" IS NULL AND "farms"."id" = $4 AND ((id = $5 and status = $6))`)).WillReturnError(fmt.Errorf("some error"))
 			},
 			r: &FarmInfraInfo{
 				ID: 1,
@@ -162,7 +194,7 @@ func TestFarm_Update(t *testing.T) {
 		{
 			name: "nil db",
 			mockFunc: func() {
-				pg.EXPECT().GetDB().Return(nil)
+				pg.EXPECT().GetDB(gomock.Any()).Return(nil)
 			},
 			r: &FarmInfraInfo{
 				ID: 1,
@@ -172,7 +204,7 @@ func TestFarm_Update(t *testing.T) {
 		{
 			name: "req nil",
 			mockFunc: func() {
-				pg.EXPECT().GetDB().Return(gormDB)
+				pg.EXPECT().GetDB(gomock.Any()).Return(gormDB)
 			},
 
 			wantErr: true,
@@ -182,7 +214,7 @@ func TestFarm_Update(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockFunc()
 			s := NewFarmStore(pg)
-			if err := s.Update(tt.r); (err != nil) != tt.wantErr {
+			if err := s.Update(context.Background(), tt.r); (err != nil) != tt.wantErr {
 				t.Errorf("Farm.Create() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -205,7 +237,7 @@ func TestFarm_Delete(t *testing.T) {
 		{
 			name: "success",
 			mockFunc: func() {
-				pg.EXPECT().GetDB().Return(gormDB)
+				pg.EXPECT().GetDB(gomock.Any()).Return(gormDB)
 				mockDB.ExpectBegin()
 				mockDB.ExpectExec(regexp.QuoteMeta(`UPDATE "farms" SET "status" = $1, "updated_at" = $2 WHERE "farms"."deleted_at" IS NULL AND "farms"."id" = $3 AND ((name = $4 AND id = $5 and status = $6))`)).WillReturnResult(sqlmock.NewResult(1, 1))
 				mockDB.ExpectCommit()
@@ -218,7 +250,7 @@ func TestFarm_Delete(t *testing.T) {
 		{
 			name: "got error exec",
 			mockFunc: func() {
-				pg.EXPECT().GetDB().Return(gormDB)
+				pg.EXPECT().GetDB(gomock.Any()).Return(gormDB)
 				mockDB.ExpectBegin()
 				mockDB.ExpectExec(regexp.QuoteMeta(`UPDATE "farms" SET "status" = $1, "updated_at" = $2 WHERE "farms"."deleted_at" IS NULL AND "farms"."id" = $3 AND ((name = $4 AND id = $5 and status = $6))`)).WillReturnResult(sqlmock.NewResult(1, 1))
 			},
@@ -230,7 +262,7 @@ func TestFarm_Delete(t *testing.T) {
 		{
 			name: "nil db",
 			mockFunc: func() {
-				pg.EXPECT().GetDB().Return(nil)
+				pg.EXPECT().GetDB(gomock.Any()).Return(nil)
 			},
 			r: &FarmInfraInfo{
 				ID: 1,
@@ -240,7 +272,7 @@ func TestFarm_Delete(t *testing.T) {
 		{
 			name: "req nil",
 			mockFunc: func() {
-				pg.EXPECT().GetDB().Return(gormDB)
+				pg.EXPECT().GetDB(gomock.Any()).Return(gormDB)
 			},
 
 			wantErr: true,
@@ -250,7 +282,7 @@ func TestFarm_Delete(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockFunc()
 			s := NewFarmStore(pg)
-			if err := s.Delete(tt.r); (err != nil) != tt.wantErr {
+			if err := s.Delete(context.Background(), tt.r); (err != nil) != tt.wantErr {
 				t.Errorf("Farm.Delete() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -285,7 +317,7 @@ func TestFarm_GetFarmByName(t *testing.T) {
 		{
 			name: "success",
 			mockFunc: func() {
-				pg.EXPECT().GetDB().Return(gormDB)
+				pg.EXPECT().GetDB(gomock.Any()).Return(gormDB)
 				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "farms" WHERE "farms"."deleted_at" IS NULL AND ((name = $1 AND Status = $2)) ORDER BY "farms"."id" ASC LIMIT 1`)).WillReturnRows(oneRows)
 			},
 			r: &FarmInfraInfo{
@@ -296,7 +328,7 @@ func TestFarm_GetFarmByName(t *testing.T) {
 		{
 			name: "got error exec",
 			mockFunc: func() {
-				pg.EXPECT().GetDB().Return(gormDB)
+				pg.EXPECT().GetDB(gomock.Any()).Return(gormDB)
 				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "farms" WHERE "farms"."deleted_at" IS NULL AND ((name = $1 AND Status = $2)) ORDER BY "farms"."id" ASC LIMIT 1`)).WillReturnError(fmt.Errorf("some error"))
 			},
 			r: &FarmInfraInfo{
@@ -307,7 +339,7 @@ func TestFarm_GetFarmByName(t *testing.T) {
 		{
 			name: "nil db",
 			mockFunc: func() {
-				pg.EXPECT().GetDB().Return(nil)
+				pg.EXPECT().GetDB(gomock.Any()).Return(nil)
 			},
 			r: &FarmInfraInfo{
 				Name: "Farm 1",
@@ -317,7 +349,7 @@ func TestFarm_GetFarmByName(t *testing.T) {
 		{
 			name: "req nil",
 			mockFunc: func() {
-				pg.EXPECT().GetDB().Return(gormDB)
+				pg.EXPECT().GetDB(gomock.Any()).Return(gormDB)
 			},
 
 			wantErr: true,
@@ -327,7 +359,7 @@ func TestFarm_GetFarmByName(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockFunc()
 			s := NewFarmStore(pg)
-			if err := s.GetFarmByName(tt.r); (err != nil) != tt.wantErr {
+			if err := s.GetFarmByName(context.Background(), tt.r); (err != nil) != tt.wantErr {
 				t.Errorf("Farm.GetFarmByName() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -362,7 +394,7 @@ func TestFarm_GetFarmByID(t *testing.T) {
 		{
 			name: "success",
 			mockFunc: func() {
-				pg.EXPECT().GetDB().Return(gormDB)
+				pg.EXPECT().GetDB(gomock.Any()).Return(gormDB)
 				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "farms" WHERE "farms"."deleted_at" IS NULL AND "farms"."id" = $1 AND ((id = $2 AND Status = $3)) ORDER BY "farms"."id" ASC LIMIT 1`)).WillReturnRows(oneRows)
 			},
 			r: &FarmInfraInfo{
@@ -373,7 +405,7 @@ func TestFarm_GetFarmByID(t *testing.T) {
 		{
 			name: "got error exec",
 			mockFunc: func() {
-				pg.EXPECT().GetDB().Return(gormDB)
+				pg.EXPECT().GetDB(gomock.Any()).Return(gormDB)
 				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "farms" WHERE "farms"."deleted_at" IS NULL AND "farms"."id" = $1 AND ((id = $2 AND Status = $3)) ORDER BY "farms"."id" ASC LIMIT 1`)).WillReturnError(fmt.Errorf("some error"))
 			},
 			r: &FarmInfraInfo{
@@ -384,7 +416,7 @@ func TestFarm_GetFarmByID(t *testing.T) {
 		{
 			name: "nil db",
 			mockFunc: func() {
-				pg.EXPECT().GetDB().Return(nil)
+				pg.EXPECT().GetDB(gomock.Any()).Return(nil)
 			},
 			r: &FarmInfraInfo{
 				ID: 1,
@@ -394,7 +426,7 @@ func TestFarm_GetFarmByID(t *testing.T) {
 		{
 			name: "req nil",
 			mockFunc: func() {
-				pg.EXPECT().GetDB().Return(gormDB)
+				pg.EXPECT().GetDB(gomock.Any()).Return(gormDB)
 			},
 
 			wantErr: true,
@@ -404,7 +436,7 @@ func TestFarm_GetFarmByID(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockFunc()
 			s := NewFarmStore(pg)
-			if err := s.GetFarmByID(tt.r); (err != nil) != tt.wantErr {
+			if err := s.GetFarmByID(context.Background(), tt.r); (err != nil) != tt.wantErr {
 				t.Errorf("Farm.GetFarmByID() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -440,7 +472,7 @@ func TestFarm_Verify(t *testing.T) {
 		{
 			name: "success with id",
 			mockFunc: func() {
-				pg.EXPECT().GetDB().Return(gormDB)
+				pg.EXPECT().GetDB(gomock.Any()).Return(gormDB)
 				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "farms"  WHERE "farms"."deleted_at" IS NULL AND "farms"."id" = $1 AND ((id = $2 AND Status = $3)) ORDER BY "farms"."id" ASC LIMIT 1`)).WillReturnRows(oneRows)
 			},
 			r: &FarmInfraInfo{
@@ -452,7 +484,7 @@ func TestFarm_Verify(t *testing.T) {
 		{
 			name: "nil db",
 			mockFunc: func() {
-				pg.EXPECT().GetDB().Return(nil)
+				pg.EXPECT().GetDB(gomock.Any()).Return(nil)
 			},
 			r: &FarmInfraInfo{
 				ID: 1,
@@ -463,7 +495,7 @@ func TestFarm_Verify(t *testing.T) {
 		{
 			name: "req nil",
 			mockFunc: func() {
-				pg.EXPECT().GetDB().Return(gormDB)
+				pg.EXPECT().GetDB(gomock.Any()).Return(gormDB)
 			},
 			want:    false,
 			wantErr: true,
@@ -471,7 +503,7 @@ func TestFarm_Verify(t *testing.T) {
 		{
 			name: "invalid req",
 			mockFunc: func() {
-				pg.EXPECT().GetDB().Return(gormDB)
+				pg.EXPECT().GetDB(gomock.Any()).Return(gormDB)
 			},
 			r:       &FarmInfraInfo{},
 			want:    false,
@@ -482,7 +514,7 @@ func TestFarm_Verify(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockFunc()
 			s := NewFarmStore(pg)
-			got, err := s.Verify(tt.r)
+			got, err := s.Verify(context.Background(), tt.r)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Farm.Verify() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -537,7 +569,7 @@ func TestFarm_GetFarmWithPaging(t *testing.T) {
 		{
 			name: "success with id",
 			mockFunc: func() {
-				pg.EXPECT().GetDB().Return(gormDB)
+				pg.EXPECT().GetDB(gomock.Any()).Return(gormDB)
 				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "farms" WHERE "farms"."deleted_at" IS NULL AND ((status = $1)) LIMIT 2 OFFSET 0`)).WillReturnRows(expectedRows)
 			},
 			r: GetFarmWithPagingRequest{
@@ -565,7 +597,7 @@ func TestFarm_GetFarmWithPaging(t *testing.T) {
 		{
 			name: "db nil",
 			mockFunc: func() {
-				pg.EXPECT().GetDB().Return(nil)
+				pg.EXPECT().GetDB(gomock.Any()).Return(nil)
 			},
 			r: GetFarmWithPagingRequest{
 				Size:   2,
@@ -578,7 +610,7 @@ func TestFarm_GetFarmWithPaging(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockFunc()
 			s := NewFarmStore(pg)
-			got, err := s.GetFarmWithPaging(tt.r)
+			got, err := s.GetFarmWithPaging(context.Background(), tt.r)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Farm.GetFarmWithPaging() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -607,7 +639,7 @@ func TestFarm_GetActivePondsInFarm(t *testing.T) {
 		{
 			name: "success",
 			mockFunc: func() {
-				pg.EXPECT().GetDB().Return(gormDB)
+				pg.EXPECT().GetDB(gomock.Any()).Return(gormDB)
 				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT "farm_ponds_mappings".* FROM "farm_ponds_mappings" JOIN ponds ON ponds.id = farm_ponds_mappings.ponds_id WHERE "farm_ponds_mappings"."deleted_at" IS NULL AND ((ponds.status = $1 AND farm_ponds_mappings.farm_id = $2))`)).WillReturnRows(sqlmock.NewRows([]string{"ponds_id"}).AddRow(1))
 			},
 			r:       1,
@@ -617,7 +649,7 @@ func TestFarm_GetActivePondsInFarm(t *testing.T) {
 		{
 			name: "db nil",
 			mockFunc: func() {
-				pg.EXPECT().GetDB().Return(nil)
+				pg.EXPECT().GetDB(gomock.Any()).Return(nil)
 			},
 			r:       1,
 			wantErr: true,
@@ -628,9 +660,67 @@ func TestFarm_GetActivePondsInFarm(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockFunc()
 			s := NewFarmStore(pg)
-			if got := s.GetActivePondsInFarm(tt.r); !reflect.DeepEqual(got, tt.want) {
+			if got := s.GetActivePondsInFarm(context.Background(), tt.r); !reflect.DeepEqual(got, tt.want) {
 				t.Errorf("Farm.GetActivePondsInFarm() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
+
+func TestFarm_GetPondCountPerFarm(t *testing.T) {
+	db, mockDB, gormDB := InitDBsMockupStat()
+	defer db.Close()
+	defer gormDB.Close()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	pg := mock_postgres.NewMockPostgresMethod(mockCtrl)
+	query := regexp.QuoteMeta(`SELECT farms.id AS farm_id, COUNT(ponds.id) AS total FROM "farms" LEFT JOIN farm_ponds_mappings ON farm_ponds_mappings.farm_id = farms.id LEFT JOIN ponds ON ponds.id = farm_ponds_mappings.ponds_id AND ponds.status = $1 WHERE (farms.status = $2) GROUP BY farms.id`)
+	tests := []struct {
+		name     string
+		mockFunc func()
+		wantErr  bool
+		want     map[uint]int
+	}{
+		{
+			name: "success",
+			mockFunc: func() {
+				pg.EXPECT().GetDB(gomock.Any()).Return(gormDB)
+				mockDB.ExpectQuery(query).WithArgs(1, 1).WillReturnRows(sqlmock.NewRows([]string{"farm_id", "total"}).AddRow(1, 3).AddRow(2, 0))
+			},
+			wantErr: false,
+			want:    map[uint]int{1: 3, 2: 0},
+		},
+		{
+			name: "error query",
+			mockFunc: func() {
+				pg.EXPECT().GetDB(gomock.Any()).Return(gormDB)
+				mockDB.ExpectQuery(query).WillReturnError(fmt.Errorf("some error"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "db nil",
+			mockFunc: func() {
+				pg.EXPECT().GetDB(gomock.Any()).Return(nil)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			s := NewFarmStore(pg)
+			got, err := s.GetPondCountPerFarm(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Farm.GetPondCountPerFarm() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Farm.GetPondCountPerFarm() = %v, want %v", got, tt.want)
+			}
+			if err := mockDB.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
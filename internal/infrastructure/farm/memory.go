@@ -0,0 +1,388 @@
+package farm
+
+import (
+	"aqua-farm-manager/internal/model"
+	"aqua-farm-manager/pkg/memorydb"
+	"aqua-farm-manager/pkg/postgres"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// MemoryFarm is in memory farm store for local development and test without postgres,
+// the domain event is not stored because there is no outbox in memory
+type MemoryFarm struct {
+	db *memorydb.DB
+}
+
+// NewMemoryFarmStore is func to generate in memory FarmStore interface
+func NewMemoryFarmStore(db *memorydb.DB) FarmStore {
+	return &MemoryFarm{
+		db: db,
+	}
+}
+
+// Create is func to store farm into memory, it return ErrDuplicateName when active farm with the same name exists
+func (f *MemoryFarm) Create(ctx context.Context, r *FarmInfraInfo) error {
+	if r == nil {
+		return errors.New("got nil request")
+	}
+
+	return f.db.Update(func(t *memorydb.Tables) error {
+		duplicate := findFarm(t, func(farm *postgres.Farms) bool {
+			return farm.Name == r.Name
+		})
+		if duplicate != nil {
+			return ErrDuplicateName
+		}
+
+		now := time.Now()
+		farm := &postgres.Farms{
+			Model: gorm.Model{
+				ID:        t.NextID("farms"),
+				CreatedAt: now,
+				UpdatedAt: now,
+			},
+			Name:     r.Name,
+			Location: r.Location,
+			Owner:    r.Owner,
+			Area:     r.Area,
+			Status:   model.Active.Value(),
+		}
+		t.Farms = append(t.Farms, farm)
+
+		r.ID = farm.ID
+		return nil
+	})
+}
+
+// BulkCreate is func to store every farm into memory, nothing is stored when any farm
+// has the same name with active farm or with another farm in the list
+func (f *MemoryFarm) BulkCreate(ctx context.Context, list []*FarmInfraInfo) error {
+	return f.db.Update(func(t *memorydb.Tables) error {
+		names := make(map[string]bool, len(list))
+		for _, r := range list {
+			if r == nil {
+				return errors.New("got nil request")
+			}
+			duplicate := findFarm(t, func(farm *postgres.Farms) bool {
+				return farm.Name == r.Name
+			})
+			if duplicate != nil || names[r.Name] {
+				return ErrDuplicateName
+			}
+			names[r.Name] = true
+		}
+
+		now := time.Now()
+		for _, r := range list {
+			farm := &postgres.Farms{
+				Model: gorm.Model{
+					ID:        t.NextID("farms"),
+					CreatedAt: now,
+					UpdatedAt: now,
+				},
+				Name:     r.Name,
+				Location: r.Location,
+				Owner:    r.Owner,
+				Area:     r.Area,
+				Status:   model.Active.Value(),
+			}
+			t.Farms = append(t.Farms, farm)
+			r.ID = farm.ID
+		}
+		return nil
+	})
+}
+
+// Update is func to update non empty field of active farm with the same id,
+// it return ErrDuplicateName when the farm is renamed into the name of other active farm
+func (f *MemoryFarm) Update(ctx context.Context, r *FarmInfraInfo) error {
+	if r == nil {
+		return errors.New("got nil request")
+	}
+
+	return f.db.Update(func(t *memorydb.Tables) error {
+		farm := findFarm(t, func(farm *postgres.Farms) bool {
+			return farm.ID == r.ID
+		})
+		if farm == nil {
+			return nil
+		}
+
+		duplicate := findFarm(t, func(farm *postgres.Farms) bool {
+			return farm.ID != r.ID && farm.Name == r.Name
+		})
+		if duplicate != nil {
+			return ErrDuplicateName
+		}
+
+		if len(r.Name) > 0 {
+			farm.Name = r.Name
+		}
+		if len(r.Location) > 0 {
+			farm.Location = r.Location
+		}
+		if len(r.Owner) > 0 {
+			farm.Owner = r.Owner
+		}
+		if len(r.Area) > 0 {
+			farm.Area = r.Area
+		}
+		farm.UpdatedAt = time.Now()
+		return nil
+	})
+}
+
+// Delete is func to soft delete active farm with the same id and name
+func (f *MemoryFarm) Delete(ctx context.Context, r *FarmInfraInfo) error {
+	if r == nil {
+		return errors.New("got nil request")
+	}
+
+	return f.db.Update(func(t *memorydb.Tables) error {
+		farm := findFarm(t, func(farm *postgres.Farms) bool {
+			return farm.ID == r.ID && farm.Name == r.Name
+		})
+		if farm == nil {
+			return nil
+		}
+
+		farm.Status = model.Inactive.Value()
+		farm.UpdatedAt = time.Now()
+		return nil
+	})
+}
+
+// GetFarmByName is func get active farm info based on name in memory
+func (f *MemoryFarm) GetFarmByName(ctx context.Context, r *FarmInfraInfo) error {
+	if r == nil || len(r.Name) <= 0 {
+		return errors.New("got nil request")
+	}
+
+	return f.db.View(func(t *memorydb.Tables) error {
+		farm := findFarm(t, func(farm *postgres.Farms) bool {
+			return farm.Name == r.Name
+		})
+		if farm == nil {
+			mapFarmInfo(r, &postgres.Farms{Name: r.Name})
+			return gorm.ErrRecordNotFound
+		}
+
+		mapFarmInfo(r, farm)
+		return nil
+	})
+}
+
+// GetFarmByID is func get active farm info based on id in memory
+func (f *MemoryFarm) GetFarmByID(ctx context.Context, r *FarmInfraInfo) error {
+	if r == nil || r.ID <= 0 {
+		return errors.New("got nil request")
+	}
+
+	return f.db.View(func(t *memorydb.Tables) error {
+		farm := findFarm(t, func(farm *postgres.Farms) bool {
+			return farm.ID == r.ID
+		})
+		if farm == nil {
+			mapFarmInfo(r, &postgres.Farms{Model: gorm.Model{ID: r.ID}})
+			return gorm.ErrRecordNotFound
+		}
+
+		mapFarmInfo(r, farm)
+		return nil
+	})
+}
+
+// GetFarmsByIDs is func to get active farm info ordered by id in memory by list of farm id,
+// farm that does not exist or inactive is not returned
+func (f *MemoryFarm) GetFarmsByIDs(ctx context.Context, ids []uint) ([]FarmInfraInfo, error) {
+	var list []FarmInfraInfo
+	if len(ids) == 0 {
+		return list, nil
+	}
+
+	wanted := make(map[uint]bool, len(ids))
+	for _, id := range ids {
+		wanted[id] = true
+	}
+	err := f.db.View(func(t *memorydb.Tables) error {
+		for _, farm := range t.Farms {
+			if !isActiveFarm(farm) || !wanted[farm.ID] {
+				continue
+			}
+
+			var info FarmInfraInfo
+			mapFarmInfo(&info, farm)
+			list = append(list, info)
+		}
+		return nil
+	})
+	return list, err
+}
+
+// Verify is func to check if active farm already exists based on id and name
+func (f *MemoryFarm) Verify(ctx context.Context, r *FarmInfraInfo) (bool, error) {
+	if r == nil {
+		return false, errors.New("got nil request")
+	}
+
+	var match func(farm *postgres.Farms) bool
+	if r.ID > 0 {
+		match = func(farm *postgres.Farms) bool { return farm.ID == r.ID }
+	} else if len(r.Name) > 0 {
+		match = func(farm *postgres.Farms) bool { return farm.Name == r.Name }
+	} else {
+		return false, errors.New("ID or Name is required")
+	}
+
+	var exists bool
+	err := f.db.View(func(t *memorydb.Tables) error {
+		farm := findFarm(t, match)
+		if farm == nil {
+			return nil
+		}
+
+		exists = true
+		r.Name = farm.Name
+		r.ID = farm.ID
+		return nil
+	})
+	return exists, err
+}
+
+// GetFarmWithPaging is func to get active farm ordered by id with paging
+func (f *MemoryFarm) GetFarmWithPaging(ctx context.Context, r GetFarmWithPagingRequest) ([]FarmInfraInfo, error) {
+	var list []FarmInfraInfo
+	err := f.db.View(func(t *memorydb.Tables) error {
+		var skip int
+		if r.Cursor > 1 {
+			skip = (r.Cursor - 1) * r.Size
+		}
+		for _, farm := range t.Farms {
+			if !isActiveFarm(farm) {
+				continue
+			}
+			if skip > 0 {
+				skip--
+				continue
+			}
+			if len(list) >= r.Size {
+				break
+			}
+
+			list = append(list, FarmInfraInfo{
+				ID:       farm.ID,
+				Name:     farm.Name,
+				Location: farm.Location,
+				Owner:    farm.Owner,
+				Area:     farm.Area,
+			})
+		}
+		return nil
+	})
+	return list, err
+}
+
+// Export is func to stream farm with the status into fn ordered by id, every farm is streamed when the status is unknown,
+// the rows is copied before fn is called so a slow fn does not block the writer
+func (f *MemoryFarm) Export(ctx context.Context, status model.Status, fn func(ExportFarmInfo) error) error {
+	var list []ExportFarmInfo
+	err := f.db.View(func(t *memorydb.Tables) error {
+		for _, farm := range t.Farms {
+			if farm.DeletedAt != nil || (status != model.Unknown && farm.Status != status.Value()) {
+				continue
+			}
+			list = append(list, ExportFarmInfo{
+				ID:        farm.ID,
+				Name:      farm.Name,
+				Location:  farm.Location,
+				Owner:     farm.Owner,
+				Area:      farm.Area,
+				Status:    farm.Status,
+				CreatedAt: farm.CreatedAt,
+				UpdatedAt: farm.UpdatedAt,
+			})
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, info := range list {
+		if err := fn(info); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetActivePondsInFarm is func to get id of active pond in the farm
+func (f *MemoryFarm) GetActivePondsInFarm(ctx context.Context, farmid uint) []uint {
+	var pondsID []uint
+	f.db.View(func(t *memorydb.Tables) error {
+		for _, mapping := range t.FarmPondsMappings {
+			if mapping.FarmID == farmid && mapping.DeletedAt == nil && isActivePondID(t, mapping.PondsID) {
+				pondsID = append(pondsID, mapping.PondsID)
+			}
+		}
+		return nil
+	})
+	return pondsID
+}
+
+// GetPondCountPerFarm is func to count active ponds of every active farm
+func (f *MemoryFarm) GetPondCountPerFarm(ctx context.Context) (map[uint]int, error) {
+	result := make(map[uint]int)
+	err := f.db.View(func(t *memorydb.Tables) error {
+		for _, farm := range t.Farms {
+			if isActiveFarm(farm) {
+				result[farm.ID] = 0
+			}
+		}
+		for _, mapping := range t.FarmPondsMappings {
+			if _, ok := result[mapping.FarmID]; ok && mapping.DeletedAt == nil && isActivePondID(t, mapping.PondsID) {
+				result[mapping.FarmID]++
+			}
+		}
+		return nil
+	})
+	return result, err
+}
+
+// findFarm is func to get the first active farm that match, it return nil when no farm match
+func findFarm(t *memorydb.Tables, match func(farm *postgres.Farms) bool) *postgres.Farms {
+	for _, farm := range t.Farms {
+		if isActiveFarm(farm) && match(farm) {
+			return farm
+		}
+	}
+	return nil
+}
+
+// isActiveFarm is func to check farm is not soft deleted
+func isActiveFarm(farm *postgres.Farms) bool {
+	return farm.Status == model.Active.Value() && farm.DeletedAt == nil
+}
+
+// isActivePondID is func to check pond with the id is not soft deleted
+func isActivePondID(t *memorydb.Tables, id uint) bool {
+	for _, pond := range t.Ponds {
+		if pond.ID == id {
+			return pond.Status == model.Active.Value() && pond.DeletedAt == nil
+		}
+	}
+	return false
+}
+
+// mapFarmInfo is func to copy farm row into FarmInfraInfo
+func mapFarmInfo(r *FarmInfraInfo, farm *postgres.Farms) {
+	r.Name = farm.Name
+	r.Area = farm.Area
+	r.ID = farm.ID
+	r.Location = farm.Location
+	r.Owner = farm.Owner
+}
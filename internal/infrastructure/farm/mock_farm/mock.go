@@ -6,6 +6,8 @@ package mock_farm
 
 import (
 	farm "aqua-farm-manager/internal/infrastructure/farm"
+	model "aqua-farm-manager/internal/model"
+	context "context"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -34,116 +36,174 @@ func (m *MockFarmStore) EXPECT() *MockFarmStoreMockRecorder {
 	return m.recorder
 }
 
+// BulkCreate mocks base method.
+func (m *MockFarmStore) BulkCreate(ctx context.Context, list []*farm.FarmInfraInfo) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkCreate", ctx, list)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BulkCreate indicates an expected call of BulkCreate.
+func (mr *MockFarmStoreMockRecorder) BulkCreate(ctx, list interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkCreate", reflect.TypeOf((*MockFarmStore)(nil).BulkCreate), ctx, list)
+}
+
 // Create mocks base method.
-func (m *MockFarmStore) Create(r *farm.FarmInfraInfo) error {
+func (m *MockFarmStore) Create(ctx context.Context, r *farm.FarmInfraInfo) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", r)
+	ret := m.ctrl.Call(m, "Create", ctx, r)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockFarmStoreMockRecorder) Create(r interface{}) *gomock.Call {
+func (mr *MockFarmStoreMockRecorder) Create(ctx, r interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockFarmStore)(nil).Create), r)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockFarmStore)(nil).Create), ctx, r)
 }
 
 // Delete mocks base method.
-func (m *MockFarmStore) Delete(r *farm.FarmInfraInfo) error {
+func (m *MockFarmStore) Delete(ctx context.Context, r *farm.FarmInfraInfo) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", r)
+	ret := m.ctrl.Call(m, "Delete", ctx, r)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockFarmStoreMockRecorder) Delete(r interface{}) *gomock.Call {
+func (mr *MockFarmStoreMockRecorder) Delete(ctx, r interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockFarmStore)(nil).Delete), r)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockFarmStore)(nil).Delete), ctx, r)
+}
+
+// Export mocks base method.
+func (m *MockFarmStore) Export(ctx context.Context, status model.Status, fn func(farm.ExportFarmInfo) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", ctx, status, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockFarmStoreMockRecorder) Export(ctx, status, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockFarmStore)(nil).Export), ctx, status, fn)
 }
 
 // GetActivePondsInFarm mocks base method.
-func (m *MockFarmStore) GetActivePondsInFarm(farmid uint) []uint {
+func (m *MockFarmStore) GetActivePondsInFarm(ctx context.Context, farmid uint) []uint {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetActivePondsInFarm", farmid)
+	ret := m.ctrl.Call(m, "GetActivePondsInFarm", ctx, farmid)
 	ret0, _ := ret[0].([]uint)
 	return ret0
 }
 
 // GetActivePondsInFarm indicates an expected call of GetActivePondsInFarm.
-func (mr *MockFarmStoreMockRecorder) GetActivePondsInFarm(farmid interface{}) *gomock.Call {
+func (mr *MockFarmStoreMockRecorder) GetActivePondsInFarm(ctx, farmid interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActivePondsInFarm", reflect.TypeOf((*MockFarmStore)(nil).GetActivePondsInFarm), farmid)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActivePondsInFarm", reflect.TypeOf((*MockFarmStore)(nil).GetActivePondsInFarm), ctx, farmid)
 }
 
 // GetFarmByID mocks base method.
-func (m *MockFarmStore) GetFarmByID(r *farm.FarmInfraInfo) error {
+func (m *MockFarmStore) GetFarmByID(ctx context.Context, r *farm.FarmInfraInfo) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetFarmByID", r)
+	ret := m.ctrl.Call(m, "GetFarmByID", ctx, r)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // GetFarmByID indicates an expected call of GetFarmByID.
-func (mr *MockFarmStoreMockRecorder) GetFarmByID(r interface{}) *gomock.Call {
+func (mr *MockFarmStoreMockRecorder) GetFarmByID(ctx, r interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFarmByID", reflect.TypeOf((*MockFarmStore)(nil).GetFarmByID), r)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFarmByID", reflect.TypeOf((*MockFarmStore)(nil).GetFarmByID), ctx, r)
+}
+
+// GetFarmsByIDs mocks base method.
+func (m *MockFarmStore) GetFarmsByIDs(ctx context.Context, ids []uint) ([]farm.FarmInfraInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFarmsByIDs", ctx, ids)
+	ret0, _ := ret[0].([]farm.FarmInfraInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFarmsByIDs indicates an expected call of GetFarmsByIDs.
+func (mr *MockFarmStoreMockRecorder) GetFarmsByIDs(ctx, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFarmsByIDs", reflect.TypeOf((*MockFarmStore)(nil).GetFarmsByIDs), ctx, ids)
 }
 
 // GetFarmByName mocks base method.
-func (m *MockFarmStore) GetFarmByName(r *farm.FarmInfraInfo) error {
+func (m *MockFarmStore) GetFarmByName(ctx context.Context, r *farm.FarmInfraInfo) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetFarmByName", r)
+	ret := m.ctrl.Call(m, "GetFarmByName", ctx, r)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // GetFarmByName indicates an expected call of GetFarmByName.
-func (mr *MockFarmStoreMockRecorder) GetFarmByName(r interface{}) *gomock.Call {
+func (mr *MockFarmStoreMockRecorder) GetFarmByName(ctx, r interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFarmByName", reflect.TypeOf((*MockFarmStore)(nil).GetFarmByName), r)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFarmByName", reflect.TypeOf((*MockFarmStore)(nil).GetFarmByName), ctx, r)
 }
 
 // GetFarmWithPaging mocks base method.
-func (m *MockFarmStore) GetFarmWithPaging(r farm.GetFarmWithPagingRequest) ([]farm.FarmInfraInfo, error) {
+func (m *MockFarmStore) GetFarmWithPaging(ctx context.Context, r farm.GetFarmWithPagingRequest) ([]farm.FarmInfraInfo, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetFarmWithPaging", r)
+	ret := m.ctrl.Call(m, "GetFarmWithPaging", ctx, r)
 	ret0, _ := ret[0].([]farm.FarmInfraInfo)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetFarmWithPaging indicates an expected call of GetFarmWithPaging.
-func (mr *MockFarmStoreMockRecorder) GetFarmWithPaging(r interface{}) *gomock.Call {
+func (mr *MockFarmStoreMockRecorder) GetFarmWithPaging(ctx, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFarmWithPaging", reflect.TypeOf((*MockFarmStore)(nil).GetFarmWithPaging), ctx, r)
+}
+
+// GetPondCountPerFarm mocks base method.
+func (m *MockFarmStore) GetPondCountPerFarm(ctx context.Context) (map[uint]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPondCountPerFarm", ctx)
+	ret0, _ := ret[0].(map[uint]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPondCountPerFarm indicates an expected call of GetPondCountPerFarm.
+func (mr *MockFarmStoreMockRecorder) GetPondCountPerFarm(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFarmWithPaging", reflect.TypeOf((*MockFarmStore)(nil).GetFarmWithPaging), r)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPondCountPerFarm", reflect.TypeOf((*MockFarmStore)(nil).GetPondCountPerFarm), ctx)
 }
 
 // Update mocks base method.
-func (m *MockFarmStore) Update(r *farm.FarmInfraInfo) error {
+func (m *MockFarmStore) Update(ctx context.Context, r *farm.FarmInfraInfo) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", r)
+	ret := m.ctrl.Call(m, "Update", ctx, r)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockFarmStoreMockRecorder) Update(r interface{}) *gomock.Call {
+func (mr *MockFarmStoreMockRecorder) Update(ctx, r interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockFarmStore)(nil).Update), r)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockFarmStore)(nil).Update), ctx, r)
 }
 
 // Verify mocks base method.
-func (m *MockFarmStore) Verify(r *farm.FarmInfraInfo) (bool, error) {
+func (m *MockFarmStore) Verify(ctx context.Context, r *farm.FarmInfraInfo) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Verify", r)
+	ret := m.ctrl.Call(m, "Verify", ctx, r)
 	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Verify indicates an expected call of Verify.
-func (mr *MockFarmStoreMockRecorder) Verify(r interface{}) *gomock.Call {
+func (mr *MockFarmStoreMockRecorder) Verify(ctx, r interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockFarmStore)(nil).Verify), r)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockFarmStore)(nil).Verify), ctx, r)
 }
@@ -1,5 +1,10 @@
 package farm
 
+import (
+	"aqua-farm-manager/internal/infrastructure/outbox"
+	"time"
+)
+
 // FarmInfraInfo struct is list parameter info for farm
 type FarmInfraInfo struct {
 	ID       uint
@@ -7,6 +12,20 @@ type FarmInfraInfo struct {
 	Location string
 	Owner    string
 	Area     string
+	// Events is list domain event stored in the same transaction of Create, Update and Delete
+	Events []outbox.Event
+}
+
+// ExportFarmInfo struct is list parameter of a farm row that is streamed by Export
+type ExportFarmInfo struct {
+	ID        uint
+	Name      string
+	Location  string
+	Owner     string
+	Area      string
+	Status    int
+	CreatedAt time.Time
+	UpdatedAt time.Time
 }
 
 //GetFarmWithPagingRequest struct is list parameter to get farm with page
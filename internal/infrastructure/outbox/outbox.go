@@ -0,0 +1,139 @@
+package outbox
+
+import (
+	"aqua-farm-manager/pkg/postgres"
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/jinzhu/gorm"
+)
+
+// OutboxStore is set of methods for interacting with outbox event storage
+type OutboxStore interface {
+	GetPendingEvents(ctx context.Context, limit int) ([]OutboxInfraInfo, error)
+	MarkPublished(ctx context.Context, ids []uint) error
+	MarkDiscarded(ctx context.Context, id uint) error
+	IncrAttempts(ctx context.Context, id uint) error
+	DeletePublished(ctx context.Context, before time.Time) (int64, error)
+}
+
+// Outbox is list dependencies outbox store
+type Outbox struct {
+	pg postgres.PostgresMethod
+}
+
+// NewOutboxStore is func to generate OutboxStore interface
+func NewOutboxStore(pg postgres.PostgresMethod) OutboxStore {
+	return &Outbox{
+		pg: pg,
+	}
+}
+
+// Insert is func to store domain events of aggregate into outbox,
+// tx must be the transaction of the write so the event is stored only when the write is committed
+func Insert(tx *gorm.DB, aggregateID uint, events []Event) error {
+	for _, e := range events {
+		eventID, err := uuid.GenerateUUID()
+		if err != nil {
+			return err
+		}
+
+		payload, err := json.Marshal(Message{
+			EventID:     eventID,
+			Version:     MessageVersion,
+			Type:        e.Type,
+			AggregateID: aggregateID,
+			OccurredAt:  time.Now().UTC(),
+			Data:        e.Data,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = tx.Create(&postgres.OutboxEvents{
+			EventID: eventID,
+			Topic:   e.Topic,
+			Payload: string(payload),
+			Status:  StatusPending,
+		}).Error
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetPendingEvents is func to get up to limit pending event in insertion order
+func (o *Outbox) GetPendingEvents(ctx context.Context, limit int) ([]OutboxInfraInfo, error) {
+	var list []OutboxInfraInfo
+	db := o.pg.GetDB(ctx)
+	if db == nil {
+		return list, errors.New("Database Client is not init")
+	}
+
+	var events []postgres.OutboxEvents
+	err := db.Where("status = ?", StatusPending).Order("id asc").Limit(limit).Find(&events).Error
+	if err != nil {
+		return list, err
+	}
+
+	for _, e := range events {
+		list = append(list, OutboxInfraInfo{
+			ID:       e.ID,
+			EventID:  e.EventID,
+			Topic:    e.Topic,
+			Payload:  e.Payload,
+			Attempts: e.Attempts,
+		})
+	}
+	return list, nil
+}
+
+// MarkPublished is func to mark list event as published
+func (o *Outbox) MarkPublished(ctx context.Context, ids []uint) error {
+	db := o.pg.GetDB(ctx)
+	if db == nil {
+		return errors.New("Database Client is not init")
+	}
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	return db.Model(&postgres.OutboxEvents{}).Where("id IN (?) AND status = ?", ids, StatusPending).Update("status", StatusPublished).Error
+}
+
+// MarkDiscarded is func to mark event that can never be published as discarded
+func (o *Outbox) MarkDiscarded(ctx context.Context, id uint) error {
+	db := o.pg.GetDB(ctx)
+	if db == nil {
+		return errors.New("Database Client is not init")
+	}
+
+	return db.Model(&postgres.OutboxEvents{}).Where("id = ? AND status = ?", id, StatusPending).Update("status", StatusDiscarded).Error
+}
+
+// IncrAttempts is func to increase number of failed publish attempt of event
+func (o *Outbox) IncrAttempts(ctx context.Context, id uint) error {
+	db := o.pg.GetDB(ctx)
+	if db == nil {
+		return errors.New("Database Client is not init")
+	}
+
+	return db.Model(&postgres.OutboxEvents{}).Where("id = ?", id).UpdateColumn("attempts", gorm.Expr("attempts + ?", 1)).Error
+}
+
+// DeletePublished is func to delete published event that is marked before the given time,
+// discarded event is kept for investigation
+func (o *Outbox) DeletePublished(ctx context.Context, before time.Time) (int64, error) {
+	db := o.pg.GetDB(ctx)
+	if db == nil {
+		return 0, errors.New("Database Client is not init")
+	}
+
+	res := db.Unscoped().Where("status = ? AND updated_at < ?", StatusPublished, before).Delete(&postgres.OutboxEvents{})
+	return res.RowsAffected, res.Error
+}
@@ -0,0 +1,411 @@
+package outbox
+
+import (
+	"aqua-farm-manager/pkg/postgres"
+	mock_postgres "aqua-farm-manager/pkg/postgres/mock"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"reflect"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jinzhu/gorm"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestNewOutboxStore(t *testing.T) {
+	type args struct {
+		pg postgres.PostgresMethod
+	}
+	tests := []struct {
+		name string
+		args args
+		want OutboxStore
+	}{
+		{
+			name: "success",
+			args: args{
+				pg: &postgres.Client{},
+			},
+			want: &Outbox{
+				pg: &postgres.Client{},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewOutboxStore(tt.args.pg); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewOutboxStore() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func InitDBsMockupOutbox() (*sql.DB, sqlmock.Sqlmock, *gorm.DB) {
+	db, mock, _ := sqlmock.New()
+	gormDB, _ := gorm.Open("postgres", db)
+	gormDB.LogMode(true)
+	gormDB.SetLogger(log.New(os.Stdout, "\n", 0))
+	gormDB.Debug()
+	return db, mock, gormDB
+}
+
+// payloadMatcher match json payload of outbox event ignoring generated event id and time
+type payloadMatcher struct {
+	eventType   string
+	aggregateID uint
+	data        string
+}
+
+func (m payloadMatcher) Match(v driver.Value) bool {
+	s, ok := v.(string)
+	if !ok {
+		return false
+	}
+	var msg struct {
+		EventID     string          `json:"event_id"`
+		Version     int             `json:"version"`
+		Type        string          `json:"type"`
+		AggregateID uint            `json:"aggregate_id"`
+		OccurredAt  time.Time       `json:"occurred_at"`
+		Data        json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal([]byte(s), &msg); err != nil {
+		return false
+	}
+	return msg.EventID != "" && msg.Version == MessageVersion && msg.Type == m.eventType &&
+		msg.AggregateID == m.aggregateID && !msg.OccurredAt.IsZero() && string(msg.Data) == m.data
+}
+
+func TestInsert(t *testing.T) {
+	db, mockDB, gormDB := InitDBsMockupOutbox()
+	defer db.Close()
+	defer gormDB.Close()
+	query := regexp.QuoteMeta(`INSERT INTO "outbox_events" ("created_at","updated_at","deleted_at","event_id","topic","payload","status","attempts") VALUES ($1,$2,$3,$4,$5,$6,$7,$8)`)
+	tests := []struct {
+		name     string
+		mockFunc func()
+		events   []Event
+		wantErr  bool
+	}{
+		{
+			name: "success",
+			mockFunc: func() {
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery(query).
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), nil, sqlmock.AnyArg(), "topic", payloadMatcher{eventType: "farm.created", aggregateID: 1, data: `{"name":"a"}`}, StatusPending, 0).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mockDB.ExpectCommit()
+			},
+			events: []Event{
+				{Topic: "topic", Type: "farm.created", Data: map[string]string{"name": "a"}},
+			},
+			wantErr: false,
+		},
+		{
+			name:     "success without event",
+			mockFunc: func() {},
+			wantErr:  false,
+		},
+		{
+			name: "got error exec",
+			mockFunc: func() {
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery(query).WillReturnError(fmt.Errorf("some error"))
+			},
+			events: []Event{
+				{Topic: "topic", Type: "farm.created"},
+			},
+			wantErr: true,
+		},
+		{
+			name:     "got error marshal data",
+			mockFunc: func() {},
+			events: []Event{
+				{Topic: "topic", Type: "farm.created", Data: make(chan int)},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			if err := Insert(gormDB, 1, tt.events); (err != nil) != tt.wantErr {
+				t.Errorf("Insert() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOutbox_GetPendingEvents(t *testing.T) {
+	db, mockDB, gormDB := InitDBsMockupOutbox()
+	defer db.Close()
+	defer gormDB.Close()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	pg := mock_postgres.NewMockPostgresMethod(mockCtrl)
+	query := regexp.QuoteMeta(`SELECT * FROM "outbox_events" WHERE "outbox_events"."deleted_at" IS NULL AND ((status = $1)) ORDER BY id asc LIMIT 2`)
+	tests := []struct {
+		name     string
+		mockFunc func()
+		want     []OutboxInfraInfo
+		wantErr  bool
+	}{
+		{
+			name: "success",
+			mockFunc: func() {
+				pg.EXPECT().GetDB(gomock.Any()).Return(gormDB)
+				mockDB.ExpectQuery(query).WithArgs(StatusPending).WillReturnRows(
+					sqlmock.NewRows([]string{"id", "event_id", "topic", "payload", "attempts"}).
+						AddRow(1, "a", "topic", "{}", 0).
+						AddRow(2, "b", "topic", "{}", 3))
+			},
+			want: []OutboxInfraInfo{
+				{ID: 1, EventID: "a", Topic: "topic", Payload: "{}"},
+				{ID: 2, EventID: "b", Topic: "topic", Payload: "{}", Attempts: 3},
+			},
+			wantErr: false,
+		},
+		{
+			name: "got error exec",
+			mockFunc: func() {
+				pg.EXPECT().GetDB(gomock.Any()).Return(gormDB)
+				mockDB.ExpectQuery(query).WillReturnError(fmt.Errorf("some error"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "nil db",
+			mockFunc: func() {
+				pg.EXPECT().GetDB(gomock.Any()).Return(nil)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			s := NewOutboxStore(pg)
+			got, err := s.GetPendingEvents(context.Background(), 2)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Outbox.GetPendingEvents() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Outbox.GetPendingEvents() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOutbox_MarkPublished(t *testing.T) {
+	db, mockDB, gormDB := InitDBsMockupOutbox()
+	defer db.Close()
+	defer gormDB.Close()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	pg := mock_postgres.NewMockPostgresMethod(mockCtrl)
+	query := regexp.QuoteMeta(`UPDATE "outbox_events" SET "status" = $1, "updated_at" = $2 WHERE "outbox_events"."deleted_at" IS NULL AND ((id IN ($3,$4) AND status = $5))`)
+	tests := []struct {
+		name     string
+		mockFunc func()
+		ids      []uint
+		wantErr  bool
+	}{
+		{
+			name: "success",
+			mockFunc: func() {
+				pg.EXPECT().GetDB(gomock.Any()).Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectExec(query).WithArgs(StatusPublished, sqlmock.AnyArg(), 1, 2, StatusPending).WillReturnResult(sqlmock.NewResult(1, 2))
+				mockDB.ExpectCommit()
+			},
+			ids:     []uint{1, 2},
+			wantErr: false,
+		},
+		{
+			name: "success without id",
+			mockFunc: func() {
+				pg.EXPECT().GetDB(gomock.Any()).Return(gormDB)
+			},
+			wantErr: false,
+		},
+		{
+			name: "got error exec",
+			mockFunc: func() {
+				pg.EXPECT().GetDB(gomock.Any()).Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectExec(query).WillReturnError(fmt.Errorf("some error"))
+			},
+			ids:     []uint{1, 2},
+			wantErr: true,
+		},
+		{
+			name: "nil db",
+			mockFunc: func() {
+				pg.EXPECT().GetDB(gomock.Any()).Return(nil)
+			},
+			ids:     []uint{1},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			s := NewOutboxStore(pg)
+			if err := s.MarkPublished(context.Background(), tt.ids); (err != nil) != tt.wantErr {
+				t.Errorf("Outbox.MarkPublished() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOutbox_MarkDiscarded(t *testing.T) {
+	db, mockDB, gormDB := InitDBsMockupOutbox()
+	defer db.Close()
+	defer gormDB.Close()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	pg := mock_postgres.NewMockPostgresMethod(mockCtrl)
+	query := regexp.QuoteMeta(`UPDATE "outbox_events" SET "status" = $1, "updated_at" = $2 WHERE "outbox_events"."deleted_at" IS NULL AND ((id = $3 AND status = $4))`)
+	tests := []struct {
+		name     string
+		mockFunc func()
+		wantErr  bool
+	}{
+		{
+			name: "success",
+			mockFunc: func() {
+				pg.EXPECT().GetDB(gomock.Any()).Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectExec(query).WithArgs(StatusDiscarded, sqlmock.AnyArg(), 1, StatusPending).WillReturnResult(sqlmock.NewResult(1, 1))
+				mockDB.ExpectCommit()
+			},
+			wantErr: false,
+		},
+		{
+			name: "nil db",
+			mockFunc: func() {
+				pg.EXPECT().GetDB(gomock.Any()).Return(nil)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			s := NewOutboxStore(pg)
+			if err := s.MarkDiscarded(context.Background(), 1); (err != nil) != tt.wantErr {
+				t.Errorf("Outbox.MarkDiscarded() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOutbox_IncrAttempts(t *testing.T) {
+	db, mockDB, gormDB := InitDBsMockupOutbox()
+	defer db.Close()
+	defer gormDB.Close()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	pg := mock_postgres.NewMockPostgresMethod(mockCtrl)
+	query := regexp.QuoteMeta(`UPDATE "outbox_events" SET "attempts" = attempts + $1 WHERE "outbox_events"."deleted_at" IS NULL AND ((id = $2))`)
+	tests := []struct {
+		name     string
+		mockFunc func()
+		wantErr  bool
+	}{
+		{
+			name: "success",
+			mockFunc: func() {
+				pg.EXPECT().GetDB(gomock.Any()).Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectExec(query).WithArgs(1, 1).WillReturnResult(sqlmock.NewResult(1, 1))
+				mockDB.ExpectCommit()
+			},
+			wantErr: false,
+		},
+		{
+			name: "nil db",
+			mockFunc: func() {
+				pg.EXPECT().GetDB(gomock.Any()).Return(nil)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			s := NewOutboxStore(pg)
+			if err := s.IncrAttempts(context.Background(), 1); (err != nil) != tt.wantErr {
+				t.Errorf("Outbox.IncrAttempts() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOutbox_DeletePublished(t *testing.T) {
+	db, mockDB, gormDB := InitDBsMockupOutbox()
+	defer db.Close()
+	defer gormDB.Close()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	pg := mock_postgres.NewMockPostgresMethod(mockCtrl)
+	query := regexp.QuoteMeta(`DELETE FROM "outbox_events" WHERE (status = $1 AND updated_at < $2)`)
+	before := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name     string
+		mockFunc func()
+		want     int64
+		wantErr  bool
+	}{
+		{
+			name: "success",
+			mockFunc: func() {
+				pg.EXPECT().GetDB(gomock.Any()).Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectExec(query).WithArgs(StatusPublished, before).WillReturnResult(sqlmock.NewResult(0, 3))
+				mockDB.ExpectCommit()
+			},
+			want:    3,
+			wantErr: false,
+		},
+		{
+			name: "got error",
+			mockFunc: func() {
+				pg.EXPECT().GetDB(gomock.Any()).Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectExec(query).WillReturnError(fmt.Errorf("some error"))
+				mockDB.ExpectRollback()
+			},
+			wantErr: true,
+		},
+		{
+			name: "nil db",
+			mockFunc: func() {
+				pg.EXPECT().GetDB(gomock.Any()).Return(nil)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			s := NewOutboxStore(pg)
+			got, err := s.DeletePublished(context.Background(), before)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Outbox.DeletePublished() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("Outbox.DeletePublished() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package outbox
+
+import "time"
+
+// list status of outbox event
+const (
+	StatusPending   = 1
+	StatusPublished = 2
+	StatusDiscarded = 3
+)
+
+// MessageVersion is version of domain event envelope published to nsq
+const MessageVersion = 1
+
+// Event struct is list parameter of domain event stored in the same transaction of the write,
+// Data is marshalled as json when the event is stored
+type Event struct {
+	Topic string
+	Type  string
+	Data  interface{}
+}
+
+// Message struct is envelope of domain event published to nsq
+type Message struct {
+	EventID     string      `json:"event_id"`
+	Version     int         `json:"version"`
+	Type        string      `json:"type"`
+	AggregateID uint        `json:"aggregate_id"`
+	OccurredAt  time.Time   `json:"occurred_at"`
+	Data        interface{} `json:"data"`
+}
+
+// OutboxInfraInfo struct is list parameter of stored outbox event
+type OutboxInfraInfo struct {
+	ID       uint
+	EventID  string
+	Topic    string
+	Payload  string
+	Attempts int
+}
@@ -0,0 +1,221 @@
+package pond
+
+import (
+	"aqua-farm-manager/internal/infrastructure/cache"
+	"aqua-farm-manager/pkg/redis"
+	"context"
+	"sort"
+	"strconv"
+)
+
+// CachedPond is PondStore decorator that cache pond lookup by id and pond ids of farm in redis,
+// the batch lookup is cached per pond and per farm so a page only load the missed ids from the store,
+// the cached value is invalidated after Create, Update and Delete with background context
+// because the write is already stored when the request is cancelled
+type CachedPond struct {
+	PondStore
+	cache *cache.Cache
+}
+
+// NewCachedPondStore is func to wrap PondStore with read-through redis cache
+func NewCachedPondStore(store PondStore, redis redis.RedisMethod, ttlInSec int) PondStore {
+	return &CachedPond{
+		PondStore: store,
+		cache:     cache.NewCache(redis, "pond", ttlInSec),
+	}
+}
+
+// GetPondByID is func to get pond info from cache or from the store when it is not cached
+func (c *CachedPond) GetPondByID(ctx context.Context, r *PondInfraInfo) error {
+	if r == nil || r.ID <= 0 {
+		return c.PondStore.GetPondByID(ctx, r)
+	}
+
+	return c.cache.Fetch(ctx, c.idKey(r.ID), r, func(ctx context.Context) (interface{}, error) {
+		info := PondInfraInfo{ID: r.ID}
+		err := c.PondStore.GetPondByID(ctx, &info)
+		return info, err
+	})
+}
+
+// GetPondIDbyFarmID is func to get pond ids of farm from cache or from the store when it is not cached
+func (c *CachedPond) GetPondIDbyFarmID(ctx context.Context, id uint) ([]uint, error) {
+	var list []uint
+	err := c.cache.Fetch(ctx, c.farmKey(id), &list, func(ctx context.Context) (interface{}, error) {
+		return c.PondStore.GetPondIDbyFarmID(ctx, id)
+	})
+	return list, err
+}
+
+// GetPondsByIDs is func to get active pond info from cache and load every missed pond from the store in a single query,
+// the pond is cached under its own key because pond without farm is returned here and is not found by GetPondByID
+func (c *CachedPond) GetPondsByIDs(ctx context.Context, ids []uint) ([]PondInfraInfo, error) {
+	if len(ids) == 0 {
+		return c.PondStore.GetPondsByIDs(ctx, ids)
+	}
+
+	keys := make([]string, len(ids))
+	cached := make([]*PondInfraInfo, len(ids))
+	for i, id := range ids {
+		keys[i] = c.batchKey(id)
+	}
+	missed := c.cache.FetchMany(ctx, keys, func(i int) interface{} {
+		return &cached[i]
+	})
+
+	var list []PondInfraInfo
+	for i := range ids {
+		// inactive pond is cached as null so it is not loaded again
+		if cached[i] != nil {
+			list = append(list, *cached[i])
+		}
+	}
+	if len(missed) == 0 {
+		return list, nil
+	}
+
+	missedIDs := make([]uint, len(missed))
+	for i, index := range missed {
+		missedIDs[i] = ids[index]
+	}
+	loaded, err := c.PondStore.GetPondsByIDs(ctx, missedIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	found := make(map[uint]PondInfraInfo, len(loaded))
+	for _, pond := range loaded {
+		found[pond.ID] = pond
+		list = append(list, pond)
+	}
+	for _, id := range missedIDs {
+		if pond, ok := found[id]; ok {
+			c.cache.Store(ctx, c.batchKey(id), pond)
+			continue
+		}
+		c.cache.Store(ctx, c.batchKey(id), nil)
+	}
+
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	return list, nil
+}
+
+// GetPondsByFarmIDs is func to get pond ids of every farm from cache and load every missed farm from the store in a single query,
+// it share the cached pond ids of GetPondIDbyFarmID
+func (c *CachedPond) GetPondsByFarmIDs(ctx context.Context, farmIDs []uint) (map[uint][]uint, error) {
+	if len(farmIDs) == 0 {
+		return c.PondStore.GetPondsByFarmIDs(ctx, farmIDs)
+	}
+
+	keys := make([]string, len(farmIDs))
+	cached := make([][]uint, len(farmIDs))
+	for i, id := range farmIDs {
+		keys[i] = c.farmKey(id)
+	}
+	missed := c.cache.FetchMany(ctx, keys, func(i int) interface{} {
+		return &cached[i]
+	})
+
+	list := make(map[uint][]uint)
+	for i, id := range farmIDs {
+		if len(cached[i]) > 0 {
+			list[id] = cached[i]
+		}
+	}
+	if len(missed) == 0 {
+		return list, nil
+	}
+
+	missedIDs := make([]uint, len(missed))
+	for i, index := range missed {
+		missedIDs[i] = farmIDs[index]
+	}
+	loaded, err := c.PondStore.GetPondsByFarmIDs(ctx, missedIDs)
+	if err != nil {
+		return list, err
+	}
+
+	for _, id := range missedIDs {
+		ponds := loaded[id]
+		c.cache.Store(ctx, c.farmKey(id), ponds)
+		if len(ponds) > 0 {
+			list[id] = ponds
+		}
+	}
+	return list, nil
+}
+
+// Create is func to store pond and invalidate the cached pond and pond ids of its farm
+func (c *CachedPond) Create(ctx context.Context, r *PondInfraInfo) error {
+	err := c.PondStore.Create(ctx, r)
+	if err != nil {
+		return err
+	}
+	c.cache.Invalidate(context.Background(), c.idKey(r.ID), c.batchKey(r.ID), c.farmKey(r.FarmID))
+	return nil
+}
+
+// BulkCreate is func to store every pond and invalidate the cached pond and pond ids of their farm
+func (c *CachedPond) BulkCreate(ctx context.Context, list []*PondInfraInfo) error {
+	err := c.PondStore.BulkCreate(ctx, list)
+	if err != nil {
+		return err
+	}
+	keys := make([]string, 0, 3*len(list))
+	for _, r := range list {
+		keys = append(keys, c.idKey(r.ID), c.batchKey(r.ID), c.farmKey(r.FarmID))
+	}
+	c.cache.Invalidate(context.Background(), keys...)
+	return nil
+}
+
+// Update is func to update pond and invalidate the cached pond and pond ids of its previous and new farm
+func (c *CachedPond) Update(ctx context.Context, r *PondInfraInfo) error {
+	previous := PondInfraInfo{ID: r.ID}
+	if r.FarmID != 0 {
+		// the previous farm is only known before the pond is moved, it is read through the cache
+		// because the cached pond is invalidated after every write
+		c.GetPondByID(ctx, &previous)
+	}
+
+	err := c.PondStore.Update(ctx, r)
+	if err != nil {
+		return err
+	}
+
+	keys := []string{c.idKey(r.ID), c.batchKey(r.ID)}
+	if r.FarmID != 0 {
+		keys = append(keys, c.farmKey(r.FarmID))
+	}
+	if previous.FarmID != 0 && previous.FarmID != r.FarmID {
+		keys = append(keys, c.farmKey(previous.FarmID))
+	}
+	c.cache.Invalidate(context.Background(), keys...)
+	return nil
+}
+
+// Delete is func to soft delete pond and invalidate its cached value,
+// the pond ids of farm is kept because the mapping of deleted pond is kept
+func (c *CachedPond) Delete(ctx context.Context, r *PondInfraInfo) error {
+	err := c.PondStore.Delete(ctx, r)
+	if err != nil {
+		return err
+	}
+	c.cache.Invalidate(context.Background(), c.idKey(r.ID), c.batchKey(r.ID))
+	return nil
+}
+
+// idKey is func to generate cache key of pond by id
+func (c *CachedPond) idKey(id uint) string {
+	return c.cache.Key("id:" + strconv.FormatUint(uint64(id), 10))
+}
+
+// batchKey is func to generate cache key of pond by id that is returned by GetPondsByIDs
+func (c *CachedPond) batchKey(id uint) string {
+	return c.cache.Key("info:" + strconv.FormatUint(uint64(id), 10))
+}
+
+// farmKey is func to generate cache key of pond ids by farm id
+func (c *CachedPond) farmKey(farmID uint) string {
+	return c.cache.Key("farm:" + strconv.FormatUint(uint64(farmID), 10))
+}
@@ -0,0 +1,109 @@
+package pond
+
+import (
+	"aqua-farm-manager/pkg/memorydb"
+	"aqua-farm-manager/pkg/postgres"
+	"aqua-farm-manager/pkg/redis"
+	mock_redis "aqua-farm-manager/pkg/redis/mock"
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jinzhu/gorm"
+)
+
+func TestCachedPond(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	db := memorydb.NewDB()
+	db.Update(func(t *memorydb.Tables) error {
+		t.Farms = append(t.Farms,
+			&postgres.Farms{Model: gorm.Model{ID: t.NextID("farms")}, Name: "farm a", Status: 1},
+			&postgres.Farms{Model: gorm.Model{ID: t.NextID("farms")}, Name: "farm b", Status: 1},
+		)
+		return nil
+	})
+	r := mock_redis.NewMockRedisMethod(mockCtrl)
+	cached := NewCachedPondStore(NewMemoryPondStore(db), r, 30)
+	idKey, batchKey, farmAKey, farmBKey := "C:pond:id:1", "C:pond:info:1", "C:pond:farm:1", "C:pond:farm:2"
+
+	// create invalidate the pond id and pond ids of its farm
+	r.EXPECT().Delete(gomock.Any(), idKey).Return(nil)
+	r.EXPECT().Delete(gomock.Any(), batchKey).Return(nil)
+	r.EXPECT().Delete(gomock.Any(), farmAKey).Return(nil)
+	pond := &PondInfraInfo{Name: "pond a", FarmID: 1}
+	if err := cached.Create(context.Background(), pond); err != nil {
+		t.Fatalf("CachedPond.Create() error = %v", err)
+	}
+
+	// miss load from the store and fill the cache
+	r.EXPECT().Get(gomock.Any(), idKey).Return("", redis.ErrNil)
+	r.EXPECT().SetEX(gomock.Any(), idKey, gomock.Any(), 30).Return(nil)
+	got := &PondInfraInfo{ID: pond.ID}
+	if err := cached.GetPondByID(context.Background(), got); err != nil || got.Name != "pond a" || got.FarmID != 1 {
+		t.Errorf("CachedPond.GetPondByID() miss got = %v %v, want pond a of farm 1", got, err)
+	}
+	r.EXPECT().Get(gomock.Any(), farmAKey).Return("", redis.ErrNil)
+	r.EXPECT().SetEX(gomock.Any(), farmAKey, "[1]", 30).Return(nil)
+	if ids, err := cached.GetPondIDbyFarmID(context.Background(), 1); err != nil || len(ids) != 1 || ids[0] != pond.ID {
+		t.Errorf("CachedPond.GetPondIDbyFarmID() miss got = %v %v, want [%d]", ids, err, pond.ID)
+	}
+
+	// hit does not read the store
+	r.EXPECT().Get(gomock.Any(), farmAKey).Return("[1,7]", nil)
+	if ids, err := cached.GetPondIDbyFarmID(context.Background(), 1); err != nil || len(ids) != 2 {
+		t.Errorf("CachedPond.GetPondIDbyFarmID() hit got = %v %v, want [1 7]", ids, err)
+	}
+
+	// batch lookup only load the missed pond and farm from the store, inactive id is cached as null
+	r.EXPECT().MGet(gomock.Any(), batchKey, "C:pond:info:7", "C:pond:info:8").
+		Return([]string{"", `{"ID":7,"Name":"cached pond","FarmID":2}`, ""}, nil)
+	r.EXPECT().SetEX(gomock.Any(), batchKey, gomock.Any(), 30).Return(nil)
+	r.EXPECT().SetEX(gomock.Any(), "C:pond:info:8", "null", 30).Return(nil)
+	if list, err := cached.GetPondsByIDs(context.Background(), []uint{1, 7, 8}); err != nil || len(list) != 2 || list[0].Name != "pond a" || list[1].Name != "cached pond" {
+		t.Errorf("CachedPond.GetPondsByIDs() got = %v %v, want pond a and cached pond", list, err)
+	}
+	r.EXPECT().MGet(gomock.Any(), batchKey, "C:pond:info:8").Return([]string{`{"ID":1,"Name":"pond a","FarmID":1}`, "null"}, nil)
+	if list, err := cached.GetPondsByIDs(context.Background(), []uint{1, 8}); err != nil || len(list) != 1 || list[0].ID != 1 {
+		t.Errorf("CachedPond.GetPondsByIDs() hit got = %v %v, want pond a", list, err)
+	}
+	r.EXPECT().MGet(gomock.Any(), farmAKey, farmBKey).Return([]string{"[1,7]", ""}, nil)
+	r.EXPECT().SetEX(gomock.Any(), farmBKey, "null", 30).Return(nil)
+	if list, err := cached.GetPondsByFarmIDs(context.Background(), []uint{1, 2}); err != nil || len(list) != 1 || len(list[1]) != 2 {
+		t.Errorf("CachedPond.GetPondsByFarmIDs() got = %v %v, want cached pond ids of farm 1", list, err)
+	}
+
+	// moving pond read the previous farm through the cache and invalidate pond ids of the previous and the new farm
+	r.EXPECT().Get(gomock.Any(), idKey).Return(`{"ID":1,"Name":"pond a","FarmID":1}`, nil)
+	r.EXPECT().Delete(gomock.Any(), idKey).Return(nil)
+	r.EXPECT().Delete(gomock.Any(), batchKey).Return(nil)
+	r.EXPECT().Delete(gomock.Any(), farmBKey).Return(nil)
+	r.EXPECT().Delete(gomock.Any(), farmAKey).Return(nil)
+	if err := cached.Update(context.Background(), &PondInfraInfo{ID: pond.ID, Name: "pond a", FarmID: 2}); err != nil {
+		t.Errorf("CachedPond.Update() error = %v", err)
+	}
+
+	// delete invalidate the pond id
+	r.EXPECT().Delete(gomock.Any(), idKey).Return(nil)
+	r.EXPECT().Delete(gomock.Any(), batchKey).Return(nil)
+	if err := cached.Delete(context.Background(), &PondInfraInfo{ID: pond.ID}); err != nil {
+		t.Errorf("CachedPond.Delete() error = %v", err)
+	}
+
+	// bulk create invalidate every created pond id and pond ids of its farm
+	r.EXPECT().Delete(gomock.Any(), "C:pond:id:2").Return(nil)
+	r.EXPECT().Delete(gomock.Any(), "C:pond:info:2").Return(nil)
+	r.EXPECT().Delete(gomock.Any(), farmAKey).Return(nil)
+	r.EXPECT().Delete(gomock.Any(), "C:pond:id:3").Return(nil)
+	r.EXPECT().Delete(gomock.Any(), "C:pond:info:3").Return(nil)
+	r.EXPECT().Delete(gomock.Any(), farmBKey).Return(nil)
+	if err := cached.BulkCreate(context.Background(), []*PondInfraInfo{{Name: "pond b", FarmID: 1}, {Name: "pond c", FarmID: 2}}); err != nil {
+		t.Errorf("CachedPond.BulkCreate() error = %v", err)
+	}
+
+	// failed bulk create keep the cache
+	if err := cached.BulkCreate(context.Background(), []*PondInfraInfo{{Name: "pond d", FarmID: 99}}); err == nil {
+		t.Errorf("CachedPond.BulkCreate() of unknown farm error = nil")
+	}
+}
@@ -0,0 +1,469 @@
+package pond
+
+import (
+	"aqua-farm-manager/internal/model"
+	"aqua-farm-manager/pkg/memorydb"
+	"aqua-farm-manager/pkg/postgres"
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// MemoryPond is in memory pond store for local development and test without postgres,
+// the domain event is not stored because there is no outbox in memory
+type MemoryPond struct {
+	db *memorydb.DB
+}
+
+// NewMemoryPondStore is func to generate in memory PondStore interface
+func NewMemoryPondStore(db *memorydb.DB) PondStore {
+	return &MemoryPond{
+		db: db,
+	}
+}
+
+// Create is func to store ponds and mapping into memory, it return ErrDuplicateName when active pond
+// with the same name exists and ErrInvalidFarm when the farm does not exist
+func (p *MemoryPond) Create(ctx context.Context, r *PondInfraInfo) error {
+	if r == nil {
+		return errors.New("got nil request")
+	}
+
+	return p.db.Update(func(t *memorydb.Tables) error {
+		duplicate := findPond(t, func(pond *postgres.Ponds) bool {
+			return pond.Name == r.Name
+		})
+		if duplicate != nil {
+			return ErrDuplicateName
+		}
+		if !farmExists(t, r.FarmID) {
+			return ErrInvalidFarm
+		}
+
+		now := time.Now()
+		pond := &postgres.Ponds{
+			Model: gorm.Model{
+				ID:        t.NextID("ponds"),
+				CreatedAt: now,
+				UpdatedAt: now,
+			},
+			Name:         r.Name,
+			Capacity:     r.Capacity,
+			Depth:        r.Depth,
+			WaterQuality: r.WaterQuality,
+			Species:      r.Species,
+			Status:       model.Active.Value(),
+		}
+		mapping := &postgres.FarmPondsMapping{
+			Model: gorm.Model{
+				ID:        t.NextID("farm_ponds_mappings"),
+				CreatedAt: now,
+				UpdatedAt: now,
+			},
+			FarmID:  r.FarmID,
+			PondsID: pond.ID,
+		}
+		t.Ponds = append(t.Ponds, pond)
+		t.FarmPondsMappings = append(t.FarmPondsMappings, mapping)
+
+		r.ID = pond.ID
+		return nil
+	})
+}
+
+// BulkCreate is func to store every pond and mapping into memory, nothing is stored when any pond has the same name
+// with active pond or with another pond in the list, or when its farm does not exist
+func (p *MemoryPond) BulkCreate(ctx context.Context, list []*PondInfraInfo) error {
+	return p.db.Update(func(t *memorydb.Tables) error {
+		names := make(map[string]bool, len(list))
+		for _, r := range list {
+			if r == nil {
+				return errors.New("got nil request")
+			}
+			duplicate := findPond(t, func(pond *postgres.Ponds) bool {
+				return pond.Name == r.Name
+			})
+			if duplicate != nil || names[r.Name] {
+				return ErrDuplicateName
+			}
+			if !farmExists(t, r.FarmID) {
+				return ErrInvalidFarm
+			}
+			names[r.Name] = true
+		}
+
+		now := time.Now()
+		for _, r := range list {
+			pond := &postgres.Ponds{
+				Model: gorm.Model{
+					ID:        t.NextID("ponds"),
+					CreatedAt: now,
+					UpdatedAt: now,
+				},
+				Name:         r.Name,
+				Capacity:     r.Capacity,
+				Depth:        r.Depth,
+				WaterQuality: r.WaterQuality,
+				Species:      r.Species,
+				Status:       model.Active.Value(),
+			}
+			mapping := &postgres.FarmPondsMapping{
+				Model: gorm.Model{
+					ID:        t.NextID("farm_ponds_mappings"),
+					CreatedAt: now,
+					UpdatedAt: now,
+				},
+				FarmID:  r.FarmID,
+				PondsID: pond.ID,
+			}
+			t.Ponds = append(t.Ponds, pond)
+			t.FarmPondsMappings = append(t.FarmPondsMappings, mapping)
+			r.ID = pond.ID
+		}
+		return nil
+	})
+}
+
+// GetPondIDbyFarmID is func to get id of every pond mapped into the farm
+func (p *MemoryPond) GetPondIDbyFarmID(ctx context.Context, id uint) ([]uint, error) {
+	var list []uint
+	err := p.db.View(func(t *memorydb.Tables) error {
+		for _, mapping := range t.FarmPondsMappings {
+			if mapping.FarmID == id && mapping.DeletedAt == nil {
+				list = append(list, mapping.PondsID)
+			}
+		}
+		return nil
+	})
+	return list, err
+}
+
+// GetPondsByFarmIDs is func to get id of every pond mapped into each farm, the result is keyed by farm id
+func (p *MemoryPond) GetPondsByFarmIDs(ctx context.Context, farmIDs []uint) (map[uint][]uint, error) {
+	list := make(map[uint][]uint)
+	err := p.db.View(func(t *memorydb.Tables) error {
+		for _, mapping := range t.FarmPondsMappings {
+			if mapping.DeletedAt == nil && containsID(farmIDs, mapping.FarmID) {
+				list[mapping.FarmID] = append(list[mapping.FarmID], mapping.PondsID)
+			}
+		}
+		return nil
+	})
+	return list, err
+}
+
+// GetPondsByIDs is func to get active pond info with its farm id in memory by list of pond id
+func (p *MemoryPond) GetPondsByIDs(ctx context.Context, ids []uint) ([]PondInfraInfo, error) {
+	var list []PondInfraInfo
+	err := p.db.View(func(t *memorydb.Tables) error {
+		for _, pond := range t.Ponds {
+			if !isActivePond(pond) || !containsID(ids, pond.ID) {
+				continue
+			}
+			var info PondInfraInfo
+			// pond without mapping is returned with empty farm id like the left join
+			mapPondInfo(t, &info, pond)
+			list = append(list, info)
+		}
+		return nil
+	})
+	return list, err
+}
+
+// GetPondByID is func to get active pond info in memory by pond id
+func (p *MemoryPond) GetPondByID(ctx context.Context, r *PondInfraInfo) error {
+	if r == nil || r.ID <= 0 {
+		return errors.New("got nil request")
+	}
+
+	return p.db.View(func(t *memorydb.Tables) error {
+		pond := findPond(t, func(pond *postgres.Ponds) bool {
+			return pond.ID == r.ID
+		})
+		if pond == nil {
+			return gorm.ErrRecordNotFound
+		}
+		return mapPondInfo(t, r, pond)
+	})
+}
+
+// GetPondByName is func to get active pond info in memory by pond name
+func (p *MemoryPond) GetPondByName(ctx context.Context, r *PondInfraInfo) error {
+	if r == nil || len(r.Name) <= 0 {
+		return errors.New("got nil request")
+	}
+
+	return p.db.View(func(t *memorydb.Tables) error {
+		pond := findPond(t, func(pond *postgres.Ponds) bool {
+			return pond.Name == r.Name
+		})
+		if pond == nil {
+			return gorm.ErrRecordNotFound
+		}
+		return mapPondInfo(t, r, pond)
+	})
+}
+
+// Update is func to update non empty field of active pond with the same id, the farm mapping is moved
+// when farm id is set, it return ErrInvalidFarm when the farm does not exist and ErrDuplicateName
+// when the pond is renamed into the name of other active pond
+func (p *MemoryPond) Update(ctx context.Context, r *PondInfraInfo) error {
+	if r == nil {
+		return errors.New("got nil request")
+	}
+
+	return p.db.Update(func(t *memorydb.Tables) error {
+		if r.FarmID != 0 && hasMapping(t, r.ID) && !farmExists(t, r.FarmID) {
+			return ErrInvalidFarm
+		}
+
+		now := time.Now()
+		pond := findPond(t, func(pond *postgres.Ponds) bool {
+			return pond.ID == r.ID
+		})
+		duplicate := findPond(t, func(pond *postgres.Ponds) bool {
+			return pond.ID != r.ID && pond.Name == r.Name
+		})
+		if pond != nil && duplicate != nil {
+			return ErrDuplicateName
+		}
+		if pond != nil {
+			if len(r.Name) > 0 {
+				pond.Name = r.Name
+			}
+			if r.Capacity != 0 {
+				pond.Capacity = r.Capacity
+			}
+			if r.Depth != 0 {
+				pond.Depth = r.Depth
+			}
+			if r.WaterQuality != 0 {
+				pond.WaterQuality = r.WaterQuality
+			}
+			if len(r.Species) > 0 {
+				pond.Species = r.Species
+			}
+			pond.UpdatedAt = now
+		}
+
+		if r.FarmID == 0 {
+			return nil
+		}
+		for _, mapping := range t.FarmPondsMappings {
+			if mapping.PondsID == r.ID && mapping.DeletedAt == nil {
+				mapping.FarmID = r.FarmID
+				mapping.UpdatedAt = now
+			}
+		}
+		return nil
+	})
+}
+
+// Delete is func to soft delete active pond with the same id and name
+func (p *MemoryPond) Delete(ctx context.Context, r *PondInfraInfo) error {
+	if r == nil {
+		return errors.New("got nil request")
+	}
+
+	return p.db.Update(func(t *memorydb.Tables) error {
+		pond := findPond(t, func(pond *postgres.Ponds) bool {
+			return pond.ID == r.ID && pond.Name == r.Name
+		})
+		if pond == nil {
+			return nil
+		}
+
+		pond.Status = model.Inactive.Value()
+		pond.UpdatedAt = time.Now()
+		return nil
+	})
+}
+
+// Verify is func to check if active pond already exists based on id or name
+func (p *MemoryPond) Verify(ctx context.Context, r *PondInfraInfo) (bool, error) {
+	if r == nil {
+		return false, errors.New("got nil request")
+	}
+
+	var match func(pond *postgres.Ponds) bool
+	if r.ID > 0 {
+		match = func(pond *postgres.Ponds) bool { return pond.ID == r.ID }
+	} else if len(r.Name) > 0 {
+		match = func(pond *postgres.Ponds) bool { return pond.Name == r.Name }
+	} else {
+		return false, errors.New("Invalid Parameter Request")
+	}
+
+	var exists bool
+	err := p.db.View(func(t *memorydb.Tables) error {
+		pond := findPond(t, match)
+		if pond == nil {
+			return nil
+		}
+
+		exists = true
+		r.Name = pond.Name
+		r.ID = pond.ID
+		return nil
+	})
+	return exists, err
+}
+
+// GetPondWithPaging is func to get active pond ordered by id with paging
+func (p *MemoryPond) GetPondWithPaging(ctx context.Context, r GetPondWithPagingRequest) ([]PondInfraInfo, error) {
+	var list []PondInfraInfo
+	err := p.db.View(func(t *memorydb.Tables) error {
+		var skip int
+		if r.Cursor > 1 {
+			skip = (r.Cursor - 1) * r.Size
+		}
+		for _, pond := range t.Ponds {
+			if !isActivePond(pond) {
+				continue
+			}
+			if skip > 0 {
+				skip--
+				continue
+			}
+			if len(list) >= r.Size {
+				break
+			}
+
+			var info PondInfraInfo
+			mapPondInfo(t, &info, pond)
+			list = append(list, info)
+		}
+		return nil
+	})
+	return list, err
+}
+
+// Export is func to stream pond with the status and the name of its farm into fn ordered by id, every pond is streamed
+// when the status is unknown, the rows is copied before fn is called so a slow fn does not block the writer
+func (p *MemoryPond) Export(ctx context.Context, status model.Status, fn func(ExportPondInfo) error) error {
+	var list []ExportPondInfo
+	err := p.db.View(func(t *memorydb.Tables) error {
+		for _, pond := range t.Ponds {
+			if pond.DeletedAt != nil || (status != model.Unknown && pond.Status != status.Value()) {
+				continue
+			}
+
+			info := ExportPondInfo{
+				ID:           pond.ID,
+				Name:         pond.Name,
+				Capacity:     pond.Capacity,
+				Depth:        pond.Depth,
+				WaterQuality: pond.WaterQuality,
+				Species:      pond.Species,
+				Status:       pond.Status,
+				CreatedAt:    pond.CreatedAt,
+				UpdatedAt:    pond.UpdatedAt,
+			}
+			for _, mapping := range t.FarmPondsMappings {
+				if mapping.PondsID == pond.ID && mapping.DeletedAt == nil {
+					info.FarmID = mapping.FarmID
+					break
+				}
+			}
+			for _, farm := range t.Farms {
+				if info.FarmID > 0 && farm.ID == info.FarmID {
+					info.FarmName = farm.Name
+					break
+				}
+			}
+			list = append(list, info)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, info := range list {
+		if err := fn(info); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CountActivePonds is func to count all active ponds
+func (p *MemoryPond) CountActivePonds(ctx context.Context) (int, error) {
+	var count int
+	err := p.db.View(func(t *memorydb.Tables) error {
+		for _, pond := range t.Ponds {
+			if isActivePond(pond) {
+				count++
+			}
+		}
+		return nil
+	})
+	return count, err
+}
+
+// findPond is func to get the first active pond that match, it return nil when no pond match
+func findPond(t *memorydb.Tables, match func(pond *postgres.Ponds) bool) *postgres.Ponds {
+	for _, pond := range t.Ponds {
+		if isActivePond(pond) && match(pond) {
+			return pond
+		}
+	}
+	return nil
+}
+
+// containsID is func to check id is in the list
+func containsID(ids []uint, id uint) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
+
+// farmExists is func to check farm row exists whether it is deleted or not, like the foreign key of mapping
+func farmExists(t *memorydb.Tables, id uint) bool {
+	for _, farm := range t.Farms {
+		if farm.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// hasMapping is func to check the pond is mapped into any farm
+func hasMapping(t *memorydb.Tables, pondID uint) bool {
+	for _, mapping := range t.FarmPondsMappings {
+		if mapping.PondsID == pondID && mapping.DeletedAt == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// isActivePond is func to check pond is not soft deleted
+func isActivePond(pond *postgres.Ponds) bool {
+	return pond.Status == model.Active.Value() && pond.DeletedAt == nil
+}
+
+// mapPondInfo is func to copy pond row and its farm id into PondInfraInfo,
+// it return gorm.ErrRecordNotFound when the pond is not mapped into any farm
+func mapPondInfo(t *memorydb.Tables, r *PondInfraInfo, pond *postgres.Ponds) error {
+	r.ID = pond.ID
+	r.Name = pond.Name
+	r.Capacity = pond.Capacity
+	r.Depth = pond.Depth
+	r.WaterQuality = pond.WaterQuality
+	r.Species = pond.Species
+	r.FarmID = 0
+
+	for _, mapping := range t.FarmPondsMappings {
+		if mapping.PondsID == pond.ID && mapping.DeletedAt == nil {
+			r.FarmID = mapping.FarmID
+			return nil
+		}
+	}
+	return gorm.ErrRecordNotFound
+}
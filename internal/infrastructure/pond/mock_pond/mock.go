@@ -6,6 +6,8 @@ package mock_pond
 
 import (
 	pond "aqua-farm-manager/internal/infrastructure/pond"
+	model "aqua-farm-manager/internal/model"
+	context "context"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -34,117 +36,190 @@ func (m *MockPondStore) EXPECT() *MockPondStoreMockRecorder {
 	return m.recorder
 }
 
+// BulkCreate mocks base method.
+func (m *MockPondStore) BulkCreate(ctx context.Context, list []*pond.PondInfraInfo) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BulkCreate", ctx, list)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BulkCreate indicates an expected call of BulkCreate.
+func (mr *MockPondStoreMockRecorder) BulkCreate(ctx, list interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BulkCreate", reflect.TypeOf((*MockPondStore)(nil).BulkCreate), ctx, list)
+}
+
+// CountActivePonds mocks base method.
+func (m *MockPondStore) CountActivePonds(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CountActivePonds", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CountActivePonds indicates an expected call of CountActivePonds.
+func (mr *MockPondStoreMockRecorder) CountActivePonds(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CountActivePonds", reflect.TypeOf((*MockPondStore)(nil).CountActivePonds), ctx)
+}
+
 // Create mocks base method.
-func (m *MockPondStore) Create(r *pond.PondInfraInfo) error {
+func (m *MockPondStore) Create(ctx context.Context, r *pond.PondInfraInfo) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Create", r)
+	ret := m.ctrl.Call(m, "Create", ctx, r)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Create indicates an expected call of Create.
-func (mr *MockPondStoreMockRecorder) Create(r interface{}) *gomock.Call {
+func (mr *MockPondStoreMockRecorder) Create(ctx, r interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPondStore)(nil).Create), r)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPondStore)(nil).Create), ctx, r)
 }
 
 // Delete mocks base method.
-func (m *MockPondStore) Delete(r *pond.PondInfraInfo) error {
+func (m *MockPondStore) Delete(ctx context.Context, r *pond.PondInfraInfo) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", r)
+	ret := m.ctrl.Call(m, "Delete", ctx, r)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockPondStoreMockRecorder) Delete(r interface{}) *gomock.Call {
+func (mr *MockPondStoreMockRecorder) Delete(ctx, r interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPondStore)(nil).Delete), r)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPondStore)(nil).Delete), ctx, r)
+}
+
+// Export mocks base method.
+func (m *MockPondStore) Export(ctx context.Context, status model.Status, fn func(pond.ExportPondInfo) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Export", ctx, status, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Export indicates an expected call of Export.
+func (mr *MockPondStoreMockRecorder) Export(ctx, status, fn interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockPondStore)(nil).Export), ctx, status, fn)
 }
 
 // GetPondByID mocks base method.
-func (m *MockPondStore) GetPondByID(r *pond.PondInfraInfo) error {
+func (m *MockPondStore) GetPondByID(ctx context.Context, r *pond.PondInfraInfo) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPondByID", r)
+	ret := m.ctrl.Call(m, "GetPondByID", ctx, r)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // GetPondByID indicates an expected call of GetPondByID.
-func (mr *MockPondStoreMockRecorder) GetPondByID(r interface{}) *gomock.Call {
+func (mr *MockPondStoreMockRecorder) GetPondByID(ctx, r interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPondByID", reflect.TypeOf((*MockPondStore)(nil).GetPondByID), r)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPondByID", reflect.TypeOf((*MockPondStore)(nil).GetPondByID), ctx, r)
 }
 
 // GetPondByName mocks base method.
-func (m *MockPondStore) GetPondByName(r *pond.PondInfraInfo) error {
+func (m *MockPondStore) GetPondByName(ctx context.Context, r *pond.PondInfraInfo) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPondByName", r)
+	ret := m.ctrl.Call(m, "GetPondByName", ctx, r)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // GetPondByName indicates an expected call of GetPondByName.
-func (mr *MockPondStoreMockRecorder) GetPondByName(r interface{}) *gomock.Call {
+func (mr *MockPondStoreMockRecorder) GetPondByName(ctx, r interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPondByName", reflect.TypeOf((*MockPondStore)(nil).GetPondByName), r)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPondByName", reflect.TypeOf((*MockPondStore)(nil).GetPondByName), ctx, r)
 }
 
 // GetPondIDbyFarmID mocks base method.
-func (m *MockPondStore) GetPondIDbyFarmID(id uint) ([]uint, error) {
+func (m *MockPondStore) GetPondIDbyFarmID(ctx context.Context, id uint) ([]uint, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPondIDbyFarmID", id)
+	ret := m.ctrl.Call(m, "GetPondIDbyFarmID", ctx, id)
 	ret0, _ := ret[0].([]uint)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetPondIDbyFarmID indicates an expected call of GetPondIDbyFarmID.
-func (mr *MockPondStoreMockRecorder) GetPondIDbyFarmID(id interface{}) *gomock.Call {
+func (mr *MockPondStoreMockRecorder) GetPondIDbyFarmID(ctx, id interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPondIDbyFarmID", reflect.TypeOf((*MockPondStore)(nil).GetPondIDbyFarmID), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPondIDbyFarmID", reflect.TypeOf((*MockPondStore)(nil).GetPondIDbyFarmID), ctx, id)
 }
 
 // GetPondWithPaging mocks base method.
-func (m *MockPondStore) GetPondWithPaging(r pond.GetPondWithPagingRequest) ([]pond.PondInfraInfo, error) {
+func (m *MockPondStore) GetPondWithPaging(ctx context.Context, r pond.GetPondWithPagingRequest) ([]pond.PondInfraInfo, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetPondWithPaging", r)
+	ret := m.ctrl.Call(m, "GetPondWithPaging", ctx, r)
 	ret0, _ := ret[0].([]pond.PondInfraInfo)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetPondWithPaging indicates an expected call of GetPondWithPaging.
-func (mr *MockPondStoreMockRecorder) GetPondWithPaging(r interface{}) *gomock.Call {
+func (mr *MockPondStoreMockRecorder) GetPondWithPaging(ctx, r interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPondWithPaging", reflect.TypeOf((*MockPondStore)(nil).GetPondWithPaging), ctx, r)
+}
+
+// GetPondsByFarmIDs mocks base method.
+func (m *MockPondStore) GetPondsByFarmIDs(ctx context.Context, farmIDs []uint) (map[uint][]uint, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPondsByFarmIDs", ctx, farmIDs)
+	ret0, _ := ret[0].(map[uint][]uint)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPondsByFarmIDs indicates an expected call of GetPondsByFarmIDs.
+func (mr *MockPondStoreMockRecorder) GetPondsByFarmIDs(ctx, farmIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPondsByFarmIDs", reflect.TypeOf((*MockPondStore)(nil).GetPondsByFarmIDs), ctx, farmIDs)
+}
+
+// GetPondsByIDs mocks base method.
+func (m *MockPondStore) GetPondsByIDs(ctx context.Context, ids []uint) ([]pond.PondInfraInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPondsByIDs", ctx, ids)
+	ret0, _ := ret[0].([]pond.PondInfraInfo)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPondsByIDs indicates an expected call of GetPondsByIDs.
+func (mr *MockPondStoreMockRecorder) GetPondsByIDs(ctx, ids interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPondWithPaging", reflect.TypeOf((*MockPondStore)(nil).GetPondWithPaging), r)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPondsByIDs", reflect.TypeOf((*MockPondStore)(nil).GetPondsByIDs), ctx, ids)
 }
 
 // Update mocks base method.
-func (m *MockPondStore) Update(r *pond.PondInfraInfo) error {
+func (m *MockPondStore) Update(ctx context.Context, r *pond.PondInfraInfo) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", r)
+	ret := m.ctrl.Call(m, "Update", ctx, r)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockPondStoreMockRecorder) Update(r interface{}) *gomock.Call {
+func (mr *MockPondStoreMockRecorder) Update(ctx, r interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockPondStore)(nil).Update), r)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockPondStore)(nil).Update), ctx, r)
 }
 
 // Verify mocks base method.
-func (m *MockPondStore) Verify(r *pond.PondInfraInfo) (bool, error) {
+func (m *MockPondStore) Verify(ctx context.Context, r *pond.PondInfraInfo) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Verify", r)
+	ret := m.ctrl.Call(m, "Verify", ctx, r)
 	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Verify indicates an expected call of Verify.
-func (mr *MockPondStoreMockRecorder) Verify(r interface{}) *gomock.Call {
+func (mr *MockPondStoreMockRecorder) Verify(ctx, r interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockPondStore)(nil).Verify), r)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Verify", reflect.TypeOf((*MockPondStore)(nil).Verify), ctx, r)
 }
@@ -1,23 +1,39 @@
 package pond
 
 import (
+	"aqua-farm-manager/internal/infrastructure/outbox"
 	"aqua-farm-manager/internal/model"
 	"aqua-farm-manager/pkg/postgres"
+	"context"
 	"errors"
 
 	"github.com/jinzhu/gorm"
 )
 
+var (
+	// ErrDuplicateName is error when active pond with the same name already exists
+	ErrDuplicateName = errors.New("pond name already exists")
+	// ErrInvalidFarm is error when the pond is mapped into farm that does not exist
+	ErrInvalidFarm = errors.New("farm does not exist")
+	// ErrNotFound is error when the pond does not exist, it is the same error of every pond store
+	ErrNotFound = gorm.ErrRecordNotFound
+)
+
 // PondStore is set of methods for interacting with a ponds storage system
 type PondStore interface {
-	Verify(r *PondInfraInfo) (bool, error)
-	GetPondIDbyFarmID(id uint) ([]uint, error)
-	GetPondByID(r *PondInfraInfo) error
-	GetPondByName(r *PondInfraInfo) error
-	Create(r *PondInfraInfo) error
-	Update(r *PondInfraInfo) error
-	Delete(r *PondInfraInfo) error
-	GetPondWithPaging(r GetPondWithPagingRequest) ([]PondInfraInfo, error)
+	Verify(ctx context.Context, r *PondInfraInfo) (bool, error)
+	GetPondIDbyFarmID(ctx context.Context, id uint) ([]uint, error)
+	GetPondsByFarmIDs(ctx context.Context, farmIDs []uint) (map[uint][]uint, error)
+	GetPondByID(ctx context.Context, r *PondInfraInfo) error
+	GetPondsByIDs(ctx context.Context, ids []uint) ([]PondInfraInfo, error)
+	GetPondByName(ctx context.Context, r *PondInfraInfo) error
+	Create(ctx context.Context, r *PondInfraInfo) error
+	BulkCreate(ctx context.Context, list []*PondInfraInfo) error
+	Update(ctx context.Context, r *PondInfraInfo) error
+	Delete(ctx context.Context, r *PondInfraInfo) error
+	GetPondWithPaging(ctx context.Context, r GetPondWithPagingRequest) ([]PondInfraInfo, error)
+	Export(ctx context.Context, status model.Status, fn func(ExportPondInfo) error) error
+	CountActivePonds(ctx context.Context) (int, error)
 }
 
 // Pond is list dependencies pond store
@@ -33,9 +49,9 @@ func NewPondStore(pg postgres.PostgresMethod) PondStore {
 }
 
 // Create is func to store ponds and mapping to database
-func (p *Pond) Create(r *PondInfraInfo) error {
+func (p *Pond) Create(ctx context.Context, r *PondInfraInfo) error {
 	var err error
-	db := p.pg.GetDB()
+	db := p.pg.GetDB(ctx)
 	if db == nil {
 		return errors.New("Database Client is not init")
 	}
@@ -53,34 +69,105 @@ func (p *Pond) Create(r *PondInfraInfo) error {
 		Status:       model.Active.Value(),
 	}
 
-	err = insert(db, pond)
+	err = db.Transaction(func(tx *gorm.DB) error {
+		err := insert(tx, pond)
+		if postgres.IsUniqueViolation(err) {
+			return ErrDuplicateName
+		}
+		if err != nil {
+			return err
+		}
+
+		farmpondMapping := &postgres.FarmPondsMapping{
+			FarmID:  r.FarmID,
+			PondsID: pond.ID,
+		}
+
+		err = insert(tx, farmpondMapping)
+		if postgres.IsForeignKeyViolation(err) {
+			return ErrInvalidFarm
+		}
+		if err != nil {
+			return err
+		}
+		return outbox.Insert(tx, pond.Model.ID, r.Events)
+	})
 	if err != nil {
 		return err
 	}
 
-	farmpondMapping := &postgres.FarmPondsMapping{
-		FarmID:  r.FarmID,
-		PondsID: pond.ID,
+	r.ID = pond.Model.ID
+
+	return err
+}
+
+// BulkCreate is func to store every pond and its mapping to database in a single transaction,
+// nothing is stored when any pond cannot be stored
+func (p *Pond) BulkCreate(ctx context.Context, list []*PondInfraInfo) error {
+	db := p.pg.GetDB(ctx)
+	if db == nil {
+		return errors.New("Database Client is not init")
 	}
 
-	err = insert(db, farmpondMapping)
+	ponds := make([]*postgres.Ponds, 0, len(list))
+	err := db.Transaction(func(tx *gorm.DB) error {
+		for _, r := range list {
+			if r == nil {
+				return errors.New("got nil request")
+			}
+
+			pond := &postgres.Ponds{
+				Name:         r.Name,
+				Capacity:     r.Capacity,
+				Depth:        r.Depth,
+				WaterQuality: r.WaterQuality,
+				Species:      r.Species,
+				Status:       model.Active.Value(),
+			}
+			err := insert(tx, pond)
+			if postgres.IsUniqueViolation(err) {
+				return ErrDuplicateName
+			}
+			if err != nil {
+				return err
+			}
+
+			err = insert(tx, &postgres.FarmPondsMapping{
+				FarmID:  r.FarmID,
+				PondsID: pond.ID,
+			})
+			if postgres.IsForeignKeyViolation(err) {
+				return ErrInvalidFarm
+			}
+			if err != nil {
+				return err
+			}
+			err = outbox.Insert(tx, pond.Model.ID, r.Events)
+			if err != nil {
+				return err
+			}
+			ponds = append(ponds, pond)
+		}
+		return nil
+	})
 	if err != nil {
 		return err
 	}
 
-	r.ID = pond.Model.ID
-
-	return err
+	for i, pond := range ponds {
+		list[i].ID = pond.Model.ID
+	}
+	return nil
 }
 
 func insert(db *gorm.DB, data interface{}) error {
 	return db.Create(data).Error
 }
 
-func (p *Pond) GetPondIDbyFarmID(id uint) ([]uint, error) {
+func (p *Pond) GetPondIDbyFarmID(ctx context.Context, id uint) ([]uint, error) {
 	var list []uint
 	var err error
-	db := p.pg.GetDB()
+	db := p.pg.GetDB(ctx)
 	if db == nil {
 		return list, errors.New("Database Client is not init")
 	}
@@ -100,11 +187,71 @@ func getPondIDbyFarmID(db *gorm.DB, farmid uint) ([]postgres.FarmPondsMapping, e
 	return mapping, err
 }
 
+// GetPondsByFarmIDs is func to get id of every pond mapped into each farm in a single query, the result is keyed by farm id
+func (p *Pond) GetPondsByFarmIDs(ctx context.Context, farmIDs []uint) (map[uint][]uint, error) {
+	list := make(map[uint][]uint)
+	db := p.pg.GetDB(ctx)
+	if db == nil {
+		return list, errors.New("Database Client is not init")
+	}
+
+	if len(farmIDs) == 0 {
+		return list, nil
+	}
+
+	mapping, err := getPondIDbyFarmIDs(db, farmIDs)
+	if err != nil {
+		return list, err
+	}
+
+	for _, data := range mapping {
+		list[data.FarmID] = append(list[data.FarmID], data.PondsID)
+	}
+	return list, nil
+}
+
+func getPondIDbyFarmIDs(db *gorm.DB, farmIDs []uint) ([]postgres.FarmPondsMapping, error) {
+	var mapping []postgres.FarmPondsMapping
+	err := db.Where("farm_id in (?)", farmIDs).Order("id").Find(&mapping).Error
+	return mapping, err
+}
+
+// GetPondsByIDs is func to get active pond info with its farm id in database by list of pond id in a single query,
+// pond that does not exist or inactive is not returned
+func (p *Pond) GetPondsByIDs(ctx context.Context, ids []uint) ([]PondInfraInfo, error) {
+	var list []PondInfraInfo
+	db := p.pg.GetDB(ctx)
+	if db == nil {
+		return list, errors.New("Database Client is not init")
+	}
+
+	if len(ids) == 0 {
+		return list, nil
+	}
+
+	return getPondsByIDs(db, ids)
+}
+
+func getPondsByIDs(db *gorm.DB, ids []uint) ([]PondInfraInfo, error) {
+	var ponds []PondInfraInfo
+	err := db.Table("ponds").
+		Select("ponds.id, ponds.name, ponds.capacity, ponds.depth, ponds.water_quality, ponds.species, farm_ponds_mappings.farm_id").
+		Joins("left join farm_ponds_mappings on farm_ponds_mappings.ponds_id = ponds.id and farm_ponds_mappings.deleted_at is null").
+		Where("ponds.id in (?) and ponds.status = ? and ponds.deleted_at is null", ids, model.Active.Value()).
+		Order("ponds.id").
+		Scan(&ponds).Error
+	if err != nil {
+		return nil, err
+	}
+
+	return ponds, nil
+}
+
 // GetPondByID is func to get pond info in database by pond id
-func (p *Pond) GetPondByID(r *PondInfraInfo) error {
+func (p *Pond) GetPondByID(ctx context.Context, r *PondInfraInfo) error {
 	var err error
 
-	db := p.pg.GetDB()
+	db := p.pg.GetDB(ctx)
 	if db == nil {
 		return errors.New("Database Client is not init")
 	}
@@ -145,10 +292,10 @@ func getPondByID(db *gorm.DB, pond *postgres.Ponds) error {
 }
 
 // GetPondByName is func to get pond info in database by pond name
-func (p *Pond) GetPondByName(r *PondInfraInfo) error {
+func (p *Pond) GetPondByName(ctx context.Context, r *PondInfraInfo) error {
 	var err error
 
-	db := p.pg.GetDB()
+	db := p.pg.GetDB(ctx)
 	if db == nil {
 		return errors.New("Database Client is not init")
 	}
@@ -187,9 +334,9 @@ func getPondByName(db *gorm.DB, pond *postgres.Ponds) error {
 }
 
 // Update is func to store pond into database
-func (f *Pond) Update(r *PondInfraInfo) error {
+func (f *Pond) Update(ctx context.Context, r *PondInfraInfo) error {
 	var err error
-	db := f.pg.GetDB()
+	db := f.pg.GetDB(ctx)
 	if db == nil {
 		return errors.New("Database Client is not init")
 	}
@@ -210,17 +357,29 @@ func (f *Pond) Update(r *PondInfraInfo) error {
 		Status:       model.Active.Value(),
 	}
 
-	err = update(db, pond)
-	if err != nil {
-		return err
-	}
-
-	farmpondMapping := &postgres.FarmPondsMapping{
-		FarmID:  r.FarmID,
-		PondsID: pond.ID,
-	}
-
-	err = updateMapping(db, farmpondMapping)
+	err = db.Transaction(func(tx *gorm.DB) error {
+		err := update(tx, pond)
+		if postgres.IsUniqueViolation(err) {
+			return ErrDuplicateName
+		}
+		if err != nil {
+			return err
+		}
+
+		farmpondMapping := &postgres.FarmPondsMapping{
+			FarmID:  r.FarmID,
+			PondsID: pond.ID,
+		}
+
+		err = updateMapping(tx, farmpondMapping)
+		if postgres.IsForeignKeyViolation(err) {
+			return ErrInvalidFarm
+		}
+		if err != nil {
+			return err
+		}
+		return outbox.Insert(tx, pond.Model.ID, r.Events)
+	})
 	if err != nil {
 		return err
 	}
@@ -228,9 +387,9 @@ func (f *Pond) Update(r *PondInfraInfo) error {
 	return err
 }
 
-// update is func to update data pond in database
+// update is func to update data active pond by id in database, the name is updated too when it is not empty
 func update(db *gorm.DB, pond *postgres.Ponds) error {
-	return db.Model(pond).Where("name = ? AND id = ? and status = ?", pond.Name, pond.Model.ID, model.Active.Value()).Updates(pond).Error
+	return db.Model(pond).Where("id = ? and status = ?", pond.Model.ID, model.Active.Value()).Updates(pond).Error
 }
 
 // updateMapping is func to update mapping data pond farm in database
@@ -239,9 +398,9 @@ func updateMapping(db *gorm.DB, mapping *postgres.FarmPondsMapping) error {
 }
 
 // Delete is func to soft delete farm into database
-func (f *Pond) Delete(r *PondInfraInfo) error {
+func (f *Pond) Delete(ctx context.Context, r *PondInfraInfo) error {
 	var err error
-	db := f.pg.GetDB()
+	db := f.pg.GetDB(ctx)
 	if db == nil {
 		return errors.New("Database Client is not init")
 	}
@@ -257,7 +416,13 @@ func (f *Pond) Delete(r *PondInfraInfo) error {
 		Name: r.Name,
 	}
 
-	err = delete(db, pond)
+	err = db.Transaction(func(tx *gorm.DB) error {
+		err := delete(tx, pond)
+		if err != nil {
+			return err
+		}
+		return outbox.Insert(tx, pond.Model.ID, r.Events)
+	})
 	if err != nil {
 		return err
 	}
@@ -271,9 +436,9 @@ func delete(db *gorm.DB, pond *postgres.Ponds) error {
 }
 
 // Verify is func to check if pond already exists based on id or name
-func (p *Pond) Verify(r *PondInfraInfo) (bool, error) {
+func (p *Pond) Verify(ctx context.Context, r *PondInfraInfo) (bool, error) {
 	var exists bool
-	db := p.pg.GetDB()
+	db := p.pg.GetDB(ctx)
 	if db == nil {
 		return false, errors.New("Database Client is not init")
 	}
@@ -316,11 +481,11 @@ func getPondbyID(db *gorm.DB, pond *postgres.Ponds) error {
 }
 
 // GetPondWithPaging is func to get all pond with paging
-func (p *Pond) GetPondWithPaging(r GetPondWithPagingRequest) ([]PondInfraInfo, error) {
+func (p *Pond) GetPondWithPaging(ctx context.Context, r GetPondWithPagingRequest) ([]PondInfraInfo, error) {
 	var list []PondInfraInfo
 	var err error
 
-	db := p.pg.GetDB()
+	db := p.pg.GetDB(ctx)
 	if db == nil {
 		return list, errors.New("Database Client is not init")
 	}
@@ -346,7 +511,59 @@ func getPondWithPaging(db *gorm.DB, cursor int, size int) ([]PondInfraInfo, erro
 	return ponds, nil
 }
 
+// Export is func to stream pond with the status and the name of its farm into fn one row at a time
+// with database cursor ordered by id, every pond is streamed when the status is unknown
+// and the stream stop at the first error of fn
+func (p *Pond) Export(ctx context.Context, status model.Status, fn func(ExportPondInfo) error) error {
+	db := p.pg.GetDB(ctx)
+	if db == nil {
+		return errors.New("Database Client is not init")
+	}
+
+	query := db.Table("ponds").
+		Select("ponds.id, ponds.name, ponds.capacity, ponds.depth, ponds.water_quality, ponds.species, ponds.status, " +
+			"ponds.created_at, ponds.updated_at, farm_ponds_mappings.farm_id, farms.name AS farm_name").
+		Joins("left join farm_ponds_mappings on farm_ponds_mappings.ponds_id = ponds.id and farm_ponds_mappings.deleted_at is null").
+		Joins("left join farms on farms.id = farm_ponds_mappings.farm_id").
+		Where("ponds.deleted_at is null")
+	if status != model.Unknown {
+		query = query.Where("ponds.status = ?", status.Value())
+	}
+
+	rows, err := query.Order("ponds.id").Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var info ExportPondInfo
+		err = db.ScanRows(rows, &info)
+		if err != nil {
+			return err
+		}
+		err = fn(info)
+		if err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
 // getFarmIDbyPondID func to get farmid by pondid id
 func getFarmIDbyPondID(db *gorm.DB, mapping *postgres.FarmPondsMapping) error {
 	return db.Where("ponds_id = ?", mapping.PondsID).First(&mapping).Error
 }
+
+// CountActivePonds is func to count all active ponds
+func (p *Pond) CountActivePonds(ctx context.Context) (int, error) {
+	var count int
+	db := p.pg.GetDB(ctx)
+	if db == nil {
+		return count, errors.New("Database Client is not init")
+	}
+
+	err := db.Model(&postgres.Ponds{}).Where("status = ?", model.Active.Value()).Count(&count).Error
+	return count, err
+}
@@ -1,9 +1,11 @@
 package pond
 
 import (
+	"aqua-farm-manager/internal/infrastructure/outbox"
 	"aqua-farm-manager/internal/model"
 	"aqua-farm-manager/pkg/postgres"
 	mock_postgres "aqua-farm-manager/pkg/postgres/mock"
+	"context"
 	"database/sql"
 	"fmt"
 	"log"
@@ -70,12 +72,10 @@ func TestPond_Create(t *testing.T) {
 		{
 			name: "success",
 			mockFunc: func() {
-				pg.EXPECT().GetDB().Return(gormDB)
+				pg.EXPECT().GetDB(gomock.Any()).Return(gormDB)
 				mockDB.ExpectBegin()
 				mockDB.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "ponds" ("created_at","updated_at","deleted_at","name","capacity","depth","water_quality","species","status") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9)`)).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
-				mockDB.ExpectCommit()
 
-				mockDB.ExpectBegin()
 				mockDB.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "farm_ponds_mappings" ("created_at","updated_at","deleted_at","farm_id","ponds_id") VALUES ($1,$2,$3,$4,$5)`)).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
 				mockDB.ExpectCommit()
 			},
@@ -88,7 +88,7 @@ func TestPond_Create(t *testing.T) {
 		{
 			name: "success",
 			mockFunc: func() {
-				pg.EXPECT().GetDB().Return(gormDB)
+				pg.EXPECT().GetDB(gomock.Any()).Return(gormDB)
 				mockDB.ExpectBegin()
 				mockDB.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "ponds" ("created_at","updated_at","deleted_at","name","capacity","depth","water_quality","species","status") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9)`)).WillReturnError(fmt.Errorf("some error"))
 			},
@@ -101,7 +101,7 @@ func TestPond_Create(t *testing.T) {
 		{
 			name: "nil db",
 			mockFunc: func() {
-				pg.EXPECT().GetDB().Return(nil)
+				pg.EXPECT().GetDB(gomock.Any()).Return(nil)
 			},
 			r: &PondInfraInfo{
 				ID: 1,
@@ -111,7 +111,7 @@ func TestPond_Create(t *testing.T) {
 		{
 			name: "req nil",
 			mockFunc: func() {
-				pg.EXPECT().GetDB().Return(gormDB)
+				pg.EXPECT().GetDB(gomock.Any()).Return(gormDB)
 			},
 			wantErr: true,
 		},
@@ -120,7 +120,7 @@ func TestPond_Create(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockFunc()
 			s := NewPondStore(pg)
-			if err := s.Create(tt.r); (err != nil) != tt.wantErr {
+			if err := s.Create(context.Background(), tt.r); (err != nil) != tt.wantErr {
 				t.Errorf("Pond.Create() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -144,7 +144,7 @@ func TestPond_GetPondIDbyFarmID(t *testing.T) {
 		{
 			name: "success",
 			mockFunc: func() {
-				pg.EXPECT().GetDB().Return(gormDB)
+				pg.EXPECT().GetDB(gomock.Any()).Return(gormDB)
 				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "farm_ponds_mappings" WHERE "farm_ponds_mappings"."deleted_at" IS NULL AND ((farm_id = $1))`)).WillReturnRows(sqlmock.NewRows([]string{"ponds_id"}).AddRow(1))
 
 			},
@@ -155,7 +155,7 @@ func TestPond_GetPondIDbyFarmID(t *testing.T) {
 		{
 			name: "nil db",
 			mockFunc: func() {
-				pg.EXPECT().GetDB().Return(nil)
+				pg.EXPECT().GetDB(gomock.Any()).Return(nil)
 			},
 			id:      1,
 			wantErr: true,
@@ -165,7 +165,7 @@ func TestPond_GetPondIDbyFarmID(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockFunc()
 			s := NewPondStore(pg)
-			got, err := s.GetPondIDbyFarmID(tt.id)
+			got, err := s.GetPondIDbyFarmID(context.Background(), tt.id)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Pond.GetPondIDbyFarmID() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -177,6 +177,76 @@ func TestPond_GetPondIDbyFarmID(t *testing.T) {
 	}
 }
 
+func TestPond_GetPondsByFarmIDs(t *testing.T) {
+	db, mockDB, gormDB := InitDBsMockupStat()
+	defer db.Close()
+	defer gormDB.Close()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	pg := mock_postgres.NewMockPostgresMethod(mockCtrl)
+	tests := []struct {
+		name     string
+		ids      []uint
+		mockFunc func()
+		wantErr  bool
+		want     map[uint][]uint
+	}{
+		{
+			name: "success",
+			mockFunc: func() {
+				pg.EXPECT().GetDB(gomock.Any()).Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "farm_ponds_mappings" WHERE "farm_ponds_mappings"."deleted_at" IS NULL AND ((farm_id in ($1,$2))) ORDER BY "id"`)).
+					WillReturnRows(sqlmock.NewRows([]string{"farm_id", "ponds_id"}).AddRow(1, 1).AddRow(2, 3).AddRow(1, 2))
+			},
+			ids:  []uint{1, 2},
+			want: map[uint][]uint{1: {1, 2}, 2: {3}},
+		},
+		{
+			name: "empty ids does not query",
+			mockFunc: func() {
+				pg.EXPECT().GetDB(gomock.Any()).Return(gormDB)
+			},
+			want: map[uint][]uint{},
+		},
+		{
+			name: "query error",
+			mockFunc: func() {
+				pg.EXPECT().GetDB(gomock.Any()).Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "farm_ponds_mappings"`)).WillReturnError(fmt.Errorf("some error"))
+			},
+			ids:     []uint{1},
+			want:    map[uint][]uint{},
+			wantErr: true,
+		},
+		{
+			name: "nil db",
+			mockFunc: func() {
+				pg.EXPECT().GetDB(gomock.Any()).Return(nil)
+			},
+			ids:     []uint{1},
+			want:    map[uint][]uint{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			s := NewPondStore(pg)
+			got, err := s.GetPondsByFarmIDs(context.Background(), tt.ids)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Pond.GetPondsByFarmIDs() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Pond.GetPondsByFarmIDs() = %v, want %v", got, tt.want)
+			}
+			if err := mockDB.ExpectationsWereMet(); err != nil {
+				t.Errorf("Pond.GetPondsByFarmIDs() query = %v", err)
+			}
+		})
+	}
+}
+
 func TestPond_GetPondByID(t *testing.T) {
 	var pond1 = &postgres.Ponds{
 		Model: gorm.Model{
@@ -210,7 +280,7 @@ func TestPond_GetPondByID(t *testing.T) {
 		{
 			name: "success",
 			mockFunc: func() {
-				pg.EXPECT().GetDB().Return(gormDB)
+				pg.EXPECT().GetDB(gomock.Any()).Return(gormDB)
 				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "ponds" WHERE "ponds"."deleted_at" IS NULL AND "ponds"."id" = $1 AND ((id = $2 and status = $3)) ORDER BY "ponds"."id" ASC LIMIT 1`)).WillReturnRows(expectedRows)
 				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "farm_ponds_mappings" WHERE "farm_ponds_mappings"."deleted_at" IS NULL AND ((ponds_id = $1)) ORDER BY "farm_ponds_mappings"."id" ASC LIMIT 1`)).WillReturnRows(sqlmock.NewRows([]string{"farms_id"}).AddRow(1))
 			},
@@ -224,7 +294,7 @@ func TestPond_GetPondByID(t *testing.T) {
 		{
 			name: "error exec",
 			mockFunc: func() {
-				pg.EXPECT().GetDB().Return(gormDB)
+				pg.EXPECT().GetDB(gomock.Any()).Return(gormDB)
 				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "ponds" WHERE "ponds"."deleted_at" IS NULL AND "ponds"."id" = $1 AND ((id = $2 and status = $3)) ORDER BY "ponds"."id" ASC LIMIT 1`)).WillReturnError(fmt.Errorf("some error"))
 			},
 			args: args{
@@ -237,14 +307,14 @@ func TestPond_GetPondByID(t *testing.T) {
 		{
 			name: "nil request",
 			mockFunc: func() {
-				pg.EXPECT().GetDB().Return(gormDB)
+				pg.EXPECT().GetDB(gomock.Any()).Return(gormDB)
 			},
 			wantErr: true,
 		},
 		{
 			name: "nil db",
 			mockFunc: func() {
-				pg.EXPECT().GetDB().Return(nil)
+				pg.EXPECT().GetDB(gomock.Any()).Return(nil)
 			},
 			args: args{
 				r: &PondInfraInfo{
@@ -258,7 +328,7 @@ func TestPond_GetPondByID(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockFunc()
 			s := NewPondStore(pg)
-			if err := s.GetPondByID(tt.args.r); (err != nil) != tt.wantErr {
+			if err := s.GetPondByID(context.Background(), tt.args.r); (err != nil) != tt.wantErr {
 				t.Errorf("Pond.GetPondByID() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -298,7 +368,7 @@ func TestPond_GetPondByName(t *testing.T) {
 		{
 			name: "success",
 			mockFunc: func() {
-				pg.EXPECT().GetDB().Return(gormDB)
+				pg.EXPECT().GetDB(gomock.Any()).Return(gormDB)
 				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "ponds" WHERE "ponds"."deleted_at" IS NULL AND ((name = $1 and status = $2)) ORDER BY "ponds"."id" ASC LIMIT 1`)).WillReturnRows(expectedRows)
 				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "farm_ponds_mappings" WHERE "farm_ponds_mappings"."deleted_at" IS NULL AND ((ponds_id = $1)) ORDER BY "farm_ponds_mappings"."id" ASC LIMIT 1`)).WillReturnRows(sqlmock.NewRows([]string{"farms_id"}).AddRow(1))
 			},
@@ -312,7 +382,7 @@ func TestPond_GetPondByName(t *testing.T) {
 		{
 			name: "error exec",
 			mockFunc: func() {
-				pg.EXPECT().GetDB().Return(gormDB)
+				pg.EXPECT().GetDB(gomock.Any()).Return(gormDB)
 				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "ponds" WHERE "ponds"."deleted_at" IS NULL AND ((name = $1 and status = $2)) ORDER BY "ponds"."id" ASC LIMIT 1`)).WillReturnError(fmt.Errorf("some errro"))
 			},
 			args: args{
@@ -325,14 +395,14 @@ func TestPond_GetPondByName(t *testing.T) {
 		{
 			name: "nil request",
 			mockFunc: func() {
-				pg.EXPECT().GetDB().Return(gormDB)
+				pg.EXPECT().GetDB(gomock.Any()).Return(gormDB)
 			},
 			wantErr: true,
 		},
 		{
 			name: "nil db",
 			mockFunc: func() {
-				pg.EXPECT().GetDB().Return(nil)
+				pg.EXPECT().GetDB(gomock.Any()).Return(nil)
 			},
 			args: args{
 				r: &PondInfraInfo{
@@ -346,7 +416,7 @@ func TestPond_GetPondByName(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockFunc()
 			s := NewPondStore(pg)
-			if err := s.GetPondByName(tt.args.r); (err != nil) != tt.wantErr {
+			if err := s.GetPondByName(context.Background(), tt.args.r); (err != nil) != tt.wantErr {
 				t.Errorf("Pond.GetPondByName() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -369,12 +439,10 @@ func TestPond_Update(t *testing.T) {
 		{
 			name: "success",
 			mockFunc: func() {
-				pg.EXPECT().GetDB().Return(gormDB)
+				pg.EXPECT().GetDB(gomock.Any()).Return(gormDB)
 				mockDB.ExpectBegin()
-				mockDB.ExpectExec(regexp.QuoteMeta(`UPDATE "ponds" SET "capacity" = $1, "depth" = $2, "id" = $3, "name" = $4, "species" = $5, "status" = $6, "updated_at" = $7, "water_quality" = $8 WHERE "ponds"."deleted_at" IS NULL AND "ponds"."id" = $9 AND ((name = $10 AND id = $11 and status = $12))`)).WillReturnResult(sqlmock.NewResult(1, 1))
-				mockDB.ExpectCommit()
+				mockDB.ExpectExec(regexp.QuoteMeta(`UPDATE "ponds" SET "capacity" = $1, "depth" = $2, "id" = $3, "name" = $4, "species" = $5, "status" = $6, "updated_at" = $7, "water_quality" = $8 WHERE "ponds"."deleted_at" IS NULL AND "ponds"."id" = $9 AND ((id = $10 and status = $11))`)).WillReturnResult(sqlmock.NewResult(1, 1))
 
-				mockDB.ExpectBegin()
 				mockDB.ExpectExec(regexp.QuoteMeta(`UPDATE "farm_ponds_mappings" SET "farm_id" = $1, "updated_at" = $2 WHERE "farm_ponds_mappings"."deleted_at" IS NULL AND ((ponds_id = $3))`)).WillReturnResult(sqlmock.NewResult(1, 1))
 				mockDB.ExpectCommit()
 			},
@@ -389,12 +457,61 @@ func TestPond_Update(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "success with event",
+			mockFunc: func() {
+				pg.EXPECT().GetDB(gomock.Any()).Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectExec(regexp.QuoteMeta(`UPDATE "ponds" SET "capacity" = $1, "depth" = $2, "id" = $3, "name" = $4, "species" = $5, "status" = $6, "updated_at" = $7, "water_quality" = $8 WHERE "ponds"."deleted_at" IS NULL AND "ponds"."id" = $9 AND ((id = $10 and status = $11))`)).WillReturnResult(sqlmock.NewResult(1, 1))
+				mockDB.ExpectExec(regexp.QuoteMeta(`UPDATE "farm_ponds_mappings" SET "farm_id" = $1, "updated_at" = $2 WHERE "farm_ponds_mappings"."deleted_at" IS NULL AND ((ponds_id = $3))`)).WillReturnResult(sqlmock.NewResult(1, 1))
+				mockDB.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "outbox_events" ("created_at","updated_at","deleted_at","event_id","topic","payload","status","attempts") VALUES ($1,$2,$3,$4,$5,$6,$7,$8)`)).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mockDB.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "outbox_events" ("created_at","updated_at","deleted_at","event_id","topic","payload","status","attempts") VALUES ($1,$2,$3,$4,$5,$6,$7,$8)`)).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(2))
+				mockDB.ExpectCommit()
+			},
+			r: &PondInfraInfo{
+				ID:           1,
+				Name:         "1",
+				Capacity:     1,
+				Depth:        1,
+				WaterQuality: 1,
+				Species:      "1",
+				FarmID:       2,
+				Events: []outbox.Event{
+					{Topic: "topic", Type: "pond.updated"},
+					{Topic: "topic", Type: "pond.moved"},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "rollback when got error update mapping",
+			mockFunc: func() {
+				pg.EXPECT().GetDB(gomock.Any()).Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectExec(regexp.QuoteMeta(`UPDATE "ponds" SET "capacity" = $1, "depth" = $2, "id" = $3, "name" = $4, "species" = $5, "status" = $6, "updated_at" = $7, "water_quality" = $8 WHERE "ponds"."deleted_at" IS NULL AND "ponds"."id" = $9 AND ((id = $10 and status = $11))`)).WillReturnResult(sqlmock.NewResult(1, 1))
+				mockDB.ExpectExec(regexp.QuoteMeta(`UPDATE "farm_ponds_mappings" SET "farm_id" = $1, "updated_at" = $2 WHERE "farm_ponds_mappings"."deleted_at" IS NULL AND ((ponds_id = $3))`)).WillReturnError(fmt.Errorf("some error"))
+				mockDB.ExpectRollback()
+			},
+			r: &PondInfraInfo{
+				ID:           1,
+				Name:         "1",
+				Capacity:     1,
+				Depth:        1,
+				WaterQuality: 1,
+				Species:      "1",
+				FarmID:       2,
+				Events: []outbox.Event{
+					{Topic: "topic", Type: "pond.moved"},
+				},
+			},
+			wantErr: true,
+		},
 		{
 			name: "got error exec",
 			mockFunc: func() {
-				pg.EXPECT().GetDB().Return(gormDB)
+				pg.EXPECT().GetDB(gomock.Any()).Return(gormDB)
 				mockDB.ExpectBegin()
-				mockDB.ExpectExec(regexp.QuoteMeta(`UPDATE "ponds" SET "capacity" = $1, "depth" = $2, "id" = $3, "name" = $4, "species" = $5, "status" = $6, "updated_at" = $7, "water_quality" = $8 WHERE "ponds"."deleted_at" IS NULL AND "ponds"."id" = $9 AND ((name = $10 AND id = $11 and status = $12))`)).WillReturnError(fmt.Errorf("some error"))
+				mockDB.ExpectExec(regexp.QuoteMeta(`UPDATE "ponds" SET "capacity" = $1, "depth" = $2, "id" = $3, "name" = $4, "species" = $5, "status" = $6, "updated_at" = $7, "water_quality" = $8 WHERE "ponds"."deleted_at" IS NULL AND "ponds"."id" = $9 AND ((id = $10 and status = $11))`)).WillReturnError(fmt.Errorf("some error"))
 			},
 			r: &PondInfraInfo{
 				ID:           1,
@@ -410,7 +527,7 @@ func TestPond_Update(t *testing.T) {
 		{
 			name: "nil db",
 			mockFunc: func() {
-				pg.EXPECT().GetDB().Return(nil)
+				pg.EXPECT().GetDB(gomock.Any()).Return(nil)
 			},
 			r: &PondInfraInfo{
 				ID: 1,
@@ -420,7 +537,7 @@ func TestPond_Update(t *testing.T) {
 		{
 			name: "req nil",
 			mockFunc: func() {
-				pg.EXPECT().GetDB().Return(gormDB)
+				pg.EXPECT().GetDB(gomock.Any()).Return(gormDB)
 			},
 
 			wantErr: true,
@@ -430,7 +547,7 @@ func TestPond_Update(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockFunc()
 			s := NewPondStore(pg)
-			if err := s.Update(tt.r); (err != nil) != tt.wantErr {
+			if err := s.Update(context.Background(), tt.r); (err != nil) != tt.wantErr {
 				t.Errorf("Pond.Update() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -453,7 +570,7 @@ func TestPond_Delete(t *testing.T) {
 		{
 			name: "success",
 			mockFunc: func() {
-				pg.EXPECT().GetDB().Return(gormDB)
+				pg.EXPECT().GetDB(gomock.Any()).Return(gormDB)
 				mockDB.ExpectBegin()
 				mockDB.ExpectExec(regexp.QuoteMeta(`UPDATE "ponds" SET "status" = $1, "updated_at" = $2 WHERE "ponds"."deleted_at" IS NULL AND "ponds"."id" = $3 AND ((name = $4 AND id = $5 and status = $6))`)).WillReturnResult(sqlmock.NewResult(1, 1))
 				mockDB.ExpectCommit()
@@ -467,7 +584,7 @@ func TestPond_Delete(t *testing.T) {
 		{
 			name: "got error exec",
 			mockFunc: func() {
-				pg.EXPECT().GetDB().Return(gormDB)
+				pg.EXPECT().GetDB(gomock.Any()).Return(gormDB)
 				mockDB.ExpectBegin()
 				mockDB.ExpectExec(regexp.QuoteMeta(`UPDATE "ponds" SET "status" = $1, "updated_at" = $2 WHERE "ponds"."deleted_at" IS NULL AND "ponds"."id" = $3 AND ((name = $4 AND id = $5 and status = $6))`)).WillReturnError(fmt.Errorf("some error"))
 			},
@@ -479,7 +596,7 @@ func TestPond_Delete(t *testing.T) {
 		{
 			name: "nil db",
 			mockFunc: func() {
-				pg.EXPECT().GetDB().Return(nil)
+				pg.EXPECT().GetDB(gomock.Any()).Return(nil)
 			},
 			r: &PondInfraInfo{
 				ID: 1,
@@ -489,7 +606,7 @@ func TestPond_Delete(t *testing.T) {
 		{
 			name: "req nil",
 			mockFunc: func() {
-				pg.EXPECT().GetDB().Return(gormDB)
+				pg.EXPECT().GetDB(gomock.Any()).Return(gormDB)
 			},
 
 			wantErr: true,
@@ -499,7 +616,7 @@ func TestPond_Delete(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockFunc()
 			s := NewPondStore(pg)
-			if err := s.Delete(tt.r); (err != nil) != tt.wantErr {
+			if err := s.Delete(context.Background(), tt.r); (err != nil) != tt.wantErr {
 				t.Errorf("Pond.Delete() error = %v, wantErr %v", err, tt.wantErr)
 			}
 		})
@@ -560,7 +677,7 @@ func TestPond_GetPondWithPaging(t *testing.T) {
 				},
 			},
 			mockFunc: func() {
-				pg.EXPECT().GetDB().Return(gormDB)
+				pg.EXPECT().GetDB(gomock.Any()).Return(gormDB)
 				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT ponds.id, ponds.name, ponds.capacity, ponds.depth, ponds.water_quality, ponds.species, farm_ponds_mappings.farm_id FROM "ponds" left join farm_ponds_mappings on farm_ponds_mappings.ponds_id = ponds.id WHERE (status = $1) LIMIT 2 OFFSET 0`)).WillReturnRows(expectedRows)
 			},
 
@@ -594,7 +711,7 @@ func TestPond_GetPondWithPaging(t *testing.T) {
 				},
 			},
 			mockFunc: func() {
-				pg.EXPECT().GetDB().Return(nil)
+				pg.EXPECT().GetDB(gomock.Any()).Return(nil)
 			},
 			wantErr: true,
 		},
@@ -603,7 +720,7 @@ func TestPond_GetPondWithPaging(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockFunc()
 			s := NewPondStore(pg)
-			got, err := s.GetPondWithPaging(tt.args.r)
+			got, err := s.GetPondWithPaging(context.Background(), tt.args.r)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Pond.GetPondWithPaging() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -615,6 +732,79 @@ func TestPond_GetPondWithPaging(t *testing.T) {
 	}
 }
 
+func TestPond_GetPondsByIDs(t *testing.T) {
+	db, mockDB, gormDB := InitDBsMockupStat()
+	defer db.Close()
+	defer gormDB.Close()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	pg := mock_postgres.NewMockPostgresMethod(mockCtrl)
+	tests := []struct {
+		name     string
+		ids      []uint
+		mockFunc func()
+		want     []PondInfraInfo
+		wantErr  bool
+	}{
+		{
+			name: "success",
+			ids:  []uint{1, 2, 3},
+			mockFunc: func() {
+				pg.EXPECT().GetDB(gomock.Any()).Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT ponds.id, ponds.name, ponds.capacity, ponds.depth, ponds.water_quality, ponds.species, farm_ponds_mappings.farm_id FROM "ponds" left join farm_ponds_mappings on farm_ponds_mappings.ponds_id = ponds.id and farm_ponds_mappings.deleted_at is null WHERE (ponds.id in ($1,$2,$3) and ponds.status = $4 and ponds.deleted_at is null) ORDER BY "ponds"."id"`)).
+					WithArgs(1, 2, 3, model.Active.Value()).
+					WillReturnRows(sqlmock.NewRows([]string{"id", "name", "capacity", "depth", "water_quality", "species", "farm_id"}).
+						AddRow(1, "1", 1, 1, 1, "1", 1).
+						AddRow(2, "2", 2, 2, 2, "2", 1))
+			},
+			want: []PondInfraInfo{
+				{ID: 1, Name: "1", Capacity: 1, Depth: 1, WaterQuality: 1, Species: "1", FarmID: 1},
+				{ID: 2, Name: "2", Capacity: 2, Depth: 2, WaterQuality: 2, Species: "2", FarmID: 1},
+			},
+		},
+		{
+			name: "empty ids does not query",
+			mockFunc: func() {
+				pg.EXPECT().GetDB(gomock.Any()).Return(gormDB)
+			},
+		},
+		{
+			name: "query error",
+			ids:  []uint{1},
+			mockFunc: func() {
+				pg.EXPECT().GetDB(gomock.Any()).Return(gormDB)
+				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT ponds.id`)).WillReturnError(fmt.Errorf("some error"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "db not init",
+			ids:  []uint{1},
+			mockFunc: func() {
+				pg.EXPECT().GetDB(gomock.Any()).Return(nil)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			s := NewPondStore(pg)
+			got, err := s.GetPondsByIDs(context.Background(), tt.ids)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Pond.GetPondsByIDs() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Pond.GetPondsByIDs() = %v, want %v", got, tt.want)
+			}
+			if err := mockDB.ExpectationsWereMet(); err != nil {
+				t.Errorf("Pond.GetPondsByIDs() query = %v", err)
+			}
+		})
+	}
+}
+
 func TestPond_Verify(t *testing.T) {
 	var pond1 = &postgres.Ponds{
 		Model: gorm.Model{
@@ -649,7 +839,7 @@ func TestPond_Verify(t *testing.T) {
 		{
 			name: "success",
 			mockFunc: func() {
-				pg.EXPECT().GetDB().Return(gormDB)
+				pg.EXPECT().GetDB(gomock.Any()).Return(gormDB)
 				mockDB.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "ponds"  WHERE "ponds"."deleted_at" IS NULL AND ((name = $1 AND Status = $2)) ORDER BY "ponds"."id" ASC LIMIT 1`)).WillReturnRows(expectedRows)
 			},
 			args: args{
@@ -663,14 +853,14 @@ func TestPond_Verify(t *testing.T) {
 		{
 			name: "nil request",
 			mockFunc: func() {
-				pg.EXPECT().GetDB().Return(gormDB)
+				pg.EXPECT().GetDB(gomock.Any()).Return(gormDB)
 			},
 			wantErr: true,
 		},
 		{
 			name: "nil db",
 			mockFunc: func() {
-				pg.EXPECT().GetDB().Return(nil)
+				pg.EXPECT().GetDB(gomock.Any()).Return(nil)
 			},
 			args: args{
 				r: &PondInfraInfo{
@@ -684,7 +874,7 @@ func TestPond_Verify(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.mockFunc()
 			s := NewPondStore(pg)
-			got, err := s.Verify(tt.args.r)
+			got, err := s.Verify(context.Background(), tt.args.r)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("Pond.Verify() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -695,3 +885,58 @@ func TestPond_Verify(t *testing.T) {
 		})
 	}
 }
+
+func TestPond_CountActivePonds(t *testing.T) {
+	db, mockDB, gormDB := InitDBsMockupStat()
+	defer db.Close()
+	defer gormDB.Close()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	pg := mock_postgres.NewMockPostgresMethod(mockCtrl)
+	query := regexp.QuoteMeta(`SELECT count(*) FROM "ponds" WHERE "ponds"."deleted_at" IS NULL AND ((status = $1))`)
+	tests := []struct {
+		name     string
+		mockFunc func()
+		wantErr  bool
+		want     int
+	}{
+		{
+			name: "success",
+			mockFunc: func() {
+				pg.EXPECT().GetDB(gomock.Any()).Return(gormDB)
+				mockDB.ExpectQuery(query).WithArgs(1).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(7))
+			},
+			wantErr: false,
+			want:    7,
+		},
+		{
+			name: "error query",
+			mockFunc: func() {
+				pg.EXPECT().GetDB(gomock.Any()).Return(gormDB)
+				mockDB.ExpectQuery(query).WillReturnError(fmt.Errorf("some error"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "nil db",
+			mockFunc: func() {
+				pg.EXPECT().GetDB(gomock.Any()).Return(nil)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			s := NewPondStore(pg)
+			got, err := s.CountActivePonds(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Pond.CountActivePonds() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("Pond.CountActivePonds() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,5 +1,10 @@
 package pond
 
+import (
+	"aqua-farm-manager/internal/infrastructure/outbox"
+	"time"
+)
+
 // PondInfraInfo struct is list parameter from Ponds Storage
 type PondInfraInfo struct {
 	ID           uint
@@ -9,6 +14,24 @@ type PondInfraInfo struct {
 	WaterQuality float64
 	Species      string
 	FarmID       uint
+	// Events is list domain event stored in the same transaction of Create, Update and Delete
+	Events []outbox.Event
+}
+
+// ExportPondInfo struct is list parameter of a pond row that is streamed by Export,
+// the farm is from FarmPondsMapping and it is empty when the pond is not mapped into any farm
+type ExportPondInfo struct {
+	ID           uint
+	Name         string
+	Capacity     float64
+	Depth        float64
+	WaterQuality float64
+	Species      string
+	Status       int
+	FarmID       uint
+	FarmName     string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
 }
 
 // FarmPondsMapping is list parameter to store Ponds Farms Mapping Information
@@ -0,0 +1,175 @@
+package stat
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"aqua-farm-manager/internal/model"
+	"aqua-farm-manager/pkg/memorydb"
+	"aqua-farm-manager/pkg/postgres"
+
+	"github.com/jinzhu/gorm"
+)
+
+// MemoryStat is in memory stat store for local development and test without redis and postgres,
+// the delta that is not yet flushed is kept in the store and the flushed stat is kept in memorydb
+type MemoryStat struct {
+	db *memorydb.DB
+
+	mu     sync.Mutex
+	deltas map[string]map[string]int
+	agents map[string]struct{}
+	batch  int64
+}
+
+// NewMemoryStatStore is func to generate in memory StatStore interface
+func NewMemoryStatStore(db *memorydb.DB) StatStore {
+	return &MemoryStat{
+		db:     db,
+		deltas: make(map[string]map[string]int),
+		agents: make(map[string]struct{}),
+	}
+}
+
+// IngestMetrics is func to ingest api metrics into delta, uniq agent counter only increase for new ua
+func (s *MemoryStat) IngestMetrics(ctx context.Context, r IngestMetricsRequest) error {
+	if r.UrlID == "" || r.Method == "" || r.UA == "" {
+		return ErrInvalidMetrics
+	}
+
+	deltaKey := generateKeyMetrics(DeltaKeyMetrics, r.UrlID, r.Method)
+	uakey := generateUAKeyMetrics(r.UrlID, r.Method, r.UA)
+
+	countResult := CountError
+	if r.IsSuccess {
+		countResult = CountSuccess
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delta, ok := s.deltas[deltaKey]
+	if !ok {
+		delta = make(map[string]int)
+		s.deltas[deltaKey] = delta
+	}
+	if _, ok := s.agents[uakey]; !ok {
+		s.agents[uakey] = struct{}{}
+		delta[CountUA]++
+	}
+	delta[CountRequested]++
+	delta[countResult]++
+	return nil
+}
+
+// GetMetrics is func to get api metrics that not yet flushed
+func (s *MemoryStat) GetMetrics(ctx context.Context, r GetMetricsRequest) (MetricsInfo, error) {
+	deltaKey := generateKeyMetrics(DeltaKeyMetrics, r.UrlID, r.Method)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delta := s.deltas[deltaKey]
+	return MetricsInfo{
+		NumRequest:   strconv.Itoa(delta[CountRequested]),
+		NumUniqAgent: strconv.Itoa(delta[CountUA]),
+		NumSuccess:   strconv.Itoa(delta[CountSuccess]),
+		NumError:     strconv.Itoa(delta[CountError]),
+	}, nil
+}
+
+// BackupMetrics is func to add the delta into the active stat row and reset the delta
+func (s *MemoryStat) BackupMetrics(ctx context.Context, r BackupMetricsRequest) error {
+	pathKey := generatePathKeyMetrics(r.UrlID, r.Method)
+	deltaKey := generateKeyMetrics(DeltaKeyMetrics, r.UrlID, r.Method)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delta, ok := s.deltas[deltaKey]
+	if !ok {
+		return nil
+	}
+
+	now := time.Now()
+	s.batch++
+	err := s.db.Update(func(t *memorydb.Tables) error {
+		stat := findStat(t, pathKey)
+		if stat == nil {
+			stat = &postgres.StatMetrics{
+				Model: gorm.Model{
+					ID:        t.NextID("stat_metrics"),
+					CreatedAt: now,
+				},
+				Key:    pathKey,
+				Status: model.Active.Value(),
+			}
+			t.StatMetrics = append(t.StatMetrics, stat)
+		}
+
+		stat.Request += delta[CountRequested]
+		stat.UniqAgent += delta[CountUA]
+		stat.NumSuccess += delta[CountSuccess]
+		stat.NumError += delta[CountError]
+		stat.LastBatch = s.batch
+		stat.UpdatedAt = now
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	delete(s.deltas, deltaKey)
+	return nil
+}
+
+// CompactMetrics is func to remove inactive stat rows
+func (s *MemoryStat) CompactMetrics(ctx context.Context) error {
+	return s.db.Update(func(t *memorydb.Tables) error {
+		stats := t.StatMetrics[:0]
+		for _, stat := range t.StatMetrics {
+			if stat.Status != model.Inactive.Value() {
+				stats = append(stats, stat)
+			}
+		}
+		for i := len(stats); i < len(t.StatMetrics); i++ {
+			t.StatMetrics[i] = nil
+		}
+		t.StatMetrics = stats
+		return nil
+	})
+}
+
+// GetStatData is func to get flushed metrics of active stat row
+func (s *MemoryStat) GetStatData(ctx context.Context, r GetStatDataRequest) (MetricsInfo, error) {
+	pathKey := generatePathKeyMetrics(r.UrlID, r.Method)
+
+	info := MetricsInfo{"0", "0", "0", "0"}
+	err := s.db.View(func(t *memorydb.Tables) error {
+		stat := findStat(t, pathKey)
+		if stat == nil {
+			return gorm.ErrRecordNotFound
+		}
+
+		info = MetricsInfo{
+			NumRequest:   strconv.Itoa(stat.Request),
+			NumUniqAgent: strconv.Itoa(stat.UniqAgent),
+			NumSuccess:   strconv.Itoa(stat.NumSuccess),
+			NumError:     strconv.Itoa(stat.NumError),
+		}
+		return nil
+	})
+	return info, err
+}
+
+// findStat is func to get active stat row by key, it return nil when the row does not exist
+func findStat(t *memorydb.Tables, key string) *postgres.StatMetrics {
+	for _, stat := range t.StatMetrics {
+		if stat.Key == key && stat.Status == model.Active.Value() && stat.DeletedAt == nil {
+			return stat
+		}
+	}
+	return nil
+}
@@ -6,6 +6,7 @@ package mock_stat
 
 import (
 	stat "aqua-farm-manager/internal/infrastructure/stat"
+	context "context"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -35,73 +36,73 @@ func (m *MockStatStore) EXPECT() *MockStatStoreMockRecorder {
 }
 
 // BackupMetrics mocks base method.
-func (m *MockStatStore) BackupMetrics(arg0 stat.BackupMetricsRequest) error {
+func (m *MockStatStore) BackupMetrics(arg0 context.Context, arg1 stat.BackupMetricsRequest) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "BackupMetrics", arg0)
+	ret := m.ctrl.Call(m, "BackupMetrics", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // BackupMetrics indicates an expected call of BackupMetrics.
-func (mr *MockStatStoreMockRecorder) BackupMetrics(arg0 interface{}) *gomock.Call {
+func (mr *MockStatStoreMockRecorder) BackupMetrics(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BackupMetrics", reflect.TypeOf((*MockStatStore)(nil).BackupMetrics), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BackupMetrics", reflect.TypeOf((*MockStatStore)(nil).BackupMetrics), arg0, arg1)
+}
+
+// CompactMetrics mocks base method.
+func (m *MockStatStore) CompactMetrics(arg0 context.Context) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CompactMetrics", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CompactMetrics indicates an expected call of CompactMetrics.
+func (mr *MockStatStoreMockRecorder) CompactMetrics(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompactMetrics", reflect.TypeOf((*MockStatStore)(nil).CompactMetrics), arg0)
 }
 
 // GetMetrics mocks base method.
-func (m *MockStatStore) GetMetrics(arg0 stat.GetMetricsRequest) (stat.MetricsInfo, error) {
+func (m *MockStatStore) GetMetrics(arg0 context.Context, arg1 stat.GetMetricsRequest) (stat.MetricsInfo, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetMetrics", arg0)
+	ret := m.ctrl.Call(m, "GetMetrics", arg0, arg1)
 	ret0, _ := ret[0].(stat.MetricsInfo)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetMetrics indicates an expected call of GetMetrics.
-func (mr *MockStatStoreMockRecorder) GetMetrics(arg0 interface{}) *gomock.Call {
+func (mr *MockStatStoreMockRecorder) GetMetrics(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMetrics", reflect.TypeOf((*MockStatStore)(nil).GetMetrics), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMetrics", reflect.TypeOf((*MockStatStore)(nil).GetMetrics), arg0, arg1)
 }
 
 // GetStatData mocks base method.
-func (m *MockStatStore) GetStatData(arg0 stat.GetStatDataRequest) (stat.MetricsInfo, error) {
+func (m *MockStatStore) GetStatData(arg0 context.Context, arg1 stat.GetStatDataRequest) (stat.MetricsInfo, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetStatData", arg0)
+	ret := m.ctrl.Call(m, "GetStatData", arg0, arg1)
 	ret0, _ := ret[0].(stat.MetricsInfo)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetStatData indicates an expected call of GetStatData.
-func (mr *MockStatStoreMockRecorder) GetStatData(arg0 interface{}) *gomock.Call {
+func (mr *MockStatStoreMockRecorder) GetStatData(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatData", reflect.TypeOf((*MockStatStore)(nil).GetStatData), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStatData", reflect.TypeOf((*MockStatStore)(nil).GetStatData), arg0, arg1)
 }
 
 // IngestMetrics mocks base method.
-func (m *MockStatStore) IngestMetrics(arg0 stat.IngestMetricsRequest) error {
+func (m *MockStatStore) IngestMetrics(arg0 context.Context, arg1 stat.IngestMetricsRequest) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "IngestMetrics", arg0)
+	ret := m.ctrl.Call(m, "IngestMetrics", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // IngestMetrics indicates an expected call of IngestMetrics.
-func (mr *MockStatStoreMockRecorder) IngestMetrics(arg0 interface{}) *gomock.Call {
-	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IngestMetrics", reflect.TypeOf((*MockStatStore)(nil).IngestMetrics), arg0)
-}
-
-// MigrateMetrics mocks base method.
-func (m *MockStatStore) MigrateMetrics(arg0 stat.MigrateMetricsRequest) error {
-	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "MigrateMetrics", arg0)
-	ret0, _ := ret[0].(error)
-	return ret0
-}
-
-// MigrateMetrics indicates an expected call of MigrateMetrics.
-func (mr *MockStatStoreMockRecorder) MigrateMetrics(arg0 interface{}) *gomock.Call {
+func (mr *MockStatStoreMockRecorder) IngestMetrics(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MigrateMetrics", reflect.TypeOf((*MockStatStore)(nil).MigrateMetrics), arg0)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IngestMetrics", reflect.TypeOf((*MockStatStore)(nil).IngestMetrics), arg0, arg1)
 }
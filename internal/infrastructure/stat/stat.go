@@ -1,12 +1,12 @@
 package stat
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"log"
 	"strconv"
 	"strings"
-	"sync"
+	"time"
 
 	"aqua-farm-manager/internal/model"
 	"aqua-farm-manager/pkg/postgres"
@@ -15,23 +15,106 @@ import (
 	"github.com/jinzhu/gorm"
 )
 
+// PathKeyMetrics is the stat_metrics key in postgres, redis only keep the delta that not yet flushed
+// into DeltaKeyMetrics and the batch that is being flushed into FlushKeyMetrics
 var (
-	PathKeyMetrics = "P:<urlID>:<method>"
-	UAKeyMetrics   = "P:<urlID>:<method>:<ua>"
+	PathKeyMetrics  = "P:<urlID>:<method>"
+	UAKeyMetrics    = "P:<urlID>:<method>:<ua>"
+	DeltaKeyMetrics = "D:<urlID>:<method>"
+	FlushKeyMetrics = "F:<urlID>:<method>"
+	SeqKeyMetrics   = "S:<urlID>:<method>"
 
 	CountUA        = "Count_UA"
 	CountRequested = "Count_Req"
 	CountSuccess   = "Count_Success"
 	CountError     = "Count_Error"
+	BatchID        = "Batch_ID"
+
+	// LegacyPrefix is prefix of the batch field that hold the total of the path key in redis,
+	// the path key was the counter of the previous backup that wrote the total into a new stat row
+	LegacyPrefix = "Legacy_"
 )
 
+// ingestMetricsScript mark the ua key and increase the delta counters in a single round-trip,
+// uniq agent counter only increase when the ua key is new
+//
+// KEYS[1] delta key, KEYS[2] ua key
+// ARGV[1] uniq agent field, ARGV[2] requested field, ARGV[3] success or error field
+const ingestMetricsScript = `
+local isNew = redis.call('SETNX', KEYS[2], 1)
+if isNew == 1 then
+	redis.call('HINCRBY', KEYS[1], ARGV[1], 1)
+end
+redis.call('HINCRBY', KEYS[1], ARGV[2], 1)
+redis.call('HINCRBY', KEYS[1], ARGV[3], 1)
+return isNew
+`
+
+// claimMetricsScript move the delta into the flush key and stamp it with a new batch id,
+// when the previous flush was not acknowledged the same batch is returned again so it can be retried.
+// batch id is max(seq+1, now in millis) so it keep increasing even after redis lost the seq key.
+// The total that is left in the path key by the previous backup is folded into the new batch
+// with the legacy prefix and the path key is removed, so it is folded only once
+//
+// KEYS[1] delta key, KEYS[2] flush key, KEYS[3] seq key, KEYS[4] path key
+// ARGV[1] batch id field, ARGV[2] now in millis, ARGV[3] legacy prefix
+const claimMetricsScript = `
+if redis.call('EXISTS', KEYS[2]) == 0 then
+	local legacy = {}
+	if redis.call('TYPE', KEYS[4]).ok == 'hash' then
+		legacy = redis.call('HGETALL', KEYS[4])
+	end
+	if redis.call('EXISTS', KEYS[1]) == 0 and #legacy == 0 then
+		return {}
+	end
+	local batch = redis.call('INCR', KEYS[3])
+	if batch < tonumber(ARGV[2]) then
+		batch = tonumber(ARGV[2])
+		redis.call('SET', KEYS[3], batch)
+	end
+	if redis.call('EXISTS', KEYS[1]) == 1 then
+		redis.call('RENAME', KEYS[1], KEYS[2])
+	end
+	for i = 1, #legacy, 2 do
+		redis.call('HSET', KEYS[2], ARGV[3] .. legacy[i], legacy[i + 1])
+	end
+	redis.call('DEL', KEYS[4])
+	redis.call('HSET', KEYS[2], ARGV[1], batch)
+end
+return redis.call('HGETALL', KEYS[2])
+`
+
+// ackMetricsScript remove the flush key only when it still hold the flushed batch
+//
+// KEYS[1] flush key
+// ARGV[1] batch id field, ARGV[2] batch id
+const ackMetricsScript = `
+if redis.call('HGET', KEYS[1], ARGV[1]) == ARGV[2] then
+	return redis.call('DEL', KEYS[1])
+end
+return 0
+`
+
+// upsertStatQuery add the batch into the active stat row, the batch is skipped
+// when it already applied so retrying the same batch never double count
+const upsertStatQuery = `INSERT INTO stat_metrics (created_at, updated_at, key, request, uniq_agent, num_success, num_error, status, last_batch)
+VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+ON CONFLICT (key) WHERE status = 1 AND deleted_at IS NULL DO UPDATE SET
+	request = stat_metrics.request + EXCLUDED.request,
+	uniq_agent = stat_metrics.uniq_agent + EXCLUDED.uniq_agent,
+	num_success = stat_metrics.num_success + EXCLUDED.num_success,
+	num_error = stat_metrics.num_error + EXCLUDED.num_error,
+	last_batch = EXCLUDED.last_batch,
+	updated_at = EXCLUDED.updated_at
+WHERE stat_metrics.last_batch < EXCLUDED.last_batch`
+
 // StatStore is set of methods for interacting with a metric storage system
 type StatStore interface {
-	IngestMetrics(IngestMetricsRequest) error
-	GetMetrics(GetMetricsRequest) (MetricsInfo, error)
-	BackupMetrics(BackupMetricsRequest) error
-	MigrateMetrics(MigrateMetricsRequest) error
-	GetStatData(GetStatDataRequest) (MetricsInfo, error)
+	IngestMetrics(context.Context, IngestMetricsRequest) error
+	GetMetrics(context.Context, GetMetricsRequest) (MetricsInfo, error)
+	BackupMetrics(context.Context, BackupMetricsRequest) error
+	CompactMetrics(context.Context) error
+	GetStatData(context.Context, GetStatDataRequest) (MetricsInfo, error)
 }
 
 // Stat is list dependencies stat store
@@ -48,55 +131,42 @@ func NewStatStore(redis redis.RedisMethod, pg postgres.PostgresMethod) StatStore
 	}
 }
 
-// IngestMetrics is func to ingest api metrics to redis
-func (s *Stat) IngestMetrics(r IngestMetricsRequest) error {
-	var err error
-	pathKey := generatePathKeyMetrics(r.UrlID, r.Method)
-	uakey := generateUAKeyMetrics(r.UrlID, r.Method, r.UA)
+// ErrInvalidMetrics is error for metrics request that never can be stored
+var ErrInvalidMetrics = errors.New("invalid metrics request")
 
-	// set key to redis, if the key is exists it's not uniq
-	isNew, err := s.redis.SETNX(uakey)
-	if err != nil {
-		return err
+// IsTransientError is func to check store error may succeed when it retried,
+// invalid request and error reply from redis server is not transient
+func IsTransientError(err error) bool {
+	if err == nil || errors.Is(err, ErrInvalidMetrics) || redis.IsReplyError(err) {
+		return false
 	}
+	return true
+}
 
-	wg := sync.WaitGroup{}
-	// incr count uniq ua if is new
-	if isNew {
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			s.redis.HINCRBY(pathKey, CountUA)
-		}()
+// IngestMetrics is func to ingest api metrics to redis
+func (s *Stat) IngestMetrics(ctx context.Context, r IngestMetricsRequest) error {
+	if r.UrlID == "" || r.Method == "" || r.UA == "" {
+		return ErrInvalidMetrics
 	}
 
-	// incr count requested
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		s.redis.HINCRBY(pathKey, CountRequested)
-	}()
-
-	wg.Add(1)
-	go func() {
-		defer wg.Done()
-		if r.IsSuccess {
-			s.redis.HINCRBY(pathKey, CountSuccess)
-		} else {
-			s.redis.HINCRBY(pathKey, CountError)
-		}
-	}()
+	deltaKey := generateKeyMetrics(DeltaKeyMetrics, r.UrlID, r.Method)
+	uakey := generateUAKeyMetrics(r.UrlID, r.Method, r.UA)
 
-	wg.Wait()
+	countResult := CountError
+	if r.IsSuccess {
+		countResult = CountSuccess
+	}
+
+	_, err := s.redis.Eval(ctx, ingestMetricsScript, []string{deltaKey, uakey}, CountUA, CountRequested, countResult)
 	return err
 }
 
-// GetMetrics is func to get api metrics from redis
-func (s *Stat) GetMetrics(r GetMetricsRequest) (MetricsInfo, error) {
+// GetMetrics is func to get api metrics from redis that not yet flushed to postgres
+func (s *Stat) GetMetrics(ctx context.Context, r GetMetricsRequest) (MetricsInfo, error) {
 	var err error
-	pathKey := generatePathKeyMetrics(r.UrlID, r.Method)
+	deltaKey := generateKeyMetrics(DeltaKeyMetrics, r.UrlID, r.Method)
 
-	metrics, err := s.redis.HGETALL(pathKey)
+	metrics, err := s.redis.HGETALL(ctx, deltaKey)
 	if err != nil {
 		return MetricsInfo{"0", "0", "0", "0"}, err
 	}
@@ -130,83 +200,89 @@ func (s *Stat) GetMetrics(r GetMetricsRequest) (MetricsInfo, error) {
 	}, nil
 }
 
-// BackupMetrics is func to backup metrics from redis to postgres
-func (s *Stat) BackupMetrics(r BackupMetricsRequest) error {
-	var err error
+// BackupMetrics is func to flush metrics delta from redis into postgres.
+// The delta is claimed as a batch, added into postgres and acknowledged,
+// a crash between those steps leave the batch in redis to be retried on the next backup.
+// The legacy total of the batch is only added by the part that the active stat row does not have yet,
+// the previous backup already wrote the total of the path key up to its last run
+func (s *Stat) BackupMetrics(ctx context.Context, r BackupMetricsRequest) error {
 	pathKey := generatePathKeyMetrics(r.UrlID, r.Method)
-	stat := postgres.StatMetrics{
-		Key:        pathKey,
-		Request:    r.Metrics.NumRequest,
-		UniqAgent:  r.Metrics.NumUniqAgent,
-		NumSuccess: r.Metrics.NumSuccess,
-		NumError:   r.Metrics.NumError,
-	}
+	deltaKey := generateKeyMetrics(DeltaKeyMetrics, r.UrlID, r.Method)
+	flushKey := generateKeyMetrics(FlushKeyMetrics, r.UrlID, r.Method)
+	seqKey := generateKeyMetrics(SeqKeyMetrics, r.UrlID, r.Method)
 
-	db := s.pg.GetDB()
+	db := s.pg.GetDB(ctx)
 	if db == nil {
 		return errors.New("Database Client is not init")
 	}
 
-	err = updateStat(db, &stat)
+	now := time.Now()
+	reply, err := s.redis.Eval(ctx, claimMetricsScript, []string{deltaKey, flushKey, seqKey, pathKey}, BatchID, now.UnixNano()/int64(time.Millisecond), LegacyPrefix)
 	if err != nil {
 		return err
 	}
-	stat.Status = model.Active.Value()
-	err = insert(db, &stat)
 
-	return err
-}
+	batch, err := toStringMap(reply)
+	if err != nil {
+		return err
+	}
 
-// MigrateMetrics is func to migrate metrics from postgres to redis
-func (s *Stat) MigrateMetrics(r MigrateMetricsRequest) error {
-	var errUA, errReq, errSuc, errEr error
-	key := generatePathKeyMetrics(r.UrlID, r.Method)
-	var wg sync.WaitGroup
-
-	wg.Add(4)
-	go func() {
-		defer wg.Done()
-		errUA = s.redis.HSET(key, CountUA, r.Metrics.NumUniqAgent)
-		if errUA != nil {
-			log.Println("MigrateMetrics-Error Ingest Uniq Agent :", errUA)
-		}
-	}()
+	if len(batch) == 0 {
+		return nil
+	}
 
-	go func() {
-		defer wg.Done()
-		errReq = s.redis.HSET(key, CountRequested, r.Metrics.NumRequest)
-		if errReq != nil {
-			log.Println("MigrateMetrics-Error Ingest Requested :", errReq)
-		}
-	}()
+	batchID, err := strconv.ParseInt(batch[BatchID], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid batch id %s: %v", batch[BatchID], err)
+	}
 
-	go func() {
-		defer wg.Done()
-		errEr = s.redis.HSET(key, CountError, r.Metrics.NumError)
-		if errEr != nil {
-			log.Println("MigrateMetrics-Error Ingest Requested :", errReq)
-		}
-	}()
+	numReq, _ := strconv.Atoi(batch[CountRequested])
+	numUA, _ := strconv.Atoi(batch[CountUA])
+	numSuc, _ := strconv.Atoi(batch[CountSuccess])
+	numErr, _ := strconv.Atoi(batch[CountError])
+	stat := &postgres.StatMetrics{
+		Model: gorm.Model{
+			CreatedAt: now,
+			UpdatedAt: now,
+		},
+		Key:        pathKey,
+		Request:    numReq,
+		UniqAgent:  numUA,
+		NumSuccess: numSuc,
+		NumError:   numErr,
+		Status:     model.Active.Value(),
+		LastBatch:  batchID,
+	}
 
-	go func() {
-		defer wg.Done()
-		errSuc = s.redis.HSET(key, CountSuccess, r.Metrics.NumSuccess)
-		if errSuc != nil {
-			log.Println("MigrateMetrics-Error Ingest Requested :", errReq)
+	if hasLegacy(batch) {
+		err = addLegacyTotal(db, stat, batch)
+		if err != nil {
+			return err
 		}
-	}()
+	}
+
+	err = upsertStat(db, stat)
+	if err != nil {
+		return err
+	}
 
-	wg.Wait()
+	_, err = s.redis.Eval(ctx, ackMetricsScript, []string{flushKey}, BatchID, batch[BatchID])
+	return err
+}
 
-	if errUA != nil || errReq != nil || errEr != nil || errSuc != nil {
-		return fmt.Errorf("got error while migrate")
+// CompactMetrics is func to remove inactive stat rows from postgres,
+// the previous backup kept the row of every backup as inactive history of the active row
+func (s *Stat) CompactMetrics(ctx context.Context) error {
+	db := s.pg.GetDB(ctx)
+	if db == nil {
+		return errors.New("Database Client is not init")
 	}
 
-	return nil
+	return deleteInactiveStat(db)
 }
 
 // GetStatData is func to metrics from postgres
-func (s *Stat) GetStatData(r GetStatDataRequest) (MetricsInfo, error) {
+func (s *Stat) GetStatData(ctx context.Context, r GetStatDataRequest) (MetricsInfo, error) {
 	var err error
 	pathKey := generatePathKeyMetrics(r.UrlID, r.Method)
 
@@ -214,7 +290,7 @@ func (s *Stat) GetStatData(r GetStatDataRequest) (MetricsInfo, error) {
 		Key: pathKey,
 	}
 
-	db := s.pg.GetDB()
+	db := s.pg.GetDB(ctx)
 	if db == nil {
 		return MetricsInfo{"0", "0", "0", "0"}, errors.New("Database Client is not init")
 	}
@@ -237,7 +313,10 @@ func (s *Stat) GetStatData(r GetStatDataRequest) (MetricsInfo, error) {
 }
 
 func generatePathKeyMetrics(urlID string, method string) string {
-	key := PathKeyMetrics
+	return generateKeyMetrics(PathKeyMetrics, urlID, method)
+}
+
+func generateKeyMetrics(key string, urlID string, method string) string {
 	key = strings.Replace(key, "<urlID>", urlID, -1)
 	key = strings.Replace(key, "<method>", method, -1)
 	return key
@@ -251,16 +330,61 @@ func generateUAKeyMetrics(urlID string, method string, ua string) string {
 	return key
 }
 
-// checkStatExists is func to check if the data is stat exist
-func checkStatExists(db *gorm.DB, stat postgres.StatMetrics) bool {
-	var count = int64(0)
-	db.Model(stat).Where("key = ?", stat.Key).Count(&count).Limit(1)
-	return count > 0
+// toStringMap is func to convert HGETALL reply of lua script into map
+func toStringMap(reply interface{}) (map[string]string, error) {
+	values, ok := reply.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unexpected reply type %T", reply)
+	}
+
+	if len(values)%2 != 0 {
+		return nil, errors.New("expects even number of values")
+	}
+
+	result := make(map[string]string, len(values)/2)
+	for i := 0; i < len(values); i += 2 {
+		key, okKey := values[i].([]byte)
+		value, okValue := values[i+1].([]byte)
+		if !okKey || !okValue {
+			return nil, errors.New("expects bulk string values")
+		}
+		result[string(key)] = string(value)
+	}
+
+	return result, nil
 }
 
-// insert is func to insert data into table
-func insert(db *gorm.DB, stat *postgres.StatMetrics) error {
-	return db.Create(stat).Error
+// hasLegacy is func to check the batch hold the legacy total of the path key
+func hasLegacy(batch map[string]string) bool {
+	for field := range batch {
+		if strings.HasPrefix(field, LegacyPrefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// addLegacyTotal is func to add the part of the legacy total that is not in the active stat row into the batch.
+// Retrying the batch after it is applied add nothing because the active row already has the legacy total
+func addLegacyTotal(db *gorm.DB, stat *postgres.StatMetrics, batch map[string]string) error {
+	active := &postgres.StatMetrics{Key: stat.Key}
+	err := getStatRecodByKey(db, active)
+	if err != nil && !gorm.IsRecordNotFoundError(err) {
+		return err
+	}
+
+	missing := func(field string, stored int) int {
+		total, _ := strconv.Atoi(batch[LegacyPrefix+field])
+		if total > stored {
+			return total - stored
+		}
+		return 0
+	}
+	stat.Request += missing(CountRequested, active.Request)
+	stat.UniqAgent += missing(CountUA, active.UniqAgent)
+	stat.NumSuccess += missing(CountSuccess, active.NumSuccess)
+	stat.NumError += missing(CountError, active.NumError)
+	return nil
 }
 
 // getStatRecodByKey is func to get data into stat table using key
@@ -268,7 +392,12 @@ func getStatRecodByKey(db *gorm.DB, stat *postgres.StatMetrics) error {
 	return db.Where("key = ? and status = ?", stat.Key, model.Active.Value()).First(stat).Error
 }
 
-// updateStat is func to update data into stat table
-func updateStat(db *gorm.DB, stat *postgres.StatMetrics) error {
-	return db.Model(stat).Where("key = ? and status = ?", stat.Key, model.Active.Value()).Update(postgres.StatMetrics{Status: model.Inactive.Value()}).Error
+// upsertStat is func to add the batch into the active stat row or create it
+func upsertStat(db *gorm.DB, stat *postgres.StatMetrics) error {
+	return db.Exec(upsertStatQuery, stat.CreatedAt, stat.UpdatedAt, stat.Key, stat.Request, stat.UniqAgent, stat.NumSuccess, stat.NumError, stat.Status, stat.LastBatch).Error
+}
+
+// deleteInactiveStat is func to hard delete inactive stat rows
+func deleteInactiveStat(db *gorm.DB) error {
+	return db.Unscoped().Where("status = ?", model.Inactive.Value()).Delete(&postgres.StatMetrics{}).Error
 }
@@ -6,7 +6,9 @@ import (
 	mock_postgres "aqua-farm-manager/pkg/postgres/mock"
 	"aqua-farm-manager/pkg/redis"
 	mock_redis "aqua-farm-manager/pkg/redis/mock"
+	"context"
 	"database/sql"
+	"database/sql/driver"
 	"fmt"
 	"log"
 	"os"
@@ -15,6 +17,7 @@ import (
 	"testing"
 
 	"github.com/golang/mock/gomock"
+	redigo "github.com/gomodule/redigo/redis"
 	"github.com/jinzhu/gorm"
 	sqlmock "gopkg.in/DATA-DOG/go-sqlmock.v1"
 )
@@ -67,7 +70,7 @@ func TestStat_IngestMetrics(t *testing.T) {
 		wantErr  bool
 	}{
 		{
-			name: "success flow new ua",
+			name: "success flow",
 			args: args{
 				urlID:     "1",
 				method:    "GET",
@@ -75,10 +78,7 @@ func TestStat_IngestMetrics(t *testing.T) {
 				isSuccess: true,
 			},
 			mockFunc: func(r *mock_redis.MockRedisMethod, p *mock_postgres.MockPostgresMethod) {
-				r.EXPECT().SETNX("P:1:GET:abcdef").Return(true, nil)
-				r.EXPECT().HINCRBY("P:1:GET", CountUA).Return(nil)
-				r.EXPECT().HINCRBY("P:1:GET", CountRequested).Return(nil)
-				r.EXPECT().HINCRBY("P:1:GET", CountSuccess).Return(nil)
+				r.EXPECT().Eval(gomock.Any(), ingestMetricsScript, []string{"D:1:GET", "P:1:GET:abcdef"}, CountUA, CountRequested, CountSuccess).Return(int64(1), nil)
 			},
 			wantErr: false,
 		},
@@ -91,25 +91,31 @@ func TestStat_IngestMetrics(t *testing.T) {
 				isSuccess: false,
 			},
 			mockFunc: func(r *mock_redis.MockRedisMethod, p *mock_postgres.MockPostgresMethod) {
-				r.EXPECT().SETNX("P:1:GET:abcdef").Return(true, nil)
-				r.EXPECT().HINCRBY("P:1:GET", CountUA).Return(nil)
-				r.EXPECT().HINCRBY("P:1:GET", CountRequested).Return(nil)
-				r.EXPECT().HINCRBY("P:1:GET", CountError).Return(nil)
+				r.EXPECT().Eval(gomock.Any(), ingestMetricsScript, []string{"D:1:GET", "P:1:GET:abcdef"}, CountUA, CountRequested, CountError).Return(int64(0), nil)
 			},
 			wantErr: false,
 		},
 		{
-			name: "got error on SetNX",
+			name: "got error on Eval",
 			args: args{
 				urlID:  "1",
 				method: "GET",
 				ua:     "abcdef",
 			},
 			mockFunc: func(r *mock_redis.MockRedisMethod, p *mock_postgres.MockPostgresMethod) {
-				r.EXPECT().SETNX("P:1:GET:abcdef").Return(false, fmt.Errorf("some error"))
+				r.EXPECT().Eval(gomock.Any(), ingestMetricsScript, []string{"D:1:GET", "P:1:GET:abcdef"}, CountUA, CountRequested, CountError).Return(nil, fmt.Errorf("some error"))
 			},
 			wantErr: true,
 		},
+		{
+			name: "got error invalid request",
+			args: args{
+				urlID:  "1",
+				method: "GET",
+			},
+			mockFunc: func(r *mock_redis.MockRedisMethod, p *mock_postgres.MockPostgresMethod) {},
+			wantErr:  true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -118,7 +124,7 @@ func TestStat_IngestMetrics(t *testing.T) {
 
 			tt.mockFunc(redis, pg)
 			s := NewStatStore(redis, pg)
-			if err := s.IngestMetrics(
+			if err := s.IngestMetrics(context.Background(),
 				IngestMetricsRequest{
 					UrlID:     tt.args.urlID,
 					Method:    tt.args.method,
@@ -154,7 +160,7 @@ func TestStat_GetMetrics(t *testing.T) {
 				method: "GET",
 			},
 			mockFunc: func(r *mock_redis.MockRedisMethod, p *mock_postgres.MockPostgresMethod) {
-				r.EXPECT().HGETALL("P:1:GET").Return(
+				r.EXPECT().HGETALL(gomock.Any(), "D:1:GET").Return(
 					map[string]string{CountUA: "1", CountRequested: "2", CountError: "1", CountSuccess: "1"},
 					nil,
 				)
@@ -175,7 +181,7 @@ func TestStat_GetMetrics(t *testing.T) {
 				method: "GET",
 			},
 			mockFunc: func(r *mock_redis.MockRedisMethod, p *mock_postgres.MockPostgresMethod) {
-				r.EXPECT().HGETALL("P:1:GET").Return(
+				r.EXPECT().HGETALL(gomock.Any(), "D:1:GET").Return(
 					map[string]string{},
 					nil,
 				)
@@ -195,7 +201,7 @@ func TestStat_GetMetrics(t *testing.T) {
 				method: "GET",
 			},
 			mockFunc: func(r *mock_redis.MockRedisMethod, p *mock_postgres.MockPostgresMethod) {
-				r.EXPECT().HGETALL("P:1:GET").Return(
+				r.EXPECT().HGETALL(gomock.Any(), "D:1:GET").Return(
 					map[string]string{},
 					fmt.Errorf("some error"),
 				)
@@ -216,7 +222,7 @@ func TestStat_GetMetrics(t *testing.T) {
 
 			tt.mockFunc(redis, pg)
 			s := NewStatStore(redis, pg)
-			got, err := s.GetMetrics(GetMetricsRequest{
+			got, err := s.GetMetrics(context.Background(), GetMetricsRequest{
 				UrlID:  tt.args.urlID,
 				Method: tt.args.method,
 			})
@@ -279,7 +285,7 @@ func TestStat_GetStatData(t *testing.T) {
 				method: "GET",
 			},
 			mockFunc: func(r *mock_redis.MockRedisMethod, p *mock_postgres.MockPostgresMethod) {
-				p.EXPECT().GetDB().Return(gormDB)
+				p.EXPECT().GetDB(gomock.Any()).Return(gormDB)
 
 				mock.ExpectQuery("SELECT (.+) FROM \"stat_metrics\"").
 					WithArgs("P:1:GET", 1).
@@ -300,7 +306,7 @@ func TestStat_GetStatData(t *testing.T) {
 				method: "GET",
 			},
 			mockFunc: func(r *mock_redis.MockRedisMethod, p *mock_postgres.MockPostgresMethod) {
-				p.EXPECT().GetDB().Return(nil)
+				p.EXPECT().GetDB(gomock.Any()).Return(nil)
 			},
 			want: MetricsInfo{
 				NumRequest:   "0",
@@ -317,7 +323,7 @@ func TestStat_GetStatData(t *testing.T) {
 				method: "GET",
 			},
 			mockFunc: func(r *mock_redis.MockRedisMethod, p *mock_postgres.MockPostgresMethod) {
-				p.EXPECT().GetDB().Return(gormDB)
+				p.EXPECT().GetDB(gomock.Any()).Return(gormDB)
 
 				mock.ExpectQuery("SELECT (.+) FROM \"stat_metrics\"").
 					WithArgs("P:1:GET", 1).WillReturnError(fmt.Errorf("some error"))
@@ -338,7 +344,7 @@ func TestStat_GetStatData(t *testing.T) {
 
 			tt.mockFunc(redis, pg)
 			s := NewStatStore(redis, pg)
-			got, err := s.GetStatData(GetStatDataRequest{
+			got, err := s.GetStatData(context.Background(), GetStatDataRequest{
 				UrlID:  tt.args.urlID,
 				Method: tt.args.method,
 			})
@@ -358,32 +364,255 @@ func TestStat_GetStatData(t *testing.T) {
 	}
 }
 
+func claimReply(batchID string, req, ua, suc, er string) []interface{} {
+	return []interface{}{
+		[]byte(CountRequested), []byte(req),
+		[]byte(CountUA), []byte(ua),
+		[]byte(CountSuccess), []byte(suc),
+		[]byte(CountError), []byte(er),
+		[]byte(BatchID), []byte(batchID),
+	}
+}
+
+var upsertStatRegex = regexp.QuoteMeta(`INSERT INTO stat_metrics (created_at, updated_at, key, request, uniq_agent, num_success, num_error, status, last_batch)`)
+
 func TestStat_BackupMetrics(t *testing.T) {
 	db, mockDB, gormDB := InitDBsMockupStat()
 	defer db.Close()
 	defer gormDB.Close()
+
+	claimKeys := []string{"D:1:GET", "F:1:GET", "S:1:GET", "P:1:GET"}
+	tests := []struct {
+		name     string
+		mockFunc func(r *mock_redis.MockRedisMethod, p *mock_postgres.MockPostgresMethod)
+		wantErr  bool
+	}{
+		{
+			name: "success flow",
+			mockFunc: func(r *mock_redis.MockRedisMethod, p *mock_postgres.MockPostgresMethod) {
+				p.EXPECT().GetDB(gomock.Any()).Return(gormDB)
+				r.EXPECT().Eval(gomock.Any(), claimMetricsScript, claimKeys, BatchID, gomock.Any(), LegacyPrefix).Return(claimReply("7", "3", "1", "2", "1"), nil)
+				mockDB.ExpectExec(upsertStatRegex).
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), "P:1:GET", 3, 1, 2, 1, model.Active.Value(), int64(7)).
+					WillReturnResult(sqlmock.NewResult(1, 1))
+				r.EXPECT().Eval(gomock.Any(), ackMetricsScript, []string{"F:1:GET"}, BatchID, "7").Return(int64(1), nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "nothing to flush flow",
+			mockFunc: func(r *mock_redis.MockRedisMethod, p *mock_postgres.MockPostgresMethod) {
+				p.EXPECT().GetDB(gomock.Any()).Return(gormDB)
+				r.EXPECT().Eval(gomock.Any(), claimMetricsScript, claimKeys, BatchID, gomock.Any(), LegacyPrefix).Return([]interface{}{}, nil)
+			},
+			wantErr: false,
+		},
+		{
+			name: "error nil db flow",
+			mockFunc: func(r *mock_redis.MockRedisMethod, p *mock_postgres.MockPostgresMethod) {
+				p.EXPECT().GetDB(gomock.Any()).Return(nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error on claim flow",
+			mockFunc: func(r *mock_redis.MockRedisMethod, p *mock_postgres.MockPostgresMethod) {
+				p.EXPECT().GetDB(gomock.Any()).Return(gormDB)
+				r.EXPECT().Eval(gomock.Any(), claimMetricsScript, claimKeys, BatchID, gomock.Any(), LegacyPrefix).Return(nil, fmt.Errorf("some error"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "error invalid batch flow",
+			mockFunc: func(r *mock_redis.MockRedisMethod, p *mock_postgres.MockPostgresMethod) {
+				p.EXPECT().GetDB(gomock.Any()).Return(gormDB)
+				r.EXPECT().Eval(gomock.Any(), claimMetricsScript, claimKeys, BatchID, gomock.Any(), LegacyPrefix).Return(claimReply("abc", "3", "1", "2", "1"), nil)
+			},
+			wantErr: true,
+		},
+		{
+			name: "error on upsert flow keep the batch",
+			mockFunc: func(r *mock_redis.MockRedisMethod, p *mock_postgres.MockPostgresMethod) {
+				p.EXPECT().GetDB(gomock.Any()).Return(gormDB)
+				r.EXPECT().Eval(gomock.Any(), claimMetricsScript, claimKeys, BatchID, gomock.Any(), LegacyPrefix).Return(claimReply("7", "3", "1", "2", "1"), nil)
+				mockDB.ExpectExec(upsertStatRegex).WillReturnError(fmt.Errorf("some error"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "error on ack flow",
+			mockFunc: func(r *mock_redis.MockRedisMethod, p *mock_postgres.MockPostgresMethod) {
+				p.EXPECT().GetDB(gomock.Any()).Return(gormDB)
+				r.EXPECT().Eval(gomock.Any(), claimMetricsScript, claimKeys, BatchID, gomock.Any(), LegacyPrefix).Return(claimReply("7", "3", "1", "2", "1"), nil)
+				mockDB.ExpectExec(upsertStatRegex).WillReturnResult(sqlmock.NewResult(1, 1))
+				r.EXPECT().Eval(gomock.Any(), ackMetricsScript, []string{"F:1:GET"}, BatchID, "7").Return(nil, fmt.Errorf("some error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			redis := mock_redis.NewMockRedisMethod(mockCtrl)
+			pg := mock_postgres.NewMockPostgresMethod(mockCtrl)
+
+			tt.mockFunc(redis, pg)
+			s := NewStatStore(redis, pg)
+			if err := s.BackupMetrics(context.Background(), BackupMetricsRequest{UrlID: "1", Method: "GET"}); (err != nil) != tt.wantErr {
+				t.Errorf("Stat.BackupMetrics() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			// Check if the expected SQL statement was executed
+			if err := mockDB.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+// TestStat_BackupMetrics_Crash run backup twice where the first run stop between steps,
+// the second run must retry the same batch instead of claiming a new delta
+func TestStat_BackupMetrics_Crash(t *testing.T) {
+	db, mockDB, gormDB := InitDBsMockupStat()
+	defer db.Close()
+	defer gormDB.Close()
+
+	claimKeys := []string{"D:1:GET", "F:1:GET", "S:1:GET", "P:1:GET"}
+	upsertArgs := []driver.Value{sqlmock.AnyArg(), sqlmock.AnyArg(), "P:1:GET", 3, 1, 2, 1, model.Active.Value(), int64(7)}
+	tests := []struct {
+		name     string
+		mockFunc func(r *mock_redis.MockRedisMethod, p *mock_postgres.MockPostgresMethod)
+		wantErr  []bool
+	}{
+		{
+			name: "crash between claim and upsert",
+			mockFunc: func(r *mock_redis.MockRedisMethod, p *mock_postgres.MockPostgresMethod) {
+				p.EXPECT().GetDB(gomock.Any()).Return(gormDB).Times(2)
+				// the flush key still hold batch 7 on the second claim
+				r.EXPECT().Eval(gomock.Any(), claimMetricsScript, claimKeys, BatchID, gomock.Any(), LegacyPrefix).Return(claimReply("7", "3", "1", "2", "1"), nil).Times(2)
+				mockDB.ExpectExec(upsertStatRegex).WithArgs(upsertArgs...).WillReturnError(fmt.Errorf("connection reset"))
+				mockDB.ExpectExec(upsertStatRegex).WithArgs(upsertArgs...).WillReturnResult(sqlmock.NewResult(1, 1))
+				r.EXPECT().Eval(gomock.Any(), ackMetricsScript, []string{"F:1:GET"}, BatchID, "7").Return(int64(1), nil)
+			},
+			wantErr: []bool{true, false},
+		},
+		{
+			name: "crash between upsert and ack",
+			mockFunc: func(r *mock_redis.MockRedisMethod, p *mock_postgres.MockPostgresMethod) {
+				p.EXPECT().GetDB(gomock.Any()).Return(gormDB).Times(2)
+				r.EXPECT().Eval(gomock.Any(), claimMetricsScript, claimKeys, BatchID, gomock.Any(), LegacyPrefix).Return(claimReply("7", "3", "1", "2", "1"), nil).Times(2)
+				mockDB.ExpectExec(upsertStatRegex).WithArgs(upsertArgs...).WillReturnResult(sqlmock.NewResult(1, 1))
+				r.EXPECT().Eval(gomock.Any(), ackMetricsScript, []string{"F:1:GET"}, BatchID, "7").Return(nil, fmt.Errorf("connection reset"))
+				// batch 7 already applied, the guarded upsert update nothing
+				mockDB.ExpectExec(upsertStatRegex).WithArgs(upsertArgs...).WillReturnResult(sqlmock.NewResult(0, 0))
+				r.EXPECT().Eval(gomock.Any(), ackMetricsScript, []string{"F:1:GET"}, BatchID, "7").Return(int64(1), nil)
+			},
+			wantErr: []bool{true, false},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			redis := mock_redis.NewMockRedisMethod(mockCtrl)
+			pg := mock_postgres.NewMockPostgresMethod(mockCtrl)
+
+			tt.mockFunc(redis, pg)
+			s := NewStatStore(redis, pg)
+			for i, wantErr := range tt.wantErr {
+				if err := s.BackupMetrics(context.Background(), BackupMetricsRequest{UrlID: "1", Method: "GET"}); (err != nil) != wantErr {
+					t.Errorf("Stat.BackupMetrics() run %d error = %v, wantErr %v", i, err, wantErr)
+				}
+			}
+
+			if err := mockDB.ExpectationsWereMet(); err != nil {
+				t.Errorf("there were unfulfilled expectations: %s", err)
+			}
+		})
+	}
+}
+
+// TestStat_BackupMetrics_Legacy_SQLite run the first backup after the upgrade from the backup that wrote
+// the total of the path key into a new stat row and kept the previous row as inactive history
+func TestStat_BackupMetrics_Legacy_SQLite(t *testing.T) {
 	mockCtrl := gomock.NewController(t)
 	defer mockCtrl.Finish()
-	type args struct {
-		r BackupMetricsRequest
+
+	pg, err := postgres.NewPostgresClient("sqlite://" + t.TempDir() + "/stat.db")
+	if err != nil {
+		t.Fatalf("NewPostgresClient() error = %v", err)
+	}
+	defer pg.GetDB(context.Background()).Close()
+	db := pg.GetDB(context.Background())
+
+	// history of the previous backup, the last backup wrote total 8 request and redis counted 2 more after it
+	for _, row := range []postgres.StatMetrics{
+		{Key: "P:1:GET", Request: 2, UniqAgent: 1, NumSuccess: 2, Status: model.Inactive.Value()},
+		{Key: "P:1:GET", Request: 5, UniqAgent: 2, NumSuccess: 4, NumError: 1, Status: model.Inactive.Value()},
+		{Key: "P:1:GET", Request: 8, UniqAgent: 2, NumSuccess: 6, NumError: 2, Status: model.Active.Value()},
+	} {
+		row := row
+		if err := db.Create(&row).Error; err != nil {
+			t.Fatalf("create stat row error = %v", err)
+		}
+	}
+
+	claimKeys := []string{"D:1:GET", "F:1:GET", "S:1:GET", "P:1:GET"}
+	// one request is counted in the delta after the upgrade
+	reply := append(claimReply("7", "1", "1", "1", "0"),
+		[]byte(LegacyPrefix+CountRequested), []byte("10"),
+		[]byte(LegacyPrefix+CountUA), []byte("2"),
+		[]byte(LegacyPrefix+CountSuccess), []byte("7"),
+		[]byte(LegacyPrefix+CountError), []byte("3"),
+	)
+	r := mock_redis.NewMockRedisMethod(mockCtrl)
+	r.EXPECT().Eval(gomock.Any(), claimMetricsScript, claimKeys, BatchID, gomock.Any(), LegacyPrefix).Return(reply, nil).Times(2)
+	r.EXPECT().Eval(gomock.Any(), ackMetricsScript, []string{"F:1:GET"}, BatchID, "7").Return(nil, fmt.Errorf("connection reset"))
+	r.EXPECT().Eval(gomock.Any(), ackMetricsScript, []string{"F:1:GET"}, BatchID, "7").Return(int64(1), nil)
+	s := NewStatStore(r, pg)
+
+	want := MetricsInfo{NumRequest: "11", NumUniqAgent: "3", NumSuccess: "8", NumError: "3"}
+	// the retry of the applied batch does not add the legacy total again
+	for i, wantErr := range []bool{true, false} {
+		if err := s.BackupMetrics(context.Background(), BackupMetricsRequest{UrlID: "1", Method: "GET"}); (err != nil) != wantErr {
+			t.Errorf("Stat.BackupMetrics() run %d error = %v, wantErr %v", i, err, wantErr)
+		}
+		if got, err := s.GetStatData(context.Background(), GetStatDataRequest{UrlID: "1", Method: "GET"}); err != nil || got != want {
+			t.Errorf("Stat.GetStatData() run %d = %+v %v, want %+v", i, got, err, want)
+		}
+	}
+
+	// compact remove the history and keep the active row
+	if err := s.CompactMetrics(context.Background()); err != nil {
+		t.Fatalf("Stat.CompactMetrics() error = %v", err)
 	}
+	var count int
+	db.Unscoped().Model(&postgres.StatMetrics{}).Where("key = ?", "P:1:GET").Count(&count)
+	if count != 1 {
+		t.Errorf("Stat.CompactMetrics() left %d rows, want the active row", count)
+	}
+	if got, err := s.GetStatData(context.Background(), GetStatDataRequest{UrlID: "1", Method: "GET"}); err != nil || got != want {
+		t.Errorf("Stat.GetStatData() after compact = %+v %v, want %+v", got, err, want)
+	}
+}
+
+func TestStat_CompactMetrics(t *testing.T) {
+	db, mockDB, gormDB := InitDBsMockupStat()
+	defer db.Close()
+	defer gormDB.Close()
 	tests := []struct {
 		name     string
 		mockFunc func(p *mock_postgres.MockPostgresMethod)
-		args     args
 		wantErr  bool
 	}{
 		{
 			name: "success flow",
 			mockFunc: func(p *mock_postgres.MockPostgresMethod) {
-				p.EXPECT().GetDB().Return(gormDB)
-
-				mockDB.ExpectBegin()
-				mockDB.ExpectExec(regexp.QuoteMeta(`UPDATE "stat_metrics" SET "status" = $1, "updated_at" = $2 WHERE "stat_metrics"."deleted_at" IS NULL AND ((key = $3 and status = $4))`)).WillReturnResult(sqlmock.NewResult(1, 1))
-				mockDB.ExpectCommit()
+				p.EXPECT().GetDB(gomock.Any()).Return(gormDB)
 
 				mockDB.ExpectBegin()
-				mockDB.ExpectQuery(regexp.QuoteMeta(`INSERT INTO "stat_metrics" ("created_at","updated_at","deleted_at","key","request","uniq_agent","num_success","num_error","status") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9)`)).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mockDB.ExpectExec(regexp.QuoteMeta(`DELETE FROM "stat_metrics" WHERE (status = $1)`)).WithArgs(model.Inactive.Value()).WillReturnResult(sqlmock.NewResult(0, 3))
 				mockDB.ExpectCommit()
 			},
 			wantErr: false,
@@ -391,32 +620,34 @@ func TestStat_BackupMetrics(t *testing.T) {
 		{
 			name: "error nil db flow",
 			mockFunc: func(p *mock_postgres.MockPostgresMethod) {
-				p.EXPECT().GetDB().Return(nil)
+				p.EXPECT().GetDB(gomock.Any()).Return(nil)
 			},
 			wantErr: true,
 		},
 		{
-			name: "error on update flow",
+			name: "error on delete flow",
 			mockFunc: func(p *mock_postgres.MockPostgresMethod) {
-				p.EXPECT().GetDB().Return(gormDB)
+				p.EXPECT().GetDB(gomock.Any()).Return(gormDB)
 
 				mockDB.ExpectBegin()
-				mockDB.ExpectExec(regexp.QuoteMeta(`UPDATE "stat_metrics" SET "status" = $1, "updated_at" = $2 WHERE "stat_metrics"."deleted_at" IS NULL AND ((key = $3 and status = $4))`)).WillReturnError(fmt.Errorf("some error"))
+				mockDB.ExpectExec(regexp.QuoteMeta(`DELETE FROM "stat_metrics" WHERE (status = $1)`)).WillReturnError(fmt.Errorf("some error"))
+				mockDB.ExpectRollback()
 			},
 			wantErr: true,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
 			pg := mock_postgres.NewMockPostgresMethod(mockCtrl)
 
 			tt.mockFunc(pg)
 			s := NewStatStore(&redis.Client{}, pg)
-			if err := s.BackupMetrics(tt.args.r); (err != nil) != tt.wantErr {
-				t.Errorf("Stat.BackupMetrics() error = %v, wantErr %v", err, tt.wantErr)
+			if err := s.CompactMetrics(context.Background()); (err != nil) != tt.wantErr {
+				t.Errorf("Stat.CompactMetrics() error = %v, wantErr %v", err, tt.wantErr)
 			}
 
-			// Check if the expected SQL statement was executed
 			if err := mockDB.ExpectationsWereMet(); err != nil {
 				t.Errorf("there were unfulfilled expectations: %s", err)
 			}
@@ -424,65 +655,85 @@ func TestStat_BackupMetrics(t *testing.T) {
 	}
 }
 
-func TestStat_MigrateMetrics(t *testing.T) {
-	mockCtrl := gomock.NewController(t)
-	defer mockCtrl.Finish()
-	type args struct {
-		r MigrateMetricsRequest
-	}
+func TestIsTransientError(t *testing.T) {
 	tests := []struct {
-		name     string
-		mockFunc func(r *mock_redis.MockRedisMethod)
-		args     args
-		wantErr  bool
+		name string
+		err  error
+		want bool
 	}{
 		{
-			name: "success flow",
-			mockFunc: func(r *mock_redis.MockRedisMethod) {
-				r.EXPECT().HSET(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).Times(4)
-			},
-			args: args{
-				r: MigrateMetricsRequest{
-					UrlID:  "1",
-					Method: "GET",
-					Metrics: MetricsInfo{
-						NumRequest:   "1",
-						NumUniqAgent: "1",
-						NumSuccess:   "1",
-						NumError:     "1",
-					},
-				},
-			},
-			wantErr: false,
+			name: "nil error",
+			err:  nil,
+			want: false,
 		},
 		{
-			name: "error flow",
-			mockFunc: func(r *mock_redis.MockRedisMethod) {
-				r.EXPECT().HSET(gomock.Any(), gomock.Any(), gomock.Any()).Return(fmt.Errorf("some error")).Times(4)
-			},
-			args: args{
-				r: MigrateMetricsRequest{
-					UrlID:  "1",
-					Method: "GET",
-					Metrics: MetricsInfo{
-						NumRequest:   "1",
-						NumUniqAgent: "1",
-						NumSuccess:   "1",
-						NumError:     "1",
-					},
-				},
-			},
-			wantErr: true,
+			name: "invalid metrics error",
+			err:  ErrInvalidMetrics,
+			want: false,
+		},
+		{
+			name: "redis reply error",
+			err:  redigo.Error("WRONGTYPE Operation against a key holding the wrong kind of value"),
+			want: false,
+		},
+		{
+			name: "connection error",
+			err:  fmt.Errorf("dial tcp: connection refused"),
+			want: true,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			redis := mock_redis.NewMockRedisMethod(mockCtrl)
-			tt.mockFunc(redis)
-			s := NewStatStore(redis, &postgres.Client{})
-			if err := s.MigrateMetrics(tt.args.r); (err != nil) != tt.wantErr {
-				t.Errorf("Stat.MigrateMetrics() error = %v, wantErr %v", err, tt.wantErr)
+			if got := IsTransientError(tt.err); got != tt.want {
+				t.Errorf("IsTransientError() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
+
+func TestUpsertStat_SQLite(t *testing.T) {
+	pg, err := postgres.NewPostgresClient("sqlite://" + t.TempDir() + "/stat.db")
+	if err != nil {
+		t.Fatalf("NewPostgresClient() error = %v", err)
+	}
+	defer pg.GetDB(context.Background()).Close()
+	db := pg.GetDB(context.Background())
+
+	upsert := func(request int, batch int64) {
+		t.Helper()
+		err := upsertStat(db, &postgres.StatMetrics{
+			Key:       "/v1/farms-GET",
+			Request:   request,
+			UniqAgent: 1,
+			Status:    model.Active.Value(),
+			LastBatch: batch,
+		})
+		if err != nil {
+			t.Fatalf("upsertStat() error = %v", err)
+		}
+	}
+	upsert(2, 1)
+	upsert(3, 2)
+	// retrying the applied batch is skipped
+	upsert(3, 2)
+
+	stat := &postgres.StatMetrics{Key: "/v1/farms-GET"}
+	if err := getStatRecodByKey(db, stat); err != nil {
+		t.Fatalf("getStatRecodByKey() error = %v", err)
+	}
+	if stat.Request != 5 || stat.UniqAgent != 2 || stat.LastBatch != 2 {
+		t.Errorf("getStatRecodByKey() = %+v, want request 5, uniq agent 2 and last batch 2", stat)
+	}
+
+	if err := db.Model(stat).Update("status", model.Inactive.Value()).Error; err != nil {
+		t.Fatalf("update status error = %v", err)
+	}
+	if err := deleteInactiveStat(db); err != nil {
+		t.Fatalf("deleteInactiveStat() error = %v", err)
+	}
+	var count int
+	db.Unscoped().Model(&postgres.StatMetrics{}).Count(&count)
+	if count != 0 {
+		t.Errorf("deleteInactiveStat() left %d rows, want 0", count)
+	}
+}
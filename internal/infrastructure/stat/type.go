@@ -16,16 +16,8 @@ type GetMetricsRequest struct {
 
 // BackupMetricsRequest list is request  for BackupMetrics
 type BackupMetricsRequest struct {
-	UrlID   string
-	Method  string
-	Metrics MetricsRequest
-}
-
-// MigrateMetricsRequest list is request  for MigrateMetrics
-type MigrateMetricsRequest struct {
-	UrlID   string
-	Method  string
-	Metrics MetricsInfo
+	UrlID  string
+	Method string
 }
 
 // GetStatDataRequest list is request  for GetStatData
@@ -34,13 +26,6 @@ type GetStatDataRequest struct {
 	Method string
 }
 
-type MetricsRequest struct {
-	NumRequest   int
-	NumUniqAgent int
-	NumSuccess   int
-	NumError     int
-}
-
 type MetricsInfo struct {
 	NumRequest   string
 	NumUniqAgent string
@@ -0,0 +1,32 @@
+package webhook
+
+import "time"
+
+// WebhookInfraInfo struct is list parameter info for webhook
+type WebhookInfraInfo struct {
+	ID         uint
+	URL        string
+	EventTypes []string
+	Secret     string
+	CreatedAt  time.Time
+}
+
+// DeliveryInfraInfo struct is list parameter info for a delivery attempt of webhook
+type DeliveryInfraInfo struct {
+	ID         uint
+	WebhookID  uint
+	EventID    string
+	EventType  string
+	Attempt    int
+	StatusCode int
+	Success    bool
+	Error      string
+	DurationMs int64
+	CreatedAt  time.Time
+}
+
+// GetWebhookWithPagingRequest struct is list parameter to get webhook with page
+type GetWebhookWithPagingRequest struct {
+	Size   int
+	Cursor int
+}
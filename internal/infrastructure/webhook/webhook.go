@@ -0,0 +1,229 @@
+package webhook
+
+import (
+	"aqua-farm-manager/internal/model"
+	"aqua-farm-manager/pkg/postgres"
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/jinzhu/gorm"
+)
+
+// ErrWebhookNotFound is error when active webhook is not found
+var ErrWebhookNotFound = errors.New("webhook is not found")
+
+// eventTypesSeparator is separator of event types stored in a single column
+const eventTypesSeparator = ","
+
+// WebhookStore is set of methods for interacting with a webhook storage system
+type WebhookStore interface {
+	Create(ctx context.Context, r *WebhookInfraInfo) error
+	Delete(ctx context.Context, r *WebhookInfraInfo) error
+	GetWebhookByID(ctx context.Context, r *WebhookInfraInfo) error
+	GetWebhookWithPaging(ctx context.Context, r GetWebhookWithPagingRequest) ([]WebhookInfraInfo, error)
+	GetActiveWebhooks(ctx context.Context) ([]WebhookInfraInfo, error)
+	RecordDelivery(ctx context.Context, r *DeliveryInfraInfo) error
+	GetDeliveries(ctx context.Context, webhookID uint, limit int) ([]DeliveryInfraInfo, error)
+}
+
+// Webhook is list dependencies webhook store
+type Webhook struct {
+	pg postgres.PostgresMethod
+}
+
+// NewWebhookStore is func to generate WebhookStore interface
+func NewWebhookStore(pg postgres.PostgresMethod) WebhookStore {
+	return &Webhook{
+		pg: pg,
+	}
+}
+
+// Create is func to store webhook into database
+func (w *Webhook) Create(ctx context.Context, r *WebhookInfraInfo) error {
+	db := w.pg.GetDB(ctx)
+	if db == nil {
+		return errors.New("Database Client is not init")
+	}
+
+	if r == nil {
+		return errors.New("got nil request")
+	}
+
+	webhook := &postgres.Webhooks{
+		URL:        r.URL,
+		EventTypes: strings.Join(r.EventTypes, eventTypesSeparator),
+		Secret:     r.Secret,
+		Status:     model.Active.Value(),
+	}
+
+	err := db.Create(webhook).Error
+	if err != nil {
+		return err
+	}
+
+	r.ID = webhook.Model.ID
+	r.CreatedAt = webhook.CreatedAt
+	return nil
+}
+
+// Delete is func to soft delete webhook in database with update the status to inactive
+func (w *Webhook) Delete(ctx context.Context, r *WebhookInfraInfo) error {
+	db := w.pg.GetDB(ctx)
+	if db == nil {
+		return errors.New("Database Client is not init")
+	}
+
+	if r == nil || r.ID <= 0 {
+		return errors.New("got nil request")
+	}
+
+	webhook := &postgres.Webhooks{
+		Model: gorm.Model{
+			ID: r.ID,
+		},
+	}
+
+	return db.Model(webhook).Where("id = ? AND status = ?", r.ID, model.Active.Value()).Update("status", model.Inactive.Value()).Error
+}
+
+// GetWebhookByID is func to get active webhook info based on id in database
+func (w *Webhook) GetWebhookByID(ctx context.Context, r *WebhookInfraInfo) error {
+	db := w.pg.GetDB(ctx)
+	if db == nil {
+		return errors.New("Database Client is not init")
+	}
+
+	if r == nil || r.ID <= 0 {
+		return errors.New("got nil request")
+	}
+
+	var webhook postgres.Webhooks
+	err := db.Where("id = ? AND status = ?", r.ID, model.Active.Value()).First(&webhook).Error
+	if gorm.IsRecordNotFoundError(err) {
+		return ErrWebhookNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	*r = mapWebhookInfraInfo(webhook)
+	return nil
+}
+
+// GetWebhookWithPaging is func to get active webhook with paging
+func (w *Webhook) GetWebhookWithPaging(ctx context.Context, r GetWebhookWithPagingRequest) ([]WebhookInfraInfo, error) {
+	var list []WebhookInfraInfo
+	db := w.pg.GetDB(ctx)
+	if db == nil {
+		return list, errors.New("Database Client is not init")
+	}
+
+	var webhooks []postgres.Webhooks
+	err := db.Where("status = ?", model.Active.Value()).Order("id asc").Limit(r.Size).Offset((r.Cursor - 1) * r.Size).Find(&webhooks).Error
+	if err != nil {
+		return list, err
+	}
+
+	for _, webhook := range webhooks {
+		list = append(list, mapWebhookInfraInfo(webhook))
+	}
+	return list, nil
+}
+
+// GetActiveWebhooks is func to get every active webhook
+func (w *Webhook) GetActiveWebhooks(ctx context.Context) ([]WebhookInfraInfo, error) {
+	var list []WebhookInfraInfo
+	db := w.pg.GetDB(ctx)
+	if db == nil {
+		return list, errors.New("Database Client is not init")
+	}
+
+	var webhooks []postgres.Webhooks
+	err := db.Where("status = ?", model.Active.Value()).Find(&webhooks).Error
+	if err != nil {
+		return list, err
+	}
+
+	for _, webhook := range webhooks {
+		list = append(list, mapWebhookInfraInfo(webhook))
+	}
+	return list, nil
+}
+
+func mapWebhookInfraInfo(w postgres.Webhooks) WebhookInfraInfo {
+	var eventTypes []string
+	if w.EventTypes != "" {
+		eventTypes = strings.Split(w.EventTypes, eventTypesSeparator)
+	}
+	return WebhookInfraInfo{
+		ID:         w.ID,
+		URL:        w.URL,
+		EventTypes: eventTypes,
+		Secret:     w.Secret,
+		CreatedAt:  w.CreatedAt,
+	}
+}
+
+// RecordDelivery is func to store a delivery attempt of webhook
+func (w *Webhook) RecordDelivery(ctx context.Context, r *DeliveryInfraInfo) error {
+	db := w.pg.GetDB(ctx)
+	if db == nil {
+		return errors.New("Database Client is not init")
+	}
+
+	if r == nil || r.WebhookID <= 0 {
+		return errors.New("got nil request")
+	}
+
+	delivery := &postgres.WebhookDeliveries{
+		WebhookID:  r.WebhookID,
+		EventID:    r.EventID,
+		EventType:  r.EventType,
+		Attempt:    r.Attempt,
+		StatusCode: r.StatusCode,
+		Success:    r.Success,
+		Error:      r.Error,
+		DurationMs: r.DurationMs,
+	}
+
+	err := db.Create(delivery).Error
+	if err != nil {
+		return err
+	}
+
+	r.ID = delivery.Model.ID
+	r.CreatedAt = delivery.CreatedAt
+	return nil
+}
+
+// GetDeliveries is func to get latest delivery attempt of webhook
+func (w *Webhook) GetDeliveries(ctx context.Context, webhookID uint, limit int) ([]DeliveryInfraInfo, error) {
+	var list []DeliveryInfraInfo
+	db := w.pg.GetDB(ctx)
+	if db == nil {
+		return list, errors.New("Database Client is not init")
+	}
+
+	var deliveries []postgres.WebhookDeliveries
+	err := db.Where("webhook_id = ?", webhookID).Order("id desc").Limit(limit).Find(&deliveries).Error
+	if err != nil {
+		return list, err
+	}
+
+	for _, d := range deliveries {
+		list = append(list, DeliveryInfraInfo{
+			ID:         d.ID,
+			WebhookID:  d.WebhookID,
+			EventID:    d.EventID,
+			EventType:  d.EventType,
+			Attempt:    d.Attempt,
+			StatusCode: d.StatusCode,
+			Success:    d.Success,
+			Error:      d.Error,
+			DurationMs: d.DurationMs,
+			CreatedAt:  d.CreatedAt,
+		})
+	}
+	return list, nil
+}
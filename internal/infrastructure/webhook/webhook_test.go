@@ -0,0 +1,413 @@
+package webhook
+
+import (
+	"aqua-farm-manager/pkg/postgres"
+	mock_postgres "aqua-farm-manager/pkg/postgres/mock"
+	"context"
+	"database/sql"
+	"fmt"
+	"log"
+	"os"
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/jinzhu/gorm"
+	"gopkg.in/DATA-DOG/go-sqlmock.v1"
+)
+
+func TestNewWebhookStore(t *testing.T) {
+	type args struct {
+		pg postgres.PostgresMethod
+	}
+	tests := []struct {
+		name string
+		args args
+		want WebhookStore
+	}{
+		{
+			name: "success",
+			args: args{
+				pg: &postgres.Client{},
+			},
+			want: &Webhook{
+				pg: &postgres.Client{},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := NewWebhookStore(tt.args.pg); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("NewWebhookStore() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func InitDBsMockupWebhook() (*sql.DB, sqlmock.Sqlmock, *gorm.DB) {
+	db, mock, _ := sqlmock.New()
+	gormDB, _ := gorm.Open("postgres", db)
+	gormDB.LogMode(true)
+	gormDB.SetLogger(log.New(os.Stdout, "\n", 0))
+	gormDB.Debug()
+	return db, mock, gormDB
+}
+
+func TestWebhook_Create(t *testing.T) {
+	db, mockDB, gormDB := InitDBsMockupWebhook()
+	defer db.Close()
+	defer gormDB.Close()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	pg := mock_postgres.NewMockPostgresMethod(mockCtrl)
+	query := regexp.QuoteMeta(`INSERT INTO "webhooks" ("created_at","updated_at","deleted_at","url","event_types","secret","status") VALUES ($1,$2,$3,$4,$5,$6,$7)`)
+	tests := []struct {
+		name     string
+		mockFunc func()
+		r        *WebhookInfraInfo
+		wantID   uint
+		wantErr  bool
+	}{
+		{
+			name: "success",
+			mockFunc: func() {
+				pg.EXPECT().GetDB(gomock.Any()).Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery(query).
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), nil, "https://example.com/hook", "farm.created,pond.moved", "secret", 1).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mockDB.ExpectCommit()
+			},
+			r: &WebhookInfraInfo{
+				URL:        "https://example.com/hook",
+				EventTypes: []string{"farm.created", "pond.moved"},
+				Secret:     "secret",
+			},
+			wantID:  1,
+			wantErr: false,
+		},
+		{
+			name: "got error exec",
+			mockFunc: func() {
+				pg.EXPECT().GetDB(gomock.Any()).Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery(query).WillReturnError(fmt.Errorf("some error"))
+			},
+			r:       &WebhookInfraInfo{},
+			wantErr: true,
+		},
+		{
+			name: "nil db",
+			mockFunc: func() {
+				pg.EXPECT().GetDB(gomock.Any()).Return(nil)
+			},
+			r:       &WebhookInfraInfo{},
+			wantErr: true,
+		},
+		{
+			name: "req nil",
+			mockFunc: func() {
+				pg.EXPECT().GetDB(gomock.Any()).Return(gormDB)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			s := NewWebhookStore(pg)
+			if err := s.Create(context.Background(), tt.r); (err != nil) != tt.wantErr {
+				t.Errorf("Webhook.Create() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if tt.r != nil && tt.r.ID != tt.wantID {
+				t.Errorf("Webhook.Create() id = %v, want %v", tt.r.ID, tt.wantID)
+			}
+		})
+	}
+}
+
+func TestWebhook_Delete(t *testing.T) {
+	db, mockDB, gormDB := InitDBsMockupWebhook()
+	defer db.Close()
+	defer gormDB.Close()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	pg := mock_postgres.NewMockPostgresMethod(mockCtrl)
+	query := regexp.QuoteMeta(`UPDATE "webhooks" SET "status" = $1, "updated_at" = $2 WHERE "webhooks"."deleted_at" IS NULL AND "webhooks"."id" = $3 AND ((id = $4 AND status = $5))`)
+	tests := []struct {
+		name     string
+		mockFunc func()
+		r        *WebhookInfraInfo
+		wantErr  bool
+	}{
+		{
+			name: "success",
+			mockFunc: func() {
+				pg.EXPECT().GetDB(gomock.Any()).Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectExec(query).WithArgs(2, sqlmock.AnyArg(), 1, 1, 1).WillReturnResult(sqlmock.NewResult(1, 1))
+				mockDB.ExpectCommit()
+			},
+			r:       &WebhookInfraInfo{ID: 1},
+			wantErr: false,
+		},
+		{
+			name: "got error exec",
+			mockFunc: func() {
+				pg.EXPECT().GetDB(gomock.Any()).Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectExec(query).WillReturnError(fmt.Errorf("some error"))
+			},
+			r:       &WebhookInfraInfo{ID: 1},
+			wantErr: true,
+		},
+		{
+			name: "invalid id",
+			mockFunc: func() {
+				pg.EXPECT().GetDB(gomock.Any()).Return(gormDB)
+			},
+			r:       &WebhookInfraInfo{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			s := NewWebhookStore(pg)
+			if err := s.Delete(context.Background(), tt.r); (err != nil) != tt.wantErr {
+				t.Errorf("Webhook.Delete() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWebhook_GetActiveWebhooks(t *testing.T) {
+	db, mockDB, gormDB := InitDBsMockupWebhook()
+	defer db.Close()
+	defer gormDB.Close()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	pg := mock_postgres.NewMockPostgresMethod(mockCtrl)
+	query := regexp.QuoteMeta(`SELECT * FROM "webhooks" WHERE "webhooks"."deleted_at" IS NULL AND ((status = $1))`)
+	tests := []struct {
+		name     string
+		mockFunc func()
+		want     []WebhookInfraInfo
+		wantErr  bool
+	}{
+		{
+			name: "success",
+			mockFunc: func() {
+				pg.EXPECT().GetDB(gomock.Any()).Return(gormDB)
+				mockDB.ExpectQuery(query).WithArgs(1).WillReturnRows(
+					sqlmock.NewRows([]string{"id", "url", "event_types", "secret"}).
+						AddRow(1, "https://a.com", "farm.created,pond.moved", "s1").
+						AddRow(2, "https://b.com", "", "s2"))
+			},
+			want: []WebhookInfraInfo{
+				{ID: 1, URL: "https://a.com", EventTypes: []string{"farm.created", "pond.moved"}, Secret: "s1"},
+				{ID: 2, URL: "https://b.com", Secret: "s2"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "got error exec",
+			mockFunc: func() {
+				pg.EXPECT().GetDB(gomock.Any()).Return(gormDB)
+				mockDB.ExpectQuery(query).WillReturnError(fmt.Errorf("some error"))
+			},
+			wantErr: true,
+		},
+		{
+			name: "nil db",
+			mockFunc: func() {
+				pg.EXPECT().GetDB(gomock.Any()).Return(nil)
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			s := NewWebhookStore(pg)
+			got, err := s.GetActiveWebhooks(context.Background())
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Webhook.GetActiveWebhooks() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Webhook.GetActiveWebhooks() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWebhook_RecordDelivery(t *testing.T) {
+	db, mockDB, gormDB := InitDBsMockupWebhook()
+	defer db.Close()
+	defer gormDB.Close()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	pg := mock_postgres.NewMockPostgresMethod(mockCtrl)
+	query := regexp.QuoteMeta(`INSERT INTO "webhook_deliveries" ("created_at","updated_at","deleted_at","webhook_id","event_id","event_type","attempt","status_code","success","error","duration_ms") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11)`)
+	tests := []struct {
+		name     string
+		mockFunc func()
+		r        *DeliveryInfraInfo
+		wantErr  bool
+	}{
+		{
+			name: "success",
+			mockFunc: func() {
+				pg.EXPECT().GetDB(gomock.Any()).Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery(query).
+					WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), nil, 1, "event", "farm.created", 2, 500, false, "status 500", 10).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+				mockDB.ExpectCommit()
+			},
+			r: &DeliveryInfraInfo{
+				WebhookID:  1,
+				EventID:    "event",
+				EventType:  "farm.created",
+				Attempt:    2,
+				StatusCode: 500,
+				Error:      "status 500",
+				DurationMs: 10,
+			},
+			wantErr: false,
+		},
+		{
+			name: "got error exec",
+			mockFunc: func() {
+				pg.EXPECT().GetDB(gomock.Any()).Return(gormDB)
+				mockDB.ExpectBegin()
+				mockDB.ExpectQuery(query).WillReturnError(fmt.Errorf("some error"))
+			},
+			r:       &DeliveryInfraInfo{WebhookID: 1},
+			wantErr: true,
+		},
+		{
+			name: "invalid webhook id",
+			mockFunc: func() {
+				pg.EXPECT().GetDB(gomock.Any()).Return(gormDB)
+			},
+			r:       &DeliveryInfraInfo{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			s := NewWebhookStore(pg)
+			if err := s.RecordDelivery(context.Background(), tt.r); (err != nil) != tt.wantErr {
+				t.Errorf("Webhook.RecordDelivery() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWebhook_GetDeliveries(t *testing.T) {
+	db, mockDB, gormDB := InitDBsMockupWebhook()
+	defer db.Close()
+	defer gormDB.Close()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	pg := mock_postgres.NewMockPostgresMethod(mockCtrl)
+	query := regexp.QuoteMeta(`SELECT * FROM "webhook_deliveries" WHERE "webhook_deliveries"."deleted_at" IS NULL AND ((webhook_id = $1)) ORDER BY id desc LIMIT 10`)
+	tests := []struct {
+		name     string
+		mockFunc func()
+		want     []DeliveryInfraInfo
+		wantErr  bool
+	}{
+		{
+			name: "success",
+			mockFunc: func() {
+				pg.EXPECT().GetDB(gomock.Any()).Return(gormDB)
+				mockDB.ExpectQuery(query).WithArgs(1).WillReturnRows(
+					sqlmock.NewRows([]string{"id", "webhook_id", "event_id", "event_type", "attempt", "status_code", "success"}).
+						AddRow(2, 1, "event", "farm.created", 2, 200, true).
+						AddRow(1, 1, "event", "farm.created", 1, 500, false))
+			},
+			want: []DeliveryInfraInfo{
+				{ID: 2, WebhookID: 1, EventID: "event", EventType: "farm.created", Attempt: 2, StatusCode: 200, Success: true},
+				{ID: 1, WebhookID: 1, EventID: "event", EventType: "farm.created", Attempt: 1, StatusCode: 500},
+			},
+			wantErr: false,
+		},
+		{
+			name: "got error exec",
+			mockFunc: func() {
+				pg.EXPECT().GetDB(gomock.Any()).Return(gormDB)
+				mockDB.ExpectQuery(query).WillReturnError(fmt.Errorf("some error"))
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			s := NewWebhookStore(pg)
+			got, err := s.GetDeliveries(context.Background(), 1, 10)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Webhook.GetDeliveries() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Webhook.GetDeliveries() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWebhook_GetWebhookByID(t *testing.T) {
+	db, mockDB, gormDB := InitDBsMockupWebhook()
+	defer db.Close()
+	defer gormDB.Close()
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	pg := mock_postgres.NewMockPostgresMethod(mockCtrl)
+	query := regexp.QuoteMeta(`SELECT * FROM "webhooks" WHERE "webhooks"."deleted_at" IS NULL AND ((id = $1 AND status = $2)) ORDER BY "webhooks"."id" ASC LIMIT 1`)
+	tests := []struct {
+		name     string
+		mockFunc func()
+		want     *WebhookInfraInfo
+		wantErr  error
+	}{
+		{
+			name: "success",
+			mockFunc: func() {
+				pg.EXPECT().GetDB(gomock.Any()).Return(gormDB)
+				mockDB.ExpectQuery(query).WithArgs(1, 1).WillReturnRows(
+					sqlmock.NewRows([]string{"id", "url", "event_types", "secret"}).AddRow(1, "https://a.com", "*", "s1"))
+			},
+			want: &WebhookInfraInfo{ID: 1, URL: "https://a.com", EventTypes: []string{"*"}, Secret: "s1"},
+		},
+		{
+			name: "not found",
+			mockFunc: func() {
+				pg.EXPECT().GetDB(gomock.Any()).Return(gormDB)
+				mockDB.ExpectQuery(query).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+			},
+			want:    &WebhookInfraInfo{ID: 1},
+			wantErr: ErrWebhookNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.mockFunc()
+			s := NewWebhookStore(pg)
+			got := &WebhookInfraInfo{ID: 1}
+			if err := s.GetWebhookByID(context.Background(), got); err != tt.wantErr {
+				t.Errorf("Webhook.GetWebhookByID() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Webhook.GetWebhookByID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
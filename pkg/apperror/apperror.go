@@ -0,0 +1,146 @@
+package apperror
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// Code is machine readable error code, client branch on the code instead of the message
+type Code string
+
+// list error code
+const (
+	CodeBadRequest        Code = "BAD_REQUEST"
+	CodeValidationFailed  Code = "VALIDATION_FAILED"
+	CodeUnauthenticated   Code = "UNAUTHENTICATED"
+	CodeNotFound          Code = "NOT_FOUND"
+	CodeFarmNotFound      Code = "FARM_NOT_FOUND"
+	CodeFarmAlreadyExists Code = "FARM_ALREADY_EXISTS"
+	CodeFarmHasPonds      Code = "FARM_HAS_PONDS"
+	CodePondNotFound      Code = "POND_NOT_FOUND"
+	CodePondAlreadyExists Code = "POND_ALREADY_EXISTS"
+	CodePondLimitReached  Code = "POND_LIMIT_REACHED"
+	CodeWebhookNotFound   Code = "WEBHOOK_NOT_FOUND"
+	CodeConflict          Code = "CONFLICT"
+	CodeUnavailable       Code = "SERVICE_UNAVAILABLE"
+	CodeTimeout           Code = "TIMEOUT"
+	CodeInternal          Code = "INTERNAL_ERROR"
+)
+
+// statusByCode is http status of every error code, unknown code is internal error
+var statusByCode = map[Code]int{
+	CodeBadRequest:        http.StatusBadRequest,
+	CodeValidationFailed:  http.StatusUnprocessableEntity,
+	CodeUnauthenticated:   http.StatusUnauthorized,
+	CodeNotFound:          http.StatusNotFound,
+	CodeFarmNotFound:      http.StatusNotFound,
+	CodeFarmAlreadyExists: http.StatusConflict,
+	CodeFarmHasPonds:      http.StatusConflict,
+	CodePondNotFound:      http.StatusNotFound,
+	CodePondAlreadyExists: http.StatusConflict,
+	CodePondLimitReached:  http.StatusConflict,
+	CodeWebhookNotFound:   http.StatusNotFound,
+	CodeConflict:          http.StatusConflict,
+	CodeUnavailable:       http.StatusServiceUnavailable,
+	CodeTimeout:           http.StatusGatewayTimeout,
+	CodeInternal:          http.StatusInternalServerError,
+}
+
+// list message of generic error
+const (
+	MessageBadRequest       = "Bad Request"
+	MessageInvalidParameter = "Invalid Parameter Request"
+	MessageUnauthenticated  = "Unauthenticated"
+	MessageNotFound         = "Data Not Found"
+	MessageTimeout          = "Timeout"
+	MessageInternal         = "Internal Server Error"
+)
+
+// Detail is one error item of the response, Field is set for validation error of a request field
+type Detail struct {
+	Code    Code   `json:"code"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+// Error is error with code and message that is safe to be returned to client,
+// the cause is kept for errors.Is and log but it is never written into the response
+type Error struct {
+	Code    Code
+	Message string
+	Fields  []Detail
+	cause   error
+}
+
+// New is func to create Error with code and message
+func New(code Code, message string) *Error {
+	return &Error{Code: code, Message: message}
+}
+
+// Wrap is func to create Error with code and message that keep err as the cause
+func Wrap(code Code, message string, err error) *Error {
+	return &Error{Code: code, Message: message, cause: err}
+}
+
+// Validation is func to create validation error with the violation of every field
+func Validation(fields ...Detail) *Error {
+	return &Error{Code: CodeValidationFailed, Message: MessageInvalidParameter, Fields: fields}
+}
+
+// Invalid is func to create validation error of a single field that use the violation as the message
+func Invalid(field, message string) *Error {
+	return &Error{Code: CodeValidationFailed, Message: message, Fields: []Detail{Field(field, message)}}
+}
+
+// Field is func to create validation violation of a request field
+func Field(field, message string) Detail {
+	return Detail{Code: CodeValidationFailed, Field: field, Message: message}
+}
+
+// Error is func to get the message of the error
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Unwrap is func to get the cause of the error
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+// Details is func to get error items of the response, validation error has one item per field
+func (e *Error) Details() []Detail {
+	if len(e.Fields) > 0 {
+		return e.Fields
+	}
+	return []Detail{{Code: e.Code, Message: e.Message}}
+}
+
+// From is func to get Error of err, context error is timeout and
+// error without code is internal error so the raw message is not returned to client
+func From(err error) *Error {
+	if err == nil {
+		return nil
+	}
+
+	var e *Error
+	if errors.As(err, &e) {
+		return e
+	}
+	if errors.Is(err, context.DeadlineExceeded) || errors.Is(err, context.Canceled) {
+		return Wrap(CodeTimeout, MessageTimeout, err)
+	}
+	return Wrap(CodeInternal, MessageInternal, err)
+}
+
+// HTTPStatus is func to get http status of err
+func HTTPStatus(err error) int {
+	e := From(err)
+	if e == nil {
+		return http.StatusOK
+	}
+	if status, ok := statusByCode[e.Code]; ok {
+		return status
+	}
+	return http.StatusInternalServerError
+}
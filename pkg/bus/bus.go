@@ -0,0 +1,79 @@
+package bus
+
+import (
+	"time"
+)
+
+// list supported message bus driver
+const (
+	DriverNSQ    = "nsq"
+	DriverMemory = "memory"
+)
+
+// Publisher is list all available method to publish message into a topic
+type Publisher interface {
+	Publish(topic string, data interface{}) error
+	MultiPublish(topic string, data []interface{}) error
+}
+
+// Subscriber is list all available method to consume message of a topic,
+// every channel of a topic receive its own copy of the message and the message
+// of a channel is shared between its handlers
+type Subscriber interface {
+	Subscribe(topic, channel string, handler Handler, config SubscribeConfig) (Subscription, error)
+}
+
+// Bus is message bus that can publish and consume message
+type Bus interface {
+	Publisher
+	Subscriber
+}
+
+// Subscription is running consumer of a topic channel
+type Subscription interface {
+	// Stop is func to stop receiving new message, the channel keep the message until it is subscribed again
+	Stop()
+	// Done is closed when every in flight handler is returned after Stop
+	Done() <-chan struct{}
+}
+
+// SubscribeConfig struct to hold the configuration of a subscription
+type SubscribeConfig struct {
+	// MaxInFlight is max number of message that is delivered but not responded yet
+	MaxInFlight int
+	// Concurrency is number of handler run concurrently
+	Concurrency int
+	// MsgTimeout is time before not responded message is delivered again
+	MsgTimeout time.Duration
+}
+
+// Message is message received from a topic channel, handler must respond
+// every message by Finish or Requeue
+type Message interface {
+	// Body is func to get message payload
+	Body() []byte
+	// Attempts is func to get number of time the message is delivered, it start from 1
+	Attempts() uint16
+	// Finish is func to mark message is processed successfully
+	Finish()
+	// Requeue is func to deliver the message again after delay and slow down the subscription,
+	// negative delay let the implementation choose the delay
+	Requeue(delay time.Duration)
+	// RequeueWithoutBackoff is func to deliver the message again after delay without slow down the subscription
+	RequeueWithoutBackoff(delay time.Duration)
+	// Touch is func to reset message timeout of in flight message
+	Touch()
+}
+
+// Handler is list method to process message
+type Handler interface {
+	HandleMessage(msg Message) error
+}
+
+// HandlerFunc is func adapter to use ordinary func as Handler
+type HandlerFunc func(msg Message) error
+
+// HandleMessage is func to call f(msg)
+func (f HandlerFunc) HandleMessage(msg Message) error {
+	return f(msg)
+}
@@ -0,0 +1,329 @@
+package memory
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"aqua-farm-manager/pkg/bus"
+	"aqua-farm-manager/pkg/nsq"
+)
+
+// defaultRequeueDelay is delay of requeued message when the handler does not choose the delay
+const defaultRequeueDelay = time.Second
+
+// Bus is in process message bus with nsq semantic, message published before a topic has channel
+// is kept until the first channel is subscribed and every channel keep its message after the
+// subscription is stopped. Message is not persisted so it is lost when the process exit
+type Bus struct {
+	mu      sync.Mutex
+	topics  map[string]*topic
+	schemas *nsq.Schemas
+}
+
+// Option set options for in memory bus
+type Option func(*Bus)
+
+// NewBus is func to create in memory message bus
+func NewBus(options ...Option) *Bus {
+	b := &Bus{
+		topics: make(map[string]*topic),
+	}
+
+	// Apply options
+	for _, opt := range options {
+		opt(b)
+	}
+
+	return b
+}
+
+// WithSchemaOptions is func to validate every published message against the topic schema
+func WithSchemaOptions(schemas *nsq.Schemas) Option {
+	return Option(
+		func(b *Bus) {
+			b.schemas = schemas
+		})
+}
+
+// Publish is func to publish message into every channel of the topic,
+// message that does not match the topic schema is not published
+func (b *Bus) Publish(topic string, data interface{}) error {
+	body, err := b.marshal(topic, data)
+	if err != nil {
+		return err
+	}
+
+	b.getTopic(topic).put(body)
+	return nil
+}
+
+// MultiPublish is func to publish list message, message that fail to marshal
+// or does not match the topic schema is skipped and reported in the error
+func (b *Bus) MultiPublish(topic string, data []interface{}) error {
+	t := b.getTopic(topic)
+	var invalid int
+	var lastErr error
+	for _, d := range data {
+		body, err := b.marshal(topic, d)
+		if err != nil {
+			invalid++
+			lastErr = err
+			continue
+		}
+		t.put(body)
+	}
+
+	if invalid > 0 {
+		return &nsq.InvalidMessagesError{Count: invalid, Err: lastErr}
+	}
+	return nil
+}
+
+// Subscribe is func to start handler of the topic channel, the channel is created when it does not exist
+func (b *Bus) Subscribe(topic, channel string, handler bus.Handler, config bus.SubscribeConfig) (bus.Subscription, error) {
+	ch := b.getTopic(topic).getChannel(channel)
+
+	concurrency := config.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	timeout := config.MsgTimeout
+	if timeout <= 0 {
+		timeout = defaultRequeueDelay
+	}
+
+	s := &subscription{
+		stop: make(chan struct{}),
+		done: make(chan struct{}),
+	}
+	s.wg.Add(concurrency)
+	for i := 0; i < concurrency; i++ {
+		go s.run(ch, handler, timeout)
+	}
+	go func() {
+		s.wg.Wait()
+		close(s.done)
+	}()
+	return s, nil
+}
+
+// marshal is func to encode message and validate it against the topic schema
+func (b *Bus) marshal(topic string, data interface{}) ([]byte, error) {
+	body, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	if b.schemas != nil {
+		err = b.schemas.Validate(topic, body)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return body, nil
+}
+
+// getTopic is func to get topic by name, the topic is created when it does not exist
+func (b *Bus) getTopic(name string) *topic {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	t, ok := b.topics[name]
+	if !ok {
+		t = &topic{channels: make(map[string]*channel)}
+		b.topics[name] = t
+	}
+	return t
+}
+
+// topic is list channel of a topic
+type topic struct {
+	mu       sync.Mutex
+	channels map[string]*channel
+	// pending is message published before the topic has channel
+	pending [][]byte
+}
+
+// put is func to copy message into every channel
+func (t *topic) put(body []byte) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if len(t.channels) == 0 {
+		t.pending = append(t.pending, body)
+		return
+	}
+	for _, ch := range t.channels {
+		ch.push(&message{body: body, channel: ch})
+	}
+}
+
+// getChannel is func to get channel by name, the first channel of topic receive the pending message
+func (t *topic) getChannel(name string) *channel {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	ch, ok := t.channels[name]
+	if ok {
+		return ch
+	}
+
+	ch = &channel{wake: make(chan struct{})}
+	if len(t.channels) == 0 {
+		for _, body := range t.pending {
+			ch.push(&message{body: body, channel: ch})
+		}
+		t.pending = nil
+	}
+	t.channels[name] = ch
+	return ch
+}
+
+// channel is queue of message shared by every subscription of the channel
+type channel struct {
+	mu    sync.Mutex
+	queue []*message
+	// wake is closed and replaced every time message is pushed
+	wake chan struct{}
+}
+
+// push is func to append message into the queue and wake waiting handler
+func (c *channel) push(m *message) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.queue = append(c.queue, m)
+	close(c.wake)
+	c.wake = make(chan struct{})
+}
+
+// pop is func to wait the next message until stop is closed
+func (c *channel) pop(stop <-chan struct{}) (*message, bool) {
+	for {
+		select {
+		case <-stop:
+			return nil, false
+		default:
+		}
+
+		c.mu.Lock()
+		if len(c.queue) > 0 {
+			m := c.queue[0]
+			c.queue[0] = nil
+			c.queue = c.queue[1:]
+			c.mu.Unlock()
+			return m, true
+		}
+		wake := c.wake
+		c.mu.Unlock()
+
+		select {
+		case <-stop:
+			return nil, false
+		case <-wake:
+		}
+	}
+}
+
+// subscription is list handler worker of a channel
+type subscription struct {
+	stop chan struct{}
+	done chan struct{}
+	once sync.Once
+	wg   sync.WaitGroup
+}
+
+// run is func to deliver message to handler until the subscription is stopped,
+// message that is not responded by the handler is requeued after the timeout
+func (s *subscription) run(ch *channel, handler bus.Handler, timeout time.Duration) {
+	defer s.wg.Done()
+	for {
+		m, ok := ch.pop(s.stop)
+		if !ok {
+			return
+		}
+
+		m.attempts++
+		delivery := &delivery{message: m, attempts: m.attempts}
+		handler.HandleMessage(delivery)
+		if !delivery.isResponded() {
+			delivery.RequeueWithoutBackoff(timeout)
+		}
+	}
+}
+
+// Stop is func to stop receiving new message
+func (s *subscription) Stop() {
+	s.once.Do(func() { close(s.stop) })
+}
+
+// Done is func to get channel that is closed after every handler is returned
+func (s *subscription) Done() <-chan struct{} {
+	return s.done
+}
+
+// message is message stored in a channel
+type message struct {
+	body     []byte
+	attempts uint16
+	channel  *channel
+}
+
+// delivery is a single delivery of message to handler
+type delivery struct {
+	message   *message
+	attempts  uint16
+	mu        sync.Mutex
+	responded bool
+}
+
+// respond is func to mark delivery is responded, it return false when it is already responded
+func (d *delivery) respond() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.responded {
+		return false
+	}
+	d.responded = true
+	return true
+}
+
+// isResponded is func to check delivery is responded
+func (d *delivery) isResponded() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.responded
+}
+
+// Body is func to get message payload
+func (d *delivery) Body() []byte { return d.message.body }
+
+// Attempts is func to get number of time the message is delivered
+func (d *delivery) Attempts() uint16 { return d.attempts }
+
+// Finish is func to remove message from the channel
+func (d *delivery) Finish() { d.respond() }
+
+// Requeue is func to push back the message into the channel after delay
+func (d *delivery) Requeue(delay time.Duration) { d.RequeueWithoutBackoff(delay) }
+
+// RequeueWithoutBackoff is func to push back the message into the channel after delay
+func (d *delivery) RequeueWithoutBackoff(delay time.Duration) {
+	if !d.respond() {
+		return
+	}
+	if delay < 0 {
+		delay = defaultRequeueDelay
+	}
+
+	m := d.message
+	if delay == 0 {
+		m.channel.push(m)
+		return
+	}
+	time.AfterFunc(delay, func() { m.channel.push(m) })
+}
+
+// Touch is func to reset message timeout, in memory message does not have timeout while it is handled
+func (d *delivery) Touch() {}
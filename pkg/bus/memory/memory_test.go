@@ -0,0 +1,193 @@
+package memory
+
+import (
+	"testing"
+	"time"
+
+	"aqua-farm-manager/pkg/bus"
+)
+
+// received is message body and attempts seen by the handler
+type received struct {
+	body     string
+	attempts uint16
+}
+
+// collect is func to create handler that send every delivery into the returned channel and respond it by respond
+func collect(respond func(msg bus.Message)) (bus.Handler, <-chan received) {
+	messages := make(chan received, 100)
+	handler := bus.HandlerFunc(func(msg bus.Message) error {
+		messages <- received{body: string(msg.Body()), attempts: msg.Attempts()}
+		respond(msg)
+		return nil
+	})
+	return handler, messages
+}
+
+// finish is func to respond every message by Finish
+func finish(msg bus.Message) { msg.Finish() }
+
+// subscribe is func to subscribe the topic channel or stop the test, the subscription is stopped after the test
+func subscribe(t *testing.T, b *Bus, channel string, handler bus.Handler, config bus.SubscribeConfig) bus.Subscription {
+	t.Helper()
+	s, err := b.Subscribe("topic", channel, handler, config)
+	if err != nil {
+		t.Fatalf("Subscribe() error = %v", err)
+	}
+	t.Cleanup(s.Stop)
+	return s
+}
+
+// publish is func to publish message into the topic or stop the test
+func publish(t *testing.T, b *Bus, data ...string) {
+	t.Helper()
+	for _, d := range data {
+		if err := b.Publish("topic", d); err != nil {
+			t.Fatalf("Publish() error = %v", err)
+		}
+	}
+}
+
+// expect is func to wait the next message of the handler and check it
+func expect(t *testing.T, messages <-chan received, want received) {
+	t.Helper()
+	select {
+	case got := <-messages:
+		if got != want {
+			t.Errorf("handler got %+v, want %+v", got, want)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("handler does not receive %+v", want)
+	}
+}
+
+// expectNone is func to check the handler does not receive any message for a while
+func expectNone(t *testing.T, messages <-chan received) {
+	t.Helper()
+	select {
+	case got := <-messages:
+		t.Errorf("handler got %+v, want nothing", got)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestBus_Subscribe_Pending(t *testing.T) {
+	b := NewBus()
+	// message published before the topic has channel is kept for the first channel
+	publish(t, b, "a", "b")
+
+	handler, first := collect(finish)
+	subscribe(t, b, "first", handler, bus.SubscribeConfig{})
+	expect(t, first, received{body: `"a"`, attempts: 1})
+	expect(t, first, received{body: `"b"`, attempts: 1})
+
+	// the next channel only receive message published after it is created
+	handler, second := collect(finish)
+	subscribe(t, b, "second", handler, bus.SubscribeConfig{})
+	expectNone(t, second)
+
+	publish(t, b, "c")
+	expect(t, first, received{body: `"c"`, attempts: 1})
+	expect(t, second, received{body: `"c"`, attempts: 1})
+}
+
+func TestBus_Publish_FanOut(t *testing.T) {
+	b := NewBus()
+	handlerA, channelA := collect(finish)
+	handlerB, channelB := collect(finish)
+	subscribe(t, b, "a", handlerA, bus.SubscribeConfig{})
+	subscribe(t, b, "b", handlerB, bus.SubscribeConfig{})
+	// second subscription of channel a share its message
+	subscribe(t, b, "a", handlerA, bus.SubscribeConfig{})
+
+	publish(t, b, "x", "y")
+	for _, messages := range []<-chan received{channelA, channelB} {
+		got := map[string]bool{}
+		for i := 0; i < 2; i++ {
+			select {
+			case m := <-messages:
+				got[m.body] = true
+			case <-time.After(time.Second):
+				t.Fatalf("handler does not receive every message, got %v", got)
+			}
+		}
+		if !got[`"x"`] || !got[`"y"`] {
+			t.Errorf("handler got %v, want every message once", got)
+		}
+		expectNone(t, messages)
+	}
+}
+
+func TestBus_Requeue(t *testing.T) {
+	b := NewBus()
+	handler, messages := collect(func(msg bus.Message) {
+		if msg.Attempts() == 1 {
+			msg.Requeue(10 * time.Millisecond)
+			// message is responded only once
+			msg.Finish()
+			return
+		}
+		msg.Finish()
+	})
+	subscribe(t, b, "channel", handler, bus.SubscribeConfig{})
+
+	publish(t, b, "a")
+	expect(t, messages, received{body: `"a"`, attempts: 1})
+	expect(t, messages, received{body: `"a"`, attempts: 2})
+	expectNone(t, messages)
+}
+
+func TestBus_Subscribe_Timeout(t *testing.T) {
+	b := NewBus()
+	// handler that does not respond the first delivery
+	handler, messages := collect(func(msg bus.Message) {
+		if msg.Attempts() > 1 {
+			msg.Finish()
+		}
+	})
+	timeout := 20 * time.Millisecond
+	subscribe(t, b, "channel", handler, bus.SubscribeConfig{MsgTimeout: timeout})
+
+	publish(t, b, "a")
+	expect(t, messages, received{body: `"a"`, attempts: 1})
+	start := time.Now()
+	expect(t, messages, received{body: `"a"`, attempts: 2})
+	if elapsed := time.Since(start); elapsed < timeout/2 {
+		t.Errorf("message is delivered again after %v, want after timeout %v", elapsed, timeout)
+	}
+	expectNone(t, messages)
+}
+
+func TestBus_Subscription_Stop(t *testing.T) {
+	b := NewBus()
+	release := make(chan struct{})
+	handler, messages := collect(func(msg bus.Message) {
+		<-release
+		msg.Finish()
+	})
+	s := subscribe(t, b, "channel", handler, bus.SubscribeConfig{Concurrency: 2})
+
+	publish(t, b, "a")
+	expect(t, messages, received{body: `"a"`, attempts: 1})
+
+	// Done wait the in flight handler
+	s.Stop()
+	s.Stop()
+	select {
+	case <-s.Done():
+		t.Fatal("Done() is closed before the in flight handler return")
+	case <-time.After(20 * time.Millisecond):
+	}
+	close(release)
+	select {
+	case <-s.Done():
+	case <-time.After(time.Second):
+		t.Fatal("Done() is not closed after the handler return")
+	}
+
+	// message published after stop is kept by the channel until it is subscribed again
+	publish(t, b, "b")
+	expectNone(t, messages)
+	subscribe(t, b, "channel", handler, bus.SubscribeConfig{})
+	expect(t, messages, received{body: `"b"`, attempts: 1})
+}
@@ -0,0 +1,462 @@
+package graphql
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+
+	"aqua-farm-manager/pkg/apperror"
+)
+
+// executor is runner of validated operation that execute it level by level, every resolver of a level is called
+// before the Thunk of the level is called so the loader of the resolver can load every key at once
+type executor struct {
+	ctx       context.Context
+	schema    *Schema
+	doc       *document
+	variables map[string]interface{}
+	errors    []*Error
+	data      interface{}
+	next      []*job
+}
+
+// job is resolved field that is waiting to be completed in its level
+type job struct {
+	def    *Field
+	fields []*field
+	value  interface{}
+	err    error
+	path   []interface{}
+	slot   *slot
+}
+
+// slot is place of a value in the response, null of non null slot is set into its parent
+type slot struct {
+	parent  *slot
+	nonNull bool
+	set     func(v interface{})
+}
+
+// null is func to set null into the nearest nullable slot
+func (s *slot) null() {
+	for s != nil && s.nonNull {
+		s = s.parent
+	}
+	if s != nil {
+		s.set(nil)
+	}
+}
+
+// execute is func to execute query operation op and get its response with every field error
+func (e *executor) execute(op *operationDef) *Response {
+	root := newOrderedMap()
+	rootSlot := &slot{set: func(v interface{}) {
+		if v == nil {
+			e.data = nullData
+			return
+		}
+		e.data = v
+	}}
+	rootSlot.set(root)
+
+	e.executeFields(e.schema.query, nil, op.selections, nil, rootSlot, root)
+	e.run()
+	return &Response{Data: e.data, Errors: e.errors}
+}
+
+// run is func to complete every level of job, the thunk that return another thunk is called again
+// after every thunk of the level is called, the execution is stopped when the context is done
+func (e *executor) run() {
+	for len(e.next) > 0 {
+		level := e.next
+		e.next = nil
+
+		for len(level) > 0 {
+			if err := e.ctx.Err(); err != nil {
+				e.errors = append(e.errors, newError(err, Location{}, nil))
+				return
+			}
+
+			var pending []*job
+			for _, j := range level {
+				if thunk, ok := j.value.(Thunk); ok && j.err == nil {
+					j.value, j.err = thunk()
+					if _, ok := j.value.(Thunk); ok && j.err == nil {
+						pending = append(pending, j)
+						continue
+					}
+				}
+				e.complete(j)
+			}
+			level = pending
+		}
+	}
+}
+
+// executeFields is func to call resolver of every selected field of object obj with source as the parent value,
+// the value is completed in the next level
+func (e *executor) executeFields(obj *Object, source interface{}, selections []selection, path []interface{}, objSlot *slot, result *orderedMap) {
+	for _, c := range e.collectFields(obj, selections, map[string]bool{}) {
+		key, f := c.key, c.fields[0]
+		if f.name == typenameField {
+			result.set(key, obj.Name)
+			continue
+		}
+
+		def := obj.Fields[f.name]
+		_, nonNull := def.Type.(*NonNull)
+		// the key is set first so the field is in the order of the selection
+		result.set(key, nil)
+		j := &job{
+			def:    def,
+			fields: c.fields,
+			path:   appendPath(path, key),
+			slot: &slot{
+				parent:  objSlot,
+				nonNull: nonNull,
+				set:     func(v interface{}) { result.set(key, v) },
+			},
+		}
+		j.value, j.err = e.resolve(def, f, source)
+		e.next = append(e.next, j)
+	}
+}
+
+// resolve is func to get value of field f with its argument from the resolver or from the source
+func (e *executor) resolve(def *Field, f *field, source interface{}) (interface{}, error) {
+	args, err := coerceArguments(def.Args, f.arguments, e.variables)
+	if err != nil {
+		return nil, err
+	}
+	if def.Resolve == nil {
+		return defaultResolve(source, f.name), nil
+	}
+	return def.Resolve(ResolveParams{Context: e.ctx, Source: source, Args: args})
+}
+
+// complete is func to set the resolved value of the job into the response
+func (e *executor) complete(j *job) {
+	if j.err != nil {
+		e.fieldError(j.err, j.fields, j.path, j.slot)
+		return
+	}
+	e.completeValue(j.def.Type, j.value, j.fields, j.path, j.slot)
+}
+
+// completeValue is func to set value v of type t into slot s, the field of object is resolved for the next level
+func (e *executor) completeValue(t Type, v interface{}, fields []*field, path []interface{}, s *slot) {
+	if nn, ok := t.(*NonNull); ok {
+		if isNil(v) {
+			e.fieldError(apperror.New(apperror.CodeInternal, fmt.Sprintf("Cannot return null for non-nullable field %s", fields[0].name)), fields, path, s)
+			return
+		}
+		t = nn.OfType
+	}
+	if isNil(v) {
+		s.set(nil)
+		return
+	}
+
+	switch t := t.(type) {
+	case *Scalar:
+		out, ok := t.serialize(v)
+		if !ok {
+			e.fieldError(apperror.New(apperror.CodeInternal, fmt.Sprintf("%s cannot represent value %v", t.Name, v)), fields, path, s)
+			return
+		}
+		s.set(out)
+	case *List:
+		rv := reflect.ValueOf(v)
+		if rv.Kind() != reflect.Slice && rv.Kind() != reflect.Array {
+			e.fieldError(apperror.New(apperror.CodeInternal, fmt.Sprintf("Expected list for field %s", fields[0].name)), fields, path, s)
+			return
+		}
+		items := make([]interface{}, rv.Len())
+		s.set(items)
+		_, nonNull := t.OfType.(*NonNull)
+		for i := range items {
+			i := i
+			item := &slot{parent: s, nonNull: nonNull, set: func(v interface{}) { items[i] = v }}
+			e.completeValue(t.OfType, rv.Index(i).Interface(), fields, appendPath(path, i), item)
+		}
+	case *Object:
+		result := newOrderedMap()
+		s.set(result)
+		var selections []selection
+		for _, f := range fields {
+			selections = append(selections, f.selections...)
+		}
+		e.executeFields(t, v, selections, path, s, result)
+	}
+}
+
+// fieldError is func to add error of field in path and set null into its slot
+func (e *executor) fieldError(err error, fields []*field, path []interface{}, s *slot) {
+	e.errors = append(e.errors, newError(err, fields[0].loc, path))
+	s.null()
+}
+
+// collectedField is every field selection with the same response key
+type collectedField struct {
+	key    string
+	fields []*field
+}
+
+// collectFields is func to get field of selections on object obj in the order of the selection,
+// fragment is spread once in a selection and the field that is skipped by directive is not collected
+func (e *executor) collectFields(obj *Object, selections []selection, visited map[string]bool) []*collectedField {
+	var collected []*collectedField
+	byKey := map[string]*collectedField{}
+	var walk func(selections []selection)
+	walk = func(selections []selection) {
+		for _, sel := range selections {
+			switch s := sel.(type) {
+			case *field:
+				if !e.include(s.directives) {
+					continue
+				}
+				c, ok := byKey[s.responseKey()]
+				if !ok {
+					c = &collectedField{key: s.responseKey()}
+					byKey[c.key] = c
+					collected = append(collected, c)
+				}
+				c.fields = append(c.fields, s)
+			case *fragmentSpread:
+				fragment := e.doc.fragments[s.name]
+				if visited[s.name] || !e.include(s.directives) || fragment.typeCondition != obj.Name {
+					continue
+				}
+				visited[s.name] = true
+				walk(fragment.selections)
+			case *inlineFragment:
+				if !e.include(s.directives) || (len(s.typeCondition) > 0 && s.typeCondition != obj.Name) {
+					continue
+				}
+				walk(s.selections)
+			}
+		}
+	}
+	walk(selections)
+	return collected
+}
+
+// include is func to evaluate @skip and @include directive
+func (e *executor) include(directives []*directive) bool {
+	for _, d := range directives {
+		args, err := coerceArguments(directiveArgs, d.arguments, e.variables)
+		if err != nil {
+			continue
+		}
+		value, _ := args["if"].(bool)
+		if (d.name == "skip" && value) || (d.name == "include" && !value) {
+			return false
+		}
+	}
+	return true
+}
+
+// defaultResolve is func to get value of field name from map key or struct field with the same json name or name
+func defaultResolve(source interface{}, name string) interface{} {
+	rv := reflect.ValueOf(source)
+	for rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Interface {
+		if rv.IsNil() {
+			return nil
+		}
+		rv = rv.Elem()
+	}
+
+	switch rv.Kind() {
+	case reflect.Map:
+		if rv.Type().Key().Kind() != reflect.String {
+			return nil
+		}
+		if value := rv.MapIndex(reflect.ValueOf(name).Convert(rv.Type().Key())); value.IsValid() {
+			return value.Interface()
+		}
+	case reflect.Struct:
+		for i := 0; i < rv.NumField(); i++ {
+			sf := rv.Type().Field(i)
+			if sf.PkgPath != "" {
+				continue
+			}
+			jsonName := strings.Split(sf.Tag.Get("json"), ",")[0]
+			if jsonName == name || (len(jsonName) == 0 && strings.EqualFold(sf.Name, name)) {
+				return rv.Field(i).Interface()
+			}
+		}
+	}
+	return nil
+}
+
+func isNil(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	switch rv := reflect.ValueOf(v); rv.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice, reflect.Interface, reflect.Func:
+		return rv.IsNil()
+	}
+	return false
+}
+
+// appendPath is func to copy path with key, the path of sibling field must not share the same array
+func appendPath(path []interface{}, key interface{}) []interface{} {
+	res := make([]interface{}, len(path), len(path)+1)
+	copy(res, path)
+	return append(res, key)
+}
+
+// orderedMap is json object that keep the order of the key, the field is in the order of the query
+type orderedMap struct {
+	keys   []string
+	values map[string]interface{}
+}
+
+func newOrderedMap() *orderedMap {
+	return &orderedMap{values: map[string]interface{}{}}
+}
+
+func (m *orderedMap) set(key string, v interface{}) {
+	if _, ok := m.values[key]; !ok {
+		m.keys = append(m.keys, key)
+	}
+	m.values[key] = v
+}
+
+// MarshalJSON is func to encode the map as json object in the order of the key
+func (m *orderedMap) MarshalJSON() ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range m.keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		k, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		v, err := json.Marshal(m.values[key])
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// limiter is measurer of depth and complexity of the operation before it is executed,
+// the value of fragment is kept so fragment that is spread many times is measured once
+type limiter struct {
+	doc          *document
+	variables    map[string]interface{}
+	depths       map[string]int
+	complexities map[string]int
+}
+
+// checkLimits is func to reject operation op that is deeper or more complex than the limit of the schema
+func (s *Schema) checkLimits(doc *document, op *operationDef, variables map[string]interface{}) *Error {
+	l := &limiter{
+		doc:          doc,
+		variables:    variables,
+		depths:       map[string]int{},
+		complexities: map[string]int{},
+	}
+
+	if depth := l.depth(op.selections); depth > s.maxDepth {
+		return validationError(op.loc, "Query depth %d exceeds the max depth %d", depth, s.maxDepth)
+	}
+	if complexity := l.complexity(s.query, op.selections); complexity > s.maxComplexity {
+		return validationError(op.loc, "Query complexity %d exceeds the max complexity %d", complexity, s.maxComplexity)
+	}
+	return nil
+}
+
+// depth is func to get the deepest field of selections, field without selection is in depth 1
+func (l *limiter) depth(selections []selection) int {
+	max := 0
+	for _, sel := range selections {
+		var d int
+		switch s := sel.(type) {
+		case *field:
+			d = 1 + l.depth(s.selections)
+		case *fragmentSpread:
+			var ok bool
+			if d, ok = l.depths[s.name]; !ok {
+				d = l.depth(l.doc.fragments[s.name].selections)
+				l.depths[s.name] = d
+			}
+		case *inlineFragment:
+			d = l.depth(s.selections)
+		}
+		if d > max {
+			max = d
+		}
+	}
+	return max
+}
+
+// complexity is func to get sum complexity of selections on object obj, directive is not evaluated
+// so the complexity is the complexity of the query when every field is included
+func (l *limiter) complexity(obj *Object, selections []selection) int {
+	total := 0
+	visited := map[string]bool{}
+	for _, sel := range selections {
+		switch s := sel.(type) {
+		case *field:
+			total = saturate(total + l.fieldComplexity(obj, s))
+		case *fragmentSpread:
+			if visited[s.name] {
+				continue
+			}
+			visited[s.name] = true
+			c, ok := l.complexities[s.name]
+			if !ok {
+				c = l.complexity(obj, l.doc.fragments[s.name].selections)
+				l.complexities[s.name] = c
+			}
+			total = saturate(total + c)
+		case *inlineFragment:
+			total = saturate(total + l.complexity(obj, s.selections))
+		}
+	}
+	return total
+}
+
+// fieldComplexity is func to get complexity of field f from its ComplexityFunc, the default complexity
+// is 1 plus the complexity of its selection and meta field cost nothing
+func (l *limiter) fieldComplexity(obj *Object, f *field) int {
+	if f.name == typenameField {
+		return 0
+	}
+
+	def := obj.Fields[f.name]
+	child := 0
+	if o, ok := namedType(def.Type).(*Object); ok {
+		child = l.complexity(o, f.selections)
+	}
+	if def.Complexity == nil {
+		return saturate(1 + child)
+	}
+
+	args, err := coerceArguments(def.Args, f.arguments, l.variables)
+	if err != nil {
+		args = map[string]interface{}{}
+	}
+	return saturate(def.Complexity(args, child))
+}
+
+// saturate is func to keep complexity in int32 so the complexity of huge query does not overflow
+func saturate(n int) int {
+	if n > math.MaxInt32 || n < 0 {
+		return math.MaxInt32
+	}
+	return n
+}
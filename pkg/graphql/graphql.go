@@ -0,0 +1,119 @@
+// Package graphql is minimal graphql executor of query operation over a schema that is declared in go,
+// the field is resolved level by level so resolver can return Thunk to batch the load of the same level
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"aqua-farm-manager/pkg/apperror"
+)
+
+// Request is graphql request in the body of http request
+type Request struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName,omitempty"`
+	Variables     map[string]interface{} `json:"variables,omitempty"`
+}
+
+// Response is graphql response, data is not set when the request is rejected before it is executed
+type Response struct {
+	Data   interface{} `json:"data,omitempty"`
+	Errors []*Error    `json:"errors,omitempty"`
+}
+
+// Location is line and column of the query that cause the error, it start from 1
+type Location struct {
+	Line   int `json:"line"`
+	Column int `json:"column"`
+}
+
+// Error is graphql error, the path is set for error of field resolution
+// and the code of the error is in the extensions
+type Error struct {
+	Message    string        `json:"message"`
+	Locations  []Location    `json:"locations,omitempty"`
+	Path       []interface{} `json:"path,omitempty"`
+	Extensions Extensions    `json:"extensions"`
+	err        error
+}
+
+// Extensions is error code of graphql error and the violation of every field for validation error
+type Extensions struct {
+	Code   apperror.Code     `json:"code"`
+	Fields []apperror.Detail `json:"fields,omitempty"`
+}
+
+// Error is func to get the message of the error
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// Unwrap is func to get the error of the resolver or the app error of the request
+func (e *Error) Unwrap() error {
+	return e.err
+}
+
+// newError is func to create graphql error from err, the message is the safe message of apperror.From
+func newError(err error, loc Location, path []interface{}) *Error {
+	e := apperror.From(err)
+	res := &Error{
+		Message:    e.Message,
+		Path:       path,
+		Extensions: Extensions{Code: e.Code, Fields: e.Fields},
+		err:        err,
+	}
+	if loc.Line > 0 {
+		res.Locations = []Location{loc}
+	}
+	return res
+}
+
+// NewError is func to create graphql error of err that is not caused by the query,
+// e.g the body of http request that cannot be decoded
+func NewError(err error) *Error {
+	return newError(err, Location{}, nil)
+}
+
+// syntaxError is func to create error of document that cannot be parsed
+func syntaxError(loc Location, format string, args ...interface{}) *Error {
+	message := "Syntax Error: " + fmt.Sprintf(format, args...)
+	return newError(apperror.New(apperror.CodeBadRequest, message), loc, nil)
+}
+
+// validationError is func to create error of document that is not valid against the schema or the limit
+func validationError(loc Location, format string, args ...interface{}) *Error {
+	return newError(apperror.New(apperror.CodeValidationFailed, fmt.Sprintf(format, args...)), loc, nil)
+}
+
+// nullData is data of executed request when null of non null field is propagated to the root,
+// it is different from nil data that is not written for rejected request
+var nullData = json.RawMessage("null")
+
+// Do is func to parse, validate and execute query request r against schema s,
+// the request is rejected with syntax or validation error when it is not executed
+func (s *Schema) Do(ctx context.Context, r Request) *Response {
+	doc, err := parse(r.Query, s.maxDepth)
+	if err != nil {
+		return &Response{Errors: []*Error{err.(*Error)}}
+	}
+
+	v := &validator{schema: s, doc: doc}
+	op := v.validate(r.OperationName)
+	if len(v.errors) > 0 {
+		return &Response{Errors: v.errors}
+	}
+
+	variables, errs := coerceVariables(op, r.Variables)
+	if len(errs) > 0 {
+		return &Response{Errors: errs}
+	}
+
+	if err := s.checkLimits(doc, op, variables); err != nil {
+		return &Response{Errors: []*Error{err}}
+	}
+
+	e := &executor{ctx: ctx, schema: s, doc: doc, variables: variables}
+	return e.execute(op)
+}
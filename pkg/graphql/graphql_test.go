@@ -0,0 +1,271 @@
+package graphql
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"testing"
+
+	"aqua-farm-manager/pkg/apperror"
+)
+
+type testPond struct {
+	Name  string  `json:"name"`
+	Depth float64 `json:"depth"`
+}
+
+type testFarm struct {
+	ID    uint       `json:"id"`
+	Name  string     `json:"name"`
+	Ponds []testPond `json:"ponds"`
+}
+
+// newTestSchema is func to create schema of farm and pond, echo return the coerced argument
+// so the coercion of literal and variable can be checked from the response
+func newTestSchema(t *testing.T) *Schema {
+	farms := []testFarm{
+		{ID: 1, Name: "Blue Lagoon", Ponds: []testPond{{Name: "Kolam A", Depth: 1.5}, {Name: "Kolam B", Depth: 2}}},
+		{ID: 2, Name: "Coral Bay"},
+	}
+
+	pond := &Object{
+		Name: "Pond",
+		Fields: Fields{
+			"name":  {Type: NewNonNull(String)},
+			"depth": {Type: Float},
+		},
+	}
+	farm := &Object{
+		Name: "Farm",
+		Fields: Fields{
+			"id":    {Type: NewNonNull(ID)},
+			"name":  {Type: NewNonNull(String)},
+			"ponds": {Type: NewList(pond)},
+		},
+	}
+	query := &Object{
+		Name: "Query",
+		Fields: Fields{
+			"farm": {
+				Type: farm,
+				Args: Args{"id": {Type: NewNonNull(ID)}},
+				Resolve: func(p ResolveParams) (interface{}, error) {
+					for _, f := range farms {
+						if fmt.Sprint(f.ID) == p.Args["id"] {
+							return f, nil
+						}
+					}
+					return nil, apperror.New(apperror.CodeFarmNotFound, "Farm Is Not Exists")
+				},
+			},
+			"farms": {
+				Type: NewList(farm),
+				Args: Args{"size": {Type: Int, DefaultValue: 20}},
+				Resolve: func(p ResolveParams) (interface{}, error) {
+					size := p.Args["size"].(int)
+					if size > len(farms) {
+						size = len(farms)
+					}
+					return farms[:size], nil
+				},
+			},
+			"echo": {
+				Type: String,
+				Args: Args{
+					"text":  {Type: String},
+					"count": {Type: Int},
+					"ratio": {Type: Float},
+					"flag":  {Type: Boolean},
+					"ids":   {Type: NewList(NewNonNull(ID))},
+				},
+				Resolve: func(p ResolveParams) (interface{}, error) {
+					data, err := json.Marshal(p.Args)
+					return string(data), err
+				},
+			},
+			"broken": {
+				Type: NewNonNull(String),
+				Resolve: func(p ResolveParams) (interface{}, error) {
+					return nil, errors.New("database is down")
+				},
+			},
+		},
+	}
+
+	schema, err := NewSchema(query)
+	if err != nil {
+		t.Fatalf("NewSchema() error = %v", err)
+	}
+	return schema
+}
+
+func TestSchema_Do(t *testing.T) {
+	schema := newTestSchema(t)
+	tests := []struct {
+		name    string
+		request Request
+		want    string
+	}{
+		{
+			name:    "string argument with escape sequence",
+			request: Request{Query: `{ echo(text: "kolam \"A\"\n\u00e9") }`},
+			want:    `{"data":{"echo":"{\"text\":\"kolam \\\"A\\\"\\né\"}"}}`,
+		},
+		{
+			name:    "block string is rejected",
+			request: Request{Query: `{ echo(text: """kolam""") }`},
+			want:    `{"errors":[{"message":"Syntax Error: Block string is not supported","locations":[{"line":1,"column":14}],"extensions":{"code":"BAD_REQUEST"}}]}`,
+		},
+		{
+			name: "variables are coerced into argument type",
+			request: Request{
+				Query:     `query Echo($text: String, $count: Int, $ratio: Float, $flag: Boolean!, $ids: [ID!]) { echo(text: $text, count: $count, ratio: $ratio, flag: $flag, ids: $ids) }`,
+				Variables: map[string]interface{}{"text": "kolam", "count": float64(3), "ratio": float64(2), "flag": true, "ids": []interface{}{float64(7), "8"}},
+			},
+			want: `{"data":{"echo":"{\"count\":3,\"flag\":true,\"ids\":[\"7\",\"8\"],\"ratio\":2,\"text\":\"kolam\"}"}}`,
+		},
+		{
+			name: "single variable value is coerced into list",
+			request: Request{
+				Query:     `query Echo($ids: [ID!]) { echo(ids: $ids) }`,
+				Variables: map[string]interface{}{"ids": "9"},
+			},
+			want: `{"data":{"echo":"{\"ids\":[\"9\"]}"}}`,
+		},
+		{
+			name:    "default value of variable",
+			request: Request{Query: `query Farms($size: Int = 1) { farms(size: $size) { name } }`},
+			want:    `{"data":{"farms":[{"name":"Blue Lagoon"}]}}`,
+		},
+		{
+			name:    "default value of argument",
+			request: Request{Query: `{ farms { id } }`},
+			want:    `{"data":{"farms":[{"id":"1"},{"id":"2"}]}}`,
+		},
+		{
+			name: "invalid variable value",
+			request: Request{
+				Query:     `query Echo($count: Int) { echo(count: $count) }`,
+				Variables: map[string]interface{}{"count": 1.5},
+			},
+			want: `{"errors":[{"message":"Variable \"$count\" got invalid value 1.5, want Int","locations":[{"line":1,"column":12}],"extensions":{"code":"VALIDATION_FAILED"}}]}`,
+		},
+		{
+			name:    "required variable is not provided",
+			request: Request{Query: `query Farm($id: ID!) { farm(id: $id) { name } }`},
+			want:    `{"errors":[{"message":"Variable \"$id\" of required type \"ID!\" was not provided","locations":[{"line":1,"column":12}],"extensions":{"code":"VALIDATION_FAILED"}}]}`,
+		},
+		{
+			name:    "int literal out of range",
+			request: Request{Query: `{ echo(count: 2147483648) }`},
+			want:    `{"errors":[{"message":"Argument \"count\" of \"Query.echo\" has invalid value 2147483648, want Int","locations":[{"line":1,"column":15}],"extensions":{"code":"VALIDATION_FAILED"}}]}`,
+		},
+		{
+			name:    "fragment spread",
+			request: Request{Query: `{ farm(id: 1) { ...farmInfo } } fragment farmInfo on Farm { id name ponds { ...pondInfo } } fragment pondInfo on Pond { name depth }`},
+			want:    `{"data":{"farm":{"id":"1","name":"Blue Lagoon","ponds":[{"name":"Kolam A","depth":1.5},{"name":"Kolam B","depth":2}]}}}`,
+		},
+		{
+			name:    "inline fragment with and without type condition",
+			request: Request{Query: `{ farm(id: 2) { ... on Farm { id } ... @include(if: true) { name } ... @skip(if: true) { ponds { name } } } }`},
+			want:    `{"data":{"farm":{"id":"2","name":"Coral Bay"}}}`,
+		},
+		{
+			name:    "fragment cycle",
+			request: Request{Query: `{ farm(id: 1) { ...a } } fragment a on Farm { ...b } fragment b on Farm { ...a }`},
+			want:    `{"errors":[{"message":"Cannot spread fragment \"a\" within itself","locations":[{"line":1,"column":75}],"extensions":{"code":"VALIDATION_FAILED"}}]}`,
+		},
+		{
+			name:    "aliases of the same field",
+			request: Request{Query: `{ first: farm(id: 1) { title: name } second: farm(id: 2) { title: name } }`},
+			want:    `{"data":{"first":{"title":"Blue Lagoon"},"second":{"title":"Coral Bay"}}}`,
+		},
+		{
+			name:    "alias conflict",
+			request: Request{Query: `{ farm(id: 1) { title: name title: id } }`},
+			want:    `{"errors":[{"message":"Fields \"title\" conflict because \"name\" and \"id\" are different fields","locations":[{"line":1,"column":29}],"extensions":{"code":"VALIDATION_FAILED"}}]}`,
+		},
+		{
+			name:    "syntax error location on the next line",
+			request: Request{Query: "{\n  farms {\n    name\n  }\n  farm(id: 1) {"},
+			want:    `{"errors":[{"message":"Syntax Error: Unexpected \u003cEOF\u003e","locations":[{"line":5,"column":16}],"extensions":{"code":"BAD_REQUEST"}}]}`,
+		},
+		{
+			name:    "validation error location of every error",
+			request: Request{Query: "{\n  farms { readings }\n  pond\n}"},
+			want: `{"errors":[{"message":"Cannot query field \"readings\" on type \"Farm\"","locations":[{"line":2,"column":11}],"extensions":{"code":"VALIDATION_FAILED"}},` +
+				`{"message":"Cannot query field \"pond\" on type \"Query\"","locations":[{"line":3,"column":3}],"extensions":{"code":"VALIDATION_FAILED"}}]}`,
+		},
+		{
+			name:    "field error location and path",
+			request: Request{Query: "{\n  farms { name }\n  missing: farm(id: 3) { name }\n}"},
+			want: `{"data":{"farms":[{"name":"Blue Lagoon"},{"name":"Coral Bay"}],"missing":null},` +
+				`"errors":[{"message":"Farm Is Not Exists","locations":[{"line":3,"column":3}],"path":["missing"],"extensions":{"code":"FARM_NOT_FOUND"}}]}`,
+		},
+		{
+			name:    "null of non null field is propagated to the root",
+			request: Request{Query: `{ broken }`},
+			want:    `{"data":null,"errors":[{"message":"Internal Server Error","locations":[{"line":1,"column":3}],"path":["broken"],"extensions":{"code":"INTERNAL_ERROR"}}]}`,
+		},
+		{
+			name:    "nested list with null list",
+			request: Request{Query: `{ farms { ponds { name } } }`},
+			want:    `{"data":{"farms":[{"ponds":[{"name":"Kolam A"},{"name":"Kolam B"}]},{"ponds":null}]}}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(schema.Do(context.Background(), tt.request))
+			if err != nil {
+				t.Fatalf("json.Marshal() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Schema.Do() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSchema_DoMaxDepth(t *testing.T) {
+	schema := newTestSchema(t)
+	WithMaxDepthOptions(2)(schema)
+
+	tests := []struct {
+		name  string
+		query string
+		want  string
+	}{
+		{
+			name:  "selection set in the max depth",
+			query: `{ farm(id: 1) { ... on Farm { name } } }`,
+			want:  `{"data":{"farm":{"name":"Blue Lagoon"}}}`,
+		},
+		{
+			name:  "selection set is deeper than max depth",
+			query: `{ farm(id: 1) { ponds { name } } }`,
+			want:  `{"errors":[{"message":"Query depth exceeds the max depth 2","locations":[{"line":1,"column":23}],"extensions":{"code":"VALIDATION_FAILED"}}]}`,
+		},
+		{
+			name:  "fragment spread deeper than max depth",
+			query: `{ farm(id: 1) { ...ponds } } fragment ponds on Farm { ponds { name } }`,
+			want:  `{"errors":[{"message":"Query depth 3 exceeds the max depth 2","locations":[{"line":1,"column":1}],"extensions":{"code":"VALIDATION_FAILED"}}]}`,
+		},
+		{
+			name:  "value is deeper than max depth",
+			query: `{ echo(ids: [[["1"]]]) }`,
+			want:  `{"errors":[{"message":"Value depth exceeds the max depth 2","locations":[{"line":1,"column":15}],"extensions":{"code":"VALIDATION_FAILED"}}]}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := json.Marshal(schema.Do(context.Background(), Request{Query: tt.query}))
+			if err != nil {
+				t.Fatalf("json.Marshal() error = %v", err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("Schema.Do() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,243 @@
+package graphql
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// tokenKind is kind of lexical token of graphql document
+type tokenKind int
+
+// list token kind
+const (
+	tokenEOF tokenKind = iota
+	tokenPunctuator
+	tokenName
+	tokenInt
+	tokenFloat
+	tokenString
+)
+
+// token is lexical token with its position in the document
+type token struct {
+	kind  tokenKind
+	value string
+	loc   Location
+}
+
+// String is func to get token representation that is used in syntax error
+func (t token) String() string {
+	switch t.kind {
+	case tokenEOF:
+		return "<EOF>"
+	case tokenString:
+		return fmt.Sprintf("%q", t.value)
+	}
+	return t.value
+}
+
+// byteOrderMark is ignored like white space
+const byteOrderMark = "\ufeff"
+
+// lexer is scanner that split graphql document into token, comma and comment is ignored
+type lexer struct {
+	src  string
+	pos  int
+	line int
+	col  int
+}
+
+func newLexer(src string) *lexer {
+	return &lexer{src: src, line: 1, col: 1}
+}
+
+// next is func to read next token of the document
+func (l *lexer) next() (token, error) {
+	l.skipIgnored()
+
+	loc := Location{Line: l.line, Column: l.col}
+	if l.pos >= len(l.src) {
+		return token{kind: tokenEOF, loc: loc}, nil
+	}
+
+	c := l.src[l.pos]
+	switch {
+	case strings.HasPrefix(l.src[l.pos:], "..."):
+		l.advance(3)
+		return token{kind: tokenPunctuator, value: "...", loc: loc}, nil
+	case strings.IndexByte("!$()[]{}:=@|&", c) >= 0:
+		l.advance(1)
+		return token{kind: tokenPunctuator, value: string(c), loc: loc}, nil
+	case c == '_' || isLetter(c):
+		start := l.pos
+		for l.pos < len(l.src) && (l.src[l.pos] == '_' || isLetter(l.src[l.pos]) || isDigit(l.src[l.pos])) {
+			l.advance(1)
+		}
+		return token{kind: tokenName, value: l.src[start:l.pos], loc: loc}, nil
+	case c == '-' || isDigit(c):
+		return l.readNumber(loc)
+	case c == '"':
+		value, err := l.readString(loc)
+		return token{kind: tokenString, value: value, loc: loc}, err
+	}
+
+	r, _ := utf8.DecodeRuneInString(l.src[l.pos:])
+	return token{}, syntaxError(loc, "Unexpected character %q", r)
+}
+
+// skipIgnored is func to skip white space, line terminator, comma and comment
+func (l *lexer) skipIgnored() {
+	for l.pos < len(l.src) {
+		switch c := l.src[l.pos]; {
+		case c == '\n':
+			l.pos++
+			l.line++
+			l.col = 1
+		case c == ' ' || c == '\t' || c == '\r' || c == ',':
+			l.advance(1)
+		case c == '#':
+			for l.pos < len(l.src) && l.src[l.pos] != '\n' {
+				l.advance(1)
+			}
+		case strings.HasPrefix(l.src[l.pos:], byteOrderMark):
+			l.advance(len(byteOrderMark))
+		default:
+			return
+		}
+	}
+}
+
+// readNumber is func to read int or float value, number must not be followed by name or dot
+func (l *lexer) readNumber(loc Location) (token, error) {
+	start := l.pos
+	kind := tokenInt
+	if l.peek() == '-' {
+		l.advance(1)
+	}
+	if !l.readDigits() {
+		return token{}, syntaxError(loc, "Invalid number, expected digit after \"-\"")
+	}
+	if l.peek() == '.' {
+		kind = tokenFloat
+		l.advance(1)
+		if !l.readDigits() {
+			return token{}, syntaxError(loc, "Invalid number, expected digit after \".\"")
+		}
+	}
+	if c := l.peek(); c == 'e' || c == 'E' {
+		kind = tokenFloat
+		l.advance(1)
+		if c := l.peek(); c == '+' || c == '-' {
+			l.advance(1)
+		}
+		if !l.readDigits() {
+			return token{}, syntaxError(loc, "Invalid number, expected digit in exponent")
+		}
+	}
+	if c := l.peek(); c == '.' || c == '_' || isLetter(c) {
+		return token{}, syntaxError(loc, "Invalid number, unexpected %q", c)
+	}
+	return token{kind: kind, value: l.src[start:l.pos], loc: loc}, nil
+}
+
+func (l *lexer) readDigits() bool {
+	start := l.pos
+	for isDigit(l.peek()) {
+		l.advance(1)
+	}
+	return l.pos > start
+}
+
+// readString is func to read quoted string with its escape sequence, block string is not supported
+func (l *lexer) readString(loc Location) (string, error) {
+	if strings.HasPrefix(l.src[l.pos:], `"""`) {
+		return "", syntaxError(loc, "Block string is not supported")
+	}
+	l.advance(1)
+
+	var sb strings.Builder
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		switch {
+		case c == '"':
+			l.advance(1)
+			return sb.String(), nil
+		case c == '\n' || c == '\r':
+			return "", syntaxError(loc, "Unterminated string")
+		case c == '\\':
+			if l.pos+1 >= len(l.src) {
+				return "", syntaxError(loc, "Unterminated string")
+			}
+			escape := l.src[l.pos+1]
+			l.advance(2)
+			switch escape {
+			case '"', '\\', '/':
+				sb.WriteByte(escape)
+			case 'b':
+				sb.WriteByte('\b')
+			case 'f':
+				sb.WriteByte('\f')
+			case 'n':
+				sb.WriteByte('\n')
+			case 'r':
+				sb.WriteByte('\r')
+			case 't':
+				sb.WriteByte('\t')
+			case 'u':
+				var r rune
+				if l.pos+4 > len(l.src) || !parseHex(l.src[l.pos:l.pos+4], &r) {
+					return "", syntaxError(loc, "Invalid unicode escape sequence")
+				}
+				sb.WriteRune(r)
+				l.advance(4)
+			default:
+				return "", syntaxError(loc, "Invalid escape sequence \\%c", escape)
+			}
+		default:
+			r, size := utf8.DecodeRuneInString(l.src[l.pos:])
+			sb.WriteRune(r)
+			l.advance(size)
+		}
+	}
+	return "", syntaxError(loc, "Unterminated string")
+}
+
+func (l *lexer) peek() byte {
+	if l.pos < len(l.src) {
+		return l.src[l.pos]
+	}
+	return 0
+}
+
+// advance is func to move n byte in the same line
+func (l *lexer) advance(n int) {
+	l.pos += n
+	l.col += n
+}
+
+func parseHex(s string, r *rune) bool {
+	for _, c := range s {
+		var d rune
+		switch {
+		case c >= '0' && c <= '9':
+			d = c - '0'
+		case c >= 'a' && c <= 'f':
+			d = c - 'a' + 10
+		case c >= 'A' && c <= 'F':
+			d = c - 'A' + 10
+		default:
+			return false
+		}
+		*r = *r*16 + d
+	}
+	return true
+}
+
+func isLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
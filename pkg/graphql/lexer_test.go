@@ -0,0 +1,127 @@
+package graphql
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLexer_next(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     string
+		want    []token
+		wantErr string
+	}{
+		{
+			name: "punctuator, name and number",
+			src:  "{ farm(id: -12, depth: 1.5e3) ... }",
+			want: []token{
+				{kind: tokenPunctuator, value: "{", loc: Location{1, 1}},
+				{kind: tokenName, value: "farm", loc: Location{1, 3}},
+				{kind: tokenPunctuator, value: "(", loc: Location{1, 7}},
+				{kind: tokenName, value: "id", loc: Location{1, 8}},
+				{kind: tokenPunctuator, value: ":", loc: Location{1, 10}},
+				{kind: tokenInt, value: "-12", loc: Location{1, 12}},
+				{kind: tokenName, value: "depth", loc: Location{1, 17}},
+				{kind: tokenPunctuator, value: ":", loc: Location{1, 22}},
+				{kind: tokenFloat, value: "1.5e3", loc: Location{1, 24}},
+				{kind: tokenPunctuator, value: ")", loc: Location{1, 29}},
+				{kind: tokenPunctuator, value: "...", loc: Location{1, 31}},
+				{kind: tokenPunctuator, value: "}", loc: Location{1, 35}},
+			},
+		},
+		{
+			name: "comment, comma and new line are ignored",
+			src:  "\ufeff# farm list\n  farms,,\n\tname",
+			want: []token{
+				{kind: tokenName, value: "farms", loc: Location{2, 3}},
+				{kind: tokenName, value: "name", loc: Location{3, 2}},
+			},
+		},
+		{
+			name: "string",
+			src:  `"Blue Lagoon" "kolam ikan"`,
+			want: []token{
+				{kind: tokenString, value: "Blue Lagoon", loc: Location{1, 1}},
+				{kind: tokenString, value: "kolam ikan", loc: Location{1, 15}},
+			},
+		},
+		{
+			name: "string with escape sequence",
+			src:  `"a\"b\\c\/d\b\f\n\r\t\u00e9\u0041"`,
+			want: []token{
+				{kind: tokenString, value: "a\"b\\c/d\b\f\n\r\téA", loc: Location{1, 1}},
+			},
+		},
+		{
+			name: "string with unicode character",
+			src:  `"kolam 🐟"`,
+			want: []token{
+				{kind: tokenString, value: "kolam 🐟", loc: Location{1, 1}},
+			},
+		},
+		{
+			name:    "block string is not supported",
+			src:     `"""block"""`,
+			wantErr: "Syntax Error: Block string is not supported",
+		},
+		{
+			name:    "unterminated string",
+			src:     `"abc`,
+			wantErr: "Syntax Error: Unterminated string",
+		},
+		{
+			name:    "string with new line",
+			src:     "\"abc\ndef\"",
+			wantErr: "Syntax Error: Unterminated string",
+		},
+		{
+			name:    "invalid escape sequence",
+			src:     `"\x41"`,
+			wantErr: `Syntax Error: Invalid escape sequence \x`,
+		},
+		{
+			name:    "invalid unicode escape sequence",
+			src:     `"\u00g1"`,
+			wantErr: "Syntax Error: Invalid unicode escape sequence",
+		},
+		{
+			name:    "number followed by name",
+			src:     "12abc",
+			wantErr: `Syntax Error: Invalid number, unexpected 'a'`,
+		},
+		{
+			name:    "minus without digit",
+			src:     "-x",
+			wantErr: `Syntax Error: Invalid number, expected digit after "-"`,
+		},
+		{
+			name:    "unexpected character",
+			src:     "farms ?",
+			want:    []token{{kind: tokenName, value: "farms", loc: Location{1, 1}}},
+			wantErr: `Syntax Error: Unexpected character '?'`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := newLexer(tt.src)
+			var got []token
+			var err error
+			for {
+				var tok token
+				tok, err = l.next()
+				if err != nil || tok.kind == tokenEOF {
+					break
+				}
+				got = append(got, tok)
+			}
+
+			if (err != nil || tt.wantErr != "") && (err == nil || err.Error() != tt.wantErr) {
+				t.Fatalf("lexer.next() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("lexer.next() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
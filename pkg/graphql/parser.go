@@ -0,0 +1,608 @@
+package graphql
+
+import (
+	"fmt"
+)
+
+// document is parsed graphql request document
+type document struct {
+	operations []*operationDef
+	fragments  map[string]*fragmentDef
+}
+
+// operationDef is operation of the document, only query operation can be executed
+type operationDef struct {
+	kind       string
+	name       string
+	variables  []*variableDef
+	directives []*directive
+	selections []selection
+	loc        Location
+}
+
+// variableDef is variable that is declared by the operation
+type variableDef struct {
+	name         string
+	typ          *typeRef
+	defaultValue *value
+	loc          Location
+}
+
+// typeRef is type that is written in variable definition
+type typeRef struct {
+	name    string
+	elem    *typeRef // element of list type, name is empty
+	nonNull bool
+}
+
+// String is func to get type representation as it is written in the document
+func (t *typeRef) String() string {
+	s := t.name
+	if t.elem != nil {
+		s = "[" + t.elem.String() + "]"
+	}
+	if t.nonNull {
+		s += "!"
+	}
+	return s
+}
+
+// fragmentDef is named fragment of the document
+type fragmentDef struct {
+	name          string
+	typeCondition string
+	directives    []*directive
+	selections    []selection
+	loc           Location
+}
+
+// selection is field, fragment spread or inline fragment
+type selection interface {
+	location() Location
+}
+
+// field is field selection with its alias, argument and sub selection
+type field struct {
+	alias      string
+	name       string
+	arguments  []*argument
+	directives []*directive
+	selections []selection
+	loc        Location
+}
+
+// responseKey is func to get key of the field in the response, it is the alias when it is set
+func (f *field) responseKey() string {
+	if len(f.alias) > 0 {
+		return f.alias
+	}
+	return f.name
+}
+
+func (f *field) location() Location { return f.loc }
+
+// fragmentSpread is selection of named fragment
+type fragmentSpread struct {
+	name       string
+	directives []*directive
+	loc        Location
+}
+
+func (f *fragmentSpread) location() Location { return f.loc }
+
+// inlineFragment is selection of fragment without name, type condition is optional
+type inlineFragment struct {
+	typeCondition string
+	directives    []*directive
+	selections    []selection
+	loc           Location
+}
+
+func (f *inlineFragment) location() Location { return f.loc }
+
+// directive is directive of field or fragment
+type directive struct {
+	name      string
+	arguments []*argument
+	loc       Location
+}
+
+// argument is named value of field or directive
+type argument struct {
+	name  string
+	value *value
+	loc   Location
+}
+
+// valueKind is kind of input value literal
+type valueKind int
+
+// list value kind
+const (
+	valueVariable valueKind = iota
+	valueInt
+	valueFloat
+	valueString
+	valueBoolean
+	valueNull
+	valueEnum
+	valueList
+	valueObject
+)
+
+// value is input value literal, raw is the variable name or the scalar value
+type value struct {
+	kind   valueKind
+	raw    string
+	list   []*value
+	fields []*argument
+	loc    Location
+}
+
+// String is func to get value representation as it is written in the document
+func (v *value) String() string {
+	switch v.kind {
+	case valueVariable:
+		return "$" + v.raw
+	case valueString:
+		return fmt.Sprintf("%q", v.raw)
+	case valueList:
+		s := "["
+		for i, item := range v.list {
+			if i > 0 {
+				s += ", "
+			}
+			s += item.String()
+		}
+		return s + "]"
+	case valueObject:
+		s := "{"
+		for i, f := range v.fields {
+			if i > 0 {
+				s += ", "
+			}
+			s += f.name + ": " + f.value.String()
+		}
+		return s + "}"
+	}
+	return v.raw
+}
+
+// parser is recursive descent parser of executable graphql document, the nesting of selection set
+// and input value is counted so the deep query is rejected before the parser recurse into it
+type parser struct {
+	lexer      *lexer
+	tok        token
+	maxDepth   int
+	depth      int
+	valueDepth int
+}
+
+// parse is func to parse query into document, type system definition is not supported
+// and the selection set or input value that is nested deeper than maxDepth is rejected
+func parse(query string, maxDepth int) (*document, error) {
+	p := &parser{lexer: newLexer(query), maxDepth: maxDepth}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	doc := &document{fragments: map[string]*fragmentDef{}}
+	for {
+		switch {
+		case p.tok.kind == tokenEOF:
+			if len(doc.operations) == 0 {
+				return nil, syntaxError(p.tok.loc, "Document does not contain any operation")
+			}
+			return doc, nil
+		case p.peek("{"), p.peekName("query"), p.peekName("mutation"), p.peekName("subscription"):
+			op, err := p.parseOperation()
+			if err != nil {
+				return nil, err
+			}
+			doc.operations = append(doc.operations, op)
+		case p.peekName("fragment"):
+			fragment, err := p.parseFragment()
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := doc.fragments[fragment.name]; ok {
+				return nil, validationError(fragment.loc, "There can be only one fragment named %q", fragment.name)
+			}
+			doc.fragments[fragment.name] = fragment
+		default:
+			return nil, p.unexpected()
+		}
+	}
+}
+
+func (p *parser) parseOperation() (*operationDef, error) {
+	op := &operationDef{kind: "query", loc: p.tok.loc}
+	if p.peek("{") {
+		selections, err := p.parseSelectionSet()
+		op.selections = selections
+		return op, err
+	}
+
+	op.kind = p.tok.value
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	if p.tok.kind == tokenName {
+		op.name = p.tok.value
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	}
+
+	var err error
+	if p.peek("(") {
+		if op.variables, err = p.parseVariableDefs(); err != nil {
+			return nil, err
+		}
+	}
+	if op.directives, err = p.parseDirectives(); err != nil {
+		return nil, err
+	}
+	op.selections, err = p.parseSelectionSet()
+	return op, err
+}
+
+func (p *parser) parseVariableDefs() ([]*variableDef, error) {
+	var defs []*variableDef
+	err := p.many("(", ")", func() error {
+		def := &variableDef{loc: p.tok.loc}
+		if err := p.expect("$"); err != nil {
+			return err
+		}
+		name, err := p.parseName()
+		if err != nil {
+			return err
+		}
+		def.name = name
+		if err := p.expect(":"); err != nil {
+			return err
+		}
+		if def.typ, err = p.parseType(); err != nil {
+			return err
+		}
+		if p.peek("=") {
+			if err := p.advance(); err != nil {
+				return err
+			}
+			if def.defaultValue, err = p.parseValue(true); err != nil {
+				return err
+			}
+		}
+		defs = append(defs, def)
+		return nil
+	})
+	return defs, err
+}
+
+func (p *parser) parseType() (*typeRef, error) {
+	t := &typeRef{}
+	if p.peek("[") {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		elem, err := p.parseType()
+		if err != nil {
+			return nil, err
+		}
+		t.elem = elem
+		if err := p.expect("]"); err != nil {
+			return nil, err
+		}
+	} else {
+		name, err := p.parseName()
+		if err != nil {
+			return nil, err
+		}
+		t.name = name
+	}
+
+	if p.peek("!") {
+		t.nonNull = true
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+	}
+	return t, nil
+}
+
+func (p *parser) parseFragment() (*fragmentDef, error) {
+	fragment := &fragmentDef{loc: p.tok.loc}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	name, err := p.parseName()
+	if err != nil {
+		return nil, err
+	}
+	if name == "on" {
+		return nil, syntaxError(fragment.loc, "Unexpected Name \"on\"")
+	}
+	fragment.name = name
+
+	if !p.peekName("on") {
+		return nil, p.unexpected()
+	}
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+	if fragment.typeCondition, err = p.parseName(); err != nil {
+		return nil, err
+	}
+	if fragment.directives, err = p.parseDirectives(); err != nil {
+		return nil, err
+	}
+	fragment.selections, err = p.parseSelectionSet()
+	return fragment, err
+}
+
+// parseSelectionSet is func to parse selection set of field, operation or fragment definition,
+// its nesting is the depth of the field in the selection set
+func (p *parser) parseSelectionSet() ([]selection, error) {
+	if p.depth >= p.maxDepth {
+		return nil, validationError(p.tok.loc, "Query depth exceeds the max depth %d", p.maxDepth)
+	}
+	p.depth++
+	defer func() { p.depth-- }()
+	return p.selectionSet()
+}
+
+// selectionSet is func to parse selection set without counting the nesting, it is used by inline
+// fragment because its field is in the same depth with the field of the parent selection set
+func (p *parser) selectionSet() ([]selection, error) {
+	var selections []selection
+	err := p.many("{", "}", func() error {
+		var s selection
+		var err error
+		if p.peek("...") {
+			s, err = p.parseFragmentSelection()
+		} else {
+			s, err = p.parseField()
+		}
+		selections = append(selections, s)
+		return err
+	})
+	return selections, err
+}
+
+func (p *parser) parseField() (*field, error) {
+	f := &field{loc: p.tok.loc}
+	name, err := p.parseName()
+	if err != nil {
+		return nil, err
+	}
+	f.name = name
+
+	if p.peek(":") {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		f.alias = name
+		if f.name, err = p.parseName(); err != nil {
+			return nil, err
+		}
+	}
+	if f.arguments, err = p.parseArguments(false); err != nil {
+		return nil, err
+	}
+	if f.directives, err = p.parseDirectives(); err != nil {
+		return nil, err
+	}
+	if p.peek("{") {
+		f.selections, err = p.parseSelectionSet()
+	}
+	return f, err
+}
+
+func (p *parser) parseFragmentSelection() (selection, error) {
+	loc := p.tok.loc
+	if err := p.advance(); err != nil {
+		return nil, err
+	}
+
+	if p.tok.kind == tokenName && !p.peekName("on") {
+		spread := &fragmentSpread{name: p.tok.value, loc: loc}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		var err error
+		spread.directives, err = p.parseDirectives()
+		return spread, err
+	}
+
+	fragment := &inlineFragment{loc: loc}
+	if p.peekName("on") {
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		name, err := p.parseName()
+		if err != nil {
+			return nil, err
+		}
+		fragment.typeCondition = name
+	}
+	var err error
+	if fragment.directives, err = p.parseDirectives(); err != nil {
+		return nil, err
+	}
+	fragment.selections, err = p.selectionSet()
+	return fragment, err
+}
+
+func (p *parser) parseArguments(constant bool) ([]*argument, error) {
+	if !p.peek("(") {
+		return nil, nil
+	}
+
+	var args []*argument
+	err := p.many("(", ")", func() error {
+		arg := &argument{loc: p.tok.loc}
+		name, err := p.parseName()
+		if err != nil {
+			return err
+		}
+		arg.name = name
+		if err := p.expect(":"); err != nil {
+			return err
+		}
+		arg.value, err = p.parseValue(constant)
+		args = append(args, arg)
+		return err
+	})
+	return args, err
+}
+
+func (p *parser) parseDirectives() ([]*directive, error) {
+	var directives []*directive
+	for p.peek("@") {
+		d := &directive{loc: p.tok.loc}
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		name, err := p.parseName()
+		if err != nil {
+			return nil, err
+		}
+		d.name = name
+		if d.arguments, err = p.parseArguments(false); err != nil {
+			return nil, err
+		}
+		directives = append(directives, d)
+	}
+	return directives, nil
+}
+
+// parseValue is func to parse input value, variable is not allowed in constant value e.g default value
+func (p *parser) parseValue(constant bool) (*value, error) {
+	if p.valueDepth >= p.maxDepth {
+		return nil, validationError(p.tok.loc, "Value depth exceeds the max depth %d", p.maxDepth)
+	}
+	p.valueDepth++
+	defer func() { p.valueDepth-- }()
+
+	v := &value{raw: p.tok.value, loc: p.tok.loc}
+	switch {
+	case p.peek("$") && !constant:
+		if err := p.advance(); err != nil {
+			return nil, err
+		}
+		name, err := p.parseName()
+		v.kind, v.raw = valueVariable, name
+		return v, err
+	case p.peek("["):
+		v.kind = valueList
+		err := p.some("[", "]", func() error {
+			item, err := p.parseValue(constant)
+			v.list = append(v.list, item)
+			return err
+		})
+		return v, err
+	case p.peek("{"):
+		v.kind = valueObject
+		err := p.some("{", "}", func() error {
+			f := &argument{loc: p.tok.loc}
+			name, err := p.parseName()
+			if err != nil {
+				return err
+			}
+			f.name = name
+			if err := p.expect(":"); err != nil {
+				return err
+			}
+			f.value, err = p.parseValue(constant)
+			v.fields = append(v.fields, f)
+			return err
+		})
+		return v, err
+	case p.tok.kind == tokenInt:
+		v.kind = valueInt
+	case p.tok.kind == tokenFloat:
+		v.kind = valueFloat
+	case p.tok.kind == tokenString:
+		v.kind = valueString
+	case p.peekName("true"), p.peekName("false"):
+		v.kind = valueBoolean
+	case p.peekName("null"):
+		v.kind = valueNull
+	case p.tok.kind == tokenName:
+		v.kind = valueEnum
+	default:
+		return nil, p.unexpected()
+	}
+	return v, p.advance()
+}
+
+func (p *parser) parseName() (string, error) {
+	if p.tok.kind != tokenName {
+		return "", p.unexpected()
+	}
+	name := p.tok.value
+	return name, p.advance()
+}
+
+// many is func to parse one or more item between open and close punctuator
+func (p *parser) many(open, close string, item func() error) error {
+	if err := p.expect(open); err != nil {
+		return err
+	}
+	for {
+		if err := item(); err != nil {
+			return err
+		}
+		if p.peek(close) {
+			return p.advance()
+		}
+		if p.tok.kind == tokenEOF {
+			return p.unexpected()
+		}
+	}
+}
+
+// some is func to parse zero or more item between open and close punctuator
+func (p *parser) some(open, close string, item func() error) error {
+	if err := p.expect(open); err != nil {
+		return err
+	}
+	for !p.peek(close) {
+		if p.tok.kind == tokenEOF {
+			return p.unexpected()
+		}
+		if err := item(); err != nil {
+			return err
+		}
+	}
+	return p.advance()
+}
+
+func (p *parser) expect(punctuator string) error {
+	if !p.peek(punctuator) {
+		return syntaxError(p.tok.loc, "Expected %q, found %s", punctuator, p.tok)
+	}
+	return p.advance()
+}
+
+func (p *parser) peek(punctuator string) bool {
+	return p.tok.kind == tokenPunctuator && p.tok.value == punctuator
+}
+
+func (p *parser) peekName(name string) bool {
+	return p.tok.kind == tokenName && p.tok.value == name
+}
+
+func (p *parser) advance() error {
+	tok, err := p.lexer.next()
+	if err != nil {
+		return err
+	}
+	p.tok = tok
+	return nil
+}
+
+func (p *parser) unexpected() error {
+	return syntaxError(p.tok.loc, "Unexpected %s", p.tok)
+}
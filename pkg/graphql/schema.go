@@ -0,0 +1,366 @@
+package graphql
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Type is type of field or argument of the schema, it is *Scalar, *Object, *List or *NonNull
+type Type interface {
+	String() string
+}
+
+// Scalar is leaf type, the value is serialized into json value and the argument is coerced into go value
+type Scalar struct {
+	Name        string
+	Description string
+	serialize   func(v interface{}) (interface{}, bool)
+	coerce      func(v interface{}) (interface{}, bool)
+}
+
+// String is func to get name of the scalar
+func (s *Scalar) String() string { return s.Name }
+
+// list built in scalar, the argument of Int is int, Float is float64, String and ID is string and Boolean is bool
+var (
+	Int = &Scalar{
+		Name:        "Int",
+		Description: "32 bit signed integer",
+		serialize:   serializeInt,
+		coerce:      coerceInt,
+	}
+	Float = &Scalar{
+		Name:        "Float",
+		Description: "Double precision floating point",
+		serialize:   serializeFloat,
+		coerce:      coerceFloat,
+	}
+	String = &Scalar{
+		Name:        "String",
+		Description: "UTF-8 character sequence",
+		serialize:   serializeString,
+		coerce:      coerceString,
+	}
+	Boolean = &Scalar{
+		Name:        "Boolean",
+		Description: "true or false",
+		serialize:   coerceBoolean,
+		coerce:      coerceBoolean,
+	}
+	ID = &Scalar{
+		Name:        "ID",
+		Description: "Unique identifier that is serialized as string, integer is accepted as argument",
+		serialize:   serializeString,
+		coerce:      coerceID,
+	}
+)
+
+// Object is output type with list of field, the field of object can refer to the object itself
+// so the fields can be set after the object is created
+type Object struct {
+	Name        string
+	Description string
+	Fields      Fields
+}
+
+// String is func to get name of the object
+func (o *Object) String() string { return o.Name }
+
+// Fields is list field of object by name
+type Fields map[string]*Field
+
+// Field is field of object, the resolver get the value of the field from the parent value.
+// Field without resolver get the value from the map key or the struct field that have the same name or json name
+type Field struct {
+	Type        Type
+	Description string
+	Args        Args
+	Resolve     ResolveFunc
+	Complexity  ComplexityFunc
+}
+
+// Args is list argument of field by name
+type Args map[string]*Arg
+
+// Arg is argument of field, the default value is used when the argument is not set
+type Arg struct {
+	Type         Type
+	Description  string
+	DefaultValue interface{}
+}
+
+// ResolveParams is parameter of resolver, source is the value of the parent object and
+// args is the coerced argument with its default value
+type ResolveParams struct {
+	Context context.Context
+	Source  interface{}
+	Args    map[string]interface{}
+}
+
+// ResolveFunc is func to get value of a field, the value can be Thunk so the value of every field
+// in the same level is loaded together before any Thunk is called
+type ResolveFunc func(p ResolveParams) (interface{}, error)
+
+// Thunk is func to get the value of a field later, the thunk can return another Thunk that is called
+// after every thunk of the same level is called
+type Thunk func() (interface{}, error)
+
+// ComplexityFunc is func to get complexity of a field from its argument and the complexity of its selection,
+// it is used to reject the expensive query before it is executed
+type ComplexityFunc func(args map[string]interface{}, childComplexity int) int
+
+// List is list of another type
+type List struct {
+	OfType Type
+}
+
+// NewList is func to create list of t
+func NewList(t Type) *List { return &List{OfType: t} }
+
+// String is func to get type representation of the list
+func (l *List) String() string { return "[" + l.OfType.String() + "]" }
+
+// NonNull is another type that cannot be null, null of non null field make its parent null
+type NonNull struct {
+	OfType Type
+}
+
+// NewNonNull is func to create non null of t
+func NewNonNull(t Type) *NonNull { return &NonNull{OfType: t} }
+
+// String is func to get type representation of the non null type
+func (n *NonNull) String() string { return n.OfType.String() + "!" }
+
+// Schema is query type of graphql api with the depth and complexity limit of the query
+type Schema struct {
+	query         *Object
+	maxDepth      int
+	maxComplexity int
+}
+
+// Option set options for schema limit
+type Option func(*Schema)
+
+const (
+	defaultMaxDepth      = 10
+	defaultMaxComplexity = 1000
+)
+
+// WithMaxDepthOptions is func to set max depth of the query, the field in the root is in depth 1
+func WithMaxDepthOptions(depth int) Option {
+	return Option(
+		func(s *Schema) {
+			if depth <= 0 {
+				depth = defaultMaxDepth
+			}
+			s.maxDepth = depth
+		})
+}
+
+// WithMaxComplexityOptions is func to set max complexity of the query, field without ComplexityFunc
+// cost 1 and list of object multiply the complexity of its selection by the complexity func
+func WithMaxComplexityOptions(complexity int) Option {
+	return Option(
+		func(s *Schema) {
+			if complexity <= 0 {
+				complexity = defaultMaxComplexity
+			}
+			s.maxComplexity = complexity
+		})
+}
+
+// namePattern is pattern of name in graphql document
+var namePattern = regexp.MustCompile(`^[_A-Za-z][_0-9A-Za-z]*$`)
+
+// NewSchema is func to create Schema with query type, every type that is reachable from the query
+// is checked so invalid schema is found when the server start instead of when it is queried
+func NewSchema(query *Object, options ...Option) (*Schema, error) {
+	s := &Schema{
+		query:         query,
+		maxDepth:      defaultMaxDepth,
+		maxComplexity: defaultMaxComplexity,
+	}
+
+	// Apply options
+	for _, opt := range options {
+		opt(s)
+	}
+
+	if query == nil {
+		return nil, fmt.Errorf("query type is required")
+	}
+	if err := checkObject(query, map[string]*Object{}); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// checkObject is func to check name, field and argument of object o and every object of its field
+func checkObject(o *Object, seen map[string]*Object) error {
+	if !namePattern.MatchString(o.Name) || strings.HasPrefix(o.Name, "__") {
+		return fmt.Errorf("invalid object name %q", o.Name)
+	}
+	if other, ok := seen[o.Name]; ok {
+		if other != o {
+			return fmt.Errorf("object %q is defined twice", o.Name)
+		}
+		return nil
+	}
+	seen[o.Name] = o
+	if len(o.Fields) == 0 {
+		return fmt.Errorf("object %q must have field", o.Name)
+	}
+
+	for _, name := range sortedFieldNames(o.Fields) {
+		f := o.Fields[name]
+		if !namePattern.MatchString(name) || strings.HasPrefix(name, "__") {
+			return fmt.Errorf("invalid field name %s.%s", o.Name, name)
+		}
+		if f == nil || f.Type == nil {
+			return fmt.Errorf("field %s.%s must have type", o.Name, name)
+		}
+		for argName, arg := range f.Args {
+			if !namePattern.MatchString(argName) || arg == nil || !isInputType(arg.Type) {
+				return fmt.Errorf("invalid argument %s.%s(%s)", o.Name, name, argName)
+			}
+		}
+		if obj, ok := namedType(f.Type).(*Object); ok {
+			if err := checkObject(obj, seen); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func sortedFieldNames(fields Fields) []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// namedType is func to get the scalar or object of t without list and non null
+func namedType(t Type) Type {
+	for {
+		switch v := t.(type) {
+		case *List:
+			t = v.OfType
+		case *NonNull:
+			t = v.OfType
+		default:
+			return t
+		}
+	}
+}
+
+// isInputType is func to check t can be type of argument, that is scalar or list of scalar
+func isInputType(t Type) bool {
+	_, ok := namedType(t).(*Scalar)
+	return ok
+}
+
+// scalars is built in scalar by name that can be used as variable type
+var scalars = map[string]*Scalar{
+	Int.Name:     Int,
+	Float.Name:   Float,
+	String.Name:  String,
+	Boolean.Name: Boolean,
+	ID.Name:      ID,
+}
+
+func serializeInt(v interface{}) (interface{}, bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return rv.Uint(), true
+	case reflect.Float32, reflect.Float64:
+		if f := rv.Float(); f == math.Trunc(f) {
+			return int64(f), true
+		}
+	}
+	return nil, false
+}
+
+func coerceInt(v interface{}) (interface{}, bool) {
+	switch n := v.(type) {
+	case int:
+		if n >= math.MinInt32 && n <= math.MaxInt32 {
+			return n, true
+		}
+	case float64:
+		if n == math.Trunc(n) && n >= math.MinInt32 && n <= math.MaxInt32 {
+			return int(n), true
+		}
+	}
+	return nil, false
+}
+
+func coerceFloat(v interface{}) (interface{}, bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(rv.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(rv.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return rv.Float(), true
+	}
+	return nil, false
+}
+
+// serializeFloat is func to get float value that can be encoded in json
+func serializeFloat(v interface{}) (interface{}, bool) {
+	f, ok := coerceFloat(v)
+	if !ok || math.IsNaN(f.(float64)) || math.IsInf(f.(float64), 0) {
+		return nil, false
+	}
+	return f, true
+}
+
+func serializeString(v interface{}) (interface{}, bool) {
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.String:
+		return rv.String(), true
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(rv.Uint(), 10), true
+	}
+	return nil, false
+}
+
+func coerceString(v interface{}) (interface{}, bool) {
+	s, ok := v.(string)
+	return s, ok
+}
+
+func coerceID(v interface{}) (interface{}, bool) {
+	switch n := v.(type) {
+	case string:
+		return n, true
+	case int:
+		return strconv.Itoa(n), true
+	case float64:
+		if n == math.Trunc(n) {
+			return strconv.FormatFloat(n, 'f', -1, 64), true
+		}
+	}
+	return nil, false
+}
+
+func coerceBoolean(v interface{}) (interface{}, bool) {
+	b, ok := v.(bool)
+	return b, ok
+}
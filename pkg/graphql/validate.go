@@ -0,0 +1,526 @@
+package graphql
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"aqua-farm-manager/pkg/apperror"
+)
+
+// typenameField is meta field to get name of the object, it can be selected in every object
+const typenameField = "__typename"
+
+// directiveArgs is argument of @skip and @include, the only supported directive
+var directiveArgs = Args{"if": {Type: NewNonNull(Boolean)}}
+
+// validator is checker of the document against the schema before it is executed,
+// every violation is collected so client get every error at once
+type validator struct {
+	schema    *Schema
+	doc       *document
+	errors    []*Error
+	variables map[string]*variableDef
+	used      map[string]bool
+	checked   map[string]bool
+}
+
+func (v *validator) report(loc Location, format string, args ...interface{}) {
+	v.errors = append(v.errors, validationError(loc, format, args...))
+}
+
+// validate is func to validate the operation with operationName and the fragment that is used by it,
+// nil is returned when the operation is not found
+func (v *validator) validate(operationName string) *operationDef {
+	names := map[string]bool{}
+	anonymous := 0
+	for _, op := range v.doc.operations {
+		if len(op.name) == 0 {
+			anonymous++
+		} else if names[op.name] {
+			v.report(op.loc, "There can be only one operation named %q", op.name)
+		}
+		names[op.name] = true
+	}
+	if anonymous > 0 && len(v.doc.operations) > 1 {
+		v.report(v.doc.operations[0].loc, "Anonymous operation must be the only defined operation")
+	}
+
+	var op *operationDef
+	switch {
+	case len(operationName) > 0:
+		for _, o := range v.doc.operations {
+			if o.name == operationName {
+				op = o
+			}
+		}
+		if op == nil {
+			v.report(Location{}, "Unknown operation named %q", operationName)
+			return nil
+		}
+	case len(v.doc.operations) == 1:
+		op = v.doc.operations[0]
+	default:
+		v.report(Location{}, "Operation name is required when the document contains multiple operations")
+		return nil
+	}
+
+	if op.kind != "query" {
+		v.report(op.loc, "Only query operation is supported, %s is not supported", op.kind)
+		return op
+	}
+
+	v.variables = map[string]*variableDef{}
+	v.used = map[string]bool{}
+	v.checked = map[string]bool{}
+	for _, def := range op.variables {
+		if _, ok := v.variables[def.name]; ok {
+			v.report(def.loc, "There can be only one variable named \"$%s\"", def.name)
+			continue
+		}
+		v.variables[def.name] = def
+
+		t, ok := resolveTypeRef(def.typ)
+		if !ok {
+			v.report(def.loc, "Variable \"$%s\" cannot be of type %q, the type must be %s or list of it", def.name, def.typ, scalarNames())
+			continue
+		}
+		if def.defaultValue != nil {
+			if _, ok := coerceLiteral(t, def.defaultValue, nil); !ok {
+				v.report(def.defaultValue.loc, "Variable \"$%s\" of type %q has invalid default value %s", def.name, def.typ, def.defaultValue)
+			}
+		}
+	}
+
+	for _, d := range op.directives {
+		v.report(d.loc, "Directive \"@%s\" may not be used on QUERY", d.name)
+	}
+	v.checkConflicts(v.schema.query, op.selections)
+	v.checkSelections(v.schema.query, op.selections, nil)
+
+	for _, def := range op.variables {
+		if !v.used[def.name] {
+			v.report(def.loc, "Variable \"$%s\" is never used in operation %q", def.name, op.name)
+		}
+	}
+	return op
+}
+
+// checkSelections is func to check selections on object obj, fragments is the fragment that is being
+// spread so the fragment that spread itself is found
+func (v *validator) checkSelections(obj *Object, selections []selection, fragments []string) {
+	for _, sel := range selections {
+		switch s := sel.(type) {
+		case *field:
+			v.checkDirectives(s.directives)
+			if s.name == typenameField {
+				v.checkArguments(nil, s.arguments, s.name, s.loc)
+				if len(s.selections) > 0 {
+					v.report(s.loc, "Field %q must not have a selection since type \"String\" has no subfields", s.name)
+				}
+				continue
+			}
+
+			def, ok := obj.Fields[s.name]
+			if !ok {
+				v.report(s.loc, "Cannot query field %q on type %q", s.name, obj.Name)
+				continue
+			}
+			v.checkArguments(def.Args, s.arguments, obj.Name+"."+s.name, s.loc)
+
+			switch t := namedType(def.Type).(type) {
+			case *Object:
+				if len(s.selections) == 0 {
+					v.report(s.loc, "Field %q of type %q must have a selection of subfields", s.name, def.Type)
+					continue
+				}
+				v.checkConflicts(t, s.selections)
+				v.checkSelections(t, s.selections, nil)
+			default:
+				if len(s.selections) > 0 {
+					v.report(s.loc, "Field %q must not have a selection since type %q has no subfields", s.name, def.Type)
+				}
+			}
+		case *fragmentSpread:
+			v.checkDirectives(s.directives)
+			fragment, ok := v.doc.fragments[s.name]
+			if !ok {
+				v.report(s.loc, "Unknown fragment %q", s.name)
+				continue
+			}
+			if contains(fragments, s.name) {
+				v.report(s.loc, "Cannot spread fragment %q within itself", s.name)
+				continue
+			}
+			if fragment.typeCondition != obj.Name {
+				v.report(s.loc, "Fragment %q cannot be spread here as objects of type %q can never be of type %q", s.name, obj.Name, fragment.typeCondition)
+				continue
+			}
+			// fragment is checked once, it is always spread on the same object
+			if v.checked[s.name] {
+				continue
+			}
+			v.checked[s.name] = true
+			for _, d := range fragment.directives {
+				v.report(d.loc, "Directive \"@%s\" may not be used on fragment definition", d.name)
+			}
+			v.checkSelections(obj, fragment.selections, append(fragments, s.name))
+		case *inlineFragment:
+			v.checkDirectives(s.directives)
+			if len(s.typeCondition) > 0 && s.typeCondition != obj.Name {
+				v.report(s.loc, "Fragment cannot be spread here as objects of type %q can never be of type %q", obj.Name, s.typeCondition)
+				continue
+			}
+			v.checkSelections(obj, s.selections, fragments)
+		}
+	}
+}
+
+// checkConflicts is func to check every field with the same response key select the same field with the same argument,
+// so the field can be merged when it is executed
+func (v *validator) checkConflicts(obj *Object, selections []selection) {
+	seen := map[string]*field{}
+	var walk func(selections []selection, visited map[string]bool)
+	walk = func(selections []selection, visited map[string]bool) {
+		for _, sel := range selections {
+			switch s := sel.(type) {
+			case *field:
+				other, ok := seen[s.responseKey()]
+				if !ok {
+					seen[s.responseKey()] = s
+					continue
+				}
+				if other.name != s.name {
+					v.report(s.loc, "Fields %q conflict because %q and %q are different fields", s.responseKey(), other.name, s.name)
+				} else if argumentsString(other.arguments) != argumentsString(s.arguments) {
+					v.report(s.loc, "Fields %q conflict because they have differing arguments", s.responseKey())
+				}
+			case *fragmentSpread:
+				fragment, ok := v.doc.fragments[s.name]
+				if !ok || visited[s.name] || fragment.typeCondition != obj.Name {
+					continue
+				}
+				visited[s.name] = true
+				walk(fragment.selections, visited)
+			case *inlineFragment:
+				walk(s.selections, visited)
+			}
+		}
+	}
+	walk(selections, map[string]bool{})
+}
+
+// checkDirectives is func to check directive of field or fragment, only @skip and @include is supported
+func (v *validator) checkDirectives(directives []*directive) {
+	seen := map[string]bool{}
+	for _, d := range directives {
+		if d.name != "skip" && d.name != "include" {
+			v.report(d.loc, "Unknown directive \"@%s\"", d.name)
+			continue
+		}
+		if seen[d.name] {
+			v.report(d.loc, "The directive \"@%s\" can only be used once at this location", d.name)
+		}
+		seen[d.name] = true
+		v.checkArguments(directiveArgs, d.arguments, "@"+d.name, d.loc)
+	}
+}
+
+// checkArguments is func to check the argument of field or directive with name in loc against its definition defs
+func (v *validator) checkArguments(defs Args, args []*argument, name string, loc Location) {
+	seen := map[string]bool{}
+	for _, arg := range args {
+		if seen[arg.name] {
+			v.report(arg.loc, "There can be only one argument named %q", arg.name)
+			continue
+		}
+		seen[arg.name] = true
+
+		def, ok := defs[arg.name]
+		if !ok {
+			v.report(arg.loc, "Unknown argument %q on %q", arg.name, name)
+			continue
+		}
+		if _, ok := coerceLiteral(def.Type, arg.value, v.checkVariable); !ok {
+			v.report(arg.value.loc, "Argument %q of %q has invalid value %s, want %s", arg.name, name, arg.value, def.Type)
+		}
+	}
+
+	for _, argName := range sortedArgNames(defs) {
+		def := defs[argName]
+		if _, ok := def.Type.(*NonNull); ok && def.DefaultValue == nil && !seen[argName] {
+			v.report(loc, "Argument %q of %q of type %q is required, but it was not provided", argName, name, def.Type)
+		}
+	}
+}
+
+// checkVariable is func to check variable is defined and its type can be used in the position of type t
+func (v *validator) checkVariable(name string, t Type) (interface{}, bool) {
+	def, ok := v.variables[name]
+	if !ok {
+		v.report(Location{}, "Variable \"$%s\" is not defined", name)
+		return nil, true
+	}
+	v.used[name] = true
+
+	varType, ok := resolveTypeRef(def.typ)
+	if ok && !isTypeCompatible(varType, t, def.defaultValue != nil) {
+		v.report(def.loc, "Variable \"$%s\" of type %q used in position expecting type %q", name, def.typ, t)
+	}
+	return nil, true
+}
+
+// isTypeCompatible is func to check variable of type varType can be used in position of type t,
+// nullable variable with default value can be used in non null position
+func isTypeCompatible(varType, t Type, hasDefault bool) bool {
+	if nn, ok := t.(*NonNull); ok {
+		varNonNull, ok := varType.(*NonNull)
+		if !ok {
+			return hasDefault && isTypeCompatible(varType, nn.OfType, false)
+		}
+		return isTypeCompatible(varNonNull.OfType, nn.OfType, false)
+	}
+	if nn, ok := varType.(*NonNull); ok {
+		return isTypeCompatible(nn.OfType, t, false)
+	}
+
+	switch t := t.(type) {
+	case *List:
+		varList, ok := varType.(*List)
+		return ok && isTypeCompatible(varList.OfType, t.OfType, false)
+	case *Scalar:
+		return varType == t
+	}
+	return false
+}
+
+// resolveTypeRef is func to get schema type of variable type, variable can only be scalar or list of scalar
+func resolveTypeRef(ref *typeRef) (Type, bool) {
+	var t Type
+	if ref.elem != nil {
+		elem, ok := resolveTypeRef(ref.elem)
+		if !ok {
+			return nil, false
+		}
+		t = NewList(elem)
+	} else {
+		scalar, ok := scalars[ref.name]
+		if !ok {
+			return nil, false
+		}
+		t = scalar
+	}
+
+	if ref.nonNull {
+		t = NewNonNull(t)
+	}
+	return t, true
+}
+
+// variableFunc is func to get value of variable in position of type t
+type variableFunc func(name string, t Type) (interface{}, bool)
+
+// coerceLiteral is func to get go value of literal v as type t, variable is get from variable func.
+// Single value is coerced into list of one item for list type
+func coerceLiteral(t Type, v *value, variable variableFunc) (interface{}, bool) {
+	if v.kind == valueVariable {
+		if variable == nil {
+			return nil, false
+		}
+		return variable(v.raw, t)
+	}
+
+	if nn, ok := t.(*NonNull); ok {
+		if v.kind == valueNull {
+			return nil, false
+		}
+		t = nn.OfType
+	}
+	if v.kind == valueNull {
+		return nil, true
+	}
+
+	switch t := t.(type) {
+	case *List:
+		if v.kind != valueList {
+			item, ok := coerceLiteral(t.OfType, v, variable)
+			return []interface{}{item}, ok
+		}
+		items := make([]interface{}, 0, len(v.list))
+		for _, literal := range v.list {
+			item, ok := coerceLiteral(t.OfType, literal, variable)
+			if !ok {
+				return nil, false
+			}
+			items = append(items, item)
+		}
+		return items, true
+	case *Scalar:
+		var raw interface{}
+		switch v.kind {
+		case valueInt:
+			n, err := strconv.Atoi(v.raw)
+			if err != nil {
+				return nil, false
+			}
+			raw = n
+		case valueFloat:
+			f, err := strconv.ParseFloat(v.raw, 64)
+			if err != nil {
+				return nil, false
+			}
+			raw = f
+		case valueString:
+			raw = v.raw
+		case valueBoolean:
+			raw = v.raw == "true"
+		default:
+			return nil, false
+		}
+		return t.coerce(raw)
+	}
+	return nil, false
+}
+
+// coerceInput is func to get go value of json value of variable as type t
+func coerceInput(t Type, raw interface{}) (interface{}, bool) {
+	if nn, ok := t.(*NonNull); ok {
+		if raw == nil {
+			return nil, false
+		}
+		t = nn.OfType
+	}
+	if raw == nil {
+		return nil, true
+	}
+
+	switch t := t.(type) {
+	case *List:
+		list, ok := raw.([]interface{})
+		if !ok {
+			item, ok := coerceInput(t.OfType, raw)
+			return []interface{}{item}, ok
+		}
+		items := make([]interface{}, 0, len(list))
+		for _, value := range list {
+			item, ok := coerceInput(t.OfType, value)
+			if !ok {
+				return nil, false
+			}
+			items = append(items, item)
+		}
+		return items, true
+	case *Scalar:
+		return t.coerce(raw)
+	}
+	return nil, false
+}
+
+// coerceVariables is func to get go value of every variable of the operation from the json value of the request,
+// the default value is used when the variable is not set
+func coerceVariables(op *operationDef, input map[string]interface{}) (map[string]interface{}, []*Error) {
+	variables := map[string]interface{}{}
+	var errs []*Error
+	for _, def := range op.variables {
+		t, _ := resolveTypeRef(def.typ)
+		raw, ok := input[def.name]
+		if !ok {
+			if def.defaultValue != nil {
+				variables[def.name], _ = coerceLiteral(t, def.defaultValue, nil)
+			} else if _, nonNull := t.(*NonNull); nonNull {
+				errs = append(errs, validationError(def.loc, "Variable \"$%s\" of required type %q was not provided", def.name, def.typ))
+			}
+			continue
+		}
+
+		value, ok := coerceInput(t, raw)
+		if !ok {
+			data, _ := json.Marshal(raw)
+			errs = append(errs, validationError(def.loc, "Variable \"$%s\" got invalid value %s, want %s", def.name, data, def.typ))
+			continue
+		}
+		variables[def.name] = value
+	}
+	return variables, errs
+}
+
+// coerceArguments is func to get go value of argument of field or directive with the default value of the argument,
+// argument with variable that is not set is not set
+func coerceArguments(defs Args, args []*argument, variables map[string]interface{}) (map[string]interface{}, error) {
+	values := map[string]interface{}{}
+	for name, def := range defs {
+		if def.DefaultValue != nil {
+			values[name] = def.DefaultValue
+		}
+	}
+
+	for _, arg := range args {
+		def, ok := defs[arg.name]
+		if !ok {
+			continue
+		}
+		if arg.value.kind == valueVariable {
+			if _, ok := variables[arg.value.raw]; !ok {
+				continue
+			}
+		}
+
+		value, ok := coerceLiteral(def.Type, arg.value, func(name string, t Type) (interface{}, bool) {
+			value := variables[name]
+			if _, nonNull := t.(*NonNull); nonNull && value == nil {
+				return nil, false
+			}
+			return value, true
+		})
+		if !ok {
+			return nil, apperror.New(apperror.CodeValidationFailed, fmt.Sprintf("Argument %q has invalid value %s, want %s", arg.name, arg.value, def.Type))
+		}
+		values[arg.name] = value
+	}
+
+	for name, def := range defs {
+		if _, nonNull := def.Type.(*NonNull); nonNull && values[name] == nil {
+			return nil, apperror.New(apperror.CodeValidationFailed, fmt.Sprintf("Argument %q of type %q is required", name, def.Type))
+		}
+	}
+	return values, nil
+}
+
+// argumentsString is func to get representation of args that is the same for the same argument in any order
+func argumentsString(args []*argument) string {
+	items := make([]string, 0, len(args))
+	for _, arg := range args {
+		items = append(items, arg.name+":"+arg.value.String())
+	}
+	sort.Strings(items)
+	return strings.Join(items, ",")
+}
+
+func sortedArgNames(args Args) []string {
+	names := make([]string, 0, len(args))
+	for name := range args {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func scalarNames() string {
+	names := make([]string, 0, len(scalars))
+	for name := range scalars {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,53 @@
+package memorydb
+
+import (
+	"sync"
+
+	"aqua-farm-manager/pkg/postgres"
+)
+
+// DB is in memory database that hold the same table of postgres,
+// every in memory store share the DB so join between table behave like in postgres
+type DB struct {
+	mu     sync.RWMutex
+	tables *Tables
+}
+
+// Tables is list table stored in DB, every row is kept in insertion order so it is ordered by id
+type Tables struct {
+	Farms             []*postgres.Farms
+	Ponds             []*postgres.Ponds
+	FarmPondsMappings []*postgres.FarmPondsMapping
+	StatMetrics       []*postgres.StatMetrics
+	sequences         map[string]uint
+}
+
+// NewDB is func to create empty in memory database
+func NewDB() *DB {
+	return &DB{
+		tables: &Tables{
+			sequences: make(map[string]uint),
+		},
+	}
+}
+
+// View is func to read the tables, fn must not modify the tables
+func (db *DB) View(fn func(t *Tables) error) error {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	return fn(db.tables)
+}
+
+// Update is func to modify the tables, fn is run exclusively so it behave like a transaction
+// as long as fn validate the request before it modify the tables
+func (db *DB) Update(fn func(t *Tables) error) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	return fn(db.tables)
+}
+
+// NextID is func to get the next id of table, it start from 1 like postgres serial column
+func (t *Tables) NextID(table string) uint {
+	t.sequences[table]++
+	return t.sequences[table]
+}
@@ -33,6 +33,20 @@ func (m *MockNsqMethod) EXPECT() *MockNsqMethodMockRecorder {
 	return m.recorder
 }
 
+// MultiPublish mocks base method.
+func (m *MockNsqMethod) MultiPublish(topic string, data []interface{}) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MultiPublish", topic, data)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// MultiPublish indicates an expected call of MultiPublish.
+func (mr *MockNsqMethodMockRecorder) MultiPublish(topic, data interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MultiPublish", reflect.TypeOf((*MockNsqMethod)(nil).MultiPublish), topic, data)
+}
+
 // Publish mocks base method.
 func (m *MockNsqMethod) Publish(topic string, data interface{}) error {
 	m.ctrl.T.Helper()
@@ -2,39 +2,138 @@ package nsq
 
 import (
 	"encoding/json"
+	"fmt"
+
+	"aqua-farm-manager/pkg/prometheus"
 
 	"github.com/nsqio/go-nsq"
 )
 
+// publishedTotal count published message by topic and status
+var publishedTotal = prometheus.Default.NewCounterVec(
+	"aqua_farm_nsq_published_messages_total",
+	"Total number of message published to nsq by topic and status.",
+	"topic", "status",
+)
+
 // NsqMethod is list all available method for nsq
 type NsqMethod interface {
 	Publish(topic string, data interface{}) error
+	MultiPublish(topic string, data []interface{}) error
 }
 
 // Client is a wrapper for Postgres client
 type Client struct {
-	nsq *nsq.Producer
+	nsq     *nsq.Producer
+	schemas *Schemas
 }
 
+// Option set options for nsq client
+type Option func(*Client)
+
 // NewNsqClient is func to create nsq client
-func NewNsqClient(host string) (NsqMethod, error) {
+func NewNsqClient(host string, options ...Option) (NsqMethod, error) {
 	conf := nsq.NewConfig()
 	producer, err := nsq.NewProducer(host, conf)
 	if err != nil {
 		return &Client{}, err
 	}
 
-	return &Client{nsq: producer}, nil
+	client := &Client{nsq: producer}
+
+	// Apply options
+	for _, opt := range options {
+		opt(client)
+	}
+
+	return client, nil
 }
 
-// Publish is func to publisn message
+// WithSchemaOptions is func to validate every published message against the topic schema
+func WithSchemaOptions(schemas *Schemas) Option {
+	return Option(
+		func(c *Client) {
+			c.schemas = schemas
+		})
+}
+
+// Publish is func to publisn message, message that does not match the topic schema is not published
 func (c *Client) Publish(topic string, data interface{}) error {
 	var body []byte
 
 	body, err := json.Marshal(data)
 	if err != nil {
+		publishedTotal.Inc(topic, "error")
+		return err
+	}
+
+	if c.schemas != nil {
+		err = c.schemas.Validate(topic, body)
+		if err != nil {
+			publishedTotal.Inc(topic, "invalid")
+			return err
+		}
+	}
+
+	err = c.nsq.Publish(topic, body)
+	if err != nil {
+		publishedTotal.Inc(topic, "error")
 		return err
 	}
 
-	return c.nsq.Publish(topic, body)
+	publishedTotal.Inc(topic, "success")
+	return nil
+}
+
+// MultiPublish is func to publish list message in a single round-trip,
+// message that fail to marshal or does not match the topic schema is skipped and reported in the error
+func (c *Client) MultiPublish(topic string, data []interface{}) error {
+	bodies := make([][]byte, 0, len(data))
+	var invalid int
+	var lastErr error
+	for _, d := range data {
+		body, err := json.Marshal(d)
+		if err == nil && c.schemas != nil {
+			err = c.schemas.Validate(topic, body)
+		}
+		if err != nil {
+			invalid++
+			lastErr = err
+			continue
+		}
+		bodies = append(bodies, body)
+	}
+	if invalid > 0 {
+		publishedTotal.Add(float64(invalid), topic, "invalid")
+	}
+
+	if len(bodies) > 0 {
+		err := c.nsq.MultiPublish(topic, bodies)
+		if err != nil {
+			publishedTotal.Add(float64(len(bodies)), topic, "error")
+			return err
+		}
+		publishedTotal.Add(float64(len(bodies)), topic, "success")
+	}
+
+	if invalid > 0 {
+		return &InvalidMessagesError{Count: invalid, Err: lastErr}
+	}
+	return nil
+}
+
+// InvalidMessagesError is error of MultiPublish when some message is skipped while the rest is published
+type InvalidMessagesError struct {
+	Count int
+	Err   error
+}
+
+// Error is func to get error message
+func (e *InvalidMessagesError) Error() string {
+	return fmt.Sprintf("skip %d invalid message: %v", e.Count, e.Err)
+}
+
+// Unwrap is func to get the last skipped message error
+func (e *InvalidMessagesError) Unwrap() error {
+	return e.Err
 }
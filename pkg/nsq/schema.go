@@ -0,0 +1,109 @@
+package nsq
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// list error of message schema
+var (
+	ErrInvalidMessage = errors.New("message does not match topic schema")
+	ErrSchemaNotFound = errors.New("topic schema is not found")
+)
+
+// Schemas is list compiled json schema by topic name
+type Schemas struct {
+	topics map[string]*gojsonschema.Schema
+}
+
+// LoadSchemas is func to compile every json schema in dir, the file name is used as topic name
+func LoadSchemas(dir string) (*Schemas, error) {
+	schemas := &Schemas{topics: make(map[string]*gojsonschema.Schema)}
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() || filepath.Ext(path) != ".json" {
+			return nil
+		}
+
+		schema, err := CompileSchema(path)
+		if err != nil {
+			return err
+		}
+		schemas.topics[strings.TrimSuffix(info.Name(), ".json")] = schema
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return schemas, nil
+}
+
+// CompileSchema is func to compile json schema file, the schema is checked against its meta schema
+// and every message in examples must be valid against the schema
+func CompileSchema(path string) (*gojsonschema.Schema, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var document struct {
+		Schema   string            `json:"$schema"`
+		Examples []json.RawMessage `json:"examples"`
+	}
+	err = json.Unmarshal(data, &document)
+	if err != nil {
+		return nil, fmt.Errorf("invalid schema %s: %v", path, err)
+	}
+	if document.Schema == "" {
+		return nil, fmt.Errorf("invalid schema %s: $schema is mandatory", path)
+	}
+
+	loader := gojsonschema.NewSchemaLoader()
+	loader.Validate = true
+	schema, err := loader.Compile(gojsonschema.NewBytesLoader(data))
+	if err != nil {
+		return nil, fmt.Errorf("invalid schema %s: %v", path, err)
+	}
+
+	for i, example := range document.Examples {
+		err = validate(schema, example)
+		if err != nil {
+			return nil, fmt.Errorf("invalid schema %s: example %d: %v", path, i, err)
+		}
+	}
+	return schema, nil
+}
+
+// Validate is func to validate message body against schema of the topic
+func (s *Schemas) Validate(topic string, body []byte) error {
+	schema, ok := s.topics[topic]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrSchemaNotFound, topic)
+	}
+	return validate(schema, body)
+}
+
+func validate(schema *gojsonschema.Schema, body []byte) error {
+	result, err := schema.Validate(gojsonschema.NewBytesLoader(body))
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidMessage, err)
+	}
+	if result.Valid() {
+		return nil
+	}
+
+	violations := make([]string, 0, len(result.Errors()))
+	for _, e := range result.Errors() {
+		violations = append(violations, e.String())
+	}
+	return fmt.Errorf("%w: %s", ErrInvalidMessage, strings.Join(violations, "; "))
+}
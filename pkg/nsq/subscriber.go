@@ -0,0 +1,113 @@
+package nsq
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"aqua-farm-manager/pkg/bus"
+
+	"github.com/nsqio/go-nsq"
+)
+
+// Subscriber is nsq consumer factory that connect to nsqlookupd
+type Subscriber struct {
+	lookupd []string
+}
+
+// NewNsqSubscriber is func to create nsq subscriber, host is comma separated nsqlookupd address
+func NewNsqSubscriber(host string) (bus.Subscriber, error) {
+	var lookupd []string
+	for _, h := range strings.Split(host, ",") {
+		if h = strings.TrimSpace(h); h != "" {
+			lookupd = append(lookupd, h)
+		}
+	}
+	if len(lookupd) < 1 {
+		return nil, fmt.Errorf("invalid lookupd config")
+	}
+	return &Subscriber{lookupd: lookupd}, nil
+}
+
+// Subscribe is func to start nsq consumer of the topic channel, message attempts is not limited
+// by nsq so the handler decide when the message is dropped
+func (sub *Subscriber) Subscribe(topic, channel string, handler bus.Handler, config bus.SubscribeConfig) (bus.Subscription, error) {
+	conf := nsq.NewConfig()
+	if config.MaxInFlight > 0 {
+		conf.MaxInFlight = config.MaxInFlight
+	}
+	if config.MsgTimeout > 0 {
+		conf.MsgTimeout = config.MsgTimeout
+	}
+	conf.MaxAttempts = 0
+
+	consumer, err := nsq.NewConsumer(topic, channel, conf)
+	if err != nil {
+		return nil, err
+	}
+
+	concurrency := config.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	consumer.AddConcurrentHandlers(nsq.HandlerFunc(func(msg *nsq.Message) error {
+		msg.DisableAutoResponse()
+		return handler.HandleMessage(NewMessage(msg))
+	}), concurrency)
+
+	err = consumer.ConnectToNSQLookupds(sub.lookupd)
+	if err != nil {
+		consumer.Stop()
+		return nil, err
+	}
+	s := &subscription{consumer: consumer, done: make(chan struct{})}
+	go func() {
+		<-consumer.StopChan
+		close(s.done)
+	}()
+	return s, nil
+}
+
+// subscription is wrapper of nsq consumer
+type subscription struct {
+	consumer *nsq.Consumer
+	done     chan struct{}
+}
+
+// Stop is func to stop nsq consumer
+func (s *subscription) Stop() {
+	s.consumer.Stop()
+}
+
+// Done is func to get channel that is closed when nsq consumer is stopped
+func (s *subscription) Done() <-chan struct{} {
+	return s.done
+}
+
+// Message is wrapper of nsq message
+type Message struct {
+	msg *nsq.Message
+}
+
+// NewMessage is func to wrap nsq message as bus message
+func NewMessage(msg *nsq.Message) bus.Message {
+	return &Message{msg: msg}
+}
+
+// Body is func to get message payload
+func (m *Message) Body() []byte { return m.msg.Body }
+
+// Attempts is func to get number of time the message is delivered
+func (m *Message) Attempts() uint16 { return m.msg.Attempts }
+
+// Finish is func to mark message is processed successfully
+func (m *Message) Finish() { m.msg.Finish() }
+
+// Requeue is func to deliver the message again after delay with consumer backoff
+func (m *Message) Requeue(delay time.Duration) { m.msg.Requeue(delay) }
+
+// RequeueWithoutBackoff is func to deliver the message again after delay without consumer backoff
+func (m *Message) RequeueWithoutBackoff(delay time.Duration) { m.msg.RequeueWithoutBackoff(delay) }
+
+// Touch is func to reset message timeout
+func (m *Message) Touch() { m.msg.Touch() }
@@ -0,0 +1,271 @@
+package openapi
+
+import (
+	"path"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Version is openapi version of the generated document
+const Version = "3.0.3"
+
+// Document is openapi 3 document
+type Document struct {
+	OpenAPI    string              `json:"openapi"`
+	Info       Info                `json:"info"`
+	Paths      map[string]PathItem `json:"paths"`
+	Components Components          `json:"components"`
+}
+
+// Info is metadata of the api
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+// PathItem is list operation of a path by lower case http method
+type PathItem map[string]*Operation
+
+// Operation is a single api operation on a path
+type Operation struct {
+	OperationID string              `json:"operationId,omitempty"`
+	Summary     string              `json:"summary,omitempty"`
+	Tags        []string            `json:"tags,omitempty"`
+	Parameters  []Parameter         `json:"parameters,omitempty"`
+	RequestBody *RequestBody        `json:"requestBody,omitempty"`
+	Responses   map[string]Response `json:"responses"`
+}
+
+// Parameter is path or query parameter of an operation
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+// RequestBody is body of an operation
+type RequestBody struct {
+	Required bool                 `json:"required,omitempty"`
+	Content  map[string]MediaType `json:"content"`
+}
+
+// Response is response of an operation by status code
+type Response struct {
+	Description string               `json:"description"`
+	Content     map[string]MediaType `json:"content,omitempty"`
+}
+
+// MediaType is schema of a content type
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Components is list reusable schema of the document
+type Components struct {
+	Schemas map[string]*Schema `json:"schemas"`
+}
+
+// Schema is subset of openapi 3 schema object that is needed by the generated document
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	AllOf                []*Schema          `json:"allOf,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	MinLength            *int               `json:"minLength,omitempty"`
+	MaxLength            *int               `json:"maxLength,omitempty"`
+}
+
+// refPrefix is prefix of reference to component schema
+const refPrefix = "#/components/schemas/"
+
+// NewDocument is func to create empty document
+func NewDocument(info Info) *Document {
+	return &Document{
+		OpenAPI: Version,
+		Info:    info,
+		Paths:   map[string]PathItem{},
+		Components: Components{
+			Schemas: map[string]*Schema{},
+		},
+	}
+}
+
+// AddOperation is func to add operation of method and path into the document
+func (d *Document) AddOperation(method, path string, op *Operation) {
+	item, ok := d.Paths[path]
+	if !ok {
+		item = PathItem{}
+		d.Paths[path] = item
+	}
+	item[strings.ToLower(method)] = op
+}
+
+// Operation is func to get operation of method and path, nil is returned when it is not in the document
+func (d *Document) Operation(method, path string) *Operation {
+	return d.Paths[path][strings.ToLower(method)]
+}
+
+// Component is func to get component schema that is referred by ref
+func (d *Document) Component(ref string) *Schema {
+	return d.Components.Schemas[strings.TrimPrefix(ref, refPrefix)]
+}
+
+// RequestSchema is func to get schema of request type v, the field is required when
+// it has required rule in its `validate` tag and the min, max rule is set as the limit
+func (d *Document) RequestSchema(v interface{}) *Schema {
+	return d.schemaOf(reflect.TypeOf(v), true)
+}
+
+// ResponseSchema is func to get schema of response type v, the field is required when
+// it is always written by encoding/json, that is the field without omitempty
+func (d *Document) ResponseSchema(v interface{}) *Schema {
+	return d.schemaOf(reflect.TypeOf(v), false)
+}
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// schemaOf is func to get schema of type t, named struct is added as component and referred
+func (d *Document) schemaOf(t reflect.Type, request bool) *Schema {
+	if t == nil {
+		return &Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		return d.schemaOf(t.Elem(), request)
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return &Schema{Type: "integer", Format: intFormat(t)}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer", Format: intFormat(t), Minimum: float(0)}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: d.schemaOf(t.Elem(), request)}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: d.schemaOf(t.Elem(), request)}
+	case reflect.Struct:
+		if t == timeType {
+			return &Schema{Type: "string", Format: "date-time"}
+		}
+		if t.Name() == "" {
+			return d.structSchema(t, request)
+		}
+
+		name := path.Base(t.PkgPath()) + "." + t.Name()
+		if _, ok := d.Components.Schemas[name]; !ok {
+			// placeholder is set first so recursive type refer to itself
+			d.Components.Schemas[name] = &Schema{}
+			*d.Components.Schemas[name] = *d.structSchema(t, request)
+		}
+		return &Schema{Ref: refPrefix + name}
+	}
+	// interface and other kind can be any value
+	return &Schema{}
+}
+
+// structSchema is func to get object schema of every exported field of struct t
+func (d *Document) structSchema(t reflect.Type, request bool) *Schema {
+	s := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+
+		tags := strings.Split(field.Tag.Get("json"), ",")
+		name := tags[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		omitempty := false
+		for _, opt := range tags[1:] {
+			omitempty = omitempty || opt == "omitempty"
+		}
+
+		prop := d.schemaOf(field.Type, request)
+		if request {
+			if applyRules(prop, field.Tag.Get("validate")) {
+				s.Required = append(s.Required, name)
+			}
+		} else if !omitempty {
+			s.Required = append(s.Required, name)
+			if kind := field.Type.Kind(); kind == reflect.Ptr || kind == reflect.Slice || kind == reflect.Map {
+				prop = nullable(prop)
+			}
+		}
+		s.Properties[name] = prop
+	}
+	return s
+}
+
+// applyRules is func to set the limit of the validate rules into the schema, it return true when the field is required
+func applyRules(s *Schema, tag string) bool {
+	required := false
+	for _, rule := range strings.Split(tag, ",") {
+		key, param := rule, ""
+		if i := strings.Index(rule, "="); i >= 0 {
+			key, param = rule[:i], rule[i+1:]
+		}
+
+		limit, err := strconv.ParseFloat(param, 64)
+		switch {
+		case key == "required":
+			required = true
+		case key == "min" && err == nil && s.Type == "string":
+			s.MinLength = length(limit)
+		case key == "max" && err == nil && s.Type == "string":
+			s.MaxLength = length(limit)
+		case key == "min" && err == nil:
+			s.Minimum = float(limit)
+		case key == "max" && err == nil:
+			s.Maximum = float(limit)
+		}
+	}
+	return required
+}
+
+// nullable is func to mark schema can be null, reference is wrapped as sibling of $ref is ignored
+func nullable(s *Schema) *Schema {
+	if s.Ref != "" {
+		return &Schema{AllOf: []*Schema{s}, Nullable: true}
+	}
+	s.Nullable = true
+	return s
+}
+
+func intFormat(t reflect.Type) string {
+	if t.Bits() == 64 {
+		return "int64"
+	}
+	return "int32"
+}
+
+func float(f float64) *float64 {
+	return &f
+}
+
+func length(f float64) *int {
+	n := int(f)
+	return &n
+}
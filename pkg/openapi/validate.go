@@ -0,0 +1,154 @@
+package openapi
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// ValidateJSON is func to check json data against schema s, every violation is returned in the error.
+// The object is strict, property that is not in the schema is a violation so undocumented field is caught
+func (d *Document) ValidateJSON(s *Schema, data []byte) error {
+	var value interface{}
+	err := json.Unmarshal(data, &value)
+	if err != nil {
+		return fmt.Errorf("invalid json : %v", err)
+	}
+
+	var violations []string
+	d.validate(s, value, "$", &violations)
+	if len(violations) > 0 {
+		return fmt.Errorf("%s", strings.Join(violations, "; "))
+	}
+	return nil
+}
+
+// validate is func to check decoded json value against schema s, the violation is added with path of the value
+func (d *Document) validate(s *Schema, value interface{}, path string, violations *[]string) {
+	if s == nil {
+		return
+	}
+	if s.Ref != "" {
+		ref := d.Component(s.Ref)
+		if ref == nil {
+			*violations = append(*violations, fmt.Sprintf("%s: unknown schema %s", path, s.Ref))
+			return
+		}
+		d.validate(ref, value, path, violations)
+		return
+	}
+	if value == nil {
+		if s.Type != "" && !s.Nullable {
+			*violations = append(*violations, fmt.Sprintf("%s: is null, want %s", path, s.Type))
+		}
+		return
+	}
+	for _, sub := range s.AllOf {
+		d.validate(sub, value, path, violations)
+	}
+
+	violation := func(format string, args ...interface{}) {
+		*violations = append(*violations, path+": "+fmt.Sprintf(format, args...))
+	}
+
+	switch s.Type {
+	case "":
+		// schema without type accept any value
+	case "object":
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			violation("is %s, want object", typeName(value))
+			return
+		}
+		for _, name := range s.Required {
+			if _, ok := object[name]; !ok {
+				violation("missing required property %q", name)
+			}
+		}
+
+		names := make([]string, 0, len(object))
+		for name := range object {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			prop, ok := s.Properties[name]
+			switch {
+			case ok:
+				d.validate(prop, object[name], path+"."+name, violations)
+			case s.AdditionalProperties != nil:
+				d.validate(s.AdditionalProperties, object[name], path+"."+name, violations)
+			default:
+				violation("unknown property %q", name)
+			}
+		}
+	case "array":
+		array, ok := value.([]interface{})
+		if !ok {
+			violation("is %s, want array", typeName(value))
+			return
+		}
+		for i, item := range array {
+			d.validate(s.Items, item, fmt.Sprintf("%s[%d]", path, i), violations)
+		}
+	case "string":
+		str, ok := value.(string)
+		if !ok {
+			violation("is %s, want string", typeName(value))
+			return
+		}
+		length := utf8.RuneCountInString(str)
+		if s.MinLength != nil && length < *s.MinLength {
+			violation("length %d is less than %d", length, *s.MinLength)
+		}
+		if s.MaxLength != nil && length > *s.MaxLength {
+			violation("length %d is more than %d", length, *s.MaxLength)
+		}
+		if s.Format == "date-time" {
+			if _, err := time.Parse(time.RFC3339Nano, str); err != nil {
+				violation("%q is not date-time", str)
+			}
+		}
+	case "integer", "number":
+		number, ok := value.(float64)
+		if !ok {
+			violation("is %s, want %s", typeName(value), s.Type)
+			return
+		}
+		if s.Type == "integer" && number != math.Trunc(number) {
+			violation("%v is not integer", number)
+		}
+		if s.Minimum != nil && number < *s.Minimum {
+			violation("%v is less than %v", number, *s.Minimum)
+		}
+		if s.Maximum != nil && number > *s.Maximum {
+			violation("%v is more than %v", number, *s.Maximum)
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			violation("is %s, want boolean", typeName(value))
+		}
+	default:
+		violation("unknown schema type %s", s.Type)
+	}
+}
+
+func typeName(value interface{}) string {
+	switch value.(type) {
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case float64:
+		return "number"
+	case bool:
+		return "boolean"
+	}
+	return "null"
+}
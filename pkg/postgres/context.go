@@ -0,0 +1,84 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/jinzhu/gorm"
+)
+
+// contextConn is sql connection pool that run every statement and transaction with the context,
+// so the query is cancelled when the context is done. gorm v1 does not pass context into the driver
+type contextConn struct {
+	ctx context.Context
+	db  *sql.DB
+}
+
+// Exec is func to run statement with the context
+func (c *contextConn) Exec(query string, args ...interface{}) (sql.Result, error) {
+	return c.db.ExecContext(c.ctx, query, args...)
+}
+
+// Prepare is func to prepare statement with the context
+func (c *contextConn) Prepare(query string) (*sql.Stmt, error) {
+	return c.db.PrepareContext(c.ctx, query)
+}
+
+// Query is func to run query with the context
+func (c *contextConn) Query(query string, args ...interface{}) (*sql.Rows, error) {
+	return c.db.QueryContext(c.ctx, query, args...)
+}
+
+// QueryRow is func to run query that return one row with the context
+func (c *contextConn) QueryRow(query string, args ...interface{}) *sql.Row {
+	return c.db.QueryRowContext(c.ctx, query, args...)
+}
+
+// Begin is func to start transaction that is rolled back when the context is done
+func (c *contextConn) Begin() (*sql.Tx, error) {
+	return c.db.BeginTx(c.ctx, nil)
+}
+
+// BeginTx is func to start transaction, gorm Transaction always pass background context
+// so the context of the connection is used when ctx can never be cancelled
+func (c *contextConn) BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error) {
+	if ctx == nil || ctx.Done() == nil {
+		ctx = c.ctx
+	}
+	return c.db.BeginTx(ctx, opts)
+}
+
+// withContext is func to get gorm db that share the connection pool and dialect of db and run every query with ctx,
+// db is returned as is when ctx can never be cancelled. The context db is opened over the connection pool
+// so it use the default callbacks and logger of gorm, the callback and log mode set on db is not carried over
+func withContext(ctx context.Context, db *gorm.DB) *gorm.DB {
+	if db == nil || ctx == nil || ctx.Done() == nil {
+		return db
+	}
+	sqlDB, ok := db.CommonDB().(*sql.DB)
+	if !ok || sqlDB == nil {
+		// db is transaction or mock that is not backed by connection pool
+		return db
+	}
+
+	ctxDB, err := gorm.Open(db.Dialect().GetName(), &contextConn{ctx: ctx, db: sqlDB})
+	if err != nil {
+		return db
+	}
+	return ctxDB
+}
+
+// SQLDB is func to get the connection pool of db that is returned by GetDB,
+// DB() of gorm panic when the query of db is run with context
+func SQLDB(db *gorm.DB) *sql.DB {
+	if db == nil {
+		return nil
+	}
+	switch conn := db.CommonDB().(type) {
+	case *sql.DB:
+		return conn
+	case *contextConn:
+		return conn.db
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestWithContext(t *testing.T) {
+	db, err := Open("sqlite://:memory:")
+	if err != nil {
+		t.Fatalf("Open() error = %v", err)
+	}
+	defer db.Close()
+
+	if got := withContext(context.Background(), db); got != db {
+		t.Errorf("withContext() of background context = %p, want db %p", got, db)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ctxDB := withContext(ctx, db)
+	if ctxDB == db {
+		t.Fatalf("withContext() of cancellable context return db as is")
+	}
+	if got := SQLDB(ctxDB); got != db.DB() {
+		t.Errorf("SQLDB() of context db = %p, want the connection pool %p", got, db.DB())
+	}
+	if _, ok := db.CommonDB().(*contextConn); ok {
+		t.Errorf("withContext() swapped the connection of db")
+	}
+
+	if got := ctxDB.Dialect().GetName(); got != DialectSQLite {
+		t.Errorf("withContext() dialect = %s, want %s", got, DialectSQLite)
+	}
+
+	var farms []Farms
+	if err := ctxDB.AutoMigrate(&Farms{}).Find(&farms).Error; err != nil {
+		t.Fatalf("Find() error = %v", err)
+	}
+
+	cancel()
+	if err := ctxDB.Find(&farms).Error; !errors.Is(err, context.Canceled) {
+		t.Errorf("Find() of cancelled context error = %v, want %v", err, context.Canceled)
+	}
+	if err := db.Find(&farms).Error; err != nil {
+		t.Errorf("Find() of db after cancel error = %v", err)
+	}
+}
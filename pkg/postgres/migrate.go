@@ -0,0 +1,315 @@
+package postgres
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// migrationFS hold numbered up and down sql migrations of every dialect,
+// the file name is migrations/<dialect>/<version>_<name>.<up|down>.sql
+//
+//go:embed migrations
+var migrationFS embed.FS
+
+// migrationLockID is postgres advisory lock key so only one instance apply migration at a time
+const migrationLockID = 7320410115
+
+const createMigrationTableQuery = `CREATE TABLE IF NOT EXISTS schema_migrations (
+	version bigint PRIMARY KEY,
+	name varchar(255) NOT NULL,
+	applied_at timestamp NOT NULL
+)`
+
+// ErrSchemaTooNew is error when the database is migrated by newer binary
+var ErrSchemaTooNew = errors.New("database schema version is newer than this binary understand")
+
+// Migration is numbered schema change
+type Migration struct {
+	Version uint
+	Name    string
+	Up      string
+	Down    string
+}
+
+// MigrationStatus is applied state of a migration
+type MigrationStatus struct {
+	Version   uint
+	Name      string
+	Applied   bool
+	AppliedAt time.Time
+}
+
+// SchemaMigrations struct to store applied migration version
+type SchemaMigrations struct {
+	Version   uint `gorm:"primary_key;auto_increment:false"`
+	Name      string
+	AppliedAt time.Time
+}
+
+// TableName is func to set table name of SchemaMigrations
+func (SchemaMigrations) TableName() string {
+	return "schema_migrations"
+}
+
+// Migrator is func to apply and rollback the embedded migration of the database dialect
+type Migrator struct {
+	db         *gorm.DB
+	dialect    string
+	migrations []Migration
+}
+
+// NewMigrator is func to create Migrator from the embedded migration of database dialect
+func NewMigrator(db *gorm.DB) (*Migrator, error) {
+	if db == nil {
+		return nil, errors.New("Database Client is not init")
+	}
+
+	dialect := db.Dialect().GetName()
+	migrations, err := LoadMigrations(dialect)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Migrator{
+		db:         db,
+		dialect:    dialect,
+		migrations: migrations,
+	}, nil
+}
+
+// LoadMigrations is func to read embedded migration of dialect ordered by version,
+// every version must have up and down file
+func LoadMigrations(dialect string) ([]Migration, error) {
+	dir := path.Join("migrations", dialect)
+	entries, err := fs.ReadDir(migrationFS, dir)
+	if err != nil {
+		return nil, fmt.Errorf("no migration for dialect %s: %v", dialect, err)
+	}
+
+	byVersion := make(map[uint]*Migration)
+	for _, entry := range entries {
+		file := entry.Name()
+		var direction string
+		switch {
+		case strings.HasSuffix(file, ".up.sql"):
+			direction = "up"
+		case strings.HasSuffix(file, ".down.sql"):
+			direction = "down"
+		default:
+			continue
+		}
+
+		base := strings.TrimSuffix(file, "."+direction+".sql")
+		parts := strings.SplitN(base, "_", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid migration file name %s", file)
+		}
+		version, err := strconv.ParseUint(parts[0], 10, 32)
+		if err != nil || version == 0 {
+			return nil, fmt.Errorf("invalid migration version of %s", file)
+		}
+
+		content, err := fs.ReadFile(migrationFS, path.Join(dir, file))
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[uint(version)]
+		if !ok {
+			m = &Migration{Version: uint(version), Name: parts[1]}
+			byVersion[uint(version)] = m
+		}
+		if m.Name != parts[1] {
+			return nil, fmt.Errorf("migration version %d has different name %s and %s", version, m.Name, parts[1])
+		}
+		if direction == "up" {
+			m.Up = string(content)
+		} else {
+			m.Down = string(content)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if len(strings.TrimSpace(m.Up)) == 0 || len(strings.TrimSpace(m.Down)) == 0 {
+			return nil, fmt.Errorf("migration %d_%s must have up and down sql", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// LatestVersion is func to get the newest migration version known by this binary
+func (m *Migrator) LatestVersion() uint {
+	if len(m.migrations) == 0 {
+		return 0
+	}
+	return m.migrations[len(m.migrations)-1].Version
+}
+
+// Version is func to get the newest applied migration version, 0 when nothing is applied
+func (m *Migrator) Version() (uint, error) {
+	applied, err := m.applied(m.db)
+	if err != nil {
+		return 0, err
+	}
+
+	var version uint
+	for v := range applied {
+		if v > version {
+			version = v
+		}
+	}
+	return version, nil
+}
+
+// CheckVersion is func to return ErrSchemaTooNew when the database has migration unknown by this binary
+func (m *Migrator) CheckVersion() error {
+	version, err := m.Version()
+	if err != nil {
+		return err
+	}
+	if version > m.LatestVersion() {
+		return fmt.Errorf("%w: database version %d, latest known version %d", ErrSchemaTooNew, version, m.LatestVersion())
+	}
+	return nil
+}
+
+// Up is func to apply every pending migration in version order, every migration is applied in its own transaction
+func (m *Migrator) Up() ([]Migration, error) {
+	if err := m.CheckVersion(); err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for _, migration := range m.migrations {
+		var applied bool
+		err := m.db.Transaction(func(tx *gorm.DB) error {
+			if err := m.lock(tx); err != nil {
+				return err
+			}
+			// other instance may apply it while waiting for the lock
+			versions, err := m.applied(tx)
+			if err != nil {
+				return err
+			}
+			if _, ok := versions[migration.Version]; ok {
+				return nil
+			}
+
+			if err := tx.Exec(migration.Up).Error; err != nil {
+				return err
+			}
+			applied = true
+			return tx.Create(&SchemaMigrations{
+				Version:   migration.Version,
+				Name:      migration.Name,
+				AppliedAt: time.Now().UTC(),
+			}).Error
+		})
+		if err != nil {
+			return done, fmt.Errorf("migration %d_%s up: %v", migration.Version, migration.Name, err)
+		}
+		if applied {
+			done = append(done, migration)
+		}
+	}
+	return done, nil
+}
+
+// Down is func to rollback the newest applied migration by number of steps
+func (m *Migrator) Down(steps int) ([]Migration, error) {
+	if err := m.CheckVersion(); err != nil {
+		return nil, err
+	}
+
+	var done []Migration
+	for i := len(m.migrations) - 1; i >= 0 && len(done) < steps; i-- {
+		migration := m.migrations[i]
+		var rolledBack bool
+		err := m.db.Transaction(func(tx *gorm.DB) error {
+			if err := m.lock(tx); err != nil {
+				return err
+			}
+			versions, err := m.applied(tx)
+			if err != nil {
+				return err
+			}
+			if _, ok := versions[migration.Version]; !ok {
+				return nil
+			}
+
+			if err := tx.Exec(migration.Down).Error; err != nil {
+				return err
+			}
+			rolledBack = true
+			return tx.Where("version = ?", migration.Version).Delete(&SchemaMigrations{}).Error
+		})
+		if err != nil {
+			return done, fmt.Errorf("migration %d_%s down: %v", migration.Version, migration.Name, err)
+		}
+		if rolledBack {
+			done = append(done, migration)
+		}
+	}
+	return done, nil
+}
+
+// Status is func to list every migration known by this binary with its applied state
+func (m *Migrator) Status() ([]MigrationStatus, error) {
+	applied, err := m.applied(m.db)
+	if err != nil {
+		return nil, err
+	}
+
+	list := make([]MigrationStatus, 0, len(m.migrations))
+	for _, migration := range m.migrations {
+		status := MigrationStatus{
+			Version: migration.Version,
+			Name:    migration.Name,
+		}
+		if row, ok := applied[migration.Version]; ok {
+			status.Applied = true
+			status.AppliedAt = row.AppliedAt
+		}
+		list = append(list, status)
+	}
+	return list, nil
+}
+
+// applied is func to get applied migration by version, schema_migrations is created when it does not exist
+func (m *Migrator) applied(db *gorm.DB) (map[uint]SchemaMigrations, error) {
+	if err := db.Exec(createMigrationTableQuery).Error; err != nil {
+		return nil, err
+	}
+
+	var rows []SchemaMigrations
+	if err := db.Find(&rows).Error; err != nil {
+		return nil, err
+	}
+
+	applied := make(map[uint]SchemaMigrations, len(rows))
+	for _, row := range rows {
+		applied[row.Version] = row
+	}
+	return applied, nil
+}
+
+// lock is func to hold postgres advisory lock until the transaction end,
+// sqlite already allow only one writer so it does not need the lock
+func (m *Migrator) lock(tx *gorm.DB) error {
+	if m.dialect != DialectPostgres {
+		return nil
+	}
+	return tx.Exec("SELECT pg_advisory_xact_lock(?)", migrationLockID).Error
+}
@@ -0,0 +1,51 @@
+-- baseline of the schema created by gorm AutoMigrate, existing table and index is kept as it is
+CREATE TABLE IF NOT EXISTS farms (
+	id serial PRIMARY KEY,
+	created_at timestamp with time zone,
+	updated_at timestamp with time zone,
+	deleted_at timestamp with time zone,
+	name text,
+	location text,
+	owner text,
+	area text,
+	status integer
+);
+CREATE INDEX IF NOT EXISTS idx_farms_deleted_at ON farms (deleted_at);
+
+CREATE TABLE IF NOT EXISTS ponds (
+	id serial PRIMARY KEY,
+	created_at timestamp with time zone,
+	updated_at timestamp with time zone,
+	deleted_at timestamp with time zone,
+	name text,
+	capacity numeric,
+	depth numeric,
+	water_quality numeric,
+	species text,
+	status integer
+);
+CREATE INDEX IF NOT EXISTS idx_ponds_deleted_at ON ponds (deleted_at);
+
+CREATE TABLE IF NOT EXISTS farm_ponds_mappings (
+	id serial PRIMARY KEY,
+	created_at timestamp with time zone,
+	updated_at timestamp with time zone,
+	deleted_at timestamp with time zone,
+	farm_id integer,
+	ponds_id integer
+);
+CREATE INDEX IF NOT EXISTS idx_farm_ponds_mappings_deleted_at ON farm_ponds_mappings (deleted_at);
+
+CREATE TABLE IF NOT EXISTS stat_metrics (
+	id serial PRIMARY KEY,
+	created_at timestamp with time zone,
+	updated_at timestamp with time zone,
+	deleted_at timestamp with time zone,
+	key text,
+	request integer,
+	uniq_agent integer,
+	num_success integer,
+	num_error integer,
+	status integer
+);
+CREATE INDEX IF NOT EXISTS idx_stat_metrics_deleted_at ON stat_metrics (deleted_at);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_stat_metrics_active_key;
+ALTER TABLE stat_metrics DROP COLUMN IF EXISTS last_batch;
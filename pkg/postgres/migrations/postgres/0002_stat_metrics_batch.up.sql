@@ -0,0 +1,11 @@
+-- stat backup record the last flushed batch of the active row so a retried batch is applied once
+ALTER TABLE stat_metrics ADD COLUMN IF NOT EXISTS last_batch bigint;
+
+-- the baseline stat backup may leave more than one active row of a key,
+-- only the newest active row is kept active before the unique index is created
+UPDATE stat_metrics SET status = 2 WHERE status = 1 AND deleted_at IS NULL AND EXISTS (
+	SELECT 1 FROM stat_metrics s WHERE s.key = stat_metrics.key AND s.status = 1 AND s.deleted_at IS NULL AND s.id > stat_metrics.id
+);
+
+-- stat backup upsert the active row by key
+CREATE UNIQUE INDEX IF NOT EXISTS idx_stat_metrics_active_key ON stat_metrics (key) WHERE status = 1 AND deleted_at IS NULL;
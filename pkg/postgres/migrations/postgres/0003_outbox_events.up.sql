@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS outbox_events (
+	id serial PRIMARY KEY,
+	created_at timestamp with time zone,
+	updated_at timestamp with time zone,
+	deleted_at timestamp with time zone,
+	event_id text,
+	topic text,
+	payload text,
+	status integer,
+	attempts integer
+);
+CREATE INDEX IF NOT EXISTS idx_outbox_events_deleted_at ON outbox_events (deleted_at);
+CREATE UNIQUE INDEX IF NOT EXISTS uix_outbox_events_event_id ON outbox_events (event_id);
+-- outbox relay poll the pending event in insertion order
+CREATE INDEX IF NOT EXISTS idx_outbox_events_pending ON outbox_events (id) WHERE status = 1 AND deleted_at IS NULL;
@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS webhooks (
+	id serial PRIMARY KEY,
+	created_at timestamp with time zone,
+	updated_at timestamp with time zone,
+	deleted_at timestamp with time zone,
+	url text,
+	event_types text,
+	secret text,
+	status integer
+);
+CREATE INDEX IF NOT EXISTS idx_webhooks_deleted_at ON webhooks (deleted_at);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+	id serial PRIMARY KEY,
+	created_at timestamp with time zone,
+	updated_at timestamp with time zone,
+	deleted_at timestamp with time zone,
+	webhook_id integer,
+	event_id text,
+	event_type text,
+	attempt integer,
+	status_code integer,
+	success boolean,
+	error text,
+	duration_ms bigint
+);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_deleted_at ON webhook_deliveries (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_webhook_id ON webhook_deliveries (webhook_id);
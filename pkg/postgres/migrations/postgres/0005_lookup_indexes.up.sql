@@ -0,0 +1,6 @@
+-- pond detail and farm detail look up the mapping by pond and by farm
+CREATE INDEX IF NOT EXISTS idx_farm_ponds_mappings_ponds_id ON farm_ponds_mappings (ponds_id);
+CREATE INDEX IF NOT EXISTS idx_farm_ponds_mappings_farm_id ON farm_ponds_mappings (farm_id);
+-- get by name and verify look up the farm and pond by name
+CREATE INDEX IF NOT EXISTS idx_farms_name ON farms (name);
+CREATE INDEX IF NOT EXISTS idx_ponds_name ON ponds (name);
@@ -0,0 +1,12 @@
+DROP INDEX IF EXISTS idx_ponds_status;
+DROP INDEX IF EXISTS idx_farms_status;
+
+ALTER TABLE farm_ponds_mappings DROP CONSTRAINT IF EXISTS fk_farm_ponds_mappings_ponds_id;
+ALTER TABLE farm_ponds_mappings DROP CONSTRAINT IF EXISTS fk_farm_ponds_mappings_farm_id;
+DROP INDEX IF EXISTS uix_farm_ponds_mappings_ponds_id;
+CREATE INDEX IF NOT EXISTS idx_farm_ponds_mappings_ponds_id ON farm_ponds_mappings (ponds_id);
+
+DROP INDEX IF EXISTS uix_ponds_active_name;
+DROP INDEX IF EXISTS uix_farms_active_name;
+CREATE INDEX IF NOT EXISTS idx_ponds_name ON ponds (name);
+CREATE INDEX IF NOT EXISTS idx_farms_name ON farms (name);
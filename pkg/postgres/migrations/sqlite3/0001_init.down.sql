@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS stat_metrics;
+DROP TABLE IF EXISTS farm_ponds_mappings;
+DROP TABLE IF EXISTS ponds;
+DROP TABLE IF EXISTS farms;
@@ -0,0 +1,51 @@
+-- baseline of the schema created by gorm AutoMigrate, existing table and index is kept as it is
+CREATE TABLE IF NOT EXISTS farms (
+	id integer PRIMARY KEY AUTOINCREMENT,
+	created_at datetime,
+	updated_at datetime,
+	deleted_at datetime,
+	name varchar(255),
+	location varchar(255),
+	owner varchar(255),
+	area varchar(255),
+	status integer
+);
+CREATE INDEX IF NOT EXISTS idx_farms_deleted_at ON farms (deleted_at);
+
+CREATE TABLE IF NOT EXISTS ponds (
+	id integer PRIMARY KEY AUTOINCREMENT,
+	created_at datetime,
+	updated_at datetime,
+	deleted_at datetime,
+	name varchar(255),
+	capacity real,
+	depth real,
+	water_quality real,
+	species varchar(255),
+	status integer
+);
+CREATE INDEX IF NOT EXISTS idx_ponds_deleted_at ON ponds (deleted_at);
+
+CREATE TABLE IF NOT EXISTS farm_ponds_mappings (
+	id integer PRIMARY KEY AUTOINCREMENT,
+	created_at datetime,
+	updated_at datetime,
+	deleted_at datetime,
+	farm_id integer,
+	ponds_id integer
+);
+CREATE INDEX IF NOT EXISTS idx_farm_ponds_mappings_deleted_at ON farm_ponds_mappings (deleted_at);
+
+CREATE TABLE IF NOT EXISTS stat_metrics (
+	id integer PRIMARY KEY AUTOINCREMENT,
+	created_at datetime,
+	updated_at datetime,
+	deleted_at datetime,
+	key varchar(255),
+	request integer,
+	uniq_agent integer,
+	num_success integer,
+	num_error integer,
+	status integer
+);
+CREATE INDEX IF NOT EXISTS idx_stat_metrics_deleted_at ON stat_metrics (deleted_at);
@@ -0,0 +1,20 @@
+DROP INDEX IF EXISTS idx_stat_metrics_active_key;
+
+-- sqlite of the driver does not support DROP COLUMN, the table is created again without last_batch
+CREATE TABLE stat_metrics_old (
+	id integer PRIMARY KEY AUTOINCREMENT,
+	created_at datetime,
+	updated_at datetime,
+	deleted_at datetime,
+	key varchar(255),
+	request integer,
+	uniq_agent integer,
+	num_success integer,
+	num_error integer,
+	status integer
+);
+INSERT INTO stat_metrics_old (id, created_at, updated_at, deleted_at, key, request, uniq_agent, num_success, num_error, status)
+SELECT id, created_at, updated_at, deleted_at, key, request, uniq_agent, num_success, num_error, status FROM stat_metrics;
+DROP TABLE stat_metrics;
+ALTER TABLE stat_metrics_old RENAME TO stat_metrics;
+CREATE INDEX idx_stat_metrics_deleted_at ON stat_metrics (deleted_at);
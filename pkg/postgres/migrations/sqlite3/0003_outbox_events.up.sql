@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS outbox_events (
+	id integer PRIMARY KEY AUTOINCREMENT,
+	created_at datetime,
+	updated_at datetime,
+	deleted_at datetime,
+	event_id varchar(255),
+	topic varchar(255),
+	payload text,
+	status integer,
+	attempts integer
+);
+CREATE INDEX IF NOT EXISTS idx_outbox_events_deleted_at ON outbox_events (deleted_at);
+CREATE UNIQUE INDEX IF NOT EXISTS uix_outbox_events_event_id ON outbox_events (event_id);
+-- outbox relay poll the pending event in insertion order
+CREATE INDEX IF NOT EXISTS idx_outbox_events_pending ON outbox_events (id) WHERE status = 1 AND deleted_at IS NULL;
@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS webhooks (
+	id integer PRIMARY KEY AUTOINCREMENT,
+	created_at datetime,
+	updated_at datetime,
+	deleted_at datetime,
+	url varchar(255),
+	event_types varchar(255),
+	secret varchar(255),
+	status integer
+);
+CREATE INDEX IF NOT EXISTS idx_webhooks_deleted_at ON webhooks (deleted_at);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+	id integer PRIMARY KEY AUTOINCREMENT,
+	created_at datetime,
+	updated_at datetime,
+	deleted_at datetime,
+	webhook_id integer,
+	event_id varchar(255),
+	event_type varchar(255),
+	attempt integer,
+	status_code integer,
+	success bool,
+	error varchar(255),
+	duration_ms bigint
+);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_deleted_at ON webhook_deliveries (deleted_at);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_webhook_id ON webhook_deliveries (webhook_id);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_ponds_name;
+DROP INDEX IF EXISTS idx_farms_name;
+DROP INDEX IF EXISTS idx_farm_ponds_mappings_farm_id;
+DROP INDEX IF EXISTS idx_farm_ponds_mappings_ponds_id;
@@ -0,0 +1,23 @@
+DROP INDEX IF EXISTS idx_ponds_status;
+DROP INDEX IF EXISTS idx_farms_status;
+
+CREATE TABLE farm_ponds_mappings_old (
+	id integer PRIMARY KEY AUTOINCREMENT,
+	created_at datetime,
+	updated_at datetime,
+	deleted_at datetime,
+	farm_id integer,
+	ponds_id integer
+);
+INSERT INTO farm_ponds_mappings_old (id, created_at, updated_at, deleted_at, farm_id, ponds_id)
+SELECT id, created_at, updated_at, deleted_at, farm_id, ponds_id FROM farm_ponds_mappings;
+DROP TABLE farm_ponds_mappings;
+ALTER TABLE farm_ponds_mappings_old RENAME TO farm_ponds_mappings;
+CREATE INDEX idx_farm_ponds_mappings_deleted_at ON farm_ponds_mappings (deleted_at);
+CREATE INDEX idx_farm_ponds_mappings_farm_id ON farm_ponds_mappings (farm_id);
+CREATE INDEX idx_farm_ponds_mappings_ponds_id ON farm_ponds_mappings (ponds_id);
+
+DROP INDEX IF EXISTS uix_ponds_active_name;
+DROP INDEX IF EXISTS uix_farms_active_name;
+CREATE INDEX IF NOT EXISTS idx_ponds_name ON ponds (name);
+CREATE INDEX IF NOT EXISTS idx_farms_name ON farms (name);
@@ -0,0 +1,49 @@
+-- existing duplicate active name is deactivated before the unique index is created,
+-- the farm and pond with the lowest id keep the name
+UPDATE farms SET status = 2 WHERE status = 1 AND deleted_at IS NULL AND EXISTS (
+	SELECT 1 FROM farms f WHERE f.name = farms.name AND f.status = 1 AND f.deleted_at IS NULL AND f.id < farms.id
+);
+UPDATE ponds SET status = 2 WHERE status = 1 AND deleted_at IS NULL AND EXISTS (
+	SELECT 1 FROM ponds p WHERE p.name = ponds.name AND p.status = 1 AND p.deleted_at IS NULL AND p.id < ponds.id
+);
+
+-- name is unique among active farm and pond so concurrent create can not duplicate it,
+-- the name of deleted farm and pond can be used again
+DROP INDEX IF EXISTS idx_farms_name;
+DROP INDEX IF EXISTS idx_ponds_name;
+CREATE UNIQUE INDEX uix_farms_active_name ON farms (name) WHERE status = 1 AND deleted_at IS NULL;
+CREATE UNIQUE INDEX uix_ponds_active_name ON ponds (name) WHERE status = 1 AND deleted_at IS NULL;
+
+-- mapping of farm or pond that does not exist is removed before the foreign key is created,
+-- then a pond keep only its live mapping with the highest id
+DELETE FROM farm_ponds_mappings WHERE
+	NOT EXISTS (SELECT 1 FROM farms WHERE farms.id = farm_ponds_mappings.farm_id) OR
+	NOT EXISTS (SELECT 1 FROM ponds WHERE ponds.id = farm_ponds_mappings.ponds_id);
+DELETE FROM farm_ponds_mappings WHERE EXISTS (
+	SELECT 1 FROM farm_ponds_mappings m WHERE m.ponds_id = farm_ponds_mappings.ponds_id AND m.id <> farm_ponds_mappings.id AND (
+		(m.deleted_at IS NULL AND farm_ponds_mappings.deleted_at IS NOT NULL) OR
+		((m.deleted_at IS NULL) = (farm_ponds_mappings.deleted_at IS NULL) AND m.id > farm_ponds_mappings.id)
+	)
+);
+
+-- sqlite can not add foreign key into existing table, so the mapping table is rebuilt
+-- and a pond is mapped into exactly one farm
+CREATE TABLE farm_ponds_mappings_new (
+	id integer PRIMARY KEY AUTOINCREMENT,
+	created_at datetime,
+	updated_at datetime,
+	deleted_at datetime,
+	farm_id integer CONSTRAINT fk_farm_ponds_mappings_farm_id REFERENCES farms (id),
+	ponds_id integer CONSTRAINT fk_farm_ponds_mappings_ponds_id REFERENCES ponds (id)
+);
+INSERT INTO farm_ponds_mappings_new (id, created_at, updated_at, deleted_at, farm_id, ponds_id)
+SELECT id, created_at, updated_at, deleted_at, farm_id, ponds_id FROM farm_ponds_mappings;
+DROP TABLE farm_ponds_mappings;
+ALTER TABLE farm_ponds_mappings_new RENAME TO farm_ponds_mappings;
+CREATE INDEX idx_farm_ponds_mappings_deleted_at ON farm_ponds_mappings (deleted_at);
+CREATE INDEX idx_farm_ponds_mappings_farm_id ON farm_ponds_mappings (farm_id);
+CREATE UNIQUE INDEX uix_farm_ponds_mappings_ponds_id ON farm_ponds_mappings (ponds_id);
+
+-- farm paging and active pond count filter by status
+CREATE INDEX IF NOT EXISTS idx_farms_status ON farms (status);
+CREATE INDEX IF NOT EXISTS idx_ponds_status ON ponds (status);
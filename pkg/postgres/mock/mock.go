@@ -5,6 +5,7 @@
 package mock_postgres
 
 import (
+	context "context"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -35,15 +36,15 @@ func (m *MockPostgresMethod) EXPECT() *MockPostgresMethodMockRecorder {
 }
 
 // GetDB mocks base method.
-func (m *MockPostgresMethod) GetDB() *gorm.DB {
+func (m *MockPostgresMethod) GetDB(ctx context.Context) *gorm.DB {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetDB")
+	ret := m.ctrl.Call(m, "GetDB", ctx)
 	ret0, _ := ret[0].(*gorm.DB)
 	return ret0
 }
 
 // GetDB indicates an expected call of GetDB.
-func (mr *MockPostgresMethodMockRecorder) GetDB() *gomock.Call {
+func (mr *MockPostgresMethodMockRecorder) GetDB(ctx interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDB", reflect.TypeOf((*MockPostgresMethod)(nil).GetDB))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDB", reflect.TypeOf((*MockPostgresMethod)(nil).GetDB), ctx)
 }
@@ -39,4 +39,37 @@ type StatMetrics struct {
 	NumSuccess int
 	NumError   int
 	Status     int
+	LastBatch  int64
+}
+
+// OutboxEvents struct to store domain event until it is published to nsq
+type OutboxEvents struct {
+	gorm.Model
+	EventID  string `gorm:"unique_index"`
+	Topic    string
+	Payload  string `gorm:"type:text"`
+	Status   int
+	Attempts int
+}
+
+// Webhooks struct to store webhook subscription information
+type Webhooks struct {
+	gorm.Model
+	URL        string
+	EventTypes string
+	Secret     string
+	Status     int
+}
+
+// WebhookDeliveries struct to store every delivery attempt of webhook
+type WebhookDeliveries struct {
+	gorm.Model
+	WebhookID  uint `gorm:"index"`
+	EventID    string
+	EventType  string
+	Attempt    int
+	StatusCode int
+	Success    bool
+	Error      string
+	DurationMs int64
 }
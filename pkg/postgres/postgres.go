@@ -1,12 +1,26 @@
 package postgres
 
 import (
+	"context"
 	"fmt"
+	"log"
+	"strings"
 
 	"github.com/jinzhu/gorm"
 	_ "github.com/jinzhu/gorm/dialects/postgres"
+	_ "github.com/jinzhu/gorm/dialects/sqlite"
 )
 
+const (
+	// DialectPostgres is dialect name of postgres database
+	DialectPostgres = "postgres"
+	// DialectSQLite is dialect name of sqlite database
+	DialectSQLite = "sqlite3"
+)
+
+// sqliteBusyTimeoutInMs is how long sqlite wait for the lock of other connection before return database is locked
+const sqliteBusyTimeoutInMs = 5000
+
 // PostgresConfig is list config to create postgres client
 type PostgresConfig struct {
 	Host     string
@@ -18,7 +32,7 @@ type PostgresConfig struct {
 
 // PostgresMethod is list all available method for postgres
 type PostgresMethod interface {
-	GetDB() *gorm.DB
+	GetDB(ctx context.Context) *gorm.DB
 }
 
 // Client is a wrapper for Postgres client
@@ -26,19 +40,87 @@ type Client struct {
 	db *gorm.DB
 }
 
-// NewPostgresClient is func to create postgres client
+// NewPostgresClient is func to create sql database client, the database is chosen by the config scheme :
+//   - sqlite://<path> or file:<path> open sqlite file, sqlite://:memory: open in memory database
+//   - anything else, postgres:// url or key=value dsn, open postgres
+//
+// Pending migration is applied on open, it return ErrSchemaTooNew when the database is migrated by newer binary
 func NewPostgresClient(config string) (PostgresMethod, error) {
-	db, err := gorm.Open("postgres", config)
+	db, err := Open(config)
 	if err != nil {
 		fmt.Println(err)
 		return nil, err
 	}
-	// Automatically create the table for the struct
-	db.AutoMigrate(&Farms{}, &Ponds{}, &FarmPondsMapping{}, &StatMetrics{})
+
+	migrator, err := NewMigrator(db)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	applied, err := migrator.Up()
+	for _, m := range applied {
+		log.Printf("Applied migration %d_%s", m.Version, m.Name)
+	}
+	if err != nil {
+		fmt.Println(err)
+		db.Close()
+		return nil, err
+	}
+
 	return &Client{db: db}, nil
 }
 
-// GetDB is func to return database client
-func (c *Client) GetDB() *gorm.DB {
-	return c.db
+// Open is func to open sql database chosen by the config scheme without applying migration
+func Open(config string) (*gorm.DB, error) {
+	dialect, source := ParseDSN(config)
+	db, err := gorm.Open(dialect, source)
+	if err != nil {
+		return nil, err
+	}
+	if dialect == DialectSQLite {
+		// sqlite only allow one writer, sharing one connection make every write wait its turn
+		// instead of fail with database is locked
+		db.DB().SetMaxOpenConns(1)
+	}
+	return db, nil
+}
+
+// ParseDSN is func to get the dialect and the data source that is passed into the driver from config
+func ParseDSN(config string) (dialect string, source string) {
+	config = strings.TrimSpace(config)
+	switch {
+	case strings.HasPrefix(config, "sqlite://"):
+		return DialectSQLite, sqliteSource(strings.TrimPrefix(config, "sqlite://"))
+	case strings.HasPrefix(config, "sqlite3://"):
+		return DialectSQLite, sqliteSource(strings.TrimPrefix(config, "sqlite3://"))
+	case strings.HasPrefix(config, "file:"):
+		return DialectSQLite, sqliteSource(config)
+	}
+	return DialectPostgres, config
+}
+
+// sqliteSource is func to add busy timeout and enable foreign key on sqlite data source when it is not set
+func sqliteSource(source string) string {
+	params := []string{}
+	if !strings.Contains(source, "_busy_timeout") && !strings.Contains(source, "_timeout") {
+		params = append(params, fmt.Sprintf("_busy_timeout=%d", sqliteBusyTimeoutInMs))
+	}
+	// sqlite does not check foreign key unless it is enabled on every connection
+	if !strings.Contains(source, "_foreign_keys") && !strings.Contains(source, "_fk") {
+		params = append(params, "_foreign_keys=1")
+	}
+	if len(params) == 0 {
+		return source
+	}
+
+	separator := "?"
+	if strings.Contains(source, "?") {
+		separator = "&"
+	}
+	return source + separator + strings.Join(params, "&")
+}
+
+// GetDB is func to return database client that cancel the query when ctx is done
+func (c *Client) GetDB(ctx context.Context) *gorm.DB {
+	return withContext(ctx, c.db)
 }
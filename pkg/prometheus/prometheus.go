@@ -0,0 +1,367 @@
+package prometheus
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ContentType is the content type of prometheus text exposition format
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// Type denotes the type of metric family
+type Type string
+
+// list supported metric type
+const (
+	Counter   Type = "counter"
+	Gauge     Type = "gauge"
+	Histogram Type = "histogram"
+)
+
+// DefBuckets is default buckets for request duration histogram in seconds
+var DefBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// Default is registry used by the service to expose metrics
+var Default = NewRegistry()
+
+// Collector is interface for anything that can produce metric families
+type Collector interface {
+	Collect() []Family
+}
+
+// Family is snapshot of metric family with the same name
+type Family struct {
+	Name    string
+	Help    string
+	Type    Type
+	Metrics []Metric
+}
+
+// Metric is single sample of family, Histogram is only filled for histogram type
+type Metric struct {
+	Labels    []Label
+	Value     float64
+	Histogram *HistogramValue
+}
+
+// Label is pair name and value of metric label
+type Label struct {
+	Name  string
+	Value string
+}
+
+// HistogramValue is snapshot of histogram, Buckets is non cumulative count per upper bound
+type HistogramValue struct {
+	Bounds  []float64
+	Buckets []uint64
+	Count   uint64
+	Sum     float64
+}
+
+// Registry is list collector that will be exposed
+type Registry struct {
+	mu         sync.RWMutex
+	collectors []Collector
+}
+
+// NewRegistry is func to create empty registry
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Register is func to add collector into registry
+func (r *Registry) Register(c Collector) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.collectors = append(r.collectors, c)
+}
+
+// Gather is func to collect all family in registry
+func (r *Registry) Gather() []Family {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var families []Family
+	for _, c := range r.collectors {
+		families = append(families, c.Collect()...)
+	}
+	return families
+}
+
+// Handler is func to serve registry in text exposition format
+func (r *Registry) Handler() http.HandlerFunc {
+	return func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", ContentType)
+		w.WriteHeader(http.StatusOK)
+		Write(w, r.Gather())
+	}
+}
+
+// CounterVec is counter partitioned by label values
+type CounterVec struct {
+	name   string
+	help   string
+	labels []string
+
+	mu     sync.Mutex
+	values map[string]*counterValue
+}
+
+type counterValue struct {
+	labels []string
+	value  float64
+}
+
+// NewCounterVec is func to create and register counter into registry
+func (r *Registry) NewCounterVec(name, help string, labels ...string) *CounterVec {
+	c := &CounterVec{
+		name:   name,
+		help:   help,
+		labels: labels,
+		values: make(map[string]*counterValue),
+	}
+	r.Register(c)
+	return c
+}
+
+// Inc is func to increase counter by 1 for the label values
+func (c *CounterVec) Inc(values ...string) {
+	c.Add(1, values...)
+}
+
+// Add is func to increase counter by v for the label values, negative value is ignored
+func (c *CounterVec) Add(v float64, values ...string) {
+	if v < 0 || len(values) != len(c.labels) {
+		return
+	}
+
+	key := strings.Join(values, "\xff")
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	cv, ok := c.values[key]
+	if !ok {
+		cv = &counterValue{labels: append([]string(nil), values...)}
+		c.values[key] = cv
+	}
+	cv.value += v
+}
+
+// Collect is func to snapshot counter
+func (c *CounterVec) Collect() []Family {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	family := Family{Name: c.name, Help: c.help, Type: Counter}
+	for _, cv := range c.values {
+		family.Metrics = append(family.Metrics, Metric{
+			Labels: toLabels(c.labels, cv.labels),
+			Value:  cv.value,
+		})
+	}
+	return []Family{family}
+}
+
+// HistogramVec is histogram partitioned by label values
+type HistogramVec struct {
+	name    string
+	help    string
+	labels  []string
+	buckets []float64
+
+	mu     sync.Mutex
+	values map[string]*histogramValue
+}
+
+type histogramValue struct {
+	labels  []string
+	buckets []uint64
+	count   uint64
+	sum     float64
+}
+
+// NewHistogramVec is func to create and register histogram into registry
+func (r *Registry) NewHistogramVec(name, help string, buckets []float64, labels ...string) *HistogramVec {
+	bounds := append([]float64(nil), buckets...)
+	sort.Float64s(bounds)
+	h := &HistogramVec{
+		name:    name,
+		help:    help,
+		labels:  labels,
+		buckets: bounds,
+		values:  make(map[string]*histogramValue),
+	}
+	r.Register(h)
+	return h
+}
+
+// Observe is func to add observation into histogram for the label values
+func (h *HistogramVec) Observe(v float64, values ...string) {
+	if len(values) != len(h.labels) {
+		return
+	}
+
+	key := strings.Join(values, "\xff")
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	hv, ok := h.values[key]
+	if !ok {
+		hv = &histogramValue{
+			labels:  append([]string(nil), values...),
+			buckets: make([]uint64, len(h.buckets)),
+		}
+		h.values[key] = hv
+	}
+
+	for i, bound := range h.buckets {
+		if v <= bound {
+			hv.buckets[i]++
+			break
+		}
+	}
+	hv.count++
+	hv.sum += v
+}
+
+// Collect is func to snapshot histogram
+func (h *HistogramVec) Collect() []Family {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	family := Family{Name: h.name, Help: h.help, Type: Histogram}
+	for _, hv := range h.values {
+		family.Metrics = append(family.Metrics, Metric{
+			Labels: toLabels(h.labels, hv.labels),
+			Histogram: &HistogramValue{
+				Bounds:  h.buckets,
+				Buckets: append([]uint64(nil), hv.buckets...),
+				Count:   hv.count,
+				Sum:     hv.sum,
+			},
+		})
+	}
+	return []Family{family}
+}
+
+// NewHistogramValue is func to build histogram snapshot from list observation
+func NewHistogramValue(bounds []float64, observations []float64) *HistogramValue {
+	hv := &HistogramValue{
+		Bounds:  bounds,
+		Buckets: make([]uint64, len(bounds)),
+	}
+	for _, v := range observations {
+		for i, bound := range bounds {
+			if v <= bound {
+				hv.Buckets[i]++
+				break
+			}
+		}
+		hv.Count++
+		hv.Sum += v
+	}
+	return hv
+}
+
+func toLabels(names, values []string) []Label {
+	labels := make([]Label, 0, len(names))
+	for i, name := range names {
+		labels = append(labels, Label{Name: name, Value: values[i]})
+	}
+	return labels
+}
+
+// Write is func to render families in text exposition format,
+// families with the same name are merged and the output is sorted to keep it stable
+func Write(w io.Writer, families []Family) error {
+	merged := make(map[string]*Family)
+	var names []string
+	for _, f := range families {
+		if existing, ok := merged[f.Name]; ok {
+			existing.Metrics = append(existing.Metrics, f.Metrics...)
+			continue
+		}
+		family := f
+		family.Metrics = append([]Metric(nil), f.Metrics...)
+		merged[f.Name] = &family
+		names = append(names, f.Name)
+	}
+	sort.Strings(names)
+
+	bw := bufio.NewWriter(w)
+	for _, name := range names {
+		writeFamily(bw, merged[name])
+	}
+	return bw.Flush()
+}
+
+func writeFamily(w *bufio.Writer, f *Family) {
+	sort.Slice(f.Metrics, func(i, j int) bool {
+		return labelsString(f.Metrics[i].Labels, nil) < labelsString(f.Metrics[j].Labels, nil)
+	})
+
+	fmt.Fprintf(w, "# HELP %s %s\n", f.Name, escapeHelp(f.Help))
+	fmt.Fprintf(w, "# TYPE %s %s\n", f.Name, f.Type)
+	for _, m := range f.Metrics {
+		if f.Type != Histogram || m.Histogram == nil {
+			fmt.Fprintf(w, "%s%s %s\n", f.Name, labelsString(m.Labels, nil), formatFloat(m.Value))
+			continue
+		}
+
+		var cumulative uint64
+		for i, bound := range m.Histogram.Bounds {
+			cumulative += m.Histogram.Buckets[i]
+			le := Label{Name: "le", Value: formatFloat(bound)}
+			fmt.Fprintf(w, "%s_bucket%s %d\n", f.Name, labelsString(m.Labels, &le), cumulative)
+		}
+		inf := Label{Name: "le", Value: "+Inf"}
+		fmt.Fprintf(w, "%s_bucket%s %d\n", f.Name, labelsString(m.Labels, &inf), m.Histogram.Count)
+		fmt.Fprintf(w, "%s_sum%s %s\n", f.Name, labelsString(m.Labels, nil), formatFloat(m.Histogram.Sum))
+		fmt.Fprintf(w, "%s_count%s %d\n", f.Name, labelsString(m.Labels, nil), m.Histogram.Count)
+	}
+}
+
+func labelsString(labels []Label, extra *Label) string {
+	if len(labels) == 0 && extra == nil {
+		return ""
+	}
+
+	parts := make([]string, 0, len(labels)+1)
+	for _, l := range labels {
+		parts = append(parts, l.Name+`="`+escapeLabelValue(l.Value)+`"`)
+	}
+	if extra != nil {
+		parts = append(parts, extra.Name+`="`+escapeLabelValue(extra.Value)+`"`)
+	}
+	return "{" + strings.Join(parts, ",") + "}"
+}
+
+var (
+	helpReplacer  = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+	labelReplacer = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+)
+
+func escapeHelp(s string) string {
+	return helpReplacer.Replace(s)
+}
+
+func escapeLabelValue(s string) string {
+	return labelReplacer.Replace(s)
+}
+
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+	return strconv.FormatFloat(v, 'g', -1, 64)
+}
@@ -5,6 +5,8 @@
 package mock_redis
 
 import (
+	redis "aqua-farm-manager/pkg/redis"
+	context "context"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -34,94 +36,270 @@ func (m *MockRedisMethod) EXPECT() *MockRedisMethodMockRecorder {
 }
 
 // Delete mocks base method.
-func (m *MockRedisMethod) Delete(key string) error {
+func (m *MockRedisMethod) Delete(ctx context.Context, key string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", key)
+	ret := m.ctrl.Call(m, "Delete", ctx, key)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockRedisMethodMockRecorder) Delete(key interface{}) *gomock.Call {
+func (mr *MockRedisMethodMockRecorder) Delete(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRedisMethod)(nil).Delete), ctx, key)
+}
+
+// Eval mocks base method.
+func (m *MockRedisMethod) Eval(ctx context.Context, script string, keys []string, args ...interface{}) (interface{}, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, script, keys}
+	for _, a := range args {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Eval", varargs...)
+	ret0, _ := ret[0].(interface{})
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Eval indicates an expected call of Eval.
+func (mr *MockRedisMethodMockRecorder) Eval(ctx, script, keys interface{}, args ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockRedisMethod)(nil).Delete), key)
+	varargs := append([]interface{}{ctx, script, keys}, args...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Eval", reflect.TypeOf((*MockRedisMethod)(nil).Eval), varargs...)
 }
 
 // Get mocks base method.
-func (m *MockRedisMethod) Get(key string) (string, error) {
+func (m *MockRedisMethod) Get(ctx context.Context, key string) (string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Get", key)
+	ret := m.ctrl.Call(m, "Get", ctx, key)
 	ret0, _ := ret[0].(string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // Get indicates an expected call of Get.
-func (mr *MockRedisMethodMockRecorder) Get(key interface{}) *gomock.Call {
+func (mr *MockRedisMethodMockRecorder) Get(ctx, key interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRedisMethod)(nil).Get), key)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockRedisMethod)(nil).Get), ctx, key)
 }
 
 // HGETALL mocks base method.
-func (m *MockRedisMethod) HGETALL(key string) (map[string]string, error) {
+func (m *MockRedisMethod) HGETALL(ctx context.Context, key string) (map[string]string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "HGETALL", key)
+	ret := m.ctrl.Call(m, "HGETALL", ctx, key)
 	ret0, _ := ret[0].(map[string]string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // HGETALL indicates an expected call of HGETALL.
-func (mr *MockRedisMethodMockRecorder) HGETALL(key interface{}) *gomock.Call {
+func (mr *MockRedisMethodMockRecorder) HGETALL(ctx, key interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HGETALL", reflect.TypeOf((*MockRedisMethod)(nil).HGETALL), key)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HGETALL", reflect.TypeOf((*MockRedisMethod)(nil).HGETALL), ctx, key)
 }
 
 // HINCRBY mocks base method.
-func (m *MockRedisMethod) HINCRBY(key, field string) error {
+func (m *MockRedisMethod) HINCRBY(ctx context.Context, key, field string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "HINCRBY", key, field)
+	ret := m.ctrl.Call(m, "HINCRBY", ctx, key, field)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // HINCRBY indicates an expected call of HINCRBY.
-func (mr *MockRedisMethodMockRecorder) HINCRBY(key, field interface{}) *gomock.Call {
+func (mr *MockRedisMethodMockRecorder) HINCRBY(ctx, key, field interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HINCRBY", reflect.TypeOf((*MockRedisMethod)(nil).HINCRBY), key, field)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HINCRBY", reflect.TypeOf((*MockRedisMethod)(nil).HINCRBY), ctx, key, field)
 }
 
 // HSET mocks base method.
-func (m *MockRedisMethod) HSET(key, field, value string) error {
+func (m *MockRedisMethod) HSET(ctx context.Context, key, field, value string) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "HSET", key, field, value)
+	ret := m.ctrl.Call(m, "HSET", ctx, key, field, value)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // HSET indicates an expected call of HSET.
-func (mr *MockRedisMethodMockRecorder) HSET(key, field, value interface{}) *gomock.Call {
+func (mr *MockRedisMethodMockRecorder) HSET(ctx, key, field, value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HSET", reflect.TypeOf((*MockRedisMethod)(nil).HSET), ctx, key, field, value)
+}
+
+// MGet mocks base method.
+func (m *MockRedisMethod) MGet(ctx context.Context, keys ...string) ([]string, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx}
+	for _, a := range keys {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "MGet", varargs...)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MGet indicates an expected call of MGet.
+func (mr *MockRedisMethodMockRecorder) MGet(ctx interface{}, keys ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx}, keys...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MGet", reflect.TypeOf((*MockRedisMethod)(nil).MGet), varargs...)
+}
+
+// Pipeline mocks base method.
+func (m *MockRedisMethod) Pipeline(ctx context.Context, fn func(redis.Pipeliner) error) ([]interface{}, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Pipeline", ctx, fn)
+	ret0, _ := ret[0].([]interface{})
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Pipeline indicates an expected call of Pipeline.
+func (mr *MockRedisMethodMockRecorder) Pipeline(ctx, fn interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HSET", reflect.TypeOf((*MockRedisMethod)(nil).HSET), key, field, value)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Pipeline", reflect.TypeOf((*MockRedisMethod)(nil).Pipeline), ctx, fn)
 }
 
 // SETNX mocks base method.
-func (m *MockRedisMethod) SETNX(key string) (bool, error) {
+func (m *MockRedisMethod) SETNX(ctx context.Context, key string) (bool, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SETNX", key)
+	ret := m.ctrl.Call(m, "SETNX", ctx, key)
 	ret0, _ := ret[0].(bool)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // SETNX indicates an expected call of SETNX.
-func (mr *MockRedisMethodMockRecorder) SETNX(key interface{}) *gomock.Call {
+func (mr *MockRedisMethodMockRecorder) SETNX(ctx, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SETNX", reflect.TypeOf((*MockRedisMethod)(nil).SETNX), ctx, key)
+}
+
+// Set mocks base method.
+func (m *MockRedisMethod) Set(ctx context.Context, key, field string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Set", ctx, key, field)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockRedisMethodMockRecorder) Set(ctx, key, field interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockRedisMethod)(nil).Set), ctx, key, field)
+}
+
+// SetEX mocks base method.
+func (m *MockRedisMethod) SetEX(ctx context.Context, key, value string, ttlInSec int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetEX", ctx, key, value, ttlInSec)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetEX indicates an expected call of SetEX.
+func (mr *MockRedisMethodMockRecorder) SetEX(ctx, key, value, ttlInSec interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetEX", reflect.TypeOf((*MockRedisMethod)(nil).SetEX), ctx, key, value, ttlInSec)
+}
+
+// Stats mocks base method.
+func (m *MockRedisMethod) Stats() redis.PoolStats {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stats")
+	ret0, _ := ret[0].(redis.PoolStats)
+	return ret0
+}
+
+// Stats indicates an expected call of Stats.
+func (mr *MockRedisMethodMockRecorder) Stats() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stats", reflect.TypeOf((*MockRedisMethod)(nil).Stats))
+}
+
+// MockPipeliner is a mock of Pipeliner interface.
+type MockPipeliner struct {
+	ctrl     *gomock.Controller
+	recorder *MockPipelinerMockRecorder
+}
+
+// MockPipelinerMockRecorder is the mock recorder for MockPipeliner.
+type MockPipelinerMockRecorder struct {
+	mock *MockPipeliner
+}
+
+// NewMockPipeliner creates a new mock instance.
+func NewMockPipeliner(ctrl *gomock.Controller) *MockPipeliner {
+	mock := &MockPipeliner{ctrl: ctrl}
+	mock.recorder = &MockPipelinerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPipeliner) EXPECT() *MockPipelinerMockRecorder {
+	return m.recorder
+}
+
+// Delete mocks base method.
+func (m *MockPipeliner) Delete(key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockPipelinerMockRecorder) Delete(key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockPipeliner)(nil).Delete), key)
+}
+
+// HINCRBY mocks base method.
+func (m *MockPipeliner) HINCRBY(key, field string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HINCRBY", key, field)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HINCRBY indicates an expected call of HINCRBY.
+func (mr *MockPipelinerMockRecorder) HINCRBY(key, field interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HINCRBY", reflect.TypeOf((*MockPipeliner)(nil).HINCRBY), key, field)
+}
+
+// HSET mocks base method.
+func (m *MockPipeliner) HSET(key, field, value string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HSET", key, field, value)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HSET indicates an expected call of HSET.
+func (mr *MockPipelinerMockRecorder) HSET(key, field, value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HSET", reflect.TypeOf((*MockPipeliner)(nil).HSET), key, field, value)
+}
+
+// SETNX mocks base method.
+func (m *MockPipeliner) SETNX(key string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SETNX", key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SETNX indicates an expected call of SETNX.
+func (mr *MockPipelinerMockRecorder) SETNX(key interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SETNX", reflect.TypeOf((*MockRedisMethod)(nil).SETNX), key)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SETNX", reflect.TypeOf((*MockPipeliner)(nil).SETNX), key)
 }
 
 // Set mocks base method.
-func (m *MockRedisMethod) Set(key, field string) error {
+func (m *MockPipeliner) Set(key, field string) error {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "Set", key, field)
 	ret0, _ := ret[0].(error)
@@ -129,7 +307,7 @@ func (m *MockRedisMethod) Set(key, field string) error {
 }
 
 // Set indicates an expected call of Set.
-func (mr *MockRedisMethodMockRecorder) Set(key, field interface{}) *gomock.Call {
+func (mr *MockPipelinerMockRecorder) Set(key, field interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockRedisMethod)(nil).Set), key, field)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockPipeliner)(nil).Set), key, field)
 }
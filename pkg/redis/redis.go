@@ -1,20 +1,47 @@
 package redis
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/gomodule/redigo/redis"
 )
 
+// ErrNil is error when the key does not exist
+var ErrNil = redis.ErrNil
+
 // RedisMethod list is all available method for redis
 type RedisMethod interface {
-	Get(key string) (string, error)
+	Get(ctx context.Context, key string) (string, error)
+	MGet(ctx context.Context, keys ...string) ([]string, error)
+	Set(ctx context.Context, key, field string) error
+	SetEX(ctx context.Context, key, value string, ttlInSec int) error
+	Delete(ctx context.Context, key string) error
+	SETNX(ctx context.Context, key string) (bool, error)
+	HINCRBY(ctx context.Context, key, field string) error
+	HGETALL(ctx context.Context, key string) (map[string]string, error)
+	HSET(ctx context.Context, key, field, value string) error
+	Pipeline(ctx context.Context, fn func(p Pipeliner) error) ([]interface{}, error)
+	Eval(ctx context.Context, script string, keys []string, args ...interface{}) (interface{}, error)
+	Stats() PoolStats
+}
+
+// PoolStats is list statistic of redis connection pool
+type PoolStats struct {
+	ActiveCount  int
+	IdleCount    int
+	WaitCount    int64
+	WaitDuration time.Duration
+}
+
+// Pipeliner is list method that can be queued into a single redis transaction
+type Pipeliner interface {
 	Set(key, field string) error
 	Delete(key string) error
-	SETNX(key string) (bool, error)
+	SETNX(key string) error
 	HINCRBY(key, field string) error
-	HGETALL(key string) (map[string]string, error)
 	HSET(key, field, value string) error
 }
 
@@ -48,51 +75,103 @@ func NewRedisClient(cfg RedisConfig) (RedisMethod, error) {
 	}, err
 }
 
-// Get retrieves a value from the Redis database
-func (c *Client) Get(key string) (string, error) {
-	conn := c.pool.Get()
+// Get retrieves a value from the Redis database, the error wrap ErrNil when the key does not exist
+func (c *Client) Get(ctx context.Context, key string) (string, error) {
+	conn, err := c.pool.GetContext(ctx)
+	if err != nil {
+		return "", fmt.Errorf("error retrieving key %s: %w", key, err)
+	}
 	defer conn.Close()
 
-	value, err := redis.String(conn.Do("GET", key))
+	value, err := redis.String(redis.DoContext(conn, ctx, "GET", key))
 	if err != nil {
-		return "", fmt.Errorf("error retrieving key %s: %v", key, err)
+		return "", fmt.Errorf("error retrieving key %s: %w", key, err)
 	}
 
 	return value, nil
 }
 
+// MGet retrieves value of every key in a single command, the value of key that does not exist is empty
+func (c *Client) MGet(ctx context.Context, keys ...string) ([]string, error) {
+	if len(keys) == 0 {
+		return nil, nil
+	}
+
+	conn, err := c.pool.GetContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving %d keys: %w", len(keys), err)
+	}
+	defer conn.Close()
+
+	args := make([]interface{}, 0, len(keys))
+	for _, key := range keys {
+		args = append(args, key)
+	}
+	values, err := redis.Strings(redis.DoContext(conn, ctx, "MGET", args...))
+	if err != nil {
+		return nil, fmt.Errorf("error retrieving %d keys: %w", len(keys), err)
+	}
+
+	return values, nil
+}
+
 // Set stores a value in the Redis database
-func (c *Client) Set(key, field string) error {
-	conn := c.pool.Get()
+func (c *Client) Set(ctx context.Context, key, field string) error {
+	conn, err := c.pool.GetContext(ctx)
+	if err != nil {
+		return fmt.Errorf("error setting key %s: %w", key, err)
+	}
+	defer conn.Close()
+
+	_, err = redis.DoContext(conn, ctx, "SET", key, field)
+	if err != nil {
+		return fmt.Errorf("error setting key %s: %w", key, err)
+	}
+
+	return nil
+}
+
+// SetEX stores a value in the Redis database that expire after ttl
+func (c *Client) SetEX(ctx context.Context, key, value string, ttlInSec int) error {
+	conn, err := c.pool.GetContext(ctx)
+	if err != nil {
+		return fmt.Errorf("error setting key %s: %w", key, err)
+	}
 	defer conn.Close()
 
-	_, err := conn.Do("SET", key, field)
+	_, err = redis.DoContext(conn, ctx, "SET", key, value, "EX", ttlInSec)
 	if err != nil {
-		return fmt.Errorf("error setting key %s: %v", key, err)
+		return fmt.Errorf("error setting key %s: %w", key, err)
 	}
 
 	return nil
 }
 
 // Delete removes a key-value pair from the Redis database
-func (c *Client) Delete(key string) error {
-	conn := c.pool.Get()
+func (c *Client) Delete(ctx context.Context, key string) error {
+	conn, err := c.pool.GetContext(ctx)
+	if err != nil {
+		return fmt.Errorf("error deleting key %s: %w", key, err)
+	}
 	defer conn.Close()
 
-	_, err := conn.Do("DEL", key)
+	_, err = redis.DoContext(conn, ctx, "DEL", key)
 	if err != nil {
-		return fmt.Errorf("error deleting key %s: %v", key, err)
+		return fmt.Errorf("error deleting key %s: %w", key, err)
 	}
 
 	return nil
 }
 
 // SETNX set a key from the Redis database and return if the key is new or not
-func (c *Client) SETNX(key string) (bool, error) {
-	conn := c.pool.Get()
+func (c *Client) SETNX(ctx context.Context, key string) (bool, error) {
+	conn, err := c.pool.GetContext(ctx)
+	if err != nil {
+		return false, err
+	}
 	defer conn.Close()
 
-	isNew, err := redis.Bool(conn.Do("SETNX", key, 1))
+	isNew, err := redis.Bool(redis.DoContext(conn, ctx, "SETNX", key, 1))
 	if err != nil {
 		return false, err
 	}
@@ -101,11 +180,14 @@ func (c *Client) SETNX(key string) (bool, error) {
 }
 
 // HINCRBY increase a key from the Redis database based on key
-func (c *Client) HINCRBY(key, field string) error {
-	conn := c.pool.Get()
+func (c *Client) HINCRBY(ctx context.Context, key, field string) error {
+	conn, err := c.pool.GetContext(ctx)
+	if err != nil {
+		return err
+	}
 	defer conn.Close()
 
-	_, err := conn.Do("HINCRBY", key, field, 1)
+	_, err = redis.DoContext(conn, ctx, "HINCRBY", key, field, 1)
 	if err != nil {
 		return err
 	}
@@ -114,12 +196,15 @@ func (c *Client) HINCRBY(key, field string) error {
 }
 
 // HGETALL is func to get all metrics from Redis database based on key
-func (c *Client) HGETALL(key string) (map[string]string, error) {
+func (c *Client) HGETALL(ctx context.Context, key string) (map[string]string, error) {
 	var values map[string]string
-	conn := c.pool.Get()
+	conn, err := c.pool.GetContext(ctx)
+	if err != nil {
+		return values, err
+	}
 	defer conn.Close()
 
-	values, err := redis.StringMap(conn.Do("HGETALL", key))
+	values, err = redis.StringMap(redis.DoContext(conn, ctx, "HGETALL", key))
 	if err != nil {
 		return values, err
 	}
@@ -127,13 +212,120 @@ func (c *Client) HGETALL(key string) (map[string]string, error) {
 }
 
 // HSET is func to set metrics to Redis database based on key
-func (c *Client) HSET(key, field, value string) error {
-	conn := c.pool.Get()
+func (c *Client) HSET(ctx context.Context, key, field, value string) error {
+	conn, err := c.pool.GetContext(ctx)
+	if err != nil {
+		return err
+	}
 	defer conn.Close()
 
-	_, err := conn.Do("HSET", key, field, value)
+	_, err = redis.DoContext(conn, ctx, "HSET", key, field, value)
 	if err != nil {
 		return err
 	}
 	return err
 }
+
+// Pipeline is func to queue commands inside MULTI/EXEC and send them in a single round-trip,
+// the transaction is discarded when fn return error
+func (c *Client) Pipeline(ctx context.Context, fn func(p Pipeliner) error) ([]interface{}, error) {
+	conn, err := c.pool.GetContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error starting pipeline: %w", err)
+	}
+	defer conn.Close()
+
+	err = conn.Send("MULTI")
+	if err != nil {
+		return nil, fmt.Errorf("error starting pipeline: %v", err)
+	}
+
+	err = fn(&pipeline{conn: conn})
+	if err != nil {
+		conn.Do("DISCARD")
+		return nil, err
+	}
+
+	values, err := redis.Values(redis.DoContext(conn, ctx, "EXEC"))
+	if err != nil {
+		return nil, fmt.Errorf("error executing pipeline: %w", err)
+	}
+
+	for _, value := range values {
+		if errReply, ok := value.(redis.Error); ok {
+			return values, fmt.Errorf("error executing pipeline: %v", errReply)
+		}
+	}
+
+	return values, nil
+}
+
+// Eval is func to run lua script atomically in a single round-trip
+func (c *Client) Eval(ctx context.Context, script string, keys []string, args ...interface{}) (interface{}, error) {
+	conn, err := c.pool.GetContext(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error evaluating script: %w", err)
+	}
+	defer conn.Close()
+
+	keysAndArgs := make([]interface{}, 0, len(keys)+len(args))
+	for _, key := range keys {
+		keysAndArgs = append(keysAndArgs, key)
+	}
+	keysAndArgs = append(keysAndArgs, args...)
+
+	value, err := redis.NewScript(len(keys), script).DoContext(ctx, conn, keysAndArgs...)
+	if err != nil {
+		return nil, fmt.Errorf("error evaluating script: %v", err)
+	}
+
+	return value, nil
+}
+
+// Stats is func to get statistic of the connection pool
+func (c *Client) Stats() PoolStats {
+	stats := c.pool.Stats()
+	return PoolStats{
+		ActiveCount:  stats.ActiveCount,
+		IdleCount:    stats.IdleCount,
+		WaitCount:    stats.WaitCount,
+		WaitDuration: stats.WaitDuration,
+	}
+}
+
+// pipeline is a Pipeliner that queue command into the connection buffer
+type pipeline struct {
+	conn redis.Conn
+}
+
+// Set queue SET command
+func (p *pipeline) Set(key, field string) error {
+	return p.conn.Send("SET", key, field)
+}
+
+// Delete queue DEL command
+func (p *pipeline) Delete(key string) error {
+	return p.conn.Send("DEL", key)
+}
+
+// SETNX queue SETNX command
+func (p *pipeline) SETNX(key string) error {
+	return p.conn.Send("SETNX", key, 1)
+}
+
+// HINCRBY queue HINCRBY command
+func (p *pipeline) HINCRBY(key, field string) error {
+	return p.conn.Send("HINCRBY", key, field, 1)
+}
+
+// HSET queue HSET command
+func (p *pipeline) HSET(key, field, value string) error {
+	return p.conn.Send("HSET", key, field, value)
+}
+
+// IsReplyError is func to check error is error reply from redis server,
+// other error is connection or pool error that may succeed when it retried
+func IsReplyError(err error) bool {
+	var replyErr redis.Error
+	return errors.As(err, &replyErr)
+}
@@ -1,6 +1,10 @@
 package utilhttp
 
-import "net/http"
+import (
+	"net/http"
+
+	"aqua-farm-manager/pkg/apperror"
+)
 
 // WriteResponse is func to generate response for http handler
 func WriteResponse(w http.ResponseWriter, data []byte, status int) (int, error) {
@@ -11,7 +15,19 @@ func WriteResponse(w http.ResponseWriter, data []byte, status int) (int, error)
 
 // StandardResponse is AquaFarmManager standard JSON HTTP response.
 type StandardResponse struct {
-	Data    interface{} `json:"data,omitempty"`
-	Code    int         `json:"code"`
-	Message string      `json:"message"`
+	Data    interface{}       `json:"data,omitempty"`
+	Code    int               `json:"code"`
+	Message string            `json:"message"`
+	Errors  []apperror.Detail `json:"errors,omitempty"`
+}
+
+// SetError is func to set message and error items of err into the response,
+// error without code is written as internal error so the raw message is not returned
+func (r *StandardResponse) SetError(err error) {
+	e := apperror.From(err)
+	if e == nil {
+		return
+	}
+	r.Message = e.Message
+	r.Errors = e.Details()
 }
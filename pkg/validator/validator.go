@@ -0,0 +1,189 @@
+package validator
+
+import (
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"aqua-farm-manager/pkg/apperror"
+)
+
+// CrossFieldValidator is implemented by request that has rule across fields,
+// it is called after the rule of every field so every violation is returned at once
+type CrossFieldValidator interface {
+	ValidateCrossField() []apperror.Detail
+}
+
+// charsets is list character set that is allowed by charset rule
+var charsets = map[string]func(r rune) bool{
+	// name allow letter, number, space and common punctuation of a name
+	"name": func(r rune) bool {
+		return unicode.IsLetter(r) || unicode.IsNumber(r) || r == ' ' || strings.ContainsRune(".,'&()_-", r)
+	},
+	// text allow every printable character
+	"text": func(r rune) bool {
+		return unicode.IsPrint(r)
+	},
+}
+
+// Validate is func to check every field of struct v against the rule in its `validate` tag
+// and the cross field rule, the violation is returned as validation error with one detail per violation.
+//
+// The rule is separated by comma :
+//   - required : string is not blank and number is not zero
+//   - min=N, max=N : number is within N, string length in character is within N
+//   - charset=name|text : every character of string is in the character set
+//
+// Float field must be finite even without rule. Field is named by its json tag.
+func Validate(v interface{}) error {
+	details := Struct(v)
+	if len(details) > 0 {
+		return apperror.Validation(details...)
+	}
+	return nil
+}
+
+// Struct is func to get every violation of struct v
+func Struct(v interface{}) []apperror.Detail {
+	val := reflect.Indirect(reflect.ValueOf(v))
+	if val.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var details []apperror.Detail
+	typ := val.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if field.PkgPath != "" {
+			continue
+		}
+		details = append(details, validateField(fieldName(field), val.Field(i), field.Tag.Get("validate"))...)
+	}
+
+	if cross, ok := v.(CrossFieldValidator); ok {
+		details = append(details, cross.ValidateCrossField()...)
+	}
+	return details
+}
+
+// fieldName is func to get json name of the field
+func fieldName(field reflect.StructField) string {
+	name := strings.Split(field.Tag.Get("json"), ",")[0]
+	if name == "" || name == "-" {
+		return field.Name
+	}
+	return name
+}
+
+// validateField is func to check a field against the rules, it stop at the first violated rule of the field
+func validateField(name string, value reflect.Value, tag string) []apperror.Detail {
+	if isFloat(value) {
+		if f := value.Float(); math.IsNaN(f) || math.IsInf(f, 0) {
+			return []apperror.Detail{apperror.Field(name, fmt.Sprintf("%s must be a finite number", name))}
+		}
+	}
+	if tag == "" {
+		return nil
+	}
+
+	for _, rule := range strings.Split(tag, ",") {
+		key, param := rule, ""
+		if i := strings.Index(rule, "="); i >= 0 {
+			key, param = rule[:i], rule[i+1:]
+		}
+
+		var message string
+		switch key {
+		case "required":
+			message = checkRequired(name, value)
+		case "min":
+			message = checkRange(name, value, param, true)
+		case "max":
+			message = checkRange(name, value, param, false)
+		case "charset":
+			message = checkCharset(name, value, param)
+		default:
+			panic(fmt.Sprintf("validator: unknown rule %q of field %s", key, name))
+		}
+		if message != "" {
+			return []apperror.Detail{apperror.Field(name, message)}
+		}
+	}
+	return nil
+}
+
+func checkRequired(name string, value reflect.Value) string {
+	if value.Kind() == reflect.String {
+		if strings.TrimSpace(value.String()) == "" {
+			return fmt.Sprintf("%s is required", name)
+		}
+		return ""
+	}
+	if value.IsZero() {
+		return fmt.Sprintf("%s is required", name)
+	}
+	return ""
+}
+
+func checkRange(name string, value reflect.Value, param string, isMin bool) string {
+	limit, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		panic(fmt.Sprintf("validator: invalid limit %q of field %s", param, name))
+	}
+
+	if value.Kind() == reflect.String {
+		length := float64(utf8.RuneCountInString(value.String()))
+		if isMin && length < limit {
+			return fmt.Sprintf("%s must be at least %s characters", name, param)
+		}
+		if !isMin && length > limit {
+			return fmt.Sprintf("%s must be at most %s characters", name, param)
+		}
+		return ""
+	}
+
+	var number float64
+	switch {
+	case isFloat(value):
+		number = value.Float()
+	case value.CanInt():
+		number = float64(value.Int())
+	case value.CanUint():
+		number = float64(value.Uint())
+	default:
+		panic(fmt.Sprintf("validator: range rule is not supported by field %s", name))
+	}
+	if isMin && number < limit {
+		return fmt.Sprintf("%s must be at least %s", name, param)
+	}
+	if !isMin && number > limit {
+		return fmt.Sprintf("%s must be at most %s", name, param)
+	}
+	return ""
+}
+
+func checkCharset(name string, value reflect.Value, param string) string {
+	allowed, ok := charsets[param]
+	if !ok || value.Kind() != reflect.String {
+		panic(fmt.Sprintf("validator: invalid charset %q of field %s", param, name))
+	}
+
+	s := value.String()
+	if !utf8.ValidString(s) {
+		return fmt.Sprintf("%s contains invalid character", name)
+	}
+	for _, r := range s {
+		if !allowed(r) {
+			return fmt.Sprintf("%s contains invalid character %q", name, r)
+		}
+	}
+	return ""
+}
+
+func isFloat(value reflect.Value) bool {
+	return value.Kind() == reflect.Float32 || value.Kind() == reflect.Float64
+}
@@ -0,0 +1,261 @@
+package validator
+
+import (
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+
+	"aqua-farm-manager/pkg/apperror"
+)
+
+type testPond struct {
+	Name     string  `json:"name,omitempty" validate:"required,max=5,charset=name"`
+	Note     string  `json:"note" validate:"min=2,charset=text"`
+	Depth    float64 `json:"depth" validate:"min=0.5,max=2.5"`
+	Count    int     `json:"count" validate:"min=-1,max=3"`
+	Capacity uint    `validate:"max=10"`
+	Ratio    float64 `json:"ratio"`
+	Ignored  string  `json:"-" validate:"required"`
+	hidden   string  `validate:"required"`
+}
+
+// testRange is request with cross field rule, the min depth must not be deeper than the max depth
+type testRange struct {
+	MinDepth float64 `json:"min_depth" validate:"min=0"`
+	MaxDepth float64 `json:"max_depth" validate:"min=0"`
+}
+
+func (r testRange) ValidateCrossField() []apperror.Detail {
+	if r.MinDepth > r.MaxDepth {
+		return []apperror.Detail{apperror.Field("min_depth", "min_depth must not be greater than max_depth")}
+	}
+	return nil
+}
+
+func validPond() testPond {
+	return testPond{Name: "Kolam", Note: "ok", Depth: 1, Count: 0, Capacity: 10, Ignored: "x", hidden: "x"}
+}
+
+func TestStruct(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(p *testPond)
+		want   []apperror.Detail
+	}{
+		{
+			name:   "valid request",
+			modify: func(p *testPond) {},
+		},
+		{
+			name: "required of blank string and first violated rule only",
+			modify: func(p *testPond) {
+				p.Name = "   "
+			},
+			want: []apperror.Detail{apperror.Field("name", "name is required")},
+		},
+		{
+			name: "max of string count character instead of byte",
+			modify: func(p *testPond) {
+				p.Name = "Kolåm"
+			},
+		},
+		{
+			name: "max and min of string length",
+			modify: func(p *testPond) {
+				p.Name = "Kolam A"
+				p.Note = "é"
+			},
+			want: []apperror.Detail{
+				apperror.Field("name", "name must be at most 5 characters"),
+				apperror.Field("note", "note must be at least 2 characters"),
+			},
+		},
+		{
+			name: "min and max of float with decimal limit",
+			modify: func(p *testPond) {
+				p.Depth = 0.4
+				p.Count = 4
+			},
+			want: []apperror.Detail{
+				apperror.Field("depth", "depth must be at least 0.5"),
+				apperror.Field("count", "count must be at most 3"),
+			},
+		},
+		{
+			name: "min and max of number include the limit",
+			modify: func(p *testPond) {
+				p.Depth = 2.5
+				p.Count = -1
+			},
+		},
+		{
+			name: "max of uint field without json name",
+			modify: func(p *testPond) {
+				p.Capacity = 11
+			},
+			want: []apperror.Detail{apperror.Field("Capacity", "Capacity must be at most 10")},
+		},
+		{
+			name: "float without rule must be finite",
+			modify: func(p *testPond) {
+				p.Ratio = math.Inf(1)
+				p.Depth = math.NaN()
+			},
+			want: []apperror.Detail{
+				apperror.Field("depth", "depth must be a finite number"),
+				apperror.Field("ratio", "ratio must be a finite number"),
+			},
+		},
+		{
+			name: "charset name reject symbol",
+			modify: func(p *testPond) {
+				p.Name = "<a>"
+			},
+			want: []apperror.Detail{apperror.Field("name", `name contains invalid character '<'`)},
+		},
+		{
+			name: "charset text reject control character",
+			modify: func(p *testPond) {
+				p.Note = "a\x00b"
+			},
+			want: []apperror.Detail{apperror.Field("note", `note contains invalid character '\x00'`)},
+		},
+		{
+			name: "charset reject invalid utf8",
+			modify: func(p *testPond) {
+				p.Note = "a\xffb"
+			},
+			want: []apperror.Detail{apperror.Field("note", "note contains invalid character")},
+		},
+		{
+			name: "json name - use go name and unexported field is skipped",
+			modify: func(p *testPond) {
+				p.Ignored = ""
+				p.hidden = ""
+			},
+			want: []apperror.Detail{apperror.Field("Ignored", "Ignored is required")},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := validPond()
+			tt.modify(&p)
+			if got := Struct(p); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Struct() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStruct_NotStruct(t *testing.T) {
+	if got := Struct("farm"); got != nil {
+		t.Errorf("Struct() of string = %v, want nil", got)
+	}
+	p := validPond()
+	if got := Struct(&p); got != nil {
+		t.Errorf("Struct() of pointer to valid struct = %v, want nil", got)
+	}
+}
+
+func TestValidate_CrossField(t *testing.T) {
+	tests := []struct {
+		name    string
+		request testRange
+		want    []apperror.Detail
+	}{
+		{
+			name:    "valid range",
+			request: testRange{MinDepth: 1, MaxDepth: 2},
+		},
+		{
+			name:    "cross field rule",
+			request: testRange{MinDepth: 3, MaxDepth: 2},
+			want:    []apperror.Detail{apperror.Field("min_depth", "min_depth must not be greater than max_depth")},
+		},
+		{
+			name:    "cross field rule after the field rule",
+			request: testRange{MinDepth: 3, MaxDepth: -1},
+			want: []apperror.Detail{
+				apperror.Field("max_depth", "max_depth must be at least 0"),
+				apperror.Field("min_depth", "min_depth must not be greater than max_depth"),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.request)
+			if tt.want == nil {
+				if err != nil {
+					t.Errorf("Validate() error = %v, want nil", err)
+				}
+				return
+			}
+
+			var appErr *apperror.Error
+			if !errors.As(err, &appErr) {
+				t.Fatalf("Validate() error = %v, want apperror", err)
+			}
+			if appErr.Code != apperror.CodeValidationFailed || appErr.Message != apperror.MessageInvalidParameter {
+				t.Errorf("Validate() error = %s %s, want %s %s", appErr.Code, appErr.Message, apperror.CodeValidationFailed, apperror.MessageInvalidParameter)
+			}
+			if !reflect.DeepEqual(appErr.Fields, tt.want) {
+				t.Errorf("Validate() fields = %v, want %v", appErr.Fields, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidate_InvalidTag(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+		want  string
+	}{
+		{
+			name: "unknown rule",
+			value: struct {
+				Name string `validate:"required,email"`
+			}{Name: "a"},
+			want: `validator: unknown rule "email" of field Name`,
+		},
+		{
+			name: "invalid limit",
+			value: struct {
+				Name string `validate:"max=ten"`
+			}{},
+			want: `validator: invalid limit "ten" of field Name`,
+		},
+		{
+			name: "unknown charset",
+			value: struct {
+				Name string `validate:"charset=email"`
+			}{},
+			want: `validator: invalid charset "email" of field Name`,
+		},
+		{
+			name: "charset of number",
+			value: struct {
+				Count int `validate:"charset=name"`
+			}{},
+			want: `validator: invalid charset "name" of field Count`,
+		},
+		{
+			name: "range of unsupported type",
+			value: struct {
+				IDs []uint `validate:"max=2"`
+			}{},
+			want: "validator: range rule is not supported by field IDs",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if got := recover(); got != tt.want {
+					t.Errorf("Validate() panic = %v, want %v", got, tt.want)
+				}
+			}()
+			Validate(tt.value)
+		})
+	}
+}
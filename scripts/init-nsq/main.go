@@ -8,6 +8,8 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+
+	"aqua-farm-manager/pkg/nsq"
 )
 
 func main() {
@@ -21,6 +23,7 @@ func main() {
 		nsqHost = "http://localhost:4151"
 	}
 
+	var invalid int
 	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			log.Println("ERROR: unable to walk dir:", err)
@@ -30,6 +33,14 @@ func main() {
 			return nil
 		}
 
+		// verify schema and its examples before creating the topic
+		_, err = nsq.CompileSchema(path)
+		if err != nil {
+			log.Printf("ERROR: %v", err)
+			invalid++
+			return nil
+		}
+
 		topicName := strings.TrimSuffix(info.Name(), ".json")
 		req, _ := http.NewRequest(http.MethodPost, nsqHost+"/topic/create?topic="+topicName, nil)
 		req.Header.Set("content-type", "application/json")
@@ -53,4 +64,9 @@ func main() {
 		fmt.Println("ERROR: while walking directory:", err)
 	}
 
+	if invalid > 0 {
+		log.Printf("ERROR: %d invalid nsq schema found", invalid)
+		os.Exit(1)
+	}
+
 }